@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+)
+
+type Controller struct {
+	auditEventStore store.AuditEventStore
+}
+
+func NewController(auditEventStore store.AuditEventStore) *Controller {
+	return &Controller{
+		auditEventStore: auditEventStore,
+	}
+}
+
+// checkAdmin ensures that only the system administrators can access the audit log.
+// Scoped tokens are rejected, as the audit log isn't part of any space or repository.
+func checkAdmin(session *auth.Session) error {
+	if session == nil || !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok && tokenMetadata.Scope != nil {
+		return usererror.ErrForbidden
+	}
+	return nil
+}
+
+func sanitizeFilter(filter *types.AuditEventFilter) error {
+	for _, resourceType := range filter.ResourceTypes {
+		if err := audit.ResourceType(resourceType).Validate(); err != nil {
+			return usererror.BadRequestf("Invalid resource type %q.", resourceType)
+		}
+	}
+
+	for _, action := range filter.Actions {
+		if err := audit.Action(action).Validate(); err != nil {
+			return usererror.BadRequestf("Invalid action %q.", action)
+		}
+	}
+
+	if filter.CreatedGt > 0 && filter.CreatedLt > 0 && filter.CreatedGt >= filter.CreatedLt {
+		return usererror.BadRequest("The start of the time range must be before its end.")
+	}
+
+	return nil
+}
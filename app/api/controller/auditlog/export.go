@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+const exportBatchSize = 100
+
+// Export writes all audit events matching the filter to the writer in the JSON lines format.
+// The pagination of the filter is ignored.
+func (c *Controller) Export(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.AuditEventFilter,
+	w io.Writer,
+) error {
+	if err := checkAdmin(session); err != nil {
+		return err
+	}
+
+	if err := sanitizeFilter(filter); err != nil {
+		return err
+	}
+
+	batchFilter := *filter
+	batchFilter.Size = exportBatchSize
+
+	// The events are listed the most recent first, so to get a stable export
+	// the events created after the export has started are excluded.
+	if last, err := c.latestEventTimestamp(ctx, filter); err != nil {
+		return err
+	} else if last > 0 && (batchFilter.CreatedLt == 0 || batchFilter.CreatedLt > last+1) {
+		batchFilter.CreatedLt = last + 1
+	}
+
+	enc := json.NewEncoder(w)
+
+	for page := 1; ; page++ {
+		batchFilter.Page = page
+
+		events, err := c.auditEventStore.List(ctx, &batchFilter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		for _, event := range events {
+			if err := enc.Encode(event); err != nil {
+				return fmt.Errorf("failed to write audit event: %w", err)
+			}
+		}
+
+		if len(events) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+func (c *Controller) latestEventTimestamp(ctx context.Context, filter *types.AuditEventFilter) (int64, error) {
+	latestFilter := *filter
+	latestFilter.Page = 1
+	latestFilter.Size = 1
+
+	events, err := c.auditEventStore.List(ctx, &latestFilter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find the latest audit event: %w", err)
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	return events[0].Timestamp, nil
+}
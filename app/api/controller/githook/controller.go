@@ -25,6 +25,7 @@ import (
 	gitevents "github.com/harness/gitness/app/events/git"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -44,24 +45,25 @@ import (
 )
 
 type Controller struct {
-	authorizer          authz.Authorizer
-	principalStore      store.PrincipalStore
-	repoStore           store.RepoStore
-	repoFinder          refcache.RepoFinder
-	gitReporter         *gitevents.Reporter
-	repoReporter        *repoevents.Reporter
-	pullreqStore        store.PullReqStore
-	urlProvider         url.Provider
-	protectionManager   *protection.Manager
-	limiter             limiter.ResourceLimiter
-	settings            *settings.Service
-	preReceiveExtender  PreReceiveExtender
-	updateExtender      UpdateExtender
-	postReceiveExtender PostReceiveExtender
-	sseStreamer         sse.Streamer
-	lfsStore            store.LFSObjectStore
-	auditService        audit.Service
-	userGroupService    usergroup.Service
+	authorizer             authz.Authorizer
+	principalStore         store.PrincipalStore
+	repoStore              store.RepoStore
+	repoFinder             refcache.RepoFinder
+	gitReporter            *gitevents.Reporter
+	repoReporter           *repoevents.Reporter
+	pullreqStore           store.PullReqStore
+	urlProvider            url.Provider
+	protectionManager      *protection.Manager
+	limiter                limiter.ResourceLimiter
+	settings               *settings.Service
+	preReceiveExtender     PreReceiveExtender
+	updateExtender         UpdateExtender
+	postReceiveExtender    PostReceiveExtender
+	sseStreamer            sse.Streamer
+	lfsStore               store.LFSObjectStore
+	auditService           audit.Service
+	userGroupService       usergroup.Service
+	signatureVerifyService publickey.SignatureVerifyService
 }
 
 func NewController(
@@ -83,26 +85,28 @@ func NewController(
 	lfsStore store.LFSObjectStore,
 	auditService audit.Service,
 	userGroupService usergroup.Service,
+	signatureVerifyService publickey.SignatureVerifyService,
 ) *Controller {
 	return &Controller{
-		authorizer:          authorizer,
-		principalStore:      principalStore,
-		repoStore:           repoStore,
-		repoFinder:          repoFinder,
-		gitReporter:         gitReporter,
-		repoReporter:        repoReporter,
-		pullreqStore:        pullreqStore,
-		urlProvider:         urlProvider,
-		protectionManager:   protectionManager,
-		limiter:             limiter,
-		settings:            settings,
-		preReceiveExtender:  preReceiveExtender,
-		updateExtender:      updateExtender,
-		postReceiveExtender: postReceiveExtender,
-		sseStreamer:         sseStreamer,
-		lfsStore:            lfsStore,
-		auditService:        auditService,
-		userGroupService:    userGroupService,
+		authorizer:             authorizer,
+		principalStore:         principalStore,
+		repoStore:              repoStore,
+		repoFinder:             repoFinder,
+		gitReporter:            gitReporter,
+		repoReporter:           repoReporter,
+		pullreqStore:           pullreqStore,
+		urlProvider:            urlProvider,
+		protectionManager:      protectionManager,
+		limiter:                limiter,
+		settings:               settings,
+		preReceiveExtender:     preReceiveExtender,
+		updateExtender:         updateExtender,
+		postReceiveExtender:    postReceiveExtender,
+		sseStreamer:            sseStreamer,
+		lfsStore:               lfsStore,
+		auditService:           auditService,
+		userGroupService:       userGroupService,
+		signatureVerifyService: signatureVerifyService,
 	}
 }
 
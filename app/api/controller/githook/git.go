@@ -31,6 +31,8 @@ type RestrictedGIT interface {
 	ScanSecrets(ctx context.Context, param *git.ScanSecretsParams) (*git.ScanSecretsOutput, error)
 	GetBranch(ctx context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error)
 	Diff(ctx context.Context, in *git.DiffParams, files ...api.FileDiffRequest) (<-chan *git.FileDiff, <-chan error)
+	DiffFileNames(ctx context.Context, in *git.DiffParams) (git.DiffFileNamesOutput, error)
+	ListCommits(ctx context.Context, params *git.ListCommitsParams) (*git.ListCommitsOutput, error)
 	GetBlob(ctx context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error)
 	ProcessPreReceiveObjects(
 		ctx context.Context,
@@ -23,7 +23,9 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -89,7 +91,7 @@ func (c *Controller) PreReceive(
 	}
 
 	protectionRules, err := c.protectionManager.ListRepoRules(
-		ctx, repo.ID, protection.TypeBranch, protection.TypeTag, protection.TypePush,
+		ctx, repo.ID, protection.TypeBranch, protection.TypeTag, protection.TypePush, protection.TypeFilePath,
 	)
 	if err != nil {
 		return hook.Output{}, fmt.Errorf(
@@ -152,6 +154,11 @@ func (c *Controller) PreReceive(
 		ruleViolations = append(ruleViolations, violations...)
 
 		processRuleViolations(&output, ruleViolations)
+
+		// internal calls report bypassed rules at the API call site, only git pushes are reported here.
+		if output.Error == nil && principal != nil && protection.IsBypassed(ruleViolations) {
+			c.reportRuleBypassed(ctx, repo, principal, in.RefUpdates)
+		}
 	}
 
 	return output, nil
@@ -204,6 +211,28 @@ func (c *Controller) processPushProtection(
 		return nil, fmt.Errorf("failed to process pre-receive objects: %w", err)
 	}
 
+	if out.FilePathProtection {
+		violationsInput.DefaultBranch = repo.DefaultBranch
+		violationsInput.ChangedFiles, err = findChangedFiles(ctx, rgit, repo, in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find changed files: %w", err)
+		}
+	}
+
+	if out.RequireSignedCommits {
+		err = c.verifyCommitSignatures(ctx, rgit, repo, violationsInput, in, output)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+		}
+	}
+
+	if out.RequireLinearHistory {
+		err = c.findMergeCommits(ctx, rgit, repo, violationsInput, in, output)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find merge commits: %w", err)
+		}
+	}
+
 	var violations []types.RuleViolations
 	if violationsInput.HasViolations() {
 		pushViolations, err := pushProtection.Violations(ctx, violationsInput)
@@ -340,6 +369,46 @@ func processRuleViolations(
 	}
 }
 
+// reportRuleBypassed reports a rule bypassed event for each of the branches and tags of the push.
+func (c *Controller) reportRuleBypassed(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	principal *types.Principal,
+	refUpdates []hook.ReferenceUpdate,
+) {
+	for _, refUpdate := range refUpdates {
+		var resourceType, resourceName string
+		switch {
+		case strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch):
+			resourceType = audit.BypassedResourceTypeBranch
+			resourceName = refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+		case strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag):
+			resourceType = audit.BypassedResourceTypeTag
+			resourceName = refUpdate.Ref[len(gitReferenceNamePrefixTag):]
+		default:
+			continue
+		}
+
+		action := audit.BypassActionPushed
+		switch {
+		case refUpdate.Old.IsNil():
+			action = audit.BypassActionCreated
+		case refUpdate.New.IsNil():
+			action = audit.BypassActionDeleted
+		}
+
+		c.repoReporter.RuleBypassed(ctx, &repoevents.RuleBypassedPayload{
+			Base: repoevents.Base{
+				RepoID:      repo.ID,
+				PrincipalID: principal.ID,
+			},
+			Action:       action,
+			ResourceType: resourceType,
+			ResourceName: resourceName,
+		})
+	}
+}
+
 type changes struct {
 	created []string
 	deleted []string
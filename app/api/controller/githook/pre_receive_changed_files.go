@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+// findChangedFiles returns the paths of the files modified by the push, grouped by branch name.
+// Updated branches are compared with their previous commit, new branches with the default branch.
+func findChangedFiles(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.RepositoryCore,
+	in types.GithookPreReceiveInput,
+) (map[string][]string, error) {
+	readParams := git.ReadParams{
+		RepoUID:             repo.GitUID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	}
+
+	changedFiles := make(map[string][]string)
+
+	var defaultBranchExists *bool
+
+	for _, refUpdate := range in.RefUpdates {
+		if !isBranch(refUpdate.Ref) || refUpdate.New.IsNil() {
+			continue
+		}
+
+		branchName := refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+
+		diffParams := &git.DiffParams{
+			ReadParams: readParams,
+			BaseRef:    refUpdate.Old.String(),
+			HeadRef:    refUpdate.New.String(),
+		}
+
+		if refUpdate.Old.IsNil() {
+			if defaultBranchExists == nil {
+				exists, err := branchExists(ctx, rgit, readParams, repo.DefaultBranch)
+				if err != nil {
+					return nil, err
+				}
+				defaultBranchExists = &exists
+			}
+
+			if *defaultBranchExists && branchName != repo.DefaultBranch {
+				diffParams.BaseRef = gitReferenceNamePrefixBranch + repo.DefaultBranch
+				diffParams.MergeBase = true
+			} else {
+				diffParams.BaseRef = sha.EmptyTree.String()
+			}
+		}
+
+		out, err := rgit.DiffFileNames(ctx, diffParams)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find changed files of branch %q: %w", branchName, err)
+		}
+
+		if len(out.Files) > 0 {
+			changedFiles[branchName] = out.Files
+		}
+	}
+
+	return changedFiles, nil
+}
+
+func branchExists(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	readParams git.ReadParams,
+	branchName string,
+) (bool, error) {
+	if branchName == "" {
+		return false, nil
+	}
+
+	_, err := rgit.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: branchName,
+	})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get branch %q: %w", branchName, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+const maxMergeCommits = 10
+
+// findMergeCommits finds merge commits (commits with more than one parent) among
+// the commits pushed to branches and reports them.
+func (c *Controller) findMergeCommits(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.RepositoryCore,
+	violationsInput *protection.PushViolationsInput,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+) error {
+	readParams := git.ReadParams{
+		RepoUID:             repo.GitUID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	}
+
+	mergeCommits := make(map[string][]sha.SHA)
+	var found []sha.SHA
+	seen := make(map[sha.SHA]struct{})
+
+	for _, refUpdate := range in.RefUpdates {
+		if !isBranch(refUpdate.Ref) || refUpdate.New.IsNil() {
+			continue
+		}
+
+		branchName := refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+
+		baseSHA, baseAvailable, err := GetBaseSHAForScanningChanges(
+			ctx,
+			rgit,
+			repo,
+			in.Environment,
+			in.RefUpdates,
+			refUpdate,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to get base sha of branch %q: %w", branchName, err)
+		}
+
+		params := &git.ListCommitsParams{
+			ReadParams: readParams,
+			GitREF:     refUpdate.New.String(),
+		}
+		if baseAvailable {
+			params.After = baseSHA.String()
+		}
+
+		out, err := rgit.ListCommits(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to list new commits of branch %q: %w", branchName, err)
+		}
+
+		for _, commit := range out.Commits {
+			if len(commit.ParentSHAs) <= 1 {
+				continue
+			}
+
+			mergeCommits[branchName] = append(mergeCommits[branchName], commit.SHA)
+
+			if _, ok := seen[commit.SHA]; ok {
+				continue
+			}
+			seen[commit.SHA] = struct{}{}
+
+			found = append(found, commit.SHA)
+		}
+	}
+
+	if len(found) > 0 {
+		total := int64(len(found))
+		if len(found) > maxMergeCommits {
+			found = found[:maxMergeCommits]
+		}
+
+		printMergeCommits(output, found, total)
+	}
+
+	violationsInput.DefaultBranch = repo.DefaultBranch
+	violationsInput.MergeCommits = mergeCommits
+
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxUnverifiedCommits = 10
+
+// unverifiedCommitInfo holds a pushed commit that doesn't have a verified signature.
+type unverifiedCommitInfo struct {
+	SHA    sha.SHA
+	Result enum.GitSignatureResult
+}
+
+// verifyCommitSignatures verifies signatures of all commits pushed to branches
+// and reports the commits that aren't signed or whose signature couldn't be verified.
+func (c *Controller) verifyCommitSignatures(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.RepositoryCore,
+	violationsInput *protection.PushViolationsInput,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+) error {
+	readParams := git.ReadParams{
+		RepoUID:             repo.GitUID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	}
+
+	session := c.signatureVerifyService.NewVerifySession(repo.ID)
+
+	unverifiedCommits := make(map[string][]sha.SHA)
+	var infos []unverifiedCommitInfo
+	seen := make(map[sha.SHA]struct{})
+
+	for _, refUpdate := range in.RefUpdates {
+		if !isBranch(refUpdate.Ref) || refUpdate.New.IsNil() {
+			continue
+		}
+
+		branchName := refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+
+		baseSHA, baseAvailable, err := GetBaseSHAForScanningChanges(
+			ctx,
+			rgit,
+			repo,
+			in.Environment,
+			in.RefUpdates,
+			refUpdate,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to get base sha of branch %q: %w", branchName, err)
+		}
+
+		params := &git.ListCommitsParams{
+			ReadParams: readParams,
+			GitREF:     refUpdate.New.String(),
+		}
+		if baseAvailable {
+			params.After = baseSHA.String()
+		}
+
+		out, err := rgit.ListCommits(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to list new commits of branch %q: %w", branchName, err)
+		}
+
+		commits := make([]*types.Commit, len(out.Commits))
+		for i := range out.Commits {
+			commits[i] = controller.MapCommit(&out.Commits[i])
+		}
+
+		if err := session.VerifyCommits(ctx, commits); err != nil {
+			return fmt.Errorf("failed to verify commit signatures of branch %q: %w", branchName, err)
+		}
+
+		for _, commit := range commits {
+			result := enum.GitSignatureResult("")
+			if commit.Signature != nil {
+				result = commit.Signature.Result
+			}
+
+			if result == enum.GitSignatureGood {
+				continue
+			}
+
+			unverifiedCommits[branchName] = append(unverifiedCommits[branchName], commit.SHA)
+
+			if _, ok := seen[commit.SHA]; ok {
+				continue
+			}
+			seen[commit.SHA] = struct{}{}
+
+			infos = append(infos, unverifiedCommitInfo{SHA: commit.SHA, Result: result})
+		}
+	}
+
+	session.StoreSignatures(ctx)
+
+	if len(infos) > 0 {
+		total := int64(len(infos))
+		if len(infos) > maxUnverifiedCommits {
+			infos = infos[:maxUnverifiedCommits]
+		}
+
+		printUnverifiedCommits(output, infos, total)
+	}
+
+	violationsInput.DefaultBranch = repo.DefaultBranch
+	violationsInput.UnverifiedCommits = unverifiedCommits
+
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
 
 	"github.com/fatih/color"
 )
@@ -208,3 +209,70 @@ func singularOrPlural(noun string, plural bool) string {
 	}
 	return noun
 }
+
+func printUnverifiedCommits(
+	output *hook.Output,
+	infos []unverifiedCommitInfo,
+	total int64,
+) {
+	output.Messages = append(
+		output.Messages,
+		colorScanHeader.Sprintf(
+			"Push contains commits without a verified signature:",
+		),
+		"", // add empty line for making it visually more consumable
+	)
+
+	for _, info := range infos {
+		result := "unsigned"
+		if info.Result != "" {
+			result = string(info.Result)
+		}
+
+		output.Messages = append(
+			output.Messages,
+			fmt.Sprintf("  %s    Signature: %s", info.SHA, result),
+			"", // add empty line for making it visually more consumable
+		)
+	}
+
+	output.Messages = append(
+		output.Messages,
+		colorScanSummary.Sprintf(
+			"%d %s found without a verified signature",
+			total, singularOrPlural("commit", total > 1),
+		),
+		"", "", // add two empty lines for making it visually more consumable
+	)
+}
+
+func printMergeCommits(
+	output *hook.Output,
+	commitSHAs []sha.SHA,
+	total int64,
+) {
+	output.Messages = append(
+		output.Messages,
+		colorScanHeader.Sprintf(
+			"Push contains merge commits:",
+		),
+		"", // add empty line for making it visually more consumable
+	)
+
+	for _, commitSHA := range commitSHAs {
+		output.Messages = append(
+			output.Messages,
+			fmt.Sprintf("  %s", commitSHA),
+			"", // add empty line for making it visually more consumable
+		)
+	}
+
+	output.Messages = append(
+		output.Messages,
+		colorScanSummary.Sprintf(
+			"%d merge %s found",
+			total, singularOrPlural("commit", total > 1),
+		),
+		"", "", // add two empty lines for making it visually more consumable
+	)
+}
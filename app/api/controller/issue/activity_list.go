@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ActivityList returns the timeline of an issue: its comments and system activities.
+func (c *Controller) ActivityList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	filter *types.IssueActivityFilter,
+) ([]*types.IssueActivity, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	list, err := c.activityStore.List(ctx, issue.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue activities: %w", err)
+	}
+
+	for _, act := range list {
+		if act.Deleted != nil {
+			act.Text = ""
+		}
+	}
+
+	return list, nil
+}
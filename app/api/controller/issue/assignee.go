@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AssigneeAddInput struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+// AssigneeAdd assigns a principal to an issue.
+func (c *Controller) AssigneeAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *AssigneeAddInput,
+) (*types.Issue, error) {
+	if in.AssigneeID == 0 {
+		return nil, usererror.BadRequest("Must specify assignee ID.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	assignee, err := c.principalStore.Find(ctx, in.AssigneeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find assignee principal: %w", err)
+	}
+
+	// To check the assignee's access to the repo we create a dummy session object.
+	if err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{
+		Principal: *assignee,
+	}, repo, enum.PermissionRepoView); err != nil {
+		return nil, usererror.BadRequest("The assignee doesn't have access to the repository.")
+	}
+
+	if err = c.assigneeStore.Create(ctx, issue.ID, assignee.ID, session.Principal.ID); err != nil {
+		return nil, fmt.Errorf("failed to add issue assignee: %w", err)
+	}
+
+	c.writeActivity(ctx, issue, session.Principal.ID, &types.IssueActivityPayloadAssigneeAdd{
+		AssigneeID: assignee.ID,
+	})
+
+	if err = c.backfill(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
+
+// AssigneeDelete removes a principal from the assignees of an issue.
+func (c *Controller) AssigneeDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	assigneeID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	if err = c.assigneeStore.Delete(ctx, issue.ID, assigneeID); err != nil {
+		return fmt.Errorf("failed to delete issue assignee: %w", err)
+	}
+
+	c.writeActivity(ctx, issue, session.Principal.ID, &types.IssueActivityPayloadAssigneeDelete{
+		AssigneeID: assigneeID,
+	})
+
+	return nil
+}
+
+// writeActivity writes a system activity entry to the timeline of the issue.
+// Failures are logged, as the activity is not essential for the operation that produced it.
+func (c *Controller) writeActivity(
+	ctx context.Context,
+	issue *types.Issue,
+	principalID int64,
+	payload types.IssueActivityPayload,
+) {
+	issue, err := c.issueStore.UpdateActivitySeq(ctx, issue)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to update issue activity sequence")
+		return
+	}
+
+	if _, err = c.activityStore.CreateWithPayload(ctx, issue, principalID, payload); err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to write issue '%s' activity", payload.ActivityType())
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CommentInput struct {
+	Text string `json:"text"`
+}
+
+func (in *CommentInput) Sanitize() error {
+	in.Text = strings.TrimSpace(in.Text)
+	return validateComment(in.Text)
+}
+
+// CommentCreate adds a comment to an issue.
+func (c *Controller) CommentCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *CommentInput,
+) (*types.IssueActivity, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	var act *types.IssueActivity
+
+	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+		if err != nil {
+			return fmt.Errorf("failed to find issue by number: %w", err)
+		}
+
+		issue.ActivitySeq++
+		issue.CommentCount++
+
+		if err = c.issueStore.Update(ctx, issue); err != nil {
+			return fmt.Errorf("failed to update issue: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		act = &types.IssueActivity{
+			CreatedBy: session.Principal.ID,
+			Created:   now,
+			Updated:   now,
+			Edited:    now,
+			RepoID:    repo.ID,
+			IssueID:   issue.ID,
+			Order:     issue.ActivitySeq,
+			Type:      enum.IssueActivityTypeComment,
+			Kind:      enum.PullReqActivityKindComment,
+			Text:      in.Text,
+		}
+
+		return c.activityStore.Create(ctx, act)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	act.Author = *session.Principal.ToPrincipalInfo()
+
+	return act, nil
+}
+
+// CommentUpdate updates the text of a comment.
+func (c *Controller) CommentUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	commentID int64,
+	in *CommentInput,
+) (*types.IssueActivity, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	act, err := c.getCommentCheckEditAccess(ctx, session, repo, issueNum, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if act.Deleted != nil {
+		return nil, usererror.BadRequest("Can't update a deleted comment.")
+	}
+
+	if act.Text == in.Text {
+		return act, nil
+	}
+
+	act, err = c.activityStore.UpdateOptLock(ctx, act, func(act *types.IssueActivity) error {
+		act.Text = in.Text
+		act.Edited = time.Now().UnixMilli()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return act, nil
+}
+
+// CommentDelete marks a comment as deleted.
+func (c *Controller) CommentDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	commentID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	return controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		act, err := c.getCommentCheckEditAccess(ctx, session, repo, issueNum, commentID)
+		if err != nil {
+			return err
+		}
+
+		if act.Deleted != nil {
+			return nil
+		}
+
+		now := time.Now().UnixMilli()
+		act.Deleted = &now
+
+		if err = c.activityStore.Update(ctx, act); err != nil {
+			return fmt.Errorf("failed to mark comment as deleted: %w", err)
+		}
+
+		issue, err := c.issueStore.Find(ctx, act.IssueID)
+		if err != nil {
+			return fmt.Errorf("failed to find issue: %w", err)
+		}
+
+		issue.CommentCount--
+
+		if err = c.issueStore.Update(ctx, issue); err != nil {
+			return fmt.Errorf("failed to decrement issue comment counter: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (c *Controller) getCommentCheckEditAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	issueNum int64,
+	commentID int64,
+) (*types.IssueActivity, error) {
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	act, err := c.activityStore.Find(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find comment: %w", err)
+	}
+
+	if act.IssueID != issue.ID || act.Kind != enum.PullReqActivityKindComment {
+		return nil, usererror.NotFound("Comment not found")
+	}
+
+	if act.CreatedBy != session.Principal.ID {
+		return nil, usererror.BadRequest("Only own comments may be updated.")
+	}
+
+	return act, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	issueservice "github.com/harness/gitness/app/services/issue"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	tx                 dbtx.Transactor
+	authorizer         authz.Authorizer
+	repoFinder         refcache.RepoFinder
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+	issueStore         store.IssueStore
+	activityStore      store.IssueActivityStore
+	assigneeStore      store.IssueAssigneeStore
+	milestoneStore     store.MilestoneStore
+	labelSvc           *label.Service
+	issueSvc           *issueservice.Service
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	repoFinder refcache.RepoFinder,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	issueStore store.IssueStore,
+	activityStore store.IssueActivityStore,
+	assigneeStore store.IssueAssigneeStore,
+	milestoneStore store.MilestoneStore,
+	labelSvc *label.Service,
+	issueSvc *issueservice.Service,
+) *Controller {
+	return &Controller{
+		tx:                 tx,
+		authorizer:         authorizer,
+		repoFinder:         repoFinder,
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+		issueStore:         issueStore,
+		activityStore:      activityStore,
+		assigneeStore:      assigneeStore,
+		milestoneStore:     milestoneStore,
+		labelSvc:           labelSvc,
+		issueSvc:           issueSvc,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+) (*types.RepositoryCore, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoFinder.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err := apiauth.CheckRepoState(ctx, session, repo, reqPermission); err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return repo, nil
+}
+
+// getIssueCheckTriageAccess returns the issue if the principal is allowed to triage it.
+// Triage is allowed to principals with push access to the repository and to the author of the issue.
+func (c *Controller) getIssueCheckTriageAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	issueNum int64,
+) (*types.Issue, error) {
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	if issue.CreatedBy == session.Principal.ID {
+		return issue, nil
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return issue, nil
+}
+
+// getMilestone returns the milestone if it belongs to the repository.
+func (c *Controller) getMilestone(ctx context.Context, repoID, milestoneID int64) (*types.Milestone, error) {
+	milestone, err := c.milestoneStore.Find(ctx, milestoneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find milestone: %w", err)
+	}
+
+	if milestone.RepoID != repoID {
+		return nil, usererror.NotFound("Milestone not found")
+	}
+
+	return milestone, nil
+}
+
+// backfill fills the assignees and the labels of the issues.
+func (c *Controller) backfill(ctx context.Context, issues ...*types.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	issueIDs := make([]int64, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+
+	assignees, err := c.assigneeStore.Map(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list issue assignees: %w", err)
+	}
+
+	principalIDs := make([]int64, 0, len(assignees))
+	for _, ids := range assignees {
+		principalIDs = append(principalIDs, ids...)
+	}
+
+	infoMap, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load assignee principal infos: %w", err)
+	}
+
+	for _, issue := range issues {
+		issue.Assignees = make([]*types.PrincipalInfo, 0, len(assignees[issue.ID]))
+		for _, id := range assignees[issue.ID] {
+			if info, ok := infoMap[id]; ok {
+				issue.Assignees = append(issue.Assignees, info)
+			}
+		}
+	}
+
+	if err = c.labelSvc.BackfillIssues(ctx, issues); err != nil {
+		return fmt.Errorf("failed to backfill issue labels: %w", err)
+	}
+
+	return nil
+}
+
+func validateTitle(title string) error {
+	if title == "" {
+		return usererror.BadRequest("Title can't be empty")
+	}
+
+	const maxLen = 256
+	if utf8.RuneCountInString(title) > maxLen {
+		return usererror.BadRequestf("Title is too long (maximum is %d characters)", maxLen)
+	}
+
+	return nil
+}
+
+func validateDescription(desc string) error {
+	const maxLen = 64 << 10 // 64K
+	if len(desc) > maxLen {
+		return usererror.BadRequest("Description is too long")
+	}
+
+	return nil
+}
+
+func validateComment(text string) error {
+	if text == "" {
+		return usererror.BadRequest("Comment text can't be empty")
+	}
+
+	const maxLen = 16 << 10 // 16K
+	if len(text) > maxLen {
+		return usererror.BadRequest("Comment is too long")
+	}
+
+	return nil
+}
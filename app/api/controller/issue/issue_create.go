@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	MilestoneID *int64 `json:"milestone_id"`
+}
+
+func (in *CreateInput) Sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := validateTitle(in.Title); err != nil {
+		return err
+	}
+
+	if err := validateDescription(in.Description); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Create creates a new issue.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.Issue, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if in.MilestoneID != nil {
+		if _, err = c.getMilestone(ctx, repo.ID, *in.MilestoneID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	issue := &types.Issue{
+		RepoID:      repo.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+		Edited:      now,
+		State:       enum.IssueStateOpen,
+		Title:       in.Title,
+		Description: in.Description,
+		MilestoneID: in.MilestoneID,
+		Author:      *session.Principal.ToPrincipalInfo(),
+	}
+
+	// the issue number is allocated by the store, concurrent creation in the same repository
+	// might claim the same number, in which case the insert is retried.
+	const maxTries = 3
+	for try := 1; ; try++ {
+		err = c.issueStore.Create(ctx, issue)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, store.ErrDuplicate) || try == maxTries {
+			return nil, fmt.Errorf("failed to create issue: %w", err)
+		}
+	}
+
+	issue.Assignees = []*types.PrincipalInfo{}
+
+	return issue, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type SetMilestoneInput struct {
+	// MilestoneID is the milestone to assign, nil removes the issue from its milestone.
+	MilestoneID *int64 `json:"milestone_id"`
+}
+
+// SetMilestone assigns an issue to a milestone or removes it from its milestone.
+func (c *Controller) SetMilestone(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *SetMilestoneInput,
+) (*types.Issue, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	var newMilestone *types.Milestone
+	if in.MilestoneID != nil {
+		if newMilestone, err = c.getMilestone(ctx, repo.ID, *in.MilestoneID); err != nil {
+			return nil, err
+		}
+	}
+
+	var oldMilestoneID *int64
+	changed := false
+
+	issue, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		oldMilestoneID = issue.MilestoneID
+		changed = !equalIDs(issue.MilestoneID, in.MilestoneID)
+		if !changed {
+			return nil
+		}
+
+		issue.MilestoneID = in.MilestoneID
+		issue.ActivitySeq++
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue milestone: %w", err)
+	}
+
+	if changed {
+		payload := &types.IssueActivityPayloadMilestoneChange{}
+		if oldMilestoneID != nil {
+			if oldMilestone, err := c.milestoneStore.Find(ctx, *oldMilestoneID); err == nil {
+				payload.Old = &oldMilestone.Title
+			}
+		}
+		if newMilestone != nil {
+			payload.New = &newMilestone.Title
+		}
+
+		if _, err = c.activityStore.CreateWithPayload(ctx, issue, session.Principal.ID, payload); err != nil {
+			log.Ctx(ctx).Err(err).Msg("failed to write issue activity after milestone change")
+		}
+	}
+
+	if err = c.backfill(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
+
+func equalIDs(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type StateInput struct {
+	State enum.IssueState `json:"state"`
+}
+
+func (in *StateInput) Sanitize() error {
+	state, ok := in.State.Sanitize()
+	if !ok || state == "" {
+		return usererror.BadRequest("Issue state must be either open or closed.")
+	}
+
+	in.State = state
+
+	return nil
+}
+
+// State opens or closes an issue.
+func (c *Controller) State(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *StateInput,
+) (*types.Issue, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.getIssueCheckTriageAccess(ctx, session, repo, issueNum)
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err = c.issueSvc.SetState(ctx, issue, session.Principal.ID, in.State, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.backfill(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UpdateInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+func (in *UpdateInput) Sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := validateTitle(in.Title); err != nil {
+		return err
+	}
+
+	if err := validateDescription(in.Description); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Update updates the title and the description of an issue.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *UpdateInput,
+) (*types.Issue, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.getIssueCheckTriageAccess(ctx, session, repo, issueNum)
+	if err != nil {
+		return nil, err
+	}
+
+	if issue.Title == in.Title && issue.Description == in.Description {
+		return issue, c.backfill(ctx, issue)
+	}
+
+	titleOld := issue.Title
+
+	issue, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		titleOld = issue.Title
+		if titleOld != in.Title {
+			issue.ActivitySeq++
+		}
+
+		issue.Title = in.Title
+		issue.Description = in.Description
+		issue.Edited = time.Now().UnixMilli()
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	if titleOld != in.Title {
+		payload := &types.IssueActivityPayloadTitleChange{
+			Old: titleOld,
+			New: in.Title,
+		}
+		if _, err = c.activityStore.CreateWithPayload(ctx, issue, session.Principal.ID, payload); err != nil {
+			log.Ctx(ctx).Err(err).Msg("failed to write issue activity after title change")
+		}
+	}
+
+	if err = c.backfill(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AssignLabel assigns a label to an issue.
+func (c *Controller) AssignLabel(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *types.PullReqLabelAssignInput,
+) (*types.IssueLabel, error) {
+	if err := in.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate input: %w", err)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	out, err := c.labelSvc.AssignToIssue(ctx, session.Principal.ID, issue.ID, repo.ID, repo.ParentID, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign issue label: %w", err)
+	}
+
+	if out.ActivityType == enum.LabelActivityNoop {
+		return out.IssueLabel, nil
+	}
+
+	payload := &types.IssueActivityPayloadLabel{
+		PullRequestActivityLabelBase: types.PullRequestActivityLabelBase{
+			Label:      out.Label.Key,
+			LabelColor: out.Label.Color,
+			LabelScope: out.Label.Scope,
+		},
+		Type: out.ActivityType,
+	}
+	if out.OldLabelValue != nil {
+		payload.OldValue = &out.OldLabelValue.Value
+		payload.OldValueColor = &out.OldLabelValue.Color
+	}
+	if out.NewLabelValue != nil {
+		payload.Value = &out.NewLabelValue.Value
+		payload.ValueColor = &out.NewLabelValue.Color
+	}
+
+	c.writeActivity(ctx, issue, session.Principal.ID, payload)
+
+	return out.IssueLabel, nil
+}
+
+// UnassignLabel removes a label from an issue.
+func (c *Controller) UnassignLabel(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	labelID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	label, value, err := c.labelSvc.UnassignFromIssue(ctx, repo.ID, repo.ParentID, issue.ID, labelID)
+	if err != nil {
+		return fmt.Errorf("failed to unassign issue label: %w", err)
+	}
+
+	payload := &types.IssueActivityPayloadLabel{
+		PullRequestActivityLabelBase: types.PullRequestActivityLabelBase{
+			Label:      label.Key,
+			LabelColor: label.Color,
+			LabelScope: label.Scope,
+		},
+		Type: enum.LabelActivityUnassign,
+	}
+	if value != nil {
+		payload.Value = &value.Value
+		payload.ValueColor = &value.Color
+	}
+
+	c.writeActivity(ctx, issue, session.Principal.ID, payload)
+
+	return nil
+}
+
+// ListLabels lists the labels assigned to an issue.
+func (c *Controller) ListLabels(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	filter *types.AssignableLabelFilter,
+) (*types.ScopesLabels, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	scopeLabels, total, err := c.labelSvc.ListIssueLabels(ctx, repo, repo.ParentID, issue.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list issue labels: %w", err)
+	}
+
+	return scopeLabels, total, nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MilestoneCreateInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DueDate     *int64 `json:"due_date"`
+}
+
+func (in *MilestoneCreateInput) Sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if err := validateTitle(in.Title); err != nil {
+		return err
+	}
+
+	return validateDescription(in.Description)
+}
+
+type MilestoneUpdateInput struct {
+	Title       *string              `json:"title"`
+	Description *string              `json:"description"`
+	State       *enum.MilestoneState `json:"state"`
+	DueDate     *int64               `json:"due_date"`
+}
+
+func (in *MilestoneUpdateInput) Sanitize() error {
+	if in.Title != nil {
+		*in.Title = strings.TrimSpace(*in.Title)
+		if err := validateTitle(*in.Title); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := validateDescription(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if in.State != nil {
+		state, ok := in.State.Sanitize()
+		if !ok {
+			return usererror.BadRequest("Milestone state must be either open or closed.")
+		}
+		in.State = &state
+	}
+
+	return nil
+}
+
+// MilestoneCreate creates a new milestone in the repository.
+func (c *Controller) MilestoneCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MilestoneCreateInput,
+) (*types.Milestone, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	milestone := &types.Milestone{
+		RepoID:      repo.ID,
+		Title:       in.Title,
+		Description: in.Description,
+		State:       enum.MilestoneStateOpen,
+		DueDate:     in.DueDate,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.milestoneStore.Create(ctx, milestone); err != nil {
+		return nil, fmt.Errorf("failed to create milestone: %w", err)
+	}
+
+	return milestone, nil
+}
+
+// MilestoneFind returns a milestone of the repository.
+func (c *Controller) MilestoneFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+) (*types.Milestone, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	milestone, err := c.getMilestone(ctx, repo.ID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.backfillMilestones(ctx, milestone); err != nil {
+		return nil, err
+	}
+
+	return milestone, nil
+}
+
+// MilestoneList returns the milestones of the repository.
+func (c *Controller) MilestoneList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.MilestoneFilter,
+) ([]*types.Milestone, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	count, err := c.milestoneStore.Count(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count milestones: %w", err)
+	}
+
+	milestones, err := c.milestoneStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	if err = c.backfillMilestones(ctx, milestones...); err != nil {
+		return nil, 0, err
+	}
+
+	return milestones, count, nil
+}
+
+// MilestoneUpdate updates a milestone of the repository.
+func (c *Controller) MilestoneUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+	in *MilestoneUpdateInput,
+) (*types.Milestone, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	milestone, err := c.getMilestone(ctx, repo.ID, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Title != nil {
+		milestone.Title = *in.Title
+	}
+	if in.Description != nil {
+		milestone.Description = *in.Description
+	}
+	if in.State != nil {
+		milestone.State = *in.State
+	}
+	if in.DueDate != nil {
+		milestone.DueDate = in.DueDate
+		if *in.DueDate == 0 {
+			milestone.DueDate = nil
+		}
+	}
+	milestone.Updated = time.Now().UnixMilli()
+
+	if err = c.milestoneStore.Update(ctx, milestone); err != nil {
+		return nil, fmt.Errorf("failed to update milestone: %w", err)
+	}
+
+	if err = c.backfillMilestones(ctx, milestone); err != nil {
+		return nil, err
+	}
+
+	return milestone, nil
+}
+
+// MilestoneDelete deletes a milestone of the repository. Its issues are kept without a milestone.
+func (c *Controller) MilestoneDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	milestone, err := c.getMilestone(ctx, repo.ID, milestoneID)
+	if err != nil {
+		return err
+	}
+
+	if err = c.milestoneStore.Delete(ctx, milestone.ID); err != nil {
+		return fmt.Errorf("failed to delete milestone: %w", err)
+	}
+
+	return nil
+}
+
+// backfillMilestones fills the open and closed issue counters of the milestones.
+func (c *Controller) backfillMilestones(ctx context.Context, milestones ...*types.Milestone) error {
+	ids := make([]int64, len(milestones))
+	for i, milestone := range milestones {
+		ids[i] = milestone.ID
+	}
+
+	counts, err := c.issueStore.CountByMilestoneIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to count milestone issues: %w", err)
+	}
+
+	for _, milestone := range milestones {
+		milestone.OpenIssues = counts[milestone.ID][enum.IssueStateOpen]
+		milestone.ClosedIssues = counts[milestone.ID][enum.IssueStateClosed]
+	}
+
+	return nil
+}
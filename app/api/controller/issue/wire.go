@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"github.com/harness/gitness/app/auth/authz"
+	issueservice "github.com/harness/gitness/app/services/issue"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideController,
+)
+
+func ProvideController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	repoFinder refcache.RepoFinder,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	issueStore store.IssueStore,
+	activityStore store.IssueActivityStore,
+	assigneeStore store.IssueAssigneeStore,
+	milestoneStore store.MilestoneStore,
+	labelSvc *label.Service,
+	issueSvc *issueservice.Service,
+) *Controller {
+	return NewController(
+		tx,
+		authorizer,
+		repoFinder,
+		principalStore,
+		principalInfoCache,
+		issueStore,
+		activityStore,
+		assigneeStore,
+		milestoneStore,
+		labelSvc,
+		issueSvc,
+	)
+}
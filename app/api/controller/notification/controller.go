@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+type Controller struct {
+	authorizer         authz.Authorizer
+	notificationStore  store.NotificationStore
+	subscriptionStore  store.NotificationSubscriptionStore
+	principalInfoCache store.PrincipalInfoCache
+	repoFinder         refcache.RepoFinder
+	spaceFinder        refcache.SpaceFinder
+	sseStreamer        sse.Streamer
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	notificationStore store.NotificationStore,
+	subscriptionStore store.NotificationSubscriptionStore,
+	principalInfoCache store.PrincipalInfoCache,
+	repoFinder refcache.RepoFinder,
+	spaceFinder refcache.SpaceFinder,
+	sseStreamer sse.Streamer,
+) *Controller {
+	return &Controller{
+		authorizer:         authorizer,
+		notificationStore:  notificationStore,
+		subscriptionStore:  subscriptionStore,
+		principalInfoCache: principalInfoCache,
+		repoFinder:         repoFinder,
+		spaceFinder:        spaceFinder,
+		sseStreamer:        sseStreamer,
+	}
+}
+
+// backfillActors sets the actor principal info of the notifications.
+func (c *Controller) backfillActors(ctx context.Context, notifications ...*types.Notification) error {
+	actorIDs := make([]int64, 0, len(notifications))
+	for _, n := range notifications {
+		if n.ActorID != nil {
+			actorIDs = append(actorIDs, *n.ActorID)
+		}
+	}
+
+	if len(actorIDs) == 0 {
+		return nil
+	}
+
+	actors, err := c.principalInfoCache.Map(ctx, actorIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load notification actors: %w", err)
+	}
+
+	for _, n := range notifications {
+		if n.ActorID != nil {
+			n.Actor = actors[*n.ActorID]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// List returns a paginated list of notifications in the inbox of the current principal.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.NotificationFilter,
+) ([]*types.Notification, int64, error) {
+	principalID := session.Principal.ID
+
+	count, err := c.notificationStore.Count(ctx, principalID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	notifications, err := c.notificationStore.List(ctx, principalID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	if err = c.backfillActors(ctx, notifications...); err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, count, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MarkAllReadOutput struct {
+	Count int64 `json:"count"`
+}
+
+// MarkAllRead marks all notifications in the inbox of the current principal as read.
+func (c *Controller) MarkAllRead(
+	ctx context.Context,
+	session *auth.Session,
+) (*MarkAllReadOutput, error) {
+	principalID := session.Principal.ID
+
+	count, err := c.notificationStore.MarkAllRead(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark all notifications as read: %w", err)
+	}
+
+	out := &MarkAllReadOutput{Count: count}
+
+	if count > 0 {
+		c.sseStreamer.PublishToPrincipal(ctx, principalID, enum.SSETypeNotificationAllRead, out)
+	}
+
+	return out, nil
+}
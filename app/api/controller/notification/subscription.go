@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type SubscriptionUpsertInput struct {
+	ResourceID   int64                  `json:"resource_id"`
+	ResourceType enum.ResourceType      `json:"resource_type"`
+	Level        enum.NotificationLevel `json:"level"`
+}
+
+func (in *SubscriptionUpsertInput) sanitize() error {
+	level, ok := in.Level.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Invalid notification level %q.", in.Level)
+	}
+	in.Level = level
+
+	return nil
+}
+
+// ListSubscriptions returns all notification subscriptions of the current principal.
+func (c *Controller) ListSubscriptions(
+	ctx context.Context,
+	session *auth.Session,
+) ([]*types.NotificationSubscription, error) {
+	subscriptions, err := c.subscriptionStore.List(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// UpsertSubscription sets the notification level of the current principal for a repository or a space.
+func (c *Controller) UpsertSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	in *SubscriptionUpsertInput,
+) (*types.NotificationSubscription, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	resourceID, err := c.checkResource(ctx, session, in.ResourceType, in.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	subscription := &types.NotificationSubscription{
+		PrincipalID:  session.Principal.ID,
+		ResourceID:   resourceID,
+		ResourceType: in.ResourceType,
+		Level:        in.Level,
+		Created:      now,
+		Updated:      now,
+	}
+
+	if err = c.subscriptionStore.Upsert(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to store notification subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// DeleteSubscription removes the notification subscription of the current principal
+// for a repository or a space. The notification level is then inherited from the parent space.
+func (c *Controller) DeleteSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	resourceType enum.ResourceType,
+	resourceID int64,
+) error {
+	switch resourceType { //nolint:exhaustive
+	case enum.ResourceTypeRepo, enum.ResourceTypeSpace:
+	default:
+		return usererror.BadRequestf("Notification subscriptions are not supported for %s.", resourceType)
+	}
+
+	err := c.subscriptionStore.Delete(ctx, session.Principal.ID, resourceType, resourceID)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification subscription: %w", err)
+	}
+
+	return nil
+}
+
+// checkResource checks if the current principal has access to the resource and returns its ID.
+func (c *Controller) checkResource(
+	ctx context.Context,
+	session *auth.Session,
+	resourceType enum.ResourceType,
+	resourceID int64,
+) (int64, error) {
+	switch resourceType { //nolint:exhaustive
+	case enum.ResourceTypeRepo:
+		repo, err := c.repoFinder.FindByID(ctx, resourceID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView); err != nil {
+			return 0, err
+		}
+
+		return repo.ID, nil
+
+	case enum.ResourceTypeSpace:
+		space, err := c.spaceFinder.FindByID(ctx, resourceID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView); err != nil {
+			return 0, err
+		}
+
+		return space.ID, nil
+
+	default:
+		return 0, usererror.BadRequestf("Notification subscriptions are not supported for %s.", resourceType)
+	}
+}
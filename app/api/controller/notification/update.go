@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Read bool `json:"read"`
+}
+
+// Update marks a notification in the inbox of the current principal as read or unread.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	notificationID int64,
+	in *UpdateInput,
+) (*types.Notification, error) {
+	principalID := session.Principal.ID
+
+	err := c.notificationStore.UpdateRead(ctx, principalID, notificationID, in.Read)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification: %w", err)
+	}
+
+	notification, err := c.notificationStore.Find(ctx, principalID, notificationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find updated notification: %w", err)
+	}
+
+	if err = c.backfillActors(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	c.sseStreamer.PublishToPrincipal(ctx, principalID, enum.SSETypeNotificationUpdated, notification)
+
+	return notification, nil
+}
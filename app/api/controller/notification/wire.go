@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideController,
+)
+
+func ProvideController(
+	authorizer authz.Authorizer,
+	notificationStore store.NotificationStore,
+	subscriptionStore store.NotificationSubscriptionStore,
+	principalInfoCache store.PrincipalInfoCache,
+	repoFinder refcache.RepoFinder,
+	spaceFinder refcache.SpaceFinder,
+	sseStreamer sse.Streamer,
+) *Controller {
+	return NewController(
+		authorizer,
+		notificationStore,
+		subscriptionStore,
+		principalInfoCache,
+		repoFinder,
+		spaceFinder,
+		sseStreamer,
+	)
+}
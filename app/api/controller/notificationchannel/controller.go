@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationchannel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	config       notification.Config
+	authorizer   authz.Authorizer
+	spaceFinder  refcache.SpaceFinder
+	channelStore store.NotificationChannelStore
+	chatClient   *notification.ChatClient
+}
+
+func NewController(
+	config notification.Config,
+	authorizer authz.Authorizer,
+	spaceFinder refcache.SpaceFinder,
+	channelStore store.NotificationChannelStore,
+	chatClient *notification.ChatClient,
+) *Controller {
+	return &Controller{
+		config:       config,
+		authorizer:   authorizer,
+		spaceFinder:  spaceFinder,
+		channelStore: channelStore,
+		chatClient:   chatClient,
+	}
+}
+
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	permission enum.Permission,
+) (*types.SpaceCore, error) {
+	return space.GetSpaceCheckAuth(ctx, c.spaceFinder, c.authorizer, session, spaceRef, permission)
+}
+
+func (c *Controller) checkURL(rawURL string) error {
+	if err := webhook.CheckURL(rawURL, c.config.AllowLoopback, c.config.AllowPrivateNetwork, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func sanitizeEvents(events []enum.NotificationChannelEvent) ([]enum.NotificationChannelEvent, error) {
+	if len(events) == 0 {
+		return nil, usererror.BadRequest("At least one event is required.")
+	}
+
+	seen := make(map[enum.NotificationChannelEvent]struct{}, len(events))
+	result := make([]enum.NotificationChannelEvent, 0, len(events))
+	for _, event := range events {
+		sanitized, ok := event.Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Unknown notification channel event %q.", event)
+		}
+
+		if _, ok = seen[sanitized]; ok {
+			continue
+		}
+
+		seen[sanitized] = struct{}{}
+		result = append(result, sanitized)
+	}
+
+	return result, nil
+}
+
+func sanitizeTemplates(
+	channelType enum.NotificationChannelType,
+	templates map[enum.NotificationChannelEvent]string,
+) (map[enum.NotificationChannelEvent]string, error) {
+	result := make(map[enum.NotificationChannelEvent]string, len(templates))
+	for event, text := range templates {
+		sanitized, ok := event.Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Unknown notification channel event %q.", event)
+		}
+
+		if text == "" {
+			continue
+		}
+
+		if _, err := notification.ParseChatTemplate(channelType, text); err != nil {
+			return nil, usererror.BadRequestf("Invalid template for event %s: %s", sanitized, err)
+		}
+
+		result[sanitized] = text
+	}
+
+	return result, nil
+}
+
+func (c *Controller) findChannel(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.NotificationChannel, error) {
+	channel, err := c.channelStore.FindByIdentifier(ctx, spaceID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification channel: %w", err)
+	}
+
+	return channel, nil
+}
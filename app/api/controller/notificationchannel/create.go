@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationchannel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Identifier  string                                   `json:"identifier"`
+	Description string                                   `json:"description"`
+	Type        enum.NotificationChannelType             `json:"type"`
+	URL         string                                   `json:"url"`
+	Events      []enum.NotificationChannelEvent          `json:"events"`
+	Templates   map[enum.NotificationChannelEvent]string `json:"templates"`
+	Enabled     *bool                                    `json:"enabled"`
+}
+
+func (in *CreateInput) sanitize() error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	channelType, ok := in.Type.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unknown notification channel type %q.", in.Type)
+	}
+	in.Type = channelType
+
+	events, err := sanitizeEvents(in.Events)
+	if err != nil {
+		return err
+	}
+	in.Events = events
+
+	templates, err := sanitizeTemplates(in.Type, in.Templates)
+	if err != nil {
+		return err
+	}
+	in.Templates = templates
+
+	return nil
+}
+
+// Create creates a new notification channel in the space.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *CreateInput,
+) (*types.NotificationChannel, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if err = c.checkURL(in.URL); err != nil {
+		return nil, err
+	}
+
+	enabled := true
+	if in.Enabled != nil {
+		enabled = *in.Enabled
+	}
+
+	now := time.Now().UnixMilli()
+	channel := &types.NotificationChannel{
+		SpaceID:     space.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Type:        in.Type,
+		URL:         in.URL,
+		Events:      in.Events,
+		Templates:   in.Templates,
+		Enabled:     enabled,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.channelStore.Create(ctx, channel); err != nil {
+		return nil, fmt.Errorf("failed to create notification channel: %w", err)
+	}
+
+	return channel, nil
+}
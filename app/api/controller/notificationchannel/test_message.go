@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationchannel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SendTestMessage posts a test message to the notification channel of the space.
+// Unlike the event notifications, a failure to post the message is returned to the caller.
+func (c *Controller) SendTestMessage(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) error {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	channel, err := c.findChannel(ctx, space.ID, identifier)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Test message from notification channel %s of %s.", channel.Identifier, space.Path)
+	if err = c.chatClient.Post(ctx, channel, message); err != nil {
+		return usererror.BadRequestf("Failed to post the test message: %s", err)
+	}
+
+	return nil
+}
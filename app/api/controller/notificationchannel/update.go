@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationchannel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Identifier  *string                                  `json:"identifier"`
+	Description *string                                  `json:"description"`
+	Type        *enum.NotificationChannelType            `json:"type"`
+	URL         *string                                  `json:"url"`
+	Events      []enum.NotificationChannelEvent          `json:"events"`
+	Templates   map[enum.NotificationChannelEvent]string `json:"templates"`
+	Enabled     *bool                                    `json:"enabled"`
+}
+
+// Update updates an existing notification channel of the space.
+// The templates, if provided, replace all custom templates of the channel.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *UpdateInput,
+) (*types.NotificationChannel, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	channel, err := c.findChannel(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Identifier != nil {
+		if err = check.Identifier(*in.Identifier); err != nil {
+			return nil, err
+		}
+		channel.Identifier = *in.Identifier
+	}
+
+	if in.Description != nil {
+		if err = check.Description(*in.Description); err != nil {
+			return nil, err
+		}
+		channel.Description = *in.Description
+	}
+
+	if in.Type != nil {
+		channelType, ok := in.Type.Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Unknown notification channel type %q.", *in.Type)
+		}
+		channel.Type = channelType
+	}
+
+	if in.URL != nil {
+		if err = c.checkURL(*in.URL); err != nil {
+			return nil, err
+		}
+		channel.URL = *in.URL
+	}
+
+	if in.Events != nil {
+		if channel.Events, err = sanitizeEvents(in.Events); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.Templates != nil {
+		channel.Templates = in.Templates
+	}
+
+	// templates are validated again as the type of the channel affects their parsing.
+	if channel.Templates, err = sanitizeTemplates(channel.Type, channel.Templates); err != nil {
+		return nil, err
+	}
+
+	if in.Enabled != nil {
+		channel.Enabled = *in.Enabled
+	}
+
+	channel.Updated = time.Now().UnixMilli()
+
+	if err = c.channelStore.Update(ctx, channel); err != nil {
+		return nil, fmt.Errorf("failed to update notification channel: %w", err)
+	}
+
+	return channel, nil
+}
@@ -42,6 +42,9 @@ func (c *Controller) ActivityList(
 		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
 	}
 
+	// the user's own pending review comments are included in the timeline
+	filter.PendingAuthorID = session.Principal.ID
+
 	list, err := c.activityStore.List(ctx, pr.ID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pull requests activities: %w", err)
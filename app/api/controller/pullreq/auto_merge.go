@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/automerge"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type AutoMergeEnableInput struct {
+	Method             enum.MergeMethod `json:"method"`
+	SourceSHA          string           `json:"source_sha"`
+	Title              string           `json:"title"`
+	Message            string           `json:"message"`
+	DeleteSourceBranch bool             `json:"delete_source_branch"`
+
+	// DisableOnPush disables the auto-merge when new commits are pushed to the source branch.
+	// If not provided, the system default is used.
+	DisableOnPush *bool `json:"disable_on_push"`
+}
+
+func (in *AutoMergeEnableInput) sanitize() error {
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("Source SHA must be provided")
+	}
+
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported merge method: %q", in.Method)
+	}
+
+	in.Method = method
+
+	// cleanup title / message (NOTE: git doesn't support white space only)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if (in.Method == enum.MergeMethodRebase || in.Method == enum.MergeMethodFastForward) &&
+		(in.Title != "" || in.Message != "") {
+		return usererror.BadRequestf(
+			"merge method %q doesn't support customizing commit title and message", in.Method)
+	}
+
+	return nil
+}
+
+// AutoMergeEnable enables the auto-merge of a pull request.
+//
+// The pull request is merged on behalf of the caller as soon as all protection rules are satisfied.
+// The protection rules are never bypassed by the auto-merge.
+func (c *Controller) AutoMergeEnable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeEnableInput,
+) (*types.AutoMerge, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil,
+			usererror.BadRequest("A newer commit is available. Only the latest commit can be merged.")
+	}
+
+	if pr.IsDraft {
+		return nil, usererror.BadRequest(
+			"Auto-merge can't be enabled for draft pull requests. Clear the draft flag first.",
+		)
+	}
+
+	am, err := c.autoMerge.Enable(ctx, &session.Principal, pr, automerge.EnableInput{
+		Method:             in.Method,
+		SourceSHA:          in.SourceSHA,
+		Title:              in.Title,
+		Message:            in.Message,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+		DisableOnPush:      in.DisableOnPush,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable pull request auto-merge: %w", err)
+	}
+
+	return am, nil
+}
+
+// AutoMergeDisable disables the auto-merge of a pull request.
+func (c *Controller) AutoMergeDisable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if err := c.autoMerge.Disable(ctx, &session.Principal, pr); err != nil {
+		return fmt.Errorf("failed to disable pull request auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// AutoMergeFind returns the auto-merge of a pull request.
+func (c *Controller) AutoMergeFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.AutoMerge, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	return c.autoMerge.Find(ctx, pr)
+}
@@ -98,6 +98,9 @@ func (c *Controller) ChangeTargetBranch(ctx context.Context,
 		pr.MergeBaseSHA = mergeBase.MergeBaseSHA.String()
 		pr.TargetBranch = in.BranchName
 
+		// the pull request is no longer stacked on the parent pull request
+		pr.ParentID = nil
+
 		pr.MarkAsMergeUnchecked()
 
 		pr.ActivitySeq++
@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/protection"
@@ -139,6 +140,16 @@ func (c *Controller) RestoreBranch(ctx context.Context,
 		if err != nil {
 			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for restore branch operation: %s", err)
 		}
+
+		c.repoReporter.RuleBypassed(ctx, &repoevents.RuleBypassedPayload{
+			Base: repoevents.Base{
+				RepoID:      repo.ID,
+				PrincipalID: session.Principal.ID,
+			},
+			Action:       audit.BypassActionCreated,
+			ResourceType: audit.BypassedResourceTypeBranch,
+			ResourceName: branch.Name,
+		})
 	}
 
 	err = func() error {
@@ -48,24 +48,36 @@ func (c *Controller) ListChecks(
 		return types.PullReqChecks{}, fmt.Errorf("failed to fetch rules: %w", err)
 	}
 
+	commitSHA := pr.SourceSHA
+
+	checks, err := c.checkStore.List(ctx, repo.ID, commitSHA, types.CheckListOptions{})
+	if err != nil {
+		return types.PullReqChecks{}, fmt.Errorf("failed to list status check results for repo: %w", err)
+	}
+
+	checkResults := make([]types.CheckResult, len(checks))
+	for i := range checks {
+		checkResults[i] = types.CheckResult{Identifier: checks[i].Identifier, Status: checks[i].Status}
+	}
+
+	changedFiles, err := c.changedFiles(ctx, repo, pr)
+	if err != nil {
+		return types.PullReqChecks{}, err
+	}
+
 	reqChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
 		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
 		Actor:              &session.Principal,
 		IsRepoOwner:        isRepoOwner,
 		Repo:               repo,
 		PullReq:            pr,
+		CheckResults:       checkResults,
+		ChangedFiles:       changedFiles,
 	})
 	if err != nil {
 		return types.PullReqChecks{}, fmt.Errorf("failed to get identifiers of required checks: %w", err)
 	}
 
-	commitSHA := pr.SourceSHA
-
-	checks, err := c.checkStore.List(ctx, repo.ID, commitSHA, types.CheckListOptions{})
-	if err != nil {
-		return types.PullReqChecks{}, fmt.Errorf("failed to list status check results for repo: %w", err)
-	}
-
 	result := types.PullReqChecks{
 		CommitSHA: commitSHA,
 		Checks:    nil,
@@ -89,10 +101,12 @@ func (c *Controller) ListChecks(
 		})
 	}
 
+	// The remaining required status checks haven't been reported for the commit.
 	for requiredID := range reqChecks.RequiredIdentifiers {
 		result.Checks = append(result.Checks, types.PullReqCheck{
 			Required:   true,
 			Bypassable: false,
+			Missing:    true,
 			Check: types.Check{
 				RepoID:     repo.ID,
 				CommitSHA:  commitSHA,
@@ -107,6 +121,7 @@ func (c *Controller) ListChecks(
 		result.Checks = append(result.Checks, types.PullReqCheck{
 			Required:   true,
 			Bypassable: true,
+			Missing:    true,
 			Check: types.Check{
 				RepoID:     repo.ID,
 				CommitSHA:  commitSHA,
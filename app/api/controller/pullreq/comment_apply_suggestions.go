@@ -178,6 +178,11 @@ func (c *Controller) CommentApplySuggestions(
 				"Only code comments or replies on code comments support applying suggestions.")
 		}
 
+		if activity.Pending || ccActivity.Pending {
+			return CommentApplySuggestionsOutput{}, nil, usererror.BadRequest(
+				"Suggestions of pending review comments cannot be applied.")
+		}
+
 		// code comment can't be part of multiple suggestions being applied
 		if _, ok := activityUpdates[ccActivity.ID]; ok {
 			return CommentApplySuggestionsOutput{}, nil, usererror.BadRequestf(
@@ -47,6 +47,9 @@ type CommentCreateInput struct {
 	LineStartNew    bool   `json:"line_start_new"`
 	LineEnd         int    `json:"line_end"`
 	LineEndNew      bool   `json:"line_end_new"`
+	// Pending makes the comment a part of the pending review of the user.
+	// It stays visible only to its author until the review is submitted.
+	Pending bool `json:"pending"`
 }
 
 func (in *CommentCreateInput) IsReply() bool {
@@ -119,10 +122,23 @@ func (c *Controller) CommentCreate(
 
 	var parentAct *types.PullReqActivity
 	if in.IsReply() {
-		parentAct, err = c.checkIsReplyable(ctx, pr, in.ParentID)
+		parentAct, err = c.checkIsReplyable(ctx, session, pr, in.ParentID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to verify reply: %w", err)
 		}
+
+		// replies to pending comments are published together with them
+		in.Pending = in.Pending || parentAct.Pending
+	}
+
+	if in.Pending {
+		if pr.CreatedBy == session.Principal.ID {
+			return nil, usererror.BadRequest("Can't start a review of own pull requests.")
+		}
+
+		if pr.Merged != nil {
+			return nil, usererror.BadRequest("Can't start a review of merged pull requests.")
+		}
 	}
 
 	// fetch code snippet from git for code comments
@@ -190,6 +206,11 @@ func (c *Controller) CommentCreate(
 			return fmt.Errorf("failed to write pull request comment: %w", err)
 		}
 
+		if act.Pending {
+			// pending comments are added to the counters when the review gets submitted
+			return nil
+		}
+
 		pr.CommentCount++
 		if act.IsBlocking() {
 			pr.UnresolvedCount++
@@ -216,16 +237,19 @@ func (c *Controller) CommentCreate(
 		c.migrateCodeComment(ctx, repo, pr, in, act.AsCodeComment(), cut)
 	}
 
-	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+	// pending comments are announced only once, with the submitted review
+	if !act.Pending {
+		c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
 
-	// publish event for all comments
-	if act.Type == enum.PullReqActivityTypeComment || act.Type == enum.PullReqActivityTypeCodeComment {
-		c.reportCommentCreated(
-			ctx,
-			pr,
-			session.Principal.ID,
-			act.ID, act.IsReply(),
-		)
+		// publish event for all comments
+		if act.Type == enum.PullReqActivityTypeComment || act.Type == enum.PullReqActivityTypeCodeComment {
+			c.reportCommentCreated(
+				ctx,
+				pr,
+				session.Principal.ID,
+				act.ID, act.IsReply(),
+			)
+		}
 	}
 
 	err = c.instrumentation.Track(ctx, instrument.Event{
@@ -249,12 +273,14 @@ func (c *Controller) CommentCreate(
 
 func (c *Controller) checkIsReplyable(
 	ctx context.Context,
+	session *auth.Session,
 	pr *types.PullReq,
 	parentID int64,
 ) (*types.PullReqActivity, error) {
 	// make sure the parent comment exists, belongs to the same PR and isn't itself a reply
 	parentAct, err := c.activityStore.Find(ctx, parentID)
-	if errors.Is(err, store.ErrResourceNotFound) || parentAct == nil {
+	if errors.Is(err, store.ErrResourceNotFound) || parentAct == nil ||
+		parentAct.Pending && parentAct.CreatedBy != session.Principal.ID {
 		return nil, usererror.BadRequest("Parent pull request activity not found.")
 	}
 	if err != nil {
@@ -333,6 +359,7 @@ func getCommentActivity(
 		Updated:    now,
 		Edited:     now,
 		Deleted:    nil,
+		Pending:    in.Pending,
 		ParentID:   nil, // Will be filled in CommentCreate
 		RepoID:     pr.TargetRepoID,
 		PullReqID:  pr.ID,
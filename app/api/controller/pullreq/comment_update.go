@@ -114,9 +114,11 @@ func (c *Controller) CommentUpdate(
 	// Populate activity mentions (used only for response purposes).
 	act.Mentions = principalInfos
 
-	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+	if !act.Pending {
+		c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
 
-	c.reportCommentUpdated(ctx, pr, session.Principal.ID, act.ID, act.IsReply())
+		c.reportCommentUpdated(ctx, pr, session.Principal.ID, act.ID, act.IsReply())
+	}
 
 	return act, nil
 }
@@ -24,15 +24,21 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -81,6 +87,13 @@ type Controller struct {
 	userGroupService       usergroup.Service
 	branchStore            store.BranchStore
 	userGroupResolver      usergroup.Resolver
+	mergeQueue             *mergequeue.Service
+	autoMerge              *automerge.Service
+	signatureVerifyService publickey.SignatureVerifyService
+	templateService        *pullreqtemplate.Service
+	settings               *settings.Service
+	repoReporter           *repoevents.Reporter
+	mergeService           *pullreq.MergeService
 }
 
 func NewController(
@@ -117,6 +130,13 @@ func NewController(
 	userGroupService usergroup.Service,
 	branchStore store.BranchStore,
 	userGroupResolver usergroup.Resolver,
+	mergeQueue *mergequeue.Service,
+	autoMerge *automerge.Service,
+	signatureVerifyService publickey.SignatureVerifyService,
+	templateService *pullreqtemplate.Service,
+	settings *settings.Service,
+	repoReporter *repoevents.Reporter,
+	mergeService *pullreq.MergeService,
 ) *Controller {
 	return &Controller{
 		tx:                     tx,
@@ -152,6 +172,13 @@ func NewController(
 		userGroupService:       userGroupService,
 		branchStore:            branchStore,
 		userGroupResolver:      userGroupResolver,
+		mergeQueue:             mergeQueue,
+		autoMerge:              autoMerge,
+		signatureVerifyService: signatureVerifyService,
+		templateService:        templateService,
+		settings:               settings,
+		repoReporter:           repoReporter,
+		mergeService:           mergeService,
 	}
 }
 
@@ -236,6 +263,24 @@ func (c *Controller) fetchRules(
 	return protectionRules, isRepoOwner, nil
 }
 
+// changedFiles returns the paths of the files modified by the pull request.
+func (c *Controller) changedFiles(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+) ([]string, error) {
+	out, err := c.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    pr.MergeBaseSHA,
+		HeadRef:    pr.SourceSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed files of pull request: %w", err)
+	}
+
+	return out.Files, nil
+}
+
 func (c *Controller) getCommentForPR(
 	ctx context.Context,
 	pr *types.PullReq,
@@ -296,6 +341,10 @@ func (c *Controller) getCommentCheckChangeStatusAccess(ctx context.Context,
 		return nil, usererror.BadRequest("Can't change status of replies.")
 	}
 
+	if comment.Pending {
+		return nil, usererror.BadRequest("Can't change status of pending review comments.")
+	}
+
 	return comment, nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -55,9 +56,11 @@ func (c *Controller) UnassignLabel(
 	}
 
 	var value *string
+	var valueID *int64
 	var color *enum.LabelColor
 	if labelValue != nil {
 		value = &labelValue.Value
+		valueID = &labelValue.ID
 		color = &labelValue.Color
 	}
 	payload := &types.PullRequestActivityLabel{
@@ -75,5 +78,11 @@ func (c *Controller) UnassignLabel(
 		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after label unassign")
 	}
 
+	c.eventReporter.LabelUnassigned(ctx, &events.LabelUnassignedPayload{
+		Base:    eventBase(pullreq, &session.Principal),
+		LabelID: label.ID,
+		ValueID: valueID,
+	})
+
 	return nil
 }
@@ -24,12 +24,9 @@ import (
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
-	pullreqevents "github.com/harness/gitness/app/events/pullreq"
-	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/codeowners"
-	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/protection"
-	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/contextutil"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
@@ -168,6 +165,24 @@ func (c *Controller) Merge(
 		)
 	}
 
+	blockingParent, err := c.stackBlockingParent(ctx, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if blockingParent != nil && !in.DryRunRules && !in.DryRun {
+		return nil, nil, usererror.BadRequestf(
+			"Pull request is stacked on pull request #%d which must be merged first.", blockingParent.Number)
+	}
+
+	var stack []types.PullReqStackItem
+	if in.DryRunRules || in.DryRun {
+		stack, err = c.pullreqListService.Stack(ctx, pr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get pull request stack: %w", err)
+		}
+	}
+
 	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
@@ -179,13 +194,7 @@ func (c *Controller) Merge(
 	}
 
 	sourceRepo := targetRepo
-	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
-		}
-
 		sourceRepo, err = c.repoFinder.FindByID(ctx, pr.SourceRepoID)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
@@ -208,18 +217,27 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
 	}
 
+	changedFiles, err := c.changedFiles(ctx, sourceRepo, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
-		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
-		Actor:              &session.Principal,
-		AllowBypass:        in.BypassRules,
-		IsRepoOwner:        isRepoOwner,
-		TargetRepo:         targetRepo,
-		SourceRepo:         sourceRepo,
-		PullReq:            pr,
-		Reviewers:          reviewers,
-		Method:             in.Method, // the method can be empty for dry run or dry run rules
-		CheckResults:       checkResults,
-		CodeOwners:         codeOwnerWithApproval,
+		ResolveUserGroupID:    c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:                 &session.Principal,
+		AllowBypass:           in.BypassRules,
+		IsRepoOwner:           isRepoOwner,
+		TargetRepo:            targetRepo,
+		SourceRepo:            sourceRepo,
+		PullReq:               pr,
+		Reviewers:             reviewers,
+		Method:                in.Method, // the method can be empty for dry run or dry run rules
+		CheckResults:          checkResults,
+		CodeOwners:            codeOwnerWithApproval,
+		ChangedFiles:          changedFiles,
+		UnverifiedCommits:     c.signatureVerifyService.UnverifiedPullReqCommits(sourceRepo, pr),
+		SourceUpToDate:        protection.SourceUpToDate(c.git, targetRepo, pr),
+		SourceHasMergeCommits: protection.SourceHasMergeCommits(c.git, targetRepo, pr),
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -249,6 +267,8 @@ func (c *Controller) Merge(
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 			DefaultReviewerApprovals:            ruleOut.DefaultReviewerApprovals,
+			BlockingParent:                      stackItem(blockingParent),
+			Stack:                               stack,
 		}, nil, nil
 	}
 
@@ -380,6 +400,8 @@ func (c *Controller) Merge(
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 			DefaultReviewerApprovals:            ruleOut.DefaultReviewerApprovals,
+			BlockingParent:                      stackItem(blockingParent),
+			Stack:                               stack,
 		}
 
 		return out, nil, nil
@@ -412,27 +434,7 @@ func (c *Controller) Merge(
 
 	// commit details: author, committer and message
 
-	var author *git.Identity
-
-	switch in.Method {
-	case enum.MergeMethodMerge:
-		author = controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodSquash:
-		author = controller.IdentityFromPrincipalInfo(pr.Author)
-	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
-		author = nil // Not important for these merge methods: the author info in the commits will be preserved.
-	}
-
-	var committer *git.Identity
-
-	switch in.Method {
-	case enum.MergeMethodMerge, enum.MergeMethodSquash:
-		committer = controller.SystemServicePrincipalInfo()
-	case enum.MergeMethodRebase:
-		committer = controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodFastForward:
-		committer = nil // Not important for fast-forward merge
-	}
+	author, committer := pullreq.MergeIdentities(pr, &session.Principal, in.Method)
 
 	// backfill commit title if none provided
 	if in.Title == "" {
@@ -552,146 +554,29 @@ func (c *Controller) Merge(
 
 	log.Ctx(ctx).Debug().Msgf("successfully merged PR")
 
-	mergedBy := session.Principal.ID
-
-	var activitySeqMerge, activitySeqBranchDeleted int64
-	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
-		pr.State = enum.PullReqStateMerged
-
-		nowMilli := now.UnixMilli()
-
-		pr.Merged = &nowMilli
-		pr.MergedBy = &mergedBy
-		pr.MergeMethod = &in.Method
-
-		// update all Merge specific information (might be empty if previous merge check failed)
-		// since this is the final operation on the PR, we update any sha that might've changed by now.
-		pr.SourceSHA = mergeOutput.HeadSHA.String()
-		pr.MergeTargetSHA = ptr.String(mergeOutput.BaseSHA.String())
-		pr.MergeBaseSHA = mergeOutput.MergeBaseSHA.String()
-		pr.MergeSHA = ptr.String(mergeOutput.MergeSHA.String())
-		pr.MarkAsMerged()
-
-		bypassed := protection.IsBypassed(violations)
-		pr.MergeViolationsBypassed = &bypassed
-
-		pr.Stats.DiffStats = types.NewDiffStats(
+	pr, branchDeleted, err := c.mergeService.Finalize(ctx, pr, pullreq.MergedInput{
+		Principal:    &session.Principal,
+		TargetRepo:   targetRepo,
+		SourceRepo:   sourceRepo,
+		Method:       in.Method,
+		Merged:       now,
+		SourceSHA:    mergeOutput.HeadSHA.String(),
+		TargetSHA:    mergeOutput.BaseSHA.String(),
+		MergeBaseSHA: mergeOutput.MergeBaseSHA.String(),
+		MergeSHA:     mergeOutput.MergeSHA.String(),
+		DiffStats: types.NewDiffStats(
 			mergeOutput.CommitCount,
 			mergeOutput.ChangedFileCount,
 			mergeOutput.Additions,
 			mergeOutput.Deletions,
-		)
-
-		// update sequence for PR activities
-		pr.ActivitySeq++
-		activitySeqMerge = pr.ActivitySeq
-
-		if deleteSourceBranch {
-			pr.ActivitySeq++
-			activitySeqBranchDeleted = pr.ActivitySeq
-		}
-
-		return nil
+		),
+		DeleteSourceBranch: deleteSourceBranch,
+		Violations:         violations,
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to update pull request: %w", err)
-	}
-
-	pr.ActivitySeq = activitySeqMerge
-	activityPayload := &types.PullRequestActivityPayloadMerge{
-		MergeMethod:   in.Method,
-		MergeSHA:      mergeOutput.MergeSHA.String(),
-		TargetSHA:     mergeOutput.BaseSHA.String(),
-		SourceSHA:     mergeOutput.HeadSHA.String(),
-		RulesBypassed: protection.IsBypassed(violations),
-	}
-	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy, activityPayload, nil); errAct != nil {
-		// non-critical error
-		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
-	}
-
-	c.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
-		Base:        eventBase(pr, &session.Principal),
-		MergeMethod: in.Method,
-		MergeSHA:    mergeOutput.MergeSHA.String(),
-		TargetSHA:   mergeOutput.BaseSHA.String(),
-		SourceSHA:   mergeOutput.HeadSHA.String(),
-	})
-
-	var branchDeleted bool
-	if deleteSourceBranch {
-		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
-			WriteParams: sourceWriteParams,
-			BranchName:  pr.SourceBranch,
-		})
-		if errDelete != nil {
-			// non-critical error
-			log.Ctx(ctx).Err(errDelete).Msgf("failed to delete source branch after merging")
-		} else {
-			branchDeleted = true
-
-			// NOTE: there is a chance someone pushed on the branch between merge and delete.
-			// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
-			pr.ActivitySeq = activitySeqBranchDeleted
-			if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy,
-				&types.PullRequestActivityPayloadBranchDelete{SHA: in.SourceSHA}, nil); errAct != nil {
-				// non-critical error
-				log.Ctx(ctx).Err(errAct).
-					Msgf("failed to write pull request activity for successful automatic branch delete")
-			}
-		}
-	}
-
-	c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
-
-	if protection.IsBypassed(violations) {
-		err = c.auditService.Log(ctx,
-			session.Principal,
-			audit.NewResource(
-				audit.ResourceTypeRepository,
-				sourceRepo.Identifier,
-				audit.RepoPath,
-				sourceRepo.Path,
-				audit.BypassedResourceType,
-				audit.BypassedResourceTypePullRequest,
-				audit.BypassedResourceName,
-				strconv.FormatInt(pr.Number, 10),
-				audit.ResourceName,
-				fmt.Sprintf(
-					audit.BypassPullReqLabelFormat,
-					sourceRepo.Identifier,
-					strconv.FormatInt(pr.Number, 10),
-				),
-				audit.BypassAction,
-				audit.BypassActionMerged,
-			),
-			audit.ActionBypassed,
-			paths.Parent(sourceRepo.Path),
-			audit.WithNewObject(audit.PullRequestObject{
-				PullReq:        *pr,
-				RepoPath:       sourceRepo.Path,
-				RuleViolations: violations,
-			}),
-		)
-		if err != nil {
-			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for merge pull request operation: %s", err)
-		}
+		return nil, nil, err
 	}
 
-	err = c.instrumentation.Track(ctx, instrument.Event{
-		Type:      instrument.EventTypeMergePullRequest,
-		Principal: session.Principal.ToPrincipalInfo(),
-		Path:      sourceRepo.Path,
-		Properties: map[instrument.Property]any{
-			instrument.PropertyRepositoryID:   sourceRepo.ID,
-			instrument.PropertyRepositoryName: sourceRepo.Identifier,
-			instrument.PropertyPullRequestID:  pr.Number,
-			instrument.PropertyMergeStrategy:  in.Method,
-		},
-	})
-	if err != nil {
-		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for merge pr operation: %s", err)
-	}
 	return &types.MergeResponse{
 		SHA:            mergeOutput.MergeSHA.String(),
 		BranchDeleted:  branchDeleted,
@@ -0,0 +1,259 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/mergequeue"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MergeQueueEnqueueInput struct {
+	Method             enum.MergeMethod `json:"method"`
+	SourceSHA          string           `json:"source_sha"`
+	Title              string           `json:"title"`
+	Message            string           `json:"message"`
+	DeleteSourceBranch bool             `json:"delete_source_branch"`
+}
+
+func (in *MergeQueueEnqueueInput) sanitize() error {
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("Source SHA must be provided")
+	}
+
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported merge method: %q", in.Method)
+	}
+
+	in.Method = method
+
+	if in.Method == enum.MergeMethodFastForward {
+		return usererror.BadRequest("Merge queue doesn't support the fast-forward merge method")
+	}
+
+	// cleanup title / message (NOTE: git doesn't support white space only)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.Method == enum.MergeMethodRebase && (in.Title != "" || in.Message != "") {
+		return usererror.BadRequestf(
+			"merge method %q doesn't support customizing commit title and message", in.Method)
+	}
+
+	return nil
+}
+
+// MergeQueueEnqueue adds a pull request to the merge queue of its target branch.
+//
+// The pull request must satisfy all protection rules except the required status checks,
+// which are evaluated against the speculative merge commit created by the merge queue.
+func (c *Controller) MergeQueueEnqueue(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *MergeQueueEnqueueInput,
+) (*types.MergeQueueEntry, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, nil,
+			usererror.BadRequest("A newer commit is available. Only the latest commit can be merged.")
+	}
+
+	if pr.IsDraft {
+		return nil, nil, usererror.BadRequest(
+			"Draft pull requests can't be merged. Clear the draft flag first.",
+		)
+	}
+
+	blockingParent, err := c.stackBlockingParent(ctx, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if blockingParent != nil {
+		return nil, nil, usererror.BadRequestf(
+			"Pull request is stacked on pull request #%d which must be merged first.", blockingParent.Number)
+	}
+
+	if pr.SourceRepoID != pr.TargetRepoID {
+		return nil, nil, usererror.BadRequest("Merge queue doesn't support pull requests from other repositories")
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, repo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	changedFiles, err := c.changedFiles(ctx, repo, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupID:    c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:                 &session.Principal,
+		IsRepoOwner:           isRepoOwner,
+		TargetRepo:            repo,
+		SourceRepo:            repo,
+		PullReq:               pr,
+		Reviewers:             reviewers,
+		Method:                in.Method,
+		CodeOwners:            codeOwnerWithApproval,
+		ChangedFiles:          changedFiles,
+		UnverifiedCommits:     c.signatureVerifyService.UnverifiedPullReqCommits(repo, pr),
+		SourceUpToDate:        protection.SourceUpToDate(c.git, repo, pr),
+		SourceHasMergeCommits: protection.SourceHasMergeCommits(c.git, repo, pr),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	violations = protection.WithoutStatusCheckViolations(violations)
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	// backfill commit title if none provided
+	if in.Title == "" {
+		switch in.Method {
+		case enum.MergeMethodMerge:
+			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, repo.Path, pr.Number)
+		case enum.MergeMethodSquash:
+			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+			// Not used.
+		}
+	}
+
+	entry, err := c.mergeQueue.Enqueue(ctx, &session.Principal, pr, mergequeue.EnqueueInput{
+		Method:             in.Method,
+		SourceSHA:          in.SourceSHA,
+		Title:              in.Title,
+		Message:            in.Message,
+		DeleteSourceBranch: in.DeleteSourceBranch || ruleOut.DeleteSourceBranch,
+	})
+	if errors.Is(err, mergequeue.ErrAlreadyQueued) {
+		return nil, nil, usererror.Conflict("Pull request is already in the merge queue")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to add pull request to the merge queue: %w", err)
+	}
+
+	return entry, nil, nil
+}
+
+// MergeQueueDequeue removes a pull request from the merge queue.
+func (c *Controller) MergeQueueDequeue(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if err := c.mergeQueue.Dequeue(ctx, &session.Principal, pr); err != nil {
+		return fmt.Errorf("failed to remove pull request from the merge queue: %w", err)
+	}
+
+	return nil
+}
+
+// MergeQueueFind returns the merge queue entry of a pull request.
+func (c *Controller) MergeQueueFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.MergeQueueEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	return c.mergeQueue.FindByPullReq(ctx, pr)
+}
+
+// MergeQueueList returns the merge queue of a branch.
+func (c *Controller) MergeQueueList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	targetBranch string,
+) (*types.MergeQueue, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	if targetBranch == "" {
+		targetBranch = repo.DefaultBranch
+	}
+
+	return c.mergeQueue.List(ctx, repo.ID, targetBranch)
+}
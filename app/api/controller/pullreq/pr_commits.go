@@ -57,9 +57,19 @@ func (c *Controller) Commits(
 		return nil, err
 	}
 
-	commits := make([]types.Commit, len(output.Commits))
+	commitPtrs := make([]*types.Commit, len(output.Commits))
 	for i := range output.Commits {
-		commits[i] = *controller.MapCommit(&output.Commits[i])
+		commitPtrs[i] = controller.MapCommit(&output.Commits[i])
+	}
+
+	err = c.signatureVerifyService.VerifyCommits(ctx, repo.ID, commitPtrs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify signature of commits: %w", err)
+	}
+
+	commits := make([]types.Commit, len(commitPtrs))
+	for i := range commitPtrs {
+		commits[i] = *commitPtrs[i]
 	}
 
 	return commits, nil
@@ -48,10 +48,17 @@ type CreateInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 
+	// Template is the name of the pull request template the description is based on.
+	// If empty, the default template of the target branch is used (if any).
+	Template string `json:"template"`
+
 	SourceRepoRef string `json:"source_repo_ref"`
 	SourceBranch  string `json:"source_branch"`
 	TargetBranch  string `json:"target_branch"`
 
+	// ParentNumber is the number of the pull request the new pull request is stacked on.
+	ParentNumber int64 `json:"parent_number"`
+
 	ReviewerIDs          []int64 `json:"reviewer_ids"`
 	UserGroupReviewerIDs []int64 `json:"user_group_reviewer_ids"`
 
@@ -63,6 +70,7 @@ type CreateInput struct {
 func (in *CreateInput) Sanitize() error {
 	in.Title = strings.TrimSpace(in.Title)
 	in.Description = strings.TrimSpace(in.Description)
+	in.Template = strings.TrimSpace(in.Template)
 
 	if err := validateTitle(in.Title); err != nil {
 		return err
@@ -103,6 +111,16 @@ func (c *Controller) Create(
 		return nil, usererror.BadRequest("Target and source branch can't be the same")
 	}
 
+	var parentID *int64
+	if in.ParentNumber != 0 {
+		parent, err := c.findStackParent(ctx, targetRepo, sourceRepo.ID, in.TargetBranch, in.ParentNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		parentID = &parent.ID
+	}
+
 	var sourceSHA sha.SHA
 
 	if sourceSHA, err = c.verifyBranchExistence(ctx, sourceRepo, in.SourceBranch); err != nil {
@@ -113,6 +131,10 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	if err = c.applyTemplate(ctx, targetRepo, in); err != nil {
+		return nil, err
+	}
+
 	if err = c.checkIfAlreadyExists(ctx, targetRepo.ID, sourceRepo.ID, in.TargetBranch, in.SourceBranch); err != nil {
 		return nil, err
 	}
@@ -274,6 +296,7 @@ func (c *Controller) Create(
 		// Create pull request in the DB
 
 		pr = newPullReq(session, targetRepoFull.PullReqSeq, sourceRepo.ID, targetRepo.ID, in, sourceSHA, mergeBaseSHA)
+		pr.ParentID = parentID
 		pr.Stats = types.PullReqStats{
 			DiffStats:       types.NewDiffStats(prStats.Commits, prStats.FilesChanged, prStats.Additions, prStats.Deletions),
 			Conversations:   0,
@@ -526,7 +549,7 @@ func (c *Controller) getCodeOwnerReviewers(
 				continue
 			}
 
-			userGroup, err := c.userGroupResolver.Resolve(ctx, identifier)
+			userGroup, err := c.userGroupResolver.Resolve(ctx, targetRepo.ParentID, identifier)
 			if errors.Is(err, usergroup.ErrNotFound) {
 				log.Ctx(ctx).Warn().Msgf("user group %q not found, skipping", identifier)
 				continue
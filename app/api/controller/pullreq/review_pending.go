@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ReviewPendingDiscard deletes all pending review comments of the current user.
+func (c *Controller) ReviewPendingDiscard(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if _, err = c.activityStore.DeletePending(ctx, pr.ID, session.Principal.ID); err != nil {
+		return fmt.Errorf("failed to delete pending review comments: %w", err)
+	}
+
+	return nil
+}
+
+// publishPendingComments makes the pending review comments of the principal visible to everyone.
+// Every pending comment thread is moved to the end of the pull request timeline
+// and the comment counters of the pull request are updated.
+// It returns IDs of all published comments.
+func (c *Controller) publishPendingComments(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+) ([]int64, error) {
+	pending, err := c.activityStore.ListPending(ctx, pr.ID, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending review comments: %w", err)
+	}
+
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	var commentCount, unresolvedCount int
+	orders := make(map[int64]int64) // old thread order -> new thread order
+	ids := make([]int64, len(pending))
+
+	// the list is sorted by order and sub-order, so a pending thread is always processed before its replies
+	for i, act := range pending {
+		if !act.IsReply() {
+			prUpd, err := c.pullreqStore.UpdateActivitySeq(ctx, pr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request activity number: %w", err)
+			}
+
+			*pr = *prUpd
+
+			orders[act.Order] = prUpd.ActivitySeq
+		}
+
+		if order, ok := orders[act.Order]; ok {
+			act.Order = order
+		}
+
+		act.Pending = false
+
+		if err = c.activityStore.Update(ctx, act); err != nil {
+			return nil, fmt.Errorf("failed to publish pending review comment: %w", err)
+		}
+
+		commentCount++
+		if act.IsBlocking() {
+			unresolvedCount++
+		}
+
+		ids[i] = act.ID
+	}
+
+	prUpd, err := c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.CommentCount += commentCount
+		pr.UnresolvedCount += unresolvedCount
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment pull request comment counters: %w", err)
+	}
+
+	*pr = *prUpd
+
+	return ids, nil
+}
@@ -95,9 +95,17 @@ func (c *Controller) ReviewSubmit(
 
 	commitSHA := commit.Commit.SHA
 
-	var review *types.PullReqReview
+	var (
+		review     *types.PullReqReview
+		commentIDs []int64
+	)
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		commentIDs, err = c.publishPendingComments(ctx, pr, session.Principal.ID)
+		if err != nil {
+			return err
+		}
+
 		now := time.Now().UnixMilli()
 		review = &types.PullReqReview{
 			ID:        0,
@@ -117,6 +125,7 @@ func (c *Controller) ReviewSubmit(
 			Base:       eventBase(pr, &session.Principal),
 			Decision:   review.Decision,
 			ReviewerID: review.CreatedBy,
+			CommentIDs: commentIDs,
 		})
 
 		_, err = c.updateReviewer(ctx, session, pr, review, commitSHA.String())
@@ -126,6 +135,10 @@ func (c *Controller) ReviewSubmit(
 		return nil, err
 	}
 
+	if len(commentIDs) > 0 {
+		c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+	}
+
 	err = func() error {
 		if pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
 			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
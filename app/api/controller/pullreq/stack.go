@@ -0,0 +1,207 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type StackParentInput struct {
+	ParentNumber int64 `json:"parent_number"`
+}
+
+func (in *StackParentInput) validate() error {
+	if in.ParentNumber <= 0 {
+		return usererror.BadRequest("A valid parent pull request number must be provided.")
+	}
+
+	return nil
+}
+
+// StackParentSet stacks the pull request on top of another pull request.
+func (c *Controller) StackParentSet(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *StackParentInput,
+) (*types.PullReq, error) {
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.Number == in.ParentNumber {
+		return nil, usererror.BadRequest("Pull request can't be stacked on itself")
+	}
+
+	parent, err := c.findStackParent(ctx, repo, pr.SourceRepoID, pr.TargetBranch, in.ParentNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.verifyStackHasNoCycle(ctx, pr, parent); err != nil {
+		return nil, err
+	}
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.ParentID = &parent.ID
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request parent: %w", err)
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return pr, nil
+}
+
+// StackParentRemove removes the pull request from the stack of its parent pull request.
+func (c *Controller) StackParentRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.ParentID == nil {
+		return pr, nil
+	}
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.ParentID = nil
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request parent: %w", err)
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return pr, nil
+}
+
+// findStackParent finds the pull request that a pull request would be stacked on and verifies that
+// stacking is possible: Both pull requests must be within the same repository and
+// the target branch must be the source branch of the parent pull request.
+func (c *Controller) findStackParent(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	sourceRepoID int64,
+	targetBranch string,
+	parentNumber int64,
+) (*types.PullReq, error) {
+	parent, err := c.pullreqStore.FindByNumber(ctx, repo.ID, parentNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent pull request by number: %w", err)
+	}
+
+	if parent.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull requests can be stacked only on an open pull request")
+	}
+
+	if sourceRepoID != repo.ID || parent.SourceRepoID != repo.ID {
+		return nil, usererror.BadRequest("Pull requests from forked repositories can't be stacked")
+	}
+
+	if targetBranch != parent.SourceBranch {
+		return nil, usererror.BadRequestf(
+			"The target branch must be the source branch %q of the parent pull request", parent.SourceBranch)
+	}
+
+	return parent, nil
+}
+
+// verifyStackHasNoCycle verifies that the pull request isn't an ancestor of the new parent pull request.
+func (c *Controller) verifyStackHasNoCycle(ctx context.Context, pr, parent *types.PullReq) error {
+	visited := map[int64]struct{}{}
+
+	for ancestor := parent; ancestor.ParentID != nil; {
+		if *ancestor.ParentID == pr.ID {
+			return usererror.BadRequest("Pull request can't be stacked on one of its descendants")
+		}
+
+		if _, ok := visited[*ancestor.ParentID]; ok {
+			break
+		}
+		visited[*ancestor.ParentID] = struct{}{}
+
+		var err error
+		ancestor, err = c.pullreqStore.Find(ctx, *ancestor.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to find ancestor pull request: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// stackBlockingParent returns the parent pull request if the pull request is stacked on a pull request
+// that is still open. Such pull request can't be merged before its parent.
+func (c *Controller) stackBlockingParent(ctx context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	if pr.ParentID == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	parent, err := c.pullreqStore.Find(ctx, *pr.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parent pull request: %w", err)
+	}
+
+	if parent.State != enum.PullReqStateOpen {
+		return nil, nil //nolint:nilnil
+	}
+
+	return parent, nil
+}
+
+func stackItem(pr *types.PullReq) *types.PullReqStackItem {
+	if pr == nil {
+		return nil
+	}
+
+	item := pr.StackItem()
+
+	return &item
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// TemplateList lists the pull request templates available in the repository at the provided git ref.
+func (c *Controller) TemplateList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+) ([]types.PullReqTemplate, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	templates, err := c.templateService.List(ctx, repo, gitRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// TemplateFind returns the pull request template with the provided name from the repository.
+func (c *Controller) TemplateFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	name string,
+	gitRef string,
+) (*types.PullReqTemplate, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	template, err := c.templateService.Find(ctx, repo, gitRef, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request template: %w", err)
+	}
+
+	return template, nil
+}
+
+// applyTemplate resolves the pull request template from the target branch.
+// An empty description is replaced with the template, and if enabled for the repository,
+// the description is verified to check all required checklist items of the template.
+func (c *Controller) applyTemplate(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	in *CreateInput,
+) error {
+	checklistRequired, err := settings.RepoGet(
+		ctx,
+		c.settings,
+		repo.ID,
+		settings.KeyPullReqTemplateChecklistRequired,
+		settings.DefaultPullReqTemplateChecklistRequired,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request template checklist setting: %w", err)
+	}
+
+	if in.Description != "" && in.Template == "" && !checklistRequired {
+		return nil
+	}
+
+	template, err := c.templateService.Find(ctx, repo, api.BranchPrefix+in.TargetBranch, in.Template)
+	if errors.IsNotFound(err) && in.Template == "" {
+		// the repository doesn't have a default template
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request template: %w", err)
+	}
+
+	if in.Description == "" {
+		in.Description = strings.TrimSpace(template.Content)
+		if err := validateDescription(in.Description); err != nil {
+			return err
+		}
+	}
+
+	if !checklistRequired {
+		return nil
+	}
+
+	if missing := pullreqtemplate.MissingRequiredItems(template.Content, in.Description); len(missing) > 0 {
+		return usererror.BadRequestf(
+			"The pull request description doesn't check all required items of the template %q: %s",
+			template.Name, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
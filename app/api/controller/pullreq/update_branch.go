@@ -0,0 +1,231 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateBranchInput struct {
+	// Method is either merge (merges the target branch into the source branch)
+	// or rebase (rebases the source branch on top of the target branch).
+	Method    enum.MergeMethod `json:"method"`
+	SourceSHA string           `json:"source_sha"`
+
+	DryRun      bool `json:"dry_run"`
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *UpdateBranchInput) sanitize() error {
+	if in.Method == "" {
+		in.Method = enum.MergeMethodMerge
+	}
+
+	if in.Method != enum.MergeMethodMerge && in.Method != enum.MergeMethodRebase {
+		return usererror.BadRequestf("Unsupported update method %q. Use merge or rebase.", in.Method)
+	}
+
+	return nil
+}
+
+// UpdateBranch brings the source branch of a pull request up to date with the target branch,
+// either by merging the target branch into it or by rebasing it on top of the target branch.
+//
+//nolint:gocognit
+func (c *Controller) UpdateBranch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *UpdateBranchInput,
+) (*types.RebaseResponse, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.SourceRepoID != pr.TargetRepoID {
+		return nil, nil, usererror.BadRequest(
+			"Updating the source branch of a pull request from a different repository is not supported")
+	}
+
+	if in.SourceSHA != "" && in.SourceSHA != pr.SourceSHA {
+		return nil, nil, usererror.BadRequest("A newer commit is available. Only the latest commit can be updated.")
+	}
+
+	refAction := protection.RefActionUpdate
+	if in.Method == enum.MergeMethodRebase {
+		refAction = protection.RefActionUpdateForce
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          refAction,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{pr.SourceBranch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		return &types.RebaseResponse{
+			RuleViolations: violations,
+			DryRunRules:    true,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	sourceBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: pr.SourceBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get source branch: %w", err)
+	}
+
+	targetBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: pr.TargetBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	isAncestor, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          readParams,
+		AncestorCommitSHA:   targetBranch.Branch.SHA,
+		DescendantCommitSHA: sourceBranch.Branch.SHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed check ancestor: %w", err)
+	}
+
+	if isAncestor.Ancestor {
+		// The source branch already contains the latest commit from the target branch - nothing to do.
+		return &types.RebaseResponse{
+			AlreadyAncestor: true,
+			RuleViolations:  violations,
+		}, nil, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	var refs []git.RefUpdate
+	if !in.DryRun {
+		sourceBranchRef, err := git.GetRefPath(pr.SourceBranch, gitenum.RefTypeBranch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate ref name: %w", err)
+		}
+
+		refs = append(refs, git.RefUpdate{
+			Name: sourceBranchRef,
+			Old:  sourceBranch.Branch.SHA,
+			New:  sha.SHA{}, // update to the result of the merge
+		})
+	}
+
+	params := &git.MergeParams{
+		WriteParams: writeParams,
+		HeadRepoUID: repo.GitUID,
+		Refs:        refs,
+		Method:      gitenum.MergeMethod(in.Method),
+	}
+
+	if in.Method == enum.MergeMethodRebase {
+		// rebase the commits of the source branch on top of the target branch
+		params.BaseSHA = targetBranch.Branch.SHA
+		params.HeadBranch = pr.SourceBranch
+		params.HeadExpectedSHA = sourceBranch.Branch.SHA
+	} else {
+		// merge the target branch into the source branch
+		params.BaseSHA = sourceBranch.Branch.SHA
+		params.HeadBranch = pr.TargetBranch
+		params.HeadExpectedSHA = targetBranch.Branch.SHA
+		params.Message = fmt.Sprintf("Merge branch '%s' into %s", pr.TargetBranch, pr.SourceBranch)
+	}
+
+	mergeOutput, err := c.git.Merge(ctx, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("update branch execution failed: %w", err)
+	}
+
+	if in.DryRun {
+		// DryRun is true: Just return rule violations and list of conflicted files.
+		return &types.RebaseResponse{
+			RuleViolations: violations,
+			DryRun:         true,
+			ConflictFiles:  mergeOutput.ConflictFiles,
+		}, nil, nil
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{
+			ConflictFiles:  mergeOutput.ConflictFiles,
+			RuleViolations: violations,
+			Message:        fmt.Sprintf("Update branch blocked by conflicting files: %v", mergeOutput.ConflictFiles),
+		}, nil
+	}
+
+	return &types.RebaseResponse{
+		NewHeadBranchSHA: mergeOutput.MergeSHA,
+		RuleViolations:   violations,
+	}, nil, nil
+}
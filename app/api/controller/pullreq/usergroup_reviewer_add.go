@@ -18,10 +18,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	events "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -56,10 +59,11 @@ func (c *Controller) UserGroupReviewerAdd(
 		return nil, fmt.Errorf("failed to list reviewers: %w", err)
 	}
 
-	userGroup, err := c.userGroupStore.Find(ctx, in.UserGroupID)
+	userGroup, err := c.findRepoUserGroup(ctx, repo, in.UserGroupID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find user group: %w", err)
+		return nil, err
 	}
+
 	userIDs, err := c.userGroupService.ListUserIDsByGroupIDs(ctx, []int64{userGroup.ID})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list user ids by group id: %w", err)
@@ -149,6 +153,32 @@ func (c *Controller) UserGroupReviewerAdd(
 	return userGroupReviewer, nil
 }
 
+// findRepoUserGroup finds the user group by its ID. The user group must be defined
+// in the space of the repository or in one of its ancestor spaces.
+func (c *Controller) findRepoUserGroup(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	userGroupID int64,
+) (*types.UserGroup, error) {
+	userGroup, err := c.userGroupStore.Find(ctx, userGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	// resolve the group scoped to the space that defines it, the resolver verifies the space is available to the repo.
+	scopedID := strconv.FormatInt(userGroup.SpaceID, 10) + "/" + userGroup.Identifier
+
+	userGroup, err = c.userGroupResolver.Resolve(ctx, repo.ParentID, scopedID)
+	if errors.Is(err, usergroup.ErrNotFound) {
+		return nil, usererror.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user group: %w", err)
+	}
+
+	return userGroup, nil
+}
+
 func (c *Controller) reportUserGroupReviewerAdded(
 	ctx context.Context,
 	principal *types.Principal,
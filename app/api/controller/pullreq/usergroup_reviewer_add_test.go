@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type mapCache[V any] map[int64]V
+
+func (c mapCache[V]) Stats() (int64, int64)        { return 0, 0 }
+func (c mapCache[V]) Evict(context.Context, int64) {}
+func (c mapCache[V]) Get(_ context.Context, key int64) (V, error) {
+	v, ok := c[key]
+	if !ok {
+		return v, gitness_store.ErrResourceNotFound
+	}
+	return v, nil
+}
+
+func (c mapCache[V]) Map(context.Context, []int64) (map[int64]V, error) {
+	return map[int64]V{}, nil
+}
+
+type testUserGroupStore struct {
+	store.UserGroupStore
+	userGroups []*types.UserGroup
+}
+
+func (s testUserGroupStore) Find(_ context.Context, id int64) (*types.UserGroup, error) {
+	for _, userGroup := range s.userGroups {
+		if userGroup.ID == id {
+			copied := *userGroup
+			return &copied, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s testUserGroupStore) FindByIdentifier(
+	_ context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.UserGroup, error) {
+	for _, userGroup := range s.userGroups {
+		if userGroup.SpaceID == spaceID && userGroup.Identifier == identifier {
+			copied := *userGroup
+			return &copied, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type testUserGroupMemberStore struct {
+	store.UserGroupMemberStore
+}
+
+func (testUserGroupMemberStore) MapPrincipalIDs(context.Context, []int64) (map[int64][]int64, error) {
+	return map[int64][]int64{}, nil
+}
+
+func TestFindRepoUserGroup(t *testing.T) {
+	userGroupStore := testUserGroupStore{userGroups: []*types.UserGroup{
+		{ID: 1, SpaceID: 1, Identifier: "all"},
+		{ID: 2, SpaceID: 2, Identifier: "backend"},
+		{ID: 3, SpaceID: 3, Identifier: "frontend"},
+		{ID: 4, SpaceID: 4, Identifier: "other"},
+	}}
+
+	spaceFinder := refcache.NewSpaceFinder(mapCache[*types.SpaceCore]{
+		1: {ID: 1, Path: "acme"},
+		2: {ID: 2, ParentID: 1, Path: "acme/backend"},
+		3: {ID: 3, ParentID: 1, Path: "acme/frontend"},
+		4: {ID: 4, Path: "other"},
+	}, nil, cache.Evictor[*types.SpaceCore]{})
+
+	c := &Controller{
+		userGroupStore: userGroupStore,
+		userGroupResolver: usergroup.NewGitnessResolver(
+			userGroupStore, testUserGroupMemberStore{}, spaceFinder, mapCache[*types.PrincipalInfo]{}),
+	}
+
+	repo := &types.RepositoryCore{ID: 10, ParentID: 2, Path: "acme/backend/repo"}
+
+	tests := []struct {
+		name        string
+		userGroupID int64
+		expErr      error
+	}{
+		{name: "group of the repo space", userGroupID: 2},
+		{name: "group of an ancestor space", userGroupID: 1},
+		{name: "group of a sibling space", userGroupID: 3, expErr: usererror.ErrNotFound},
+		{name: "group of an unrelated space", userGroupID: 4, expErr: usererror.ErrNotFound},
+		{name: "unknown group", userGroupID: 5, expErr: gitness_store.ErrResourceNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userGroup, err := c.findRepoUserGroup(context.Background(), repo, test.userGroupID)
+			if test.expErr != nil {
+				if !errors.Is(err, test.expErr) {
+					t.Errorf("expected error %v, got %v", test.expErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if userGroup.ID != test.userGroupID {
+				t.Errorf("expected user group %d, got %d", test.userGroupID, userGroup.ID)
+			}
+		})
+	}
+}
@@ -17,15 +17,21 @@ package pullreq
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -69,6 +75,13 @@ func ProvideController(
 	userGroupService usergroup.Service,
 	branchStore store.BranchStore,
 	userGroupResolver usergroup.Resolver,
+	mergeQueue *mergequeue.Service,
+	autoMerge *automerge.Service,
+	signatureVerifyService publickey.SignatureVerifyService,
+	templateService *pullreqtemplate.Service,
+	settings *settings.Service,
+	repoReporter *repoevents.Reporter,
+	mergeService *pullreq.MergeService,
 ) *Controller {
 	return NewController(tx,
 		urlProvider,
@@ -103,5 +116,12 @@ func ProvideController(
 		userGroupService,
 		branchStore,
 		userGroupResolver,
+		mergeQueue,
+		autoMerge,
+		signatureVerifyService,
+		templateService,
+		settings,
+		repoReporter,
+		mergeService,
 	)
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/audit"
@@ -215,9 +216,19 @@ func (c *Controller) CommitFiles(ctx context.Context,
 				RuleViolations: violations,
 			}),
 		)
-	}
-	if err != nil {
-		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for commit operation: %s", err)
+		if err != nil {
+			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for commit operation: %s", err)
+		}
+
+		c.eventReporter.RuleBypassed(ctx, &repoevents.RuleBypassedPayload{
+			Base: repoevents.Base{
+				RepoID:      repo.ID,
+				PrincipalID: session.Principal.ID,
+			},
+			Action:       audit.BypassActionCommitted,
+			ResourceType: audit.BypassedResourceTypeCommit,
+			ResourceName: commit.CommitID.String(),
+		})
 	}
 
 	return types.CommitFilesResponse{
@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/protection"
@@ -142,6 +143,16 @@ func (c *Controller) CreateBranch(ctx context.Context,
 		if err != nil {
 			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create branch operation: %s", err)
 		}
+
+		c.eventReporter.RuleBypassed(ctx, &repoevents.RuleBypassedPayload{
+			Base: repoevents.Base{
+				RepoID:      repo.ID,
+				PrincipalID: session.Principal.ID,
+			},
+			Action:       audit.BypassActionCreated,
+			ResourceType: audit.BypassedResourceTypeBranch,
+			ResourceName: branch.Name,
+		})
 	}
 
 	err = c.instrumentation.Track(ctx, instrument.Event{
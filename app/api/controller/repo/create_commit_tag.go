@@ -21,6 +21,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/protection"
@@ -147,6 +148,16 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 		if err != nil {
 			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create tag operation: %s", err)
 		}
+
+		c.eventReporter.RuleBypassed(ctx, &repoevents.RuleBypassedPayload{
+			Base: repoevents.Base{
+				RepoID:      repo.ID,
+				PrincipalID: session.Principal.ID,
+			},
+			Action:       audit.BypassActionCreated,
+			ResourceType: audit.BypassedResourceTypeTag,
+			ResourceName: commitTag.Name,
+		})
 	}
 
 	err = c.instrumentation.Track(ctx, instrument.Event{
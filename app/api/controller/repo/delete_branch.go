@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/audit"
@@ -129,6 +130,16 @@ func (c *Controller) DeleteBranch(ctx context.Context,
 		if err != nil {
 			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete branch operation: %s", err)
 		}
+
+		c.eventReporter.RuleBypassed(ctx, &repoevents.RuleBypassedPayload{
+			Base: repoevents.Base{
+				RepoID:      repo.ID,
+				PrincipalID: session.Principal.ID,
+			},
+			Action:       audit.BypassActionDeleted,
+			ResourceType: audit.BypassedResourceTypeBranch,
+			ResourceName: branchName,
+		})
 	}
 
 	return types.DeleteBranchOutput{
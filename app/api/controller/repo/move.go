@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -165,6 +166,14 @@ func (c *Controller) Move(ctx context.Context,
 		"Moved repository %s to %s operation perofrmed by %s",
 		repo.Path, movedRepo.Path, session.Principal.Email)
 
+	c.eventReporter.Renamed(ctx, &repoevents.RenamedPayload{
+		Base:          eventBase(movedRepo.Core(), &session.Principal),
+		OldIdentifier: repo.Identifier,
+		NewIdentifier: movedRepo.Identifier,
+		OldPath:       repo.Path,
+		NewPath:       movedRepo.Path,
+	})
+
 	return GetRepoOutput(ctx, c.publicAccess, movedRepo)
 }
 
@@ -24,12 +24,16 @@ import (
 type GeneralSettings struct {
 	FileSizeLimit *int64 `json:"file_size_limit" yaml:"file_size_limit" description:"file size limit in bytes"`
 	GitLFSEnabled *bool  `json:"git_lfs_enabled" yaml:"git_lfs_enabled"`
+
+	PullReqTemplateChecklistRequired *bool `json:"pullreq_template_checklist_required" yaml:"pullreq_template_checklist_required" description:"require checked template checklist items"` //nolint:lll
 }
 
 func GetDefaultGeneralSettings() *GeneralSettings {
 	return &GeneralSettings{
 		FileSizeLimit: ptr.Int64(settings.DefaultFileSizeLimit),
 		GitLFSEnabled: ptr.Bool(settings.DefaultGitLFSEnabled),
+
+		PullReqTemplateChecklistRequired: ptr.Bool(settings.DefaultPullReqTemplateChecklistRequired),
 	}
 }
 
@@ -37,6 +41,7 @@ func GetGeneralSettingsMappings(s *GeneralSettings) []settings.SettingHandler {
 	return []settings.SettingHandler{
 		settings.Mapping(settings.KeyFileSizeLimit, s.FileSizeLimit),
 		settings.Mapping(settings.KeyGitLFSEnabled, s.GitLFSEnabled),
+		settings.Mapping(settings.KeyPullReqTemplateChecklistRequired, s.PullReqTemplateChecklistRequired),
 	}
 }
 
@@ -57,5 +62,12 @@ func GetGeneralSettingsAsKeyValues(s *GeneralSettings) []settings.KeyValue {
 		})
 	}
 
+	if s.PullReqTemplateChecklistRequired != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyPullReqTemplateChecklistRequired,
+			Value: s.PullReqTemplateChecklistRequired,
+		})
+	}
+
 	return kvs
 }
@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/rolemapping"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Config struct {
+	Enabled           bool
+	ServiceAccountUID string
+	RoleMappings      []rolemapping.Mapping
+	LinkByEmail       bool
+}
+
+// Controller implements the SCIM 2.0 provisioning endpoints.
+// Users are linked to the SCIM identity provider with a principal identity, and groups are
+// user groups of the parent space of the SCIM service account.
+type Controller struct {
+	config               Config
+	tx                   dbtx.Transactor
+	principalStore       store.PrincipalStore
+	identityStore        store.PrincipalIdentityStore
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+	membershipStore      store.MembershipStore
+	spaceFinder          refcache.SpaceFinder
+	userCtrl             *user.Controller
+}
+
+func NewController(
+	config Config,
+	tx dbtx.Transactor,
+	principalStore store.PrincipalStore,
+	identityStore store.PrincipalIdentityStore,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	membershipStore store.MembershipStore,
+	spaceFinder refcache.SpaceFinder,
+	userCtrl *user.Controller,
+) *Controller {
+	return &Controller{
+		config:               config,
+		tx:                   tx,
+		principalStore:       principalStore,
+		identityStore:        identityStore,
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+		membershipStore:      membershipStore,
+		spaceFinder:          spaceFinder,
+		userCtrl:             userCtrl,
+	}
+}
+
+// getSpaceCheckAuth verifies that the session belongs to the SCIM service account
+// and returns the space the provisioned groups are created in.
+func (c *Controller) getSpaceCheckAuth(ctx context.Context, session *auth.Session) (*types.SpaceCore, error) {
+	if !c.config.Enabled {
+		return nil, usererror.NotFound("SCIM provisioning is disabled")
+	}
+
+	if session == nil || auth.IsAnonymousSession(session) {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	if session.Principal.Type != enum.PrincipalTypeServiceAccount ||
+		session.Principal.UID != c.config.ServiceAccountUID {
+		return nil, usererror.ErrForbidden
+	}
+
+	sa, err := c.principalStore.FindServiceAccount(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find scim service account: %w", err)
+	}
+
+	if sa.ParentType != enum.ParentResourceTypeSpace {
+		return nil, usererror.Forbidden("The SCIM service account must belong to a space")
+	}
+
+	space, err := c.spaceFinder.FindByID(ctx, sa.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space of scim service account: %w", err)
+	}
+
+	return space, nil
+}
+
+// parseID parses the SCIM resource ID, unknown IDs are reported as not found.
+func parseID(id string) (int64, error) {
+	value, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || value <= 0 {
+		return 0, usererror.NotFoundf("Resource %q not found", id)
+	}
+
+	return value, nil
+}
+
+func formatTime(millis int64) string {
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+// paginate returns the page of the resources selected by the list params.
+func paginate[T any](resources []T, params ListParams) (ListResponse, []T) {
+	startIndex := max(params.StartIndex, 1)
+
+	page := []T{}
+	if startIndex <= len(resources) {
+		page = resources[startIndex-1:]
+	}
+
+	if params.Count >= 0 && params.Count < len(page) {
+		page = page[:params.Count]
+	}
+
+	return newListResponse(len(resources), startIndex, len(page)), page
+}
+
+func newListResponse(totalResults, startIndex, itemsPerPage int) ListResponse {
+	return ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: itemsPerPage,
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// filter is a SCIM filter expression. Only the equality filters used by the identity providers
+// to look up resources are supported, e.g. `userName eq "john@example.com"`.
+type filter struct {
+	attribute string
+	value     string
+}
+
+// parseFilter parses the SCIM filter expression, it returns nil if the expression is empty.
+func parseFilter(expr string) (*filter, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil //nolint:nilnil // no filter
+	}
+
+	attribute, rest, _ := strings.Cut(expr, " ")
+	operator, value, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	value = strings.TrimSpace(value)
+
+	if attribute == "" || value == "" || !strings.EqualFold(operator, "eq") {
+		return nil, usererror.BadRequestf("Unsupported filter %q, only the eq operator is supported", expr)
+	}
+
+	if strings.HasPrefix(value, `"`) {
+		if err := json.Unmarshal([]byte(value), &value); err != nil {
+			return nil, usererror.BadRequestf("Invalid filter value in %q", expr)
+		}
+	}
+
+	return &filter{
+		attribute: attribute,
+		value:     value,
+	}, nil
+}
+
+// isOn returns whether the filter is on one of the attributes. Attribute names are compared case-insensitively.
+func (f *filter) isOn(attributes ...string) bool {
+	for _, attribute := range attributes {
+		if strings.EqualFold(f.attribute, attribute) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches returns whether the filter is on one of the attributes and equals any of the values.
+// Attribute names and values are compared case-insensitively.
+func (f *filter) matches(attributes []string, values ...string) bool {
+	if !f.isOn(attributes...) {
+		return false
+	}
+
+	for _, value := range values {
+		if strings.EqualFold(f.value, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// userIdentityFilter returns the filter of the SCIM identities of the users that satisfy the filter.
+// Returns false if no user can satisfy the filter.
+func (f *filter) userIdentityFilter() (*types.PrincipalIdentityFilter, bool) {
+	identityFilter := &types.PrincipalIdentityFilter{Provider: enum.IdentityProviderSCIM}
+	if f == nil {
+		return identityFilter, true
+	}
+
+	switch {
+	case f.isOn("id"):
+		id, err := strconv.ParseInt(f.value, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, false
+		}
+		identityFilter.PrincipalID = id
+	case f.isOn("userName"):
+		identityFilter.Subject = f.value
+	case f.isOn("externalId"):
+		identityFilter.ExternalID = f.value
+	case f.isOn("displayName"):
+		identityFilter.DisplayName = f.value
+	case f.isOn("emails", "emails.value"):
+		identityFilter.Email = f.value
+	default:
+		return nil, false
+	}
+
+	// an empty value would disable the filter on the attribute
+	if f.value == "" {
+		return nil, false
+	}
+
+	return identityFilter, true
+}
+
+// matchGroup returns whether the group satisfies the filter.
+func (f *filter) matchGroup(group *Group) bool {
+	if f == nil {
+		return true
+	}
+
+	members := make([]string, len(group.Members))
+	for i, member := range group.Members {
+		members[i] = member.Value
+	}
+
+	return f.matches([]string{"id"}, group.ID) ||
+		f.matches([]string{"displayName"}, group.DisplayName) ||
+		f.matches([]string{"members", "members.value"}, members...)
+}
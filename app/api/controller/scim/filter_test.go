@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		expr      string
+		attribute string
+		value     string
+	}{
+		{expr: `userName eq "john@example.com"`, attribute: "userName", value: "john@example.com"},
+		{expr: ` externalId EQ "a \"quoted\" id" `, attribute: "externalId", value: `a "quoted" id`},
+		{expr: `emails.value eq john@example.com`, attribute: "emails.value", value: "john@example.com"},
+	}
+
+	for _, test := range tests {
+		f, err := parseFilter(test.expr)
+		if err != nil {
+			t.Fatalf("unexpected error for filter %q: %s", test.expr, err)
+		}
+
+		if f.attribute != test.attribute || f.value != test.value {
+			t.Errorf("filter %q: expected %s=%q, got %s=%q", test.expr, test.attribute, test.value, f.attribute, f.value)
+		}
+	}
+
+	f, err := parseFilter("")
+	if err != nil || f != nil {
+		t.Errorf("expected no filter for an empty expression, got %+v, %v", f, err)
+	}
+
+	for _, invalid := range []string{`userName`, `userName sw "john"`, `userName eq "john`} {
+		if _, err := parseFilter(invalid); err == nil {
+			t.Errorf("expected an error for filter %q", invalid)
+		}
+	}
+}
+
+func TestFilterUserIdentityFilter(t *testing.T) {
+	scim := func(filter types.PrincipalIdentityFilter) *types.PrincipalIdentityFilter {
+		filter.Provider = enum.IdentityProviderSCIM
+		return &filter
+	}
+
+	tests := map[string]*types.PrincipalIdentityFilter{
+		``:                                   scim(types.PrincipalIdentityFilter{}),
+		`userName eq "john@example.com"`:     scim(types.PrincipalIdentityFilter{Subject: "john@example.com"}),
+		`externalid eq "00u1"`:               scim(types.PrincipalIdentityFilter{ExternalID: "00u1"}),
+		`emails eq "john.doe@example.com"`:   scim(types.PrincipalIdentityFilter{Email: "john.doe@example.com"}),
+		`emails.value eq "john@example.com"`: scim(types.PrincipalIdentityFilter{Email: "john@example.com"}),
+		`displayName eq "John Doe"`:          scim(types.PrincipalIdentityFilter{DisplayName: "John Doe"}),
+		`id eq "42"`:                         scim(types.PrincipalIdentityFilter{PrincipalID: 42}),
+		`id eq "john"`:                       nil,
+		`userName eq ""`:                     nil,
+		`title eq "developer"`:               nil,
+	}
+
+	for expr, expected := range tests {
+		f, err := parseFilter(expr)
+		if err != nil {
+			t.Fatalf("unexpected error for filter %q: %s", expr, err)
+		}
+
+		identityFilter, ok := f.userIdentityFilter()
+		if ok != (expected != nil) {
+			t.Errorf("filter %q: expected satisfiable %t, got %t", expr, expected != nil, ok)
+			continue
+		}
+
+		if !reflect.DeepEqual(expected, identityFilter) {
+			t.Errorf("filter %q: expected %+v, got %+v", expr, expected, identityFilter)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/rolemapping"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+// findGroup returns the user group with the SCIM ID defined in the space.
+func (c *Controller) findGroup(ctx context.Context, spaceID int64, id string) (*types.UserGroup, error) {
+	groupID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := c.userGroupStore.Find(ctx, groupID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) || (err == nil && group.SpaceID != spaceID) {
+		return nil, usererror.NotFoundf("Group %q not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	return group, nil
+}
+
+// saveGroup updates the name and the members of the user group with the provided SCIM representation,
+// and syncs the space memberships of the members that were added or removed.
+// It should be called in a transaction.
+func (c *Controller) saveGroup(
+	ctx context.Context,
+	spaceID int64,
+	createdBy int64,
+	group *types.UserGroup,
+	in *Group,
+) ([]int64, error) {
+	displayName := strings.TrimSpace(in.DisplayName)
+	if displayName == "" {
+		return nil, usererror.BadRequest("The displayName attribute is required")
+	}
+
+	desired := make([]int64, 0, len(in.Members))
+	for _, member := range in.Members {
+		principalID, err := strconv.ParseInt(member.Value, 10, 64)
+		if err != nil {
+			return nil, usererror.BadRequestf("Member %q not found", member.Value)
+		}
+
+		_, err = c.principalStore.FindUser(ctx, principalID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, usererror.BadRequestf("Member %q not found", member.Value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find member: %w", err)
+		}
+
+		if !slices.Contains(desired, principalID) {
+			desired = append(desired, principalID)
+		}
+	}
+
+	members, err := c.userGroupMemberStore.MapPrincipalIDs(ctx, []int64{group.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of user group: %w", err)
+	}
+
+	current := members[group.ID]
+	now := time.Now().UnixMilli()
+
+	var affected []int64
+
+	if group.Name != displayName {
+		group.Name = displayName
+		group.Updated = now
+
+		if err = c.userGroupStore.Update(ctx, group); err != nil {
+			return nil, fmt.Errorf("failed to update user group: %w", err)
+		}
+
+		// the roles are mapped by the group name, all members are affected by a rename.
+		affected = append(affected, current...)
+	}
+
+	for _, principalID := range desired {
+		if slices.Contains(current, principalID) {
+			continue
+		}
+
+		err = c.userGroupMemberStore.Create(ctx, &types.UserGroupMember{
+			UserGroupID: group.ID,
+			PrincipalID: principalID,
+			CreatedBy:   createdBy,
+			Created:     now,
+		})
+		if err != nil && !errors.Is(err, gitness_store.ErrDuplicate) {
+			return nil, fmt.Errorf("failed to add member to user group: %w", err)
+		}
+
+		affected = append(affected, principalID)
+	}
+
+	for _, principalID := range current {
+		if slices.Contains(desired, principalID) {
+			continue
+		}
+
+		err = c.userGroupMemberStore.Delete(ctx, group.ID, principalID)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to remove member from user group: %w", err)
+		}
+
+		if !slices.Contains(affected, principalID) {
+			affected = append(affected, principalID)
+		}
+	}
+
+	if err = c.syncRoles(ctx, spaceID, createdBy, affected); err != nil {
+		return nil, err
+	}
+
+	return desired, nil
+}
+
+// syncRoles sets the memberships of the principals in the mapped spaces
+// based on the user groups of the SCIM space they are members of.
+func (c *Controller) syncRoles(ctx context.Context, spaceID int64, createdBy int64, principalIDs []int64) error {
+	if len(c.config.RoleMappings) == 0 || len(principalIDs) == 0 {
+		return nil
+	}
+
+	spaces, err := rolemapping.ResolveSpaces(ctx, c.spaceFinder, c.config.RoleMappings)
+	if err != nil {
+		return err
+	}
+
+	principalGroupIDs, err := c.userGroupMemberStore.MapUserGroupIDs(ctx, principalIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list user groups of members: %w", err)
+	}
+
+	var groupIDs []int64
+	for _, ids := range principalGroupIDs {
+		groupIDs = append(groupIDs, ids...)
+	}
+
+	groups, err := c.userGroupStore.FindManyByIDs(ctx, groupIDs)
+	if err != nil {
+		return fmt.Errorf("failed to find user groups of members: %w", err)
+	}
+
+	for _, principalID := range principalIDs {
+		var groupNames []string
+		for _, groupID := range principalGroupIDs[principalID] {
+			if group, ok := groups[groupID]; ok && group.SpaceID == spaceID {
+				groupNames = append(groupNames, group.Name)
+			}
+		}
+
+		roles := rolemapping.SpaceRoles(c.config.RoleMappings, groupNames)
+		for spacePath, space := range spaces {
+			err = rolemapping.SyncMembership(ctx, c.membershipStore, space.ID, principalID, roles[spacePath], createdBy)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// groupIdentifier returns the identifier of the user group the SCIM group is provisioned into.
+func groupIdentifier(displayName string) string {
+	identifier := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(displayName))
+
+	if len(identifier) > check.MaxIdentifierLength {
+		identifier = identifier[:check.MaxIdentifierLength]
+	}
+
+	return identifier
+}
+
+func toGroup(group *types.UserGroup, memberIDs []int64) *Group {
+	members := make([]Reference, len(memberIDs))
+	for i, memberID := range memberIDs {
+		members[i] = Reference{Value: strconv.FormatInt(memberID, 10)}
+	}
+
+	return &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          strconv.FormatInt(group.ID, 10),
+		DisplayName: group.Name,
+		Members:     members,
+		Meta: &Meta{
+			ResourceType: ResourceTypeGroup,
+			Created:      formatTime(group.Created),
+			LastModified: formatTime(group.Updated),
+		},
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+// CreateGroup provisions a new user group in the SCIM space.
+// The members of the group are granted the roles mapped to the group.
+func (c *Controller) CreateGroup(ctx context.Context, session *auth.Session, in *Group) (*Group, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := strings.TrimSpace(in.DisplayName)
+	if displayName == "" {
+		return nil, usererror.BadRequest("The displayName attribute is required")
+	}
+
+	identifier := groupIdentifier(displayName)
+	if err = check.Identifier(identifier); err != nil {
+		return nil, usererror.BadRequestf("The displayName %q can't be used as a user group identifier", displayName)
+	}
+
+	_, err = c.userGroupStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err == nil {
+		return nil, usererror.Conflict("A group with the same displayName already exists")
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	group := &types.UserGroup{
+		Identifier:  identifier,
+		Name:        displayName,
+		Description: "Provisioned with SCIM",
+		SpaceID:     space.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	var memberIDs []int64
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.userGroupStore.Create(ctx, space.ID, group); err != nil {
+			return fmt.Errorf("failed to create user group: %w", err)
+		}
+
+		memberIDs, err = c.saveGroup(ctx, space.ID, session.Principal.ID, group, in)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toGroup(group, memberIDs), nil
+}
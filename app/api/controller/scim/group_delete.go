@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+// DeleteGroup deletes the user group of the SCIM space.
+// The former members lose the roles that were mapped to the group.
+func (c *Controller) DeleteGroup(ctx context.Context, session *auth.Session, id string) error {
+	space, err := c.getSpaceCheckAuth(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	group, err := c.findGroup(ctx, space.ID, id)
+	if err != nil {
+		return err
+	}
+
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		members, err := c.userGroupMemberStore.MapPrincipalIDs(ctx, []int64{group.ID})
+		if err != nil {
+			return fmt.Errorf("failed to list members of user group: %w", err)
+		}
+
+		if err = c.userGroupStore.Delete(ctx, group.ID); err != nil {
+			return fmt.Errorf("failed to delete user group: %w", err)
+		}
+
+		return c.syncRoles(ctx, space.ID, session.Principal.ID, members[group.ID])
+	})
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+// FindGroup returns the user group of the SCIM space.
+func (c *Controller) FindGroup(ctx context.Context, session *auth.Session, id string) (*Group, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := c.findGroup(ctx, space.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := c.userGroupMemberStore.MapPrincipalIDs(ctx, []int64{group.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of user group: %w", err)
+	}
+
+	return toGroup(group, members[group.ID]), nil
+}
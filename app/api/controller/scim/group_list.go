@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+const listGroupsPageSize = 100
+
+// ListGroups lists the user groups of the SCIM space that satisfy the filter.
+func (c *Controller) ListGroups(
+	ctx context.Context,
+	session *auth.Session,
+	params ListParams,
+) (*GroupListResponse, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := parseFilter(params.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var userGroups []*types.UserGroup
+	for page := 1; ; page++ {
+		userGroupsPage, err := c.userGroupStore.List(ctx, []int64{space.ID}, &types.ListQueryFilter{
+			Pagination: types.Pagination{Page: page, Size: listGroupsPageSize},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list user groups: %w", err)
+		}
+
+		userGroups = append(userGroups, userGroupsPage...)
+
+		if len(userGroupsPage) < listGroupsPageSize {
+			break
+		}
+	}
+
+	groupIDs := make([]int64, len(userGroups))
+	for i, userGroup := range userGroups {
+		groupIDs[i] = userGroup.ID
+	}
+
+	members, err := c.userGroupMemberStore.MapPrincipalIDs(ctx, groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of user groups: %w", err)
+	}
+
+	groups := make([]*Group, 0, len(userGroups))
+	for _, userGroup := range userGroups {
+		if group := toGroup(userGroup, members[userGroup.ID]); f.matchGroup(group) {
+			groups = append(groups, group)
+		}
+	}
+
+	list, page := paginate(groups, params)
+
+	return &GroupListResponse{
+		ListResponse: list,
+		Resources:    page,
+	}, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+// ReplaceGroup replaces the name and the members of the user group of the SCIM space.
+func (c *Controller) ReplaceGroup(ctx context.Context, session *auth.Session, id string, in *Group) (*Group, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := c.findGroup(ctx, space.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberIDs []int64
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		memberIDs, err = c.saveGroup(ctx, space.ID, session.Principal.ID, group, in)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toGroup(group, memberIDs), nil
+}
+
+// PatchGroup applies the patch operations to the user group of the SCIM space.
+func (c *Controller) PatchGroup(
+	ctx context.Context,
+	session *auth.Session,
+	id string,
+	in *PatchRequest,
+) (*Group, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := c.findGroup(ctx, space.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberIDs []int64
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		members, err := c.userGroupMemberStore.MapPrincipalIDs(ctx, []int64{group.ID})
+		if err != nil {
+			return fmt.Errorf("failed to list members of user group: %w", err)
+		}
+
+		scimGroup := toGroup(group, members[group.ID])
+		if err = applyGroupPatch(scimGroup, in.Operations); err != nil {
+			return err
+		}
+
+		memberIDs, err = c.saveGroup(ctx, space.ID, session.Principal.ID, group, scimGroup)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toGroup(group, memberIDs), nil
+}
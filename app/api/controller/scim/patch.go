@@ -0,0 +1,280 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	patchOpAdd     = "add"
+	patchOpReplace = "replace"
+	patchOpRemove  = "remove"
+)
+
+// applyUserPatch applies the patch operations to the user.
+// Attributes that aren't stored (e.g. the enterprise extension attributes) are ignored.
+func applyUserPatch(user *User, ops []PatchOperation) error {
+	for _, op := range ops {
+		opName := strings.ToLower(op.Op)
+		path := trimSchema(op.Path, SchemaUser)
+
+		switch opName {
+		case patchOpAdd, patchOpReplace:
+			if path != "" {
+				if err := setUserAttribute(user, path, op.Value); err != nil {
+					return err
+				}
+				continue
+			}
+
+			values, err := decodeObject(op.Value)
+			if err != nil {
+				return err
+			}
+
+			for attribute, value := range values {
+				if err = setUserAttribute(user, trimSchema(attribute, SchemaUser), value); err != nil {
+					return err
+				}
+			}
+		case patchOpRemove:
+			removeUserAttribute(user, path)
+		default:
+			return usererror.BadRequestf("Unsupported patch operation %q", op.Op)
+		}
+	}
+
+	return nil
+}
+
+func setUserAttribute(user *User, path string, raw json.RawMessage) error {
+	var err error
+
+	switch strings.ToLower(path) {
+	case "active":
+		var active bool
+		active, err = decodeBool(raw)
+		user.Active = &active
+	case "username":
+		user.UserName, err = decodeString(raw)
+	case "externalid":
+		user.ExternalID, err = decodeString(raw)
+	case "displayname":
+		user.DisplayName, err = decodeString(raw)
+	case "name":
+		user.Name = &Name{}
+		if err = json.Unmarshal(raw, user.Name); err != nil {
+			err = usererror.BadRequest("Invalid value of the name attribute")
+		}
+	case "name.formatted":
+		user.Name = ensureName(user.Name)
+		user.Name.Formatted, err = decodeString(raw)
+	case "name.givenname":
+		user.Name = ensureName(user.Name)
+		user.Name.GivenName, err = decodeString(raw)
+	case "name.familyname":
+		user.Name = ensureName(user.Name)
+		user.Name.FamilyName, err = decodeString(raw)
+	case "emails":
+		var emails []Email
+		if err = json.Unmarshal(raw, &emails); err != nil {
+			return usererror.BadRequest("Invalid value of the emails attribute")
+		}
+		user.Emails = emails
+	default:
+		// e.g. emails[type eq "work"].value
+		if lower := strings.ToLower(path); strings.HasPrefix(lower, "emails[") && strings.HasSuffix(lower, "].value") {
+			var email string
+			if email, err = decodeString(raw); err == nil {
+				user.Emails = []Email{{Value: email, Type: "work", Primary: true}}
+			}
+		}
+	}
+
+	return err
+}
+
+func removeUserAttribute(user *User, path string) {
+	switch strings.ToLower(path) {
+	case "externalid":
+		user.ExternalID = ""
+	case "displayname":
+		user.DisplayName = ""
+	case "name":
+		user.Name = nil
+	case "name.formatted":
+		user.Name = ensureName(user.Name)
+		user.Name.Formatted = ""
+	case "name.givenname":
+		user.Name = ensureName(user.Name)
+		user.Name.GivenName = ""
+	case "name.familyname":
+		user.Name = ensureName(user.Name)
+		user.Name.FamilyName = ""
+	}
+}
+
+// applyGroupPatch applies the patch operations to the group.
+func applyGroupPatch(group *Group, ops []PatchOperation) error {
+	for _, op := range ops {
+		opName := strings.ToLower(op.Op)
+		path := trimSchema(op.Path, SchemaGroup)
+
+		switch {
+		case opName != patchOpAdd && opName != patchOpReplace && opName != patchOpRemove:
+			return usererror.BadRequestf("Unsupported patch operation %q", op.Op)
+		case path == "" && opName == patchOpRemove:
+			return usererror.BadRequest("Remove patch operation requires a path")
+		case path == "":
+			values, err := decodeObject(op.Value)
+			if err != nil {
+				return err
+			}
+
+			for attribute, value := range values {
+				if err = setGroupAttribute(group, opName, trimSchema(attribute, SchemaGroup), value); err != nil {
+					return err
+				}
+			}
+		case strings.HasPrefix(strings.ToLower(path), "members["):
+			// e.g. members[value eq "42"]
+			if opName != patchOpRemove {
+				return usererror.BadRequestf("Unsupported patch operation %q on path %q", op.Op, op.Path)
+			}
+
+			f, err := parseFilter(strings.TrimSuffix(path[len("members["):], "]"))
+			if err != nil {
+				return err
+			}
+
+			group.Members = slices.DeleteFunc(group.Members, func(m Reference) bool {
+				return f != nil && f.matches([]string{"value"}, m.Value)
+			})
+		default:
+			if err := setGroupAttribute(group, opName, path, op.Value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func setGroupAttribute(group *Group, opName string, path string, raw json.RawMessage) error {
+	var err error
+
+	switch strings.ToLower(path) {
+	case "displayname":
+		if opName != patchOpRemove {
+			group.DisplayName, err = decodeString(raw)
+		}
+	case "externalid":
+		if opName != patchOpRemove {
+			group.ExternalID, err = decodeString(raw)
+		}
+	case "members":
+		var members []Reference
+		if len(raw) > 0 {
+			if err = json.Unmarshal(raw, &members); err != nil {
+				return usererror.BadRequest("Invalid value of the members attribute")
+			}
+		}
+
+		switch opName {
+		case patchOpAdd:
+			for _, member := range members {
+				if !slices.ContainsFunc(group.Members, func(m Reference) bool { return m.Value == member.Value }) {
+					group.Members = append(group.Members, member)
+				}
+			}
+		case patchOpReplace:
+			group.Members = members
+		case patchOpRemove:
+			if len(members) == 0 {
+				group.Members = nil
+				break
+			}
+
+			group.Members = slices.DeleteFunc(group.Members, func(m Reference) bool {
+				return slices.ContainsFunc(members, func(r Reference) bool { return r.Value == m.Value })
+			})
+		}
+	}
+
+	return err
+}
+
+// trimSchema removes the schema URN prefix from the attribute path, e.g. the path
+// "urn:ietf:params:scim:schemas:core:2.0:User:userName" becomes "userName".
+func trimSchema(path, schema string) string {
+	path = strings.TrimSpace(path)
+	if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+		return path[len(schema)+1:]
+	}
+
+	return path
+}
+
+func ensureName(name *Name) *Name {
+	if name == nil {
+		return &Name{}
+	}
+
+	return name
+}
+
+func decodeObject(raw json.RawMessage) (map[string]json.RawMessage, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, usererror.BadRequest("Patch operation without a path requires an object value")
+	}
+
+	return values, nil
+}
+
+func decodeString(raw json.RawMessage) (string, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", usererror.BadRequest("Invalid patch value, expected a string")
+	}
+
+	return value, nil
+}
+
+// decodeBool decodes a boolean value, some identity providers send booleans as strings (e.g. "False").
+func decodeBool(raw json.RawMessage) (bool, error) {
+	var value bool
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, nil
+	}
+
+	str, err := decodeString(raw)
+	if err != nil {
+		return false, usererror.BadRequest("Invalid patch value, expected a boolean")
+	}
+
+	value, err = strconv.ParseBool(str)
+	if err != nil {
+		return false, usererror.BadRequest("Invalid patch value, expected a boolean")
+	}
+
+	return value, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestApplyUserPatch(t *testing.T) {
+	active := true
+	user := &User{
+		UserName: "john@example.com",
+		Emails:   []Email{{Value: "john@example.com", Primary: true}},
+		Active:   &active,
+	}
+
+	var in PatchRequest
+	err := json.Unmarshal([]byte(`{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "Replace", "path": "active", "value": "False"},
+			{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "jdoe@example.com"},
+			{"op": "add", "path": "urn:ietf:params:scim:schemas:core:2.0:User:name.givenName", "value": "John"},
+			{"op": "replace", "value": {"displayName": "John Doe", "externalId": "00u1"}},
+			{"op": "add", "path": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", "value": "R&D"}
+		]
+	}`), &in)
+	if err != nil {
+		t.Fatalf("failed to decode patch request: %s", err)
+	}
+
+	if err = applyUserPatch(user, in.Operations); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if user.IsActive() {
+		t.Errorf("expected the user to be deactivated")
+	}
+
+	if email := user.PrimaryEmail(); email != "jdoe@example.com" {
+		t.Errorf("expected primary email %q, got %q", "jdoe@example.com", email)
+	}
+
+	if user.Name == nil || user.Name.GivenName != "John" {
+		t.Errorf("expected given name %q, got %+v", "John", user.Name)
+	}
+
+	if user.DisplayName != "John Doe" || user.ExternalID != "00u1" {
+		t.Errorf("expected display name and external id to be replaced, got %q and %q",
+			user.DisplayName, user.ExternalID)
+	}
+
+	err = applyUserPatch(user, []PatchOperation{{Op: "move", Path: "active"}})
+	if err == nil {
+		t.Errorf("expected an error for an unsupported operation")
+	}
+}
+
+func TestApplyGroupPatch(t *testing.T) {
+	group := &Group{
+		DisplayName: "developers",
+		Members:     []Reference{{Value: "1"}, {Value: "2"}},
+	}
+
+	var in PatchRequest
+	err := json.Unmarshal([]byte(`{
+		"Operations": [
+			{"op": "add", "path": "members", "value": [{"value": "2"}, {"value": "3"}]},
+			{"op": "remove", "path": "members[value eq \"1\"]"},
+			{"op": "replace", "value": {"displayName": "engineering"}}
+		]
+	}`), &in)
+	if err != nil {
+		t.Fatalf("failed to decode patch request: %s", err)
+	}
+
+	if err = applyGroupPatch(group, in.Operations); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &Group{
+		DisplayName: "engineering",
+		Members:     []Reference{{Value: "2"}, {Value: "3"}},
+	}
+	if !reflect.DeepEqual(expected, group) {
+		t.Errorf("expected %+v, got %+v", expected, group)
+	}
+
+	err = applyGroupPatch(group, []PatchOperation{
+		{Op: "remove", Path: "members", Value: json.RawMessage(`[{"value": "3"}]`)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual([]Reference{{Value: "2"}}, group.Members) {
+		t.Errorf("expected only member 2 to remain, got %+v", group.Members)
+	}
+
+	if err = applyGroupPatch(group, []PatchOperation{{Op: "remove", Path: "members"}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(group.Members) != 0 {
+		t.Errorf("expected all members to be removed, got %+v", group.Members)
+	}
+}
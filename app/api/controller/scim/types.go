@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	ResourceTypeUser  = "User"
+	ResourceTypeGroup = "Group"
+)
+
+// Meta contains the metadata of a SCIM resource.
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// Name contains the components of the name of a user.
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// Email is an email address of a user.
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Reference references another SCIM resource, e.g. a member of a group.
+type Reference struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// User is the SCIM representation of a user.
+type User struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	UserName    string   `json:"userName"`
+	Name        *Name    `json:"name,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Emails      []Email  `json:"emails,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// PrimaryEmail returns the primary email of the user, or the first one if none is marked as primary.
+func (u *User) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return strings.TrimSpace(email.Value)
+		}
+	}
+
+	if len(u.Emails) > 0 {
+		return strings.TrimSpace(u.Emails[0].Value)
+	}
+
+	return ""
+}
+
+// FullName returns the display name of the user, falling back to the name and the user name.
+func (u *User) FullName() string {
+	if displayName := strings.TrimSpace(u.DisplayName); displayName != "" {
+		return displayName
+	}
+
+	if u.Name != nil {
+		if formatted := strings.TrimSpace(u.Name.Formatted); formatted != "" {
+			return formatted
+		}
+
+		if fullName := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); fullName != "" {
+			return fullName
+		}
+	}
+
+	return strings.TrimSpace(u.UserName)
+}
+
+// IsActive returns whether the user is active, users are active unless stated otherwise.
+func (u *User) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// Group is the SCIM representation of a user group.
+// The external ID of groups isn't persisted.
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []Reference `json:"members"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+// ListResponse contains the pagination of the result of a SCIM query.
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+}
+
+// UserListResponse is the result of a SCIM query of users.
+type UserListResponse struct {
+	ListResponse
+	Resources []*User `json:"Resources"`
+}
+
+// GroupListResponse is the result of a SCIM query of groups.
+type GroupListResponse struct {
+	ListResponse
+	Resources []*Group `json:"Resources"`
+}
+
+// ListParams are the parameters of a SCIM query.
+type ListParams struct {
+	Filter string
+	// StartIndex is the 1-based index of the first result.
+	StartIndex int
+	Count      int
+}
+
+// PatchRequest is a SCIM patch request.
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is a single operation of a SCIM patch request.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Error is the SCIM representation of an error.
+type Error struct {
+	Schemas []string `json:"schemas"`
+	Status  string   `json:"status"`
+	Detail  string   `json:"detail,omitempty"`
+}
@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// findUser returns the user with the SCIM ID and its SCIM identity.
+// Users that aren't provisioned with SCIM aren't visible to the identity provider.
+func (c *Controller) findUser(ctx context.Context, id string) (*types.User, *types.PrincipalIdentity, error) {
+	principalID, err := parseID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	identities, err := c.identityStore.ListByPrincipal(ctx, principalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list identities of user: %w", err)
+	}
+
+	for _, identity := range identities {
+		if identity.Provider != enum.IdentityProviderSCIM {
+			continue
+		}
+
+		user, err := c.principalStore.FindUser(ctx, principalID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find user: %w", err)
+		}
+
+		return user, identity, nil
+	}
+
+	return nil, nil, usererror.NotFoundf("User %q not found", id)
+}
+
+// saveUser updates the user and its SCIM identity with the provided SCIM representation.
+// Only the users provisioned with SCIM can be modified, users linked by email are read only.
+func (c *Controller) saveUser(
+	ctx context.Context,
+	user *types.User,
+	identity *types.PrincipalIdentity,
+	in *User,
+) error {
+	if !identity.Provisioned {
+		return usererror.Forbidden("The user wasn't provisioned with SCIM and can't be modified")
+	}
+
+	if err := sanitizeUser(in); err != nil {
+		return err
+	}
+
+	if !in.IsActive() && !user.Blocked {
+		if err := c.checkNotOnlyAdmin(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UnixMilli()
+
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if identity.Subject != in.UserName {
+			_, err := c.identityStore.Find(ctx, enum.IdentityProviderSCIM, in.UserName)
+			if err == nil {
+				return usererror.Conflict("A user with the same userName already exists")
+			}
+			if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return fmt.Errorf("failed to find identity: %w", err)
+			}
+		}
+
+		email := in.PrimaryEmail()
+
+		if identity.Subject != in.UserName || identity.ExternalID != in.ExternalID || identity.Email != email {
+			identity.Subject = in.UserName
+			identity.ExternalID = in.ExternalID
+			identity.Email = email
+			identity.Updated = now
+
+			if err := c.identityStore.Update(ctx, identity); err != nil {
+				return fmt.Errorf("failed to update identity: %w", err)
+			}
+		}
+
+		if user.Email == email && user.DisplayName == in.FullName() && user.Blocked == !in.IsActive() {
+			return nil
+		}
+
+		user.Email = email
+		user.DisplayName = in.FullName()
+		user.Blocked = !in.IsActive()
+		user.Updated = now
+
+		if err := c.principalStore.UpdateUser(ctx, user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// checkNotOnlyAdmin prevents blocking the only admin user.
+func (c *Controller) checkNotOnlyAdmin(ctx context.Context, user *types.User) error {
+	if !user.Admin {
+		return nil
+	}
+
+	admUsrCount, err := c.principalStore.CountUsers(ctx, &types.UserFilter{Admin: true})
+	if err != nil {
+		return fmt.Errorf("failed to check admin user count: %w", err)
+	}
+
+	if admUsrCount <= 1 {
+		log.Ctx(ctx).Warn().Msgf("scim request to block the only admin user %q rejected", user.UID)
+		return usererror.BadRequest("The only admin user can't be deactivated")
+	}
+
+	return nil
+}
+
+func sanitizeUser(in *User) error {
+	in.UserName = strings.TrimSpace(in.UserName)
+	in.ExternalID = strings.TrimSpace(in.ExternalID)
+
+	if in.UserName == "" {
+		return usererror.BadRequest("The userName attribute is required")
+	}
+
+	if err := check.Email(in.PrimaryEmail()); err != nil {
+		return err
+	}
+
+	if err := check.DisplayName(in.FullName()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func toUser(user *types.User, identity *types.PrincipalIdentity) *User {
+	active := !user.Blocked
+
+	return &User{
+		Schemas:     []string{SchemaUser},
+		ID:          strconv.FormatInt(user.ID, 10),
+		ExternalID:  identity.ExternalID,
+		UserName:    identity.Subject,
+		Name:        &Name{Formatted: user.DisplayName},
+		DisplayName: user.DisplayName,
+		Emails:      []Email{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: ResourceTypeUser,
+			Created:      formatTime(user.Created),
+			LastModified: formatTime(user.Updated),
+		},
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	controlleruser "github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CreateUser provisions a new user. If linking by email is enabled, an existing (non admin) user
+// with the same email is linked instead.
+func (c *Controller) CreateUser(ctx context.Context, session *auth.Session, in *User) (*User, error) {
+	if _, err := c.getSpaceCheckAuth(ctx, session); err != nil {
+		return nil, err
+	}
+
+	if err := sanitizeUser(in); err != nil {
+		return nil, err
+	}
+
+	_, err := c.identityStore.Find(ctx, enum.IdentityProviderSCIM, in.UserName)
+	if err == nil {
+		return nil, usererror.Conflict("A user with the same userName already exists")
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find identity: %w", err)
+	}
+
+	username, _, _ := strings.Cut(in.UserName, "@")
+
+	user, err := c.userCtrl.FindOrProvisionExternalUser(ctx, controlleruser.ExternalIdentity{
+		Provider:   enum.IdentityProviderSCIM,
+		Subject:    in.UserName,
+		ExternalID: in.ExternalID,
+		Email:      in.PrimaryEmail(),
+		// the identity provider is trusted to provide verified emails.
+		EmailVerified: true,
+		Username:      username,
+		Name:          in.FullName(),
+	}, c.config.LinkByEmail, true)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := c.identityStore.Find(ctx, enum.IdentityProviderSCIM, in.UserName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find identity of provisioned user: %w", err)
+	}
+
+	// the provisioned user might have been created inactive. Users linked by email are left as they are.
+	if identity.Provisioned {
+		if err = c.saveUser(ctx, user, identity, in); err != nil {
+			return nil, err
+		}
+	}
+
+	return toUser(user, identity), nil
+}
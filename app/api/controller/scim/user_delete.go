@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+// DeleteUser deprovisions the user. The user is blocked rather than deleted,
+// to keep the ownership of its resources and the history of its activities.
+func (c *Controller) DeleteUser(ctx context.Context, session *auth.Session, id string) error {
+	if _, err := c.getSpaceCheckAuth(ctx, session); err != nil {
+		return err
+	}
+
+	user, identity, err := c.findUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	scimUser := toUser(user, identity)
+	active := false
+	scimUser.Active = &active
+
+	return c.saveUser(ctx, user, identity, scimUser)
+}
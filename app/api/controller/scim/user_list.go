@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// ListUsers lists the users provisioned with SCIM that satisfy the filter.
+func (c *Controller) ListUsers(
+	ctx context.Context,
+	session *auth.Session,
+	params ListParams,
+) (*UserListResponse, error) {
+	if _, err := c.getSpaceCheckAuth(ctx, session); err != nil {
+		return nil, err
+	}
+
+	f, err := parseFilter(params.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	startIndex := max(params.StartIndex, 1)
+
+	identityFilter, ok := f.userIdentityFilter()
+	if !ok {
+		return &UserListResponse{
+			ListResponse: newListResponse(0, startIndex, 0),
+			Resources:    []*User{},
+		}, nil
+	}
+
+	total, err := c.identityStore.Count(ctx, identityFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count scim identities: %w", err)
+	}
+
+	users := []*User{}
+	if params.Count != 0 && int64(startIndex) <= total {
+		identityFilter.Offset = startIndex - 1
+		identityFilter.Limit = max(params.Count, 0)
+
+		users, err = c.listUsers(ctx, identityFilter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &UserListResponse{
+		ListResponse: newListResponse(int(total), startIndex, len(users)),
+		Resources:    users,
+	}, nil
+}
+
+// listUsers returns the SCIM users of the identities that match the filter.
+func (c *Controller) listUsers(ctx context.Context, filter *types.PrincipalIdentityFilter) ([]*User, error) {
+	identities, err := c.identityStore.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scim identities: %w", err)
+	}
+
+	principalIDs := make([]int64, len(identities))
+	for i, identity := range identities {
+		principalIDs[i] = identity.PrincipalID
+	}
+
+	principals, err := c.principalStore.FindManyUsersByID(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	usersByID := make(map[int64]*types.User, len(principals))
+	for _, user := range principals {
+		usersByID[user.ID] = user
+	}
+
+	users := make([]*User, 0, len(identities))
+	for _, identity := range identities {
+		if user, ok := usersByID[identity.PrincipalID]; ok {
+			users = append(users, toUser(user, identity))
+		}
+	}
+
+	return users, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+// ReplaceUser replaces the attributes of the user provisioned with SCIM.
+// Deactivated users are blocked.
+func (c *Controller) ReplaceUser(ctx context.Context, session *auth.Session, id string, in *User) (*User, error) {
+	if _, err := c.getSpaceCheckAuth(ctx, session); err != nil {
+		return nil, err
+	}
+
+	user, identity, err := c.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.saveUser(ctx, user, identity, in); err != nil {
+		return nil, err
+	}
+
+	return toUser(user, identity), nil
+}
+
+// PatchUser applies the patch operations to the user provisioned with SCIM.
+// Deactivated users are blocked.
+func (c *Controller) PatchUser(
+	ctx context.Context,
+	session *auth.Session,
+	id string,
+	in *PatchRequest,
+) (*User, error) {
+	if _, err := c.getSpaceCheckAuth(ctx, session); err != nil {
+		return nil, err
+	}
+
+	user, identity, err := c.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	scimUser := toUser(user, identity)
+	if err = applyUserPatch(scimUser, in.Operations); err != nil {
+		return nil, err
+	}
+
+	if err = c.saveUser(ctx, user, identity, scimUser); err != nil {
+		return nil, err
+	}
+
+	return toUser(user, identity), nil
+}
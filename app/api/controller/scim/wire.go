@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"errors"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/auth/rolemapping"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideController,
+)
+
+func ProvideController(
+	config *types.Config,
+	tx dbtx.Transactor,
+	principalStore store.PrincipalStore,
+	identityStore store.PrincipalIdentityStore,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	membershipStore store.MembershipStore,
+	spaceFinder refcache.SpaceFinder,
+	userCtrl *user.Controller,
+) (*Controller, error) {
+	if config.SCIM.Enabled && config.SCIM.ServiceAccountUID == "" {
+		return nil, errors.New("scim service account uid is required when scim provisioning is enabled")
+	}
+
+	roleMappings, err := rolemapping.Parse(config.SCIM.RoleMappings)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewController(
+		Config{
+			Enabled:           config.SCIM.Enabled,
+			ServiceAccountUID: config.SCIM.ServiceAccountUID,
+			RoleMappings:      roleMappings,
+			LinkByEmail:       config.SCIM.LinkByEmail,
+		},
+		tx,
+		principalStore,
+		identityStore,
+		userGroupStore,
+		userGroupMemberStore,
+		membershipStore,
+		spaceFinder,
+		userCtrl,
+	), nil
+}
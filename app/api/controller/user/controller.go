@@ -18,6 +18,8 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
 	userevents "github.com/harness/gitness/app/events/user"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
@@ -42,6 +44,10 @@ type Controller struct {
 	eventReporter           *userevents.Reporter
 	repoFinder              refcache.RepoFinder
 	favoriteStore           store.FavoriteStore
+	principalIdentityStore  store.PrincipalIdentityStore
+	spaceFinder             refcache.SpaceFinder
+	oidcProvider            *oidc.Provider
+	ldapClient              *ldap.Client
 }
 
 func NewController(
@@ -57,6 +63,10 @@ func NewController(
 	eventReporter *userevents.Reporter,
 	repoFinder refcache.RepoFinder,
 	favoriteStore store.FavoriteStore,
+	principalIdentityStore store.PrincipalIdentityStore,
+	spaceFinder refcache.SpaceFinder,
+	oidcProvider *oidc.Provider,
+	ldapClient *ldap.Client,
 ) *Controller {
 	return &Controller{
 		tx:                      tx,
@@ -71,6 +81,10 @@ func NewController(
 		eventReporter:           eventReporter,
 		repoFinder:              repoFinder,
 		favoriteStore:           favoriteStore,
+		principalIdentityStore:  principalIdentityStore,
+		spaceFinder:             spaceFinder,
+		oidcProvider:            oidcProvider,
+		ldapClient:              ldapClient,
 	}
 }
 
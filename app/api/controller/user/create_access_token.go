@@ -17,9 +17,11 @@ package user
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
@@ -32,6 +34,9 @@ type CreateTokenInput struct {
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+	// Scope optionally restricts the token to a subset of permissions, spaces, repositories and IP ranges.
+	// Spaces and repositories are provided as references (path or ID).
+	Scope *types.TokenScope `json:"scope"`
 }
 
 /*
@@ -57,6 +62,15 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
+	// a restricted token must not be usable to issue tokens without the restrictions.
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok && tokenMetadata.Scope != nil {
+		return nil, usererror.Forbidden("Tokens can't be created using a scoped token.")
+	}
+
+	if in.Scope, err = c.sanitizeTokenScope(ctx, session, in.Scope); err != nil {
+		return nil, err
+	}
+
 	token, jwtToken, err := token.CreatePAT(
 		ctx,
 		c.tokenStore,
@@ -64,6 +78,7 @@ func (c *Controller) CreateAccessToken(
 		user,
 		in.Identifier,
 		in.Lifetime,
+		in.Scope,
 	)
 	if err != nil {
 		return nil, err
@@ -89,3 +104,75 @@ func (c *Controller) sanitizeCreateTokenInput(in *CreateTokenInput) error {
 
 	return nil
 }
+
+// sanitizeTokenScope validates the token scope and resolves the referenced spaces and repositories.
+// It returns nil in case the scope doesn't impose any restriction.
+func (c *Controller) sanitizeTokenScope(
+	ctx context.Context,
+	session *auth.Session,
+	scope *types.TokenScope,
+) (*types.TokenScope, error) {
+	if scope == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	sanitized := &types.TokenScope{}
+
+	for _, permission := range scope.Permissions {
+		p, ok := permission.Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Invalid permission %q.", permission)
+		}
+		if !slices.Contains(sanitized.Permissions, p) {
+			sanitized.Permissions = append(sanitized.Permissions, p)
+		}
+	}
+
+	for _, spaceRef := range scope.Spaces {
+		space, err := c.spaceFinder.FindByRef(ctx, spaceRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %q: %w", spaceRef, err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView); err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(sanitized.SpaceIDs, space.ID) {
+			sanitized.SpaceIDs = append(sanitized.SpaceIDs, space.ID)
+			sanitized.Spaces = append(sanitized.Spaces, space.Path)
+		}
+	}
+
+	for _, repoRef := range scope.Repos {
+		repo, err := c.repoFinder.FindByRef(ctx, repoRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository %q: %w", repoRef, err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView); err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(sanitized.RepoIDs, repo.ID) {
+			sanitized.RepoIDs = append(sanitized.RepoIDs, repo.ID)
+			sanitized.Repos = append(sanitized.Repos, repo.Path)
+		}
+	}
+
+	for _, ipRange := range scope.IPRanges {
+		prefix, err := token.ParseIPRange(ipRange)
+		if err != nil {
+			return nil, usererror.BadRequestf("Invalid IP range %q.", ipRange)
+		}
+		if !slices.Contains(sanitized.IPRanges, prefix.String()) {
+			sanitized.IPRanges = append(sanitized.IPRanges, prefix.String())
+		}
+	}
+
+	if sanitized.IsEmpty() {
+		return nil, nil //nolint:nilnil
+	}
+
+	return sanitized, nil
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 /*
@@ -43,5 +45,40 @@ func (c *Controller) ListTokens(ctx context.Context, session *auth.Session,
 		return nil, usererror.ErrBadRequest
 	}
 
-	return c.tokenStore.List(ctx, user.ID, tokenType)
+	tokens, err := c.tokenStore.List(ctx, user.ID, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tkn := range tokens {
+		c.populateTokenScopePaths(ctx, tkn.Scope)
+	}
+
+	return tokens, nil
+}
+
+// populateTokenScopePaths fills in the current paths of the spaces and repositories of the token scope.
+// Spaces and repositories that no longer exist are skipped.
+func (c *Controller) populateTokenScopePaths(ctx context.Context, scope *types.TokenScope) {
+	if scope == nil {
+		return
+	}
+
+	for _, spaceID := range scope.SpaceIDs {
+		space, err := c.spaceFinder.FindByID(ctx, spaceID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("space_id", spaceID).Msg("failed to find token scope space")
+			continue
+		}
+		scope.Spaces = append(scope.Spaces, space.Path)
+	}
+
+	for _, repoID := range scope.RepoIDs {
+		repo, err := c.repoFinder.FindByID(ctx, repoID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).Msg("failed to find token scope repository")
+			continue
+		}
+		scope.Repos = append(scope.Repos, repo.Path)
+	}
 }
@@ -40,6 +40,38 @@ func (c *Controller) Login(
 ) (*types.TokenResponse, error) {
 	// no auth check required, password is used for it.
 
+	user, err := c.loginLDAP(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		user, err = c.loginLocal(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if user.Blocked {
+		return nil, usererror.Forbidden("User is blocked")
+	}
+
+	tokenIdentifier := token.GenerateIdentifier("login")
+
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	c.eventReporter.LoggedIn(ctx, &userevents.LoggedInPayload{
+		Base: userevents.Base{PrincipalID: user.ID},
+	})
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+}
+
+// loginLocal authenticates the user with the password stored in the database.
+func (c *Controller) loginLocal(ctx context.Context, in *LoginInput) (*types.User, error) {
 	user, err := findUserFromUID(ctx, c.principalStore, in.LoginIdentifier)
 	if errors.Is(err, store.ErrResourceNotFound) {
 		user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
@@ -52,6 +84,23 @@ func (c *Controller) Login(
 		return nil, usererror.ErrNotFound
 	}
 
+	// the directory is the source of truth for the credentials of its users once LDAP is enabled,
+	// their local password might have been changed or revoked in the directory since.
+	if c.ldapClient != nil {
+		var linked bool
+		linked, err = c.isLinkedToLDAP(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if linked {
+			log.Ctx(ctx).Debug().
+				Str("user_uid", user.UID).
+				Msg("local login of ldap user rejected")
+
+			return nil, usererror.ErrNotFound
+		}
+	}
+
 	err = bcrypt.CompareHashAndPassword(
 		[]byte(user.Password),
 		[]byte(in.Password),
@@ -64,16 +113,5 @@ func (c *Controller) Login(
 		return nil, usererror.ErrNotFound
 	}
 
-	tokenIdentifier := token.GenerateIdentifier("login")
-
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
-	if err != nil {
-		return nil, err
-	}
-
-	c.eventReporter.LoggedIn(ctx, &userevents.LoggedInPayload{
-		Base: userevents.Base{PrincipalID: user.ID},
-	})
-
-	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+	return user, nil
 }
@@ -0,0 +1,279 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/rolemapping"
+	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
+)
+
+// ExternalIdentity is the user information provided by an external identity provider.
+type ExternalIdentity struct {
+	Provider      enum.IdentityProvider
+	Subject       string
+	ExternalID    string
+	Email         string
+	EmailVerified bool
+	Username      string
+	Name          string
+}
+
+// FindOrProvisionExternalUser returns the user linked to the external identity.
+// If there's none, the identity is linked to the (non admin) user with the same verified email,
+// or to a newly created user if auto provisioning is enabled.
+func (c *Controller) FindOrProvisionExternalUser(
+	ctx context.Context,
+	identity ExternalIdentity,
+	linkByEmail bool,
+	autoProvision bool,
+) (*types.User, error) {
+	now := time.Now().UnixMilli()
+
+	var (
+		user        *types.User
+		provisioned bool
+	)
+
+	err := c.tx.WithTx(ctx, func(ctx context.Context) error {
+		linked, err := c.principalIdentityStore.Find(ctx, identity.Provider, identity.Subject)
+		if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to find identity: %w", err)
+		}
+
+		if linked != nil {
+			user, err = c.principalStore.FindUser(ctx, linked.PrincipalID)
+			if err != nil {
+				return fmt.Errorf("failed to find user of identity: %w", err)
+			}
+
+			if linked.Email != identity.Email || linked.ExternalID != identity.ExternalID {
+				linked.Email = identity.Email
+				linked.ExternalID = identity.ExternalID
+				linked.Updated = now
+				if err = c.principalIdentityStore.Update(ctx, linked); err != nil {
+					return fmt.Errorf("failed to update identity: %w", err)
+				}
+			}
+
+			return nil
+		}
+
+		if linkByEmail && identity.EmailVerified && identity.Email != "" {
+			user, err = c.principalStore.FindUserByEmail(ctx, identity.Email)
+			if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+				return fmt.Errorf("failed to find user by email: %w", err)
+			}
+
+			// an admin account must never be taken over by an external identity with a matching email.
+			if user != nil && user.Admin {
+				return usererror.Forbidden("An admin user can't be linked to an external identity by email")
+			}
+		}
+
+		if user == nil {
+			if !autoProvision {
+				return usererror.Forbidden("User doesn't exist")
+			}
+
+			user, err = c.provisionExternalUser(ctx, identity)
+			if err != nil {
+				return err
+			}
+
+			provisioned = true
+		}
+
+		err = c.principalIdentityStore.Create(ctx, &types.PrincipalIdentity{
+			PrincipalID: user.ID,
+			Provider:    identity.Provider,
+			Subject:     identity.Subject,
+			ExternalID:  identity.ExternalID,
+			Email:       identity.Email,
+			Provisioned: provisioned,
+			Created:     now,
+			Updated:     now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to link identity to user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if provisioned {
+		c.eventReporter.Registered(ctx, &userevents.RegisteredPayload{
+			Base: userevents.Base{PrincipalID: user.ID},
+		})
+	}
+
+	return user, nil
+}
+
+func (c *Controller) provisionExternalUser(ctx context.Context, identity ExternalIdentity) (*types.User, error) {
+	if identity.Email == "" {
+		return nil, usererror.BadRequest("Identity provider didn't return the email of the user")
+	}
+
+	_, err := c.principalStore.FindUserByEmail(ctx, identity.Email)
+	if err == nil {
+		return nil, usererror.Conflict("A user with the same email already exists")
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	uid, err := c.externalUserUID(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := strings.TrimSpace(identity.Name)
+	if displayName == "" {
+		displayName = uid
+	}
+
+	// the user authenticates with the identity provider, the password is random and never revealed.
+	user, err := c.CreateNoAuth(ctx, &CreateInput{
+		UID:         uid,
+		Email:       identity.Email,
+		DisplayName: displayName,
+		Password:    uniuri.NewLen(64),
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+// externalUserUID derives a unique and valid user UID from the username or the email of the identity.
+func (c *Controller) externalUserUID(ctx context.Context, identity ExternalIdentity) (string, error) {
+	base := identity.Username
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	base = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, base)
+
+	const suffixLength = 6
+	if len(base) > check.MaxIdentifierLength-suffixLength-1 {
+		base = base[:check.MaxIdentifierLength-suffixLength-1]
+	}
+
+	uid := base
+	for range 5 {
+		if uid != "" && c.principalUIDCheck(uid) == nil {
+			_, err := c.principalStore.FindUserByUID(ctx, uid)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return uid, nil
+			}
+			if err != nil {
+				return "", fmt.Errorf("failed to find user by uid: %w", err)
+			}
+		}
+
+		uid = base + "-" + strings.ToLower(uniuri.NewLenChars(suffixLength, []byte("abcdefghijklmnopqrstuvwxyz0123456789")))
+		if base == "" {
+			uid = uid[1:]
+		}
+	}
+
+	return "", errors.New("failed to generate a unique user uid")
+}
+
+// syncExternalUserRoles updates the admin status and the space memberships of the user
+// based on the groups provided by the external identity provider.
+// The memberships in the mapped spaces are added, updated or removed to match the groups,
+// the memberships in spaces that aren't mapped are left as they are.
+func (c *Controller) syncExternalUserRoles(
+	ctx context.Context,
+	user *types.User,
+	groups []string,
+	adminGroups []string,
+	roleMappings []rolemapping.Mapping,
+) error {
+	if len(adminGroups) > 0 {
+		isAdmin := slices.ContainsFunc(adminGroups, func(g string) bool { return slices.Contains(groups, g) })
+		if err := c.syncExternalUserAdmin(ctx, user, isAdmin); err != nil {
+			return err
+		}
+	}
+
+	spaces, err := rolemapping.ResolveSpaces(ctx, c.spaceFinder, roleMappings)
+	if err != nil {
+		return err
+	}
+
+	roles := rolemapping.SpaceRoles(roleMappings, groups)
+	for spacePath, space := range spaces {
+		err = rolemapping.SyncMembership(ctx, c.membershipStore, space.ID, user.ID, roles[spacePath], user.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) syncExternalUserAdmin(ctx context.Context, user *types.User, admin bool) error {
+	if user.Admin == admin {
+		return nil
+	}
+
+	// Never revoke the admin status of the only admin user.
+	if user.Admin {
+		admUsrCount, err := c.principalStore.CountUsers(ctx, &types.UserFilter{Admin: true})
+		if err != nil {
+			return fmt.Errorf("failed to check admin user count: %w", err)
+		}
+
+		if admUsrCount <= 1 {
+			log.Ctx(ctx).Warn().Msgf("not revoking admin status of the only admin user %q", user.UID)
+			return nil
+		}
+	}
+
+	user.Admin = admin
+	user.Updated = time.Now().UnixMilli()
+
+	if err := c.principalStore.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to update admin status of user: %w", err)
+	}
+
+	return nil
+}
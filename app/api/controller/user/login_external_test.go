@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth/rolemapping"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type mapCache[K comparable, V any] map[K]V
+
+func (c mapCache[K, V]) Stats() (int64, int64)    { return 0, 0 }
+func (c mapCache[K, V]) Evict(context.Context, K) {}
+func (c mapCache[K, V]) Get(_ context.Context, key K) (V, error) {
+	v, ok := c[key]
+	if !ok {
+		return v, gitness_store.ErrResourceNotFound
+	}
+	return v, nil
+}
+
+type testMembershipStore struct {
+	store.MembershipStore
+	memberships map[types.MembershipKey]enum.MembershipRole
+}
+
+func (s *testMembershipStore) Find(_ context.Context, key types.MembershipKey) (*types.Membership, error) {
+	role, ok := s.memberships[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Membership{MembershipKey: key, Role: role}, nil
+}
+
+func (s *testMembershipStore) Create(_ context.Context, membership *types.Membership) error {
+	s.memberships[membership.MembershipKey] = membership.Role
+	return nil
+}
+
+func (s *testMembershipStore) Update(_ context.Context, membership *types.Membership) error {
+	s.memberships[membership.MembershipKey] = membership.Role
+	return nil
+}
+
+func (s *testMembershipStore) Delete(_ context.Context, key types.MembershipKey) error {
+	delete(s.memberships, key)
+	return nil
+}
+
+func TestSyncExternalUserRoles(t *testing.T) {
+	const userID = 7
+
+	membershipStore := &testMembershipStore{memberships: map[types.MembershipKey]enum.MembershipRole{
+		// membership in a space that isn't mapped
+		{SpaceID: 3, PrincipalID: userID}: enum.MembershipRoleReader,
+	}}
+
+	c := &Controller{
+		membershipStore: membershipStore,
+		spaceFinder: refcache.NewSpaceFinder(
+			mapCache[int64, *types.SpaceCore]{
+				1: {ID: 1, Path: "acme"},
+				2: {ID: 2, Path: "acme/backend"},
+			},
+			mapCache[string, *types.SpacePath]{
+				"acme":         {Value: "acme", SpaceID: 1},
+				"acme/backend": {Value: "acme/backend", SpaceID: 2},
+			},
+			cache.Evictor[*types.SpaceCore]{},
+		),
+	}
+
+	mappings := []rolemapping.Mapping{
+		{Group: "readers", SpacePath: "acme", Role: enum.MembershipRoleReader},
+		{Group: "developers", SpacePath: "acme/backend", Role: enum.MembershipRoleContributor},
+		{Group: "leads", SpacePath: "acme/backend", Role: enum.MembershipRoleSpaceOwner},
+	}
+
+	user := &types.User{ID: userID}
+
+	sync := func(groups ...string) {
+		t.Helper()
+		if err := c.syncExternalUserRoles(context.Background(), user, groups, nil, mappings); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	expect := func(spaceID int64, role enum.MembershipRole) {
+		t.Helper()
+		got := membershipStore.memberships[types.MembershipKey{SpaceID: spaceID, PrincipalID: userID}]
+		if got != role {
+			t.Errorf("space %d: expected role %q, got %q", spaceID, role, got)
+		}
+	}
+
+	sync("readers", "leads")
+	expect(1, enum.MembershipRoleReader)
+	expect(2, enum.MembershipRoleSpaceOwner)
+
+	// leaving a group downgrades the role in the space
+	sync("readers", "developers")
+	expect(1, enum.MembershipRoleReader)
+	expect(2, enum.MembershipRoleContributor)
+
+	// leaving all groups of a space removes the membership
+	sync("developers")
+	expect(1, "")
+	expect(2, enum.MembershipRoleContributor)
+
+	sync()
+	expect(1, "")
+	expect(2, "")
+
+	// memberships in spaces that aren't mapped are left as they are
+	expect(3, enum.MembershipRoleReader)
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// loginLDAP authenticates the user with a bind against the LDAP directory.
+// Returns nil if the user should be authenticated with the local password instead,
+// which is the case if LDAP is disabled or if the directory doesn't accept the credentials.
+// Users linked to the directory are never authenticated with their local password, see loginLocal.
+func (c *Controller) loginLDAP(ctx context.Context, in *LoginInput) (*types.User, error) {
+	if c.ldapClient == nil {
+		return nil, nil //nolint:nilnil // no ldap user means fallback to local login
+	}
+
+	ldapUser, groups, err := c.ldapClient.Authenticate(ctx, in.LoginIdentifier, in.Password)
+	if errors.Is(err, ldap.ErrUserNotFound) || errors.Is(err, ldap.ErrInvalidCredentials) {
+		log.Ctx(ctx).Debug().Err(err).
+			Msgf("ldap authentication of %q failed, falling back to local login", in.LoginIdentifier)
+		return nil, nil //nolint:nilnil // no ldap user means fallback to local login
+	}
+	if err != nil {
+		// the local password of a directory user could be outdated, so there's no fallback if the directory fails.
+		return nil, fmt.Errorf("failed to authenticate with ldap: %w", err)
+	}
+
+	config := c.ldapClient.Config()
+
+	// the directory is the source of truth, so the email is considered verified.
+	user, err := c.FindOrProvisionExternalUser(ctx, ExternalIdentity{
+		Provider:      enum.IdentityProviderLDAP,
+		Subject:       ldapUser.ID,
+		Email:         ldapUser.Email,
+		EmailVerified: true,
+		Username:      ldapUser.Username,
+		Name:          ldapUser.DisplayName,
+	}, config.LinkByEmail, config.AutoProvision)
+	if err != nil {
+		return nil, err
+	}
+
+	// blocked users aren't synced, the login is rejected by the caller.
+	if user.Blocked {
+		return user, nil
+	}
+
+	err = c.syncExternalUserRoles(ctx, user, groups, config.AdminGroups, config.RoleMappings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync user with ldap groups: %w", err)
+	}
+
+	return user, nil
+}
+
+// isLinkedToLDAP returns true if the user is linked to an identity of the LDAP directory.
+func (c *Controller) isLinkedToLDAP(ctx context.Context, userID int64) (bool, error) {
+	identities, err := c.principalIdentityStore.ListByPrincipal(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list identities of user: %w", err)
+	}
+
+	for _, identity := range identities {
+		if identity.Provider == enum.IdentityProviderLDAP {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/oauth2"
+)
+
+// OIDCLoginState is the state of an OIDC login that is kept by the client
+// between the start of the login and the callback from the identity provider.
+type OIDCLoginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+type OIDCCallbackInput struct {
+	Code             string `json:"code"`
+	State            string `json:"state"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// LoginOIDCStart starts the OIDC login - returns the login state and the URL of the identity provider.
+func (c *Controller) LoginOIDCStart(ctx context.Context) (*OIDCLoginState, string, error) {
+	if c.oidcProvider == nil {
+		return nil, "", usererror.NotFound("OIDC login is not enabled")
+	}
+
+	state := &OIDCLoginState{
+		State:    oauth2.GenerateVerifier(),
+		Nonce:    oauth2.GenerateVerifier(),
+		Verifier: oauth2.GenerateVerifier(),
+	}
+
+	authURL, err := c.oidcProvider.AuthCodeURL(ctx, state.State, state.Nonce, state.Verifier)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate oidc authorization url: %w", err)
+	}
+
+	return state, authURL, nil
+}
+
+// LoginOIDCCallback completes the OIDC login - returns the session token if successful.
+// The user is found by the linked identity, linked by verified email or provisioned on first login.
+func (c *Controller) LoginOIDCCallback(
+	ctx context.Context,
+	state *OIDCLoginState,
+	in *OIDCCallbackInput,
+) (*types.TokenResponse, error) {
+	if c.oidcProvider == nil {
+		return nil, usererror.NotFound("OIDC login is not enabled")
+	}
+
+	if in.Error != "" {
+		log.Ctx(ctx).Debug().
+			Str("error", in.Error).
+			Str("error_description", in.ErrorDescription).
+			Msg("oidc provider returned an error")
+		return nil, usererror.BadRequestf("Login failed: %s", in.Error)
+	}
+
+	if state == nil || in.State == "" || subtle.ConstantTimeCompare([]byte(state.State), []byte(in.State)) != 1 {
+		return nil, usererror.BadRequest("Invalid or expired login state")
+	}
+
+	if in.Code == "" {
+		return nil, usererror.BadRequest("Authorization code is missing")
+	}
+
+	claims, err := c.oidcProvider.Exchange(ctx, in.Code, state.Nonce, state.Verifier)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to complete oidc login")
+		return nil, usererror.ErrUnauthorized
+	}
+
+	config := c.oidcProvider.Config()
+
+	user, err := c.FindOrProvisionExternalUser(ctx, ExternalIdentity{
+		Provider:      enum.IdentityProviderOIDC,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Username:      claims.Username,
+		Name:          claims.Name,
+	}, config.LinkByEmail, config.AutoProvision)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		return nil, usererror.Forbidden("User is blocked")
+	}
+
+	err = c.syncExternalUserRoles(ctx, user, claims.Groups, config.AdminGroups, config.RoleMappings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync user with oidc claims: %w", err)
+	}
+
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, token.GenerateIdentifier("login"))
+	if err != nil {
+		return nil, err
+	}
+
+	c.eventReporter.LoggedIn(ctx, &userevents.LoggedInPayload{
+		Base: userevents.Base{PrincipalID: user.ID},
+	})
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+}
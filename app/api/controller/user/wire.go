@@ -16,6 +16,8 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
 	userevents "github.com/harness/gitness/app/events/user"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
@@ -42,6 +44,10 @@ func ProvideController(
 	eventReporter *userevents.Reporter,
 	repoFinder refcache.RepoFinder,
 	favoriteStore store.FavoriteStore,
+	principalIdentityStore store.PrincipalIdentityStore,
+	spaceFinder refcache.SpaceFinder,
+	oidcProvider *oidc.Provider,
+	ldapClient *ldap.Client,
 ) *Controller {
 	return NewController(
 		tx,
@@ -55,5 +61,10 @@ func ProvideController(
 		gitSignatureResultStore,
 		eventReporter,
 		repoFinder,
-		favoriteStore)
+		favoriteStore,
+		principalIdentityStore,
+		spaceFinder,
+		oidcProvider,
+		ldapClient,
+	)
 }
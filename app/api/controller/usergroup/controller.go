@@ -29,26 +29,32 @@ import (
 )
 
 type Controller struct {
-	userGroupStore   store.UserGroupStore
-	spaceStore       store.SpaceStore
-	spaceFinder      refcache.SpaceFinder
-	authorizer       authz.Authorizer
-	userGroupService usergroup.Service
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+	principalStore       store.PrincipalStore
+	spaceStore           store.SpaceStore
+	spaceFinder          refcache.SpaceFinder
+	authorizer           authz.Authorizer
+	userGroupService     usergroup.Service
 }
 
 func NewController(
 	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	principalStore store.PrincipalStore,
 	spaceStore store.SpaceStore,
 	spaceFinder refcache.SpaceFinder,
 	authorizer authz.Authorizer,
 	userGroupService usergroup.Service,
 ) *Controller {
 	return &Controller{
-		userGroupStore:   userGroupStore,
-		spaceStore:       spaceStore,
-		spaceFinder:      spaceFinder,
-		authorizer:       authorizer,
-		userGroupService: userGroupService,
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+		principalStore:       principalStore,
+		spaceStore:           spaceStore,
+		spaceFinder:          spaceFinder,
+		authorizer:           authorizer,
+		userGroupService:     userGroupService,
 	}
 }
 
@@ -72,3 +78,24 @@ func getSpaceCheckAuth(
 
 	return space, nil
 }
+
+// getUserGroupCheckAuth fetches a user group defined in the space and checks the space permission.
+func (c *Controller) getUserGroupCheckAuth(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	permission enum.Permission,
+) (*types.SpaceCore, *types.UserGroup, error) {
+	space, err := getSpaceCheckAuth(ctx, c.spaceFinder, c.authorizer, session, spaceRef, permission)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	userGroup, err := c.userGroupStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	return space, userGroup, nil
+}
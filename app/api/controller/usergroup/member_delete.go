@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MemberDelete removes a user from a user group defined in the space.
+func (c *Controller) MemberDelete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	userUID string,
+) error {
+	_, userGroup, err := c.getUserGroupCheckAuth(ctx, session, spaceRef, identifier, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.BadRequestf("User '%s' not found", userUID)
+	} else if err != nil {
+		return fmt.Errorf("failed to find the user: %w", err)
+	}
+
+	err = c.userGroupMemberStore.Delete(ctx, userGroup.ID, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to remove user group member: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MemberList lists the members of a user group defined in the space.
+func (c *Controller) MemberList(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	filter *types.ListQueryFilter,
+) ([]*types.UserGroupMember, int64, error) {
+	_, userGroup, err := c.getUserGroupCheckAuth(ctx, session, spaceRef, identifier, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	members, err := c.userGroupMemberStore.List(ctx, userGroup.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list user group members: %w", err)
+	}
+
+	if filter.Page == 1 && len(members) < filter.Size {
+		return members, int64(len(members)), nil
+	}
+
+	count, err := c.userGroupMemberStore.Count(ctx, userGroup.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count user group members: %w", err)
+	}
+
+	return members, count, nil
+}
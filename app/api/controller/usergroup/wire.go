@@ -30,10 +30,13 @@ var WireSet = wire.NewSet(
 
 func ProvideController(
 	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	principalStore store.PrincipalStore,
 	spaceStore store.SpaceStore,
 	spaceFinder refcache.SpaceFinder,
 	authorizer authz.Authorizer,
 	searchSvc usergroup.Service,
 ) *Controller {
-	return NewController(userGroupStore, spaceStore, spaceFinder, authorizer, searchSvc)
+	return NewController(
+		userGroupStore, userGroupMemberStore, principalStore, spaceStore, spaceFinder, authorizer, searchSvc)
 }
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PreviewSpace renders the request body a space webhook would send for a sample event.
+func (c *Controller) PreviewSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.WebhookPreviewInput,
+) (*types.WebhookPreviewOutput, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	out, err := c.webhookService.Preview(
+		ctx, &session.Principal, webhook.ParentResource{
+			ID:         space.ID,
+			Identifier: space.Identifier,
+			Type:       enum.WebhookParentSpace,
+			Path:       space.Path,
+		}, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview webhook: %w", err)
+	}
+
+	return out, nil
+}
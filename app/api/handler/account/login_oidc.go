@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+)
+
+const oidcStateCookieLifetime = 10 * time.Minute
+
+// HandleLoginOIDC returns an http.HandlerFunc that starts the OIDC login
+// by redirecting the user to the identity provider.
+func HandleLoginOIDC(userCtrl *user.Controller, cookieName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		state, authURL, err := userCtrl.LoginOIDCStart(ctx)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		stateJSON, err := json.Marshal(state)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		cookie := newOIDCStateCookie(r, cookieName)
+		cookie.Value = base64.RawURLEncoding.EncodeToString(stateJSON)
+		cookie.Expires = time.Now().Add(oidcStateCookieLifetime)
+
+		http.SetCookie(w, cookie)
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
+// HandleLoginOIDCCallback returns an http.HandlerFunc that completes the OIDC login
+// and redirects the user to the UI with the session token cookie on success.
+func HandleLoginOIDCCallback(userCtrl *user.Controller, cookieName string, uiURL string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		query := r.URL.Query()
+		in := &user.OIDCCallbackInput{
+			Code:             query.Get("code"),
+			State:            query.Get("state"),
+			Error:            query.Get("error"),
+			ErrorDescription: query.Get("error_description"),
+		}
+
+		state := readOIDCStateCookie(r, cookieName)
+
+		// the login state is single use.
+		cookie := newOIDCStateCookie(r, cookieName)
+		cookie.Expires = time.UnixMilli(0)
+		http.SetCookie(w, cookie)
+
+		tokenResponse, err := userCtrl.LoginOIDCCallback(ctx, state, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if cookieName != "" {
+			includeTokenCookie(r, w, tokenResponse, cookieName)
+		}
+
+		http.Redirect(w, r, uiURL, http.StatusFound)
+	}
+}
+
+func readOIDCStateCookie(r *http.Request, cookieName string) *user.OIDCLoginState {
+	cookie, err := r.Cookie(oidcStateCookieName(cookieName))
+	if errors.Is(err, http.ErrNoCookie) {
+		return nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil
+	}
+
+	state := &user.OIDCLoginState{}
+	if err = json.Unmarshal(raw, state); err != nil {
+		return nil
+	}
+
+	return state
+}
+
+func newOIDCStateCookie(r *http.Request, cookieName string) *http.Cookie {
+	// SameSite=Lax is required as the cookie has to be sent with the redirect from the identity provider.
+	return &http.Cookie{
+		Name:     oidcStateCookieName(cookieName),
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+	}
+}
+
+func oidcStateCookieName(cookieName string) string {
+	if cookieName == "" {
+		cookieName = "token"
+	}
+	return cookieName + "_oidc_state"
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/notification"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleEvents returns a http.HandlerFunc that watches for notification events of the current principal.
+func HandleEvents(appCtx context.Context, notificationCtrl *notification.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) { //nolint:contextcheck
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx) //nolint:contextcheck
+
+		chEvents, chErr, sseCancel := notificationCtrl.Events(ctx, session) //nolint:contextcheck
+		defer func() {
+			if err := sseCancel(ctx); err != nil {
+				log.Ctx(ctx).Err(err).Msg("failed to cancel sse stream for notifications")
+			}
+		}()
+
+		render.StreamSSE(ctx, w, appCtx.Done(), chEvents, chErr) //nolint:contextcheck
+	}
+}
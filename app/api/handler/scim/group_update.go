@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/scim"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleReplaceGroup returns a http.HandlerFunc that replaces a SCIM group.
+func HandleReplaceGroup(scimCtrl *scim.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		id, err := request.GetSCIMResourceIDFromPath(r)
+		if err != nil {
+			renderError(ctx, w, err)
+			return
+		}
+
+		in := new(scim.Group)
+		if !decodeBody(ctx, w, r, in) {
+			return
+		}
+
+		result, err := scimCtrl.ReplaceGroup(ctx, session, id, in)
+		if err != nil {
+			renderError(ctx, w, err)
+			return
+		}
+
+		renderJSON(w, http.StatusOK, result)
+	}
+}
+
+// HandlePatchGroup returns a http.HandlerFunc that applies patch operations to a SCIM group.
+func HandlePatchGroup(scimCtrl *scim.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		id, err := request.GetSCIMResourceIDFromPath(r)
+		if err != nil {
+			renderError(ctx, w, err)
+			return
+		}
+
+		in := new(scim.PatchRequest)
+		if !decodeBody(ctx, w, r, in) {
+			return
+		}
+
+		result, err := scimCtrl.PatchGroup(ctx, session, id, in)
+		if err != nil {
+			renderError(ctx, w, err)
+			return
+		}
+
+		renderJSON(w, http.StatusOK, result)
+	}
+}
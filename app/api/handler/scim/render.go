@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/controller/scim"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	contentType = "application/scim+json"
+
+	// defaultCount is the number of resources returned if the identity provider doesn't ask for a page size.
+	defaultCount = 100
+)
+
+// renderJSON writes the json-encoded SCIM resource to the response.
+func renderJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// renderError writes the error in the format defined by the SCIM protocol.
+func renderError(ctx context.Context, w http.ResponseWriter, err error) {
+	userErr := usererror.Translate(ctx, err)
+
+	log.Ctx(ctx).Debug().Err(userErr).Msgf("scim operation resulted in user facing error")
+
+	renderJSON(w, userErr.Status, &scim.Error{
+		Schemas: []string{scim.SchemaError},
+		Status:  strconv.Itoa(userErr.Status),
+		Detail:  userErr.Message,
+	})
+}
+
+// decodeBody decodes the SCIM resource of the request body.
+func decodeBody(ctx context.Context, w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		renderError(ctx, w, usererror.BadRequestf("Invalid Request Body: %s.", err))
+		return false
+	}
+
+	return true
+}
+
+// parseListParams parses the filter and the pagination of a SCIM query.
+func parseListParams(r *http.Request) (scim.ListParams, error) {
+	startIndex, err := request.QueryParamAsPositiveInt64OrDefault(r, request.QueryParamSCIMStartIndex, 1)
+	if err != nil {
+		return scim.ListParams{}, err
+	}
+
+	count, err := request.QueryParamAsPositiveInt64OrDefault(r, request.QueryParamSCIMCount, defaultCount)
+	if err != nil {
+		return scim.ListParams{}, err
+	}
+
+	return scim.ListParams{
+		Filter:     request.QueryParamOrDefault(r, request.QueryParamSCIMFilter, ""),
+		StartIndex: int(startIndex),
+		Count:      int(count),
+	}, nil
+}
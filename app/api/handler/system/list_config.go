@@ -33,6 +33,12 @@ type ConfigOutput struct {
 	GitspaceEnabled               bool `json:"gitspace_enabled"`
 	ArtifactRegistryEnabled       bool `json:"artifact_registry_enabled"`
 	UI                            UI   `json:"ui"`
+	OIDC                          OIDC `json:"oidc"`
+}
+
+type OIDC struct {
+	Enabled      bool   `json:"enabled"`
+	ProviderName string `json:"provider_name,omitempty"`
 }
 
 // HandleGetConfig returns an http.HandlerFunc that processes an http.Request
@@ -54,6 +60,10 @@ func HandleGetConfig(config *types.Config, sysCtrl *system.Controller) http.Hand
 			GitspaceEnabled:               config.Gitspace.Enable,
 			ArtifactRegistryEnabled:       config.Registry.Enable,
 			UI:                            UI{ShowPlugin: config.UI.ShowPlugin},
+			OIDC: OIDC{
+				Enabled:      config.OIDC.Enabled,
+				ProviderName: config.OIDC.ProviderName,
+			},
 		})
 	}
 }
@@ -46,6 +46,14 @@ type loginRequest struct {
 	user.LoginInput
 }
 
+// callback of the oidc identity provider.
+type loginOIDCCallbackRequest struct {
+	Code             string `query:"code"`
+	State            string `query:"state"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+}
+
 // request to register an account.
 type registerRequest struct {
 	user.RegisterInput
@@ -65,6 +73,27 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/login", onLogin)
 
+	onLoginOIDC := openapi3.Operation{}
+	onLoginOIDC.WithTags("account")
+	onLoginOIDC.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginOIDC"})
+	_ = reflector.SetRequest(&onLoginOIDC, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&onLoginOIDC, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onLoginOIDC, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLoginOIDC, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/login/oidc", onLoginOIDC)
+
+	onLoginOIDCCallback := openapi3.Operation{}
+	onLoginOIDCCallback.WithTags("account")
+	onLoginOIDCCallback.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginOIDCCallback"})
+	_ = reflector.SetRequest(&onLoginOIDCCallback, new(loginOIDCCallbackRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/login/oidc/callback", onLoginOIDCCallback)
+
 	opLogout := openapi3.Operation{}
 	opLogout.WithTags("account")
 	opLogout.WithMapOfAnything(map[string]interface{}{"operationId": "opLogout"})
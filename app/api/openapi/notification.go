@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/notification"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type (
+	// notificationListRequest is the request for listing the notifications of the current user.
+	notificationListRequest struct {
+		UnreadOnly bool     `query:"unread_only"`
+		Type       []string `query:"type"`
+
+		// include pagination request
+		paginationRequest
+	}
+
+	// notificationUpdateRequest is the request for marking a notification as read or unread.
+	notificationUpdateRequest struct {
+		NotificationID int64 `path:"notification_id"`
+		notification.UpdateInput
+	}
+
+	// notificationSubscriptionDeleteRequest is the request for deleting a notification subscription.
+	notificationSubscriptionDeleteRequest struct {
+		ResourceID   int64  `path:"resource_id"`
+		ResourceType string `query:"resource_type"`
+	}
+)
+
+// helper function that constructs the openapi specification
+// for the in-app notification resources.
+func buildNotification(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("user")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listNotifications"})
+	_ = reflector.SetRequest(&opList, new(notificationListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.Notification), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications", opList)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("user")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateNotification"})
+	_ = reflector.SetRequest(&opUpdate, new(notificationUpdateRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Notification), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/notifications/{notification_id}", opUpdate)
+
+	opMarkAllRead := openapi3.Operation{}
+	opMarkAllRead.WithTags("user")
+	opMarkAllRead.WithMapOfAnything(map[string]interface{}{"operationId": "markAllNotificationsRead"})
+	_ = reflector.SetRequest(&opMarkAllRead, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMarkAllRead, new(notification.MarkAllReadOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMarkAllRead, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMarkAllRead, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/notifications/mark-all-read", opMarkAllRead)
+
+	opListSubscriptions := openapi3.Operation{}
+	opListSubscriptions.WithTags("user")
+	opListSubscriptions.WithMapOfAnything(map[string]interface{}{"operationId": "listNotificationSubscriptions"})
+	_ = reflector.SetRequest(&opListSubscriptions, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSubscriptions, new([]*types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSubscriptions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListSubscriptions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-subscriptions", opListSubscriptions)
+
+	opUpsertSubscription := openapi3.Operation{}
+	opUpsertSubscription.WithTags("user")
+	opUpsertSubscription.WithMapOfAnything(map[string]interface{}{"operationId": "upsertNotificationSubscription"})
+	_ = reflector.SetRequest(&opUpsertSubscription, new(notification.SubscriptionUpsertInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpsertSubscription, new(types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpsertSubscription, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpsertSubscription, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpsertSubscription, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpsertSubscription, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpsertSubscription, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/notification-subscriptions", opUpsertSubscription)
+
+	opDeleteSubscription := openapi3.Operation{}
+	opDeleteSubscription.WithTags("user")
+	opDeleteSubscription.WithMapOfAnything(map[string]interface{}{"operationId": "deleteNotificationSubscription"})
+	_ = reflector.SetRequest(&opDeleteSubscription, new(notificationSubscriptionDeleteRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSubscription, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSubscription, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeleteSubscription, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteSubscription, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/user/notification-subscriptions/{resource_id}", opDeleteSubscription)
+}
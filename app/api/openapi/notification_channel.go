@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/notificationchannel"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type (
+	// notificationChannelRequest is the request for a notification channel of a space.
+	notificationChannelRequest struct {
+		spaceRequest
+		Identifier string `path:"notification_channel_identifier"`
+	}
+
+	notificationChannelCreateRequest struct {
+		spaceRequest
+		notificationchannel.CreateInput
+	}
+
+	notificationChannelUpdateRequest struct {
+		notificationChannelRequest
+		notificationchannel.UpdateInput
+	}
+)
+
+// helper function that constructs the openapi specification
+// for the chat notification channel resources of spaces.
+func buildNotificationChannel(reflector *openapi3.Reflector) {
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("space")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createNotificationChannel"})
+	_ = reflector.SetRequest(&opCreate, new(notificationChannelCreateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.NotificationChannel), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/notification-channels", opCreate)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("space")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listNotificationChannels"})
+	opList.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.NotificationChannel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/notification-channels", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("space")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findNotificationChannel"})
+	_ = reflector.SetRequest(&opFind, new(notificationChannelRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.NotificationChannel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("space")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateNotificationChannel"})
+	_ = reflector.SetRequest(&opUpdate, new(notificationChannelUpdateRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.NotificationChannel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("space")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteNotificationChannel"})
+	_ = reflector.SetRequest(&opDelete, new(notificationChannelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}", opDelete)
+
+	opTest := openapi3.Operation{}
+	opTest.WithTags("space")
+	opTest.WithMapOfAnything(map[string]interface{}{"operationId": "testNotificationChannel"})
+	_ = reflector.SetRequest(&opTest, new(notificationChannelRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTest, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opTest, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTest, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTest, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTest, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opTest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}/test", opTest)
+}
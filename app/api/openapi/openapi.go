@@ -56,8 +56,12 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	buildSystem(&reflector)
 	buildAccount(&reflector)
 	buildUser(&reflector)
+	buildNotification(&reflector)
+	buildNotificationChannel(&reflector)
 	buildAdmin(&reflector)
+	buildAuditLog(&reflector)
 	buildPrincipals(&reflector)
+	buildSCIM(&reflector)
 	spaceOperations(&reflector)
 	pluginOperations(&reflector)
 	repoOperations(&reflector)
@@ -68,6 +72,7 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	secretOperations(&reflector)
 	resourceOperations(&reflector)
 	pullReqOperations(&reflector)
+	issueOperations(&reflector)
 	webhookOperations(&reflector)
 	checkOperations(&reflector)
 	uploadOperations(&reflector)
@@ -53,6 +53,11 @@ type getPullReqByBranchesRequest struct {
 	TargetBranch string `path:"target_branch"`
 }
 
+type pullReqTemplateRequest struct {
+	repoRequest
+	Name string `path:"pullreq_template_name"`
+}
+
 type updatePullReqRequest struct {
 	pullReqRequest
 	pullreq.UpdateInput
@@ -82,6 +87,26 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type updateBranchPullReq struct {
+	pullReqRequest
+	pullreq.UpdateBranchInput
+}
+
+type stackParentSetRequest struct {
+	pullReqRequest
+	pullreq.StackParentInput
+}
+
+type mergeQueueEnqueueRequest struct {
+	pullReqRequest
+	pullreq.MergeQueueEnqueueInput
+}
+
+type autoMergeEnableRequest struct {
+	pullReqRequest
+	pullreq.AutoMergeEnableInput
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -207,6 +232,35 @@ var queryParameterSourceBranchPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterIncludeStack = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeStack,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("If true, the stack of the pull request would be included in the response."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterTargetBranchMergeQueue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTargetBranch,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Target branch of the merge queue. The default branch is used if omitted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterTargetBranchPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamTargetBranch,
@@ -517,7 +571,8 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 		QueryParameterLabelID, QueryParameterValueID,
 		queryParameterAuthorID, queryParameterCommenterID, queryParameterMentionedID,
 		queryParameterReviewerID, queryParameterReviewDecision,
-		queryParamIncludeGitStats, queryParameterIncludeChecks, queryParameterIncludeRules)
+		queryParamIncludeGitStats, queryParameterIncludeChecks, queryParameterIncludeRules,
+		queryParameterIncludeStack)
 	_ = reflector.SetRequest(&listPullReq, new(listPullReqRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&listPullReq, new([]types.PullReq), http.StatusOK)
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusBadRequest)
@@ -529,7 +584,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	getPullReq := openapi3.Operation{}
 	getPullReq.WithTags("pullreq")
 	getPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReq"})
-	getPullReq.WithParameters(queryParameterIncludeChecks, queryParameterIncludeRules)
+	getPullReq.WithParameters(queryParameterIncludeChecks, queryParameterIncludeRules, queryParameterIncludeStack)
 	_ = reflector.SetRequest(&getPullReq, new(getPullReqRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&getPullReq, new(types.PullReq), http.StatusOK)
 	_ = reflector.SetJSONResponse(&getPullReq, new(usererror.Error), http.StatusBadRequest)
@@ -542,7 +597,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	getPullReqByBranches.WithTags("pullreq")
 	getPullReqByBranches.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReqByBranches"})
 	getPullReqByBranches.WithParameters(queryParameterSourceRepoRefPullRequest,
-		queryParameterIncludeChecks, queryParameterIncludeRules)
+		queryParameterIncludeChecks, queryParameterIncludeRules, queryParameterIncludeStack)
 	_ = reflector.SetRequest(&getPullReqByBranches, new(getPullReqByBranchesRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&getPullReqByBranches, new(types.PullReq), http.StatusOK)
 	_ = reflector.SetJSONResponse(&getPullReqByBranches, new(usererror.Error), http.StatusBadRequest)
@@ -699,6 +754,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviews", reviewSubmit)
 
+	reviewPendingDiscard := openapi3.Operation{}
+	reviewPendingDiscard.WithTags("pullreq")
+	reviewPendingDiscard.WithMapOfAnything(map[string]interface{}{"operationId": "reviewPendingDiscardPullReq"})
+	_ = reflector.SetRequest(&reviewPendingDiscard, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&reviewPendingDiscard, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&reviewPendingDiscard, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reviewPendingDiscard, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reviewPendingDiscard, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reviewPendingDiscard, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviews/pending", reviewPendingDiscard)
+
 	userGroupReviewerAdd := openapi3.Operation{}
 	userGroupReviewerAdd.WithTags("pullreq")
 	userGroupReviewerAdd.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupReviewerAddPullReq"})
@@ -750,6 +817,132 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	updateBranchPullReqOp := openapi3.Operation{}
+	updateBranchPullReqOp.WithTags("pullreq")
+	updateBranchPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "updateBranchPullReq"})
+	_ = reflector.SetRequest(&updateBranchPullReqOp, new(updateBranchPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(types.RebaseResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/update-branch", updateBranchPullReqOp)
+
+	stackParentSetOp := openapi3.Operation{}
+	stackParentSetOp.WithTags("pullreq")
+	stackParentSetOp.WithMapOfAnything(map[string]interface{}{"operationId": "stackParentSet"})
+	_ = reflector.SetRequest(&stackParentSetOp, new(stackParentSetRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&stackParentSetOp, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stackParentSetOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stackParentSetOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stackParentSetOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stackParentSetOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stackParentSetOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/stack-parent", stackParentSetOp)
+
+	stackParentRemoveOp := openapi3.Operation{}
+	stackParentRemoveOp.WithTags("pullreq")
+	stackParentRemoveOp.WithMapOfAnything(map[string]interface{}{"operationId": "stackParentRemove"})
+	_ = reflector.SetRequest(&stackParentRemoveOp, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&stackParentRemoveOp, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stackParentRemoveOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stackParentRemoveOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stackParentRemoveOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stackParentRemoveOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/stack-parent", stackParentRemoveOp)
+
+	mergeQueueEnqueueOp := openapi3.Operation{}
+	mergeQueueEnqueueOp.WithTags("pullreq")
+	mergeQueueEnqueueOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueEnqueue"})
+	_ = reflector.SetRequest(&mergeQueueEnqueueOp, new(mergeQueueEnqueueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueueOp, new(types.MergeQueueEntry), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueueOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueueOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueueOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueueOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueueOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueueOp, new(types.MergeViolations),
+		http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueEnqueueOp)
+
+	mergeQueueDequeueOp := openapi3.Operation{}
+	mergeQueueDequeueOp.WithTags("pullreq")
+	mergeQueueDequeueOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueDequeue"})
+	_ = reflector.SetRequest(&mergeQueueDequeueOp, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeueOp, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeueOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeueOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeueOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeueOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueDequeueOp)
+
+	mergeQueueFindOp := openapi3.Operation{}
+	mergeQueueFindOp.WithTags("pullreq")
+	mergeQueueFindOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueFind"})
+	_ = reflector.SetRequest(&mergeQueueFindOp, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&mergeQueueFindOp, new(types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeQueueFindOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&mergeQueueFindOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueFindOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueFindOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueFindOp)
+
+	mergeQueueListOp := openapi3.Operation{}
+	mergeQueueListOp.WithTags("pullreq")
+	mergeQueueListOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueList"})
+	mergeQueueListOp.WithParameters(queryParameterTargetBranchMergeQueue)
+	_ = reflector.SetRequest(&mergeQueueListOp, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(types.MergeQueue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/merge-queue", mergeQueueListOp)
+
+	autoMergeEnableOp := openapi3.Operation{}
+	autoMergeEnableOp.WithTags("pullreq")
+	autoMergeEnableOp.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeEnable"})
+	_ = reflector.SetRequest(&autoMergeEnableOp, new(autoMergeEnableRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(types.AutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeEnableOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", autoMergeEnableOp)
+
+	autoMergeDisableOp := openapi3.Operation{}
+	autoMergeDisableOp.WithTags("pullreq")
+	autoMergeDisableOp.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeDisable"})
+	_ = reflector.SetRequest(&autoMergeDisableOp, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&autoMergeDisableOp, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&autoMergeDisableOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&autoMergeDisableOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeDisableOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeDisableOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", autoMergeDisableOp)
+
+	autoMergeFindOp := openapi3.Operation{}
+	autoMergeFindOp.WithTags("pullreq")
+	autoMergeFindOp.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeFind"})
+	_ = reflector.SetRequest(&autoMergeFindOp, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&autoMergeFindOp, new(types.AutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&autoMergeFindOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&autoMergeFindOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeFindOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeFindOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", autoMergeFindOp)
+
 	revertPullReqOp := openapi3.Operation{}
 	revertPullReqOp.WithTags("pullreq")
 	revertPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "revertPullReqOp"})
@@ -973,4 +1166,31 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opPRCandidates, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pullreq/candidates", opPRCandidates)
+
+	opTemplateList := openapi3.Operation{}
+	opTemplateList.WithTags("pullreq")
+	opTemplateList.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqTemplates"})
+	opTemplateList.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&opTemplateList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTemplateList, new([]types.PullReqTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/templates", opTemplateList)
+
+	opTemplateFind := openapi3.Operation{}
+	opTemplateFind.WithTags("pullreq")
+	opTemplateFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPullReqTemplate"})
+	opTemplateFind.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&opTemplateFind, new(pullReqTemplateRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTemplateFind, new(types.PullReqTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTemplateFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTemplateFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTemplateFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTemplateFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTemplateFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/templates/{pullreq_template_name}", opTemplateFind)
 }
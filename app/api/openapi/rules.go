@@ -32,14 +32,14 @@ import (
 type RuleType string
 
 func (RuleType) Enum() []interface{} {
-	return []interface{}{protection.TypeBranch, protection.TypeTag, protection.TypePush}
+	return []interface{}{protection.TypeBranch, protection.TypeTag, protection.TypePush, protection.TypeFilePath}
 }
 
 // RuleDefinition is a plugin for types.Rule Definition to allow using oneof.
 type RuleDefinition struct{}
 
 func (RuleDefinition) JSONSchemaOneOf() []interface{} {
-	return []interface{}{protection.Branch{}, protection.Tag{}, protection.Push{}}
+	return []interface{}{protection.Branch{}, protection.Tag{}, protection.Push{}, protection.FilePath{}}
 }
 
 type Rule struct {
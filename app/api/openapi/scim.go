@@ -0,0 +1,238 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/scim"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type scimResourceRequest struct {
+	ID string `path:"scim_resource_id"`
+}
+
+type scimUserRequest struct {
+	scimResourceRequest
+	scim.User
+}
+
+type scimGroupRequest struct {
+	scimResourceRequest
+	scim.Group
+}
+
+type scimPatchRequest struct {
+	scimResourceRequest
+	scim.PatchRequest
+}
+
+var queryParameterSCIMFilter = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSCIMFilter,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String(`The SCIM filter, only the eq operator is supported (e.g. userName eq "john").`),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterSCIMStartIndex = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSCIMStartIndex,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The 1-based index of the first result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(1),
+				Minimum: ptr.Float64(1),
+			},
+		},
+	},
+}
+
+var queryParameterSCIMCount = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSCIMCount,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The maximum number of results."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(100),
+				Minimum: ptr.Float64(1),
+			},
+		},
+	},
+}
+
+// buildSCIM function that constructs the openapi specification
+// for the SCIM provisioning resources.
+func buildSCIM(reflector *openapi3.Reflector) {
+	opListUsers := openapi3.Operation{}
+	opListUsers.WithTags("scim")
+	opListUsers.WithMapOfAnything(map[string]interface{}{"operationId": "listScimUsers"})
+	opListUsers.WithParameters(queryParameterSCIMFilter, queryParameterSCIMStartIndex, queryParameterSCIMCount)
+	_ = reflector.SetRequest(&opListUsers, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListUsers, new(scim.UserListResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListUsers, new(scim.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListUsers, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListUsers, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListUsers, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/scim/v2/Users", opListUsers)
+
+	opCreateUser := openapi3.Operation{}
+	opCreateUser.WithTags("scim")
+	opCreateUser.WithMapOfAnything(map[string]interface{}{"operationId": "createScimUser"})
+	_ = reflector.SetRequest(&opCreateUser, new(scim.User), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateUser, new(scim.User), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateUser, new(scim.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateUser, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateUser, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateUser, new(scim.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreateUser, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/scim/v2/Users", opCreateUser)
+
+	opFindUser := openapi3.Operation{}
+	opFindUser.WithTags("scim")
+	opFindUser.WithMapOfAnything(map[string]interface{}{"operationId": "getScimUser"})
+	_ = reflector.SetRequest(&opFindUser, new(scimResourceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindUser, new(scim.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindUser, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindUser, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindUser, new(scim.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFindUser, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/scim/v2/Users/{scim_resource_id}", opFindUser)
+
+	opReplaceUser := openapi3.Operation{}
+	opReplaceUser.WithTags("scim")
+	opReplaceUser.WithMapOfAnything(map[string]interface{}{"operationId": "replaceScimUser"})
+	_ = reflector.SetRequest(&opReplaceUser, new(scimUserRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opReplaceUser, new(scim.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReplaceUser, new(scim.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReplaceUser, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReplaceUser, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReplaceUser, new(scim.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opReplaceUser, new(scim.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opReplaceUser, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/scim/v2/Users/{scim_resource_id}", opReplaceUser)
+
+	opPatchUser := openapi3.Operation{}
+	opPatchUser.WithTags("scim")
+	opPatchUser.WithMapOfAnything(map[string]interface{}{"operationId": "patchScimUser"})
+	_ = reflector.SetRequest(&opPatchUser, new(scimPatchRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opPatchUser, new(scim.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPatchUser, new(scim.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPatchUser, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPatchUser, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPatchUser, new(scim.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opPatchUser, new(scim.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opPatchUser, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/scim/v2/Users/{scim_resource_id}", opPatchUser)
+
+	opDeleteUser := openapi3.Operation{}
+	opDeleteUser.WithTags("scim")
+	opDeleteUser.WithMapOfAnything(map[string]interface{}{"operationId": "deleteScimUser"})
+	_ = reflector.SetRequest(&opDeleteUser, new(scimResourceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteUser, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteUser, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteUser, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteUser, new(scim.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteUser, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/scim/v2/Users/{scim_resource_id}", opDeleteUser)
+
+	opListGroups := openapi3.Operation{}
+	opListGroups.WithTags("scim")
+	opListGroups.WithMapOfAnything(map[string]interface{}{"operationId": "listScimGroups"})
+	opListGroups.WithParameters(queryParameterSCIMFilter, queryParameterSCIMStartIndex, queryParameterSCIMCount)
+	_ = reflector.SetRequest(&opListGroups, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListGroups, new(scim.GroupListResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListGroups, new(scim.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListGroups, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListGroups, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListGroups, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/scim/v2/Groups", opListGroups)
+
+	opCreateGroup := openapi3.Operation{}
+	opCreateGroup.WithTags("scim")
+	opCreateGroup.WithMapOfAnything(map[string]interface{}{"operationId": "createScimGroup"})
+	_ = reflector.SetRequest(&opCreateGroup, new(scim.Group), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateGroup, new(scim.Group), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateGroup, new(scim.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateGroup, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateGroup, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateGroup, new(scim.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreateGroup, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/scim/v2/Groups", opCreateGroup)
+
+	opFindGroup := openapi3.Operation{}
+	opFindGroup.WithTags("scim")
+	opFindGroup.WithMapOfAnything(map[string]interface{}{"operationId": "getScimGroup"})
+	_ = reflector.SetRequest(&opFindGroup, new(scimResourceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindGroup, new(scim.Group), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindGroup, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindGroup, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindGroup, new(scim.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFindGroup, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/scim/v2/Groups/{scim_resource_id}", opFindGroup)
+
+	opReplaceGroup := openapi3.Operation{}
+	opReplaceGroup.WithTags("scim")
+	opReplaceGroup.WithMapOfAnything(map[string]interface{}{"operationId": "replaceScimGroup"})
+	_ = reflector.SetRequest(&opReplaceGroup, new(scimGroupRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opReplaceGroup, new(scim.Group), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReplaceGroup, new(scim.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReplaceGroup, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReplaceGroup, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReplaceGroup, new(scim.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opReplaceGroup, new(scim.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opReplaceGroup, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/scim/v2/Groups/{scim_resource_id}", opReplaceGroup)
+
+	opPatchGroup := openapi3.Operation{}
+	opPatchGroup.WithTags("scim")
+	opPatchGroup.WithMapOfAnything(map[string]interface{}{"operationId": "patchScimGroup"})
+	_ = reflector.SetRequest(&opPatchGroup, new(scimPatchRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opPatchGroup, new(scim.Group), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPatchGroup, new(scim.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPatchGroup, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPatchGroup, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPatchGroup, new(scim.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opPatchGroup, new(scim.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opPatchGroup, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/scim/v2/Groups/{scim_resource_id}", opPatchGroup)
+
+	opDeleteGroup := openapi3.Operation{}
+	opDeleteGroup.WithTags("scim")
+	opDeleteGroup.WithMapOfAnything(map[string]interface{}{"operationId": "deleteScimGroup"})
+	_ = reflector.SetRequest(&opDeleteGroup, new(scimResourceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteGroup, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteGroup, new(scim.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteGroup, new(scim.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteGroup, new(scim.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteGroup, new(scim.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/scim/v2/Groups/{scim_resource_id}", opDeleteGroup)
+}
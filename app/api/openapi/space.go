@@ -19,6 +19,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
@@ -36,6 +37,11 @@ type spaceRequest struct {
 	Ref string `path:"space_ref"`
 }
 
+type userGroupRequest struct {
+	spaceRequest
+	Identifier string `path:"usergroup_identifier"`
+}
+
 type updateSpaceRequest struct {
 	spaceRequest
 	space.UpdateInput
@@ -743,7 +749,8 @@ func spaceOperations(reflector *openapi3.Reflector) {
 		QueryParameterLabelID, QueryParameterValueID,
 		queryParameterAuthorID, queryParameterCommenterID, queryParameterMentionedID,
 		queryParameterReviewerID, queryParameterReviewDecision,
-		queryParamIncludeGitStats, queryParameterIncludeChecks, queryParameterIncludeRules)
+		queryParamIncludeGitStats, queryParameterIncludeChecks, queryParameterIncludeRules,
+		queryParameterIncludeStack)
 	_ = reflector.SetRequest(&listPullReq, new(spaceRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&listPullReq, new([]types.PullReqRepo), http.StatusOK)
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusBadRequest)
@@ -773,4 +780,101 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUsergroups, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opUsergroups, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups", opUsergroups)
+
+	opUsergroupCreate := openapi3.Operation{}
+	opUsergroupCreate.WithTags("space")
+	opUsergroupCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createUsergroup"})
+	_ = reflector.SetRequest(&opUsergroupCreate, struct {
+		spaceRequest
+		usergroup.CreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUsergroupCreate, new(types.UserGroupInfo), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opUsergroupCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUsergroupCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUsergroupCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUsergroupCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUsergroupCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/usergroups", opUsergroupCreate)
+
+	opUsergroupFind := openapi3.Operation{}
+	opUsergroupFind.WithTags("space")
+	opUsergroupFind.WithMapOfAnything(map[string]interface{}{"operationId": "findUsergroup"})
+	_ = reflector.SetRequest(&opUsergroupFind, new(userGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opUsergroupFind, new(types.UserGroupInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUsergroupFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUsergroupFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUsergroupFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUsergroupFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUsergroupFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups/{usergroup_identifier}", opUsergroupFind)
+
+	opUsergroupUpdate := openapi3.Operation{}
+	opUsergroupUpdate.WithTags("space")
+	opUsergroupUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateUsergroup"})
+	_ = reflector.SetRequest(&opUsergroupUpdate, struct {
+		userGroupRequest
+		usergroup.UpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUsergroupUpdate, new(types.UserGroupInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUsergroupUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUsergroupUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUsergroupUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUsergroupUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUsergroupUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/usergroups/{usergroup_identifier}", opUsergroupUpdate)
+
+	opUsergroupDelete := openapi3.Operation{}
+	opUsergroupDelete.WithTags("space")
+	opUsergroupDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUsergroup"})
+	_ = reflector.SetRequest(&opUsergroupDelete, new(userGroupRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUsergroupDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUsergroupDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUsergroupDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUsergroupDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUsergroupDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUsergroupDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/usergroups/{usergroup_identifier}", opUsergroupDelete)
+
+	opUsergroupMemberList := openapi3.Operation{}
+	opUsergroupMemberList.WithTags("space")
+	opUsergroupMemberList.WithMapOfAnything(map[string]interface{}{"operationId": "listUsergroupMembers"})
+	opUsergroupMemberList.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opUsergroupMemberList, new(userGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberList, new([]*types.UserGroupMember), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups/{usergroup_identifier}/members", opUsergroupMemberList)
+
+	opUsergroupMemberAdd := openapi3.Operation{}
+	opUsergroupMemberAdd.WithTags("space")
+	opUsergroupMemberAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addUsergroupMember"})
+	_ = reflector.SetRequest(&opUsergroupMemberAdd, struct {
+		userGroupRequest
+		usergroup.MemberAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberAdd, new(types.UserGroupMember), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/usergroups/{usergroup_identifier}/members", opUsergroupMemberAdd)
+
+	opUsergroupMemberDelete := openapi3.Operation{}
+	opUsergroupMemberDelete.WithTags("space")
+	opUsergroupMemberDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUsergroupMember"})
+	_ = reflector.SetRequest(&opUsergroupMemberDelete, struct {
+		userGroupRequest
+		UserUID string `path:"user_uid"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUsergroupMemberDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/usergroups/{usergroup_identifier}/members/{user_uid}", opUsergroupMemberDelete)
 }
@@ -42,6 +42,16 @@ type createRepoWebhookRequest struct {
 	types.WebhookCreateInput
 }
 
+type previewSpaceWebhookRequest struct {
+	spaceRequest
+	types.WebhookPreviewInput
+}
+
+type previewRepoWebhookRequest struct {
+	repoRequest
+	types.WebhookPreviewInput
+}
+
 type listSpaceWebhooksRequest struct {
 	spaceRequest
 }
@@ -86,6 +96,16 @@ type updateRepoWebhookRequest struct {
 	types.WebhookUpdateInput
 }
 
+type replaySpaceWebhookExecutionsRequest struct {
+	spaceWebhookRequest
+	types.WebhookReplayInput
+}
+
+type replayRepoWebhookExecutionsRequest struct {
+	repoWebhookRequest
+	types.WebhookReplayInput
+}
+
 type listSpaceWebhookExecutionsRequest struct {
 	spaceWebhookRequest
 }
@@ -178,6 +198,17 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listSpaceWebhooks, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/webhooks", listSpaceWebhooks)
 
+	previewSpaceWebhook := openapi3.Operation{}
+	previewSpaceWebhook.WithTags("webhook")
+	previewSpaceWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "previewSpaceWebhook"})
+	_ = reflector.SetRequest(&previewSpaceWebhook, new(previewSpaceWebhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&previewSpaceWebhook, new(types.WebhookPreviewOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&previewSpaceWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&previewSpaceWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&previewSpaceWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&previewSpaceWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/webhooks/preview", previewSpaceWebhook)
+
 	getSpaceWebhook := openapi3.Operation{}
 	getSpaceWebhook.WithTags("webhook")
 	getSpaceWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "getSpaceWebhook"})
@@ -243,6 +274,18 @@ func webhookOperations(reflector *openapi3.Reflector) {
 		getSpaceWebhookExecution,
 	)
 
+	replaySpaceWebhookExecutions := openapi3.Operation{}
+	replaySpaceWebhookExecutions.WithTags("webhook")
+	replaySpaceWebhookExecutions.WithMapOfAnything(map[string]interface{}{"operationId": "replaySpaceWebhookExecutions"})
+	_ = reflector.SetRequest(&replaySpaceWebhookExecutions, new(replaySpaceWebhookExecutionsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&replaySpaceWebhookExecutions, new(types.WebhookReplayOutput), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&replaySpaceWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&replaySpaceWebhookExecutions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&replaySpaceWebhookExecutions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&replaySpaceWebhookExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/executions/replay", replaySpaceWebhookExecutions)
+
 	retriggerSpaceWebhookExecution := openapi3.Operation{}
 	retriggerSpaceWebhookExecution.WithTags("webhook")
 	retriggerSpaceWebhookExecution.WithMapOfAnything(
@@ -285,6 +328,17 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listRepoWebhooks, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/webhooks", listRepoWebhooks)
 
+	previewRepoWebhook := openapi3.Operation{}
+	previewRepoWebhook.WithTags("webhook")
+	previewRepoWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "previewRepoWebhook"})
+	_ = reflector.SetRequest(&previewRepoWebhook, new(previewRepoWebhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&previewRepoWebhook, new(types.WebhookPreviewOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&previewRepoWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&previewRepoWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&previewRepoWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&previewRepoWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/webhooks/preview", previewRepoWebhook)
+
 	getRepoWebhook := openapi3.Operation{}
 	getRepoWebhook.WithTags("webhook")
 	getRepoWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "getRepoWebhook"})
@@ -346,6 +400,18 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/{webhook_execution_id}", getRepoWebhookExecution)
 
+	replayRepoWebhookExecutions := openapi3.Operation{}
+	replayRepoWebhookExecutions.WithTags("webhook")
+	replayRepoWebhookExecutions.WithMapOfAnything(map[string]interface{}{"operationId": "replayRepoWebhookExecutions"})
+	_ = reflector.SetRequest(&replayRepoWebhookExecutions, new(replayRepoWebhookExecutionsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&replayRepoWebhookExecutions, new(types.WebhookReplayOutput), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&replayRepoWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&replayRepoWebhookExecutions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&replayRepoWebhookExecutions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&replayRepoWebhookExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/replay", replayRepoWebhookExecutions)
+
 	retriggerRepoWebhookExecution := openapi3.Operation{}
 	retriggerRepoWebhookExecution.WithTags("webhook")
 	retriggerRepoWebhookExecution.WithMapOfAnything(map[string]interface{}{"operationId": "retriggerRepoWebhookExecution"})
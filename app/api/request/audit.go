@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	QueryParamAuditSpace       = "space"
+	QueryParamAuditAction      = "action"
+	QueryParamAuditPrincipalID = "principal_id"
+)
+
+// ParseAuditEventFilter extracts the audit event filter from the url.
+func ParseAuditEventFilter(r *http.Request) (*types.AuditEventFilter, error) {
+	created, err := ParseCreated(r)
+	if err != nil {
+		return nil, err
+	}
+
+	recursive, err := ParseRecursiveFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	principalIDs, err := QueryParamListAsPositiveInt64(r, QueryParamAuditPrincipalID)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTypes, _ := QueryParamList(r, QueryParamResourceType)
+	actions, _ := QueryParamList(r, QueryParamAuditAction)
+
+	return &types.AuditEventFilter{
+		Pagination: types.Pagination{
+			Page: ParsePage(r),
+			Size: ParseLimit(r),
+		},
+		CreatedFilter: created,
+		SpacePath:     QueryParamOrDefault(r, QueryParamAuditSpace, ""),
+		Recursive:     recursive,
+		ResourceTypes: resourceTypes,
+		Actions:       actions,
+		PrincipalIDs:  principalIDs,
+	}, nil
+}
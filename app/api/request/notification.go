@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamNotificationID                = "notification_id"
+	PathParamNotificationChannelIdentifier = "notification_channel_identifier"
+
+	QueryParamUnreadOnly       = "unread_only"
+	QueryParamNotificationType = "type"
+)
+
+// GetNotificationIDFromPath extracts the notification id from the url path.
+func GetNotificationIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamNotificationID)
+}
+
+// GetNotificationChannelIdentifierFromPath extracts the notification channel identifier from the url path.
+func GetNotificationChannelIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamNotificationChannelIdentifier)
+}
+
+// ParseNotificationFilter extracts the notification filter from the url.
+func ParseNotificationFilter(r *http.Request) (*types.NotificationFilter, error) {
+	unreadOnly, err := QueryParamAsBoolOrDefault(r, QueryParamUnreadOnly, false)
+	if err != nil {
+		return nil, err
+	}
+
+	typeStrings, _ := QueryParamList(r, QueryParamNotificationType)
+	notificationTypes := make([]enum.NotificationType, 0, len(typeStrings))
+	for _, s := range typeStrings {
+		if t, ok := enum.NotificationType(s).Sanitize(); ok {
+			notificationTypes = append(notificationTypes, t)
+		}
+	}
+
+	return &types.NotificationFilter{
+		Pagination: types.Pagination{
+			Page: ParsePage(r),
+			Size: ParseLimit(r),
+		},
+		UnreadOnly: unreadOnly,
+		Types:      notificationTypes,
+	}, nil
+}
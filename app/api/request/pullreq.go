@@ -30,6 +30,7 @@ const (
 	PathParamUserGroupID      = "user_group_id"
 	PathParamSourceBranch     = "source_branch"
 	PathParamTargetBranch     = "target_branch"
+	PathParamTemplateName     = "pullreq_template_name"
 
 	QueryParamCommenterID        = "commenter_id"
 	QueryParamReviewerID         = "reviewer_id"
@@ -39,6 +40,7 @@ const (
 	QueryParamSourceRepoRef      = "source_repo_ref"
 	QueryParamSourceBranch       = "source_branch"
 	QueryParamTargetBranch       = "target_branch"
+	QueryParamIncludeStack       = "include_stack"
 )
 
 func GetPullReqNumberFromPath(r *http.Request) (int64, error) {
@@ -64,6 +66,10 @@ func GetPullReqTargetBranchFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTargetBranch)
 }
 
+func GetPullReqTemplateNameFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamTemplateName)
+}
+
 func GetSourceRepoRefFromQueryOrDefault(r *http.Request, deflt string) string {
 	return QueryParamOrDefault(r, QueryParamSourceRepoRef, deflt)
 }
@@ -131,10 +137,16 @@ func ParsePullReqMetadataOptions(r *http.Request) (types.PullReqMetadataOptions,
 		return types.PullReqMetadataOptions{}, err
 	}
 
+	includeStack, err := QueryParamAsBoolOrDefault(r, QueryParamIncludeStack, false)
+	if err != nil {
+		return types.PullReqMetadataOptions{}, err
+	}
+
 	return types.PullReqMetadataOptions{
 		IncludeGitStats: includeGitStats,
 		IncludeChecks:   includeChecks,
 		IncludeRules:    includeRules,
+		IncludeStack:    includeStack,
 	}, nil
 }
 
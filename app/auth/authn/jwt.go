@@ -18,42 +18,55 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 
 	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
 )
 
 const (
 	headerTokenPrefixBearer = "Bearer "
 	//nolint:gosec // wrong flagging
 	HeaderTokenPrefixRemoteAuth = "RemoteAuth "
+
+	// tokenLastUsedUpdateInterval is the minimum duration between two updates of the last used time of a token.
+	tokenLastUsedUpdateInterval = time.Minute
 )
 
 var _ Authenticator = (*JWTAuthenticator)(nil)
 
 // JWTAuthenticator uses the provided JWT to authenticate the caller.
 type JWTAuthenticator struct {
-	cookieName     string
-	principalStore store.PrincipalStore
-	tokenStore     store.TokenStore
+	cookieName          string
+	clientIPHeader      string
+	clientIPTrustedHops int
+	principalStore      store.PrincipalStore
+	tokenStore          store.TokenStore
 }
 
 func NewTokenAuthenticator(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	cookieName string,
+	clientIPHeader string,
+	clientIPTrustedHops int,
 ) *JWTAuthenticator {
 	return &JWTAuthenticator{
-		cookieName:     cookieName,
-		principalStore: principalStore,
-		tokenStore:     tokenStore,
+		cookieName:          cookieName,
+		clientIPHeader:      clientIPHeader,
+		clientIPTrustedHops: clientIPTrustedHops,
+		principalStore:      principalStore,
+		tokenStore:          tokenStore,
 	}
 }
 
@@ -83,6 +96,10 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		return nil, fmt.Errorf("failed to get principal for token: %w", err)
 	}
 
+	if principal.Blocked {
+		return nil, errors.New("principal is blocked")
+	}
+
 	// Support for multiple secrets (comma-separated)
 	saltValues := strings.Split(principal.Salt, ",")
 	var lastErr error
@@ -102,7 +119,7 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 
 		if err == nil && parsedToken.Valid {
 			// Use the helper function to create the session
-			return createSessionFromClaims(ctx, a, principal, verifiedClaims)
+			return createSessionFromClaims(r, a, principal, verifiedClaims)
 		}
 
 		lastErr = err
@@ -117,10 +134,12 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 }
 
 func (a *JWTAuthenticator) metadataFromTokenClaims(
-	ctx context.Context,
+	r *http.Request,
 	principal *types.Principal,
 	tknClaims *jwt.SubClaimsToken,
 ) (auth.Metadata, error) {
+	ctx := r.Context()
+
 	// ensure tkn exists
 	tkn, err := a.tokenStore.Find(ctx, tknClaims.ID)
 	if err != nil {
@@ -135,12 +154,64 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 		)
 	}
 
+	if tkn.Scope != nil && len(tkn.Scope.IPRanges) > 0 {
+		clientIP := a.clientIP(r)
+		if !token.IPRangesContain(tkn.Scope.IPRanges, clientIP) {
+			return nil, fmt.Errorf("token %d is not allowed to be used from IP address %q", tkn.ID, clientIP)
+		}
+	}
+
+	a.updateLastUsed(ctx, tkn)
+
 	return &auth.TokenMetadata{
 		TokenType: tkn.Type,
 		TokenID:   tkn.ID,
+		Scope:     tkn.Scope,
 	}, nil
 }
 
+// updateLastUsed records the token usage. To limit the number of db writes,
+// the last used time is only updated if it's older than tokenLastUsedUpdateInterval.
+func (a *JWTAuthenticator) updateLastUsed(ctx context.Context, tkn *types.Token) {
+	now := time.Now().UnixMilli()
+	if tkn.LastUsed != nil && now-*tkn.LastUsed < tokenLastUsedUpdateInterval.Milliseconds() {
+		return
+	}
+
+	if err := a.tokenStore.UpdateLastUsed(ctx, tkn.ID, now); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("token_id", tkn.ID).Msg("failed to update token last used time")
+	}
+}
+
+// clientIP returns the IP address of the client. The configured client IP header is only taken into account
+// if set, as it's only trustworthy if the server is running behind a proxy that sets it.
+// For list headers like X-Forwarded-For the entries are counted from the right, as proxies append the address
+// they received the request from - the leftmost entries are provided by the client and can't be trusted.
+func (a *JWTAuthenticator) clientIP(r *http.Request) string {
+	if a.clientIPHeader != "" {
+		var ips []string
+		for _, value := range r.Header.Values(a.clientIPHeader) {
+			for _, ip := range strings.Split(value, ",") {
+				if ip = strings.TrimSpace(ip); ip != "" {
+					ips = append(ips, ip)
+				}
+			}
+		}
+
+		// fewer entries than trusted proxies means the header wasn't set by all of them, don't trust it.
+		if hops := max(a.clientIPTrustedHops, 1); len(ips) >= hops {
+			return ips[len(ips)-hops]
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
 func (a *JWTAuthenticator) metadataFromMembershipClaims(
 	mbsClaims *jwt.SubClaimsMembership,
 ) auth.Metadata {
@@ -195,7 +266,7 @@ func extractToken(r *http.Request, cookieName string) string {
 
 // createSessionFromClaims creates an auth session from verified JWT claims.
 func createSessionFromClaims(
-	ctx context.Context,
+	r *http.Request,
 	a *JWTAuthenticator,
 	principal *types.Principal,
 	claims *jwt.Claims,
@@ -203,7 +274,7 @@ func createSessionFromClaims(
 	var metadata auth.Metadata
 	switch {
 	case claims.Token != nil:
-		tokenMetadata, err := a.metadataFromTokenClaims(ctx, principal, claims.Token)
+		tokenMetadata, err := a.metadataFromTokenClaims(r, principal, claims.Token)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get metadata from token claims: %w", err)
 		}
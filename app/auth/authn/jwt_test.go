@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJWTAuthenticatorClientIP(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		trustedHops int
+		values      []string
+		want        string
+	}{
+		{
+			name: "no header configured",
+			want: "192.0.2.1",
+		},
+		{
+			name:   "header configured but missing",
+			header: "X-Forwarded-For",
+			want:   "192.0.2.1",
+		},
+		{
+			name:   "single value header",
+			header: "X-Real-IP",
+			values: []string{"10.0.0.1"},
+			want:   "10.0.0.1",
+		},
+		{
+			name:   "forged leftmost entry is ignored",
+			header: "X-Forwarded-For",
+			values: []string{"10.0.0.1, 203.0.113.7"},
+			want:   "203.0.113.7",
+		},
+		{
+			name:        "multiple trusted hops",
+			header:      "X-Forwarded-For",
+			trustedHops: 2,
+			values:      []string{"10.0.0.1, 203.0.113.7", "198.51.100.2"},
+			want:        "203.0.113.7",
+		},
+		{
+			name:        "fewer entries than trusted hops",
+			header:      "X-Forwarded-For",
+			trustedHops: 3,
+			values:      []string{"10.0.0.1, 203.0.113.7"},
+			want:        "192.0.2.1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewTokenAuthenticator(nil, nil, "token", test.header, test.trustedHops)
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = "192.0.2.1:1234"
+			for _, value := range test.values {
+				r.Header.Add(test.header, value)
+			}
+
+			if got := a.clientIP(r); got != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
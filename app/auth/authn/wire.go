@@ -31,5 +31,11 @@ func ProvideAuthenticator(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 ) Authenticator {
-	return NewTokenAuthenticator(principalStore, tokenStore, config.Token.CookieName)
+	return NewTokenAuthenticator(
+		principalStore,
+		tokenStore,
+		config.Token.CookieName,
+		config.Token.ClientIPHeader,
+		config.Token.ClientIPTrustedHops,
+	)
 }
@@ -17,6 +17,7 @@ package authz
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
@@ -34,17 +35,20 @@ var _ Authorizer = (*MembershipAuthorizer)(nil)
 type MembershipAuthorizer struct {
 	permissionCache PermissionCache
 	spaceFinder     refcache.SpaceFinder
+	repoFinder      refcache.RepoFinder
 	publicAccess    publicaccess.Service
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
 	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
 	publicAccess publicaccess.Service,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
 		spaceFinder:     spaceFinder,
+		repoFinder:      repoFinder,
 		publicAccess:    publicAccess,
 	}
 }
@@ -76,6 +80,15 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
+	// token restrictions apply to any principal, including system admins.
+	tokenMetadata, isToken := session.Metadata.(*auth.TokenMetadata)
+	if isToken && tokenMetadata.Scope != nil {
+		allowed, err := a.checkTokenScope(ctx, tokenMetadata.Scope, scope, resource, permission)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+
 	if session.Principal.Admin {
 		return true, nil // system admin can call any API
 	}
@@ -148,7 +161,8 @@ func (a *MembershipAuthorizer) Check(
 	}
 
 	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+	// (token scopes are known and were already enforced above).
+	if !isToken && session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
 		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
 	}
 
@@ -231,3 +245,87 @@ func (a *MembershipAuthorizer) checkWithAccessPermissionMetadata(
 
 	return false, fmt.Errorf("no %s permission provided", requestedPermission)
 }
+
+// checkTokenScope checks whether the requested permission is within the restrictions of the token scope.
+// Permissions of the principal itself are checked separately.
+func (a *MembershipAuthorizer) checkTokenScope(
+	ctx context.Context,
+	tokenScope *types.TokenScope,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	if len(tokenScope.Permissions) > 0 && !slices.Contains(tokenScope.Permissions, permission) {
+		log.Ctx(ctx).Debug().Msgf("[MembershipAuthorizer] permission %s is outside of token scope", permission)
+		return false, nil
+	}
+
+	if !tokenScope.RestrictsResources() {
+		return true, nil
+	}
+
+	var spacePath, repoPath string
+
+	//nolint:exhaustive // principal and service resources are outside of any space or repository.
+	switch resource.Type {
+	case enum.ResourceTypeUser, enum.ResourceTypeServiceAccount, enum.ResourceTypeService:
+		return false, nil
+
+	case enum.ResourceTypeSpace:
+		spacePath = paths.Concatenate(scope.SpacePath, resource.Identifier)
+
+	case enum.ResourceTypeRepo:
+		spacePath = scope.SpacePath
+		if resource.Identifier != "" {
+			repoPath = paths.Concatenate(scope.SpacePath, resource.Identifier)
+		}
+
+	default:
+		spacePath = scope.SpacePath
+		if scope.Repo != "" {
+			repoPath = paths.Concatenate(scope.SpacePath, scope.Repo)
+		}
+	}
+
+	for _, spaceID := range tokenScope.SpaceIDs {
+		space, err := a.spaceFinder.FindByID(ctx, spaceID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find token scope space: %w", err)
+		}
+
+		if isSameOrDescendantPath(space.Path, spacePath) {
+			return true, nil
+		}
+	}
+
+	if repoPath == "" {
+		return false, nil
+	}
+
+	for _, repoID := range tokenScope.RepoIDs {
+		repo, err := a.repoFinder.FindByID(ctx, repoID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find token scope repository: %w", err)
+		}
+
+		if strings.EqualFold(repo.Path, repoPath) {
+			return true, nil
+		}
+	}
+
+	log.Ctx(ctx).Debug().Msgf("[MembershipAuthorizer] resource in space '%s' is outside of token scope", spacePath)
+
+	return false, nil
+}
+
+// isSameOrDescendantPath returns true if the path is equal to or below the parent path (case insensitive).
+func isSameOrDescendantPath(parent string, path string) bool {
+	if parent == "" || path == "" {
+		return false
+	}
+
+	parent = strings.ToLower(parent)
+	path = strings.ToLower(path)
+
+	return path == parent || strings.HasPrefix(path, parent+types.PathSeparatorAsString)
+}
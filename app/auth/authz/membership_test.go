@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store/cache"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type mapCache[V any] map[int64]V
+
+func (c mapCache[V]) Stats() (int64, int64)        { return 0, 0 }
+func (c mapCache[V]) Evict(context.Context, int64) {}
+func (c mapCache[V]) Get(_ context.Context, key int64) (V, error) {
+	v, ok := c[key]
+	if !ok {
+		return v, gitness_store.ErrResourceNotFound
+	}
+	return v, nil
+}
+
+func TestCheckTokenScope(t *testing.T) {
+	a := &MembershipAuthorizer{
+		spaceFinder: refcache.NewSpaceFinder(mapCache[*types.SpaceCore]{
+			1: {ID: 1, Path: "Space/Team"},
+		}, nil, cache.Evictor[*types.SpaceCore]{}),
+		repoFinder: refcache.NewRepoFinder(nil, nil, mapCache[*types.RepositoryCore]{
+			2: {ID: 2, Path: "other/repo"},
+		}, nil, cache.Evictor[*types.RepositoryCore]{}),
+	}
+
+	tokenScope := &types.TokenScope{
+		Permissions: []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush},
+		SpaceIDs:    []int64{1},
+		RepoIDs:     []int64{2},
+	}
+
+	repo := func(spacePath, identifier string) (*types.Scope, *types.Resource) {
+		return &types.Scope{SpacePath: spacePath},
+			&types.Resource{Type: enum.ResourceTypeRepo, Identifier: identifier}
+	}
+
+	tests := []struct {
+		name       string
+		scope      *types.Scope
+		resource   *types.Resource
+		permission enum.Permission
+		want       bool
+	}{
+		{
+			name:       "repo in scoped space",
+			permission: enum.PermissionRepoPush,
+			want:       true,
+		},
+		{
+			name:       "repo in sub space of scoped space",
+			scope:      &types.Scope{SpacePath: "space/team/sub"},
+			permission: enum.PermissionRepoView,
+			want:       true,
+		},
+		{
+			name:       "permission outside of scope",
+			permission: enum.PermissionRepoEdit,
+			want:       false,
+		},
+		{
+			name:       "space with same prefix",
+			scope:      &types.Scope{SpacePath: "space/teams"},
+			permission: enum.PermissionRepoView,
+			want:       false,
+		},
+		{
+			name:       "scoped repo",
+			scope:      &types.Scope{SpacePath: "other"},
+			permission: enum.PermissionRepoView,
+			want:       true,
+		},
+		{
+			name:       "other repo in space of scoped repo",
+			scope:      &types.Scope{SpacePath: "other"},
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "second"},
+			permission: enum.PermissionRepoView,
+			want:       false,
+		},
+		{
+			name:       "user resource",
+			scope:      &types.Scope{},
+			resource:   &types.Resource{Type: enum.ResourceTypeUser, Identifier: "user"},
+			permission: enum.PermissionRepoView,
+			want:       false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scope, resource := repo("space/team", "repo")
+			if test.scope != nil {
+				scope = test.scope
+			}
+			if test.resource != nil {
+				resource = test.resource
+			}
+
+			got, err := a.checkTokenScope(context.Background(), tokenScope, scope, resource, test.permission)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckTokenScopePermissionsOnly(t *testing.T) {
+	a := &MembershipAuthorizer{}
+	tokenScope := &types.TokenScope{Permissions: []enum.Permission{enum.PermissionSpaceView}}
+
+	got, err := a.checkTokenScope(context.Background(), tokenScope,
+		&types.Scope{}, &types.Resource{Type: enum.ResourceTypeSpace, Identifier: "space"}, enum.PermissionSpaceView)
+	if err != nil || !got {
+		t.Errorf("expected permission to be granted, got %t, %v", got, err)
+	}
+
+	got, err = a.checkTokenScope(context.Background(), tokenScope,
+		&types.Scope{}, &types.Resource{Type: enum.ResourceTypeSpace, Identifier: "space"}, enum.PermissionSpaceEdit)
+	if err != nil || got {
+		t.Errorf("expected permission to be denied, got %t, %v", got, err)
+	}
+}
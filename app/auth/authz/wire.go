@@ -33,9 +33,10 @@ var WireSet = wire.NewSet(
 func ProvideAuthorizer(
 	pCache PermissionCache,
 	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
 	publicAccess publicaccess.Service,
 ) Authorizer {
-	return NewMembershipAuthorizer(pCache, spaceFinder, publicAccess)
+	return NewMembershipAuthorizer(pCache, spaceFinder, repoFinder, publicAccess)
 }
 
 func ProvidePermissionCache(
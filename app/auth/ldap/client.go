@@ -0,0 +1,353 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/app/auth/rolemapping"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+var (
+	ErrUserNotFound       = errors.New("user not found in the directory")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+const (
+	timeout    = 30 * time.Second
+	pagingSize = 500
+)
+
+// Config holds the configuration of the LDAP directory.
+type Config struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+
+	BindDN       string
+	BindPassword string
+
+	UserBaseDN           string
+	UserFilter           string
+	UserListFilter       string
+	UniqueIDAttribute    string
+	UsernameAttribute    string
+	EmailAttribute       string
+	DisplayNameAttribute string
+
+	GroupBaseDN          string
+	GroupFilter          string
+	GroupNameAttribute   string
+	GroupMemberAttribute string
+
+	// AutoProvision specifies whether unknown users are created on their first login.
+	AutoProvision bool
+	// LinkByEmail specifies whether an existing user is linked to the directory account with the same email.
+	LinkByEmail bool
+	// AdminGroups are the groups whose members are system administrators.
+	AdminGroups []string
+	// RoleMappings grant space memberships to the members of groups.
+	RoleMappings []rolemapping.Mapping
+}
+
+// User is a user account of the directory.
+type User struct {
+	DN          string
+	ID          string
+	Username    string
+	Email       string
+	DisplayName string
+}
+
+// Group is a group of the directory.
+type Group struct {
+	DN        string
+	Name      string
+	MemberDNs []string
+}
+
+// conn is the subset of the LDAP connection used by the client.
+type conn interface {
+	Bind(username, password string) error
+	SearchWithPaging(searchRequest *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error)
+	Close() error
+}
+
+// Client authenticates users with a bind against an LDAP directory and reads users and groups from it.
+type Client struct {
+	config Config
+	dial   func() (conn, error)
+}
+
+func NewClient(config Config) *Client {
+	c := &Client{config: config}
+	c.dial = c.dialLDAP
+	return c
+}
+
+// Config returns the configuration of the client.
+func (c *Client) Config() Config {
+	return c.config
+}
+
+// Authenticate searches the user with the configured user filter and verifies the password with a bind as the user.
+// Returns ErrUserNotFound if the user doesn't exist and ErrInvalidCredentials if the password is wrong.
+func (c *Client) Authenticate(_ context.Context, username, password string) (*User, []string, error) {
+	// an empty password would result in an unauthenticated bind, which always succeeds.
+	if username == "" || password == "" {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.connect()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close()
+
+	filter := strings.ReplaceAll(c.config.UserFilter, "%s", ldap.EscapeFilter(username))
+
+	entries, err := c.search(conn, c.config.UserBaseDN, filter, c.userAttributes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search user: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, nil, ErrUserNotFound
+	}
+	if len(entries) > 1 {
+		return nil, nil, fmt.Errorf("user filter matched %d entries for user %q", len(entries), username)
+	}
+
+	user := c.toUser(entries[0])
+
+	err = conn.Bind(user.DN, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return nil, nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to bind as user: %w", err)
+	}
+
+	// the user might not be allowed to read groups, so bind again with the service account.
+	if err = c.bindServiceAccount(conn); err != nil {
+		return nil, nil, err
+	}
+
+	groups, err := c.userGroups(conn, user.DN)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, groups, nil
+}
+
+// ListUsers returns all users matched by the user list filter.
+func (c *Client) ListUsers(context.Context) ([]*User, error) {
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entries, err := c.search(conn, c.config.UserBaseDN, c.config.UserListFilter, c.userAttributes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]*User, len(entries))
+	for i, entry := range entries {
+		users[i] = c.toUser(entry)
+	}
+
+	return users, nil
+}
+
+// ListGroups returns all groups matched by the group filter.
+func (c *Client) ListGroups(context.Context) ([]*Group, error) {
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	attributes := []string{c.config.GroupNameAttribute, c.config.GroupMemberAttribute}
+
+	entries, err := c.search(conn, c.groupBaseDN(), c.config.GroupFilter, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	groups := make([]*Group, len(entries))
+	for i, entry := range entries {
+		groups[i] = &Group{
+			DN:        entry.DN,
+			Name:      entry.GetAttributeValue(c.config.GroupNameAttribute),
+			MemberDNs: entry.GetAttributeValues(c.config.GroupMemberAttribute),
+		}
+	}
+
+	return groups, nil
+}
+
+// userGroups returns names of the groups the user is a member of.
+func (c *Client) userGroups(conn conn, userDN string) ([]string, error) {
+	filter := fmt.Sprintf("(&%s(%s=%s))",
+		c.config.GroupFilter, c.config.GroupMemberAttribute, ldap.EscapeFilter(userDN))
+
+	entries, err := c.search(conn, c.groupBaseDN(), filter, []string{c.config.GroupNameAttribute})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search groups of user: %w", err)
+	}
+
+	groups := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if name := entry.GetAttributeValue(c.config.GroupNameAttribute); name != "" {
+			groups = append(groups, name)
+		}
+	}
+
+	return groups, nil
+}
+
+func (c *Client) connect() (conn, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ldap server: %w", err)
+	}
+
+	if err = c.bindServiceAccount(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *Client) bindServiceAccount(conn conn) error {
+	if c.config.BindDN == "" {
+		return nil
+	}
+
+	if err := conn.Bind(c.config.BindDN, c.config.BindPassword); err != nil {
+		return fmt.Errorf("failed to bind with the service account: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) dialLDAP() (conn, error) {
+	//nolint:gosec // skipping the verification is an explicit opt-in for test setups.
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.config.InsecureSkipVerify}
+
+	l, err := ldap.DialURL(c.config.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	l.SetTimeout(timeout)
+
+	if c.config.StartTLS {
+		if err = l.StartTLS(tlsConfig); err != nil {
+			_ = l.Close()
+			return nil, fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	return l, nil
+}
+
+func (c *Client) search(conn conn, baseDN, filter string, attributes []string) ([]*ldap.Entry, error) {
+	req := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		int(timeout.Seconds()),
+		false,
+		filter,
+		attributes,
+		nil,
+	)
+
+	result, err := conn.SearchWithPaging(req, pagingSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Entries, nil
+}
+
+func (c *Client) userAttributes() []string {
+	attributes := []string{
+		c.config.UsernameAttribute,
+		c.config.EmailAttribute,
+		c.config.DisplayNameAttribute,
+	}
+
+	if c.config.UniqueIDAttribute != "" {
+		attributes = append(attributes, c.config.UniqueIDAttribute)
+	}
+
+	return attributes
+}
+
+func (c *Client) toUser(entry *ldap.Entry) *User {
+	user := &User{
+		DN:          entry.DN,
+		ID:          NormalizeDN(entry.DN),
+		Username:    entry.GetAttributeValue(c.config.UsernameAttribute),
+		Email:       entry.GetAttributeValue(c.config.EmailAttribute),
+		DisplayName: entry.GetAttributeValue(c.config.DisplayNameAttribute),
+	}
+
+	if c.config.UniqueIDAttribute != "" {
+		// binary identifiers (e.g. objectGUID of Active Directory) are hex encoded.
+		if id := entry.GetRawAttributeValue(c.config.UniqueIDAttribute); len(id) > 0 {
+			if utf8.Valid(id) {
+				user.ID = string(id)
+			} else {
+				user.ID = hex.EncodeToString(id)
+			}
+		}
+	}
+
+	return user
+}
+
+func (c *Client) groupBaseDN() string {
+	if c.config.GroupBaseDN != "" {
+		return c.config.GroupBaseDN
+	}
+	return c.config.UserBaseDN
+}
+
+// NormalizeDN returns the DN in a normalized form that can be used for comparisons.
+func NormalizeDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(dn))
+	}
+
+	return strings.ToLower(parsed.String())
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// fakeConn is an in-memory directory that supports the filters used by the client.
+type fakeConn struct {
+	passwords map[string]string
+	searches  map[string][]*ldap.Entry
+	binds     []string
+	filters   []string
+}
+
+func (c *fakeConn) Bind(username, password string) error {
+	c.binds = append(c.binds, username)
+	if pw, ok := c.passwords[username]; !ok || pw != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	return nil
+}
+
+func (c *fakeConn) SearchWithPaging(req *ldap.SearchRequest, _ uint32) (*ldap.SearchResult, error) {
+	c.filters = append(c.filters, req.Filter)
+	return &ldap.SearchResult{Entries: c.searches[req.BaseDN+"|"+req.Filter]}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func newTestClient(fc *fakeConn) *Client {
+	c := NewClient(Config{
+		BindDN:               "cn=admin,dc=example,dc=org",
+		BindPassword:         "admin",
+		UserBaseDN:           "ou=users,dc=example,dc=org",
+		UserFilter:           "(&(objectClass=inetOrgPerson)(uid=%s))",
+		UsernameAttribute:    "uid",
+		EmailAttribute:       "mail",
+		DisplayNameAttribute: "cn",
+		GroupBaseDN:          "ou=groups,dc=example,dc=org",
+		GroupFilter:          "(objectClass=groupOfNames)",
+		GroupNameAttribute:   "cn",
+		GroupMemberAttribute: "member",
+	})
+	c.dial = func() (conn, error) { return fc, nil }
+	return c
+}
+
+const johnDN = "uid=john,ou=users,dc=example,dc=org"
+
+func newTestConn() *fakeConn {
+	return &fakeConn{
+		passwords: map[string]string{
+			"cn=admin,dc=example,dc=org": "admin",
+			johnDN:                       "secret",
+		},
+		searches: map[string][]*ldap.Entry{
+			"ou=users,dc=example,dc=org|(&(objectClass=inetOrgPerson)(uid=john))": {
+				ldap.NewEntry(johnDN, map[string][]string{
+					"uid":  {"john"},
+					"mail": {"john@example.org"},
+					"cn":   {"John Doe"},
+				}),
+			},
+			"ou=groups,dc=example,dc=org|(&(objectClass=groupOfNames)(member=" + johnDN + "))": {
+				ldap.NewEntry("cn=developers,ou=groups,dc=example,dc=org", map[string][]string{
+					"cn": {"developers"},
+				}),
+			},
+		},
+	}
+}
+
+func TestClient_Authenticate(t *testing.T) {
+	conn := newTestConn()
+	client := newTestClient(conn)
+
+	user, groups, err := client.Authenticate(context.Background(), "john", "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedUser := &User{
+		DN:          johnDN,
+		ID:          johnDN,
+		Username:    "john",
+		Email:       "john@example.org",
+		DisplayName: "John Doe",
+	}
+	if !reflect.DeepEqual(expectedUser, user) {
+		t.Errorf("expected user %+v, got %+v", expectedUser, user)
+	}
+
+	if !reflect.DeepEqual([]string{"developers"}, groups) {
+		t.Errorf("expected groups [developers], got %v", groups)
+	}
+
+	expectedBinds := []string{"cn=admin,dc=example,dc=org", johnDN, "cn=admin,dc=example,dc=org"}
+	if !reflect.DeepEqual(expectedBinds, conn.binds) {
+		t.Errorf("expected binds %v, got %v", expectedBinds, conn.binds)
+	}
+}
+
+func TestClient_AuthenticateFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		password string
+		expected error
+	}{
+		{name: "unknown-user", username: "jane", password: "secret", expected: ErrUserNotFound},
+		{name: "wrong-password", username: "john", password: "wrong", expected: ErrInvalidCredentials},
+		{name: "empty-password", username: "john", password: "", expected: ErrInvalidCredentials},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(newTestConn())
+
+			_, _, err := client.Authenticate(context.Background(), test.username, test.password)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("expected error %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestClient_AuthenticateEscapesFilter(t *testing.T) {
+	conn := newTestConn()
+	client := newTestClient(conn)
+
+	_, _, err := client.Authenticate(context.Background(), "*)(uid=*", "secret")
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected user not found, got %v", err)
+	}
+
+	expected := `(&(objectClass=inetOrgPerson)(uid=\2a\29\28uid=\2a))`
+	if len(conn.filters) != 1 || conn.filters[0] != expected {
+		t.Errorf("expected filter %q, got %v", expected, conn.filters)
+	}
+}
+
+func TestNormalizeDN(t *testing.T) {
+	if a, b := NormalizeDN("UID=John, OU=Users,dc=example,dc=org"), NormalizeDN(johnDN); a != b {
+		t.Errorf("expected equal normalized DNs, got %q and %q", a, b)
+	}
+}
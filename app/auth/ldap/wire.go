@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth/rolemapping"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideClient,
+)
+
+// ProvideClient provides the LDAP client. Returns nil if LDAP login is disabled.
+func ProvideClient(config *types.Config) (*Client, error) {
+	if !config.LDAP.Enabled {
+		return nil, nil //nolint:nilnil // nil client means ldap login is disabled
+	}
+
+	if config.LDAP.URL == "" || config.LDAP.UserBaseDN == "" {
+		return nil, errors.New("ldap url and user base dn are required when ldap login is enabled")
+	}
+
+	if !strings.Contains(config.LDAP.UserFilter, "%s") {
+		return nil, fmt.Errorf("ldap user filter %q must contain the %%s placeholder for the username",
+			config.LDAP.UserFilter)
+	}
+
+	roleMappings, err := rolemapping.Parse(config.LDAP.RoleMappings)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(Config{
+		URL:                  config.LDAP.URL,
+		StartTLS:             config.LDAP.StartTLS,
+		InsecureSkipVerify:   config.LDAP.InsecureSkipVerify,
+		BindDN:               config.LDAP.BindDN,
+		BindPassword:         config.LDAP.BindPassword,
+		UserBaseDN:           config.LDAP.UserBaseDN,
+		UserFilter:           config.LDAP.UserFilter,
+		UserListFilter:       config.LDAP.UserListFilter,
+		UniqueIDAttribute:    config.LDAP.UniqueIDAttribute,
+		UsernameAttribute:    config.LDAP.UsernameAttribute,
+		EmailAttribute:       config.LDAP.EmailAttribute,
+		DisplayNameAttribute: config.LDAP.DisplayNameAttribute,
+		GroupBaseDN:          config.LDAP.GroupBaseDN,
+		GroupFilter:          config.LDAP.GroupFilter,
+		GroupNameAttribute:   config.LDAP.GroupNameAttribute,
+		GroupMemberAttribute: config.LDAP.GroupMemberAttribute,
+		AutoProvision:        config.LDAP.AutoProvision,
+		LinkByEmail:          config.LDAP.LinkByEmail,
+		AdminGroups:          config.LDAP.AdminGroups,
+		RoleMappings:         roleMappings,
+	}), nil
+}
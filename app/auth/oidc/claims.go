@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims contains the information about the user extracted from a verified ID token.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Username      string
+	Name          string
+	Groups        []string
+}
+
+func newClaims(m jwt.MapClaims, usernameClaim, groupsClaim string) *Claims {
+	c := &Claims{}
+
+	c.Subject, _ = m["sub"].(string)
+	c.Email, _ = m["email"].(string)
+	c.Name, _ = m["name"].(string)
+	c.Username, _ = m[usernameClaim].(string)
+
+	// some providers return the email_verified claim as a string.
+	switch v := m["email_verified"].(type) {
+	case bool:
+		c.EmailVerified = v
+	case string:
+		c.EmailVerified = strings.EqualFold(v, "true")
+	}
+
+	switch v := m[groupsClaim].(type) {
+	case string:
+		c.Groups = []string{v}
+	case []any:
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				c.Groups = append(c.Groups, s)
+			}
+		}
+	}
+
+	return c
+}
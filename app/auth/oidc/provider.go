@@ -0,0 +1,278 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/auth/rolemapping"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+var ErrInvalidToken = errors.New("invalid id token")
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+// Config holds the configuration of an OpenID Connect relying party.
+type Config struct {
+	Issuer        string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string
+	Scopes        []string
+	UsernameClaim string
+	GroupsClaim   string
+
+	// AutoProvision specifies whether unknown users are created on their first login.
+	AutoProvision bool
+	// LinkByEmail specifies whether an existing user is linked to the identity with the same verified email.
+	LinkByEmail bool
+	// AdminGroups are the groups whose members are system administrators.
+	AdminGroups []string
+	// RoleMappings grant space memberships to the members of groups.
+	RoleMappings []rolemapping.Mapping
+}
+
+// Provider implements the OpenID Connect authorization code flow with PKCE against a single OpenID provider.
+// The provider metadata is discovered lazily on first use, so that an unreachable provider
+// doesn't prevent the server from starting.
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mx        sync.Mutex
+	discovery *discovery
+	keys      map[string]any
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+func NewProvider(config Config, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &Provider{
+		config: config,
+		client: client,
+	}
+}
+
+// Config returns the configuration of the provider.
+func (p *Provider) Config() Config {
+	return p.config
+}
+
+// AuthCodeURL returns the URL of the provider's authorization endpoint
+// to which the user should be redirected to start the login.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	oauthConfig, err := p.oauth2Config(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return oauthConfig.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("nonce", nonce),
+		oauth2.S256ChallengeOption(verifier),
+	), nil
+}
+
+// Exchange exchanges the authorization code for the tokens and returns the claims of the verified ID token.
+func (p *Provider) Exchange(ctx context.Context, code, nonce, verifier string) (*Claims, error) {
+	oauthConfig, err := p.oauth2Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+
+	token, err := oauthConfig.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("%w: token response doesn't contain an id token", ErrInvalidToken)
+	}
+
+	return p.Verify(ctx, rawIDToken, nonce)
+}
+
+// Verify verifies the signature and the standard claims of the ID token and returns its claims.
+func (p *Provider) Verify(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mapClaims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, mapClaims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.getKey(ctx, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if tokenNonce, _ := mapClaims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+
+	claims := newClaims(mapClaims, p.config.UsernameClaim, p.config.GroupsClaim)
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+func (p *Provider) oauth2Config(ctx context.Context) (*oauth2.Config, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  d.AuthorizationEndpoint,
+			TokenURL: d.TokenEndpoint,
+		},
+		RedirectURL: p.config.RedirectURL,
+		Scopes:      p.config.Scopes,
+	}, nil
+}
+
+func (p *Provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	d := &discovery{}
+	err := p.getJSON(ctx, strings.TrimSuffix(p.config.Issuer, "/")+discoveryPath, d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider configuration: %w", err)
+	}
+
+	// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationValidation
+	if d.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("oidc provider issuer %q doesn't match the configured issuer %q",
+			d.Issuer, p.config.Issuer)
+	}
+
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, errors.New("oidc provider configuration is missing required endpoints")
+	}
+
+	p.discovery = d
+
+	return d, nil
+}
+
+// getKey returns the public key with the provided key ID.
+// Provider's keys are fetched again if the key is unknown, to support key rotation.
+func (p *Provider) getKey(ctx context.Context, kid string) (any, error) {
+	p.mx.Lock()
+	key, ok := p.keys[kid]
+	p.mx.Unlock()
+
+	if ok {
+		return key, nil
+	}
+
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &jsonWebKeySet{}
+	if err = p.getJSON(ctx, d.JWKSURI, set); err != nil {
+		return nil, fmt.Errorf("failed to fetch oidc provider keys: %w", err)
+	}
+
+	keys, err := set.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mx.Lock()
+	p.keys = keys
+	p.mx.Unlock()
+
+	key, ok = keys[kid]
+	if !ok {
+		// Tokens without a key ID are accepted if the provider has a single key.
+		if kid == "" && len(keys) == 1 {
+			for _, k := range keys {
+				return k, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	return key, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
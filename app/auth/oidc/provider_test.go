@@ -0,0 +1,287 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID     = "gitness"
+	testClientSecret = "secret"
+	testCode         = "auth-code"
+	testKeyID        = "key-1"
+)
+
+// mockProvider is a minimal OpenID provider that issues an ID token for a single authorization code.
+type mockProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+	nonce  string
+	pkce   string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	m := &mockProvider{t: t, key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(discovery{
+			Issuer:                m.server.URL,
+			AuthorizationEndpoint: m.server.URL + "/authorize",
+			TokenEndpoint:         m.server.URL + "/token",
+			JWKSURI:               m.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{{
+			Kid: testKeyID,
+			Kty: "RSA",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", m.handleToken)
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	return m
+}
+
+func (m *mockProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	clientID, clientSecret, _ := r.BasicAuth()
+	if clientID == "" {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+
+	verifierHash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+
+	if clientID != testClientID || clientSecret != testClientSecret ||
+		r.PostForm.Get("code") != testCode ||
+		base64.RawURLEncoding.EncodeToString(verifierHash[:]) != m.pkce {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   m.server.URL,
+		"aud":   testClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": m.nonce,
+	}
+	for k, v := range m.claims {
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKeyID
+
+	idToken, err := token.SignedString(m.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// authorize simulates the user's login on the provider's authorization endpoint.
+func (m *mockProvider) authorize(authURL string) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		m.t.Fatalf("failed to parse auth url: %s", err)
+	}
+
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" {
+		m.t.Fatalf("expected S256 code challenge method, got %q", q.Get("code_challenge_method"))
+	}
+
+	m.pkce = q.Get("code_challenge")
+	m.nonce = q.Get("nonce")
+}
+
+func (m *mockProvider) newProvider() *Provider {
+	return NewProvider(Config{
+		Issuer:        m.server.URL,
+		ClientID:      testClientID,
+		ClientSecret:  testClientSecret,
+		RedirectURL:   "http://localhost:3000/api/v1/login/oidc/callback",
+		Scopes:        []string{"openid", "email", "profile"},
+		UsernameClaim: "preferred_username",
+		GroupsClaim:   "groups",
+	}, m.server.Client())
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		claims        jwt.MapClaims
+		verifier      string
+		nonce         string
+		expected      *Claims
+		expectedError error
+	}{
+		{
+			name: "success",
+			claims: jwt.MapClaims{
+				"sub":                "user-1",
+				"email":              "john@example.com",
+				"email_verified":     true,
+				"preferred_username": "john",
+				"name":               "John Doe",
+				"groups":             []string{"developers", "admins"},
+			},
+			expected: &Claims{
+				Subject:       "user-1",
+				Email:         "john@example.com",
+				EmailVerified: true,
+				Username:      "john",
+				Name:          "John Doe",
+				Groups:        []string{"developers", "admins"},
+			},
+		},
+		{
+			name: "email-verified-as-string",
+			claims: jwt.MapClaims{
+				"sub":            "user-2",
+				"email":          "jane@example.com",
+				"email_verified": "true",
+				"groups":         "developers",
+			},
+			expected: &Claims{
+				Subject:       "user-2",
+				Email:         "jane@example.com",
+				EmailVerified: true,
+				Groups:        []string{"developers"},
+			},
+		},
+		{
+			name:          "wrong-audience",
+			claims:        jwt.MapClaims{"sub": "user-1", "aud": "other-client"},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "expired",
+			claims:        jwt.MapClaims{"sub": "user-1", "exp": time.Now().Add(-time.Hour).Unix()},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "nonce-mismatch",
+			claims:        jwt.MapClaims{"sub": "user-1"},
+			nonce:         "other-nonce",
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:     "wrong-verifier",
+			claims:   jwt.MapClaims{"sub": "user-1"},
+			verifier: "wrong-verifier",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newMockProvider(t)
+			mock.claims = test.claims
+
+			provider := mock.newProvider()
+
+			const (
+				state    = "state"
+				nonce    = "nonce"
+				verifier = "verifier-verifier-verifier-verifier-verifier"
+			)
+
+			authURL, err := provider.AuthCodeURL(ctx, state, nonce, verifier)
+			if err != nil {
+				t.Fatalf("failed to get auth code url: %s", err)
+			}
+
+			mock.authorize(authURL)
+
+			exchangeVerifier := verifier
+			if test.verifier != "" {
+				exchangeVerifier = test.verifier
+			}
+
+			exchangeNonce := nonce
+			if test.nonce != "" {
+				exchangeNonce = test.nonce
+			}
+
+			claims, err := provider.Exchange(ctx, testCode, exchangeNonce, exchangeVerifier)
+
+			if test.expected == nil {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if test.expectedError != nil && !errors.Is(err, test.expectedError) {
+					t.Fatalf("expected error %q, got %q", test.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(test.expected, claims) {
+				t.Errorf("expected claims %+v, got %+v", test.expected, claims)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"errors"
+
+	"github.com/harness/gitness/app/auth/rolemapping"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideProvider,
+)
+
+// ProvideProvider provides the OpenID Connect provider. Returns nil if OIDC login is disabled.
+func ProvideProvider(config *types.Config) (*Provider, error) {
+	if !config.OIDC.Enabled {
+		return nil, nil //nolint:nilnil // nil provider means oidc login is disabled
+	}
+
+	if config.OIDC.Issuer == "" || config.OIDC.ClientID == "" {
+		return nil, errors.New("oidc issuer and client id are required when oidc login is enabled")
+	}
+
+	roleMappings, err := rolemapping.Parse(config.OIDC.RoleMappings)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProvider(Config{
+		Issuer:        config.OIDC.Issuer,
+		ClientID:      config.OIDC.ClientID,
+		ClientSecret:  config.OIDC.ClientSecret,
+		RedirectURL:   config.OIDC.RedirectURL,
+		Scopes:        config.OIDC.Scopes,
+		UsernameClaim: config.OIDC.UsernameClaim,
+		GroupsClaim:   config.OIDC.GroupsClaim,
+		AutoProvision: config.OIDC.AutoProvision,
+		LinkByEmail:   config.OIDC.LinkByEmail,
+		AdminGroups:   config.OIDC.AdminGroups,
+		RoleMappings:  roleMappings,
+	}, nil), nil
+}
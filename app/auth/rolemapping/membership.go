@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rolemapping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ResolveSpaces returns the mapped spaces by their path. Spaces that don't exist are skipped.
+func ResolveSpaces(
+	ctx context.Context,
+	spaceFinder refcache.SpaceFinder,
+	mappings []Mapping,
+) (map[string]*types.SpaceCore, error) {
+	spaces := make(map[string]*types.SpaceCore)
+
+	for _, m := range mappings {
+		if _, ok := spaces[m.SpacePath]; ok {
+			continue
+		}
+
+		space, err := spaceFinder.FindByRef(ctx, m.SpacePath)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			log.Ctx(ctx).Warn().Msgf("space %q of role mapping not found", m.SpacePath)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %q: %w", m.SpacePath, err)
+		}
+
+		spaces[m.SpacePath] = space
+	}
+
+	return spaces, nil
+}
+
+// SyncMembership sets the membership role of the principal in the space, an empty role removes the membership.
+// New memberships are recorded as created by the provided principal.
+func SyncMembership(
+	ctx context.Context,
+	membershipStore store.MembershipStore,
+	spaceID, principalID int64,
+	role enum.MembershipRole,
+	createdBy int64,
+) error {
+	key := types.MembershipKey{SpaceID: spaceID, PrincipalID: principalID}
+	now := time.Now().UnixMilli()
+
+	membership, err := membershipStore.Find(ctx, key)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		if role == "" {
+			return nil
+		}
+
+		err = membershipStore.Create(ctx, &types.Membership{
+			MembershipKey: key,
+			CreatedBy:     createdBy,
+			Created:       now,
+			Updated:       now,
+			Role:          role,
+		})
+		if err != nil && !errors.Is(err, gitness_store.ErrDuplicate) {
+			return fmt.Errorf("failed to create membership: %w", err)
+		}
+
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find membership: %w", err)
+	}
+
+	if role == "" {
+		if err = membershipStore.Delete(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete membership: %w", err)
+		}
+		return nil
+	}
+
+	if membership.Role == role {
+		return nil
+	}
+
+	membership.Role = role
+	membership.Updated = now
+
+	if err = membershipStore.Update(ctx, membership); err != nil {
+		return fmt.Errorf("failed to update membership: %w", err)
+	}
+
+	return nil
+}
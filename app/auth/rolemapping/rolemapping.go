@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rolemapping maps groups of external identity providers to space membership roles.
+package rolemapping
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Mapping grants a space membership role to the members of a group.
+type Mapping struct {
+	Group     string
+	SpacePath string
+	Role      enum.MembershipRole
+}
+
+// Parse parses role mappings provided in the format "group=space/path:role".
+func Parse(mappings []string) ([]Mapping, error) {
+	result := make([]Mapping, 0, len(mappings))
+
+	for _, mapping := range mappings {
+		group, target, ok := strings.Cut(mapping, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid role mapping %q: expected format group=space/path:role", mapping)
+		}
+
+		spacePath, roleStr, ok := strings.Cut(target, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid role mapping %q: expected format group=space/path:role", mapping)
+		}
+
+		group = strings.TrimSpace(group)
+		spacePath = strings.Trim(strings.TrimSpace(spacePath), "/")
+		if group == "" || spacePath == "" {
+			return nil, fmt.Errorf("invalid role mapping %q: group and space path are required", mapping)
+		}
+
+		role, ok := enum.MembershipRole(strings.TrimSpace(roleStr)).Sanitize()
+		if !ok || role == "" {
+			return nil, fmt.Errorf("invalid role mapping %q: unknown role %q", mapping, roleStr)
+		}
+
+		result = append(result, Mapping{
+			Group:     group,
+			SpacePath: spacePath,
+			Role:      role,
+		})
+	}
+
+	return result, nil
+}
+
+// rolePriority is used to pick the role with the most permissions
+// when a user is a member of multiple groups that are mapped to the same space.
+var rolePriority = []enum.MembershipRole{
+	enum.MembershipRoleReader,
+	enum.MembershipRoleExecutor,
+	enum.MembershipRoleContributor,
+	enum.MembershipRoleSpaceOwner,
+}
+
+// SpaceRoles returns the membership role the members of the provided groups should have
+// in each of the mapped spaces.
+func SpaceRoles(mappings []Mapping, groups []string) map[string]enum.MembershipRole {
+	roles := make(map[string]enum.MembershipRole)
+
+	for _, m := range mappings {
+		if !slices.Contains(groups, m.Group) {
+			continue
+		}
+
+		existing, ok := roles[m.SpacePath]
+		if ok && slices.Index(rolePriority, existing) >= slices.Index(rolePriority, m.Role) {
+			continue
+		}
+
+		roles[m.SpacePath] = m.Role
+	}
+
+	return roles
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rolemapping
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParse(t *testing.T) {
+	mappings, err := Parse([]string{
+		"developers=acme/backend:contributor",
+		" admins = acme/ : space_owner",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []Mapping{
+		{Group: "developers", SpacePath: "acme/backend", Role: enum.MembershipRoleContributor},
+		{Group: "admins", SpacePath: "acme", Role: enum.MembershipRoleSpaceOwner},
+	}
+	if !reflect.DeepEqual(expected, mappings) {
+		t.Errorf("expected %+v, got %+v", expected, mappings)
+	}
+
+	for _, invalid := range []string{"developers", "developers=acme", "=acme:reader", "developers=acme:admin"} {
+		if _, err := Parse([]string{invalid}); err == nil {
+			t.Errorf("expected an error for mapping %q", invalid)
+		}
+	}
+}
+
+func TestSpaceRoles(t *testing.T) {
+	mappings := []Mapping{
+		{Group: "readers", SpacePath: "acme", Role: enum.MembershipRoleReader},
+		{Group: "owners", SpacePath: "acme", Role: enum.MembershipRoleSpaceOwner},
+		{Group: "developers", SpacePath: "acme/backend", Role: enum.MembershipRoleContributor},
+		{Group: "ops", SpacePath: "acme/infra", Role: enum.MembershipRoleExecutor},
+	}
+
+	roles := SpaceRoles(mappings, []string{"owners", "readers", "developers"})
+
+	expected := map[string]enum.MembershipRole{
+		"acme":         enum.MembershipRoleSpaceOwner,
+		"acme/backend": enum.MembershipRoleContributor,
+	}
+	if !reflect.DeepEqual(expected, roles) {
+		t.Errorf("expected %v, got %v", expected, roles)
+	}
+}
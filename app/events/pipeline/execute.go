@@ -46,3 +46,26 @@ func (r *Reader) RegisterExecuted(fn events.HandlerFunc[*ExecutedPayload],
 	opts ...events.HandlerOption) error {
 	return events.ReaderRegisterEvent(r.innerReader, ExecutedEvent, fn, opts...)
 }
+
+const ExecutionStartedEvent events.EventType = "execution-started"
+
+type ExecutionStartedPayload struct {
+	PipelineID   int64 `json:"pipeline_id"`
+	RepoID       int64 `json:"repo_id"`
+	ExecutionNum int64 `json:"execution_number"`
+}
+
+func (r *Reporter) ExecutionStarted(ctx context.Context, payload *ExecutionStartedPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ExecutionStartedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pipeline execution started event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pipeline execution started event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterExecutionStarted(fn events.HandlerFunc[*ExecutionStartedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, ExecutionStartedEvent, fn, opts...)
+}
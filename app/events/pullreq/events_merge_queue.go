@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MergeQueueSpeculatedEvent events.EventType = "merge-queue-speculated"
+
+// MergeQueueSpeculatedPayload is sent when the merge queue creates a speculative merge of a pull request.
+// The required status checks of the pull request are expected to be reported for the MergeSHA commit.
+type MergeQueueSpeculatedPayload struct {
+	Base
+	TargetBranch string `json:"target_branch"`
+	Ref          string `json:"ref"`
+	SourceSHA    string `json:"source_sha"`
+	BaseSHA      string `json:"base_sha"`
+	MergeSHA     string `json:"merge_sha"`
+}
+
+func (r *Reporter) MergeQueueSpeculated(ctx context.Context, payload *MergeQueueSpeculatedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeQueueSpeculatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge queue speculated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge queue speculated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeQueueSpeculated(
+	fn events.HandlerFunc[*MergeQueueSpeculatedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeQueueSpeculatedEvent, fn, opts...)
+}
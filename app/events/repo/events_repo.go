@@ -212,3 +212,67 @@ func (r *Reader) RegisterPushed(
 ) error {
 	return events.ReaderRegisterEvent(r.innerReader, PushedEvent, fn, opts...)
 }
+
+const RuleBypassedEvent events.EventType = "rule-bypassed"
+
+type RuleBypassedPayload struct {
+	Base
+	// Action is the action that was allowed by bypassing the rules, like "merged" or "deleted".
+	Action string `json:"action"`
+	// ResourceType is the type of the resource that was affected, like "branch" or "pull_request".
+	ResourceType string `json:"resource_type"`
+	// ResourceName is the human-readable name of the affected resource.
+	ResourceName string `json:"resource_name"`
+}
+
+func (r *Reporter) RuleBypassed(ctx context.Context, payload *RuleBypassedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, RuleBypassedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send rule bypassed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported rule bypassed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterRuleBypassed(
+	fn events.HandlerFunc[*RuleBypassedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, RuleBypassedEvent, fn, opts...)
+}
+
+const RenamedEvent events.EventType = "renamed"
+
+type RenamedPayload struct {
+	Base
+	OldIdentifier string `json:"old_identifier"`
+	NewIdentifier string `json:"new_identifier"`
+	OldPath       string `json:"old_path"`
+	NewPath       string `json:"new_path"`
+}
+
+func (r *Reporter) Renamed(ctx context.Context, payload *RenamedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, RenamedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send repo renamed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported repo renamed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterRenamed(
+	fn events.HandlerFunc[*RenamedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, RenamedEvent, fn, opts...)
+}
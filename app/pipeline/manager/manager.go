@@ -473,6 +473,7 @@ func (m *Manager) BeforeStage(_ context.Context, stage *types.Stage) error {
 		Steps:       m.Steps,
 		Stages:      m.Stages,
 		Users:       m.Users,
+		Reporter:    m.reporter,
 	}
 	//nolint:contextcheck
 	return s.do(noContext, stage)
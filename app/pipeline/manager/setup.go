@@ -19,6 +19,7 @@ import (
 	"errors"
 	"time"
 
+	events "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -38,6 +39,7 @@ type setup struct {
 	Steps       store.StepStore
 	Stages      store.StageStore
 	Users       store.PrincipalStore
+	Reporter    events.Reporter
 }
 
 func (s *setup) do(ctx context.Context, stage *types.Stage) error {
@@ -87,7 +89,7 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 		}
 	}
 
-	_, err = s.updateExecution(noContext, execution) //nolint:contextcheck
+	started, err := s.updateExecution(noContext, execution) //nolint:contextcheck
 	if err != nil {
 		log.Error().Err(err).Msg("manager: cannot update the execution")
 		return err
@@ -111,9 +113,22 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 
 	s.SSEStreamer.Publish(noContext, repo.ParentID, enum.SSETypeExecutionRunning, execution) //nolint:contextcheck
 
+	// only the first stage of an execution transitions it to running.
+	if started {
+		s.reportExecutionStarted(ctx, execution)
+	}
+
 	return nil
 }
 
+func (s *setup) reportExecutionStarted(ctx context.Context, execution *types.Execution) {
+	s.Reporter.ExecutionStarted(ctx, &events.ExecutionStartedPayload{
+		PipelineID:   execution.PipelineID,
+		RepoID:       execution.RepoID,
+		ExecutionNum: execution.Number,
+	})
+}
+
 // helper function that updates the execution status from pending to running.
 // This accounts for the fact that another agent may have already updated
 // the execution status, which may happen if two stages execute concurrently.
@@ -19,21 +19,26 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/auditlog"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
 	"github.com/harness/gitness/app/api/controller/infraprovider"
+	"github.com/harness/gitness/app/api/controller/issue"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/notification"
+	"github.com/harness/gitness/app/api/controller/notificationchannel"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/scim"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -45,15 +50,19 @@ import (
 	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
+	handlerauditlog "github.com/harness/gitness/app/api/handler/auditlog"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
 	handlergitspace "github.com/harness/gitness/app/api/handler/gitspace"
 	handlerinfraProvider "github.com/harness/gitness/app/api/handler/infraprovider"
+	handlerissue "github.com/harness/gitness/app/api/handler/issue"
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
 	handlermigrate "github.com/harness/gitness/app/api/handler/migrate"
+	handlernotification "github.com/harness/gitness/app/api/handler/notification"
+	handlernotificationchannel "github.com/harness/gitness/app/api/handler/notificationchannel"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
@@ -61,6 +70,7 @@ import (
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	handlerreposettings "github.com/harness/gitness/app/api/handler/reposettings"
 	"github.com/harness/gitness/app/api/handler/resource"
+	handlerscim "github.com/harness/gitness/app/api/handler/scim"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
@@ -117,6 +127,7 @@ func NewAPIHandler(
 	templateCtrl *template.Controller,
 	pluginCtrl *plugin.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
 	githookCtrl *controllergithook.Controller,
 	git git.Interface,
@@ -131,6 +142,10 @@ func NewAPIHandler(
 	infraProviderCtrl *infraprovider.Controller,
 	migrateCtrl *migrate.Controller,
 	gitspaceCtrl *gitspace.Controller,
+	auditLogCtrl *auditlog.Controller,
+	notificationCtrl *notification.Controller,
+	notificationChannelCtrl *notificationchannel.Controller,
+	scimCtrl *scim.Controller,
 	usageSender usage.Sender,
 ) http.Handler {
 	// Use go-chi router for inner routing.
@@ -162,9 +177,10 @@ func NewAPIHandler(
 			r.Use(middlewareauthn.Attempt(authenticator))
 
 			setupRoutesV1WithAuth(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl,
-				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
+				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl, issueCtrl,
 				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
-				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, usageSender)
+				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, auditLogCtrl, notificationCtrl,
+				notificationChannelCtrl, scimCtrl, usageSender)
 		})
 	})
 
@@ -201,6 +217,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	secretCtrl *secret.Controller,
 	spaceCtrl *space.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
 	githookCtrl *controllergithook.Controller,
 	git git.Interface,
@@ -214,25 +231,31 @@ func setupRoutesV1WithAuth(r chi.Router,
 	gitspaceCtrl *gitspace.Controller,
 	infraProviderCtrl *infraprovider.Controller,
 	migrateCtrl *migrate.Controller,
+	auditLogCtrl *auditlog.Controller,
+	notificationCtrl *notification.Controller,
+	notificationChannelCtrl *notificationchannel.Controller,
+	scimCtrl *scim.Controller,
 	usageSender usage.Sender,
 ) {
 	setupAccountWithAuth(r, userCtrl, config)
-	setupSpaces(r, appCtx, infraProviderCtrl, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl)
+	setupSpaces(r, appCtx, infraProviderCtrl, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl,
+		notificationChannelCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
-		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, usageSender)
+		logCtrl, pullreqCtrl, issueCtrl, webhookCtrl, checkCtrl, uploadCtrl, usageSender)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
-	setupUser(r, userCtrl)
+	setupUser(r, appCtx, userCtrl, notificationCtrl)
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, auditLogCtrl)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
 	setupInfraProviders(r, infraProviderCtrl)
 	setupGitspaces(r, gitspaceCtrl)
 	setupMigrate(r, migrateCtrl)
+	setupSCIM(r, scimCtrl)
 }
 
 // nolint: revive // it's the app context, it shouldn't be the first argument
@@ -244,6 +267,7 @@ func setupSpaces(
 	userGroupCtrl *usergroup.Controller,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
+	notificationChannelCtrl *notificationchannel.Controller,
 ) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -266,7 +290,21 @@ func setupSpaces(
 			r.Get("/pipelines", handlerspace.HandleListPipelines(spaceCtrl))
 			r.Get("/executions", handlerspace.HandleListExecutions(spaceCtrl))
 			r.Get("/repos", handlerspace.HandleListRepos(spaceCtrl))
-			r.Get("/usergroups", handlerUserGroup.HandleList(userGroupCtrl))
+			r.Route("/usergroups", func(r chi.Router) {
+				r.Get("/", handlerUserGroup.HandleList(userGroupCtrl))
+				r.Post("/", handlerUserGroup.HandleCreate(userGroupCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupIdentifier), func(r chi.Router) {
+					r.Get("/", handlerUserGroup.HandleFind(userGroupCtrl))
+					r.Patch("/", handlerUserGroup.HandleUpdate(userGroupCtrl))
+					r.Delete("/", handlerUserGroup.HandleDelete(userGroupCtrl))
+					r.Route("/members", func(r chi.Router) {
+						r.Get("/", handlerUserGroup.HandleMemberList(userGroupCtrl))
+						r.Post("/", handlerUserGroup.HandleMemberAdd(userGroupCtrl))
+						r.Delete(fmt.Sprintf("/{%s}", request.PathParamUserUID),
+							handlerUserGroup.HandleMemberDelete(userGroupCtrl))
+					})
+				})
+			})
 			r.Get("/service-accounts", handlerspace.HandleListServiceAccounts(spaceCtrl))
 			r.Get("/secrets", handlerspace.HandleListSecrets(spaceCtrl))
 			r.Get("/connectors", handlerspace.HandleListConnectors(spaceCtrl))
@@ -291,6 +329,7 @@ func setupSpaces(
 			SetupSpaceLabels(r, spaceCtrl)
 			SetupWebhookSpace(r, webhookCtrl)
 			SetupRulesSpace(r, spaceCtrl)
+			SetupNotificationChannelsSpace(r, notificationChannelCtrl)
 
 			r.Get("/checks/recent", handlercheck.HandleCheckListRecentSpace(checkCtrl))
 			r.Route("/usage", func(r chi.Router) {
@@ -323,10 +362,25 @@ func SetupSpaceLabels(r chi.Router, spaceCtrl *space.Controller) {
 	})
 }
 
+func SetupNotificationChannelsSpace(r chi.Router, notificationChannelCtrl *notificationchannel.Controller) {
+	r.Route("/notification-channels", func(r chi.Router) {
+		r.Post("/", handlernotificationchannel.HandleCreate(notificationChannelCtrl))
+		r.Get("/", handlernotificationchannel.HandleList(notificationChannelCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamNotificationChannelIdentifier), func(r chi.Router) {
+			r.Get("/", handlernotificationchannel.HandleFind(notificationChannelCtrl))
+			r.Patch("/", handlernotificationchannel.HandleUpdate(notificationChannelCtrl))
+			r.Delete("/", handlernotificationchannel.HandleDelete(notificationChannelCtrl))
+			r.Post("/test", handlernotificationchannel.HandleSendTestMessage(notificationChannelCtrl))
+		})
+	})
+}
+
 func SetupWebhookSpace(r chi.Router, webhookCtrl *webhook.Controller) {
 	r.Route("/webhooks", func(r chi.Router) {
 		r.Post("/", handlerwebhook.HandleCreateSpace(webhookCtrl))
 		r.Get("/", handlerwebhook.HandleListSpace(webhookCtrl))
+		r.Post("/preview", handlerwebhook.HandlePreviewSpace(webhookCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookIdentifier), func(r chi.Router) {
 			r.Get("/", handlerwebhook.HandleFindSpace(webhookCtrl))
@@ -335,6 +389,7 @@ func SetupWebhookSpace(r chi.Router, webhookCtrl *webhook.Controller) {
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutionsSpace(webhookCtrl))
+				r.Post("/replay", handlerwebhook.HandleReplayExecutionsSpace(webhookCtrl))
 
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
 					r.Get("/", handlerwebhook.HandleFindExecutionSpace(webhookCtrl))
@@ -366,6 +421,7 @@ func setupRepos(r chi.Router,
 	triggerCtrl *trigger.Controller,
 	logCtrl *logs.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
@@ -475,6 +531,8 @@ func setupRepos(r chi.Router,
 
 			SetupPullReq(r, pullreqCtrl)
 
+			SetupIssues(r, issueCtrl)
+
 			SetupWebhookRepo(r, webhookCtrl)
 
 			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
@@ -657,6 +715,11 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			handlerpullreq.HandleFindByBranches(pullreqCtrl),
 		)
 		r.Get("/candidates", handlerpullreq.HandlePRBranchCandidates(pullreqCtrl))
+		r.Get("/merge-queue", handlerpullreq.HandleMergeQueueList(pullreqCtrl))
+		r.Route("/templates", func(r chi.Router) {
+			r.Get("/", handlerpullreq.HandleTemplateList(pullreqCtrl))
+			r.Get(fmt.Sprintf("/{%s}", request.PathParamTemplateName), handlerpullreq.HandleTemplateFind(pullreqCtrl))
+		})
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqNumber), func(r chi.Router) {
 			r.Get("/", handlerpullreq.HandleFind(pullreqCtrl))
@@ -690,8 +753,20 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			})
 			r.Route("/reviews", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
+				r.Delete("/pending", handlerpullreq.HandleReviewPendingDiscard(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Post("/update-branch", handlerpullreq.HandleUpdateBranch(pullreqCtrl))
+			r.Route("/merge-queue", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleMergeQueueFind(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleMergeQueueEnqueue(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMergeQueueDequeue(pullreqCtrl))
+			})
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleAutoMergeFind(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisable(pullreqCtrl))
+			})
 			r.Post("/revert", handlerpullreq.HandleRevert(pullreqCtrl))
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
@@ -701,6 +776,10 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			})
 
 			r.Put("/target-branch", handlerpullreq.HandleChangeTargetBranch(pullreqCtrl))
+			r.Route("/stack-parent", func(r chi.Router) {
+				r.Put("/", handlerpullreq.HandleStackParentSet(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleStackParentRemove(pullreqCtrl))
+			})
 
 			r.Route("/file-views", func(r chi.Router) {
 				r.Put("/", handlerpullreq.HandleFileViewAdd(pullreqCtrl))
@@ -717,6 +796,47 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	})
 }
 
+func SetupIssues(r chi.Router, issueCtrl *issue.Controller) {
+	r.Route("/issues", func(r chi.Router) {
+		r.Post("/", handlerissue.HandleCreate(issueCtrl))
+		r.Get("/", handlerissue.HandleList(issueCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamIssueNumber), func(r chi.Router) {
+			r.Get("/", handlerissue.HandleFind(issueCtrl))
+			r.Patch("/", handlerissue.HandleUpdate(issueCtrl))
+			r.Post("/state", handlerissue.HandleState(issueCtrl))
+			r.Put("/milestone", handlerissue.HandleSetMilestone(issueCtrl))
+			r.Get("/activities", handlerissue.HandleActivityList(issueCtrl))
+			r.Route("/comments", func(r chi.Router) {
+				r.Post("/", handlerissue.HandleCommentCreate(issueCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamIssueCommentID), func(r chi.Router) {
+					r.Patch("/", handlerissue.HandleCommentUpdate(issueCtrl))
+					r.Delete("/", handlerissue.HandleCommentDelete(issueCtrl))
+				})
+			})
+			r.Route("/assignees", func(r chi.Router) {
+				r.Put("/", handlerissue.HandleAssigneeAdd(issueCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamAssigneeID), handlerissue.HandleAssigneeDelete(issueCtrl))
+			})
+			r.Route("/labels", func(r chi.Router) {
+				r.Put("/", handlerissue.HandleAssignLabel(issueCtrl))
+				r.Get("/", handlerissue.HandleListLabels(issueCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamLabelID), handlerissue.HandleUnassignLabel(issueCtrl))
+			})
+		})
+	})
+
+	r.Route("/milestones", func(r chi.Router) {
+		r.Post("/", handlerissue.HandleMilestoneCreate(issueCtrl))
+		r.Get("/", handlerissue.HandleMilestoneList(issueCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamMilestoneID), func(r chi.Router) {
+			r.Get("/", handlerissue.HandleMilestoneFind(issueCtrl))
+			r.Patch("/", handlerissue.HandleMilestoneUpdate(issueCtrl))
+			r.Delete("/", handlerissue.HandleMilestoneDelete(issueCtrl))
+		})
+	})
+}
+
 func setupPullReqLabels(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	r.Route("/labels", func(r chi.Router) {
 		r.Put("/", handlerpullreq.HandleAssignLabel(pullreqCtrl))
@@ -731,6 +851,7 @@ func SetupWebhookRepo(r chi.Router, webhookCtrl *webhook.Controller) {
 	r.Route("/webhooks", func(r chi.Router) {
 		r.Post("/", handlerwebhook.HandleCreateRepo(webhookCtrl))
 		r.Get("/", handlerwebhook.HandleListRepo(webhookCtrl))
+		r.Post("/preview", handlerwebhook.HandlePreviewRepo(webhookCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookIdentifier), func(r chi.Router) {
 			r.Get("/", handlerwebhook.HandleFindRepo(webhookCtrl))
@@ -739,6 +860,7 @@ func SetupWebhookRepo(r chi.Router, webhookCtrl *webhook.Controller) {
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutionsRepo(webhookCtrl))
+				r.Post("/replay", handlerwebhook.HandleReplayExecutionsRepo(webhookCtrl))
 
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
 					r.Get("/", handlerwebhook.HandleFindExecutionRepo(webhookCtrl))
@@ -772,7 +894,13 @@ func SetupRulesRepo(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
-func setupUser(r chi.Router, userCtrl *user.Controller) {
+// nolint: revive // it's the app context, it shouldn't be the first argument
+func setupUser(
+	r chi.Router,
+	appCtx context.Context,
+	userCtrl *user.Controller,
+	notificationCtrl *notification.Controller,
+) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
 		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
@@ -817,6 +945,22 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			r.Delete(fmt.Sprintf("/{%s}", request.PathParamResourceID),
 				handleruser.HandleDeleteFavorite(userCtrl))
 		})
+
+		// Notifications
+		r.Route("/notifications", func(r chi.Router) {
+			r.Get("/", handlernotification.HandleList(notificationCtrl))
+			r.Get("/events", handlernotification.HandleEvents(appCtx, notificationCtrl))
+			r.Post("/mark-all-read", handlernotification.HandleMarkAllRead(notificationCtrl))
+			r.Patch(fmt.Sprintf("/{%s}", request.PathParamNotificationID),
+				handlernotification.HandleUpdate(notificationCtrl))
+		})
+
+		r.Route("/notification-subscriptions", func(r chi.Router) {
+			r.Get("/", handlernotification.HandleListSubscriptions(notificationCtrl))
+			r.Put("/", handlernotification.HandleUpsertSubscription(notificationCtrl))
+			r.Delete(fmt.Sprintf("/{%s}", request.PathParamResourceID),
+				handlernotification.HandleDeleteSubscription(notificationCtrl))
+		})
 	})
 }
 
@@ -896,7 +1040,7 @@ func setupInfraProviders(r chi.Router, infraProviderCtrl *infraprovider.Controll
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(r chi.Router, userCtrl *user.Controller, auditLogCtrl *auditlog.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -910,6 +1054,10 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
 			})
 		})
+		r.Route("/audit-logs", func(r chi.Router) {
+			r.Get("/", handlerauditlog.HandleList(auditLogCtrl))
+			r.Get("/export", handlerauditlog.HandleExport(auditLogCtrl))
+		})
 	})
 }
 
@@ -921,6 +1069,8 @@ func setupAccountWithoutAuth(
 ) {
 	cookieName := config.Token.CookieName
 	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
+	r.Get("/login/oidc", account.HandleLoginOIDC(userCtrl, cookieName))
+	r.Get("/login/oidc/callback", account.HandleLoginOIDCCallback(userCtrl, cookieName, config.URL.UI))
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
 }
 
@@ -948,3 +1098,29 @@ func setupMigrate(r chi.Router, migCtrl *migrate.Controller) {
 		})
 	})
 }
+
+func setupSCIM(r chi.Router, scimCtrl *scim.Controller) {
+	r.Route("/scim/v2", func(r chi.Router) {
+		r.Route("/Users", func(r chi.Router) {
+			r.Get("/", handlerscim.HandleListUsers(scimCtrl))
+			r.Post("/", handlerscim.HandleCreateUser(scimCtrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamSCIMResourceID), func(r chi.Router) {
+				r.Get("/", handlerscim.HandleFindUser(scimCtrl))
+				r.Put("/", handlerscim.HandleReplaceUser(scimCtrl))
+				r.Patch("/", handlerscim.HandlePatchUser(scimCtrl))
+				r.Delete("/", handlerscim.HandleDeleteUser(scimCtrl))
+			})
+		})
+
+		r.Route("/Groups", func(r chi.Router) {
+			r.Get("/", handlerscim.HandleListGroups(scimCtrl))
+			r.Post("/", handlerscim.HandleCreateGroup(scimCtrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamSCIMResourceID), func(r chi.Router) {
+				r.Get("/", handlerscim.HandleFindGroup(scimCtrl))
+				r.Put("/", handlerscim.HandleReplaceGroup(scimCtrl))
+				r.Patch("/", handlerscim.HandlePatchGroup(scimCtrl))
+				r.Delete("/", handlerscim.HandleDeleteGroup(scimCtrl))
+			})
+		})
+	})
+}
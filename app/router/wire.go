@@ -18,22 +18,27 @@ import (
 	"context"
 	"strings"
 
+	"github.com/harness/gitness/app/api/controller/auditlog"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
 	"github.com/harness/gitness/app/api/controller/infraprovider"
+	"github.com/harness/gitness/app/api/controller/issue"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/notification"
+	"github.com/harness/gitness/app/api/controller/notificationchannel"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/scim"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -93,6 +98,7 @@ func ProvideRouter(
 	templateCtrl *template.Controller,
 	pluginCtrl *plugin.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
 	githookCtrl *githook.Controller,
 	git git.Interface,
@@ -112,6 +118,10 @@ func ProvideRouter(
 	registryRouter router.AppRouter,
 	usageSender usage.Sender,
 	lfsCtrl *lfs.Controller,
+	auditLogCtrl *auditlog.Controller,
+	notificationCtrl *notification.Controller,
+	notificationChannelCtrl *notificationchannel.Controller,
+	scimCtrl *scim.Controller,
 ) *Router {
 	routers := make([]Interface, 4)
 
@@ -130,9 +140,10 @@ func ProvideRouter(
 	apiHandler := NewAPIHandler(
 		appCtx, config,
 		authenticator, repoCtrl, repoSettingsCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
-		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
+		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, issueCtrl, webhookCtrl,
 		githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl,
-		infraProviderCtrl, migrateCtrl, gitspaceCtrl, usageSender)
+		infraProviderCtrl, migrateCtrl, gitspaceCtrl, auditLogCtrl, notificationCtrl,
+		notificationChannelCtrl, scimCtrl, usageSender)
 	routers[2] = NewAPIRouter(apiHandler)
 
 	sec := NewSecure(config)
@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/uuid"
+)
+
+const dataKeyRequestID = "requestID"
+
+var _ audit.Service = (*Service)(nil)
+
+// Service is an audit.Service that persists the audit events in the database.
+type Service struct {
+	auditEventStore store.AuditEventStore
+}
+
+func NewService(auditEventStore store.AuditEventStore) *Service {
+	return &Service{
+		auditEventStore: auditEventStore,
+	}
+}
+
+// Log validates and stores an audit event.
+func (s *Service) Log(
+	ctx context.Context,
+	user types.Principal,
+	resource audit.Resource,
+	action audit.Action,
+	spacePath string,
+	options ...audit.Option,
+) error {
+	event := audit.Event{
+		Timestamp:     time.Now().UnixMilli(),
+		Action:        action,
+		User:          user,
+		SpacePath:     spacePath,
+		Resource:      resource,
+		ClientIP:      audit.GetRealIP(ctx),
+		RequestMethod: audit.GetRequestMethod(ctx),
+	}
+
+	if requestID := audit.GetRequestID(ctx); requestID != "" {
+		event.Data = map[string]string{dataKeyRequestID: requestID}
+	}
+
+	for _, opt := range options {
+		opt.Apply(&event)
+	}
+
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid audit event: %w", err)
+	}
+
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+
+	auditEvent, err := mapEvent(&event)
+	if err != nil {
+		return err
+	}
+
+	if err := s.auditEventStore.Create(ctx, auditEvent); err != nil {
+		return fmt.Errorf("failed to store audit event: %w", err)
+	}
+
+	return nil
+}
+
+func mapEvent(event *audit.Event) (*types.AuditEvent, error) {
+	oldObject, err := marshalObject(event.DiffObject.OldObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal old object: %w", err)
+	}
+
+	newObject, err := marshalObject(event.DiffObject.NewObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal new object: %w", err)
+	}
+
+	return &types.AuditEvent{
+		Identifier:         event.ID,
+		Timestamp:          event.Timestamp,
+		Action:             string(event.Action),
+		ResourceType:       string(event.Resource.Type),
+		ResourceIdentifier: event.Resource.Identifier,
+		ResourceData:       event.Resource.Data,
+		SpacePath:          event.SpacePath,
+		Principal:          *event.User.ToPrincipalInfo(),
+		OldObject:          oldObject,
+		NewObject:          newObject,
+		ClientIP:           event.ClientIP,
+		RequestMethod:      event.RequestMethod,
+		Data:               event.Data,
+	}, nil
+}
+
+func marshalObject(object any) (json.RawMessage, error) {
+	if object == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(object)
+}
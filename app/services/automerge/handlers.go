@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+)
+
+func (s *Service) handleEventPullReqBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.Process(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handleEventPullReqTargetBranchChanged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.TargetBranchChangedPayload],
+) error {
+	return s.Process(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handleEventPullReqReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	return s.Process(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handleEventPullReqCommentStatusUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CommentStatusUpdatedPayload],
+) error {
+	return s.Process(ctx, event.Payload.PullReqID)
+}
+
+// handleEventPullReqUpdated re-evaluates the pull request because it might have been marked as ready for review.
+func (s *Service) handleEventPullReqUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.UpdatedPayload],
+) error {
+	return s.Process(ctx, event.Payload.PullReqID)
+}
+
+// handleEventPullReqClosed removes the auto-merge of the closed pull request.
+func (s *Service) handleEventPullReqClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.Process(ctx, event.Payload.PullReqID)
+}
+
+// handleEventPullReqMerged removes the auto-merge of a pull request that has been merged by other means.
+// It also re-evaluates pull requests of the repository, because merging might have unblocked stacked pull requests.
+func (s *Service) handleEventPullReqMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	if err := s.Process(ctx, event.Payload.PullReqID); err != nil {
+		return err
+	}
+
+	_, _, err := s.processPullReqs(ctx, event.Payload.TargetRepoID, nil)
+
+	return err
+}
+
+// handleEventCheckReported re-evaluates the pull requests whose source branch commit got a completed status check.
+func (s *Service) handleEventCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	_, _, err := s.processPullReqs(ctx, event.Payload.RepoID, func(am *types.AutoMerge) bool {
+		return am.SourceSHA == event.Payload.SHA
+	})
+
+	return err
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/job"
+)
+
+const jobType = "pullreq-auto-merge"
+
+var _ job.Handler = (*Service)(nil)
+
+// Register schedules the recurring job that re-evaluates all pull requests with enabled auto-merge.
+// The status checks reported by pipelines are picked up by this job.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.config.CRON, s.config.MaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pull request auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the pull request auto-merge background job handler.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	processed, failed, err := s.processPullReqs(ctx, 0, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("processed %d pull requests with auto-merge, %d failed", processed, failed), nil
+}
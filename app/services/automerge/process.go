@@ -0,0 +1,366 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/contextutil"
+	gitness_errors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// Process re-evaluates the merge requirements of the pull request with enabled auto-merge
+// and merges the pull request if all of them are satisfied.
+// If the auto-merge is not enabled for the pull request, the function does nothing.
+func (s *Service) Process(ctx context.Context, pullReqID int64) error {
+	am, err := s.autoMergeStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	// Merges are serialized on the repository level, the same lock is used by the merge API.
+	unlock, err := s.locker.LockPR(ctx, am.RepoID, 0, processTimeout+30*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for pull request auto-merge: %w", err)
+	}
+	defer unlock()
+
+	ctx, cancel := contextutil.WithNewTimeout(ctx, processTimeout)
+	defer cancel()
+
+	// reload, the auto-merge might have been disabled while waiting for the lock.
+	am, err = s.autoMergeStore.Find(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, pullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	return s.process(ctx, am, pr)
+}
+
+//nolint:gocognit,cyclop // the merge requirements are easier to follow in a single function.
+func (s *Service) process(ctx context.Context, am *types.AutoMerge, pr *types.PullReq) error {
+	systemPrincipalID := bootstrap.NewSystemServiceSession().Principal.ID
+
+	if pr.State != enum.PullReqStateOpen {
+		return s.disable(ctx, pr, systemPrincipalID, "")
+	}
+
+	if pr.SourceSHA != am.SourceSHA {
+		if am.DisableOnPush {
+			return s.disable(ctx, pr, systemPrincipalID, "New commits have been pushed to the source branch.")
+		}
+
+		if err := s.autoMergeStore.UpdateSourceSHA(ctx, pr.ID, pr.SourceSHA); err != nil {
+			return fmt.Errorf("failed to update source SHA of pull request auto-merge: %w", err)
+		}
+
+		am.SourceSHA = pr.SourceSHA
+	}
+
+	if pr.IsDraft {
+		return nil
+	}
+
+	if pr.ParentID != nil {
+		parent, err := s.pullreqStore.Find(ctx, *pr.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to find parent pull request: %w", err)
+		}
+
+		if parent.State == enum.PullReqStateOpen {
+			return nil // the parent pull request must be merged first
+		}
+	}
+
+	if hasKnownConflicts(pr, am.MergeMethod) {
+		return nil // the pull request's merge check status is reset when either branch is updated
+	}
+
+	principal, err := s.principalStore.Find(ctx, am.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal who enabled the auto-merge: %w", err)
+	}
+
+	session := &auth.Session{Principal: *principal}
+
+	targetRepo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = s.repoFinder.FindByID(ctx, pr.SourceRepoID)
+		if err != nil {
+			return fmt.Errorf("failed to find source repository: %w", err)
+		}
+	}
+
+	err = apiauth.CheckRepo(ctx, s.authorizer, session, targetRepo, enum.PermissionRepoPush)
+	if apiauth.IsNoAccess(err) {
+		return s.disable(ctx, pr, systemPrincipalID,
+			fmt.Sprintf("%s is no longer allowed to merge the pull request.", principal.DisplayName))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check access of the principal who enabled the auto-merge: %w", err)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, s.authorizer, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	rules, err := s.protectionManager.ListRepoBranchRules(ctx, targetRepo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load list of reviewers: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, targetRepo.ID, pr.SourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	codeOwnerWithApproval, err := s.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	changedFiles, err := s.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.CreateReadParams(sourceRepo),
+		BaseRef:    pr.MergeBaseSHA,
+		HeadRef:    pr.SourceSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get changed files of pull request: %w", err)
+	}
+
+	ruleOut, violations, err := rules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupID:    s.userGroupService.ListUserIDsByGroupIDs,
+		Actor:                 principal,
+		AllowBypass:           false,
+		IsRepoOwner:           isRepoOwner,
+		TargetRepo:            targetRepo,
+		SourceRepo:            sourceRepo,
+		PullReq:               pr,
+		Reviewers:             reviewers,
+		Method:                am.MergeMethod,
+		CheckResults:          checkResults,
+		CodeOwners:            codeOwnerWithApproval,
+		ChangedFiles:          changedFiles.Files,
+		UnverifiedCommits:     s.signatureVerify.UnverifiedPullReqCommits(sourceRepo, pr),
+		SourceUpToDate:        protection.SourceUpToDate(s.git, targetRepo, pr),
+		SourceHasMergeCommits: protection.SourceHasMergeCommits(s.git, targetRepo, pr),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		log.Ctx(ctx).Debug().Msgf("auto-merge of pull request %d is blocked by rule violations", pr.Number)
+		return nil
+	}
+
+	return s.merge(ctx, am, pr, principal, targetRepo, sourceRepo, am.DeleteSourceBranch || ruleOut.DeleteSourceBranch)
+}
+
+// hasKnownConflicts returns true if the last merge check of the pull request found conflicts for the merge method.
+func hasKnownConflicts(pr *types.PullReq, method enum.MergeMethod) bool {
+	switch method {
+	case enum.MergeMethodMerge, enum.MergeMethodSquash:
+		return pr.MergeCheckStatus == enum.MergeCheckStatusConflict
+	case enum.MergeMethodRebase:
+		return pr.RebaseCheckStatus == enum.MergeCheckStatusConflict
+	case enum.MergeMethodFastForward:
+		return false
+	}
+
+	return false
+}
+
+// commitTitle returns the title of the merge commit. It follows the conventions of the pull request merge API.
+func commitTitle(am *types.AutoMerge, pr *types.PullReq, sourceRepo *types.RepositoryCore) string {
+	if am.Title != "" {
+		return am.Title
+	}
+
+	switch am.MergeMethod {
+	case enum.MergeMethodMerge:
+		return fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	case enum.MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+		// Not used.
+	}
+
+	return ""
+}
+
+// merge merges the pull request on behalf of the principal who enabled the auto-merge.
+func (s *Service) merge(
+	ctx context.Context,
+	am *types.AutoMerge,
+	pr *types.PullReq,
+	principal *types.Principal,
+	targetRepo *types.RepositoryCore,
+	sourceRepo *types.RepositoryCore,
+	deleteSourceBranch bool,
+) error {
+	targetWriteParams, err := s.mergeService.CreateWriteParams(ctx, targetRepo, principal)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	author, committer := pullreq.MergeIdentities(pr, principal, am.MergeMethod)
+
+	refTargetBranch, err := git.GetRefPath(pr.TargetBranch, gitenum.RefTypeBranch)
+	if err != nil {
+		return fmt.Errorf("failed to generate target branch ref name: %w", err)
+	}
+
+	prNumber := strconv.FormatInt(pr.Number, 10)
+
+	refPullReqHead, err := git.GetRefPath(prNumber, gitenum.RefTypePullReqHead)
+	if err != nil {
+		return fmt.Errorf("failed to generate pull request head ref name: %w", err)
+	}
+
+	refPullReqMerge, err := git.GetRefPath(prNumber, gitenum.RefTypePullReqMerge)
+	if err != nil {
+		return fmt.Errorf("failed to generate pull request merge ref name: %w", err)
+	}
+
+	sourceSHA := sha.Must(am.SourceSHA)
+
+	now := time.Now()
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:   targetWriteParams,
+		BaseBranch:    pr.TargetBranch,
+		HeadRepoUID:   sourceRepo.GitUID,
+		HeadBranch:    pr.SourceBranch,
+		Message:       git.CommitMessage(commitTitle(am, pr, sourceRepo), am.Message),
+		Committer:     committer,
+		CommitterDate: &now,
+		Author:        author,
+		AuthorDate:    &now,
+		Refs: []git.RefUpdate{
+			{Name: refTargetBranch, Old: sha.SHA{}, New: sha.SHA{}},
+			{Name: refPullReqHead, Old: sha.SHA{}, New: sourceSHA},
+			{Name: refPullReqMerge, Old: sha.SHA{}, New: sha.Nil},
+		},
+		HeadExpectedSHA: sourceSHA,
+		Method:          gitenum.MergeMethod(am.MergeMethod),
+	})
+	if gitness_errors.IsInvalidArgument(err) || gitness_errors.IsPreconditionFailed(err) {
+		// For example, the fast-forward merge is not possible. Wait for the branches to get updated.
+		log.Ctx(ctx).Info().Err(err).Msgf("auto-merge of pull request %d is not possible", pr.Number)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("merge execution failed: %w", err)
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+			if pr.SourceSHA != mergeOutput.HeadSHA.String() {
+				return errors.New("source SHA has changed")
+			}
+
+			pr.MergeBaseSHA = mergeOutput.MergeBaseSHA.String()
+			pr.MergeTargetSHA = ptr.String(mergeOutput.BaseSHA.String())
+			pr.MergeSHA = nil
+			pr.UpdateMergeOutcome(am.MergeMethod, mergeOutput.ConflictFiles)
+			pr.Stats.DiffStats = types.NewDiffStats(
+				mergeOutput.CommitCount,
+				mergeOutput.ChangedFileCount,
+				mergeOutput.Additions,
+				mergeOutput.Deletions,
+			)
+			return nil
+		})
+		if err != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to update pull request with conflict files")
+		} else {
+			s.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
+		}
+
+		log.Ctx(ctx).Info().Msgf("auto-merge of pull request %d is blocked by conflicts", pr.Number)
+
+		return nil
+	}
+
+	log.Ctx(ctx).Debug().Msgf("auto-merge merged pull request %d", pr.Number)
+
+	if err := s.autoMergeStore.Delete(ctx, pr.ID); err != nil {
+		// non-critical error, the auto-merge is removed again when the merged event is processed.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete pull request auto-merge")
+	}
+
+	_, _, err = s.mergeService.Finalize(ctx, pr, pullreq.MergedInput{
+		Principal:    principal,
+		TargetRepo:   targetRepo,
+		SourceRepo:   sourceRepo,
+		Method:       am.MergeMethod,
+		Merged:       now,
+		SourceSHA:    mergeOutput.HeadSHA.String(),
+		TargetSHA:    mergeOutput.BaseSHA.String(),
+		MergeBaseSHA: mergeOutput.MergeBaseSHA.String(),
+		MergeSHA:     mergeOutput.MergeSHA.String(),
+		DiffStats: types.NewDiffStats(
+			mergeOutput.CommitCount,
+			mergeOutput.ChangedFileCount,
+			mergeOutput.Additions,
+			mergeOutput.Deletions,
+		),
+		DeleteSourceBranch: deleteSourceBranch,
+	})
+
+	return err
+}
@@ -0,0 +1,512 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCommitTitle(t *testing.T) {
+	pr := &types.PullReq{Number: 42, Title: "Add feature", SourceBranch: "feature"}
+	repo := &types.RepositoryCore{Path: "space/repo"}
+
+	tests := []struct {
+		name   string
+		method enum.MergeMethod
+		title  string
+		exp    string
+	}{
+		{
+			name:   "custom-title",
+			method: enum.MergeMethodSquash,
+			title:  "Custom title",
+			exp:    "Custom title",
+		},
+		{
+			name:   "merge",
+			method: enum.MergeMethodMerge,
+			exp:    "Merge branch 'feature' of space/repo (#42)",
+		},
+		{
+			name:   "squash",
+			method: enum.MergeMethodSquash,
+			exp:    "Add feature (#42)",
+		},
+		{
+			name:   "rebase",
+			method: enum.MergeMethodRebase,
+			exp:    "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			am := &types.AutoMerge{MergeMethod: test.method, Title: test.title}
+
+			if got := commitTitle(am, pr, repo); got != test.exp {
+				t.Errorf("expected=%q, got=%q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestHasKnownConflicts(t *testing.T) {
+	pr := &types.PullReq{
+		MergeCheckStatus:  enum.MergeCheckStatusConflict,
+		RebaseCheckStatus: enum.MergeCheckStatusMergeable,
+	}
+
+	tests := []struct {
+		method enum.MergeMethod
+		exp    bool
+	}{
+		{method: enum.MergeMethodMerge, exp: true},
+		{method: enum.MergeMethodSquash, exp: true},
+		{method: enum.MergeMethodRebase, exp: false},
+		{method: enum.MergeMethodFastForward, exp: false},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.method), func(t *testing.T) {
+			if got := hasKnownConflicts(pr, test.method); got != test.exp {
+				t.Errorf("expected=%t, got=%t", test.exp, got)
+			}
+		})
+	}
+}
+
+const (
+	testSourceSHA    = "1111111111111111111111111111111111111111"
+	testNewSourceSHA = "2222222222222222222222222222222222222222"
+	testTargetSHA    = "3333333333333333333333333333333333333333"
+	testMergeSHA     = "4444444444444444444444444444444444444444"
+)
+
+type testAutoMergeStore struct {
+	store.AutoMergeStore
+	deleted bool
+}
+
+func (s *testAutoMergeStore) Delete(context.Context, int64) error {
+	s.deleted = true
+	return nil
+}
+
+func (s *testAutoMergeStore) UpdateSourceSHA(context.Context, int64, string) error {
+	return nil
+}
+
+type testPullReqStore struct {
+	store.PullReqStore
+}
+
+func (s *testPullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	updated := *pr
+	if err := mutateFn(&updated); err != nil {
+		return nil, err
+	}
+
+	*pr = updated
+
+	return &updated, nil
+}
+
+func (s *testPullReqStore) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	pr.ActivitySeq++
+	return pr, nil
+}
+
+type testActivityStore struct {
+	store.PullReqActivityStore
+	payloads []types.PullReqActivityPayload
+}
+
+func (s *testActivityStore) CreateWithPayload(
+	_ context.Context,
+	_ *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+	_ *types.PullReqActivityMetadata,
+) (*types.PullReqActivity, error) {
+	s.payloads = append(s.payloads, payload)
+	return &types.PullReqActivity{}, nil
+}
+
+type testReviewerStore struct {
+	store.PullReqReviewerStore
+}
+
+func (testReviewerStore) List(context.Context, int64) ([]*types.PullReqReviewer, error) {
+	return nil, nil
+}
+
+type testCheckStore struct {
+	store.CheckStore
+}
+
+func (testCheckStore) ListResults(context.Context, int64, string) ([]types.CheckResult, error) {
+	return nil, nil
+}
+
+type testPrincipalStore struct {
+	store.PrincipalStore
+	principal *types.Principal
+}
+
+func (s testPrincipalStore) Find(context.Context, int64) (*types.Principal, error) {
+	return s.principal, nil
+}
+
+func (s testPrincipalStore) FindServiceByUID(_ context.Context, uid string) (*types.Service, error) {
+	return &types.Service{ID: 1, UID: uid, DisplayName: "Harness", Email: "system@example.com", Admin: true}, nil
+}
+
+type testRuleStore struct {
+	store.RuleStore
+	rules []types.RuleInfoInternal
+}
+
+func (s testRuleStore) ListAllRepoRules(context.Context, int64, ...enum.RuleType) ([]types.RuleInfoInternal, error) {
+	return s.rules, nil
+}
+
+type testRepoIDCache struct {
+	store.RepoIDCache
+	repo *types.RepositoryCore
+}
+
+func (c testRepoIDCache) Get(context.Context, int64) (*types.RepositoryCore, error) {
+	return c.repo, nil
+}
+
+type testGit struct {
+	git.Interface
+	merged          bool
+	deletedBranches []string
+}
+
+func (g *testGit) DiffFileNames(context.Context, *git.DiffParams) (git.DiffFileNamesOutput, error) {
+	return git.DiffFileNamesOutput{Files: []string{"README.md"}}, nil
+}
+
+func (g *testGit) Merge(context.Context, *git.MergeParams) (git.MergeOutput, error) {
+	g.merged = true
+	return git.MergeOutput{
+		BaseSHA:          sha.Must(testTargetSHA),
+		HeadSHA:          sha.Must(testSourceSHA),
+		MergeBaseSHA:     sha.Must(testTargetSHA),
+		MergeSHA:         sha.Must(testMergeSHA),
+		CommitCount:      1,
+		ChangedFileCount: 1,
+	}, nil
+}
+
+func (g *testGit) DeleteBranch(_ context.Context, params *git.DeleteBranchParams) error {
+	g.deletedBranches = append(g.deletedBranches, params.BranchName)
+	return nil
+}
+
+type testAuthorizer struct {
+	authz.Authorizer
+}
+
+func (testAuthorizer) Check(
+	context.Context,
+	*auth.Session,
+	*types.Scope,
+	*types.Resource,
+	enum.Permission,
+) (bool, error) {
+	return true, nil
+}
+
+type testUserGroupService struct {
+	usergroup.Service
+}
+
+func (testUserGroupService) ListUserIDsByGroupIDs(context.Context, []int64) ([]int64, error) {
+	return nil, nil
+}
+
+type testStreamer struct {
+	sse.Streamer
+}
+
+func (testStreamer) Publish(context.Context, int64, enum.SSEType, any) {}
+
+type testURLProvider struct {
+	url.Provider
+}
+
+func (testURLProvider) GetInternalAPIURL(context.Context) string {
+	return "http://localhost:3000"
+}
+
+type testStreamProducer struct {
+	streamIDs []string
+}
+
+func (p *testStreamProducer) Send(_ context.Context, streamID string, _ map[string]any) (string, error) {
+	p.streamIDs = append(p.streamIDs, streamID)
+	return "", nil
+}
+
+type processTest struct {
+	service        *Service
+	autoMergeStore *testAutoMergeStore
+	activityStore  *testActivityStore
+	git            *testGit
+	producer       *testStreamProducer
+}
+
+func newProcessTest(t *testing.T, repo *types.RepositoryCore, rules []types.RuleInfoInternal) *processTest {
+	t.Helper()
+
+	principalStore := testPrincipalStore{
+		principal: &types.Principal{ID: 7, UID: "user", DisplayName: "User", Email: "user@example.com"},
+	}
+
+	config := &types.Config{}
+	config.Principal.System.UID = "harness"
+
+	err := bootstrap.SystemService(context.Background(), config, service.NewController(nil, nil, principalStore))
+	if err != nil {
+		t.Fatalf("failed to set up system service: %s", err)
+	}
+
+	producer := &testStreamProducer{}
+	eventsSystem, err := events.NewSystem(func(string, string) (events.StreamConsumer, error) {
+		return nil, errors.New("consumers are not used in the test")
+	}, producer)
+	if err != nil {
+		t.Fatalf("failed to create events system: %s", err)
+	}
+
+	pullreqEvReporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reporter: %s", err)
+	}
+
+	repoEvReporter, err := repoevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create repository event reporter: %s", err)
+	}
+
+	protectionManager := protection.NewManager(testRuleStore{rules: rules})
+	if err := protectionManager.Register(protection.TypeBranch, func() protection.Definition {
+		return &protection.Branch{}
+	}); err != nil {
+		t.Fatalf("failed to register branch rules: %s", err)
+	}
+
+	autoMergeStore := &testAutoMergeStore{}
+	pullreqStore := &testPullReqStore{}
+	activityStore := &testActivityStore{}
+	gitService := &testGit{}
+
+	return &processTest{
+		service: &Service{
+			autoMergeStore:    autoMergeStore,
+			pullreqStore:      pullreqStore,
+			activityStore:     activityStore,
+			reviewerStore:     testReviewerStore{},
+			checkStore:        testCheckStore{},
+			principalStore:    principalStore,
+			repoFinder:        refcache.NewRepoFinder(nil, nil, testRepoIDCache{repo: repo}, nil, cache.Evictor[*types.RepositoryCore]{}),
+			git:               gitService,
+			authorizer:        testAuthorizer{},
+			protectionManager: protectionManager,
+			codeOwners:        codeowners.New(nil, gitService, codeowners.Config{}, nil, nil),
+			userGroupService:  testUserGroupService{},
+			sseStreamer:       testStreamer{},
+			mergeService: pullreq.NewMergeService(
+				gitService,
+				pullreqStore,
+				activityStore,
+				testURLProvider{},
+				pullreqEvReporter,
+				repoEvReporter,
+				testStreamer{},
+				audit.New(),
+				instrument.Noop{},
+			),
+		},
+		autoMergeStore: autoMergeStore,
+		activityStore:  activityStore,
+		git:            gitService,
+		producer:       producer,
+	}
+}
+
+func TestProcess(t *testing.T) {
+	repo := &types.RepositoryCore{
+		ID:            1,
+		ParentID:      1,
+		Identifier:    "repo",
+		Path:          "space/repo",
+		GitUID:        "repo-uid",
+		DefaultBranch: "main",
+	}
+
+	approvalRule := types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			RepoPath:   "space/repo",
+			ID:         1,
+			Identifier: "approvals",
+			Type:       protection.TypeBranch,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    []byte(`{"default":true}`),
+		Definition: []byte(`{"pullreq":{"approvals":{"require_minimum_count":1}}}`),
+		RepoTarget: []byte(`{"include": {}, "exclude": {}}`),
+	}
+
+	newPullReq := func(sourceSHA string) *types.PullReq {
+		return &types.PullReq{
+			ID:           10,
+			Number:       3,
+			State:        enum.PullReqStateOpen,
+			SourceRepoID: repo.ID,
+			TargetRepoID: repo.ID,
+			SourceBranch: "feature",
+			TargetBranch: "main",
+			SourceSHA:    sourceSHA,
+			MergeBaseSHA: testTargetSHA,
+		}
+	}
+
+	newAutoMerge := func(disableOnPush bool) *types.AutoMerge {
+		return &types.AutoMerge{
+			PullReqID:          10,
+			RepoID:             repo.ID,
+			MergeMethod:        enum.MergeMethodSquash,
+			DeleteSourceBranch: true,
+			DisableOnPush:      disableOnPush,
+			SourceSHA:          testSourceSHA,
+			CreatedBy:          7,
+		}
+	}
+
+	t.Run("disabled-on-push", func(t *testing.T) {
+		test := newProcessTest(t, repo, nil)
+
+		pr := newPullReq(testNewSourceSHA)
+		if err := test.service.process(context.Background(), newAutoMerge(true), pr); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !test.autoMergeStore.deleted {
+			t.Error("expected the auto-merge to be disabled")
+		}
+		if test.git.merged {
+			t.Error("expected the pull request not to be merged")
+		}
+		if len(test.activityStore.payloads) != 1 {
+			t.Fatalf("expected one activity, got %d", len(test.activityStore.payloads))
+		}
+
+		payload, ok := test.activityStore.payloads[0].(*types.PullRequestActivityPayloadAutoMerge)
+		if !ok || payload.Action != enum.AutoMergeActionDisabled || payload.Reason == "" {
+			t.Errorf("expected auto-merge disabled activity with a reason, got %+v", test.activityStore.payloads[0])
+		}
+	})
+
+	t.Run("blocked-by-rule-violations", func(t *testing.T) {
+		test := newProcessTest(t, repo, []types.RuleInfoInternal{approvalRule})
+
+		pr := newPullReq(testSourceSHA)
+		if err := test.service.process(context.Background(), newAutoMerge(false), pr); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if test.git.merged {
+			t.Error("expected the pull request not to be merged")
+		}
+		if test.autoMergeStore.deleted {
+			t.Error("expected the auto-merge to stay enabled")
+		}
+		if pr.State != enum.PullReqStateOpen {
+			t.Errorf("expected the pull request to stay open, got %s", pr.State)
+		}
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		test := newProcessTest(t, repo, nil)
+
+		pr := newPullReq(testSourceSHA)
+		if err := test.service.process(context.Background(), newAutoMerge(false), pr); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !test.git.merged {
+			t.Fatal("expected the pull request to be merged")
+		}
+		if !test.autoMergeStore.deleted {
+			t.Error("expected the auto-merge to be removed")
+		}
+		if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || *pr.MergeSHA != testMergeSHA {
+			t.Errorf("expected the pull request to be merged with %s, got state=%s", testMergeSHA, pr.State)
+		}
+		if !slices.Equal(test.git.deletedBranches, []string{"feature"}) {
+			t.Errorf("expected the source branch to be deleted, got %v", test.git.deletedBranches)
+		}
+		if !slices.Contains(test.producer.streamIDs, "events:pullreq:merged") {
+			t.Errorf("expected the merged event to be reported, got %v", test.producer.streamIDs)
+		}
+
+		var merged, branchDeleted bool
+		for _, payload := range test.activityStore.payloads {
+			switch payload.(type) {
+			case *types.PullRequestActivityPayloadMerge:
+				merged = true
+			case *types.PullRequestActivityPayloadBranchDelete:
+				branchDeleted = true
+			}
+		}
+		if !merged || !branchDeleted {
+			t.Errorf("expected merge and branch delete activities, got %+v", test.activityStore.payloads)
+		}
+	})
+}
@@ -0,0 +1,329 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:automerge"
+
+	// processTimeout is the max time given to the evaluation and the merge of a single pull request.
+	processTimeout = 3 * time.Minute
+)
+
+type Config struct {
+	Concurrency   int
+	MaxRetries    int
+	CRON          string
+	MaxDuration   time.Duration
+	DisableOnPush bool
+}
+
+// Service merges pull requests with enabled auto-merge as soon as all their merge requirements are satisfied.
+//
+// The protection rules of a pull request are re-evaluated whenever something that might affect them happens:
+// a status check is reported, a review is submitted, a comment is resolved or the source branch is updated.
+// The pull request is merged on behalf of the principal who enabled the auto-merge, and the rules are never bypassed.
+type Service struct {
+	config            Config
+	autoMergeStore    store.AutoMergeStore
+	pullreqStore      store.PullReqStore
+	activityStore     store.PullReqActivityStore
+	reviewerStore     store.PullReqReviewerStore
+	checkStore        store.CheckStore
+	principalStore    store.PrincipalStore
+	repoFinder        refcache.RepoFinder
+	git               git.Interface
+	authorizer        authz.Authorizer
+	protectionManager *protection.Manager
+	codeOwners        *codeowners.Service
+	userGroupService  usergroup.Service
+	signatureVerify   publickey.SignatureVerifyService
+	locker            *locker.Locker
+	sseStreamer       sse.Streamer
+	mergeService      *pullreq.MergeService
+	scheduler         *job.Scheduler
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	autoMergeStore store.AutoMergeStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	checkStore store.CheckStore,
+	principalStore store.PrincipalStore,
+	repoFinder refcache.RepoFinder,
+	git git.Interface,
+	authorizer authz.Authorizer,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	userGroupService usergroup.Service,
+	signatureVerify publickey.SignatureVerifyService,
+	locker *locker.Locker,
+	sseStreamer sse.Streamer,
+	mergeService *pullreq.MergeService,
+	scheduler *job.Scheduler,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	instanceID string,
+) (*Service, error) {
+	service := &Service{
+		config:            config,
+		autoMergeStore:    autoMergeStore,
+		pullreqStore:      pullreqStore,
+		activityStore:     activityStore,
+		reviewerStore:     reviewerStore,
+		checkStore:        checkStore,
+		principalStore:    principalStore,
+		repoFinder:        repoFinder,
+		git:               git,
+		authorizer:        authorizer,
+		protectionManager: protectionManager,
+		codeOwners:        codeOwners,
+		userGroupService:  userGroupService,
+		signatureVerify:   signatureVerify,
+		locker:            locker,
+		sseStreamer:       sseStreamer,
+		mergeService:      mergeService,
+		scheduler:         scheduler,
+	}
+
+	handlerOptions := stream.WithHandlerOptions(
+		stream.WithIdleTimeout(processTimeout+time.Minute),
+		stream.WithMaxRetries(config.MaxRetries),
+	)
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, instanceID,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(stream.WithConcurrency(config.Concurrency), handlerOptions)
+
+			_ = r.RegisterBranchUpdated(service.handleEventPullReqBranchUpdated)
+			_ = r.RegisterTargetBranchChanged(service.handleEventPullReqTargetBranchChanged)
+			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
+			_ = r.RegisterCommentStatusUpdated(service.handleEventPullReqCommentStatusUpdated)
+			_ = r.RegisterUpdated(service.handleEventPullReqUpdated)
+			_ = r.RegisterClosed(service.handleEventPullReqClosed)
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request events reader: %w", err)
+	}
+
+	_, err = checkEvReaderFactory.Launch(ctx, eventsReaderGroupName, instanceID,
+		func(r *checkevents.Reader) error {
+			r.Configure(stream.WithConcurrency(config.Concurrency), handlerOptions)
+
+			_ = r.RegisterReported(service.handleEventCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// EnableInput holds the merge details of a pull request with enabled auto-merge.
+// The commit title and message are used as provided, if empty they are generated at the time of the merge.
+type EnableInput struct {
+	Method             enum.MergeMethod
+	SourceSHA          string
+	Title              string
+	Message            string
+	DeleteSourceBranch bool
+
+	// DisableOnPush overrides the system default for disabling auto-merge on new commits.
+	DisableOnPush *bool
+}
+
+// Enable enables the auto-merge of the pull request, or replaces the merge details if it's already enabled.
+// The pull request is evaluated right away, so it might get merged before the function returns.
+func (s *Service) Enable(
+	ctx context.Context,
+	principal *types.Principal,
+	pr *types.PullReq,
+	in EnableInput,
+) (*types.AutoMerge, error) {
+	disableOnPush := s.config.DisableOnPush
+	if in.DisableOnPush != nil {
+		disableOnPush = *in.DisableOnPush
+	}
+
+	now := time.Now().UnixMilli()
+	am := &types.AutoMerge{
+		PullReqID:          pr.ID,
+		RepoID:             pr.TargetRepoID,
+		MergeMethod:        in.Method,
+		Title:              in.Title,
+		Message:            in.Message,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+		DisableOnPush:      disableOnPush,
+		SourceSHA:          in.SourceSHA,
+		CreatedBy:          principal.ID,
+		Created:            now,
+		Updated:            now,
+	}
+
+	if err := s.autoMergeStore.Upsert(ctx, am); err != nil {
+		return nil, fmt.Errorf("failed to enable pull request auto-merge: %w", err)
+	}
+
+	s.writeActivity(ctx, pr, principal.ID, &types.PullRequestActivityPayloadAutoMerge{
+		Action:      enum.AutoMergeActionEnabled,
+		MergeMethod: in.Method,
+	})
+
+	// Failures aren't fatal, the pull request will be evaluated again later.
+	if err := s.Process(ctx, pr.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to process pull request %d after enabling auto-merge", pr.Number)
+	}
+
+	return am, nil
+}
+
+// Disable disables the auto-merge of the pull request.
+func (s *Service) Disable(
+	ctx context.Context,
+	principal *types.Principal,
+	pr *types.PullReq,
+) error {
+	if _, err := s.autoMergeStore.Find(ctx, pr.ID); err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	unlock, err := s.locker.LockPR(ctx, pr.TargetRepoID, 0, processTimeout+30*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for pull request auto-merge update: %w", err)
+	}
+	defer unlock()
+
+	return s.disable(ctx, pr, principal.ID, "")
+}
+
+// Find returns the auto-merge of the pull request.
+func (s *Service) Find(ctx context.Context, pr *types.PullReq) (*types.AutoMerge, error) {
+	am, err := s.autoMergeStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	return am, nil
+}
+
+// disable removes the auto-merge of the pull request and, if the pull request is still open,
+// records the action in the pull request activity.
+func (s *Service) disable(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	reason string,
+) error {
+	if err := s.autoMergeStore.Delete(ctx, pr.ID); err != nil {
+		return fmt.Errorf("failed to delete pull request auto-merge: %w", err)
+	}
+
+	if pr.State == enum.PullReqStateOpen {
+		s.writeActivity(ctx, pr, principalID, &types.PullRequestActivityPayloadAutoMerge{
+			Action: enum.AutoMergeActionDisabled,
+			Reason: reason,
+		})
+	}
+
+	return nil
+}
+
+func (s *Service) writeActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+) {
+	err := func() error {
+		pr, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to update pull request activity sequence: %w", err)
+		}
+
+		_, err = s.activityStore.CreateWithPayload(ctx, pr, principalID, payload, nil)
+		return err
+	}()
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request auto-merge activity")
+	}
+}
+
+// processPullReqs evaluates all pull requests of the repository with enabled auto-merge
+// that are accepted by the filter function.
+func (s *Service) processPullReqs(
+	ctx context.Context,
+	repoID int64,
+	filter func(am *types.AutoMerge) bool,
+) (int, int, error) {
+	autoMerges, err := s.autoMergeStore.List(ctx, repoID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list pull request auto-merges: %w", err)
+	}
+
+	var processed, failed int
+	for _, am := range autoMerges {
+		if ctx.Err() != nil {
+			return processed, failed, ctx.Err()
+		}
+
+		if filter != nil && !filter(am) {
+			continue
+		}
+
+		processed++
+
+		if err := s.Process(ctx, am.PullReqID); err != nil {
+			// keep going, a single broken pull request shouldn't block all others.
+			failed++
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to process auto-merge of pull request %d", am.PullReqID)
+		}
+	}
+
+	return processed, failed, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	autoMergeStore store.AutoMergeStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	checkStore store.CheckStore,
+	principalStore store.PrincipalStore,
+	repoFinder refcache.RepoFinder,
+	git git.Interface,
+	authorizer authz.Authorizer,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	userGroupService usergroup.Service,
+	signatureVerify publickey.SignatureVerifyService,
+	locker *locker.Locker,
+	sseStreamer sse.Streamer,
+	mergeService *pullreq.MergeService,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+) (*Service, error) {
+	service, err := NewService(
+		ctx,
+		Config{
+			Concurrency:   config.AutoMerge.Concurrency,
+			MaxRetries:    config.AutoMerge.MaxRetries,
+			CRON:          config.AutoMerge.CRON,
+			MaxDuration:   config.AutoMerge.MaxDuration,
+			DisableOnPush: config.AutoMerge.DisableOnPush,
+		},
+		autoMergeStore,
+		pullreqStore,
+		activityStore,
+		reviewerStore,
+		checkStore,
+		principalStore,
+		repoFinder,
+		git,
+		authorizer,
+		protectionManager,
+		codeOwners,
+		userGroupService,
+		signatureVerify,
+		locker,
+		sseStreamer,
+		mergeService,
+		scheduler,
+		pullreqEvReaderFactory,
+		checkEvReaderFactory,
+		config.InstanceID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = executor.Register(jobType, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -442,7 +442,7 @@ func (s *Service) Evaluate(
 		for _, owner := range entry.Owners {
 			// user group identifier specified codeowner
 			if userGroupOwner, ok := ParseUserGroupOwner(owner); ok {
-				userGroupEvaluation, err := s.resolveUserGroupCodeOwner(ctx, userGroupOwner, reviewers)
+				userGroupEvaluation, err := s.resolveUserGroupCodeOwner(ctx, repo.ParentID, userGroupOwner, reviewers)
 				if errors.Is(err, usergroup.ErrNotFound) {
 					log.Ctx(ctx).Debug().Msgf("user group %q not found", userGroupOwner)
 					continue
@@ -492,10 +492,11 @@ func (s *Service) Evaluate(
 
 func (s *Service) resolveUserGroupCodeOwner(
 	ctx context.Context,
+	spaceID int64,
 	owner string,
 	reviewers []*types.PullReqReviewer,
 ) (*UserGroupEvaluation, error) {
-	userGroup, err := s.userGroupResolver.Resolve(ctx, owner)
+	userGroup, err := s.userGroupResolver.Resolve(ctx, spaceID, owner)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve usergroup : %w", err)
 	}
@@ -577,7 +578,7 @@ func (s *Service) Validate(
 			validatedOwners[owner] = struct{}{}
 
 			if usrGrpOwner, ok := ParseUserGroupOwner(owner); ok { // user group owner
-				_, err = s.userGroupResolver.Resolve(ctx, usrGrpOwner)
+				_, err = s.userGroupResolver.Resolve(ctx, repo.ParentID, usrGrpOwner)
 				if errors.Is(err, usergroup.ErrNotFound) {
 					codeOwnerValidation.Addf(
 						enum.CodeOwnerViolationCodeUserGroupNotFound,
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxScannedCommits limits the number of pull request commits scanned for issue references.
+const maxScannedCommits = 250
+
+// pullReqReference is an issue reference found in a merged pull request.
+type pullReqReference struct {
+	closing bool
+	// commitSHA is set if the reference comes from a commit message rather than from the pull request itself.
+	commitSHA string
+}
+
+// handleEventPullReqMerged links the issues referenced by the pull request title, description
+// and commit messages to the pull request, and closes those referenced with a closing keyword.
+func (s *Service) handleEventPullReqMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	refs, err := s.collectReferences(ctx, pr, event.Payload.SourceSHA)
+	if err != nil {
+		return err
+	}
+
+	for number, ref := range refs {
+		issue, err := s.issueStore.FindByNumber(ctx, pr.TargetRepoID, number)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find issue #%d: %w", number, err)
+		}
+
+		if err = s.linkPullReq(ctx, issue, pr, event.Payload.PrincipalID, ref); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("issue_number", number).
+				Int64("pullreq_number", pr.Number).
+				Msg("failed to link issue to merged pull request")
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) collectReferences(
+	ctx context.Context,
+	pr *types.PullReq,
+	sourceSHA string,
+) (map[int64]pullReqReference, error) {
+	refs := make(map[int64]pullReqReference)
+
+	add := func(text, commitSHA string) {
+		for _, ref := range ParseReferences(text) {
+			existing, ok := refs[ref.Number]
+			if ok && (existing.closing || !ref.Closing) {
+				continue
+			}
+			refs[ref.Number] = pullReqReference{closing: ref.Closing, commitSHA: commitSHA}
+		}
+	}
+
+	add(pr.Title+"\n"+pr.Description, "")
+
+	repo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	if sourceSHA == "" {
+		sourceSHA = pr.SourceSHA
+	}
+
+	output, err := s.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     sourceSHA,
+		After:      pr.MergeBaseSHA,
+		Limit:      maxScannedCommits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request commits: %w", err)
+	}
+
+	for _, commit := range output.Commits {
+		add(commit.Message, commit.SHA.String())
+	}
+
+	return refs, nil
+}
+
+func (s *Service) linkPullReq(
+	ctx context.Context,
+	issue *types.Issue,
+	pr *types.PullReq,
+	principalID int64,
+	ref pullReqReference,
+) error {
+	issue, err := s.issueStore.UpdateActivitySeq(ctx, issue)
+	if err != nil {
+		return fmt.Errorf("failed to update issue activity sequence: %w", err)
+	}
+
+	_, err = s.activityStore.CreateWithPayload(ctx, issue, principalID, &types.IssueActivityPayloadReference{
+		PullReqNumber: pr.Number,
+		PullReqTitle:  pr.Title,
+		CommitSHA:     ref.commitSHA,
+		Closing:       ref.closing,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !ref.closing || issue.State == enum.IssueStateClosed {
+		return nil
+	}
+
+	_, err = s.SetState(ctx, issue, principalID, enum.IssueStateClosed, &types.IssueActivityPayloadStateChange{
+		PullReqNumber: &pr.Number,
+		CommitSHA:     ref.commitSHA,
+	})
+
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Reference is a mention of an issue of the same repository, e.g. "#12" or "fixes #12".
+type Reference struct {
+	Number  int64
+	Closing bool
+}
+
+// referenceRegexp matches "#<number>" optionally preceded by a closing keyword.
+// The reference must not be a part of a word or a path, so "abc#12" and "/#12" are ignored.
+var referenceRegexp = regexp.MustCompile(
+	`(?i)(?:^|[^\w/#])(?:(close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+)?#(\d+)\b`)
+
+// ParseReferences returns the issues referenced in the text, ordered by issue number.
+// An issue referenced several times is returned once, as closing if any of its references is.
+func ParseReferences(text string) []Reference {
+	refs := make(map[int64]bool)
+
+	for _, match := range referenceRegexp.FindAllStringSubmatch(text, -1) {
+		number, err := strconv.ParseInt(match[2], 10, 64)
+		if err != nil || number <= 0 {
+			continue
+		}
+
+		refs[number] = refs[number] || match[1] != ""
+	}
+
+	result := make([]Reference, 0, len(refs))
+	for number, closing := range refs {
+		result = append(result, Reference{Number: number, Closing: closing})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Number < result[j].Number
+	})
+
+	return result
+}
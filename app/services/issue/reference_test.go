@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		exp  []Reference
+	}{
+		{
+			name: "empty",
+			text: "",
+			exp:  []Reference{},
+		},
+		{
+			name: "mention",
+			text: "related to #7",
+			exp:  []Reference{{Number: 7}},
+		},
+		{
+			name: "closing-keywords",
+			text: "Fixes #12, closes #3 and resolved: #5",
+			exp:  []Reference{{Number: 3, Closing: true}, {Number: 5, Closing: true}, {Number: 12, Closing: true}},
+		},
+		{
+			name: "start-of-text",
+			text: "#4 is done\nfix #9",
+			exp:  []Reference{{Number: 4}, {Number: 9, Closing: true}},
+		},
+		{
+			name: "closing-wins",
+			text: "see #2\n\nfixed #2",
+			exp:  []Reference{{Number: 2, Closing: true}},
+		},
+		{
+			name: "not-a-reference",
+			text: "abc#1 http://host/page#2 ##3 #4x prefix fixes#5 #0",
+			exp:  []Reference{},
+		},
+		{
+			name: "keyword-must-precede",
+			text: "#8 fixes nothing",
+			exp:  []Reference{{Number: 8}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ParseReferences(test.text)
+			if !reflect.DeepEqual(test.exp, got) {
+				t.Errorf("expected %v, got %v", test.exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const eventsReaderGroupName = "gitness:issue"
+
+var errStateUnchanged = errors.New("issue state unchanged")
+
+type Service struct {
+	git           git.Interface
+	repoFinder    refcache.RepoFinder
+	pullreqStore  store.PullReqStore
+	issueStore    store.IssueStore
+	activityStore store.IssueActivityStore
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	git git.Interface,
+	repoFinder refcache.RepoFinder,
+	pullreqStore store.PullReqStore,
+	issueStore store.IssueStore,
+	activityStore store.IssueActivityStore,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+) (*Service, error) {
+	service := &Service{
+		git:           git,
+		repoFinder:    repoFinder,
+		pullreqStore:  pullreqStore,
+		issueStore:    issueStore,
+		activityStore: activityStore,
+	}
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 30 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for issues: %w", err)
+	}
+
+	return service, nil
+}
+
+// SetState opens or closes the issue and writes the state change activity.
+// The payload can be used to provide the pull request or the commit that caused the change.
+func (s *Service) SetState(
+	ctx context.Context,
+	issue *types.Issue,
+	principalID int64,
+	state enum.IssueState,
+	payload *types.IssueActivityPayloadStateChange,
+) (*types.Issue, error) {
+	if payload == nil {
+		payload = &types.IssueActivityPayloadStateChange{}
+	}
+
+	updated, err := s.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		if issue.State == state {
+			return errStateUnchanged
+		}
+
+		payload.Old = issue.State
+		payload.New = state
+
+		issue.State = state
+		issue.ActivitySeq++
+
+		if state == enum.IssueStateClosed {
+			now := time.Now().UnixMilli()
+			issue.Closed = &now
+			issue.ClosedBy = &principalID
+		} else {
+			issue.Closed = nil
+			issue.ClosedBy = nil
+		}
+
+		return nil
+	})
+	if errors.Is(err, errStateUnchanged) {
+		return s.issueStore.Find(ctx, issue.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue state: %w", err)
+	}
+
+	if _, err = s.activityStore.CreateWithPayload(ctx, updated, principalID, payload); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -49,6 +49,15 @@ func (s *Service) handleUpdateDefaultBranch(ctx context.Context,
 	return nil
 }
 
+func (s *Service) handleRepoDeleted(ctx context.Context,
+	event *events.Event[*repoevents.DeletedPayload]) error {
+	if err := s.indexer.DeleteIndex(ctx, event.Payload.RepoID); err != nil {
+		return fmt.Errorf("index removal failed for repo %d: %w", event.Payload.RepoID, err)
+	}
+
+	return nil
+}
+
 func (s *Service) indexRepo(
 	ctx context.Context,
 	repoID int64,
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+const (
+	jobTypeIndex       = "keyword-search-index"
+	indexJobMaxRetries = 2
+	indexJobTimeout    = 30 * time.Minute
+)
+
+var _ job.Handler = (*LocalIndexSearcher)(nil)
+
+// scheduleIndex schedules the background job that indexes the repository.
+// The job UID is derived from the repository ID, so a repository is scheduled only once
+// while its previous job is still stored.
+func (s *LocalIndexSearcher) scheduleIndex(ctx context.Context, repoID int64) error {
+	err := s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobTypeIndex + "-" + strconv.FormatInt(repoID, 10),
+		Type:       jobTypeIndex,
+		MaxRetries: indexJobMaxRetries,
+		Timeout:    indexJobTimeout,
+		Data:       strconv.FormatInt(repoID, 10),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run index job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the background job handler that indexes a repository.
+func (s *LocalIndexSearcher) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	repoID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse repository ID of index job: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "repository not found", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err = s.Index(ctx, repo); err != nil {
+		return "", fmt.Errorf("failed to index repository %d: %w", repoID, err)
+	}
+
+	return "", nil
+}
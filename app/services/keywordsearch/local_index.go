@@ -0,0 +1,292 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// localIndexVersion is stored in every index file, indices of a different version are rebuilt from scratch.
+const localIndexVersion = 1
+
+// headerOffsetSize is the number of bytes at the beginning of an index file
+// that hold the offset of the index header.
+const headerOffsetSize = 8
+
+// trigram holds three consecutive bytes of (ASCII lower-cased) file content.
+type trigram uint32
+
+func newTrigram(a, b, c byte) trigram {
+	return trigram(uint32(a)<<16 | uint32(b)<<8 | uint32(c))
+}
+
+// indexedFile describes a single file stored in the index.
+type indexedFile struct {
+	Path   string
+	SHA    string
+	Offset int64
+	Size   int64
+}
+
+// indexHeader contains everything stored in an index file apart from the file contents.
+// Postings maps every trigram to the (sorted) positions in Files of the files containing it.
+type indexHeader struct {
+	Version  int
+	Branch   string
+	TreeSHA  string
+	Files    []indexedFile
+	Postings map[trigram][]uint32
+}
+
+// localIndex is an opened index file of a single repository.
+//
+// The file layout is:
+//
+//	[8 byte offset of the header][file contents ...][gob encoded indexHeader]
+//
+// Only the header is loaded into memory, file contents are read on demand.
+type localIndex struct {
+	file   *os.File
+	header indexHeader
+}
+
+func openLocalIndex(path string) (*localIndex, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := readLocalIndex(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to read index file %q: %w", path, err)
+	}
+
+	return idx, nil
+}
+
+func readLocalIndex(f *os.File) (*localIndex, error) {
+	var buf [headerOffsetSize]byte
+	if _, err := io.ReadFull(f, buf[:]); err != nil {
+		return nil, fmt.Errorf("failed to read header offset: %w", err)
+	}
+
+	offset := int64(binary.BigEndian.Uint64(buf[:]))
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek to header: %w", err)
+	}
+
+	idx := &localIndex{file: f}
+	if err := gob.NewDecoder(bufio.NewReader(f)).Decode(&idx.header); err != nil {
+		return nil, fmt.Errorf("failed to decode header: %w", err)
+	}
+
+	return idx, nil
+}
+
+func (idx *localIndex) Close() error {
+	return idx.file.Close()
+}
+
+// content returns the content of the file at the provided position.
+func (idx *localIndex) content(i int) ([]byte, error) {
+	f := idx.header.Files[i]
+	data := make([]byte, f.Size)
+	if _, err := idx.file.ReadAt(data, f.Offset); err != nil {
+		return nil, fmt.Errorf("failed to read content of %q from index: %w", f.Path, err)
+	}
+
+	return data, nil
+}
+
+// candidates returns the positions of all files containing all provided trigrams.
+// A nil trigram list matches all files.
+func (idx *localIndex) candidates(trigrams []trigram) []uint32 {
+	if len(trigrams) == 0 {
+		all := make([]uint32, len(idx.header.Files))
+		for i := range all {
+			all[i] = uint32(i)
+		}
+		return all
+	}
+
+	lists := make([][]uint32, len(trigrams))
+	for i, t := range trigrams {
+		lists[i] = idx.header.Postings[t]
+		if len(lists[i]) == 0 {
+			return nil
+		}
+	}
+
+	// intersect starting with the shortest list to keep the intermediate results small.
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+
+	result := lists[0]
+	for _, list := range lists[1:] {
+		result = intersect(result, list)
+		if len(result) == 0 {
+			return nil
+		}
+	}
+
+	return result
+}
+
+func intersect(a, b []uint32) []uint32 {
+	result := make([]uint32, 0, min(len(a), len(b)))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// localIndexWriter writes a new index file. The file only replaces the existing index on Commit,
+// which keeps an index file that's concurrently being searched intact.
+type localIndexWriter struct {
+	path   string
+	file   *os.File
+	w      *bufio.Writer
+	offset int64
+	header indexHeader
+}
+
+func newLocalIndexWriter(path string, branch string, treeSHA string) (*localIndexWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+
+	w := bufio.NewWriter(f)
+	if _, err = w.Write(make([]byte, headerOffsetSize)); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, fmt.Errorf("failed to reserve header offset: %w", err)
+	}
+
+	return &localIndexWriter{
+		path:   path,
+		file:   f,
+		w:      w,
+		offset: headerOffsetSize,
+		header: indexHeader{
+			Version:  localIndexVersion,
+			Branch:   branch,
+			TreeSHA:  treeSHA,
+			Postings: map[trigram][]uint32{},
+		},
+	}, nil
+}
+
+// Add appends a file to the index. Files have to be added in path order.
+func (iw *localIndexWriter) Add(path string, sha string, content []byte) error {
+	if _, err := iw.w.Write(content); err != nil {
+		return fmt.Errorf("failed to write content of %q: %w", path, err)
+	}
+
+	pos := uint32(len(iw.header.Files))
+	iw.header.Files = append(iw.header.Files, indexedFile{
+		Path:   path,
+		SHA:    sha,
+		Offset: iw.offset,
+		Size:   int64(len(content)),
+	})
+	iw.offset += int64(len(content))
+
+	seen := map[trigram]struct{}{}
+	for i := 0; i+3 <= len(content); i++ {
+		t := newTrigram(toLowerASCII(content[i]), toLowerASCII(content[i+1]), toLowerASCII(content[i+2]))
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		iw.header.Postings[t] = append(iw.header.Postings[t], pos)
+	}
+
+	return nil
+}
+
+// Commit writes the header and atomically replaces the existing index file.
+func (iw *localIndexWriter) Commit() error {
+	if err := gob.NewEncoder(iw.w).Encode(&iw.header); err != nil {
+		iw.Abort()
+		return fmt.Errorf("failed to encode index header: %w", err)
+	}
+
+	if err := iw.w.Flush(); err != nil {
+		iw.Abort()
+		return fmt.Errorf("failed to flush index file: %w", err)
+	}
+
+	var buf [headerOffsetSize]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(iw.offset))
+	if _, err := iw.file.WriteAt(buf[:], 0); err != nil {
+		iw.Abort()
+		return fmt.Errorf("failed to write header offset: %w", err)
+	}
+
+	if err := iw.file.Close(); err != nil {
+		_ = os.Remove(iw.file.Name())
+		return fmt.Errorf("failed to close index file: %w", err)
+	}
+
+	if err := os.Rename(iw.file.Name(), iw.path); err != nil {
+		_ = os.Remove(iw.file.Name())
+		return fmt.Errorf("failed to replace index file: %w", err)
+	}
+
+	return nil
+}
+
+// Abort discards the new index file.
+func (iw *localIndexWriter) Abort() {
+	_ = iw.file.Close()
+	_ = os.Remove(iw.file.Name())
+}
+
+func removeLocalIndex(path string) error {
+	err := os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove index file: %w", err)
+	}
+	return nil
+}
+
+func toLowerASCII(b byte) byte {
+	if b >= 'A' && b <= 'Z' {
+		return b + ('a' - 'A')
+	}
+	return b
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+// searchQuery is a compiled search query.
+// Files are pre-selected using the trigrams that any match has to contain and verified using the regular expression.
+type searchQuery struct {
+	re       *regexp.Regexp
+	trigrams []trigram
+}
+
+func compileSearchQuery(query string, enableRegex bool, caseSensitive bool) (*searchQuery, error) {
+	expr := query
+	if !enableRegex {
+		expr = regexp.QuoteMeta(query)
+	}
+	if !caseSensitive {
+		expr = "(?i)" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid regular expression: %s", err)
+	}
+
+	parsed, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid regular expression: %s", err)
+	}
+
+	return &searchQuery{
+		re:       re,
+		trigrams: literalTrigrams(requiredLiterals(parsed.Simplify())),
+	}, nil
+}
+
+// requiredLiterals returns literals that are part of every string matched by the regular expression.
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op { //nolint:exhaustive // all other operators don't guarantee any literal
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return requiredLiterals(re.Sub[0])
+		}
+	case syntax.OpConcat:
+		var literals []string
+		for _, sub := range re.Sub {
+			literals = append(literals, requiredLiterals(sub)...)
+		}
+		return literals
+	}
+
+	return nil
+}
+
+// literalTrigrams returns the distinct trigrams of the provided literals.
+// Trigrams with non-ASCII characters are skipped, as case folding could change their byte representation.
+func literalTrigrams(literals []string) []trigram {
+	var trigrams []trigram
+	seen := map[trigram]struct{}{}
+	for _, literal := range literals {
+		for i := 0; i+3 <= len(literal); i++ {
+			a, b, c := literal[i], literal[i+1], literal[i+2]
+			if a >= 0x80 || b >= 0x80 || c >= 0x80 {
+				continue
+			}
+
+			t := newTrigram(toLowerASCII(a), toLowerASCII(b), toLowerASCII(c))
+			if _, ok := seen[t]; ok {
+				continue
+			}
+			seen[t] = struct{}{}
+			trigrams = append(trigrams, t)
+		}
+	}
+
+	return trigrams
+}
+
+// match returns all lines of the content that match the query.
+func (q *searchQuery) match(content string) []types.Match {
+	lines := strings.Split(content, "\n")
+
+	var matches []types.Match
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+
+		var fragments []types.Fragment
+		prev := 0
+		for _, loc := range q.re.FindAllStringIndex(line, -1) {
+			// empty matches (e.g. "a*") don't carry any information
+			if loc[0] == loc[1] {
+				continue
+			}
+
+			fragments = append(fragments, types.Fragment{
+				Pre:   line[prev:loc[0]],
+				Match: line[loc[0]:loc[1]],
+			})
+			prev = loc[1]
+		}
+
+		if len(fragments) == 0 {
+			continue
+		}
+
+		// the last fragment holds the remainder of the line
+		fragments[len(fragments)-1].Post = line[prev:]
+
+		match := types.Match{
+			LineNum:   i + 1,
+			Fragments: fragments,
+		}
+		if i > 0 {
+			match.Before = strings.TrimSuffix(lines[i-1], "\r")
+		}
+		if i < len(lines)-1 {
+			match.After = strings.TrimSuffix(lines[i+1], "\r")
+		}
+
+		matches = append(matches, match)
+	}
+
+	return matches
+}
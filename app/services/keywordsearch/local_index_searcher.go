@@ -15,28 +15,377 @@
 package keywordsearch
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 
+	"github.com/harness/gitness/app/store"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultMaxResultCount is the number of file matches returned in case the caller didn't provide a limit.
+	defaultMaxResultCount = 50
+
+	// binaryDetectionSize is the number of leading bytes checked for a NUL byte to detect binary files.
+	binaryDetectionSize = 8000
 )
 
+// LocalIndexSearcher maintains an on-disk trigram index of the default branch of every repository
+// and searches it. Every repository is stored in a separate index file inside the configured index directory.
 type LocalIndexSearcher struct {
+	config    Config
+	git       git.Interface
+	repoStore store.RepoStore
+	scheduler *job.Scheduler
+
+	// repoLocks serializes index updates per repository.
+	repoLocks sync.Map
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+func NewLocalIndexSearcher(
+	config Config,
+	git git.Interface,
+	repoStore store.RepoStore,
+	scheduler *job.Scheduler,
+) *LocalIndexSearcher {
+	return &LocalIndexSearcher{
+		config:    config,
+		git:       git,
+		repoStore: repoStore,
+		scheduler: scheduler,
+	}
 }
 
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ bool,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	query string,
+	enableRegex bool,
+	caseSensitive bool,
+	maxResultCount int,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, nil
+	q, err := compileSearchQuery(query, enableRegex, caseSensitive)
+	if err != nil {
+		return types.SearchResult{}, err
+	}
+
+	if maxResultCount <= 0 {
+		maxResultCount = defaultMaxResultCount
+	}
+
+	// search repos in a stable order to return consistent results when the limit is reached.
+	repoIDs = slices.Clone(repoIDs)
+	slices.Sort(repoIDs)
+
+	result := types.SearchResult{FileMatches: []types.FileMatch{}}
+	for _, repoID := range repoIDs {
+		if err = ctx.Err(); err != nil {
+			return types.SearchResult{}, err
+		}
+
+		limit := maxResultCount - len(result.FileMatches)
+		if limit <= 0 {
+			break
+		}
+
+		fileMatches, err := s.searchRepo(ctx, repoID, q, limit)
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to search repo %d: %w", repoID, err)
+		}
+
+		result.FileMatches = append(result.FileMatches, fileMatches...)
+	}
+
+	result.Stats.TotalFiles = len(result.FileMatches)
+	for _, fileMatch := range result.FileMatches {
+		for _, match := range fileMatch.Matches {
+			result.Stats.TotalMatches += len(match.Fragments)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *LocalIndexSearcher) searchRepo(
+	ctx context.Context,
+	repoID int64,
+	q *searchQuery,
+	limit int,
+) ([]types.FileMatch, error) {
+	idx, err := s.openIndex(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	if idx == nil {
+		return nil, nil
+	}
+	defer idx.Close()
+
+	var fileMatches []types.FileMatch
+	for _, pos := range idx.candidates(q.trigrams) {
+		if len(fileMatches) >= limit {
+			break
+		}
+
+		content, err := idx.content(int(pos))
+		if err != nil {
+			return nil, err
+		}
+
+		matches := q.match(string(content))
+		if len(matches) == 0 {
+			continue
+		}
+
+		fileName := idx.header.Files[pos].Path
+		fileMatches = append(fileMatches, types.FileMatch{
+			FileName:   fileName,
+			RepoID:     repoID,
+			RepoBranch: idx.header.Branch,
+			Language:   languageFromFileName(fileName),
+			Matches:    matches,
+		})
+	}
+
+	return fileMatches, nil
+}
+
+// openIndex opens the index of the repository. Repositories that haven't been indexed yet
+// (e.g. created before the index existed) are scheduled for indexing, until then nil is returned for them.
+// A nil index is also returned for repositories without any content.
+func (s *LocalIndexSearcher) openIndex(ctx context.Context, repoID int64) (*localIndex, error) {
+	idx, err := openLocalIndex(s.indexPath(repoID))
+	if err == nil {
+		return idx, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to open index of repo %d, scheduling a rebuild", repoID)
+	}
+
+	if err = s.scheduleIndex(ctx, repoID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to schedule indexing of repo %d", repoID)
+	}
+
+	return nil, nil
+}
+
+// Index updates the index of the default branch of the repository.
+// Only files whose content changed since the last update are read from the repository.
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	unlock := s.lockRepo(repo.ID)
+	defer unlock()
+
+	indexPath := s.indexPath(repo.ID)
+
+	if repo.IsEmpty || repo.DefaultBranch == "" {
+		return removeLocalIndex(indexPath)
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	commit, err := s.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: readParams,
+		Revision:   repo.DefaultBranch,
+	})
+	if gitnesserrors.IsNotFound(err) {
+		return removeLocalIndex(indexPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get latest commit of the default branch: %w", err)
+	}
+
+	treeSHA := commit.Commit.TreeSHA.String()
+
+	old, err := openLocalIndex(indexPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to open index of repo %d, rebuilding it", repo.ID)
+	}
+	if old != nil {
+		defer old.Close()
+
+		if old.header.Version == localIndexVersion &&
+			old.header.Branch == repo.DefaultBranch &&
+			old.header.TreeSHA == treeSHA {
+			return nil
+		}
+	}
+
+	// content of unchanged blobs is taken over from the existing index.
+	reusable := map[string]int{}
+	if old != nil && old.header.Version == localIndexVersion {
+		for i, f := range old.header.Files {
+			reusable[f.SHA] = i
+		}
+	}
+
+	var blobs []git.TreeNode
+	err = s.listBlobs(ctx, readParams, commit.Commit.SHA.String(), "", &blobs)
+	if err != nil {
+		return err
+	}
+
+	slices.SortFunc(blobs, func(a, b git.TreeNode) int { return strings.Compare(a.Path, b.Path) })
+
+	w, err := newLocalIndexWriter(indexPath, repo.DefaultBranch, treeSHA)
+	if err != nil {
+		return err
+	}
+
+	for _, blob := range blobs {
+		var content []byte
+		if i, ok := reusable[blob.SHA]; ok {
+			content, err = old.content(i)
+		} else {
+			content, err = s.readBlob(ctx, readParams, blob.SHA)
+		}
+		if err != nil {
+			w.Abort()
+			return fmt.Errorf("failed to get content of %q: %w", blob.Path, err)
+		}
+
+		if content == nil {
+			continue
+		}
+
+		if err = w.Add(blob.Path, blob.SHA, content); err != nil {
+			w.Abort()
+			return err
+		}
+	}
+
+	return w.Commit()
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
+// listBlobs recursively lists all regular files of the provided directory.
+func (s *LocalIndexSearcher) listBlobs(
+	ctx context.Context,
+	readParams git.ReadParams,
+	rev string,
+	dir string,
+	blobs *[]git.TreeNode,
+) error {
+	out, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     rev,
+		Path:       dir,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list tree nodes of %q: %w", dir, err)
+	}
+
+	for _, node := range out.Nodes {
+		switch {
+		case node.Type == git.TreeNodeTypeTree:
+			if err = s.listBlobs(ctx, readParams, rev, node.Path, blobs); err != nil {
+				return err
+			}
+		case node.Type == git.TreeNodeTypeBlob && node.Mode != git.TreeNodeModeSymlink:
+			*blobs = append(*blobs, node)
+		}
+	}
+
 	return nil
 }
+
+// readBlob returns the content of the blob, or nil in case the blob is too large or binary.
+func (s *LocalIndexSearcher) readBlob(
+	ctx context.Context,
+	readParams git.ReadParams,
+	blobSHA string,
+) ([]byte, error) {
+	out, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        blobSHA,
+		SizeLimit:  s.config.MaxFileSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Content.Close()
+
+	if out.Size > s.config.MaxFileSize {
+		return nil, nil
+	}
+
+	content, err := io.ReadAll(out.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	if bytes.IndexByte(content[:min(len(content), binaryDetectionSize)], 0) >= 0 {
+		return nil, nil
+	}
+
+	return content, nil
+}
+
+// DeleteIndex removes the index of the repository.
+func (s *LocalIndexSearcher) DeleteIndex(_ context.Context, repoID int64) error {
+	unlock := s.lockRepo(repoID)
+	defer unlock()
+
+	return removeLocalIndex(s.indexPath(repoID))
+}
+
+func (s *LocalIndexSearcher) indexPath(repoID int64) string {
+	return filepath.Join(s.config.IndexDir, strconv.FormatInt(repoID, 10)+".idx")
+}
+
+func (s *LocalIndexSearcher) lockRepo(repoID int64) func() {
+	mx, _ := s.repoLocks.LoadOrStore(repoID, &sync.Mutex{})
+	mx.(*sync.Mutex).Lock()
+	return mx.(*sync.Mutex).Unlock
+}
+
+var languagesByExtension = map[string]string{
+	".c":     "C",
+	".cc":    "C++",
+	".cpp":   "C++",
+	".cs":    "C#",
+	".css":   "CSS",
+	".go":    "Go",
+	".h":     "C",
+	".hpp":   "C++",
+	".html":  "HTML",
+	".java":  "Java",
+	".js":    "JavaScript",
+	".json":  "JSON",
+	".jsx":   "JavaScript",
+	".kt":    "Kotlin",
+	".md":    "Markdown",
+	".php":   "PHP",
+	".py":    "Python",
+	".rb":    "Ruby",
+	".rs":    "Rust",
+	".scala": "Scala",
+	".sh":    "Shell",
+	".sql":   "SQL",
+	".swift": "Swift",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+	".xml":   "XML",
+	".yaml":  "YAML",
+	".yml":   "YAML",
+}
+
+func languageFromFileName(fileName string) string {
+	if path.Base(fileName) == "Dockerfile" {
+		return "Dockerfile"
+	}
+	return languagesByExtension[strings.ToLower(path.Ext(fileName))]
+}
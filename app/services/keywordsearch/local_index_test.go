@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+)
+
+func TestLocalIndexSearch(t *testing.T) {
+	files := []struct {
+		path    string
+		content string
+	}{
+		{path: "README.md", content: "# Hello World\nThis is a readme.\n"},
+		{path: "main.go", content: "package main\n\nfunc main() {\n\tprintln(\"hello world\")\n}\n"},
+		{path: "util.go", content: "package main\n\nfunc helper() int {\n\treturn 42\n}\n"},
+	}
+
+	indexPath := filepath.Join(t.TempDir(), "1.idx")
+
+	w, err := newLocalIndexWriter(indexPath, "main", "tree-sha")
+	if err != nil {
+		t.Fatalf("failed to create index writer: %s", err)
+	}
+	for _, f := range files {
+		if err = w.Add(f.path, f.path+"-sha", []byte(f.content)); err != nil {
+			t.Fatalf("failed to add file: %s", err)
+		}
+	}
+	if err = w.Commit(); err != nil {
+		t.Fatalf("failed to commit index: %s", err)
+	}
+
+	idx, err := openLocalIndex(indexPath)
+	if err != nil {
+		t.Fatalf("failed to open index: %s", err)
+	}
+	defer idx.Close()
+
+	if idx.header.Branch != "main" || idx.header.TreeSHA != "tree-sha" {
+		t.Fatalf("unexpected index header: %+v", idx.header)
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		enableRegex   bool
+		caseSensitive bool
+		expected      map[string][]int // file path -> matched line numbers
+	}{
+		{
+			name:     "literal case insensitive",
+			query:    "hello world",
+			expected: map[string][]int{"README.md": {1}, "main.go": {4}},
+		},
+		{
+			name:          "literal case sensitive",
+			query:         "Hello",
+			caseSensitive: true,
+			expected:      map[string][]int{"README.md": {1}},
+		},
+		{
+			name:     "literal with regex characters",
+			query:    "main()",
+			expected: map[string][]int{"main.go": {3}},
+		},
+		{
+			name:        "regex",
+			query:       `func \w+\(\) int`,
+			enableRegex: true,
+			expected:    map[string][]int{"util.go": {3}},
+		},
+		{
+			name:        "regex without literals",
+			query:       `\d\d`,
+			enableRegex: true,
+			expected:    map[string][]int{"util.go": {4}},
+		},
+		{
+			name:     "short query",
+			query:    "42",
+			expected: map[string][]int{"util.go": {4}},
+		},
+		{
+			name:     "no match",
+			query:    "goodbye",
+			expected: map[string][]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := compileSearchQuery(test.query, test.enableRegex, test.caseSensitive)
+			if err != nil {
+				t.Fatalf("failed to compile query: %s", err)
+			}
+
+			got := map[string][]int{}
+			for _, pos := range idx.candidates(q.trigrams) {
+				content, err := idx.content(int(pos))
+				if err != nil {
+					t.Fatalf("failed to read content: %s", err)
+				}
+
+				for _, m := range q.match(string(content)) {
+					got[idx.header.Files[pos].Path] = append(got[idx.header.Files[pos].Path], m.LineNum)
+				}
+			}
+
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSearchQueryMatchFragments(t *testing.T) {
+	q, err := compileSearchQuery("ab", false, false)
+	if err != nil {
+		t.Fatalf("failed to compile query: %s", err)
+	}
+
+	matches := q.match("first\nxxABxxab-\nlast")
+	if len(matches) != 1 {
+		t.Fatalf("expected a single match, got %d", len(matches))
+	}
+
+	m := matches[0]
+	if m.LineNum != 2 || m.Before != "first" || m.After != "last" {
+		t.Errorf("unexpected match: %+v", m)
+	}
+
+	var line string
+	for _, f := range m.Fragments {
+		line += f.Pre + f.Match + f.Post
+	}
+	if len(m.Fragments) != 2 || line != "xxABxxab-" {
+		t.Errorf("unexpected fragments: %+v", m.Fragments)
+	}
+}
+
+func TestHandleRepoDeleted(t *testing.T) {
+	searcher := NewLocalIndexSearcher(Config{IndexDir: t.TempDir()}, nil, nil, nil)
+	service := &Service{indexer: searcher}
+
+	indexPath := searcher.indexPath(1)
+
+	w, err := newLocalIndexWriter(indexPath, "main", "tree-sha")
+	if err != nil {
+		t.Fatalf("failed to create index writer: %s", err)
+	}
+	if err = w.Commit(); err != nil {
+		t.Fatalf("failed to commit index: %s", err)
+	}
+
+	deleted := func(repoID int64) *events.Event[*repoevents.DeletedPayload] {
+		return &events.Event[*repoevents.DeletedPayload]{
+			Payload: &repoevents.DeletedPayload{Base: repoevents.Base{RepoID: repoID}},
+		}
+	}
+
+	if err = service.handleRepoDeleted(context.Background(), deleted(1)); err != nil {
+		t.Fatalf("failed to handle repo deleted event: %s", err)
+	}
+
+	if _, err = os.Stat(indexPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the index to be removed, got %v", err)
+	}
+
+	// repositories that were never indexed have nothing to remove.
+	if err = service.handleRepoDeleted(context.Background(), deleted(2)); err != nil {
+		t.Errorf("failed to handle repo deleted event of unindexed repo: %s", err)
+	}
+}
@@ -33,6 +33,10 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+	// IndexDir is the directory in which the local index searcher stores its indices.
+	IndexDir string
+	// MaxFileSize is the size (in bytes) above which files aren't indexed.
+	MaxFileSize int64
 }
 
 func (c *Config) Prepare() error {
@@ -48,6 +52,12 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.IndexDir == "" {
+		return errors.New("config.IndexDir is required")
+	}
+	if c.MaxFileSize < 1 {
+		return errors.New("config.MaxFileSize has to be a positive number")
+	}
 	return nil
 }
 
@@ -106,6 +116,7 @@ func NewService(
 				))
 
 			_ = r.RegisterDefaultBranchUpdated((service.handleUpdateDefaultBranch))
+			_ = r.RegisterDeleted(service.handleRepoDeleted)
 			return nil
 		})
 	if err != nil {
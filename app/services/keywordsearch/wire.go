@@ -16,11 +16,14 @@ package keywordsearch
 
 import (
 	"context"
+	"fmt"
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
@@ -48,8 +51,20 @@ func ProvideService(ctx context.Context,
 		indexer)
 }
 
-func ProvideLocalIndexSearcher() *LocalIndexSearcher {
-	return NewLocalIndexSearcher()
+func ProvideLocalIndexSearcher(
+	config Config,
+	git git.Interface,
+	repoStore store.RepoStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*LocalIndexSearcher, error) {
+	searcher := NewLocalIndexSearcher(config, git, repoStore, scheduler)
+
+	if err := executor.Register(jobTypeIndex, searcher); err != nil {
+		return nil, fmt.Errorf("failed to register keyword search index job handler: %w", err)
+	}
+
+	return searcher, nil
 }
 
 func ProvideIndexer(l *LocalIndexSearcher) Indexer {
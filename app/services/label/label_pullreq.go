@@ -297,28 +297,53 @@ func (s *Service) ListPullReqLabels(
 	pullreqID int64,
 	filter *types.AssignableLabelFilter,
 ) (*types.ScopesLabels, int64, error) {
+	spaceIDs, spaces, err := s.spaceHierarchy(ctx, spaceID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pullreqAssignments, err := s.pullReqLabelAssignmentStore.ListAssigned(ctx, pullreqID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list labels assigned to pullreq: %w", err)
+	}
+
+	return s.listAssignments(ctx, repo, spaceIDs, spaces, pullreqAssignments, filter)
+}
+
+func (s *Service) spaceHierarchy(
+	ctx context.Context,
+	spaceID int64,
+) ([]int64, []*types.SpaceCore, error) {
 	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, spaceID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get space hierarchy: %w", err)
+		return nil, nil, fmt.Errorf("failed to get space hierarchy: %w", err)
 	}
 
 	spaces := make([]*types.SpaceCore, len(spaceIDs))
 	for i, id := range spaceIDs {
 		spaces[i], err = s.spaceFinder.FindByID(ctx, id)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to find space by ID: %w", err)
+			return nil, nil, fmt.Errorf("failed to find space by ID: %w", err)
 		}
 	}
 
-	scopeLabelsMap := make(map[int64]*types.ScopeData)
+	return spaceIDs, spaces, nil
+}
 
-	pullreqAssignments, err := s.pullReqLabelAssignmentStore.ListAssigned(ctx, pullreqID)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list labels assigned to pullreq: %w", err)
-	}
+// listAssignments returns the assigned labels grouped by scope. If the filter requests assignable labels,
+// all labels available to the repo are returned instead, with the assigned ones marked as such.
+func (s *Service) listAssignments(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	spaceIDs []int64,
+	spaces []*types.SpaceCore,
+	assignments map[int64]*types.LabelAssignment,
+	filter *types.AssignableLabelFilter,
+) (*types.ScopesLabels, int64, error) {
+	scopeLabelsMap := make(map[int64]*types.ScopeData)
 
 	if !filter.Assignable {
-		sortedAssignments := maps.Values(pullreqAssignments)
+		sortedAssignments := maps.Values(assignments)
 		sort.Slice(sortedAssignments, func(i, j int) bool {
 			if sortedAssignments[i].Key != sortedAssignments[j].Key {
 				return sortedAssignments[i].Key < sortedAssignments[j].Key
@@ -356,7 +381,7 @@ func (s *Service) ListPullReqLabels(
 
 	allAssignments := make([]*types.LabelAssignment, len(labelInfos))
 	for i, labelInfo := range labelInfos {
-		assignment, ok := pullreqAssignments[labelInfo.ID]
+		assignment, ok := assignments[labelInfo.ID]
 		if !ok {
 			assignment = &types.LabelAssignment{
 				LabelInfo: *labelInfo,
@@ -32,7 +32,8 @@ func ProvideLabel(
 	labelStore store.LabelStore,
 	labelValueStore store.LabelValueStore,
 	pullReqLabelStore store.PullReqLabelAssignmentStore,
+	issueLabelStore store.IssueLabelAssignmentStore,
 	spaceFinder refcache.SpaceFinder,
 ) *Service {
-	return New(tx, spaceStore, labelStore, labelValueStore, pullReqLabelStore, spaceFinder)
+	return New(tx, spaceStore, labelStore, labelValueStore, pullReqLabelStore, issueLabelStore, spaceFinder)
 }
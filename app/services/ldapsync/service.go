@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+)
+
+const jobType = "ldap-sync"
+
+// DeprovisionAction is applied to the users that were removed from the directory.
+type DeprovisionAction string
+
+const (
+	DeprovisionActionBlock  DeprovisionAction = "block"
+	DeprovisionActionDelete DeprovisionAction = "delete"
+)
+
+type Config struct {
+	CRON              string
+	MaxDuration       time.Duration
+	DeprovisionAction DeprovisionAction
+}
+
+// Service periodically mirrors the groups of the LDAP directory into user groups and space memberships,
+// and deprovisions the users that were removed from the directory.
+type Service struct {
+	config               Config
+	ldapClient           *ldap.Client
+	principalStore       store.PrincipalStore
+	identityStore        store.PrincipalIdentityStore
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+	membershipStore      store.MembershipStore
+	spaceFinder          refcache.SpaceFinder
+	scheduler            *job.Scheduler
+}
+
+func NewService(
+	config Config,
+	ldapClient *ldap.Client,
+	principalStore store.PrincipalStore,
+	identityStore store.PrincipalIdentityStore,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	membershipStore store.MembershipStore,
+	spaceFinder refcache.SpaceFinder,
+	scheduler *job.Scheduler,
+) (*Service, error) {
+	switch config.DeprovisionAction {
+	case DeprovisionActionBlock, DeprovisionActionDelete:
+	default:
+		return nil, fmt.Errorf("unsupported ldap deprovision action %q", config.DeprovisionAction)
+	}
+
+	return &Service{
+		config:               config,
+		ldapClient:           ldapClient,
+		principalStore:       principalStore,
+		identityStore:        identityStore,
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+		membershipStore:      membershipStore,
+		spaceFinder:          spaceFinder,
+		scheduler:            scheduler,
+	}, nil
+}
+
+var _ job.Handler = (*Service)(nil)
+
+// Register schedules the recurring LDAP sync job. Nothing is scheduled if LDAP is disabled.
+func (s *Service) Register(ctx context.Context) error {
+	if s.ldapClient == nil {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.config.CRON, s.config.MaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for ldap sync: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the LDAP sync background job handler.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if s.ldapClient == nil {
+		return "ldap is disabled", nil
+	}
+
+	result, err := s.Sync(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("synced %d users, deprovisioned %d users, synced %d groups",
+		result.Users, result.Deprovisioned, result.Groups), nil
+}
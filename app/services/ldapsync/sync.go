@@ -0,0 +1,365 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/rolemapping"
+	"github.com/harness/gitness/app/bootstrap"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Result contains the statistics of a sync.
+type Result struct {
+	Users         int
+	Deprovisioned int
+	Groups        int
+}
+
+// Sync mirrors the directory into the database:
+//   - Users linked to a directory account that no longer exists are blocked or deleted.
+//     Users that reappear in the directory are not unblocked automatically.
+//   - The admin status of users is synced with the admin groups, if configured.
+//   - Each mapped group is mirrored into a user group of the mapped space with the same members.
+//   - Users get the role of the mapped groups in the mapped spaces, and lose the memberships
+//     of the mapped spaces if they are no longer members of any of the mapped groups.
+//
+// Only users that have already logged in with LDAP are synced.
+func (s *Service) Sync(ctx context.Context) (Result, error) {
+	config := s.ldapClient.Config()
+
+	dirUsers, err := s.ldapClient.ListUsers(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to list directory users: %w", err)
+	}
+
+	dirGroups, err := s.ldapClient.ListGroups(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to list directory groups: %w", err)
+	}
+
+	identities, err := s.identityStore.ListByProvider(ctx, enum.IdentityProviderLDAP)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to list ldap identities: %w", err)
+	}
+
+	linked, removed := matchIdentities(dirUsers, identities)
+
+	result := Result{}
+
+	// an empty directory is most likely the result of a misconfiguration, so nobody is deprovisioned.
+	if len(dirUsers) == 0 && len(removed) > 0 {
+		log.Ctx(ctx).Warn().Msg("ldap sync found no users in the directory, skipping deprovisioning")
+		removed = nil
+	}
+
+	for _, identity := range removed {
+		deprovisioned, err := s.deprovision(ctx, identity.PrincipalID)
+		if err != nil {
+			return result, err
+		}
+		if deprovisioned {
+			result.Deprovisioned++
+		}
+	}
+
+	users := make(map[int64]*types.User, len(linked))
+	for _, l := range linked {
+		user, err := s.principalStore.FindUser(ctx, l.identity.PrincipalID)
+		if err != nil {
+			return result, fmt.Errorf("failed to find user %d: %w", l.identity.PrincipalID, err)
+		}
+
+		if user.Blocked {
+			continue
+		}
+
+		if l.identity.Email != l.user.Email {
+			l.identity.Email = l.user.Email
+			l.identity.Updated = time.Now().UnixMilli()
+			if err = s.identityStore.Update(ctx, l.identity); err != nil {
+				return result, fmt.Errorf("failed to update identity of user %q: %w", user.UID, err)
+			}
+		}
+
+		users[user.ID] = user
+	}
+
+	result.Users = len(users)
+
+	principalGroups, groupPrincipals := mapGroupMembers(dirGroups, linked, users)
+
+	if len(config.AdminGroups) > 0 {
+		for _, user := range users {
+			isAdmin := slices.ContainsFunc(config.AdminGroups, func(g string) bool {
+				return slices.Contains(principalGroups[user.ID], g)
+			})
+			if err = s.syncAdmin(ctx, user, isAdmin); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	spaces, err := rolemapping.ResolveSpaces(ctx, s.spaceFinder, config.RoleMappings)
+	if err != nil {
+		return result, err
+	}
+
+	for _, m := range config.RoleMappings {
+		space, ok := spaces[m.SpacePath]
+		if !ok {
+			continue
+		}
+
+		if err = s.syncUserGroup(ctx, space.ID, m.Group, groupPrincipals[m.Group]); err != nil {
+			return result, err
+		}
+
+		result.Groups++
+	}
+
+	systemPrincipalID := bootstrap.NewSystemServiceSession().Principal.ID
+
+	for _, user := range users {
+		roles := rolemapping.SpaceRoles(config.RoleMappings, principalGroups[user.ID])
+		for spacePath, space := range spaces {
+			err = rolemapping.SyncMembership(ctx, s.membershipStore, space.ID, user.ID, roles[spacePath],
+				systemPrincipalID)
+			if err != nil {
+				return result, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+type linkedUser struct {
+	identity *types.PrincipalIdentity
+	user     *ldap.User
+}
+
+// matchIdentities matches the ldap identities to the directory users.
+// Returns the identities with the matching users and the identities without a matching user.
+func matchIdentities(
+	dirUsers []*ldap.User,
+	identities []*types.PrincipalIdentity,
+) ([]linkedUser, []*types.PrincipalIdentity) {
+	usersByID := make(map[string]*ldap.User, len(dirUsers))
+	for _, u := range dirUsers {
+		usersByID[u.ID] = u
+	}
+
+	var (
+		linked  []linkedUser
+		removed []*types.PrincipalIdentity
+	)
+
+	for _, identity := range identities {
+		u, ok := usersByID[identity.Subject]
+		if !ok {
+			removed = append(removed, identity)
+			continue
+		}
+
+		linked = append(linked, linkedUser{identity: identity, user: u})
+	}
+
+	return linked, removed
+}
+
+// mapGroupMembers returns the group names of each of the users and the users of each of the groups.
+// Members of the groups that aren't in the provided users are ignored.
+func mapGroupMembers(
+	dirGroups []*ldap.Group,
+	linked []linkedUser,
+	users map[int64]*types.User,
+) (map[int64][]string, map[string][]int64) {
+	principalsByDN := make(map[string]int64, len(linked))
+	for _, l := range linked {
+		if _, ok := users[l.identity.PrincipalID]; ok {
+			principalsByDN[ldap.NormalizeDN(l.user.DN)] = l.identity.PrincipalID
+		}
+	}
+
+	principalGroups := make(map[int64][]string)
+	groupPrincipals := make(map[string][]int64)
+
+	for _, g := range dirGroups {
+		for _, memberDN := range g.MemberDNs {
+			principalID, ok := principalsByDN[ldap.NormalizeDN(memberDN)]
+			if !ok || slices.Contains(groupPrincipals[g.Name], principalID) {
+				continue
+			}
+
+			principalGroups[principalID] = append(principalGroups[principalID], g.Name)
+			groupPrincipals[g.Name] = append(groupPrincipals[g.Name], principalID)
+		}
+	}
+
+	return principalGroups, groupPrincipals
+}
+
+func (s *Service) deprovision(ctx context.Context, principalID int64) (bool, error) {
+	user, err := s.principalStore.FindUser(ctx, principalID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find user %d for deprovisioning: %w", principalID, err)
+	}
+
+	if user.Blocked && s.config.DeprovisionAction == DeprovisionActionBlock {
+		return false, nil
+	}
+
+	if user.Admin {
+		admUsrCount, err := s.principalStore.CountUsers(ctx, &types.UserFilter{Admin: true})
+		if err != nil {
+			return false, fmt.Errorf("failed to check admin user count: %w", err)
+		}
+
+		if admUsrCount <= 1 {
+			log.Ctx(ctx).Warn().Msgf("ldap sync is not deprovisioning the only admin user %q", user.UID)
+			return false, nil
+		}
+	}
+
+	switch s.config.DeprovisionAction {
+	case DeprovisionActionDelete:
+		if err = s.principalStore.DeleteUser(ctx, user.ID); err != nil {
+			return false, fmt.Errorf("failed to delete user %q: %w", user.UID, err)
+		}
+	case DeprovisionActionBlock:
+		user.Blocked = true
+		user.Updated = time.Now().UnixMilli()
+		if err = s.principalStore.UpdateUser(ctx, user); err != nil {
+			return false, fmt.Errorf("failed to block user %q: %w", user.UID, err)
+		}
+	}
+
+	log.Ctx(ctx).Info().Msgf("ldap sync deprovisioned user %q (%s)", user.UID, s.config.DeprovisionAction)
+
+	return true, nil
+}
+
+func (s *Service) syncAdmin(ctx context.Context, user *types.User, admin bool) error {
+	if user.Admin == admin {
+		return nil
+	}
+
+	// Never revoke the admin status of the only admin user.
+	if user.Admin {
+		admUsrCount, err := s.principalStore.CountUsers(ctx, &types.UserFilter{Admin: true})
+		if err != nil {
+			return fmt.Errorf("failed to check admin user count: %w", err)
+		}
+
+		if admUsrCount <= 1 {
+			return nil
+		}
+	}
+
+	user.Admin = admin
+	user.Updated = time.Now().UnixMilli()
+
+	if err := s.principalStore.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to update admin status of user %q: %w", user.UID, err)
+	}
+
+	return nil
+}
+
+// syncUserGroup mirrors the directory group into a user group of the space.
+func (s *Service) syncUserGroup(ctx context.Context, spaceID int64, groupName string, principalIDs []int64) error {
+	identifier := UserGroupIdentifier(groupName)
+	if err := check.Identifier(identifier); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("ldap group %q can't be mirrored into a user group", groupName)
+		return nil
+	}
+
+	userGroup, err := s.userGroupStore.FindByIdentifier(ctx, spaceID, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		now := time.Now().UnixMilli()
+		userGroup = &types.UserGroup{
+			Identifier:  identifier,
+			Name:        groupName,
+			Description: fmt.Sprintf("Mirrored from the LDAP group %q", groupName),
+			SpaceID:     spaceID,
+			Created:     now,
+			Updated:     now,
+		}
+		err = s.userGroupStore.Create(ctx, spaceID, userGroup)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find or create user group %q: %w", identifier, err)
+	}
+
+	members, err := s.userGroupMemberStore.MapPrincipalIDs(ctx, []int64{userGroup.ID})
+	if err != nil {
+		return fmt.Errorf("failed to list members of user group %q: %w", identifier, err)
+	}
+
+	current := members[userGroup.ID]
+	systemPrincipalID := bootstrap.NewSystemServiceSession().Principal.ID
+
+	for _, principalID := range principalIDs {
+		if slices.Contains(current, principalID) {
+			continue
+		}
+
+		err = s.userGroupMemberStore.Create(ctx, &types.UserGroupMember{
+			UserGroupID: userGroup.ID,
+			PrincipalID: principalID,
+			CreatedBy:   systemPrincipalID,
+			Created:     time.Now().UnixMilli(),
+		})
+		if err != nil && !errors.Is(err, gitness_store.ErrDuplicate) {
+			return fmt.Errorf("failed to add member to user group %q: %w", identifier, err)
+		}
+	}
+
+	for _, principalID := range current {
+		if slices.Contains(principalIDs, principalID) {
+			continue
+		}
+
+		err = s.userGroupMemberStore.Delete(ctx, userGroup.ID, principalID)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to remove member from user group %q: %w", identifier, err)
+		}
+	}
+
+	return nil
+}
+
+// UserGroupIdentifier returns the identifier of the user group the directory group is mirrored into.
+func UserGroupIdentifier(groupName string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(groupName))
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapsync
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/types"
+)
+
+func TestMatchIdentities(t *testing.T) {
+	dirUsers := []*ldap.User{
+		{DN: "uid=john,ou=users,dc=example,dc=org", ID: "id-john"},
+		{DN: "uid=jane,ou=users,dc=example,dc=org", ID: "id-jane"},
+	}
+
+	identities := []*types.PrincipalIdentity{
+		{PrincipalID: 1, Subject: "id-john"},
+		{PrincipalID: 2, Subject: "id-removed"},
+	}
+
+	linked, removed := matchIdentities(dirUsers, identities)
+
+	if len(linked) != 1 || linked[0].identity.PrincipalID != 1 || linked[0].user != dirUsers[0] {
+		t.Errorf("expected john to be linked, got %+v", linked)
+	}
+
+	if len(removed) != 1 || removed[0].PrincipalID != 2 {
+		t.Errorf("expected principal 2 to be removed, got %+v", removed)
+	}
+}
+
+func TestMapGroupMembers(t *testing.T) {
+	linked := []linkedUser{
+		{
+			identity: &types.PrincipalIdentity{PrincipalID: 1},
+			user:     &ldap.User{DN: "uid=john,ou=users,dc=example,dc=org"},
+		},
+		{
+			identity: &types.PrincipalIdentity{PrincipalID: 2},
+			user:     &ldap.User{DN: "uid=jane,ou=users,dc=example,dc=org"},
+		},
+		{
+			// blocked users are not in the users map.
+			identity: &types.PrincipalIdentity{PrincipalID: 3},
+			user:     &ldap.User{DN: "uid=blocked,ou=users,dc=example,dc=org"},
+		},
+	}
+
+	users := map[int64]*types.User{1: {ID: 1}, 2: {ID: 2}}
+
+	dirGroups := []*ldap.Group{
+		{
+			Name: "developers",
+			MemberDNs: []string{
+				"UID=John,OU=Users,DC=example,DC=org",
+				"uid=jane,ou=users,dc=example,dc=org",
+				"uid=blocked,ou=users,dc=example,dc=org",
+				"uid=unknown,ou=users,dc=example,dc=org",
+			},
+		},
+		{
+			Name:      "admins",
+			MemberDNs: []string{"uid=john,ou=users,dc=example,dc=org", "uid=john,ou=users,dc=example,dc=org"},
+		},
+	}
+
+	principalGroups, groupPrincipals := mapGroupMembers(dirGroups, linked, users)
+
+	expectedPrincipalGroups := map[int64][]string{
+		1: {"developers", "admins"},
+		2: {"developers"},
+	}
+	if !reflect.DeepEqual(expectedPrincipalGroups, principalGroups) {
+		t.Errorf("expected principal groups %v, got %v", expectedPrincipalGroups, principalGroups)
+	}
+
+	expectedGroupPrincipals := map[string][]int64{
+		"developers": {1, 2},
+		"admins":     {1},
+	}
+	if !reflect.DeepEqual(expectedGroupPrincipals, groupPrincipals) {
+		t.Errorf("expected group principals %v, got %v", expectedGroupPrincipals, groupPrincipals)
+	}
+}
+
+func TestUserGroupIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"developers":         "developers",
+		"Backend Team":       "Backend_Team",
+		" ops/on-call ":      "ops_on-call",
+		"release.managers_1": "release.managers_1",
+	}
+
+	for groupName, expected := range tests {
+		if got := UserGroupIdentifier(groupName); got != expected {
+			t.Errorf("expected identifier %q for group %q, got %q", expected, groupName, got)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+const refsBranchPrefix = "refs/heads/"
+
+// handleEventBranchUpdated rebuilds the merge queue of the updated branch,
+// because all speculative merges of the queue are based on the previous commit of the branch.
+func (s *Service) handleEventBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	branch := strings.TrimPrefix(event.Payload.Ref, refsBranchPrefix)
+	return s.Process(ctx, event.Payload.RepoID, branch)
+}
+
+func (s *Service) handleEventPullReqBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.processPullReqQueue(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handleEventPullReqClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.processPullReqQueue(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handleEventPullReqMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.processPullReqQueue(ctx, event.Payload.PullReqID)
+}
+
+func (s *Service) handleEventPullReqTargetBranchChanged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.TargetBranchChangedPayload],
+) error {
+	return s.processPullReqQueue(ctx, event.Payload.PullReqID)
+}
+
+// handleEventCheckReported advances the merge queues of the repository when a status check is reported.
+func (s *Service) handleEventCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	branches, err := s.mergeQueueStore.ListBranches(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue branches: %w", err)
+	}
+
+	for _, branch := range branches {
+		if branch.RepoID != event.Payload.RepoID {
+			continue
+		}
+
+		if err := s.Process(ctx, branch.RepoID, branch.TargetBranch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processPullReqQueue advances the merge queue that contains the pull request, if any.
+func (s *Service) processPullReqQueue(ctx context.Context, pullReqID int64) error {
+	entry, err := s.mergeQueueStore.FindByPullReqID(ctx, pullReqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	return s.Process(ctx, entry.RepoID, entry.TargetBranch)
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobType = "merge-queue-process"
+
+var _ job.Handler = (*Service)(nil)
+
+// Register schedules the recurring job that advances all merge queues.
+// The status checks reported by pipelines are picked up by this job.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.config.CRON, s.config.MaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for merge queues: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the merge queue background job handler.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	branches, err := s.mergeQueueStore.ListBranches(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list merge queue branches: %w", err)
+	}
+
+	failed := 0
+	for _, branch := range branches {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		if err := s.Process(ctx, branch.RepoID, branch.TargetBranch); err != nil {
+			// keep going, a single broken queue shouldn't block all others.
+			failed++
+			log.Ctx(ctx).Warn().Err(err).
+				Msgf("failed to process merge queue of branch %q in repo %d", branch.TargetBranch, branch.RepoID)
+		}
+	}
+
+	return fmt.Sprintf("processed %d merge queues, %d failed", len(branches), failed), nil
+}
@@ -0,0 +1,615 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/contextutil"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Process advances the merge queue of the target branch: it (re)builds the speculative merges,
+// ejects the pull requests that can't be merged and lands the pull requests at the front of the queue
+// for which all required status checks succeeded.
+func (s *Service) Process(ctx context.Context, repoID int64, targetBranch string) error {
+	unlock, err := s.locker.LockMergeQueue(ctx, repoID, targetBranch, processTimeout+30*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to lock merge queue for processing: %w", err)
+	}
+	defer unlock()
+
+	ctx, cancel := contextutil.WithNewTimeout(ctx, processTimeout)
+	defer cancel()
+
+	defer s.publish(ctx, repoID, targetBranch)
+
+	repo, err := s.repoFinder.FindByID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	for {
+		changed, err := s.advance(ctx, repo, targetBranch)
+		if err != nil {
+			return err
+		}
+
+		if !changed {
+			return nil
+		}
+	}
+}
+
+// advance makes a single pass through the merge queue. Returns true if the first pull request
+// has been merged or ejected, in which case the speculative merges behind it must be rebuilt.
+//
+//nolint:gocognit // it's easier to follow the queue logic in a single pass.
+func (s *Service) advance(ctx context.Context, repo *types.RepositoryCore, targetBranch string) (bool, error) {
+	entries, err := s.mergeQueueStore.List(ctx, repo.ID, targetBranch)
+	if err != nil {
+		return false, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	branchOut, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: targetBranch,
+	})
+	if errors.IsNotFound(err) {
+		for _, entry := range entries {
+			s.eject(ctx, entry, nil, "The target branch doesn't exist.")
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	rules, err := s.protectionManager.ListRepoBranchRules(ctx, repo.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	base := branchOut.Branch.SHA
+
+	var head *types.MergeQueueEntry
+	var headPR *types.PullReq
+	var headReady bool
+
+	for _, entry := range entries {
+		pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if reason := invalidReason(pr, entry); reason != "" {
+			s.eject(ctx, entry, pr, reason)
+			continue
+		}
+
+		if entry.State != enum.MergeQueueEntryStateChecking || entry.BaseSHA != base.String() {
+			conflict, err := s.speculate(ctx, repo, pr, entry, base)
+			if err != nil {
+				return false, err
+			}
+
+			if conflict != "" {
+				s.eject(ctx, entry, pr, conflict)
+				continue
+			}
+		}
+
+		results, err := s.checkStore.ListResults(ctx, repo.ID, entry.MergeSHA)
+		if err != nil {
+			return false, fmt.Errorf("failed to list status checks: %w", err)
+		}
+
+		required, err := s.requiredChecks(ctx, repo, rules, pr, entry, results)
+		if err != nil {
+			return false, err
+		}
+
+		pending, failed := evaluateChecks(required, results)
+		if len(failed) > 0 {
+			s.eject(ctx, entry, pr, fmt.Sprintf(
+				"The following required status checks failed for the speculative merge commit %s: %s",
+				entry.MergeSHA, strings.Join(failed, ", ")))
+			continue
+		}
+
+		base = sha.Must(entry.MergeSHA)
+
+		if head == nil {
+			head = entry
+			headPR = pr
+			headReady = !pending
+		}
+	}
+
+	if head == nil || !headReady {
+		return false, nil
+	}
+
+	// The protection rules were verified when the pull request was added to the queue,
+	// but the reviews, the code owners or the access of the principal might have changed since.
+	reason, err := s.verifyRules(ctx, repo, rules, head, headPR)
+	if err != nil {
+		return false, err
+	}
+
+	if reason != "" {
+		s.eject(ctx, head, headPR, reason)
+		return true, nil
+	}
+
+	if err := s.land(ctx, repo, head, headPR); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// invalidReason returns why the pull request can't stay in the merge queue, or an empty string.
+func invalidReason(pr *types.PullReq, entry *types.MergeQueueEntry) string {
+	switch {
+	case pr.State != enum.PullReqStateOpen:
+		return "The pull request is not open."
+	case pr.TargetBranch != entry.TargetBranch:
+		return "The target branch of the pull request has changed."
+	case pr.SourceSHA != entry.SourceSHA:
+		return "The source branch of the pull request has been updated."
+	case pr.IsDraft:
+		return "The pull request has been marked as draft."
+	}
+
+	return ""
+}
+
+// verifyRules verifies the protection rules of the pull request on behalf of the principal
+// who added it to the merge queue. Returns a non-empty description if the pull request can't be merged.
+// The status checks are not verified here, they are evaluated against the speculative merge commit.
+func (s *Service) verifyRules(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	rules protection.BranchProtection,
+	entry *types.MergeQueueEntry,
+	pr *types.PullReq,
+) (string, error) {
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return "", fmt.Errorf("failed to find principal who added the pull request to the merge queue: %w", err)
+	}
+
+	session := &auth.Session{Principal: *principal}
+
+	err = apiauth.CheckRepo(ctx, s.authorizer, session, repo, enum.PermissionRepoPush)
+	if apiauth.IsNoAccess(err) {
+		return fmt.Sprintf("%s is no longer allowed to merge the pull request.", principal.DisplayName), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check access of the principal who added the pull request: %w", err)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, s.authorizer, session, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load list of reviewers: %w", err)
+	}
+
+	codeOwnerWithApproval, err := s.codeOwners.Evaluate(ctx, repo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return "", fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	changedFiles, err := s.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    pr.MergeBaseSHA,
+		HeadRef:    pr.SourceSHA,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get changed files of pull request: %w", err)
+	}
+
+	_, violations, err := rules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupID:    s.userGroupService.ListUserIDsByGroupIDs,
+		Actor:                 principal,
+		AllowBypass:           false,
+		IsRepoOwner:           isRepoOwner,
+		TargetRepo:            repo,
+		SourceRepo:            repo,
+		PullReq:               pr,
+		Reviewers:             reviewers,
+		Method:                entry.MergeMethod,
+		CodeOwners:            codeOwnerWithApproval,
+		ChangedFiles:          changedFiles.Files,
+		UnverifiedCommits:     s.signatureVerify.UnverifiedPullReqCommits(repo, pr),
+		SourceUpToDate:        queueSourceUpToDate,
+		SourceHasMergeCommits: protection.SourceHasMergeCommits(s.git, repo, pr),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	violations = protection.WithoutStatusCheckViolations(violations)
+	if protection.IsCritical(violations) {
+		return protection.GenerateErrorMessageForBlockingViolations(violations), nil
+	}
+
+	return "", nil
+}
+
+// queueSourceUpToDate is used instead of protection.SourceUpToDate when verifying the queued pull requests.
+// Landing a pull request moves the target branch, so the source branches of the pull requests behind it
+// are never up to date. That's fine, because each pull request is tested by its speculative merge commit,
+// which is built on top of the current target branch and the pull requests ahead of it in the queue.
+func queueSourceUpToDate(context.Context) (bool, error) {
+	return true, nil
+}
+
+// evaluateChecks returns whether any of the required status checks is still not completed
+// and the sorted list of the required status checks that completed unsuccessfully.
+func evaluateChecks(required map[string]struct{}, results []types.CheckResult) (bool, []string) {
+	statuses := make(map[string]enum.CheckStatus, len(results))
+	for _, result := range results {
+		statuses[result.Identifier] = result.Status
+	}
+
+	var pending bool
+	var failed []string
+
+	for identifier := range required {
+		status, ok := statuses[identifier]
+		switch {
+		case !ok || !status.IsCompleted():
+			pending = true
+		case !status.IsSuccess():
+			failed = append(failed, identifier)
+		}
+	}
+
+	sort.Strings(failed)
+
+	return pending, failed
+}
+
+// requiredChecks returns identifiers of all status checks required by the protection rules.
+// In the merge queue the bypassable status checks are required too, because verifying
+// the speculative merge commits is the reason why the pull request is in the queue.
+func (s *Service) requiredChecks(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	rules protection.BranchProtection,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	results []types.CheckResult,
+) (map[string]struct{}, error) {
+	actor, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal who added the pull request to the merge queue: %w", err)
+	}
+
+	changedFiles, err := s.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    entry.BaseSHA,
+		HeadRef:    entry.MergeSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed files of the speculative merge: %w", err)
+	}
+
+	out, err := rules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		ResolveUserGroupID: s.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              actor,
+		Repo:               repo,
+		PullReq:            pr,
+		CheckResults:       results,
+		ChangedFiles:       changedFiles.Files,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required status checks: %w", err)
+	}
+
+	required := make(map[string]struct{}, len(out.RequiredIdentifiers)+len(out.BypassableIdentifiers))
+	for identifier := range out.RequiredIdentifiers {
+		required[identifier] = struct{}{}
+	}
+	for identifier := range out.BypassableIdentifiers {
+		required[identifier] = struct{}{}
+	}
+
+	return required, nil
+}
+
+// speculate merges the pull request on top of the base commit and writes the result to the queue reference.
+// Returns a non-empty description if the pull request can't be merged.
+func (s *Service) speculate(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	base sha.SHA,
+) (string, error) {
+	writeParams, err := s.createSystemWriteParams(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return "", fmt.Errorf("failed to find principal who added the pull request to the merge queue: %w", err)
+	}
+
+	author, committer := pullreq.MergeIdentities(pr, principal, entry.MergeMethod)
+
+	refQueue, err := git.GetRefPath(strconv.FormatInt(pr.Number, 10), gitenum.RefTypePullReqQueue)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate pull request queue ref name: %w", err)
+	}
+
+	now := time.Now()
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:   writeParams,
+		BaseSHA:       base,
+		BaseBranch:    entry.TargetBranch,
+		HeadRepoUID:   repo.GitUID,
+		HeadBranch:    pr.SourceBranch,
+		Message:       git.CommitMessage(entry.Title, entry.Message),
+		Committer:     committer,
+		CommitterDate: &now,
+		Author:        author,
+		AuthorDate:    &now,
+		Refs: []git.RefUpdate{
+			{
+				Name: refQueue,
+				Old:  sha.SHA{}, // don't care about the previous speculative merge.
+				New:  sha.SHA{}, // update to the result of the merge.
+			},
+		},
+		HeadExpectedSHA: sha.Must(entry.SourceSHA),
+		Method:          gitenum.MergeMethod(entry.MergeMethod),
+	})
+	if errors.IsInvalidArgument(err) || errors.IsPreconditionFailed(err) {
+		return fmt.Sprintf("The pull request can't be merged: %s", errors.Message(err)), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create speculative merge: %w", err)
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		return fmt.Sprintf("Merge blocked by conflicting files: %v", mergeOutput.ConflictFiles), nil
+	}
+
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.BaseSHA = base.String()
+	entry.MergeSHA = mergeOutput.MergeSHA.String()
+
+	if err := s.mergeQueueStore.Update(ctx, entry); err != nil {
+		return "", fmt.Errorf("failed to update merge queue entry: %w", err)
+	}
+
+	s.pullreqEvReporter.MergeQueueSpeculated(ctx, &pullreqevents.MergeQueueSpeculatedPayload{
+		Base:         eventBase(pr, entry.CreatedBy),
+		TargetBranch: entry.TargetBranch,
+		Ref:          refQueue,
+		SourceSHA:    entry.SourceSHA,
+		BaseSHA:      entry.BaseSHA,
+		MergeSHA:     entry.MergeSHA,
+	})
+
+	return "", nil
+}
+
+// land fast-forwards the target branch to the speculative merge commit and marks the pull request as merged.
+func (s *Service) land(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	entry *types.MergeQueueEntry,
+	pr *types.PullReq,
+) error {
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal who added the pull request to the merge queue: %w", err)
+	}
+
+	writeParams, err := s.mergeService.CreateWriteParams(ctx, repo, principal)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// The target branch must still point to the base of the speculative merge, otherwise the update is rejected.
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        entry.TargetBranch,
+		OldValue:    sha.Must(entry.BaseSHA),
+		NewValue:    sha.Must(entry.MergeSHA),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fast-forward target branch to the speculative merge: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().Msgf("merge queue merged pull request %d", pr.Number)
+
+	if err := s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	s.updatePullReqRefs(ctx, repo, pr)
+
+	_, _, err = s.mergeService.Finalize(ctx, pr, pullreq.MergedInput{
+		Principal:          principal,
+		TargetRepo:         repo,
+		SourceRepo:         repo,
+		Method:             entry.MergeMethod,
+		Merged:             time.Now(),
+		SourceSHA:          entry.SourceSHA,
+		TargetSHA:          entry.BaseSHA,
+		MergeBaseSHA:       pr.MergeBaseSHA,
+		MergeSHA:           entry.MergeSHA,
+		DiffStats:          pr.Stats.DiffStats,
+		DeleteSourceBranch: entry.DeleteSourceBranch,
+	})
+
+	return err
+}
+
+// updatePullReqRefs points the pull request head reference to the merged commit
+// and removes the merge and the queue references of the pull request.
+func (s *Service) updatePullReqRefs(ctx context.Context, repo *types.RepositoryCore, pr *types.PullReq) {
+	writeParams, err := s.createSystemWriteParams(ctx, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create write params for updating pull request references")
+		return
+	}
+
+	prNumber := strconv.FormatInt(pr.Number, 10)
+
+	updates := []git.UpdateRefParams{
+		{WriteParams: writeParams, Type: gitenum.RefTypePullReqHead, Name: prNumber, NewValue: sha.Must(pr.SourceSHA)},
+		{WriteParams: writeParams, Type: gitenum.RefTypePullReqMerge, Name: prNumber, NewValue: sha.Nil},
+		{WriteParams: writeParams, Type: gitenum.RefTypePullReqQueue, Name: prNumber, NewValue: sha.Nil},
+	}
+
+	for _, update := range updates {
+		if err := s.git.UpdateRef(ctx, update); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to update pull request %s reference", update.Type)
+		}
+	}
+}
+
+// eject removes the entry from the merge queue and records the reason in the pull request activity.
+// Errors are only logged, the processing of the queue continues with the next entry.
+func (s *Service) eject(ctx context.Context, entry *types.MergeQueueEntry, pr *types.PullReq, reason string) {
+	if pr == nil {
+		var err error
+		if pr, err = s.pullreqStore.Find(ctx, entry.PullReqID); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to find pull request ejected from the merge queue")
+			return
+		}
+	}
+
+	log.Ctx(ctx).Info().Msgf("ejecting pull request %d from the merge queue: %s", pr.Number, reason)
+
+	principalID := bootstrap.NewSystemServiceSession().Principal.ID
+
+	err := s.remove(ctx, entry, pr, principalID, enum.MergeQueueActionEjected, reason)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to eject pull request from the merge queue")
+	}
+}
+
+func (s *Service) remove(
+	ctx context.Context,
+	entry *types.MergeQueueEntry,
+	pr *types.PullReq,
+	principalID int64,
+	action enum.MergeQueueAction,
+	reason string,
+) error {
+	if err := s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, entry.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if writeParams, err := s.createSystemWriteParams(ctx, repo); err == nil {
+		err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+			WriteParams: writeParams,
+			Type:        gitenum.RefTypePullReqQueue,
+			Name:        strconv.FormatInt(pr.Number, 10),
+			NewValue:    sha.Nil,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to delete pull request queue reference")
+		}
+	}
+
+	if pr.State == enum.PullReqStateOpen {
+		s.writeActivity(ctx, pr, principalID, &types.PullRequestActivityPayloadMergeQueue{
+			Action:       action,
+			TargetBranch: entry.TargetBranch,
+			Reason:       reason,
+		})
+	}
+
+	return nil
+}
+
+// createSystemWriteParams creates write parameters for updating the pull request references (git hooks are skipped).
+func (s *Service) createSystemWriteParams(ctx context.Context, repo *types.RepositoryCore) (git.WriteParams, error) {
+	principal := bootstrap.NewSystemServiceSession().Principal
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(ctx),
+		repo.ID,
+		principal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repo.GitUID,
+		EnvVars: envVars,
+	}, nil
+}
+
+func eventBase(pr *types.PullReq, principalID int64) pullreqevents.Base {
+	return pullreqevents.Base{
+		PullReqID:    pr.ID,
+		SourceRepoID: pr.SourceRepoID,
+		TargetRepoID: pr.TargetRepoID,
+		PrincipalID:  principalID,
+		Number:       pr.Number,
+	}
+}
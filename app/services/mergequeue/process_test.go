@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestEvaluateChecks(t *testing.T) {
+	tests := []struct {
+		name       string
+		required   []string
+		results    []types.CheckResult
+		expPending bool
+		expFailed  []string
+	}{
+		{
+			name:       "no-required-checks",
+			required:   nil,
+			results:    []types.CheckResult{{Identifier: "lint", Status: enum.CheckStatusFailure}},
+			expPending: false,
+			expFailed:  nil,
+		},
+		{
+			name:       "missing-result",
+			required:   []string{"build"},
+			results:    nil,
+			expPending: true,
+			expFailed:  nil,
+		},
+		{
+			name:     "running",
+			required: []string{"build", "test"},
+			results: []types.CheckResult{
+				{Identifier: "build", Status: enum.CheckStatusSuccess},
+				{Identifier: "test", Status: enum.CheckStatusRunning},
+			},
+			expPending: true,
+			expFailed:  nil,
+		},
+		{
+			name:     "all-successful",
+			required: []string{"build", "test"},
+			results: []types.CheckResult{
+				{Identifier: "build", Status: enum.CheckStatusSuccess},
+				{Identifier: "test", Status: enum.CheckStatusFailureIgnored},
+			},
+			expPending: false,
+			expFailed:  nil,
+		},
+		{
+			name:     "failed-sorted",
+			required: []string{"build", "test", "lint"},
+			results: []types.CheckResult{
+				{Identifier: "test", Status: enum.CheckStatusError},
+				{Identifier: "build", Status: enum.CheckStatusFailure},
+				{Identifier: "lint", Status: enum.CheckStatusPending},
+			},
+			expPending: true,
+			expFailed:  []string{"build", "test"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			required := make(map[string]struct{}, len(test.required))
+			for _, identifier := range test.required {
+				required[identifier] = struct{}{}
+			}
+
+			pending, failed := evaluateChecks(required, test.results)
+
+			if pending != test.expPending {
+				t.Errorf("pending: expected=%t, got=%t", test.expPending, pending)
+			}
+			if !slices.Equal(failed, test.expFailed) {
+				t.Errorf("failed: expected=%v, got=%v", test.expFailed, failed)
+			}
+		})
+	}
+}
+
+// testGit reports the head of the target branch and whether it is an ancestor of a commit.
+type testGit struct {
+	git.Interface
+	targetSHA sha.SHA
+	ancestors map[string][]string
+}
+
+func (g *testGit) GetBranch(context.Context, *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	return &git.GetBranchOutput{Branch: git.Branch{SHA: g.targetSHA}}, nil
+}
+
+func (g *testGit) IsAncestor(_ context.Context, params git.IsAncestorParams) (git.IsAncestorOutput, error) {
+	ancestors := g.ancestors[params.DescendantCommitSHA.String()]
+	return git.IsAncestorOutput{Ancestor: slices.Contains(ancestors, params.AncestorCommitSHA.String())}, nil
+}
+
+func TestVerifyRulesSourceUpToDate(t *testing.T) {
+	const (
+		base    = "1111111111111111111111111111111111111111"
+		source1 = "2222222222222222222222222222222222222222"
+		source2 = "3333333333333333333333333333333333333333"
+		landed  = "4444444444444444444444444444444444444444"
+	)
+
+	rules := protection.DefPullReq{Merge: protection.DefMerge{RequireUpToDate: true}}
+	if err := rules.Sanitize(); err != nil {
+		t.Fatalf("invalid rules: %s", err)
+	}
+
+	// Both pull requests were up to date with the target branch when they were added to the queue.
+	gitService := &testGit{
+		targetSHA: sha.Must(base),
+		ancestors: map[string][]string{
+			source1: {base},
+			source2: {base},
+			landed:  {base, source1},
+		},
+	}
+
+	repo := &types.RepositoryCore{}
+	pr1 := &types.PullReq{Number: 1, SourceSHA: source1, SourceBranch: "first", TargetBranch: "main"}
+	pr2 := &types.PullReq{Number: 2, SourceSHA: source2, SourceBranch: "second", TargetBranch: "main"}
+
+	verify := func(pr *types.PullReq, sourceUpToDate func(context.Context) (bool, error)) []types.RuleViolations {
+		t.Helper()
+		_, violations, err := rules.MergeVerify(context.Background(), protection.MergeVerifyInput{
+			Method:         enum.MergeMethodMerge,
+			PullReq:        pr,
+			SourceUpToDate: sourceUpToDate,
+		})
+		if err != nil {
+			t.Fatalf("failed to verify rules: %s", err)
+		}
+		return violations
+	}
+
+	if violations := verify(pr1, queueSourceUpToDate); len(violations) > 0 {
+		t.Fatalf("expected the first pull request to pass, got %+v", violations)
+	}
+
+	// Landing the first pull request moves the target branch.
+	gitService.targetSHA = sha.Must(landed)
+
+	if violations := verify(pr2, protection.SourceUpToDate(gitService, repo, pr2)); len(violations) == 0 {
+		t.Fatalf("expected the second pull request to be behind the target branch")
+	}
+
+	if violations := verify(pr2, queueSourceUpToDate); len(violations) > 0 {
+		t.Errorf("expected the second pull request to pass in the merge queue, got %+v", violations)
+	}
+}
@@ -0,0 +1,357 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:mergequeue"
+
+	// processTimeout is the max time given to advancing a single merge queue.
+	processTimeout = 3 * time.Minute
+)
+
+var ErrAlreadyQueued = errors.New("pull request is already in the merge queue")
+
+type Config struct {
+	Concurrency int
+	MaxRetries  int
+	CRON        string
+	MaxDuration time.Duration
+}
+
+// Service maintains the merge queues of the branches.
+//
+// Every pull request in a queue is speculatively merged on top of the pull requests ahead of it,
+// and the result is written to the pull request's queue reference. The required status checks are
+// evaluated against the speculative merge commits. Once the checks of the first pull request succeed,
+// the target branch is fast-forwarded to its speculative merge commit. Pull requests that can't be merged
+// or whose checks fail are ejected from the queue and the speculative merges behind them are rebuilt.
+type Service struct {
+	config            Config
+	mergeQueueStore   store.MergeQueueStore
+	pullreqStore      store.PullReqStore
+	activityStore     store.PullReqActivityStore
+	checkStore        store.CheckStore
+	principalStore    store.PrincipalStore
+	reviewerStore     store.PullReqReviewerStore
+	repoFinder        refcache.RepoFinder
+	git               git.Interface
+	protectionManager *protection.Manager
+	userGroupService  usergroup.Service
+	authorizer        authz.Authorizer
+	codeOwners        *codeowners.Service
+	signatureVerify   publickey.SignatureVerifyService
+	mergeService      *pullreq.MergeService
+	locker            *locker.Locker
+	pullreqEvReporter *pullreqevents.Reporter
+	sseStreamer       sse.Streamer
+	urlProvider       url.Provider
+	scheduler         *job.Scheduler
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	mergeQueueStore store.MergeQueueStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	checkStore store.CheckStore,
+	principalStore store.PrincipalStore,
+	reviewerStore store.PullReqReviewerStore,
+	repoFinder refcache.RepoFinder,
+	git git.Interface,
+	protectionManager *protection.Manager,
+	userGroupService usergroup.Service,
+	authorizer authz.Authorizer,
+	codeOwners *codeowners.Service,
+	signatureVerify publickey.SignatureVerifyService,
+	mergeService *pullreq.MergeService,
+	locker *locker.Locker,
+	pullreqEvReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+	urlProvider url.Provider,
+	scheduler *job.Scheduler,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	instanceID string,
+) (*Service, error) {
+	service := &Service{
+		config:            config,
+		mergeQueueStore:   mergeQueueStore,
+		pullreqStore:      pullreqStore,
+		activityStore:     activityStore,
+		checkStore:        checkStore,
+		principalStore:    principalStore,
+		reviewerStore:     reviewerStore,
+		repoFinder:        repoFinder,
+		git:               git,
+		protectionManager: protectionManager,
+		userGroupService:  userGroupService,
+		authorizer:        authorizer,
+		codeOwners:        codeOwners,
+		signatureVerify:   signatureVerify,
+		mergeService:      mergeService,
+		locker:            locker,
+		pullreqEvReporter: pullreqEvReporter,
+		sseStreamer:       sseStreamer,
+		urlProvider:       urlProvider,
+		scheduler:         scheduler,
+	}
+
+	handlerOptions := stream.WithHandlerOptions(
+		stream.WithIdleTimeout(processTimeout+time.Minute),
+		stream.WithMaxRetries(config.MaxRetries),
+	)
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, instanceID,
+		func(r *gitevents.Reader) error {
+			r.Configure(stream.WithConcurrency(config.Concurrency), handlerOptions)
+
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git events reader: %w", err)
+	}
+
+	_, err = pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, instanceID,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(stream.WithConcurrency(config.Concurrency), handlerOptions)
+
+			_ = r.RegisterBranchUpdated(service.handleEventPullReqBranchUpdated)
+			_ = r.RegisterClosed(service.handleEventPullReqClosed)
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterTargetBranchChanged(service.handleEventPullReqTargetBranchChanged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request events reader: %w", err)
+	}
+
+	_, err = checkEvReaderFactory.Launch(ctx, eventsReaderGroupName, instanceID,
+		func(r *checkevents.Reader) error {
+			r.Configure(stream.WithConcurrency(config.Concurrency), handlerOptions)
+
+			_ = r.RegisterReported(service.handleEventCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// EnqueueInput holds the merge details of a pull request added to the merge queue.
+// The commit title and message must already be final, the merge queue doesn't generate them.
+type EnqueueInput struct {
+	Method             enum.MergeMethod
+	SourceSHA          string
+	Title              string
+	Message            string
+	DeleteSourceBranch bool
+}
+
+// Enqueue adds the pull request to the merge queue of its target branch.
+// The caller is responsible for verifying the pull request's protection rules.
+// The returned entry describes the pull request at the time it was added to the queue,
+// further changes of the queue are published as SSE events.
+func (s *Service) Enqueue(
+	ctx context.Context,
+	principal *types.Principal,
+	pr *types.PullReq,
+	in EnqueueInput,
+) (*types.MergeQueueEntry, error) {
+	now := time.Now().UnixMilli()
+	entry := &types.MergeQueueEntry{
+		RepoID:             pr.TargetRepoID,
+		PullReqID:          pr.ID,
+		PullReqNumber:      pr.Number,
+		TargetBranch:       pr.TargetBranch,
+		State:              enum.MergeQueueEntryStateQueued,
+		MergeMethod:        in.Method,
+		Title:              in.Title,
+		Message:            in.Message,
+		DeleteSourceBranch: in.DeleteSourceBranch,
+		SourceSHA:          in.SourceSHA,
+		CreatedBy:          principal.ID,
+		Created:            now,
+		Updated:            now,
+	}
+
+	err := s.mergeQueueStore.Create(ctx, entry)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, ErrAlreadyQueued
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merge queue entry: %w", err)
+	}
+
+	entries, err := s.mergeQueueStore.List(ctx, entry.RepoID, entry.TargetBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+	entry.Position = len(entries)
+
+	s.writeActivity(ctx, pr, principal.ID, &types.PullRequestActivityPayloadMergeQueue{
+		Action:       enum.MergeQueueActionEnqueued,
+		TargetBranch: pr.TargetBranch,
+	})
+
+	// Build the speculative merge right away. Failures aren't fatal, the queue will be advanced again later.
+	if err := s.Process(ctx, entry.RepoID, entry.TargetBranch); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to process merge queue after adding pull request %d", pr.Number)
+	}
+
+	return entry, nil
+}
+
+// Dequeue removes the pull request from the merge queue.
+func (s *Service) Dequeue(
+	ctx context.Context,
+	principal *types.Principal,
+	pr *types.PullReq,
+) error {
+	entry, err := s.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	err = func() error {
+		unlock, err := s.locker.LockMergeQueue(ctx, entry.RepoID, entry.TargetBranch, processTimeout+30*time.Second)
+		if err != nil {
+			return fmt.Errorf("failed to lock merge queue for update: %w", err)
+		}
+		defer unlock()
+
+		return s.remove(ctx, entry, pr, principal.ID, enum.MergeQueueActionDequeued, "")
+	}()
+	if err != nil {
+		return err
+	}
+
+	// The pull requests behind the removed one need new speculative merges.
+	if err := s.Process(ctx, entry.RepoID, entry.TargetBranch); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to process merge queue after removing pull request %d", pr.Number)
+	}
+
+	return nil
+}
+
+// List returns the merge queue of the target branch.
+func (s *Service) List(ctx context.Context, repoID int64, targetBranch string) (*types.MergeQueue, error) {
+	entries, err := s.mergeQueueStore.List(ctx, repoID, targetBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	return &types.MergeQueue{
+		RepoID:       repoID,
+		TargetBranch: targetBranch,
+		Entries:      entries,
+	}, nil
+}
+
+// FindByPullReq returns the merge queue entry of the pull request.
+func (s *Service) FindByPullReq(ctx context.Context, pr *types.PullReq) (*types.MergeQueueEntry, error) {
+	entry, err := s.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	entries, err := s.mergeQueueStore.List(ctx, entry.RepoID, entry.TargetBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.ID == entry.ID {
+			return e, nil
+		}
+	}
+
+	return entry, nil
+}
+
+func (s *Service) writeActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+) {
+	err := func() error {
+		pr, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to update pull request activity sequence: %w", err)
+		}
+
+		_, err = s.activityStore.CreateWithPayload(ctx, pr, principalID, payload, nil)
+		return err
+	}()
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request merge queue activity")
+	}
+}
+
+// publish sends the current state of the merge queue to the clients.
+func (s *Service) publish(ctx context.Context, repoID int64, targetBranch string) {
+	repo, err := s.repoFinder.FindByID(ctx, repoID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find repository to publish merge queue update")
+		return
+	}
+
+	queue, err := s.List(ctx, repoID, targetBranch)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list merge queue to publish merge queue update")
+		return
+	}
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeMergeQueueUpdated, queue)
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	mergeQueueStore store.MergeQueueStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	checkStore store.CheckStore,
+	principalStore store.PrincipalStore,
+	reviewerStore store.PullReqReviewerStore,
+	repoFinder refcache.RepoFinder,
+	git git.Interface,
+	protectionManager *protection.Manager,
+	userGroupService usergroup.Service,
+	authorizer authz.Authorizer,
+	codeOwners *codeowners.Service,
+	signatureVerify publickey.SignatureVerifyService,
+	mergeService *pullreq.MergeService,
+	locker *locker.Locker,
+	pullreqEvReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+	urlProvider url.Provider,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+) (*Service, error) {
+	service, err := NewService(
+		ctx,
+		Config{
+			Concurrency: config.MergeQueue.Concurrency,
+			MaxRetries:  config.MergeQueue.MaxRetries,
+			CRON:        config.MergeQueue.CRON,
+			MaxDuration: config.MergeQueue.MaxDuration,
+		},
+		mergeQueueStore,
+		pullreqStore,
+		activityStore,
+		checkStore,
+		principalStore,
+		reviewerStore,
+		repoFinder,
+		git,
+		protectionManager,
+		userGroupService,
+		authorizer,
+		codeOwners,
+		signatureVerify,
+		mergeService,
+		locker,
+		pullreqEvReporter,
+		sseStreamer,
+		urlProvider,
+		scheduler,
+		gitReaderFactory,
+		pullreqEvReaderFactory,
+		checkEvReaderFactory,
+		config.InstanceID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = executor.Register(jobType, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
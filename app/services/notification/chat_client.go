@@ -0,0 +1,280 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	chatTemplatesDir = "templates/chat"
+	chatPostTimeout  = 30 * time.Second
+)
+
+// chatTemplates holds the default message templates of the chat channels, per event.
+var chatTemplates map[enum.NotificationChannelEvent]string
+
+func loadChatTemplates() error {
+	chatTemplates = make(map[enum.NotificationChannelEvent]string)
+	tmplFiles, err := fs.ReadDir(files, chatTemplatesDir)
+	if err != nil {
+		return err
+	}
+
+	for _, tmpl := range tmplFiles {
+		if tmpl.IsDir() {
+			continue
+		}
+
+		data, err := fs.ReadFile(files, path.Join(chatTemplatesDir, tmpl.Name()))
+		if err != nil {
+			return err
+		}
+
+		event := enum.NotificationChannelEvent(strings.TrimSuffix(tmpl.Name(), path.Ext(tmpl.Name())))
+		chatTemplates[event] = string(data)
+	}
+
+	return nil
+}
+
+// ParseChatTemplate parses the message template of a chat channel of the provided type.
+// Templates are Go text templates that can use the "link" function to render a link
+// in the markup of the chat platform.
+func ParseChatTemplate(channelType enum.NotificationChannelType, text string) (*template.Template, error) {
+	return template.New("message").
+		Funcs(template.FuncMap{"link": chatLinkFunc(channelType)}).
+		Option("missingkey=zero").
+		Parse(text)
+}
+
+func chatLinkFunc(channelType enum.NotificationChannelType) func(url, text string) string {
+	if channelType == enum.NotificationChannelTypeSlack {
+		escaper := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+		return func(url, text string) string {
+			return "<" + url + "|" + escaper.Replace(text) + ">"
+		}
+	}
+
+	escaper := strings.NewReplacer("[", `\[`, "]", `\]`)
+	return func(url, text string) string {
+		return "[" + escaper.Replace(text) + "](" + url + ")"
+	}
+}
+
+// RenderChatMessage renders the message of the event for the chat channel,
+// using the custom template of the channel if there is one.
+func RenderChatMessage(
+	channel *types.NotificationChannel,
+	event enum.NotificationChannelEvent,
+	data any,
+) (string, error) {
+	text, ok := channel.Templates[event]
+	if !ok || text == "" {
+		text, ok = chatTemplates[event]
+		if !ok {
+			return "", fmt.Errorf("no template for chat event %s", event)
+		}
+	}
+
+	tmpl, err := ParseChatTemplate(channel.Type, text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template for chat event %s: %w", event, err)
+	}
+
+	buf := bytes.Buffer{}
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template for chat event %s: %w", event, err)
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// ChatClient is a notification client that posts the notifications to chat channels
+// (Slack, Microsoft Teams, Mattermost) configured in the space of the repository or in any of its ancestors.
+// The recipients are ignored as the messages are posted to the channel.
+type ChatClient struct {
+	channelStore store.NotificationChannelStore
+	spaceStore   store.SpaceStore
+	httpClient   *http.Client
+}
+
+func NewChatClient(
+	channelStore store.NotificationChannelStore,
+	spaceStore store.SpaceStore,
+	httpClient *http.Client,
+) *ChatClient {
+	return &ChatClient{
+		channelStore: channelStore,
+		spaceStore:   spaceStore,
+		httpClient:   httpClient,
+	}
+}
+
+func (c *ChatClient) SendCommentPRAuthor(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendCommentMentions(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendCommentParticipants(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendReviewerAdded(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return c.notify(ctx, payload.Base.Repo, enum.NotificationChannelEventPullReqReviewRequested, payload)
+}
+
+func (c *ChatClient) SendPullReqBranchUpdated(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*PullReqBranchUpdatedPayload,
+) error {
+	return nil
+}
+
+func (c *ChatClient) SendReviewSubmitted(context.Context, []*types.PrincipalInfo, *ReviewSubmittedPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendPullReqStateChanged(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	if payload.State != PullReqStateMerged {
+		return nil
+	}
+
+	return c.notify(ctx, payload.Base.Repo, enum.NotificationChannelEventPullReqMerged, payload)
+}
+
+func (c *ChatClient) SendPullReqCreated(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqCreatedPayload,
+) error {
+	return c.notify(ctx, payload.Base.Repo, enum.NotificationChannelEventPullReqCreated, payload)
+}
+
+func (c *ChatClient) SendPipelineFailed(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PipelineFailedPayload,
+) error {
+	return c.notify(ctx, payload.Repo, enum.NotificationChannelEventPipelineFailed, payload)
+}
+
+func (c *ChatClient) SendRuleBypassed(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *RuleBypassedPayload,
+) error {
+	return c.notify(ctx, payload.Repo, enum.NotificationChannelEventRuleBypassed, payload)
+}
+
+// Post posts the message to the chat channel.
+func (c *ChatClient) Post(ctx context.Context, channel *types.NotificationChannel, message string) error {
+	body, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{Text: message})
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, chatPostTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create chat request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post chat message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("chat channel responded with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+// notify posts the event to all enabled channels that are subscribed to it.
+// Failures of individual channels are only logged, a broken channel shouldn't cause the event to be retried.
+func (c *ChatClient) notify(
+	ctx context.Context,
+	repo *types.Repository,
+	event enum.NotificationChannelEvent,
+	data any,
+) error {
+	spaceIDs, err := c.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to get ancestor spaces of repo %d: %w", repo.ID, err)
+	}
+
+	channels, err := c.channelStore.ListEnabled(ctx, spaceIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list notification channels: %w", err)
+	}
+
+	for _, channel := range channels {
+		if !channel.HasEvent(event) {
+			continue
+		}
+
+		message, err := RenderChatMessage(channel, event, data)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Msgf("failed to render message for notification channel %d", channel.ID)
+			continue
+		}
+
+		if err = c.Post(ctx, channel, message); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Msgf("failed to post message to notification channel %d", channel.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+type chatTestSpaceStore struct {
+	store.SpaceStore
+	ancestorIDs []int64
+}
+
+func (s chatTestSpaceStore) GetAncestorIDs(context.Context, int64) ([]int64, error) {
+	return s.ancestorIDs, nil
+}
+
+type chatTestChannelStore struct {
+	store.NotificationChannelStore
+	channels []*types.NotificationChannel
+}
+
+func (s chatTestChannelStore) ListEnabled(
+	_ context.Context,
+	spaceIDs []int64,
+) ([]*types.NotificationChannel, error) {
+	var result []*types.NotificationChannel
+	for _, c := range s.channels {
+		for _, id := range spaceIDs {
+			if c.SpaceID == id && c.Enabled {
+				result = append(result, c)
+			}
+		}
+	}
+	return result, nil
+}
+
+func TestChatClient(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Text string `json:"text"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received = append(received, r.URL.Path+": "+body.Text)
+	}))
+	defer server.Close()
+
+	channels := []*types.NotificationChannel{
+		{
+			SpaceID: 1,
+			Type:    enum.NotificationChannelTypeSlack,
+			URL:     server.URL + "/slack",
+			Events:  []enum.NotificationChannelEvent{enum.NotificationChannelEventPullReqCreated},
+			Enabled: true,
+		},
+		{
+			SpaceID: 2,
+			Type:    enum.NotificationChannelTypeMattermost,
+			URL:     server.URL + "/mattermost",
+			Events: []enum.NotificationChannelEvent{
+				enum.NotificationChannelEventPullReqCreated,
+				enum.NotificationChannelEventPullReqMerged,
+			},
+			Templates: map[enum.NotificationChannelEvent]string{
+				enum.NotificationChannelEventPullReqCreated: "PR {{link .Base.PullReqURL .Base.PullReq.Title}}",
+			},
+			Enabled: true,
+		},
+		{
+			SpaceID: 2,
+			Type:    enum.NotificationChannelTypeMSTeams,
+			URL:     server.URL + "/disabled",
+			Events:  []enum.NotificationChannelEvent{enum.NotificationChannelEventPullReqCreated},
+			Enabled: false,
+		},
+		{
+			SpaceID: 3,
+			Type:    enum.NotificationChannelTypeMSTeams,
+			URL:     server.URL + "/other-space",
+			Events:  []enum.NotificationChannelEvent{enum.NotificationChannelEventPullReqCreated},
+			Enabled: true,
+		},
+	}
+
+	client := NewChatClient(
+		chatTestChannelStore{channels: channels},
+		chatTestSpaceStore{ancestorIDs: []int64{2, 1}},
+		server.Client(),
+	)
+
+	base := &BasePullReqPayload{
+		Repo:       &types.Repository{ID: 1, ParentID: 2, Path: "space/sub/repo"},
+		PullReq:    &types.PullReq{Number: 7, Title: "Fix <bug> [x]", SourceBranch: "fix", TargetBranch: "main"},
+		Author:     &types.PrincipalInfo{DisplayName: "Jane"},
+		PullReqURL: "http://gitness/pulls/7",
+	}
+
+	ctx := context.Background()
+
+	require.NoError(t, client.SendPullReqCreated(ctx, nil, &PullReqCreatedPayload{Base: base}))
+	require.Equal(t, []string{
+		"/slack: Jane opened pull request <http://gitness/pulls/7|#7 Fix &lt;bug&gt; [x]> in space/sub/repo (fix → main)",
+		"/mattermost: PR [Fix <bug> \\[x\\]](http://gitness/pulls/7)",
+	}, received)
+
+	received = nil
+	require.NoError(t, client.SendPullReqStateChanged(ctx, nil, &PullReqStateChangedPayload{
+		Base:      base,
+		ChangedBy: &types.PrincipalInfo{DisplayName: "John"},
+		State:     PullReqStateClosed,
+	}))
+	require.Empty(t, received)
+
+	require.NoError(t, client.SendPullReqStateChanged(ctx, nil, &PullReqStateChangedPayload{
+		Base:      base,
+		ChangedBy: &types.PrincipalInfo{DisplayName: "John"},
+		State:     PullReqStateMerged,
+	}))
+	require.Equal(t, []string{
+		"/mattermost: John merged pull request [#7 Fix <bug> \\[x\\]](http://gitness/pulls/7) into main in space/sub/repo",
+	}, received)
+}
+
+func TestChatClientPostFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewChatClient(nil, nil, server.Client())
+
+	err := client.Post(context.Background(), &types.NotificationChannel{URL: server.URL}, "hello")
+	require.ErrorContains(t, err, "status 403")
+	require.ErrorContains(t, err, "invalid_token")
+}
+
+func TestParseChatTemplate(t *testing.T) {
+	_, err := ParseChatTemplate(enum.NotificationChannelTypeSlack, "{{.Repo.Path}")
+	require.Error(t, err)
+
+	_, err = ParseChatTemplate(enum.NotificationChannelTypeSlack, `{{link "http://x" "y"}}`)
+	require.NoError(t, err)
+
+	events, _ := enum.GetAllNotificationChannelEvents()
+	for _, event := range events {
+		_, ok := chatTemplates[event]
+		require.True(t, ok, "missing default chat template for %s", event)
+	}
+}
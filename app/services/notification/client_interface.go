@@ -58,4 +58,19 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *PullReqStateChangedPayload,
 	) error
+	SendPullReqCreated(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *PullReqCreatedPayload,
+	) error
+	SendPipelineFailed(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *PipelineFailedPayload,
+	) error
+	SendRuleBypassed(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *RuleBypassedPayload,
+	) error
 }
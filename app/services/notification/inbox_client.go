@@ -0,0 +1,268 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// InboxClient is a notification client that stores the notifications in the in-app inbox
+// of the recipients and delivers them in real time over the server sent events stream.
+type InboxClient struct {
+	notificationStore store.NotificationStore
+	subscriptionStore store.NotificationSubscriptionStore
+	spaceStore        store.SpaceStore
+	sseStreamer       sse.Streamer
+}
+
+func NewInboxClient(
+	notificationStore store.NotificationStore,
+	subscriptionStore store.NotificationSubscriptionStore,
+	spaceStore store.SpaceStore,
+	sseStreamer sse.Streamer,
+) *InboxClient {
+	return &InboxClient{
+		notificationStore: notificationStore,
+		subscriptionStore: subscriptionStore,
+		spaceStore:        spaceStore,
+		sseStreamer:       sseStreamer,
+	}
+}
+
+func (c *InboxClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.notify(ctx, enum.NotificationTypePullReqComment, recipients,
+		payload.Base, payload.Commenter, payload.Text)
+}
+
+func (c *InboxClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.notify(ctx, enum.NotificationTypePullReqMention, recipients,
+		payload.Base, payload.Commenter, payload.Text)
+}
+
+func (c *InboxClient) SendCommentParticipants(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*CommentPayload,
+) error {
+	return nil
+}
+
+func (c *InboxClient) SendReviewerAdded(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	// Only the reviewer gets the review request in the inbox.
+	return c.notify(ctx, enum.NotificationTypePullReqReviewRequested, []*types.PrincipalInfo{payload.Reviewer},
+		payload.Base, nil, "")
+}
+
+func (c *InboxClient) SendPullReqBranchUpdated(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*PullReqBranchUpdatedPayload,
+) error {
+	return nil
+}
+
+func (c *InboxClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return c.notify(ctx, enum.NotificationTypePullReqReviewSubmitted, recipients,
+		payload.Base, payload.Reviewer, string(payload.Decision))
+}
+
+func (c *InboxClient) SendPullReqStateChanged(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*PullReqStateChangedPayload,
+) error {
+	return nil
+}
+
+func (c *InboxClient) SendPullReqCreated(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*PullReqCreatedPayload,
+) error {
+	return nil
+}
+
+func (c *InboxClient) SendPipelineFailed(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*PipelineFailedPayload,
+) error {
+	return nil
+}
+
+func (c *InboxClient) SendRuleBypassed(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*RuleBypassedPayload,
+) error {
+	return nil
+}
+
+func (c *InboxClient) notify(
+	ctx context.Context,
+	notificationType enum.NotificationType,
+	recipients []*types.PrincipalInfo,
+	base *BasePullReqPayload,
+	actor *types.PrincipalInfo,
+	text string,
+) error {
+	if actor != nil {
+		// Nobody should be notified about own actions.
+		filtered := make([]*types.PrincipalInfo, 0, len(recipients))
+		for _, recipient := range recipients {
+			if recipient.ID != actor.ID {
+				filtered = append(filtered, recipient)
+			}
+		}
+		recipients = filtered
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	levels, err := c.notificationLevels(ctx, recipients, base.Repo)
+	if err != nil {
+		return fmt.Errorf("failed to get notification levels of recipients: %w", err)
+	}
+
+	var actorID *int64
+	if actor != nil {
+		actorID = &actor.ID
+	}
+
+	now := time.Now().UnixMilli()
+	subject := GetSubjectPullRequest(base.Repo.Identifier, base.PullReq.Number, base.PullReq.Title)
+
+	for _, recipient := range recipients {
+		if level, ok := levels[recipient.ID]; ok && !level.Allows(notificationType) {
+			continue
+		}
+
+		notification := &types.Notification{
+			PrincipalID:   recipient.ID,
+			Type:          notificationType,
+			RepoID:        base.Repo.ID,
+			PullReqID:     base.PullReq.ID,
+			PullReqNumber: base.PullReq.Number,
+			ActorID:       actorID,
+			Subject:       subject,
+			Text:          text,
+			URL:           base.PullReqURL,
+			Read:          false,
+			Created:       now,
+			Updated:       now,
+		}
+
+		if err = c.notificationStore.Create(ctx, notification); err != nil {
+			return fmt.Errorf("failed to create notification for principal %d: %w", recipient.ID, err)
+		}
+
+		notification.Actor = actor
+
+		c.sseStreamer.PublishToPrincipal(ctx, recipient.ID, enum.SSETypeNotificationCreated, notification)
+	}
+
+	return nil
+}
+
+// notificationLevels returns the effective notification levels of the recipients for the repository.
+// The recipients without a subscription are not included in the result.
+func (c *InboxClient) notificationLevels(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	repo *types.Repository,
+) (map[int64]enum.NotificationLevel, error) {
+	spaces, err := c.spaceStore.GetAncestors(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor spaces of the repository: %w", err)
+	}
+
+	// spaceDepth holds the distance of every ancestor space from the repository.
+	spaceDepth := make(map[int64]int, len(spaces))
+	spaceParent := make(map[int64]int64, len(spaces))
+	spaceIDs := make([]int64, len(spaces))
+	for i, space := range spaces {
+		spaceParent[space.ID] = space.ParentID
+		spaceIDs[i] = space.ID
+	}
+	for depth, spaceID := 1, repo.ParentID; spaceID != 0; depth, spaceID = depth+1, spaceParent[spaceID] {
+		if _, ok := spaceDepth[spaceID]; ok {
+			break
+		}
+		spaceDepth[spaceID] = depth
+	}
+
+	principalIDs := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		principalIDs[i] = recipient.ID
+	}
+
+	subscriptions, err := c.subscriptionStore.ListForResources(ctx, principalIDs, repo.ID, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification subscriptions: %w", err)
+	}
+
+	// closest holds the distance of the closest subscription of every principal. Repository is at distance 0.
+	closest := make(map[int64]int)
+	levels := make(map[int64]enum.NotificationLevel)
+
+	for _, sub := range subscriptions {
+		var depth int
+		switch sub.ResourceType { //nolint:exhaustive
+		case enum.ResourceTypeRepo:
+			depth = 0
+		case enum.ResourceTypeSpace:
+			var ok bool
+			if depth, ok = spaceDepth[sub.ResourceID]; !ok {
+				continue
+			}
+		default:
+			continue
+		}
+
+		if d, ok := closest[sub.PrincipalID]; ok && d <= depth {
+			continue
+		}
+
+		closest[sub.PrincipalID] = depth
+		levels[sub.PrincipalID] = sub.Level
+	}
+
+	return levels, nil
+}
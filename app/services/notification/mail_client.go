@@ -169,6 +169,30 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendPullReqCreated(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*PullReqCreatedPayload,
+) error {
+	return nil
+}
+
+func (m MailClient) SendPipelineFailed(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*PipelineFailedPayload,
+) error {
+	return nil
+}
+
+func (m MailClient) SendRuleBypassed(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*RuleBypassedPayload,
+) error {
+	return nil
+}
+
 func GetSubjectPullRequest(
 	repoIdentifier string,
 	prNum int64,
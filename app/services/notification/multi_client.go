@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/types"
+)
+
+// MultiClient is a notification client that forwards the notifications to all the provided clients.
+// A failure of one client doesn't prevent the other clients from sending the notification.
+type MultiClient []Client
+
+func NewMultiClient(clients ...Client) MultiClient {
+	return clients
+}
+
+func (m MultiClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return m.forEach(func(c Client) error { return c.SendCommentPRAuthor(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return m.forEach(func(c Client) error { return c.SendCommentMentions(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return m.forEach(func(c Client) error { return c.SendCommentParticipants(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return m.forEach(func(c Client) error { return c.SendReviewerAdded(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return m.forEach(func(c Client) error { return c.SendPullReqBranchUpdated(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return m.forEach(func(c Client) error { return c.SendReviewSubmitted(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return m.forEach(func(c Client) error { return c.SendPullReqStateChanged(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendPullReqCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqCreatedPayload,
+) error {
+	return m.forEach(func(c Client) error { return c.SendPullReqCreated(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendPipelineFailed(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PipelineFailedPayload,
+) error {
+	return m.forEach(func(c Client) error { return c.SendPipelineFailed(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendRuleBypassed(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *RuleBypassedPayload,
+) error {
+	return m.forEach(func(c Client) error { return c.SendRuleBypassed(ctx, recipients, payload) })
+}
+
+func (m MultiClient) forEach(fn func(c Client) error) error {
+	var errs []error
+	for _, c := range m {
+		if err := fn(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+)
+
+type PipelineFailedPayload struct {
+	Repo         *types.Repository
+	Pipeline     *types.Pipeline
+	Execution    *types.Execution
+	TriggeredBy  *types.PrincipalInfo
+	ExecutionURL string
+}
+
+func (s *Service) notifyPipelineExecuted(
+	ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload],
+) error {
+	if !event.Payload.Status.IsFailed() {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch repo from repoStore: %w", err)
+	}
+
+	pipeline, err := s.pipelineStore.Find(ctx, event.Payload.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pipeline from pipelineStore: %w", err)
+	}
+
+	execution, err := s.executionStore.FindByNumber(ctx, pipeline.ID, event.Payload.ExecutionNum)
+	if err != nil {
+		return fmt.Errorf("failed to fetch execution from executionStore: %w", err)
+	}
+
+	triggeredBy, err := s.principalInfoCache.Get(ctx, execution.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to fetch principal %d from principalInfoCache: %w", execution.CreatedBy, err)
+	}
+
+	payload := &PipelineFailedPayload{
+		Repo:         repo,
+		Pipeline:     pipeline,
+		Execution:    execution,
+		TriggeredBy:  triggeredBy,
+		ExecutionURL: s.urlProvider.GenerateUIBuildURL(ctx, repo.Path, pipeline.Identifier, execution.Number),
+	}
+
+	if err = s.notificationClient.SendPipelineFailed(
+		ctx,
+		[]*types.PrincipalInfo{triggeredBy},
+		payload,
+	); err != nil {
+		return fmt.Errorf(
+			"failed to send notification for event %s for pipelineID %d: %w",
+			pipelineevents.ExecutedEvent,
+			pipeline.ID,
+			err,
+		)
+	}
+
+	return nil
+}
@@ -23,6 +23,10 @@ import (
 	"github.com/harness/gitness/types"
 )
 
+type PullReqCreatedPayload struct {
+	Base *BasePullReqPayload
+}
+
 func (s *Service) notifyPullReqCreated(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload],
@@ -32,6 +36,19 @@ func (s *Service) notifyPullReqCreated(
 		return fmt.Errorf("failed to get base payload: %w", err)
 	}
 
+	if err := s.notificationClient.SendPullReqCreated(
+		ctx,
+		[]*types.PrincipalInfo{base.Author},
+		&PullReqCreatedPayload{Base: base},
+	); err != nil {
+		return fmt.Errorf(
+			"failed to send notification for event %s for pullReqID %d: %w",
+			pullreqevents.CreatedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
 	reviewers, err := s.principalInfoCache.Map(ctx, event.Payload.ReviewerIDs)
 	if err != nil {
 		return fmt.Errorf("failed to get principal infos from cache: %w", err)
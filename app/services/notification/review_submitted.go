@@ -29,6 +29,8 @@ type ReviewSubmittedPayload struct {
 	Author   *types.PrincipalInfo
 	Reviewer *types.PrincipalInfo
 	Decision enum.PullReqReviewDecision
+	// CommentCount is the number of comments published with the review.
+	CommentCount int
 }
 
 func (s *Service) notifyReviewSubmitted(
@@ -92,9 +94,10 @@ func (s *Service) processReviewSubmittedEvent(
 	}
 
 	return &ReviewSubmittedPayload{
-		Base:     base,
-		Author:   authorPrincipal,
-		Decision: event.Payload.Decision,
-		Reviewer: reviewerPrincipal,
+		Base:         base,
+		Author:       authorPrincipal,
+		Decision:     event.Payload.Decision,
+		Reviewer:     reviewerPrincipal,
+		CommentCount: len(event.Payload.CommentIDs),
 	}, []*types.PrincipalInfo{authorPrincipal}, nil
 }
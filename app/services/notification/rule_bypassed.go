@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+)
+
+type RuleBypassedPayload struct {
+	Repo         *types.Repository
+	BypassedBy   *types.PrincipalInfo
+	Action       string
+	ResourceType string
+	ResourceName string
+	RepoURL      string
+}
+
+func (s *Service) notifyRuleBypassed(
+	ctx context.Context,
+	event *events.Event[*repoevents.RuleBypassedPayload],
+) error {
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch repo from repoStore: %w", err)
+	}
+
+	bypassedBy, err := s.principalInfoCache.Get(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch principal %d from principalInfoCache: %w",
+			event.Payload.PrincipalID, err)
+	}
+
+	payload := &RuleBypassedPayload{
+		Repo:         repo,
+		BypassedBy:   bypassedBy,
+		Action:       event.Payload.Action,
+		ResourceType: event.Payload.ResourceType,
+		ResourceName: event.Payload.ResourceName,
+		RepoURL:      s.urlProvider.GenerateUIRepoURL(ctx, repo.Path),
+	}
+
+	if err = s.notificationClient.SendRuleBypassed(ctx, nil, payload); err != nil {
+		return fmt.Errorf(
+			"failed to send notification for event %s for repoID %d: %w",
+			repoevents.RuleBypassedEvent,
+			repo.ID,
+			err,
+		)
+	}
+
+	return nil
+}
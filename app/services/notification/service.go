@@ -22,7 +22,9 @@ import (
 	"io/fs"
 	"path"
 
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
@@ -68,7 +70,7 @@ func LoadTemplates() error {
 
 		htmlTemplates[tmpl.Name()] = pt
 	}
-	return nil
+	return loadChatTemplates()
 }
 
 type BasePullReqPayload struct {
@@ -82,12 +84,18 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// AllowLoopback and AllowPrivateNetwork control the addresses chat channels can post to.
+	AllowLoopback       bool
+	AllowPrivateNetwork bool
 }
 
 type Service struct {
 	config                Config
 	notificationClient    Client
 	prReaderFactory       *events.ReaderFactory[*pullreqevents.Reader]
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader]
+	repoReaderFactory     *events.ReaderFactory[*repoevents.Reader]
 	pullReqStore          store.PullReqStore
 	repoStore             store.RepoStore
 	principalInfoView     store.PrincipalInfoView
@@ -95,6 +103,8 @@ type Service struct {
 	pullReqReviewersStore store.PullReqReviewerStore
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
+	pipelineStore         store.PipelineStore
+	executionStore        store.ExecutionStore
 	urlProvider           url.Provider
 }
 
@@ -103,6 +113,8 @@ func NewService(
 	config Config,
 	notificationClient Client,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -110,12 +122,16 @@ func NewService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
 		notificationClient:    notificationClient,
 		prReaderFactory:       prReaderFactory,
+		pipelineReaderFactory: pipelineReaderFactory,
+		repoReaderFactory:     repoReaderFactory,
 		pullReqStore:          pullReqStore,
 		repoStore:             repoStore,
 		principalInfoView:     principalInfoView,
@@ -123,6 +139,8 @@ func NewService(
 		pullReqReviewersStore: pullReqReviewersStore,
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
+		pipelineStore:         pipelineStore,
+		executionStore:        executionStore,
 		urlProvider:           urlProvider,
 	}
 
@@ -155,6 +173,42 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
 	}
 
+	_, err = service.pipelineReaderFactory.Launch(
+		ctx,
+		eventReaderGroupName,
+		config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterExecuted(service.notifyPipelineExecuted)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader for %s: %w", eventReaderGroupName, err)
+	}
+
+	_, err = service.repoReaderFactory.Launch(
+		ctx,
+		eventReaderGroupName,
+		config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterRuleBypassed(service.notifyRuleBypassed)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for %s: %w", eventReaderGroupName, err)
+	}
+
 	return service, nil
 }
 
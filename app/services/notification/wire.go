@@ -17,8 +17,12 @@ package notification
 import (
 	"context"
 
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
@@ -28,6 +32,9 @@ import (
 
 var WireSet = wire.NewSet(
 	ProvideMailClient,
+	ProvideInboxClient,
+	ProvideChatClient,
+	ProvideNotificationClient,
 	ProvideNotificationService,
 )
 
@@ -36,6 +43,8 @@ func ProvideNotificationService(
 	notificationClient Client,
 	pullReqConfig Config,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -43,6 +52,8 @@ func ProvideNotificationService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	return NewService(
@@ -50,6 +61,8 @@ func ProvideNotificationService(
 		pullReqConfig,
 		notificationClient,
 		prReaderFactory,
+		pipelineReaderFactory,
+		repoReaderFactory,
 		pullReqStore,
 		repoStore,
 		principalInfoView,
@@ -57,10 +70,39 @@ func ProvideNotificationService(
 		pullReqReviewersStore,
 		pullReqActivityStore,
 		spacePathStore,
+		pipelineStore,
+		executionStore,
 		urlProvider,
 	)
 }
 
-func ProvideMailClient(mailer mailer.Mailer) Client {
+func ProvideMailClient(mailer mailer.Mailer) MailClient {
 	return NewMailClient(mailer)
 }
+
+func ProvideInboxClient(
+	notificationStore store.NotificationStore,
+	subscriptionStore store.NotificationSubscriptionStore,
+	spaceStore store.SpaceStore,
+	sseStreamer sse.Streamer,
+) *InboxClient {
+	return NewInboxClient(notificationStore, subscriptionStore, spaceStore, sseStreamer)
+}
+
+func ProvideChatClient(
+	config Config,
+	channelStore store.NotificationChannelStore,
+	spaceStore store.SpaceStore,
+) *ChatClient {
+	return NewChatClient(
+		channelStore,
+		spaceStore,
+		webhook.NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
+	)
+}
+
+// ProvideNotificationClient provides the notification client that sends the notifications
+// by email, to the in-app inbox and to the chat channels.
+func ProvideNotificationClient(mailClient MailClient, inboxClient *InboxClient, chatClient *ChatClient) Client {
+	return NewMultiClient(mailClient, inboxClient, chatClient)
+}
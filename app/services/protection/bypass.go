@@ -50,6 +50,30 @@ func (v DefBypass) matches(
 			slices.Contains(v.UserIDs, actor.ID))
 }
 
+// matchesAnyUser returns true if any of the provided users is allowed to bypass the rule.
+func (v DefBypass) matchesAnyUser(
+	ctx context.Context,
+	userIDs []int64,
+	userGroupResolverFn func(context.Context, []int64) ([]int64, error),
+) bool {
+	if userGroupResolverFn != nil {
+		groupUserIDs, err := userGroupResolverFn(ctx, v.UserGroupIDs)
+		if err != nil {
+			return false
+		}
+
+		v.UserIDs = append(v.UserIDs, groupUserIDs...)
+	}
+
+	for _, userID := range userIDs {
+		if slices.Contains(v.UserIDs, userID) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (v DefBypass) Sanitize() error {
 	if err := validateIDSlice(v.UserIDs); err != nil {
 		return fmt.Errorf("user IDs error: %w", err)
@@ -73,7 +73,7 @@ func TestBranch_MergeVerify(t *testing.T) {
 					Bypassed:   false,
 					Violations: []types.Violation{
 						{Code: codePullReqCommentsReqResolveAll},
-						{Code: codePullReqStatusChecksReqIdentifiers},
+						{Code: codePullReqStatusChecksMissing},
 					},
 				},
 			},
@@ -104,7 +104,7 @@ func TestBranch_MergeVerify(t *testing.T) {
 					Bypassed:   true,
 					Violations: []types.Violation{
 						{Code: codePullReqCommentsReqResolveAll},
-						{Code: codePullReqStatusChecksReqIdentifiers},
+						{Code: codePullReqStatusChecksMissing},
 					},
 				},
 			},
@@ -134,7 +134,7 @@ func TestBranch_MergeVerify(t *testing.T) {
 					Bypassed:   false,
 					Violations: []types.Violation{
 						{Code: codePullReqCommentsReqResolveAll},
-						{Code: codePullReqStatusChecksReqIdentifiers},
+						{Code: codePullReqStatusChecksMissing},
 					},
 				},
 			},
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const TypeFilePath enum.RuleType = "file_path"
+
+// FilePath implements protection rules for the rule type TypeFilePath.
+// It blocks pushes and pull request merges that modify protected files of the branches matched by the rule.
+type FilePath struct {
+	Bypass   DefBypass   `json:"bypass"`
+	FilePath DefFilePath `json:"file_path"`
+}
+
+var (
+	// ensures that the FilePath type implements Definition interface.
+	_ Definition       = (*FilePath)(nil)
+	_ BranchProtection = (*FilePath)(nil)
+	_ PushProtection   = (*FilePath)(nil)
+)
+
+// MergeVerify verifies that the pull request doesn't modify any protected files.
+// Changes of protected files are allowed if either the author of the pull request
+// or any reviewer that approved the latest commit is allowed to bypass the rule.
+func (v *FilePath) MergeVerify(
+	ctx context.Context,
+	in MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	out := MergeVerifyOutput{
+		AllowedMethods: slices.Clone(enum.MergeMethods),
+	}
+
+	violations := v.FilePath.mergeViolations(in.ChangedFiles)
+	if len(violations.Violations) == 0 {
+		return out, nil, nil
+	}
+
+	userIDs := []int64{in.PullReq.Author.ID}
+	for _, reviewer := range in.Reviewers {
+		if reviewer.ReviewDecision == enum.PullReqReviewDecisionApproved && reviewer.SHA == in.PullReq.SourceSHA {
+			userIDs = append(userIDs, reviewer.Reviewer.ID)
+		}
+	}
+
+	if v.Bypass.matchesAnyUser(ctx, userIDs, in.ResolveUserGroupID) {
+		return out, nil, nil
+	}
+
+	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
+	violations.Bypassable = bypassable
+	violations.Bypassed = in.AllowBypass && bypassable
+
+	return out, []types.RuleViolations{violations}, nil
+}
+
+func (v *FilePath) RequiredChecks(
+	context.Context,
+	RequiredChecksInput,
+) (RequiredChecksOutput, error) {
+	return RequiredChecksOutput{}, nil
+}
+
+func (v *FilePath) CreatePullReqVerify(
+	context.Context,
+	CreatePullReqVerifyInput,
+) (CreatePullReqVerifyOutput, []types.RuleViolations, error) {
+	return CreatePullReqVerifyOutput{}, nil, nil
+}
+
+func (v *FilePath) RefChangeVerify(
+	context.Context,
+	RefChangeVerifyInput,
+) ([]types.RuleViolations, error) {
+	return []types.RuleViolations{}, nil
+}
+
+func (v *FilePath) PushVerify(
+	context.Context,
+	PushVerifyInput,
+) (PushVerifyOutput, []types.RuleViolations, error) {
+	return PushVerifyOutput{
+		FilePathProtection: true,
+	}, nil, nil
+}
+
+func (v *FilePath) Violations(
+	ctx context.Context,
+	in *PushViolationsInput,
+) (PushViolationsOutput, error) {
+	violations := v.FilePath.pushViolations(in.ChangedFiles)
+
+	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
+	violations.Bypassable = bypassable
+	violations.Bypassed = bypassable
+
+	return PushViolationsOutput{
+		Violations: []types.RuleViolations{violations},
+	}, nil
+}
+
+func (v *FilePath) UserIDs() ([]int64, error) {
+	return v.Bypass.UserIDs, nil
+}
+
+func (v *FilePath) UserGroupIDs() ([]int64, error) {
+	return v.Bypass.UserGroupIDs, nil
+}
+
+func (v *FilePath) Sanitize() error {
+	if err := v.Bypass.Sanitize(); err != nil {
+		return fmt.Errorf("bypass: %w", err)
+	}
+
+	if err := v.FilePath.Sanitize(); err != nil {
+		return fmt.Errorf("file path: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestFilePath_MergeVerify(t *testing.T) {
+	author := types.PrincipalInfo{ID: 1}
+	owner := types.PrincipalInfo{ID: 2}
+	actor := &types.Principal{ID: 3}
+
+	rule := FilePath{
+		Bypass: DefBypass{UserIDs: []int64{owner.ID}},
+		FilePath: DefFilePath{
+			Include: []string{"deploy/**", "*.tf"},
+			Exclude: []string{"deploy/README.md"},
+		},
+	}
+
+	pr := &types.PullReq{Author: author, SourceSHA: "abc"}
+
+	tests := []struct {
+		name  string
+		rule  FilePath
+		in    MergeVerifyInput
+		expVs []types.RuleViolations
+	}{
+		{
+			name: "no-protected-files",
+			rule: rule,
+			in: MergeVerifyInput{
+				Actor:        actor,
+				PullReq:      pr,
+				ChangedFiles: []string{"main.go", "deploy/README.md", "infra/main.tf"},
+			},
+		},
+		{
+			name: "protected-files",
+			rule: rule,
+			in: MergeVerifyInput{
+				Actor:        actor,
+				PullReq:      pr,
+				ChangedFiles: []string{"main.go", "deploy/prod/values.yaml", "main.tf"},
+			},
+			expVs: []types.RuleViolations{
+				{Violations: []types.Violation{{Code: codeFilePathMergeProtected}}},
+			},
+		},
+		{
+			name: "approved-by-owner",
+			rule: rule,
+			in: MergeVerifyInput{
+				Actor:   actor,
+				PullReq: pr,
+				Reviewers: []*types.PullReqReviewer{
+					{Reviewer: owner, ReviewDecision: enum.PullReqReviewDecisionApproved, SHA: "abc"},
+				},
+				ChangedFiles: []string{"main.tf"},
+			},
+		},
+		{
+			name: "approved-by-owner-old-commit",
+			rule: rule,
+			in: MergeVerifyInput{
+				Actor:   actor,
+				PullReq: pr,
+				Reviewers: []*types.PullReqReviewer{
+					{Reviewer: owner, ReviewDecision: enum.PullReqReviewDecisionApproved, SHA: "old"},
+				},
+				ChangedFiles: []string{"main.tf"},
+			},
+			expVs: []types.RuleViolations{
+				{Violations: []types.Violation{{Code: codeFilePathMergeProtected}}},
+			},
+		},
+		{
+			name: "authored-by-owner",
+			rule: rule,
+			in: MergeVerifyInput{
+				Actor:        actor,
+				PullReq:      &types.PullReq{Author: owner, SourceSHA: "abc"},
+				ChangedFiles: []string{"main.tf"},
+			},
+		},
+		{
+			name: "actor-bypass",
+			rule: FilePath{
+				Bypass:   DefBypass{UserIDs: []int64{actor.ID}},
+				FilePath: rule.FilePath,
+			},
+			in: MergeVerifyInput{
+				Actor:        actor,
+				AllowBypass:  true,
+				PullReq:      pr,
+				ChangedFiles: []string{"main.tf"},
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{{Code: codeFilePathMergeProtected}},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, violations, err := test.rule.MergeVerify(ctx, test.in)
+			if err != nil {
+				t.Fatalf("got error: %s", err.Error())
+			}
+
+			if want, got := enum.MergeMethods, out.AllowedMethods; !reflect.DeepEqual(want, got) {
+				t.Errorf("allowed methods mismatch: want=%v got=%v", want, got)
+			}
+
+			inspectRuleViolations(t, test.expVs, violations)
+		})
+	}
+}
+
+func TestFilePath_PushViolations(t *testing.T) {
+	user := &types.Principal{ID: 42}
+
+	rule := FilePath{
+		FilePath: DefFilePath{Include: []string{"deploy/**"}},
+	}
+
+	tests := []struct {
+		name         string
+		rule         FilePath
+		changedFiles map[string][]string
+		expVs        []types.RuleViolations
+	}{
+		{
+			name:         "no-changes",
+			rule:         rule,
+			changedFiles: nil,
+			expVs:        []types.RuleViolations{{}},
+		},
+		{
+			name: "protected-files",
+			rule: rule,
+			changedFiles: map[string][]string{
+				"main":    {"deploy/app.yaml"},
+				"feature": {"README.md"},
+			},
+			expVs: []types.RuleViolations{
+				{Violations: []types.Violation{{Code: codeFilePathPushProtected}}},
+			},
+		},
+		{
+			name: "bypass",
+			rule: FilePath{
+				Bypass:   DefBypass{UserIDs: []int64{user.ID}},
+				FilePath: rule.FilePath,
+			},
+			changedFiles: map[string][]string{"main": {"deploy/app.yaml"}},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{{Code: codeFilePathPushProtected}},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := test.rule.Violations(ctx, &PushViolationsInput{
+				Actor:        user,
+				ChangedFiles: test.changedFiles,
+			})
+			if err != nil {
+				t.Fatalf("got error: %s", err.Error())
+			}
+
+			inspectRuleViolations(t, test.expVs, out.Violations)
+		})
+	}
+}
+
+func TestFilePath_Sanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   FilePath
+		expErr bool
+	}{
+		{name: "valid", rule: FilePath{FilePath: DefFilePath{Include: []string{"deploy/**"}}}},
+		{name: "no-include", rule: FilePath{FilePath: DefFilePath{Exclude: []string{"deploy/**"}}}, expErr: true},
+		{name: "invalid-pattern", rule: FilePath{FilePath: DefFilePath{Include: []string{"[a"}}}, expErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.rule.Sanitize(); (err != nil) != test.expErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func inspectRuleViolations(t *testing.T, expVs, results []types.RuleViolations) {
+	t.Helper()
+
+	if want, got := len(expVs), len(results); want != got {
+		t.Errorf("number of violations mismatch: want=%d got=%d", want, got)
+		return
+	}
+
+	for i := range results {
+		if want, got := expVs[i].Bypassable, results[i].Bypassable; want != got {
+			t.Errorf("rule result %d, bypassable mismatch: want=%t got=%t", i, want, got)
+		}
+
+		if want, got := expVs[i].Bypassed, results[i].Bypassed; want != got {
+			t.Errorf("rule result %d, bypassed mismatch: want=%t got=%t", i, want, got)
+		}
+
+		if want, got := len(expVs[i].Violations), len(results[i].Violations); want != got {
+			t.Errorf("rule result %d, violations count mismatch: want=%d got=%d", i, want, got)
+			continue
+		}
+
+		for j := range results[i].Violations {
+			if want, got := expVs[i].Violations[j].Code, results[i].Violations[j].Code; want != got {
+				t.Errorf("rule result %d, violation %d, code mismatch: want=%s got=%s", i, j, want, got)
+			}
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -87,6 +88,38 @@ func (p *Push) Violations(
 		)
 	}
 
+	if p.Push.RequireSignedCommits {
+		unverified := make(map[sha.SHA]struct{})
+		for _, commitSHAs := range in.UnverifiedCommits {
+			for _, commitSHA := range commitSHAs {
+				unverified[commitSHA] = struct{}{}
+			}
+		}
+
+		if len(unverified) > 0 {
+			violations.Addf(codePushRequireSignedCommits,
+				"Found total of %d commit(s) without a verified signature.",
+				len(unverified),
+			)
+		}
+	}
+
+	if p.Push.RequireLinearHistory {
+		merges := make(map[sha.SHA]struct{})
+		for _, commitSHAs := range in.MergeCommits {
+			for _, commitSHA := range commitSHAs {
+				merges[commitSHA] = struct{}{}
+			}
+		}
+
+		if len(merges) > 0 {
+			violations.Addf(codePushRequireLinearHistory,
+				"Linear history is required. Found total of %d merge commit(s).",
+				len(merges),
+			)
+		}
+	}
+
 	bypassable := p.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
 	violations.Bypassable = bypassable
 	violations.Bypassed = bypassable
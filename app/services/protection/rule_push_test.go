@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+func TestPush_Violations(t *testing.T) {
+	user := &types.Principal{ID: 42}
+
+	commitA := sha.Must("aaaa")
+	commitB := sha.Must("bbbb")
+
+	tests := []struct {
+		name       string
+		rule       Push
+		unverified map[string][]sha.SHA
+		merges     map[string][]sha.SHA
+		expVs      []types.RuleViolations
+	}{
+		{
+			name:       "signed-commits-not-required",
+			rule:       Push{},
+			unverified: map[string][]sha.SHA{"main": {commitA}},
+			expVs:      []types.RuleViolations{{}},
+		},
+		{
+			name:       "signed-commits-all-verified",
+			rule:       Push{Push: DefPush{RequireSignedCommits: true}},
+			unverified: nil,
+			expVs:      []types.RuleViolations{{}},
+		},
+		{
+			name: "signed-commits-unverified",
+			rule: Push{Push: DefPush{RequireSignedCommits: true}},
+			unverified: map[string][]sha.SHA{
+				"main":    {commitA},
+				"feature": {commitA, commitB},
+			},
+			expVs: []types.RuleViolations{
+				{Violations: []types.Violation{{Code: codePushRequireSignedCommits}}},
+			},
+		},
+		{
+			name: "signed-commits-bypass",
+			rule: Push{
+				Bypass: DefBypass{UserIDs: []int64{user.ID}},
+				Push:   DefPush{RequireSignedCommits: true},
+			},
+			unverified: map[string][]sha.SHA{"main": {commitA}},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{{Code: codePushRequireSignedCommits}},
+				},
+			},
+		},
+		{
+			name:   "linear-history-not-required",
+			rule:   Push{},
+			merges: map[string][]sha.SHA{"main": {commitA}},
+			expVs:  []types.RuleViolations{{}},
+		},
+		{
+			name: "linear-history-merge-commits",
+			rule: Push{Push: DefPush{RequireLinearHistory: true}},
+			merges: map[string][]sha.SHA{
+				"main":    {commitA},
+				"feature": {commitA, commitB},
+			},
+			expVs: []types.RuleViolations{
+				{Violations: []types.Violation{{Code: codePushRequireLinearHistory}}},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := test.rule.Violations(ctx, &PushViolationsInput{
+				Actor:             user,
+				UnverifiedCommits: test.unverified,
+				MergeCommits:      test.merges,
+			})
+			if err != nil {
+				t.Fatalf("got error: %s", err.Error())
+			}
+
+			inspectRuleViolations(t, test.expVs, out.Violations)
+		})
+	}
+}
@@ -98,6 +98,33 @@ func IsBypassed(violations []types.RuleViolations) bool {
 	return false
 }
 
+// WithoutStatusCheckViolations returns the rule violations without the violations of the required status checks.
+// Used by the merge queue, which evaluates the status checks later, against the speculative merge commit.
+func WithoutStatusCheckViolations(violations []types.RuleViolations) []types.RuleViolations {
+	result := make([]types.RuleViolations, 0, len(violations))
+	for _, ruleViolations := range violations {
+		filtered := make([]types.Violation, 0, len(ruleViolations.Violations))
+		for _, violation := range ruleViolations.Violations {
+			switch violation.Code {
+			case codePullReqStatusChecksReqIdentifiers,
+				codePullReqStatusChecksPending,
+				codePullReqStatusChecksMissing:
+			default:
+				filtered = append(filtered, violation)
+			}
+		}
+
+		if len(filtered) == 0 {
+			continue
+		}
+
+		ruleViolations.Violations = filtered
+		result = append(result, ruleViolations)
+	}
+
+	return result
+}
+
 // NewManager creates new protection Manager.
 func NewManager(ruleStore store.RuleStore) *Manager {
 	return &Manager{
@@ -179,7 +206,7 @@ func (m *Manager) ListRepoBranchRules(
 	ctx context.Context,
 	repoID int64,
 ) (BranchProtection, error) {
-	ruleInfos, err := m.ListRepoRules(ctx, repoID, TypeBranch)
+	ruleInfos, err := m.ListRepoRules(ctx, repoID, TypeBranch, TypeFilePath)
 	if err != nil {
 		return branchRuleSet{}, err
 	}
@@ -254,7 +281,7 @@ func (m *Manager) FilterCreatePushProtection(rules []types.RuleInfoInternal) Pus
 	var pushRules []types.RuleInfoInternal
 
 	for _, rule := range rules {
-		if rule.Type == TypePush {
+		if rule.Type == TypePush || rule.Type == TypeFilePath {
 			pushRules = append(pushRules, rule)
 		}
 	}
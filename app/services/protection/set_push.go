@@ -70,6 +70,12 @@ func (s pushRuleSet) PushVerify(
 		out.PrincipalCommitterMatch = out.PrincipalCommitterMatch || rOut.PrincipalCommitterMatch
 
 		out.SecretScanningEnabled = out.SecretScanningEnabled || rOut.SecretScanningEnabled
+
+		out.FilePathProtection = out.FilePathProtection || rOut.FilePathProtection
+
+		out.RequireSignedCommits = out.RequireSignedCommits || rOut.RequireSignedCommits
+
+		out.RequireLinearHistory = out.RequireLinearHistory || rOut.RequireLinearHistory
 	}
 
 	return out, violations, nil
@@ -79,7 +85,21 @@ func (s pushRuleSet) Violations(ctx context.Context, in *PushViolationsInput) (P
 	output := PushViolationsOutput{}
 
 	for _, r := range s.rules {
-		out, err := in.Protections[r.ID].Violations(ctx, in)
+		ruleIn := *in
+
+		// only the changes pushed to the branches matching the rule pattern are verified by the rule.
+		var err error
+		if ruleIn.ChangedFiles, err = filterByRuleRef(r, in.DefaultBranch, in.ChangedFiles); err != nil {
+			return PushViolationsOutput{}, err
+		}
+		if ruleIn.UnverifiedCommits, err = filterByRuleRef(r, in.DefaultBranch, in.UnverifiedCommits); err != nil {
+			return PushViolationsOutput{}, err
+		}
+		if ruleIn.MergeCommits, err = filterByRuleRef(r, in.DefaultBranch, in.MergeCommits); err != nil {
+			return PushViolationsOutput{}, err
+		}
+
+		out, err := in.Protections[r.ID].Violations(ctx, &ruleIn)
 		if err != nil {
 			return PushViolationsOutput{}, fmt.Errorf(
 				"failed to backfill violations: %w", err,
@@ -92,6 +112,30 @@ func (s pushRuleSet) Violations(ctx context.Context, in *PushViolationsInput) (P
 	return output, nil
 }
 
+// filterByRuleRef returns the entries of the map whose branch matches the rule pattern.
+func filterByRuleRef[T any](
+	r types.RuleInfoInternal,
+	defaultBranch string,
+	byBranch map[string]T,
+) (map[string]T, error) {
+	if len(byBranch) == 0 {
+		return byBranch, nil
+	}
+
+	filtered := make(map[string]T, len(byBranch))
+	for branch, v := range byBranch {
+		matched, err := matchesRef(r.Pattern, defaultBranch, branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match rule pattern: %w", err)
+		}
+		if matched {
+			filtered[branch] = v
+		}
+	}
+
+	return filtered, nil
+}
+
 func (s pushRuleSet) UserIDs() ([]int64, error) {
 	return collectIDs(s.manager, s.rules, Protection.UserIDs)
 }
@@ -32,6 +32,20 @@ func validateIDSlice(ids []int64) error {
 	return nil
 }
 
+func validatePatternSlice(patterns []string) error {
+	if len(patterns) > maxElements {
+		return errors.New("too many patterns provided")
+	}
+
+	for _, pattern := range patterns {
+		if err := patternValidate(pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func validateIdentifierSlice(identifiers []string) error {
 	if len(identifiers) > maxElements {
 		return errors.New("too many Identifiers provided")
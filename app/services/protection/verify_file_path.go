@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	codeFilePathPushProtected  = "file_path.push.protected"
+	codeFilePathMergeProtected = "file_path.merge.protected"
+)
+
+// DefFilePath defines the protected file paths. The include and exclude patterns
+// use the same globstar syntax as the branch name patterns, e.g. "deploy/**" or "**/*.tf".
+type DefFilePath struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Ensures that the DefFilePath type implements Sanitizer interface.
+var _ Sanitizer = (*DefFilePath)(nil)
+
+func (v *DefFilePath) pattern() *Pattern {
+	return &Pattern{
+		Include: v.Include,
+		Exclude: v.Exclude,
+	}
+}
+
+// protectedFiles returns the sorted list of the provided files that are protected.
+func (v *DefFilePath) protectedFiles(files []string) []string {
+	if len(v.Include) == 0 {
+		return nil
+	}
+
+	pattern := v.pattern()
+
+	var protected []string
+	for _, file := range files {
+		if pattern.Matches(file, "") {
+			protected = append(protected, file)
+		}
+	}
+
+	sort.Strings(protected)
+
+	return protected
+}
+
+func (v *DefFilePath) pushViolations(changedFiles map[string][]string) types.RuleViolations {
+	var violations types.RuleViolations
+
+	branches := make([]string, 0, len(changedFiles))
+	for branch := range changedFiles {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+
+	for _, branch := range branches {
+		protected := v.protectedFiles(changedFiles[branch])
+		if len(protected) == 0 {
+			continue
+		}
+
+		violations.Addf(codeFilePathPushProtected,
+			"Push to branch %q modifies %d protected file(s), including %q.",
+			branch, len(protected), protected[0],
+		)
+	}
+
+	return violations
+}
+
+func (v *DefFilePath) mergeViolations(changedFiles []string) types.RuleViolations {
+	var violations types.RuleViolations
+
+	protected := v.protectedFiles(changedFiles)
+	if len(protected) == 0 {
+		return violations
+	}
+
+	violations.Addf(codeFilePathMergeProtected,
+		"Pull request modifies %d protected file(s), including %q. "+
+			"Either the author or an approver of the latest commit must be allowed to bypass the rule.",
+		len(protected), protected[0],
+	)
+
+	return violations
+}
+
+func (v *DefFilePath) Sanitize() error {
+	if len(v.Include) == 0 {
+		return errors.New("at least one protected path pattern must be provided")
+	}
+
+	if len(v.Include) > maxElements || len(v.Exclude) > maxElements {
+		return errors.New("too many path patterns provided")
+	}
+
+	if err := v.pattern().Validate(); err != nil {
+		return fmt.Errorf("invalid path pattern: %w", err)
+	}
+
+	return nil
+}
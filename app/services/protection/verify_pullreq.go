@@ -22,6 +22,8 @@ import (
 	"strings"
 
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -46,6 +48,20 @@ type (
 		Method             enum.MergeMethod
 		CheckResults       []types.CheckResult
 		CodeOwners         *codeowners.Evaluation
+		ChangedFiles       []string
+
+		// UnverifiedCommits returns SHAs of the pull request commits that aren't signed
+		// or whose signature doesn't verify against the committer's registered keys.
+		// It's called only if a rule requires signed commits.
+		UnverifiedCommits func(ctx context.Context) ([]sha.SHA, error)
+
+		// SourceUpToDate reports whether the target branch head is an ancestor of the source branch head.
+		// It's called only if a rule requires the source branch to be up to date.
+		SourceUpToDate func(ctx context.Context) (bool, error)
+
+		// SourceHasMergeCommits reports whether the pull request commits contain merge commits.
+		// It's called only if a rule requires linear history and fast-forward merge is otherwise allowed.
+		SourceHasMergeCommits func(ctx context.Context) (bool, error)
 	}
 
 	MergeVerifyOutput struct {
@@ -66,8 +82,12 @@ type (
 		IsRepoOwner        bool
 		Repo               *types.RepositoryCore
 		PullReq            *types.PullReq
+		CheckResults       []types.CheckResult
+		ChangedFiles       []string
 	}
 
+	// RequiredChecksOutput holds identifiers of the required status checks. The identifiers of the required
+	// status checks that haven't been reported are included too, and for those the identifier can be a glob pattern.
 	RequiredChecksOutput struct {
 		RequiredIdentifiers   map[string]struct{}
 		BypassableIdentifiers map[string]struct{}
@@ -122,14 +142,19 @@ const (
 	codePullReqMergeStrategiesAllowed = "pullreq.merge.strategies_allowed"
 	codePullReqMergeDeleteBranch      = "pullreq.merge.delete_branch"
 	codePullReqMergeBlock             = "pullreq.merge.blocked"
+	codePullReqMergeSignedCommits     = "pullreq.merge.require_signed_commits"
+	codePullReqMergeLinearHistory     = "pullreq.merge.require_linear_history"
+	codePullReqMergeUpToDate          = "pullreq.merge.require_up_to_date"
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
+	codePullReqStatusChecksPending        = "pullreq.status_checks.pending"
+	codePullReqStatusChecksMissing        = "pullreq.status_checks.missing"
 )
 
 //nolint:gocognit,gocyclo,cyclop // well aware of this
 func (v *DefPullReq) MergeVerify(
-	_ context.Context,
+	ctx context.Context,
 	in MergeVerifyInput,
 ) (MergeVerifyOutput, []types.RuleViolations, error) {
 	var out MergeVerifyOutput
@@ -278,26 +303,29 @@ func (v *DefPullReq) MergeVerify(
 
 	// pullreq.status_checks
 
-	var violatingStatusCheckIdentifiers []string
-	for _, requiredIdentifier := range v.StatusChecks.RequireIdentifiers {
-		var succeeded bool
-		for i := range in.CheckResults {
-			if in.CheckResults[i].Identifier == requiredIdentifier {
-				succeeded = in.CheckResults[i].Status.IsSuccess()
-				break
-			}
-		}
+	failedChecks, pendingChecks, missingChecks := v.StatusChecks.evaluate(in.CheckResults, in.ChangedFiles)
 
-		if !succeeded {
-			violatingStatusCheckIdentifiers = append(violatingStatusCheckIdentifiers, requiredIdentifier)
-		}
-	}
-
-	if len(violatingStatusCheckIdentifiers) > 0 {
+	if len(failedChecks) > 0 {
 		violations.Addf(
 			codePullReqStatusChecksReqIdentifiers,
 			"The following status checks are required to be completed successfully: %s",
-			strings.Join(violatingStatusCheckIdentifiers, ", "),
+			strings.Join(failedChecks, ", "),
+		)
+	}
+
+	if len(pendingChecks) > 0 {
+		violations.Addf(
+			codePullReqStatusChecksPending,
+			"The following required status checks are not completed yet: %s",
+			strings.Join(pendingChecks, ", "),
+		)
+	}
+
+	if len(missingChecks) > 0 {
+		violations.Addf(
+			codePullReqStatusChecksMissing,
+			"The following required status checks haven't been reported: %s",
+			strings.Join(missingChecks, ", "),
 		)
 	}
 
@@ -320,12 +348,67 @@ func (v *DefPullReq) MergeVerify(
 		}
 	}
 
+	if v.Merge.RequireLinearHistory {
+		// fast-forward merge would put the merge commits of the source branch on the target branch.
+		var hasMergeCommits bool
+		if slices.Contains(out.AllowedMethods, enum.MergeMethodFastForward) && in.SourceHasMergeCommits != nil {
+			var err error
+			hasMergeCommits, err = in.SourceHasMergeCommits(ctx)
+			if err != nil {
+				return out, nil, fmt.Errorf("failed to check for merge commits: %w", err)
+			}
+		}
+
+		out.AllowedMethods = slices.DeleteFunc(slices.Clone(out.AllowedMethods), func(m enum.MergeMethod) bool {
+			return m == enum.MergeMethodMerge || hasMergeCommits && m == enum.MergeMethodFastForward
+		})
+
+		switch {
+		case in.Method == enum.MergeMethodMerge:
+			violations.Addf(codePullReqMergeLinearHistory,
+				"Linear history is required for the branch %s. Merge commits are not allowed.",
+				in.PullReq.TargetBranch)
+		case in.Method == enum.MergeMethodFastForward && hasMergeCommits:
+			violations.Addf(codePullReqMergeLinearHistory,
+				"Linear history is required for the branch %s. "+
+					"Fast-forward is not allowed because the source branch contains merge commits.",
+				in.PullReq.TargetBranch)
+		}
+	}
+
 	if v.Merge.Block {
 		violations.Addf(
 			codePullReqMergeBlock,
 			"The merge for the branch %s is not allowed.", in.PullReq.TargetBranch)
 	}
 
+	if v.Merge.RequireSignedCommits && in.UnverifiedCommits != nil {
+		unverified, err := in.UnverifiedCommits(ctx)
+		if err != nil {
+			return out, nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+		}
+
+		if len(unverified) > 0 {
+			violations.Addf(
+				codePullReqMergeSignedCommits,
+				"All commits must have a verified signature. Found %d commit(s) without a verified signature.",
+				len(unverified))
+		}
+	}
+
+	if v.Merge.RequireUpToDate && in.SourceUpToDate != nil {
+		upToDate, err := in.SourceUpToDate(ctx)
+		if err != nil {
+			return out, nil, fmt.Errorf("failed to check if the source branch is up to date: %w", err)
+		}
+
+		if !upToDate {
+			violations.Addf(codePullReqMergeUpToDate,
+				"The source branch %s must be up to date with the target branch %s.",
+				in.PullReq.SourceBranch, in.PullReq.TargetBranch)
+		}
+	}
+
 	if len(violations.Violations) > 0 {
 		return out, []types.RuleViolations{violations}, nil
 	}
@@ -333,16 +416,76 @@ func (v *DefPullReq) MergeVerify(
 	return out, nil, nil
 }
 
+// SourceHasMergeCommits returns a function usable as MergeVerifyInput.SourceHasMergeCommits. The function
+// reports whether any of the commits between the merge base and the source SHA has more than one parent.
+func SourceHasMergeCommits(
+	gitService git.Interface,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+) func(ctx context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		out, err := gitService.ListCommits(ctx, &git.ListCommitsParams{
+			ReadParams: git.CreateReadParams(targetRepo),
+			GitREF:     pr.SourceSHA,
+			After:      pr.MergeBaseSHA,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to list pull request commits: %w", err)
+		}
+
+		for _, commit := range out.Commits {
+			if len(commit.ParentSHAs) > 1 {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+}
+
+// SourceUpToDate returns a function usable as MergeVerifyInput.SourceUpToDate. The function
+// reports whether the current head of the pull request's target branch is an ancestor of the source SHA.
+func SourceUpToDate(
+	gitService git.Interface,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+) func(ctx context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		sourceSHA, err := sha.New(pr.SourceSHA)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse source SHA: %w", err)
+		}
+
+		readParams := git.CreateReadParams(targetRepo)
+
+		targetBranch, err := gitService.GetBranch(ctx, &git.GetBranchParams{
+			ReadParams: readParams,
+			BranchName: pr.TargetBranch,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to get target branch: %w", err)
+		}
+
+		result, err := gitService.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          readParams,
+			AncestorCommitSHA:   targetBranch.Branch.SHA,
+			DescendantCommitSHA: sourceSHA,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to check ancestor: %w", err)
+		}
+
+		return result.Ancestor, nil
+	}
+}
+
 func (v *DefPullReq) RequiredChecks(
 	_ context.Context,
-	_ RequiredChecksInput,
+	in RequiredChecksInput,
 ) (RequiredChecksOutput, error) {
-	m := make(map[string]struct{}, len(v.StatusChecks.RequireIdentifiers))
-	for _, id := range v.StatusChecks.RequireIdentifiers {
-		m[id] = struct{}{}
-	}
+	required, _ := v.StatusChecks.required(in.CheckResults, in.ChangedFiles)
 	return RequiredChecksOutput{
-		RequiredIdentifiers: m,
+		RequiredIdentifiers: required,
 	}, nil
 }
 
@@ -391,6 +534,25 @@ func (DefComments) Sanitize() error {
 
 type DefStatusChecks struct {
 	RequireIdentifiers []string `json:"require_identifiers,omitempty"`
+
+	// RequirePatterns holds glob patterns of status check identifiers, e.g. "ci/*".
+	// All reported status checks matching any of the patterns are required.
+	RequirePatterns []string `json:"require_patterns,omitempty"`
+
+	// RequireIfChanged holds the status checks that are required
+	// only if the pull request changes a file matching the path patterns.
+	RequireIfChanged []DefStatusChecksIfChanged `json:"require_if_changed,omitempty"`
+
+	// MissingPolicy defines how the required status checks that were never reported are treated.
+	MissingPolicy enum.CheckMissingPolicy `json:"missing_policy,omitempty"`
+}
+
+// DefStatusChecksIfChanged defines path-conditional status check requirements.
+// The paths use the same globstar syntax as the file path protection, e.g. "web/**".
+type DefStatusChecksIfChanged struct {
+	Paths       []string `json:"paths"`
+	Identifiers []string `json:"identifiers,omitempty"`
+	Patterns    []string `json:"patterns,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -433,6 +595,51 @@ func (c *DefStatusChecks) Sanitize() error {
 		return fmt.Errorf("required identifiers error: %w", err)
 	}
 
+	if err := validatePatternSlice(c.RequirePatterns); err != nil {
+		return fmt.Errorf("required patterns error: %w", err)
+	}
+
+	if len(c.RequireIfChanged) > maxElements {
+		return errors.New("too many path-conditional requirements provided")
+	}
+
+	for i := range c.RequireIfChanged {
+		if err := c.RequireIfChanged[i].Sanitize(); err != nil {
+			return fmt.Errorf("path-conditional requirement error: %w", err)
+		}
+	}
+
+	policy, ok := c.MissingPolicy.Sanitize()
+	if !ok {
+		return fmt.Errorf("unrecognized missing status check policy: %s", c.MissingPolicy)
+	}
+
+	c.MissingPolicy = policy
+
+	return nil
+}
+
+func (c *DefStatusChecksIfChanged) Sanitize() error {
+	if len(c.Paths) == 0 {
+		return errors.New("at least one path must be provided")
+	}
+
+	if err := validatePatternSlice(c.Paths); err != nil {
+		return fmt.Errorf("paths error: %w", err)
+	}
+
+	if len(c.Identifiers) == 0 && len(c.Patterns) == 0 {
+		return errors.New("at least one status check identifier or pattern must be provided")
+	}
+
+	if err := validateIdentifierSlice(c.Identifiers); err != nil {
+		return fmt.Errorf("identifiers error: %w", err)
+	}
+
+	if err := validatePatternSlice(c.Patterns); err != nil {
+		return fmt.Errorf("patterns error: %w", err)
+	}
+
 	return nil
 }
 
@@ -440,6 +647,16 @@ type DefMerge struct {
 	StrategiesAllowed []enum.MergeMethod `json:"strategies_allowed,omitempty"`
 	DeleteBranch      bool               `json:"delete_branch,omitempty"`
 	Block             bool               `json:"block,omitempty"`
+
+	// RequireSignedCommits requires all commits of a pull request to have a verified signature.
+	RequireSignedCommits bool `json:"require_signed_commits,omitempty"`
+
+	// RequireLinearHistory disallows the merge strategy, and fast-forward if the source branch contains
+	// merge commits, so no merge commits reach the target branch.
+	RequireLinearHistory bool `json:"require_linear_history,omitempty"`
+
+	// RequireUpToDate requires the source branch to contain the latest commit of the target branch.
+	RequireUpToDate bool `json:"require_up_to_date,omitempty"`
 }
 
 func (v *DefMerge) Sanitize() error {
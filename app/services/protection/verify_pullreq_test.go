@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -547,7 +548,7 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			},
 		},
 		{
-			name: codePullReqStatusChecksReqIdentifiers + "-missing",
+			name: codePullReqStatusChecksMissing,
 			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"check1"}}},
 			in: MergeVerifyInput{
 				CheckResults: []types.CheckResult{
@@ -555,12 +556,104 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				},
 				Method: enum.MergeMethodMerge,
 			},
-			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
+			expCodes:  []string{codePullReqStatusChecksMissing},
+			expParams: [][]any{{"check1"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksMissing + "-skip",
+			def: DefPullReq{StatusChecks: DefStatusChecks{
+				RequireIdentifiers: []string{"check1"},
+				RequirePatterns:    []string{"ci/*"},
+				MissingPolicy:      enum.CheckMissingPolicySkip,
+			}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "check2", Status: enum.CheckStatusSuccess},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksPending,
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"check1"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "check1", Status: enum.CheckStatusRunning},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksPending},
 			expParams: [][]any{{"check1"}},
 			expOut: MergeVerifyOutput{
 				AllowedMethods: enum.MergeMethods,
 			},
 		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-pattern",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequirePatterns: []string{"ci/*"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "ci/build", Status: enum.CheckStatusSuccess},
+					{Identifier: "ci/test", Status: enum.CheckStatusFailure},
+					{Identifier: "lint", Status: enum.CheckStatusFailure},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
+			expParams: [][]any{{"ci/test"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksMissing + "-pattern",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequirePatterns: []string{"ci/*"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "lint", Status: enum.CheckStatusSuccess},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksMissing},
+			expParams: [][]any{{"ci/*"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksMissing + "-path-changed",
+			def: DefPullReq{StatusChecks: DefStatusChecks{RequireIfChanged: []DefStatusChecksIfChanged{
+				{Paths: []string{"web/**"}, Identifiers: []string{"frontend-tests"}},
+			}}},
+			in: MergeVerifyInput{
+				ChangedFiles: []string{"README.md", "web/src/app.ts"},
+				Method:       enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksMissing},
+			expParams: [][]any{{"frontend-tests"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksMissing + "-path-not-changed",
+			def: DefPullReq{StatusChecks: DefStatusChecks{RequireIfChanged: []DefStatusChecksIfChanged{
+				{Paths: []string{"web/**"}, Identifiers: []string{"frontend-tests"}},
+			}}},
+			in: MergeVerifyInput{
+				ChangedFiles: []string{"README.md", "server/main.go"},
+				Method:       enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
 		{
 			name: codePullReqStatusChecksReqIdentifiers + "-success",
 			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"check1"}}},
@@ -715,6 +808,170 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods: enum.MergeMethods,
 			},
 		},
+		{
+			name: codePullReqMergeSignedCommits + "-fail",
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireSignedCommits: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method:  enum.MergeMethodMerge,
+				PullReq: &types.PullReq{},
+				UnverifiedCommits: func(context.Context) ([]sha.SHA, error) {
+					return []sha.SHA{sha.Must("1234")}, nil
+				},
+			},
+			expCodes:  []string{codePullReqMergeSignedCommits},
+			expParams: [][]any{{1}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqMergeSignedCommits + "-success",
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireSignedCommits: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method:  enum.MergeMethodMerge,
+				PullReq: &types.PullReq{},
+				UnverifiedCommits: func(context.Context) ([]sha.SHA, error) {
+					return nil, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		}, {
+			name: codePullReqMergeLinearHistory + "-fail",
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireLinearHistory: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodMerge,
+				PullReq: &types.PullReq{
+					TargetBranch: "abc",
+				},
+			},
+			expCodes:  []string{codePullReqMergeLinearHistory},
+			expParams: [][]any{{"abc"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: []enum.MergeMethod{
+					enum.MergeMethodFastForward,
+					enum.MergeMethodRebase,
+					enum.MergeMethodSquash,
+				},
+			},
+		},
+		{
+			name: codePullReqMergeLinearHistory + "-fast-forward-fail",
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireLinearHistory: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodFastForward,
+				PullReq: &types.PullReq{
+					TargetBranch: "abc",
+				},
+				SourceHasMergeCommits: func(context.Context) (bool, error) {
+					return true, nil
+				},
+			},
+			expCodes:  []string{codePullReqMergeLinearHistory},
+			expParams: [][]any{{"abc"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: []enum.MergeMethod{
+					enum.MergeMethodRebase,
+					enum.MergeMethodSquash,
+				},
+			},
+		},
+		{
+			name: codePullReqMergeLinearHistory + "-fast-forward-success",
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireLinearHistory: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method:  enum.MergeMethodFastForward,
+				PullReq: &types.PullReq{},
+				SourceHasMergeCommits: func(context.Context) (bool, error) {
+					return false, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: []enum.MergeMethod{
+					enum.MergeMethodFastForward,
+					enum.MergeMethodRebase,
+					enum.MergeMethodSquash,
+				},
+			},
+		},
+		{
+			name: codePullReqMergeLinearHistory + "-success",
+			def: DefPullReq{
+				Merge: DefMerge{
+					StrategiesAllowed:    []enum.MergeMethod{enum.MergeMethodMerge, enum.MergeMethodSquash},
+					RequireLinearHistory: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method:  enum.MergeMethodSquash,
+				PullReq: &types.PullReq{},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: []enum.MergeMethod{enum.MergeMethodSquash},
+			},
+		},
+		{
+			name: codePullReqMergeUpToDate + "-fail",
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireUpToDate: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodMerge,
+				PullReq: &types.PullReq{
+					SourceBranch: "feature",
+					TargetBranch: "main",
+				},
+				SourceUpToDate: func(context.Context) (bool, error) {
+					return false, nil
+				},
+			},
+			expCodes:  []string{codePullReqMergeUpToDate},
+			expParams: [][]any{{"feature", "main"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqMergeUpToDate + "-success",
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireUpToDate: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method:  enum.MergeMethodMerge,
+				PullReq: &types.PullReq{},
+				SourceUpToDate: func(context.Context) (bool, error) {
+					return true, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 )
 
@@ -25,6 +26,8 @@ const (
 	codePushFileSizeLimit           = "push.file.size.limit"
 	codePushPrincipalCommitterMatch = "push.principal.committer.match"
 	codeSecretScanningEnabled       = "push.secret.scanning.enabled"
+	codePushRequireSignedCommits    = "push.require_signed_commits"
+	codePushRequireLinearHistory    = "push.require_linear_history"
 )
 
 type (
@@ -46,6 +49,10 @@ type (
 		CommitterMismatchCount  int64
 		SecretScanningEnabled   bool
 		FoundSecretCount        int
+		DefaultBranch           string
+		ChangedFiles            map[string][]string  // branch name -> paths of changed files
+		UnverifiedCommits       map[string][]sha.SHA // branch name -> new commits without a verified signature
+		MergeCommits            map[string][]sha.SHA // branch name -> new commits with more than one parent
 	}
 
 	PushViolationsOutput struct {
@@ -56,6 +63,9 @@ type (
 		FileSizeLimit           int64
 		PrincipalCommitterMatch bool
 		SecretScanningEnabled   bool
+		FilePathProtection      bool
+		RequireSignedCommits    bool
+		RequireLinearHistory    bool
 		Protections             map[int64]PushProtection
 	}
 
@@ -72,13 +82,23 @@ type (
 		FileSizeLimit           int64 `json:"file_size_limit"`
 		PrincipalCommitterMatch bool  `json:"principal_committer_match"`
 		SecretScanningEnabled   bool  `json:"secret_scanning_enabled"`
+
+		// RequireSignedCommits rejects pushes of commits that aren't signed
+		// or whose signature doesn't verify against the committer's registered keys.
+		RequireSignedCommits bool `json:"require_signed_commits"`
+
+		// RequireLinearHistory rejects pushes that introduce merge commits.
+		RequireLinearHistory bool `json:"require_linear_history"`
 	}
 )
 
 func (in *PushViolationsInput) HasViolations() bool {
 	return in.FindOversizeFilesOutput != nil && (in.FindOversizeFilesOutput.Total > 0) ||
 		in.CommitterMismatchCount > 0 ||
-		in.FoundSecretCount > 0
+		in.FoundSecretCount > 0 ||
+		len(in.ChangedFiles) > 0 ||
+		len(in.UnverifiedCommits) > 0 ||
+		len(in.MergeCommits) > 0
 }
 
 func (v *DefPush) PushVerify(
@@ -89,5 +109,7 @@ func (v *DefPush) PushVerify(
 		FileSizeLimit:           v.FileSizeLimit,
 		PrincipalCommitterMatch: v.PrincipalCommitterMatch,
 		SecretScanningEnabled:   v.SecretScanningEnabled,
+		RequireSignedCommits:    v.RequireSignedCommits,
+		RequireLinearHistory:    v.RequireLinearHistory,
 	}, nil, nil
 }
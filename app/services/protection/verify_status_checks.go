@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"sort"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// required returns identifiers of the required status checks for the reported status checks
+// and the files changed by the pull request. The second returned map holds the required identifiers
+// and identifier patterns without any reported status check. These are included in the first map too.
+// With the skip policy the status checks that were never reported are not required.
+func (c *DefStatusChecks) required(
+	results []types.CheckResult,
+	changedFiles []string,
+) (map[string]struct{}, map[string]struct{}) {
+	reported := make([]string, len(results))
+	for i := range results {
+		reported[i] = results[i].Identifier
+	}
+
+	required := make(map[string]struct{})
+	missing := make(map[string]struct{})
+
+	skipMissing := c.MissingPolicy == enum.CheckMissingPolicySkip
+
+	requireIdentifiers := func(identifiers []string) {
+		for _, identifier := range identifiers {
+			found := false
+			for _, r := range reported {
+				if r == identifier {
+					found = true
+					break
+				}
+			}
+
+			if !found && skipMissing {
+				continue
+			}
+
+			required[identifier] = struct{}{}
+			if !found {
+				missing[identifier] = struct{}{}
+			}
+		}
+	}
+
+	requirePatterns := func(patterns []string) {
+		for _, pattern := range patterns {
+			found := false
+			for _, r := range reported {
+				if patternMatches(pattern, r) {
+					required[r] = struct{}{}
+					found = true
+				}
+			}
+
+			if !found && !skipMissing {
+				required[pattern] = struct{}{}
+				missing[pattern] = struct{}{}
+			}
+		}
+	}
+
+	requireIdentifiers(c.RequireIdentifiers)
+	requirePatterns(c.RequirePatterns)
+
+	for i := range c.RequireIfChanged {
+		if !c.RequireIfChanged[i].matchesAny(changedFiles) {
+			continue
+		}
+
+		requireIdentifiers(c.RequireIfChanged[i].Identifiers)
+		requirePatterns(c.RequireIfChanged[i].Patterns)
+	}
+
+	return required, missing
+}
+
+// evaluate returns sorted lists of the required status checks that completed unsuccessfully,
+// that are not completed yet and that were never reported.
+func (c *DefStatusChecks) evaluate(
+	results []types.CheckResult,
+	changedFiles []string,
+) ([]string, []string, []string) {
+	required, missing := c.required(results, changedFiles)
+
+	statuses := make(map[string]enum.CheckStatus, len(results))
+	for i := range results {
+		statuses[results[i].Identifier] = results[i].Status
+	}
+
+	var failed, pending, missingList []string
+
+	for identifier := range required {
+		if _, ok := missing[identifier]; ok {
+			missingList = append(missingList, identifier)
+			continue
+		}
+
+		status := statuses[identifier]
+		switch {
+		case status.IsSuccess():
+		case status.IsCompleted():
+			failed = append(failed, identifier)
+		default:
+			pending = append(pending, identifier)
+		}
+	}
+
+	sort.Strings(failed)
+	sort.Strings(pending)
+	sort.Strings(missingList)
+
+	return failed, pending, missingList
+}
+
+// matchesAny returns true if any of the files matches any of the path patterns.
+func (c *DefStatusChecksIfChanged) matchesAny(files []string) bool {
+	for _, file := range files {
+		for _, path := range c.Paths {
+			if patternMatches(path, file) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -39,5 +39,9 @@ func ProvideManager(ruleStore store.RuleStore) (*Manager, error) {
 		return nil, err
 	}
 
+	if err := m.Register(TypeFilePath, func() Definition { return &FilePath{} }); err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/services/publickey/keypgp"
 	"github.com/harness/gitness/app/services/publickey/keyssh"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/sha"
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -38,17 +39,20 @@ type SignatureVerifyService struct {
 	principalStore          store.PrincipalStore
 	keyFetcher              keyfetcher.Service
 	gitSignatureResultStore store.GitSignatureResultStore
+	git                     git.Interface
 }
 
 func NewSignatureVerifyService(
 	principalStore store.PrincipalStore,
 	keyFetcher keyfetcher.Service,
 	gitSignatureResultStore store.GitSignatureResultStore,
+	git git.Interface,
 ) SignatureVerifyService {
 	return SignatureVerifyService{
 		principalStore:          principalStore,
 		keyFetcher:              keyFetcher,
 		gitSignatureResultStore: gitSignatureResultStore,
+		git:                     git,
 	}
 }
 
@@ -85,6 +89,77 @@ func (s SignatureVerifyService) VerifyCommits(ctx context.Context, repoID int64,
 	return nil
 }
 
+// UnverifiedCommits verifies signatures of the provided commits and returns SHAs of the commits
+// that aren't signed or whose signature isn't verified against the committer's registered keys.
+func (s SignatureVerifyService) UnverifiedCommits(
+	ctx context.Context,
+	repoID int64,
+	commits []*types.Commit,
+) ([]sha.SHA, error) {
+	if err := s.VerifyCommits(ctx, repoID, commits); err != nil {
+		return nil, err
+	}
+
+	var unverified []sha.SHA
+	for _, commit := range commits {
+		if commit.Signature == nil || commit.Signature.Result != enum.GitSignatureGood {
+			unverified = append(unverified, commit.SHA)
+		}
+	}
+
+	return unverified, nil
+}
+
+// UnverifiedPullReqCommits returns a function that lists the commits of the pull request
+// and returns SHAs of the commits without a verified signature.
+// The commits are listed and verified only once, on the first call of the returned function.
+func (s SignatureVerifyService) UnverifiedPullReqCommits(
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+) func(ctx context.Context) ([]sha.SHA, error) {
+	var (
+		done       bool
+		unverified []sha.SHA
+	)
+
+	return func(ctx context.Context) ([]sha.SHA, error) {
+		if done {
+			return unverified, nil
+		}
+
+		out, err := s.git.ListCommits(ctx, &git.ListCommitsParams{
+			ReadParams: git.CreateReadParams(repo),
+			GitREF:     pr.SourceSHA,
+			After:      pr.MergeBaseSHA,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request commits: %w", err)
+		}
+
+		// Only the fields required for the signature verification are mapped.
+		commits := make([]*types.Commit, len(out.Commits))
+		for i := range out.Commits {
+			commits[i] = &types.Commit{
+				SHA: out.Commits[i].SHA,
+				Committer: types.Signature{
+					Identity: types.Identity(out.Commits[i].Committer.Identity),
+					When:     out.Commits[i].Committer.When,
+				},
+				SignedData: (*types.SignedData)(out.Commits[i].SignedData),
+			}
+		}
+
+		unverified, err = s.UnverifiedCommits(ctx, repo.ID, commits)
+		if err != nil {
+			return nil, err
+		}
+
+		done = true
+
+		return unverified, nil
+	}
+}
+
 func (s *VerifySession) VerifyCommitTags(ctx context.Context, tags []*types.CommitTag) error {
 	return verifyObjects(ctx, s, tags)
 }
@@ -17,6 +17,7 @@ package publickey
 import (
 	"github.com/harness/gitness/app/services/keyfetcher"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
 
 	"github.com/google/wire"
 )
@@ -37,9 +38,11 @@ func ProvideSignatureVerifyService(
 	principalStore store.PrincipalStore,
 	keyFetcher keyfetcher.Service,
 	gitSignatureResultStore store.GitSignatureResultStore,
+	git git.Interface,
 ) SignatureVerifyService {
 	return NewSignatureVerifyService(
 		principalStore,
 		keyFetcher,
-		gitSignatureResultStore)
+		gitSignatureResultStore,
+		git)
 }
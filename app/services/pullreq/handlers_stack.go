@@ -0,0 +1,278 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// retargetStackOnMerge handles pull request merged events. Every open pull request stacked on the merged
+// pull request is retargeted to the target branch of the merged pull request, and its source branch is
+// rebased on top of the target branch, leaving out the commits of the merged pull request.
+// The rebase is done only if the principal who merged the parent pull request is allowed to force push
+// the source branch, otherwise it's left to the author of the pull request.
+func (s *Service) retargetStackOnMerge(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	parent, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find merged pull request: %w", err)
+	}
+
+	children, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		ParentID: parent.ID,
+		States:   []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:     enum.PullReqSortNumber,
+		Order:    enum.OrderAsc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests stacked on the merged pull request: %w", err)
+	}
+
+	for _, child := range children {
+		if err := s.retargetStackChild(ctx, event.Payload, parent, child); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_number", child.Number).
+				Msg("failed to retarget pull request stacked on the merged pull request")
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) retargetStackChild(
+	ctx context.Context,
+	payload *pullreqevents.MergedPayload,
+	parent *types.PullReq,
+	child *types.PullReq,
+) error {
+	if child.TargetRepoID != parent.TargetRepoID || child.SourceRepoID != child.TargetRepoID {
+		return nil // only pull requests within the same repository can be stacked
+	}
+
+	if child.TargetBranch != parent.SourceBranch {
+		return nil // the pull request has been manually retargeted
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, child.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get repo info: %w", err)
+	}
+
+	mergeBase, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Ref1:       child.SourceSHA,
+		Ref2:       parent.TargetBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find merge base: %w", err)
+	}
+
+	oldTargetBranch := child.TargetBranch
+	oldMergeBaseSHA := child.MergeBaseSHA
+
+	child, err = s.pullreqStore.UpdateOptLock(ctx, child, func(pr *types.PullReq) error {
+		if pr.State != enum.PullReqStateOpen {
+			return errPRNotOpen
+		}
+
+		pr.MergeSHA = nil
+		pr.MergeTargetSHA = nil
+		pr.Stats.DiffStats = types.DiffStats{}
+
+		pr.MergeBaseSHA = mergeBase.MergeBaseSHA.String()
+		pr.TargetBranch = parent.TargetBranch
+
+		pr.MarkAsMergeUnchecked()
+
+		pr.ActivitySeq++
+
+		return nil
+	})
+	if errors.Is(err, errPRNotOpen) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update target branch of the pull request: %w", err)
+	}
+
+	_, err = s.activityStore.CreateWithPayload(ctx, child, payload.PrincipalID,
+		&types.PullRequestActivityPayloadBranchChangeTarget{
+			Old: oldTargetBranch,
+			New: child.TargetBranch,
+		}, nil)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity for stacked pull request retarget")
+	}
+
+	s.pullreqEvReporter.TargetBranchChanged(ctx, &pullreqevents.TargetBranchChangedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    child.ID,
+			SourceRepoID: child.SourceRepoID,
+			TargetRepoID: child.TargetRepoID,
+			PrincipalID:  payload.PrincipalID,
+			Number:       child.Number,
+		},
+		SourceSHA:       child.SourceSHA,
+		OldTargetBranch: oldTargetBranch,
+		NewTargetBranch: child.TargetBranch,
+		OldMergeBaseSHA: oldMergeBaseSHA,
+		NewMergeBaseSHA: child.MergeBaseSHA,
+	})
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, child)
+
+	// The source branch update triggers the regular branch update processing of the pull request.
+	if err := s.rebaseStackChild(ctx, payload, repo, child); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("pullreq_number", child.Number).
+			Msg("failed to rebase pull request stacked on the merged pull request")
+	}
+
+	return nil
+}
+
+func (s *Service) rebaseStackChild(
+	ctx context.Context,
+	payload *pullreqevents.MergedPayload,
+	repo *types.RepositoryCore,
+	child *types.PullReq,
+) error {
+	principal, err := s.principalStore.Find(ctx, payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to find principal who merged the parent pull request: %w", err)
+	}
+
+	allowed, err := s.canForcePushStackChild(ctx, principal, repo, child)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		log.Ctx(ctx).Info().
+			Int64("pullreq_number", child.Number).
+			Msg("stacked pull request isn't rebased, the principal isn't allowed to force push its source branch")
+		return nil
+	}
+
+	writeParams, err := createRPCInternalWriteParams(ctx, s.urlProvider, repo.ID, repo.GitUID,
+		principal.ToPrincipalInfo())
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	sourceBranchRef, err := git.GetRefPath(child.SourceBranch, gitenum.RefTypeBranch)
+	if err != nil {
+		return fmt.Errorf("failed to get ref path: %w", err)
+	}
+
+	sourceSHA, err := sha.New(child.SourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to parse source SHA of the pull request: %w", err)
+	}
+
+	upstreamSHA, err := sha.New(payload.SourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to parse source SHA of the parent pull request: %w", err)
+	}
+
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams: writeParams,
+		BaseBranch:  child.TargetBranch,
+		HeadRepoUID: repo.GitUID,
+		HeadBranch:  child.SourceBranch,
+		Refs: []git.RefUpdate{{
+			Name: sourceBranchRef,
+			Old:  sourceSHA,
+			New:  sha.SHA{}, // update to the result of the rebase
+		}},
+		HeadExpectedSHA: sourceSHA,
+		Method:          gitenum.MergeMethodRebase,
+		UpstreamSHA:     upstreamSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("rebase execution failed: %w", err)
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		log.Ctx(ctx).Info().
+			Int64("pullreq_number", child.Number).
+			Strs("conflict_files", mergeOutput.ConflictFiles).
+			Msg("stacked pull request can't be rebased automatically because of conflicts")
+	}
+
+	return nil
+}
+
+// canForcePushStackChild checks whether the principal has push access to the repository and whether
+// the protection rules allow the principal to force push the source branch of the pull request.
+// The rules are never bypassed.
+func (s *Service) canForcePushStackChild(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.RepositoryCore,
+	child *types.PullReq,
+) (bool, error) {
+	session := &auth.Session{Principal: *principal}
+
+	err := apiauth.CheckRepo(ctx, s.authorizer, session, repo, enum.PermissionRepoPush)
+	if apiauth.IsNoAccess(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check push access of the principal: %w", err)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, s.authorizer, session, repo)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	rules, err := s.protectionManager.ListRepoBranchRules(ctx, repo.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: s.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              principal,
+		AllowBypass:        false,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          protection.RefActionUpdateForce,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{child.SourceBranch},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	return !protection.IsCritical(violations), nil
+}
@@ -20,12 +20,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -49,6 +52,10 @@ type Service struct {
 	fileViewStore       store.PullReqFileViewStore
 	sseStreamer         sse.Streamer
 	urlProvider         url.Provider
+	principalStore      store.PrincipalStore
+	authorizer          authz.Authorizer
+	protectionManager   *protection.Manager
+	userGroupService    usergroup.Service
 
 	cancelMutex        sync.Mutex
 	cancelMergeability map[string]context.CancelFunc
@@ -74,6 +81,10 @@ func New(ctx context.Context,
 	bus pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
+	protectionManager *protection.Manager,
+	userGroupService usergroup.Service,
 ) (*Service, error) {
 	service := &Service{
 		pullreqEvReporter:   pullreqEvReporter,
@@ -90,6 +101,10 @@ func New(ctx context.Context,
 		cancelMergeability:  make(map[string]context.CancelFunc),
 		pubsub:              bus,
 		sseStreamer:         sseStreamer,
+		principalStore:      principalStore,
+		authorizer:          authorizer,
+		protectionManager:   protectionManager,
+		userGroupService:    userGroupService,
 	}
 
 	var err error
@@ -180,6 +195,27 @@ func New(ctx context.Context,
 		return nil, err
 	}
 
+	// stacked pull requests maintenance
+
+	const groupPullReqStack = "gitness:pullreq:stack"
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqStack, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterMerged(service.retargetStackOnMerge)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
 	// mergeability check
 	const groupPullReqMergeable = "gitness:pullreq:mergeable"
 	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqMergeable, config.InstanceID,
@@ -279,3 +315,34 @@ func createRPCSystemReferencesWriteParams(
 		EnvVars: envVars,
 	}, nil
 }
+
+// createRPCInternalWriteParams creates base write parameters for internal write operations
+// performed on behalf of the principal. Git hooks are executed, but protection rules are not verified.
+func createRPCInternalWriteParams(
+	ctx context.Context,
+	urlProvider url.Provider,
+	repoID int64,
+	repoGITUID string,
+	principal *types.PrincipalInfo,
+) (git.WriteParams, error) {
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		urlProvider.GetInternalAPIURL(ctx),
+		repoID,
+		principal.ID,
+		false,
+		true,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repoGITUID,
+		EnvVars: envVars,
+	}, nil
+}
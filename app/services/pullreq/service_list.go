@@ -15,8 +15,10 @@
 package pullreq
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
@@ -378,6 +380,101 @@ func (c *ListService) backfillRules(
 	return nil
 }
 
+// backfillStack collects the pull request stack for every pull request in the list
+// that has a parent pull request or is a parent of another pull request.
+func (c *ListService) backfillStack(
+	ctx context.Context,
+	list []types.PullReqRepo,
+) error {
+	stacks := make(map[int64][]types.PullReqStackItem)
+
+	for _, entry := range list {
+		if stack, ok := stacks[entry.PullRequest.ID]; ok {
+			entry.PullRequest.Stack = stack
+			continue
+		}
+
+		stack, err := c.Stack(ctx, entry.PullRequest)
+		if err != nil {
+			return err
+		}
+
+		entry.PullRequest.Stack = stack
+		for _, item := range stack {
+			stacks[item.ID] = stack
+		}
+	}
+
+	return nil
+}
+
+// Stack returns the pull request stack the pull request belongs to, ordered from the root pull request,
+// with children following their parent. It returns nil if the pull request isn't part of a stack.
+func (c *ListService) Stack(ctx context.Context, pr *types.PullReq) ([]types.PullReqStackItem, error) {
+	prs, err := c.pullreqStore.ListStack(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request stack: %w", err)
+	}
+
+	if len(prs) <= 1 {
+		return nil, nil
+	}
+
+	return buildStack(prs), nil
+}
+
+// buildStack converts the pull requests of a stack to stack items ordered depth-first,
+// starting with the root pull request. Siblings are ordered by number.
+func buildStack(prs []*types.PullReq) []types.PullReqStackItem {
+	numbers := make(map[int64]int64, len(prs))
+	children := make(map[int64][]*types.PullReq, len(prs))
+	for _, pr := range prs {
+		numbers[pr.ID] = pr.Number
+	}
+
+	var roots []*types.PullReq
+	for _, pr := range prs {
+		if pr.ParentID == nil {
+			roots = append(roots, pr)
+			continue
+		}
+		if _, ok := numbers[*pr.ParentID]; !ok {
+			roots = append(roots, pr)
+			continue
+		}
+		children[*pr.ParentID] = append(children[*pr.ParentID], pr)
+	}
+
+	byNumber := func(a, b *types.PullReq) int { return cmp.Compare(a.Number, b.Number) }
+	slices.SortFunc(roots, byNumber)
+
+	stack := make([]types.PullReqStackItem, 0, len(prs))
+
+	var walk func(pr *types.PullReq)
+	walk = func(pr *types.PullReq) {
+		item := pr.StackItem()
+		if pr.ParentID != nil {
+			if number, ok := numbers[*pr.ParentID]; ok {
+				item.ParentNumber = &number
+			}
+		}
+
+		stack = append(stack, item)
+
+		prChildren := children[pr.ID]
+		slices.SortFunc(prChildren, byNumber)
+		for _, child := range prChildren {
+			walk(child)
+		}
+	}
+
+	for _, root := range roots {
+		walk(root)
+	}
+
+	return stack
+}
+
 func (c *ListService) BackfillMetadata(
 	ctx context.Context,
 	list []types.PullReqRepo,
@@ -395,6 +492,12 @@ func (c *ListService) BackfillMetadata(
 		}
 	}
 
+	if options.IncludeStack {
+		if err := c.backfillStack(ctx, list); err != nil {
+			return fmt.Errorf("failed to backfill stack")
+		}
+	}
+
 	if options.IncludeGitStats {
 		if err := c.backfillStats(ctx, list); err != nil {
 			log.Ctx(ctx).Warn().Err(err).Msg("failed to backfill PR stats")
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestBuildStack(t *testing.T) {
+	prs := []*types.PullReq{
+		{ID: 11, Number: 1},
+		{ID: 12, Number: 2, ParentID: ptr.Int64(11)},
+		{ID: 13, Number: 3, ParentID: ptr.Int64(15)},
+		{ID: 14, Number: 4, ParentID: ptr.Int64(11)},
+		{ID: 15, Number: 5, ParentID: ptr.Int64(12)},
+	}
+
+	stack := buildStack(prs)
+
+	type item struct {
+		number       int64
+		parentNumber int64
+	}
+
+	exp := []item{{1, 0}, {2, 1}, {5, 2}, {3, 5}, {4, 1}}
+
+	if len(stack) != len(exp) {
+		t.Fatalf("expected %d stack items, got %d", len(exp), len(stack))
+	}
+
+	for i, e := range exp {
+		got := item{number: stack[i].Number}
+		if stack[i].ParentNumber != nil {
+			got.parentNumber = *stack[i].ParentNumber
+		}
+
+		if got != e {
+			t.Errorf("stack item %d: expected=%+v, got=%+v", i, e, got)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// MergeService completes the merge of a pull request once its changes have been written to the target branch.
+// It's shared by the pull request merge API, the auto-merge and the merge queue.
+type MergeService struct {
+	git               git.Interface
+	pullreqStore      store.PullReqStore
+	activityStore     store.PullReqActivityStore
+	urlProvider       url.Provider
+	pullreqEvReporter *pullreqevents.Reporter
+	repoEvReporter    *repoevents.Reporter
+	sseStreamer       sse.Streamer
+	auditService      audit.Service
+	instrumentation   instrument.Service
+}
+
+func NewMergeService(
+	git git.Interface,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	urlProvider url.Provider,
+	pullreqEvReporter *pullreqevents.Reporter,
+	repoEvReporter *repoevents.Reporter,
+	sseStreamer sse.Streamer,
+	auditService audit.Service,
+	instrumentation instrument.Service,
+) *MergeService {
+	return &MergeService{
+		git:               git,
+		pullreqStore:      pullreqStore,
+		activityStore:     activityStore,
+		urlProvider:       urlProvider,
+		pullreqEvReporter: pullreqEvReporter,
+		repoEvReporter:    repoEvReporter,
+		sseStreamer:       sseStreamer,
+		auditService:      auditService,
+		instrumentation:   instrumentation,
+	}
+}
+
+// CreateWriteParams creates write parameters for merging the pull request on behalf of the principal.
+func (s *MergeService) CreateWriteParams(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	principal *types.Principal,
+) (git.WriteParams, error) {
+	return createRPCInternalWriteParams(ctx, s.urlProvider, repo.ID, repo.GitUID, principal.ToPrincipalInfo())
+}
+
+// MergeIdentities returns the author and the committer of the merge commit created by the principal.
+// For the rebase and fast-forward merge methods the author info in the commits is preserved.
+func MergeIdentities(
+	pr *types.PullReq,
+	principal *types.Principal,
+	method enum.MergeMethod,
+) (*git.Identity, *git.Identity) {
+	actor := identityFromPrincipalInfo(*principal.ToPrincipalInfo())
+	system := identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+
+	switch method {
+	case enum.MergeMethodMerge:
+		return actor, system
+	case enum.MergeMethodSquash:
+		return identityFromPrincipalInfo(pr.Author), system
+	case enum.MergeMethodRebase:
+		return nil, actor
+	case enum.MergeMethodFastForward:
+		return nil, nil
+	}
+
+	return nil, nil
+}
+
+func identityFromPrincipalInfo(p types.PrincipalInfo) *git.Identity {
+	return &git.Identity{
+		Name:  p.DisplayName,
+		Email: p.Email,
+	}
+}
+
+// MergedInput holds the outcome of a pull request merge.
+type MergedInput struct {
+	Principal  *types.Principal
+	TargetRepo *types.RepositoryCore
+	SourceRepo *types.RepositoryCore
+
+	Method       enum.MergeMethod
+	Merged       time.Time
+	SourceSHA    string
+	TargetSHA    string
+	MergeBaseSHA string
+	MergeSHA     string
+	DiffStats    types.DiffStats
+
+	DeleteSourceBranch bool
+
+	// Violations are the protection rule violations of the merge. If any of them has been bypassed,
+	// the bypass is recorded in the audit log and reported as an event.
+	Violations []types.RuleViolations
+}
+
+// Finalize marks the pull request as merged, writes the merge activity, reports the merged event,
+// optionally deletes the source branch and records the bypassed protection rules.
+// Returns the updated pull request and whether the source branch has been deleted.
+func (s *MergeService) Finalize(
+	ctx context.Context,
+	pr *types.PullReq,
+	in MergedInput,
+) (*types.PullReq, bool, error) {
+	mergedBy := in.Principal.ID
+	bypassed := protection.IsBypassed(in.Violations)
+
+	var activitySeqMerge, activitySeqBranchDeleted int64
+	pr, err := s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.State = enum.PullReqStateMerged
+
+		nowMilli := in.Merged.UnixMilli()
+
+		pr.Merged = &nowMilli
+		pr.MergedBy = &mergedBy
+		pr.MergeMethod = &in.Method
+
+		// update all Merge specific information (might be empty if previous merge check failed)
+		// since this is the final operation on the PR, we update any sha that might've changed by now.
+		pr.SourceSHA = in.SourceSHA
+		pr.MergeTargetSHA = ptr.String(in.TargetSHA)
+		pr.MergeBaseSHA = in.MergeBaseSHA
+		pr.MergeSHA = ptr.String(in.MergeSHA)
+		pr.MarkAsMerged()
+
+		pr.MergeViolationsBypassed = &bypassed
+
+		pr.Stats.DiffStats = in.DiffStats
+
+		// update sequence for PR activities
+		pr.ActivitySeq++
+		activitySeqMerge = pr.ActivitySeq
+
+		if in.DeleteSourceBranch {
+			pr.ActivitySeq++
+			activitySeqBranchDeleted = pr.ActivitySeq
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	pr.ActivitySeq = activitySeqMerge
+	activityPayload := &types.PullRequestActivityPayloadMerge{
+		MergeMethod:   in.Method,
+		MergeSHA:      in.MergeSHA,
+		TargetSHA:     in.TargetSHA,
+		SourceSHA:     in.SourceSHA,
+		RulesBypassed: bypassed,
+	}
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, mergedBy, activityPayload, nil); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
+	}
+
+	s.pullreqEvReporter.Merged(ctx, &pullreqevents.MergedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  mergedBy,
+			Number:       pr.Number,
+		},
+		MergeMethod: in.Method,
+		MergeSHA:    in.MergeSHA,
+		TargetSHA:   in.TargetSHA,
+		SourceSHA:   in.SourceSHA,
+	})
+
+	var branchDeleted bool
+	if in.DeleteSourceBranch {
+		branchDeleted = s.deleteSourceBranch(ctx, pr, in, activitySeqBranchDeleted)
+	}
+
+	s.sseStreamer.Publish(ctx, in.TargetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	if bypassed {
+		s.reportRuleBypassed(ctx, pr, in)
+	}
+
+	err = s.instrumentation.Track(ctx, instrument.Event{
+		Type:      instrument.EventTypeMergePullRequest,
+		Principal: in.Principal.ToPrincipalInfo(),
+		Path:      in.SourceRepo.Path,
+		Properties: map[instrument.Property]any{
+			instrument.PropertyRepositoryID:   in.SourceRepo.ID,
+			instrument.PropertyRepositoryName: in.SourceRepo.Identifier,
+			instrument.PropertyPullRequestID:  pr.Number,
+			instrument.PropertyMergeStrategy:  in.Method,
+		},
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for merge pr operation: %s", err)
+	}
+
+	return pr, branchDeleted, nil
+}
+
+// deleteSourceBranch deletes the source branch of the merged pull request. Failures are only logged.
+func (s *MergeService) deleteSourceBranch(
+	ctx context.Context,
+	pr *types.PullReq,
+	in MergedInput,
+	activitySeq int64,
+) bool {
+	writeParams, err := s.CreateWriteParams(ctx, in.SourceRepo, in.Principal)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to create write params for deleting source branch after merging")
+		return false
+	}
+
+	err = s.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+		WriteParams: writeParams,
+		BranchName:  pr.SourceBranch,
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to delete source branch after merging")
+		return false
+	}
+
+	// NOTE: there is a chance someone pushed on the branch between merge and delete.
+	// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
+	pr.ActivitySeq = activitySeq
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, in.Principal.ID,
+		&types.PullRequestActivityPayloadBranchDelete{SHA: in.SourceSHA}, nil); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).
+			Msgf("failed to write pull request activity for successful automatic branch delete")
+	}
+
+	return true
+}
+
+// reportRuleBypassed records the merge with bypassed protection rules in the audit log and reports it as an event.
+func (s *MergeService) reportRuleBypassed(ctx context.Context, pr *types.PullReq, in MergedInput) {
+	prNumber := strconv.FormatInt(pr.Number, 10)
+
+	err := s.auditService.Log(ctx,
+		*in.Principal,
+		audit.NewResource(
+			audit.ResourceTypeRepository,
+			in.SourceRepo.Identifier,
+			audit.RepoPath,
+			in.SourceRepo.Path,
+			audit.BypassedResourceType,
+			audit.BypassedResourceTypePullRequest,
+			audit.BypassedResourceName,
+			prNumber,
+			audit.ResourceName,
+			fmt.Sprintf(
+				audit.BypassPullReqLabelFormat,
+				in.SourceRepo.Identifier,
+				prNumber,
+			),
+			audit.BypassAction,
+			audit.BypassActionMerged,
+		),
+		audit.ActionBypassed,
+		paths.Parent(in.SourceRepo.Path),
+		audit.WithNewObject(audit.PullRequestObject{
+			PullReq:        *pr,
+			RepoPath:       in.SourceRepo.Path,
+			RuleViolations: in.Violations,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for merge pull request operation: %s", err)
+	}
+
+	s.repoEvReporter.RuleBypassed(ctx, &repoevents.RuleBypassedPayload{
+		Base: repoevents.Base{
+			RepoID:      in.TargetRepo.ID,
+			PrincipalID: in.Principal.ID,
+		},
+		Action:       audit.BypassActionMerged,
+		ResourceType: audit.BypassedResourceTypePullRequest,
+		ResourceName: prNumber,
+	})
+}
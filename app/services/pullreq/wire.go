@@ -20,13 +20,17 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/pubsub"
@@ -39,6 +43,7 @@ import (
 var WireSet = wire.NewSet(
 	ProvideService,
 	ProvideListService,
+	ProvideMergeService,
 )
 
 func ProvideService(ctx context.Context,
@@ -58,6 +63,10 @@ func ProvideService(ctx context.Context,
 	pubsub pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
+	protectionManager *protection.Manager,
+	userGroupService usergroup.Service,
 ) (*Service, error) {
 	return New(ctx,
 		config,
@@ -76,6 +85,10 @@ func ProvideService(ctx context.Context,
 		pubsub,
 		urlProvider,
 		sseStreamer,
+		principalStore,
+		authorizer,
+		protectionManager,
+		userGroupService,
 	)
 }
 
@@ -102,3 +115,27 @@ func ProvideListService(
 		protectionManager,
 	)
 }
+
+func ProvideMergeService(
+	git git.Interface,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	urlProvider url.Provider,
+	pullreqEvReporter *pullreqevents.Reporter,
+	repoEvReporter *repoevents.Reporter,
+	sseStreamer sse.Streamer,
+	auditService audit.Service,
+	instrumentation instrument.Service,
+) *MergeService {
+	return NewMergeService(
+		git,
+		pullreqStore,
+		activityStore,
+		urlProvider,
+		pullreqEvReporter,
+		repoEvReporter,
+		sseStreamer,
+		auditService,
+		instrumentation,
+	)
+}
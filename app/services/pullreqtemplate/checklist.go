@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqtemplate
+
+import (
+	"regexp"
+	"strings"
+)
+
+// RequiredItemMarker marks a checklist item of a template as required, e.g.
+//
+//   - [ ] I have added tests <!-- required -->
+//
+// The marker is an html comment so it doesn't show up in the rendered pull request description.
+const RequiredItemMarker = "<!-- required -->"
+
+var (
+	// checklistItemRegex matches a markdown task list item and captures its check state and text.
+	checklistItemRegex = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	whitespaceRegex    = regexp.MustCompile(`\s+`)
+)
+
+// RequiredItems returns the texts of all checklist items of the template that are marked as required.
+func RequiredItems(template string) []string {
+	var items []string
+	for _, line := range strings.Split(template, "\n") {
+		matches := checklistItemRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		text, ok := cutRequiredMarker(matches[2])
+		if !ok || text == "" {
+			continue
+		}
+
+		items = append(items, text)
+	}
+
+	return items
+}
+
+// MissingRequiredItems returns all required checklist items of the template
+// that aren't checked in the provided pull request description.
+func MissingRequiredItems(template string, description string) []string {
+	required := RequiredItems(template)
+	if len(required) == 0 {
+		return nil
+	}
+
+	checked := make(map[string]struct{})
+	for _, line := range strings.Split(description, "\n") {
+		matches := checklistItemRegex.FindStringSubmatch(line)
+		if matches == nil || matches[1] == " " {
+			continue
+		}
+
+		text, _ := cutRequiredMarker(matches[2])
+		checked[text] = struct{}{}
+	}
+
+	var missing []string
+	for _, item := range required {
+		if _, ok := checked[item]; !ok {
+			missing = append(missing, item)
+		}
+	}
+
+	return missing
+}
+
+// cutRequiredMarker removes the required marker from the item text and normalizes its whitespace.
+func cutRequiredMarker(text string) (string, bool) {
+	text = strings.TrimSpace(strings.TrimRight(text, "\r"))
+	text, required := strings.CutSuffix(text, RequiredItemMarker)
+	return whitespaceRegex.ReplaceAllString(strings.TrimSpace(text), " "), required
+}
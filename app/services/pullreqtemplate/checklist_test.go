@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqtemplate
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testTemplate = `## Description
+
+## Checklist
+- [ ] I have added tests <!-- required -->
+* [ ]   I have updated   the docs <!-- required -->
+- [ ] Optional item
+- [x] Pre-checked item <!-- required -->
+`
+
+func TestRequiredItems(t *testing.T) {
+	want := []string{"I have added tests", "I have updated the docs", "Pre-checked item"}
+	if got := RequiredItems(testTemplate); !reflect.DeepEqual(got, want) {
+		t.Errorf("RequiredItems() = %v, want %v", got, want)
+	}
+
+	if got := RequiredItems("no checklist here"); got != nil {
+		t.Errorf("RequiredItems() = %v, want nil", got)
+	}
+}
+
+func TestMissingRequiredItems(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		want        []string
+	}{
+		{
+			name:        "unfilled template",
+			description: testTemplate,
+			want:        []string{"I have added tests", "I have updated the docs"},
+		},
+		{
+			name:        "empty description",
+			description: "",
+			want:        []string{"I have added tests", "I have updated the docs", "Pre-checked item"},
+		},
+		{
+			name: "all checked",
+			description: "- [x] I have added tests <!-- required -->\r\n" +
+				"- [X] I have updated the docs\n" +
+				"+ [x] Pre-checked item\n",
+			want: nil,
+		},
+		{
+			name:        "partially checked",
+			description: "- [x] I have added tests\n- [ ] I have updated the docs\n- [x] Pre-checked item",
+			want:        []string{"I have updated the docs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MissingRequiredItems(testTemplate, tt.description); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MissingRequiredItems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := MissingRequiredItems("- [ ] Optional item", ""); got != nil {
+		t.Errorf("MissingRequiredItems() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqtemplate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultName is the name of the template stored in DefaultFilePath.
+	DefaultName = "default"
+
+	// DefaultFilePath is the path of the default pull request template.
+	DefaultFilePath = ".harness/PULL_REQUEST_TEMPLATE.md"
+
+	// DirectoryPath is the path of the directory containing named pull request templates.
+	// Each markdown file in the directory is a template named after the file (without extension).
+	DirectoryPath = ".harness/PULL_REQUEST_TEMPLATE"
+
+	templateFileExtension = ".md"
+
+	// maxTemplateSize is the max size of a template file that is read from the repository.
+	maxTemplateSize = 64 * 1024
+)
+
+type Service struct {
+	git git.Interface
+}
+
+func New(git git.Interface) *Service {
+	return &Service{
+		git: git,
+	}
+}
+
+// List returns all pull request templates found in the repository at the provided git ref.
+// The default template, if present, is always the first entry. Template contents aren't returned.
+func (s *Service) List(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	gitRef string,
+) ([]types.PullReqTemplate, error) {
+	params := git.CreateReadParams(repo)
+	gitRef = refOrDefaultBranch(repo, gitRef)
+
+	templates := make([]types.PullReqTemplate, 0)
+
+	defaultNode, err := s.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: params,
+		GitREF:     gitRef,
+		Path:       DefaultFilePath,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get default pull request template node: %w", err)
+	}
+	if err == nil && isTemplateFile(defaultNode.Node) {
+		templates = append(templates, types.PullReqTemplate{
+			Name:    DefaultName,
+			Path:    DefaultFilePath,
+			Default: true,
+			SHA:     defaultNode.Node.SHA,
+		})
+	}
+
+	dirNodes, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: params,
+		GitREF:     gitRef,
+		Path:       DirectoryPath,
+	})
+	if errors.IsNotFound(err) {
+		return templates, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request template directory: %w", err)
+	}
+
+	named := make([]types.PullReqTemplate, 0, len(dirNodes.Nodes))
+	for _, node := range dirNodes.Nodes {
+		name, ok := templateName(node)
+		if !ok {
+			continue
+		}
+
+		named = append(named, types.PullReqTemplate{
+			Name: name,
+			Path: node.Path,
+			SHA:  node.SHA,
+		})
+	}
+
+	sort.Slice(named, func(i, j int) bool { return named[i].Name < named[j].Name })
+
+	return append(templates, named...), nil
+}
+
+// Find returns the pull request template with the provided name (including its content)
+// from the repository at the provided git ref. An empty name refers to the default template.
+func (s *Service) Find(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	gitRef string,
+	name string,
+) (*types.PullReqTemplate, error) {
+	if name == "" {
+		name = DefaultName
+	}
+
+	templatePath := DefaultFilePath
+	if name != DefaultName {
+		if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+			return nil, errors.InvalidArgument("Invalid pull request template name %q.", name)
+		}
+		templatePath = path.Join(DirectoryPath, name+templateFileExtension)
+	}
+
+	params := git.CreateReadParams(repo)
+	gitRef = refOrDefaultBranch(repo, gitRef)
+
+	node, err := s.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: params,
+		GitREF:     gitRef,
+		Path:       templatePath,
+	})
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFound("Pull request template %q not found.", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request template node: %w", err)
+	}
+	if !isTemplateFile(node.Node) {
+		return nil, errors.NotFound("Pull request template %q not found.", name)
+	}
+
+	output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: params,
+		SHA:        node.Node.SHA,
+		SizeLimit:  maxTemplateSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request template blob: %w", err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pull request template content: %w", err)
+	}
+
+	return &types.PullReqTemplate{
+		Name:          name,
+		Path:          templatePath,
+		Default:       name == DefaultName,
+		SHA:           output.SHA.String(),
+		Content:       string(content),
+		RequiredItems: RequiredItems(string(content)),
+	}, nil
+}
+
+func refOrDefaultBranch(repo *types.RepositoryCore, gitRef string) string {
+	if gitRef == "" {
+		return api.BranchPrefix + repo.DefaultBranch
+	}
+	return gitRef
+}
+
+func isTemplateFile(node git.TreeNode) bool {
+	return node.Type == git.TreeNodeTypeBlob &&
+		(node.Mode == git.TreeNodeModeFile || node.Mode == git.TreeNodeModeExec)
+}
+
+// templateName returns the name of the template stored in the provided node of the template directory.
+func templateName(node git.TreeNode) (string, bool) {
+	if !isTemplateFile(node) {
+		return "", false
+	}
+
+	name, ok := strings.CutSuffix(node.Name, templateFileExtension)
+	if !ok {
+		return "", false
+	}
+
+	if name == "" || name == DefaultName {
+		// the default name is reserved for the default template
+		return "", false
+	}
+
+	return name, true
+}
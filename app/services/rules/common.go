@@ -38,6 +38,8 @@ func ruleTypeToResourceType(ruleType enum.RuleType) audit.ResourceType {
 		return audit.ResourceTypeTagRule
 	case protection.TypePush:
 		return audit.ResourceTypePushRule
+	case protection.TypeFilePath:
+		return audit.ResourceTypeFilePathRule
 	}
 	return audit.ResourceTypeBranchRule
 }
@@ -28,4 +28,8 @@ var (
 	DefaultPrincipalCommitterMatch     = false
 	KeyGitLFSEnabled               Key = "git_lfs_enabled"
 	DefaultGitLFSEnabled               = true
+	// KeyPullReqTemplateChecklistRequired [bool] rejects pull requests that leave required
+	// checklist items of the pull request template unchecked.
+	KeyPullReqTemplateChecklistRequired     Key = "pullreq_template_checklist_required"
+	DefaultPullReqTemplateChecklistRequired     = false
 )
@@ -102,6 +102,27 @@ func (s *Service) handleEventPullReqMerged(
 	return s.trigger(ctx, event.Payload.SourceRepoID, enum.TriggerActionPullReqMerged, hook)
 }
 
+// handleEventPullReqMergeQueueSpeculated triggers the pipelines of the target repository
+// for the speculative merge commit, so that the required status checks get reported for it.
+func (s *Service) handleEventPullReqMergeQueueSpeculated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergeQueueSpeculatedPayload],
+) error {
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerHook,
+		Action:      enum.TriggerActionPullReqMergeQueued,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		After:       event.Payload.MergeSHA,
+	}
+	err := s.augmentPullReqInfo(ctx, hook, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("could not augment pull request info: %w", err)
+	}
+	hook.Before = event.Payload.BaseSHA
+	hook.Ref = event.Payload.Ref
+	return s.trigger(ctx, event.Payload.TargetRepoID, enum.TriggerActionPullReqMergeQueued, hook)
+}
+
 // augmentPullReqInfo adds in information into the hook pertaining to the pull request
 // by querying the database.
 func (s *Service) augmentPullReqInfo(
@@ -133,6 +133,7 @@ func New(
 			_ = r.RegisterReopened(service.handleEventPullReqReopened)
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterMergeQueueSpeculated(service.handleEventPullReqMergeQueueSpeculated)
 
 			return nil
 		})
@@ -16,20 +16,105 @@ package usergroup
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
 )
 
 var _ Resolver = (*GitnessResolver)(nil)
 
 type GitnessResolver struct {
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+	spaceFinder          refcache.SpaceFinder
+	principalInfoCache   store.PrincipalInfoCache
 }
 
-func NewGitnessResolver() *GitnessResolver {
-	return &GitnessResolver{}
+func NewGitnessResolver(
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	spaceFinder refcache.SpaceFinder,
+	principalInfoCache store.PrincipalInfoCache,
+) *GitnessResolver {
+	return &GitnessResolver{
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+		spaceFinder:          spaceFinder,
+		principalInfoCache:   principalInfoCache,
+	}
 }
 
-func (s *GitnessResolver) Resolve(context.Context, string) (*types.UserGroup, error) {
-	// todo: implement once usergroup is supported
-	return nil, ErrNotFound
+func (s *GitnessResolver) Resolve(
+	ctx context.Context,
+	spaceID int64,
+	scopedID string,
+) (*types.UserGroup, error) {
+	spaceIDs, err := ancestorSpaceIDs(ctx, s.spaceFinder, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	identifier := scopedID
+	if idx := strings.LastIndex(scopedID, "/"); idx >= 0 {
+		// the group is defined in a specific space, which must be the space itself or one of its ancestors.
+		identifier = scopedID[idx+1:]
+
+		space, err := s.spaceFinder.FindByRef(ctx, scopedID[:idx])
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, ErrNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space of user group: %w", err)
+		}
+
+		if !slices.Contains(spaceIDs, space.ID) {
+			return nil, ErrNotFound
+		}
+
+		spaceIDs = []int64{space.ID}
+	}
+
+	var userGroup *types.UserGroup
+	for _, id := range spaceIDs {
+		userGroup, err = s.userGroupStore.FindByIdentifier(ctx, id, identifier)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user group: %w", err)
+		}
+
+		break
+	}
+
+	if userGroup == nil {
+		return nil, ErrNotFound
+	}
+
+	memberMap, err := s.userGroupMemberStore.MapPrincipalIDs(ctx, []int64{userGroup.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members: %w", err)
+	}
+
+	infoMap, err := s.principalInfoCache.Map(ctx, memberMap[userGroup.ID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user group member principal infos: %w", err)
+	}
+
+	userGroup.Users = make([]string, 0, len(infoMap))
+	for _, principalID := range memberMap[userGroup.ID] {
+		if info, ok := infoMap[principalID]; ok && info.Type == enum.PrincipalTypeUser {
+			userGroup.Users = append(userGroup.Users, info.UID)
+		}
+	}
+
+	return userGroup, nil
 }
@@ -18,34 +18,139 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 )
 
 type service struct {
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+	spaceFinder          refcache.SpaceFinder
+	principalInfoCache   store.PrincipalInfoCache
 }
 
-func NewService() Service {
-	return &service{}
+func NewService(
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	spaceFinder refcache.SpaceFinder,
+	principalInfoCache store.PrincipalInfoCache,
+) Service {
+	return &service{
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+		spaceFinder:          spaceFinder,
+		principalInfoCache:   principalInfoCache,
+	}
 }
 
+// List returns the user groups available in the space:
+// The ones defined in the space itself and the ones inherited from its ancestor spaces.
 func (s *service) List(
-	_ context.Context,
-	_ *types.ListQueryFilter,
-	_ *types.SpaceCore,
+	ctx context.Context,
+	filter *types.ListQueryFilter,
+	space *types.SpaceCore,
 ) ([]*types.UserGroupInfo, error) {
-	return nil, fmt.Errorf("not implemented")
+	spaceIDs, err := ancestorSpaceIDs(ctx, s.spaceFinder, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userGroups, err := s.userGroupStore.List(ctx, spaceIDs, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user groups: %w", err)
+	}
+
+	userGroupInfos := make([]*types.UserGroupInfo, len(userGroups))
+	for i, userGroup := range userGroups {
+		userGroupInfos[i] = userGroup.ToUserGroupInfo()
+	}
+
+	return userGroupInfos, nil
 }
 
 func (s *service) ListUserIDsByGroupIDs(
-	_ context.Context,
-	_ []int64,
+	ctx context.Context,
+	userGroupIDs []int64,
 ) ([]int64, error) {
-	return []int64{}, nil
+	memberMap, err := s.userGroupMemberStore.MapPrincipalIDs(ctx, userGroupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members: %w", err)
+	}
+
+	seen := make(map[int64]struct{})
+	userIDs := make([]int64, 0)
+	for _, principalIDs := range memberMap {
+		for _, principalID := range principalIDs {
+			if _, ok := seen[principalID]; ok {
+				continue
+			}
+			seen[principalID] = struct{}{}
+			userIDs = append(userIDs, principalID)
+		}
+	}
+
+	return userIDs, nil
 }
 
 func (s *service) MapGroupIDsToPrincipals(
-	_ context.Context,
-	_ []int64,
+	ctx context.Context,
+	groupIDs []int64,
 ) (map[int64][]*types.Principal, error) {
-	return map[int64][]*types.Principal{}, nil
+	memberMap, err := s.userGroupMemberStore.MapPrincipalIDs(ctx, groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user group members: %w", err)
+	}
+
+	var principalIDs []int64
+	for _, ids := range memberMap {
+		principalIDs = append(principalIDs, ids...)
+	}
+
+	infoMap, err := s.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user group member principal infos: %w", err)
+	}
+
+	result := make(map[int64][]*types.Principal, len(groupIDs))
+	for _, groupID := range groupIDs {
+		principals := make([]*types.Principal, 0, len(memberMap[groupID]))
+		for _, principalID := range memberMap[groupID] {
+			info, ok := infoMap[principalID]
+			if !ok {
+				continue
+			}
+
+			principals = append(principals, &types.Principal{
+				ID:          info.ID,
+				UID:         info.UID,
+				Email:       info.Email,
+				Type:        info.Type,
+				DisplayName: info.DisplayName,
+				Created:     info.Created,
+				Updated:     info.Updated,
+			})
+		}
+
+		result[groupID] = principals
+	}
+
+	return result, nil
+}
+
+// ancestorSpaceIDs returns IDs of the space and all its ancestors, ordered from the space up to the root space.
+func ancestorSpaceIDs(ctx context.Context, spaceFinder refcache.SpaceFinder, spaceID int64) ([]int64, error) {
+	var spaceIDs []int64
+
+	for spaceID > 0 {
+		space, err := spaceFinder.FindByID(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spaceIDs = append(spaceIDs, space.ID)
+		spaceID = space.ParentID
+	}
+
+	return spaceIDs, nil
 }
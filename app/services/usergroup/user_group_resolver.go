@@ -24,5 +24,8 @@ import (
 var ErrNotFound = errors.New("usergroup not found")
 
 type Resolver interface {
-	Resolve(ctx context.Context, scopedID string) (*types.UserGroup, error)
+	// Resolve finds a user group available in the space. The scoped ID is either the group identifier,
+	// in which case the nearest group up the space tree wins, or the path of the space
+	// that defines the group followed by the group identifier, e.g. "org/team/backend".
+	Resolve(ctx context.Context, spaceID int64, scopedID string) (*types.UserGroup, error)
 }
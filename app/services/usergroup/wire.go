@@ -15,6 +15,9 @@
 package usergroup
 
 import (
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+
 	"github.com/google/wire"
 )
 
@@ -24,10 +27,20 @@ var WireSet = wire.NewSet(
 	ProvideService,
 )
 
-func ProvideUserGroupResolver() Resolver {
-	return NewGitnessResolver()
+func ProvideUserGroupResolver(
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	spaceFinder refcache.SpaceFinder,
+	principalInfoCache store.PrincipalInfoCache,
+) Resolver {
+	return NewGitnessResolver(userGroupStore, userGroupMemberStore, spaceFinder, principalInfoCache)
 }
 
-func ProvideService() Service {
-	return NewService()
+func ProvideService(
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	spaceFinder refcache.SpaceFinder,
+	principalInfoCache store.PrincipalInfoCache,
+) Service {
+	return NewService(userGroupStore, userGroupMemberStore, spaceFinder, principalInfoCache)
 }
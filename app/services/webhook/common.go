@@ -16,6 +16,7 @@ package webhook
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/url"
 
@@ -84,6 +85,11 @@ func CheckURL(rawURL string, allowLoopback bool, allowPrivateNetwork bool, inter
 	return nil
 }
 
+// reservedHeaderPrefix returns the prefix of the headers that are set by the webhook itself.
+func (s *Service) reservedHeaderPrefix() string {
+	return fmt.Sprintf("X-%s-", s.config.HeaderIdentity)
+}
+
 // CheckSecret validates the secret of a webhook.
 func CheckSecret(secret string) error {
 	if len(secret) > webhookMaxSecretLength {
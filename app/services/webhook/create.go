@@ -66,7 +66,13 @@ func (s *Service) sanitizeCreateInput(in *types.WebhookCreateInput, internal boo
 	if err := CheckSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := CheckTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := CheckTriggers(in.Triggers); err != nil {
+		return err
+	}
+	if err := CheckExtraHeaders(in.ExtraHeaders, s.reservedHeaderPrefix()); err != nil {
+		return err
+	}
+	if err := CheckPayloadTemplate(in.PayloadTemplate); err != nil { //nolint:revive
 		return err
 	}
 
@@ -121,6 +127,8 @@ func (s *Service) Create(
 		Insecure:              in.Insecure,
 		Triggers:              DeduplicateTriggers(in.Triggers),
 		LatestExecutionResult: nil,
+		ExtraHeaders:          in.ExtraHeaders,
+		PayloadTemplate:       in.PayloadTemplate,
 	}
 
 	err = s.webhookStore.Create(ctx, hook)
@@ -203,7 +203,8 @@ func (w *WebhookExecutor) TriggerForEvent(
 					result.Execution.ID, result.Webhook.ID, result.Execution.Result, result.Err))
 		}
 
-		if result.Execution.Result == enum.WebhookExecutionResultRetriableError {
+		// with reliable delivery the retries are scheduled per execution, no need to reprocess the event
+		if result.Execution.Result == enum.WebhookExecutionResultRetriableError && !w.reliableDelivery {
 			retryRequired = true
 		}
 	}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CheckStatusUpdatedPayload describes the body of the check status updated trigger.
+type CheckStatusUpdatedPayload struct {
+	BaseSegment
+	CheckSegment
+}
+
+// handleEventCheckReported handles reported events for status checks
+// and triggers check status updated webhooks for the repo.
+func (s *Service) handleEventCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	check, err := s.checkStore.FindByIdentifier(ctx, event.Payload.RepoID, event.Payload.SHA, event.Payload.Identifier)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("check '%s' for commit '%s' doesn't exist anymore",
+			event.Payload.Identifier, event.Payload.SHA)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get check '%s' for commit '%s': %w",
+			event.Payload.Identifier, event.Payload.SHA, err)
+	}
+
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerCheckStatusUpdated,
+		event.ID, check.CreatedBy, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &CheckStatusUpdatedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerCheckStatusUpdated,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				CheckSegment: CheckSegment{
+					Check: CheckInfo{
+						Identifier: check.Identifier,
+						// the check might have been updated since, the status of the event is reported
+						Status:    event.Payload.Status,
+						CommitSHA: check.CommitSHA,
+						Summary:   check.Summary,
+						Link:      check.Link,
+						Started:   check.Started,
+						Ended:     check.Ended,
+					},
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PipelineExecutionPayload describes the body of the pipeline execution started and finished triggers.
+type PipelineExecutionPayload struct {
+	BaseSegment
+	PipelineExecutionSegment
+}
+
+// handleEventPipelineExecutionStarted handles execution started events for pipelines
+// and triggers pipeline execution started webhooks for the repo.
+func (s *Service) handleEventPipelineExecutionStarted(
+	ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutionStartedPayload],
+) error {
+	return s.triggerForPipelineExecutionEvent(ctx, enum.WebhookTriggerPipelineExecutionStarted, event.ID,
+		event.Payload.RepoID, event.Payload.PipelineID, event.Payload.ExecutionNum, enum.CIStatusRunning)
+}
+
+// handleEventPipelineExecuted handles executed events for pipelines
+// and triggers pipeline execution finished webhooks for the repo.
+func (s *Service) handleEventPipelineExecuted(
+	ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload],
+) error {
+	return s.triggerForPipelineExecutionEvent(ctx, enum.WebhookTriggerPipelineExecutionFinished, event.ID,
+		event.Payload.RepoID, event.Payload.PipelineID, event.Payload.ExecutionNum, event.Payload.Status)
+}
+
+// triggerForPipelineExecutionEvent triggers the webhooks of a pipeline execution event.
+// The status is taken from the event, as the execution might have progressed in the meantime.
+// The principal of the payload is the principal that triggered the execution.
+func (s *Service) triggerForPipelineExecutionEvent(
+	ctx context.Context,
+	triggerType enum.WebhookTrigger,
+	eventID string,
+	repoID int64,
+	pipelineID int64,
+	executionNum int64,
+	status enum.CIStatus,
+) error {
+	pipeline, err := s.pipelineStore.Find(ctx, pipelineID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("pipeline with id '%d' doesn't exist anymore", pipelineID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline for id '%d': %w", pipelineID, err)
+	}
+
+	execution, err := s.executionStore.FindByNumber(ctx, pipelineID, executionNum)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("execution %d of pipeline with id '%d' doesn't exist anymore",
+			executionNum, pipelineID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get execution %d of pipeline with id '%d': %w", executionNum, pipelineID, err)
+	}
+
+	return s.triggerForEventWithRepo(ctx, triggerType, eventID, execution.CreatedBy, repoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &PipelineExecutionPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   triggerType,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PipelineExecutionSegment: PipelineExecutionSegment{
+					Pipeline: PipelineInfo{
+						ID:         pipeline.ID,
+						Identifier: pipeline.Identifier,
+						ConfigPath: pipeline.ConfigPath,
+					},
+					Execution: PipelineExecutionInfo{
+						Number:   execution.Number,
+						Status:   status,
+						Event:    execution.Event,
+						Trigger:  execution.Trigger,
+						Ref:      execution.Ref,
+						SHA:      execution.After,
+						Started:  execution.Started,
+						Finished: execution.Finished,
+						URL: s.urlProvider.GenerateUIBuildURL(ctx, repo.Path,
+							pipeline.Identifier, execution.Number),
+					},
+				},
+			}, nil
+		})
+}
@@ -473,18 +473,9 @@ func (s *Service) handleEventPullReqLabelAssigned(
 			targetRepo,
 			_ *types.Repository,
 		) (any, error) {
-			label, err := s.labelStore.FindByID(ctx, event.Payload.LabelID)
+			labelInfo, err := s.labelInfoForEvent(ctx, event.Payload.LabelID, event.Payload.ValueID)
 			if err != nil {
-				return nil, fmt.Errorf("failed to find label by id: %w", err)
-			}
-
-			var labelValue *string
-			if event.Payload.ValueID != nil {
-				value, err := s.labelValueStore.FindByID(ctx, *event.Payload.ValueID)
-				if err != nil {
-					return nil, fmt.Errorf("failed to find label value by id: %d %w", *event.Payload.ValueID, err)
-				}
-				labelValue = &value.Value
+				return nil, err
 			}
 
 			targetRepoInfo := repositoryInfoFrom(ctx, targetRepo, s.urlProvider)
@@ -498,17 +489,70 @@ func (s *Service) handleEventPullReqLabelAssigned(
 					PullReq: pullReqInfoFrom(ctx, pr, targetRepo, s.urlProvider),
 				},
 				PullReqLabelSegment: PullReqLabelSegment{
-					LabelInfo: LabelInfo{
-						ID:      event.Payload.LabelID,
-						Key:     label.Key,
-						ValueID: event.Payload.ValueID,
-						Value:   labelValue,
-					},
+					LabelInfo: labelInfo,
+				},
+			}, nil
+		})
+}
+
+// PullReqLabelRemovedPayload describes the body of the pullreq label removal trigger.
+// Note: same as payload for label assigned.
+type PullReqLabelRemovedPayload PullReqLabelAssignedPayload
+
+// handleEventPullReqLabelUnassigned handles label unassigned events for pull requests
+// and triggers pullreq label removed webhooks for the target repo.
+func (s *Service) handleEventPullReqLabelUnassigned(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.LabelUnassignedPayload],
+) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqLabelRemoved,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, _ *types.Repository) (any, error) {
+			labelInfo, err := s.labelInfoForEvent(ctx, event.Payload.LabelID, event.Payload.ValueID)
+			if err != nil {
+				return nil, err
+			}
+
+			return &PullReqLabelRemovedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerPullReqLabelRemoved,
+					Repo:      repositoryInfoFrom(ctx, targetRepo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(ctx, pr, targetRepo, s.urlProvider),
+				},
+				PullReqLabelSegment: PullReqLabelSegment{
+					LabelInfo: labelInfo,
 				},
 			}, nil
 		})
 }
 
+// labelInfoForEvent finds the label and the optional label value referenced by a label event.
+func (s *Service) labelInfoForEvent(ctx context.Context, labelID int64, valueID *int64) (LabelInfo, error) {
+	label, err := s.labelStore.FindByID(ctx, labelID)
+	if err != nil {
+		return LabelInfo{}, fmt.Errorf("failed to find label by id: %w", err)
+	}
+
+	var labelValue *string
+	if valueID != nil {
+		value, err := s.labelValueStore.FindByID(ctx, *valueID)
+		if err != nil {
+			return LabelInfo{}, fmt.Errorf("failed to find label value by id: %d %w", *valueID, err)
+		}
+		labelValue = &value.Value
+	}
+
+	return LabelInfo{
+		ID:      labelID,
+		Key:     label.Key,
+		ValueID: valueID,
+		Value:   labelValue,
+	}, nil
+}
+
 // PullReqUpdatedPayload describes the body of the pullreq updated trigger.
 type PullReqUpdatedPayload struct {
 	BaseSegment
@@ -647,6 +691,7 @@ type PullReqReviewSubmittedPayload struct {
 	PullReqTargetReferenceSegment
 	ReferenceSegment
 	PullReqReviewSegment
+	Comments []PullReqCommentSegment `json:"comments,omitempty"`
 }
 
 // handleEventPullReqReviewSubmitted handles review events for pull requests
@@ -674,6 +719,29 @@ func (s *Service) handleEventPullReqReviewSubmitted(
 				return nil, fmt.Errorf("failed to get reviewer by id for reviewer id %d: %w", event.Payload.ReviewerID, err)
 			}
 
+			comments := make([]PullReqCommentSegment, 0, len(event.Payload.CommentIDs))
+			for _, id := range event.Payload.CommentIDs {
+				activity, err := s.activityStore.Find(ctx, id)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get activity by id for activity id %d: %w", id, err)
+				}
+				if activity.Deleted != nil {
+					continue
+				}
+
+				comments = append(comments, PullReqCommentSegment{
+					CommentInfo: CommentInfo{
+						ID:       activity.ID,
+						ParentID: activity.ParentID,
+						Text:     activity.Text,
+						Created:  activity.Created,
+						Updated:  activity.Updated,
+						Kind:     activity.Kind,
+					},
+					CodeCommentInfo: extractCodeCommentInfoIfAvailable(activity),
+				})
+			}
+
 			return &PullReqReviewSubmittedPayload{
 				BaseSegment: BaseSegment{
 					Trigger:   enum.WebhookTriggerPullReqReviewSubmitted,
@@ -699,6 +767,7 @@ func (s *Service) handleEventPullReqReviewSubmitted(
 					ReviewDecision: event.Payload.Decision,
 					ReviewerInfo:   principalInfoFrom(reviewer.ToPrincipalInfo()),
 				},
+				Comments: comments,
 			}, nil
 		})
 }
@@ -771,3 +840,61 @@ func (s *Service) handleEventPullReqTargetBranchChanged(
 			}, nil
 		})
 }
+
+// PullReqReviewerPayload describes the body of the pullreq reviewer added and removed triggers.
+type PullReqReviewerPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqReviewerSegment
+}
+
+// handleEventPullReqReviewerAdded handles reviewer added events for pull requests
+// and triggers pullreq reviewer added webhooks for the target repo.
+func (s *Service) handleEventPullReqReviewerAdded(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerAddedPayload],
+) error {
+	return s.triggerForPullReqReviewerEvent(ctx, enum.WebhookTriggerPullReqReviewerAdded,
+		event.ID, event.Payload.Base, event.Payload.ReviewerID)
+}
+
+// handleEventPullReqReviewerRemoved handles reviewer removed events for pull requests
+// and triggers pullreq reviewer removed webhooks for the target repo.
+func (s *Service) handleEventPullReqReviewerRemoved(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerRemovedPayload],
+) error {
+	return s.triggerForPullReqReviewerEvent(ctx, enum.WebhookTriggerPullReqReviewerRemoved,
+		event.ID, event.Payload.Base, event.Payload.ReviewerID)
+}
+
+func (s *Service) triggerForPullReqReviewerEvent(
+	ctx context.Context,
+	triggerType enum.WebhookTrigger,
+	eventID string,
+	base pullreqevents.Base,
+	reviewerID int64,
+) error {
+	return s.triggerForEventWithPullReq(ctx, triggerType,
+		eventID, base.PrincipalID, base.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, _ *types.Repository) (any, error) {
+			reviewer, err := s.WebhookExecutor.FindPrincipalForEvent(ctx, reviewerID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get reviewer by id for reviewer id %d: %w", reviewerID, err)
+			}
+
+			return &PullReqReviewerPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   triggerType,
+					Repo:      repositoryInfoFrom(ctx, targetRepo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(ctx, pr, targetRepo, s.urlProvider),
+				},
+				PullReqReviewerSegment: PullReqReviewerSegment{
+					ReviewerInfo: principalInfoFrom(reviewer.ToPrincipalInfo()),
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoCreatedPayload describes the body of the repo created trigger.
+type RepoCreatedPayload struct {
+	BaseSegment
+	RepoVisibilitySegment
+}
+
+// handleEventRepoCreated handles created events for repositories
+// and triggers repo created webhooks for the repo and its parent spaces.
+func (s *Service) handleEventRepoCreated(
+	ctx context.Context,
+	event *events.Event[*repoevents.CreatedPayload],
+) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoCreated,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &RepoCreatedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoCreated,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				RepoVisibilitySegment: RepoVisibilitySegment{
+					IsPublic: event.Payload.IsPublic,
+				},
+			}, nil
+		})
+}
+
+// RepoDeletedPayload describes the body of the repo deleted trigger.
+type RepoDeletedPayload struct {
+	BaseSegment
+	RepoDeletedSegment
+}
+
+// handleEventRepoSoftDeleted handles soft deleted events for repositories
+// and triggers repo deleted webhooks for the repo and its parent spaces.
+// NOTE: The soft delete is what users perceive as deletion, the later purge isn't exposed via webhooks.
+func (s *Service) handleEventRepoSoftDeleted(
+	ctx context.Context,
+	event *events.Event[*repoevents.SoftDeletedPayload],
+) error {
+	principal, err := s.WebhookExecutor.FindPrincipalForEvent(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return err
+	}
+
+	repo, err := s.repoStore.FindDeleted(ctx, event.Payload.RepoID, &event.Payload.Deleted)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// the repo got restored or purged in the meantime
+		return events.NewDiscardEventErrorf("deleted repo with id '%d' doesn't exist anymore", event.Payload.RepoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get deleted repo for id '%d': %w", event.Payload.RepoID, err)
+	}
+
+	body := &RepoDeletedPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerRepoDeleted,
+			Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+			Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+		},
+		RepoDeletedSegment: RepoDeletedSegment{
+			Deleted: event.Payload.Deleted,
+		},
+	}
+
+	parents, err := s.getParentInfoForRepo(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook parent info: %w", err)
+	}
+
+	return s.WebhookExecutor.TriggerForEvent(ctx, event.ID, parents, enum.WebhookTriggerRepoDeleted, body)
+}
+
+// RepoRenamedPayload describes the body of the repo renamed trigger.
+type RepoRenamedPayload struct {
+	BaseSegment
+	RepoRenamedSegment
+}
+
+// handleEventRepoRenamed handles renamed events for repositories
+// and triggers repo renamed webhooks for the repo and its (new) parent spaces.
+func (s *Service) handleEventRepoRenamed(
+	ctx context.Context,
+	event *events.Event[*repoevents.RenamedPayload],
+) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoRenamed,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &RepoRenamedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoRenamed,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				RepoRenamedSegment: RepoRenamedSegment{
+					OldIdentifier: event.Payload.OldIdentifier,
+					OldPath:       event.Payload.OldPath,
+				},
+			}, nil
+		})
+}
+
+// RepoVisibilityChangedPayload describes the body of the repo visibility changed trigger.
+type RepoVisibilityChangedPayload struct {
+	BaseSegment
+	RepoVisibilitySegment
+	RepoVisibilityChangedSegment
+}
+
+// handleEventRepoPublicAccessChanged handles public access changed events for repositories
+// and triggers repo visibility changed webhooks for the repo and its parent spaces.
+func (s *Service) handleEventRepoPublicAccessChanged(
+	ctx context.Context,
+	event *events.Event[*repoevents.PublicAccessChangedPayload],
+) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoVisibilityChanged,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &RepoVisibilityChangedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoVisibilityChanged,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				RepoVisibilitySegment: RepoVisibilitySegment{
+					IsPublic: event.Payload.NewIsPublic,
+				},
+				RepoVisibilityChangedSegment: RepoVisibilityChangedSegment{
+					OldIsPublic: event.Payload.OldIsPublic,
+				},
+			}, nil
+		})
+}
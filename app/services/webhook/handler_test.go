@@ -0,0 +1,607 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPrincipalID = 1
+	testReviewerID  = 2
+	testSpaceID     = 100
+	testRepoID      = 10
+	testPullReqID   = 20
+	testLabelID     = 30
+	testValueID     = 31
+	testPipelineID  = 40
+	testDeleted     = 1700000000000
+)
+
+type testPrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (testPrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	switch id {
+	case testPrincipalID:
+		return &types.Principal{ID: id, UID: "jane", Email: "jane@example.com", Type: enum.PrincipalTypeUser}, nil
+	case testReviewerID:
+		return &types.Principal{ID: id, UID: "john", Email: "john@example.com", Type: enum.PrincipalTypeUser}, nil
+	default:
+		return nil, gitnessstore.ErrResourceNotFound
+	}
+}
+
+func testRepo() *types.Repository {
+	return &types.Repository{
+		ID:            testRepoID,
+		ParentID:      testSpaceID,
+		Identifier:    "hello",
+		Path:          "acme/hello",
+		DefaultBranch: "main",
+	}
+}
+
+type testRepoStore struct {
+	store.RepoStore
+}
+
+func (testRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	if id != testRepoID {
+		return nil, gitnessstore.ErrResourceNotFound
+	}
+	return testRepo(), nil
+}
+
+func (testRepoStore) FindDeleted(_ context.Context, id int64, deleted *int64) (*types.Repository, error) {
+	if id != testRepoID || deleted == nil || *deleted != testDeleted {
+		return nil, gitnessstore.ErrResourceNotFound
+	}
+	repo := testRepo()
+	repo.Deleted = deleted
+	return repo, nil
+}
+
+type testSpaceStore struct {
+	store.SpaceStore
+}
+
+func (testSpaceStore) GetAncestorIDs(_ context.Context, spaceID int64) ([]int64, error) {
+	return []int64{spaceID, 1}, nil
+}
+
+type testPullReqStore struct {
+	store.PullReqStore
+}
+
+func (testPullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	if id != testPullReqID {
+		return nil, gitnessstore.ErrResourceNotFound
+	}
+	return &types.PullReq{
+		ID:           id,
+		Number:       3,
+		State:        enum.PullReqStateOpen,
+		Title:        "Add feature",
+		SourceRepoID: testRepoID,
+		SourceBranch: "feature",
+		TargetRepoID: testRepoID,
+		TargetBranch: "main",
+		Author:       types.PrincipalInfo{ID: testPrincipalID, UID: "jane"},
+	}, nil
+}
+
+type testLabelStore struct {
+	store.LabelStore
+}
+
+func (testLabelStore) FindByID(_ context.Context, id int64) (*types.Label, error) {
+	return &types.Label{ID: id, Key: "priority"}, nil
+}
+
+type testLabelValueStore struct {
+	store.LabelValueStore
+}
+
+func (testLabelValueStore) FindByID(_ context.Context, id int64) (*types.LabelValue, error) {
+	return &types.LabelValue{ID: id, LabelID: testLabelID, Value: "high"}, nil
+}
+
+type testCheckStore struct {
+	store.CheckStore
+}
+
+func (testCheckStore) FindByIdentifier(
+	_ context.Context,
+	repoID int64,
+	commitSHA string,
+	identifier string,
+) (types.Check, error) {
+	if repoID != testRepoID || identifier != "lint" {
+		return types.Check{}, gitnessstore.ErrResourceNotFound
+	}
+	return types.Check{
+		RepoID:     repoID,
+		CommitSHA:  commitSHA,
+		Identifier: identifier,
+		// the status got updated after the event was reported.
+		Status:    enum.CheckStatusSuccess,
+		Summary:   "lint results",
+		Link:      "https://ci.example.com/lint",
+		CreatedBy: testPrincipalID,
+	}, nil
+}
+
+type testPipelineStore struct {
+	store.PipelineStore
+}
+
+func (testPipelineStore) Find(_ context.Context, id int64) (*types.Pipeline, error) {
+	return &types.Pipeline{ID: id, Identifier: "build", ConfigPath: ".harness/build.yaml"}, nil
+}
+
+type testExecutionStore struct {
+	store.ExecutionStore
+}
+
+func (testExecutionStore) FindByNumber(_ context.Context, pipelineID int64, num int64) (*types.Execution, error) {
+	return &types.Execution{
+		PipelineID: pipelineID,
+		Number:     num,
+		Status:     enum.CIStatusRunning,
+		Event:      enum.TriggerEventPush,
+		Ref:        "refs/heads/main",
+		After:      "abc123",
+		CreatedBy:  testPrincipalID,
+	}, nil
+}
+
+type testURLProvider struct {
+	url.Provider
+}
+
+func (testURLProvider) GenerateUIRepoURL(_ context.Context, repoPath string) string {
+	return "https://git.example.com/" + repoPath
+}
+
+func (testURLProvider) GenerateGITCloneURL(_ context.Context, repoPath string) string {
+	return "https://git.example.com/git/" + repoPath + ".git"
+}
+
+func (testURLProvider) GenerateGITCloneSSHURL(_ context.Context, repoPath string) string {
+	return "ssh://git.example.com/" + repoPath + ".git"
+}
+
+func (testURLProvider) GenerateUIPRURL(_ context.Context, repoPath string, prID int64) string {
+	return fmt.Sprintf("https://git.example.com/%s/pulls/%d", repoPath, prID)
+}
+
+func (testURLProvider) GenerateUIBuildURL(
+	_ context.Context,
+	repoPath, pipelineIdentifier string,
+	seqNumber int64,
+) string {
+	return fmt.Sprintf("https://git.example.com/%s/pipelines/%s/executions/%d",
+		repoPath, pipelineIdentifier, seqNumber)
+}
+
+type testWebhookURLProvider struct {
+	url string
+}
+
+func (p testWebhookURLProvider) GetWebhookURL(context.Context, *types.WebhookCore) (string, error) {
+	return p.url, nil
+}
+
+// testWebhookExecutorStore returns a webhook for every trigger and records all executions.
+type testWebhookExecutorStore struct {
+	mx         sync.Mutex
+	parents    []types.WebhookParentInfo
+	executions []*types.WebhookExecutionCore
+}
+
+func (s *testWebhookExecutorStore) Find(context.Context, int64) (*types.WebhookExecutionCore, error) {
+	return nil, gitnessstore.ErrResourceNotFound
+}
+
+func (s *testWebhookExecutorStore) ListWebhooks(
+	_ context.Context,
+	parents []types.WebhookParentInfo,
+) ([]*types.WebhookCore, error) {
+	s.parents = parents
+
+	// one webhook per trigger, only the one registered for the trigger of the event gets executed.
+	webhooks := make([]*types.WebhookCore, len(enum.WebhookTrigger("").Enum()))
+	for i, trigger := range enum.WebhookTrigger("").Enum() {
+		webhooks[i] = &types.WebhookCore{
+			ID:         int64(i + 1),
+			ParentType: enum.WebhookParentRepo,
+			ParentID:   testRepoID,
+			Identifier: string(trigger.(enum.WebhookTrigger)),
+			Enabled:    true,
+			Triggers:   []enum.WebhookTrigger{trigger.(enum.WebhookTrigger)},
+		}
+	}
+
+	return webhooks, nil
+}
+
+func (s *testWebhookExecutorStore) UpdateOptLock(
+	_ context.Context,
+	hook *types.WebhookCore,
+	_ *types.WebhookExecutionCore,
+) (*types.WebhookCore, error) {
+	return hook, nil
+}
+
+func (s *testWebhookExecutorStore) FindWebhook(context.Context, int64) (*types.WebhookCore, error) {
+	return nil, gitnessstore.ErrResourceNotFound
+}
+
+func (s *testWebhookExecutorStore) ListForTrigger(context.Context, string) ([]*types.WebhookExecutionCore, error) {
+	return nil, nil
+}
+
+func (s *testWebhookExecutorStore) CreateWebhookExecution(
+	_ context.Context,
+	execution *types.WebhookExecutionCore,
+) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.executions = append(s.executions, execution)
+	return nil
+}
+
+func newHandlerTest(t *testing.T) (*Service, *testWebhookExecutorStore, *[]string) {
+	t.Helper()
+
+	var mx sync.Mutex
+	var triggerHeaders []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mx.Lock()
+		defer mx.Unlock()
+		triggerHeaders = append(triggerHeaders, r.Header.Get("X-Gitness-Trigger"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	config := Config{
+		UserAgentIdentity: "Gitness",
+		HeaderIdentity:    "Gitness",
+		AllowLoopback:     true,
+	}
+	executorStore := &testWebhookExecutorStore{}
+	principalStore := testPrincipalStore{}
+
+	return &Service{
+		WebhookExecutor: NewWebhookExecutor(config, testWebhookURLProvider{url: srv.URL}, nil, nil, nil,
+			principalStore, executorStore, RepoTrigger),
+		urlProvider:     testURLProvider{},
+		spaceStore:      testSpaceStore{},
+		repoStore:       testRepoStore{},
+		pullreqStore:    testPullReqStore{},
+		principalStore:  principalStore,
+		labelStore:      testLabelStore{},
+		labelValueStore: testLabelValueStore{},
+		config:          config,
+		checkStore:      testCheckStore{},
+		pipelineStore:   testPipelineStore{},
+		executionStore:  testExecutionStore{},
+	}, executorStore, &triggerHeaders
+}
+
+// jsonValue returns the value at the dot separated path of the decoded JSON payload.
+func jsonValue(payload map[string]any, path string) any {
+	var v any = payload
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+func TestHandleEvents(t *testing.T) {
+	valueID := int64(testValueID)
+	prBase := pullreqevents.Base{
+		PullReqID:    testPullReqID,
+		SourceRepoID: testRepoID,
+		TargetRepoID: testRepoID,
+		PrincipalID:  testPrincipalID,
+		Number:       3,
+	}
+	repoBase := repoevents.Base{RepoID: testRepoID, PrincipalID: testPrincipalID}
+
+	tests := []struct {
+		name     string
+		trigger  enum.WebhookTrigger
+		handle   func(ctx context.Context, s *Service, eventID string) error
+		expected map[string]any
+	}{
+		{
+			name:    "repo created",
+			trigger: enum.WebhookTriggerRepoCreated,
+			handle: func(ctx context.Context, s *Service, eventID string) error {
+				return s.handleEventRepoCreated(ctx, &events.Event[*repoevents.CreatedPayload]{
+					ID:      eventID,
+					Payload: &repoevents.CreatedPayload{Base: repoBase, IsPublic: true},
+				})
+			},
+			expected: map[string]any{
+				"is_public": true,
+			},
+		},
+		{
+			name:    "repo deleted",
+			trigger: enum.WebhookTriggerRepoDeleted,
+			handle: func(ctx context.Context, s *Service, eventID string) error {
+				return s.handleEventRepoSoftDeleted(ctx, &events.Event[*repoevents.SoftDeletedPayload]{
+					ID: eventID,
+					Payload: &repoevents.SoftDeletedPayload{
+						Base:     repoBase,
+						RepoPath: "acme/hello",
+						Deleted:  testDeleted,
+					},
+				})
+			},
+			expected: map[string]any{
+				"deleted": float64(testDeleted),
+			},
+		},
+		{
+			name:    "repo renamed",
+			trigger: enum.WebhookTriggerRepoRenamed,
+			handle: func(ctx context.Context, s *Service, eventID string) error {
+				return s.handleEventRepoRenamed(ctx, &events.Event[*repoevents.RenamedPayload]{
+					ID: eventID,
+					Payload: &repoevents.RenamedPayload{
+						Base:          repoBase,
+						OldIdentifier: "hi",
+						NewIdentifier: "hello",
+						OldPath:       "acme/hi",
+						NewPath:       "acme/hello",
+					},
+				})
+			},
+			expected: map[string]any{
+				"old_identifier": "hi",
+				"old_path":       "acme/hi",
+			},
+		},
+		{
+			name:    "repo visibility changed",
+			trigger: enum.WebhookTriggerRepoVisibilityChanged,
+			handle: func(ctx context.Context, s *Service, eventID string) error {
+				return s.handleEventRepoPublicAccessChanged(ctx, &events.Event[*repoevents.PublicAccessChangedPayload]{
+					ID:      eventID,
+					Payload: &repoevents.PublicAccessChangedPayload{Base: repoBase, OldIsPublic: true},
+				})
+			},
+			expected: map[string]any{
+				"is_public":     false,
+				"old_is_public": true,
+			},
+		},
+		{
+			name:    "check status updated",
+			trigger: enum.WebhookTriggerCheckStatusUpdated,
+			handle: func(ctx context.Context, s *Service, eventID string) error {
+				return s.handleEventCheckReported(ctx, &events.Event[*checkevents.ReportedPayload]{
+					ID: eventID,
+					Payload: &checkevents.ReportedPayload{
+						Base:       checkevents.Base{RepoID: testRepoID, SHA: "abc123"},
+						Identifier: "lint",
+						Status:     enum.CheckStatusFailure,
+					},
+				})
+			},
+			expected: map[string]any{
+				"check.identifier": "lint",
+				"check.status":     string(enum.CheckStatusFailure),
+				"check.commit_sha": "abc123",
+				"check.summary":    "lint results",
+				"check.link":       "https://ci.example.com/lint",
+			},
+		},
+		{
+			name:    "pipeline execution started",
+			trigger: enum.WebhookTriggerPipelineExecutionStarted,
+			handle: func(ctx context.Context, s *Service, eventID string) error {
+				return s.handleEventPipelineExecutionStarted(ctx, &events.Event[*pipelineevents.ExecutionStartedPayload]{
+					ID: eventID,
+					Payload: &pipelineevents.ExecutionStartedPayload{
+						PipelineID:   testPipelineID,
+						RepoID:       testRepoID,
+						ExecutionNum: 5,
+					},
+				})
+			},
+			expected: map[string]any{
+				"pipeline.identifier":  "build",
+				"pipeline.config_path": ".harness/build.yaml",
+				"execution.number":     float64(5),
+				"execution.status":     string(enum.CIStatusRunning),
+				"execution.sha":        "abc123",
+				"execution.url":        "https://git.example.com/acme/hello/pipelines/build/executions/5",
+			},
+		},
+		{
+			name:    "pipeline execution finished",
+			trigger: enum.WebhookTriggerPipelineExecutionFinished,
+			handle: func(ctx context.Context, s *Service, eventID string) error {
+				return s.handleEventPipelineExecuted(ctx, &events.Event[*pipelineevents.ExecutedPayload]{
+					ID: eventID,
+					Payload: &pipelineevents.ExecutedPayload{
+						PipelineID:   testPipelineID,
+						RepoID:       testRepoID,
+						ExecutionNum: 5,
+						Status:       enum.CIStatusSuccess,
+					},
+				})
+			},
+			expected: map[string]any{
+				"pipeline.identifier": "build",
+				"execution.number":    float64(5),
+				"execution.status":    string(enum.CIStatusSuccess),
+			},
+		},
+		{
+			name:    "pullreq reviewer added",
+			trigger: enum.WebhookTriggerPullReqReviewerAdded,
+			handle: func(ctx context.Context, s *Service, eventID string) error {
+				return s.handleEventPullReqReviewerAdded(ctx, &events.Event[*pullreqevents.ReviewerAddedPayload]{
+					ID:      eventID,
+					Payload: &pullreqevents.ReviewerAddedPayload{Base: prBase, ReviewerID: testReviewerID},
+				})
+			},
+			expected: map[string]any{
+				"pull_req.number": float64(3),
+				"reviewer.id":     float64(testReviewerID),
+				"reviewer.uid":    "john",
+			},
+		},
+		{
+			name:    "pullreq reviewer removed",
+			trigger: enum.WebhookTriggerPullReqReviewerRemoved,
+			handle: func(ctx context.Context, s *Service, eventID string) error {
+				return s.handleEventPullReqReviewerRemoved(ctx, &events.Event[*pullreqevents.ReviewerRemovedPayload]{
+					ID:      eventID,
+					Payload: &pullreqevents.ReviewerRemovedPayload{Base: prBase, ReviewerID: testReviewerID},
+				})
+			},
+			expected: map[string]any{
+				"pull_req.number": float64(3),
+				"reviewer.uid":    "john",
+			},
+		},
+		{
+			name:    "pullreq label removed",
+			trigger: enum.WebhookTriggerPullReqLabelRemoved,
+			handle: func(ctx context.Context, s *Service, eventID string) error {
+				return s.handleEventPullReqLabelUnassigned(ctx, &events.Event[*pullreqevents.LabelUnassignedPayload]{
+					ID: eventID,
+					Payload: &pullreqevents.LabelUnassignedPayload{
+						Base:    prBase,
+						LabelID: testLabelID,
+						ValueID: &valueID,
+					},
+				})
+			},
+			expected: map[string]any{
+				"pull_req.number": float64(3),
+				"label.id":        float64(testLabelID),
+				"label.key":       "priority",
+				"label.value_id":  float64(testValueID),
+				"label.value":     "high",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, executorStore, triggerHeaders := newHandlerTest(t)
+
+			require.NoError(t, test.handle(ctx, s, "event-1"))
+
+			// the webhooks of the repo and all of its ancestor spaces are considered.
+			require.Equal(t, []types.WebhookParentInfo{
+				{ID: testRepoID, Type: enum.WebhookParentRepo},
+				{ID: testSpaceID, Type: enum.WebhookParentSpace},
+				{ID: 1, Type: enum.WebhookParentSpace},
+			}, executorStore.parents)
+
+			require.Len(t, executorStore.executions, 1)
+			execution := executorStore.executions[0]
+			require.Equal(t, test.trigger, execution.TriggerType)
+			require.Equal(t, generateTriggerIDFromEventID("event-1"), execution.TriggerID)
+			require.Equal(t, enum.WebhookExecutionResultSuccess, execution.Result)
+			require.Equal(t, []string{string(test.trigger)}, *triggerHeaders)
+
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(execution.Request.Body), &payload))
+
+			require.Equal(t, string(test.trigger), payload["trigger"])
+			require.Equal(t, float64(testRepoID), jsonValue(payload, "repo.id"))
+			require.Equal(t, "acme/hello", jsonValue(payload, "repo.path"))
+			require.Equal(t, "jane", jsonValue(payload, "principal.uid"))
+			for path, value := range test.expected {
+				require.Equal(t, value, jsonValue(payload, path), path)
+			}
+		})
+	}
+}
+
+// errDiscardEvent matches any discard event error returned by the handlers.
+var errDiscardEvent = events.NewDiscardEventError(nil)
+
+func TestHandleEventsDiscardMissingResources(t *testing.T) {
+	ctx := context.Background()
+	s, executorStore, _ := newHandlerTest(t)
+
+	err := s.handleEventRepoSoftDeleted(ctx, &events.Event[*repoevents.SoftDeletedPayload]{
+		ID: "event-1",
+		Payload: &repoevents.SoftDeletedPayload{
+			Base:    repoevents.Base{RepoID: testRepoID, PrincipalID: testPrincipalID},
+			Deleted: testDeleted + 1,
+		},
+	})
+	require.True(t, errors.Is(err, errDiscardEvent), "restored repo: %v", err)
+
+	err = s.handleEventCheckReported(ctx, &events.Event[*checkevents.ReportedPayload]{
+		ID: "event-2",
+		Payload: &checkevents.ReportedPayload{
+			Base:       checkevents.Base{RepoID: testRepoID, SHA: "abc123"},
+			Identifier: "unknown",
+			Status:     enum.CheckStatusSuccess,
+		},
+	})
+	require.True(t, errors.Is(err, errDiscardEvent), "missing check: %v", err)
+
+	err = s.handleEventPullReqReviewerAdded(ctx, &events.Event[*pullreqevents.ReviewerAddedPayload]{
+		ID: "event-3",
+		Payload: &pullreqevents.ReviewerAddedPayload{
+			Base:       pullreqevents.Base{PullReqID: testPullReqID, PrincipalID: testPrincipalID},
+			ReviewerID: 99,
+		},
+	})
+	require.Error(t, err, "missing reviewer")
+
+	require.Empty(t, executorStore.executions)
+}
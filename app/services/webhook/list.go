@@ -67,30 +67,42 @@ func (s *Service) getParentInfoRepo(
 	repoID int64,
 	inherited bool,
 ) ([]types.WebhookParentInfo, error) {
-	var parents []types.WebhookParentInfo
-
-	parents = append(parents, types.WebhookParentInfo{
-		ID:   repoID,
-		Type: enum.WebhookParentRepo,
-	})
-
 	if inherited {
 		repo, err := s.repoStore.Find(ctx, repoID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get repo: %w", err)
 		}
 
-		ids, err := s.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get parent space ids: %w", err)
-		}
+		return s.getParentInfoForRepo(ctx, repo)
+	}
 
-		for _, id := range ids {
-			parents = append(parents, types.WebhookParentInfo{
-				Type: enum.WebhookParentSpace,
-				ID:   id,
-			})
-		}
+	return []types.WebhookParentInfo{{
+		ID:   repoID,
+		Type: enum.WebhookParentRepo,
+	}}, nil
+}
+
+// getParentInfoForRepo returns the parent info of the provided repo and all of its ancestor spaces.
+// NOTE: the repo isn't looked up again, which allows using it for deleted repos as well.
+func (s *Service) getParentInfoForRepo(
+	ctx context.Context,
+	repo *types.Repository,
+) ([]types.WebhookParentInfo, error) {
+	parents := []types.WebhookParentInfo{{
+		ID:   repo.ID,
+		Type: enum.WebhookParentRepo,
+	}}
+
+	ids, err := s.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent space ids: %w", err)
+	}
+
+	for _, id := range ids {
+		parents = append(parents, types.WebhookParentInfo{
+			Type: enum.WebhookParentSpace,
+			ID:   id,
+		})
 	}
 
 	return parents, nil
@@ -0,0 +1,327 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+const (
+	// webhookMaxPayloadTemplateLength defines the max allowed length of a webhook payload template.
+	webhookMaxPayloadTemplateLength = 65536
+	// webhookMaxExtraHeaders defines the max allowed number of extra headers of a webhook.
+	webhookMaxExtraHeaders = 32
+	// webhookMaxExtraHeaderLength defines the max allowed length of an extra header value.
+	webhookMaxExtraHeaderLength = 4096
+)
+
+// reservedHeaders are headers that are set by the transport and can't be provided as extra headers.
+var reservedHeaders = map[string]struct{}{
+	"Host":              {},
+	"Content-Length":    {},
+	"Transfer-Encoding": {},
+	"Connection":        {},
+}
+
+// CheckPayloadTemplate validates the payload template of a webhook.
+func CheckPayloadTemplate(payloadTemplate *types.WebhookPayloadTemplate) error {
+	if payloadTemplate == nil {
+		return nil
+	}
+
+	if _, ok := payloadTemplate.Type.Sanitize(); !ok {
+		return check.NewValidationErrorf("The provided payload template type '%s' is invalid.", payloadTemplate.Type)
+	}
+
+	if len(payloadTemplate.Template) > webhookMaxPayloadTemplateLength {
+		return check.NewValidationErrorf("The payload template of a webhook can be at most %d characters long.",
+			webhookMaxPayloadTemplateLength)
+	}
+
+	if _, err := parsePayloadTemplate(payloadTemplate); err != nil {
+		return check.NewValidationErrorf("The provided payload template is invalid: %s", err)
+	}
+
+	return nil
+}
+
+// CheckExtraHeaders validates the extra headers of a webhook.
+// Headers with the headerPrefix are reserved for the headers set by the webhook itself (e.g. the signature).
+func CheckExtraHeaders(headers []types.ExtraHeader, headerPrefix string) error {
+	if len(headers) > webhookMaxExtraHeaders {
+		return check.NewValidationErrorf("A webhook can have at most %d extra headers.", webhookMaxExtraHeaders)
+	}
+
+	for _, h := range headers {
+		if !httpguts.ValidHeaderFieldName(h.Key) {
+			return check.NewValidationErrorf("The header name '%s' is invalid.", h.Key)
+		}
+
+		if !httpguts.ValidHeaderFieldValue(h.Value) {
+			return check.NewValidationErrorf("The value of the header '%s' is invalid.", h.Key)
+		}
+
+		if len(h.Value) > webhookMaxExtraHeaderLength {
+			return check.NewValidationErrorf("The value of a header can be at most %d characters long.",
+				webhookMaxExtraHeaderLength)
+		}
+
+		key := http.CanonicalHeaderKey(h.Key)
+		if _, ok := reservedHeaders[key]; ok ||
+			strings.HasPrefix(key, http.CanonicalHeaderKey(headerPrefix)) {
+			return check.NewValidationErrorf("The header '%s' can't be overwritten.", h.Key)
+		}
+	}
+
+	return nil
+}
+
+// payloadTemplater transforms the JSON serialized default payload of a webhook into a custom request body.
+type payloadTemplater interface {
+	render(payload []byte) ([]byte, error)
+}
+
+func parsePayloadTemplate(payloadTemplate *types.WebhookPayloadTemplate) (payloadTemplater, error) {
+	switch payloadTemplate.Type {
+	case enum.WebhookPayloadTemplateTypeGoTemplate:
+		tmpl, err := template.New("payload").
+			Funcs(template.FuncMap{"toJSON": templateToJSON}).
+			Option("missingkey=zero").
+			Parse(payloadTemplate.Template)
+		if err != nil {
+			return nil, err
+		}
+		return goPayloadTemplate{tmpl: tmpl}, nil
+
+	case enum.WebhookPayloadTemplateTypeJSONPath:
+		var doc any
+		if err := json.Unmarshal([]byte(payloadTemplate.Template), &doc); err != nil {
+			return nil, fmt.Errorf("template is not valid JSON: %w", err)
+		}
+		// evaluate all expressions against no data to ensure they can be parsed.
+		if _, err := replaceJSONPaths(copyJSON(doc), nil); err != nil {
+			return nil, err
+		}
+		return jsonPathPayloadTemplate{doc: doc}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown payload template type '%s'", payloadTemplate.Type)
+	}
+}
+
+// renderPayloadTemplate transforms the JSON serialized default payload using the payload template.
+func renderPayloadTemplate(payloadTemplate *types.WebhookPayloadTemplate, payload []byte) ([]byte, error) {
+	templater, err := parsePayloadTemplate(payloadTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse payload template: %w", err)
+	}
+
+	return templater.render(payload)
+}
+
+func templateToJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// goPayloadTemplate renders the request body with a Go text template.
+// The template data is the default payload decoded into generic JSON values,
+// so the fields are referenced by their JSON names, e.g. {{.pull_req.title}}.
+// Optional fields can be defaulted using {{or .pull_req.merge_strategy "none"}}.
+type goPayloadTemplate struct {
+	tmpl *template.Template
+}
+
+func (t goPayloadTemplate) render(payload []byte) ([]byte, error) {
+	var data any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := t.tmpl.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute payload template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// jsonPathPayloadTemplate builds the request body from a JSON document in which every string value
+// that is a JSONPath expression (starts with "$") is replaced with the value it selects from the default payload.
+// Expressions that don't select anything are replaced with null.
+// Supported is the dot and the bracket notation with member names and array indexes, e.g. $.commits[0].sha.
+type jsonPathPayloadTemplate struct {
+	doc any
+}
+
+func (t jsonPathPayloadTemplate) render(payload []byte) ([]byte, error) {
+	var data any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	// the document is shared, work on a copy.
+	doc := copyJSON(t.doc)
+	doc, err := replaceJSONPaths(doc, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+type jsonPathStep struct {
+	key   string
+	index int
+	isKey bool
+}
+
+func replaceJSONPaths(doc any, data any) (any, error) {
+	switch v := doc.(type) {
+	case string:
+		if !strings.HasPrefix(v, "$") {
+			return v, nil
+		}
+		steps, err := parseJSONPath(v)
+		if err != nil {
+			return nil, err
+		}
+		return evalJSONPath(data, steps), nil
+	case map[string]any:
+		for key, value := range v {
+			replaced, err := replaceJSONPaths(value, data)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = replaced
+		}
+		return v, nil
+	case []any:
+		for i, value := range v {
+			replaced, err := replaceJSONPaths(value, data)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = replaced
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+//nolint:gocognit // it's a small hand written parser, splitting it doesn't make it more readable.
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("JSONPath '%s' must start with '$'", path)
+	}
+
+	var steps []jsonPathStep
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("JSONPath '%s' has an empty member name", path)
+			}
+			steps = append(steps, jsonPathStep{key: rest[:end], isKey: true})
+			rest = rest[end:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("JSONPath '%s' has an unclosed bracket", path)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				steps = append(steps, jsonPathStep{key: inner[1 : len(inner)-1], isKey: true})
+				continue
+			}
+
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("JSONPath '%s' has an invalid array index '%s'", path, inner)
+			}
+			steps = append(steps, jsonPathStep{index: index})
+
+		default:
+			return nil, fmt.Errorf("JSONPath '%s' has an unexpected character '%c'", path, rest[0])
+		}
+	}
+
+	return steps, nil
+}
+
+func evalJSONPath(data any, steps []jsonPathStep) any {
+	current := data
+	for _, step := range steps {
+		switch v := current.(type) {
+		case map[string]any:
+			if !step.isKey {
+				return nil
+			}
+			current = v[step.key]
+		case []any:
+			if step.isKey || step.index >= len(v) {
+				return nil
+			}
+			current = v[step.index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+func copyJSON(doc any) any {
+	switch v := doc.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[key] = copyJSON(value)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, value := range v {
+			s[i] = copyJSON(value)
+		}
+		return s
+	default:
+		return v
+	}
+}
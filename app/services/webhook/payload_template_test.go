@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testPayload = `{"trigger":"pullreq_created","pull_req":{"number":7,"title":"Fix \"bug\""},` +
+	`"commits":[{"sha":"abc"},{"sha":"def"}]}`
+
+func TestRenderPayloadTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmpl     types.WebhookPayloadTemplate
+		expected string
+	}{
+		{
+			name: "go template",
+			tmpl: types.WebhookPayloadTemplate{
+				Type:     enum.WebhookPayloadTemplateTypeGoTemplate,
+				Template: `{"text":{{toJSON .pull_req.title}},"number":{{.pull_req.number}},"missing":"{{or .nope "none"}}"}`,
+			},
+			expected: `{"text":"Fix \"bug\"","number":7,"missing":"none"}`,
+		},
+		{
+			name: "jsonpath",
+			tmpl: types.WebhookPayloadTemplate{
+				Type: enum.WebhookPayloadTemplateTypeJSONPath,
+				Template: `{"event":"$.trigger","pr":{"id":"$['pull_req'].number"},` +
+					`"shas":["$.commits[1].sha","$.commits[5].sha"],"static":"value"}`,
+			},
+			expected: `{"event":"pullreq_created","pr":{"id":7},"shas":["def",null],"static":"value"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, CheckPayloadTemplate(&test.tmpl))
+
+			body, err := renderPayloadTemplate(&test.tmpl, []byte(testPayload))
+			require.NoError(t, err)
+			require.JSONEq(t, test.expected, string(body))
+		})
+	}
+}
+
+func TestCheckPayloadTemplateInvalid(t *testing.T) {
+	tests := []types.WebhookPayloadTemplate{
+		{Type: "xml", Template: `<a/>`},
+		{Type: enum.WebhookPayloadTemplateTypeGoTemplate, Template: `{{.pull_req.title`},
+		{Type: enum.WebhookPayloadTemplateTypeJSONPath, Template: `{"a":`},
+		{Type: enum.WebhookPayloadTemplateTypeJSONPath, Template: `{"a":"$.commits[x]"}`},
+		{Type: enum.WebhookPayloadTemplateTypeJSONPath, Template: `{"a":"$..sha"}`},
+	}
+
+	for _, test := range tests {
+		require.Error(t, CheckPayloadTemplate(&test), test.Template)
+	}
+}
+
+func TestCheckExtraHeaders(t *testing.T) {
+	require.NoError(t, CheckExtraHeaders([]types.ExtraHeader{
+		{Key: "Authorization", Value: "Bearer token"},
+		{Key: "Content-Type", Value: "text/plain"},
+	}, "X-Gitness-"))
+
+	require.Error(t, CheckExtraHeaders([]types.ExtraHeader{{Key: "Bad Header", Value: "v"}}, "X-Gitness-"))
+	require.Error(t, CheckExtraHeaders([]types.ExtraHeader{{Key: "Key", Value: "a\nb"}}, "X-Gitness-"))
+	require.Error(t, CheckExtraHeaders([]types.ExtraHeader{{Key: "host", Value: "example.com"}}, "X-Gitness-"))
+	require.Error(t, CheckExtraHeaders([]types.ExtraHeader{{Key: "x-gitness-signature", Value: "v"}}, "X-Gitness-"))
+}
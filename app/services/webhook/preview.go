@@ -0,0 +1,344 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	previewSampleRepoIdentifier = "sample-repo"
+	previewSampleSHA            = "2f4ba5a5e1f6b36f5ec4aa2c5b0e5e2f7c0a1d3e"
+	previewSampleOldSHA         = "9b1c3d0b2ac6e1f0d4e7a1b9c8f2e3d4a5b6c7d8"
+)
+
+// Preview renders the request body a webhook of the parent resource would send for a sample event
+// of the provided trigger, using the provided payload template (or the default payload if none is provided).
+func (s *Service) Preview(
+	ctx context.Context,
+	principal *types.Principal,
+	parentResource ParentResource,
+	in *types.WebhookPreviewInput,
+) (*types.WebhookPreviewOutput, error) {
+	if _, ok := in.Trigger.Sanitize(); !ok {
+		return nil, check.NewValidationErrorf("The provided webhook trigger '%s' is invalid.", in.Trigger)
+	}
+	if err := CheckPayloadTemplate(in.PayloadTemplate); err != nil {
+		return nil, err
+	}
+
+	repo, err := s.getPreviewRepo(ctx, parentResource)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := s.samplePayload(ctx, in.Trigger, principal, repo)
+
+	// serialize the same way as the payload of an actual webhook execution.
+	buf := &bytes.Buffer{}
+	if err = json.NewEncoder(buf).Encode(payload); err != nil {
+		return nil, fmt.Errorf("failed to serialize sample payload to json: %w", err)
+	}
+
+	body := buf.Bytes()
+	if in.PayloadTemplate != nil {
+		body, err = renderPayloadTemplate(in.PayloadTemplate, body)
+		if err != nil {
+			return nil, check.NewValidationErrorf("Failed to render the payload template: %s", err)
+		}
+	}
+
+	return &types.WebhookPreviewOutput{
+		Body: string(body),
+	}, nil
+}
+
+// getPreviewRepo returns the repository used for the sample event.
+// For space webhooks a sample repository located in the space is returned.
+func (s *Service) getPreviewRepo(ctx context.Context, parentResource ParentResource) (*types.Repository, error) {
+	if parentResource.Type == enum.WebhookParentRepo {
+		repo, err := s.repoStore.Find(ctx, parentResource.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repo: %w", err)
+		}
+		return repo, nil
+	}
+
+	return &types.Repository{
+		ParentID:      parentResource.ID,
+		Identifier:    previewSampleRepoIdentifier,
+		Path:          path.Join(parentResource.Path, previewSampleRepoIdentifier),
+		Description:   "Sample repository",
+		DefaultBranch: "main",
+	}, nil
+}
+
+//nolint:funlen // one sample per payload type.
+func (s *Service) samplePayload(
+	ctx context.Context,
+	trigger enum.WebhookTrigger,
+	principal *types.Principal,
+	repo *types.Repository,
+) any {
+	now := time.Now()
+	principalInfo := principalInfoFrom(principal.ToPrincipalInfo())
+	repoInfo := repositoryInfoFrom(ctx, repo, s.urlProvider)
+
+	signature := SignatureInfo{
+		Identity: IdentityInfo{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		When: now,
+	}
+	commitInfo := CommitInfo{
+		SHA:       previewSampleSHA,
+		Message:   "Sample commit message",
+		Author:    signature,
+		Committer: signature,
+		URL:       s.urlProvider.GenerateUIRefURL(ctx, repo.Path, previewSampleSHA),
+		Added:     []string{"added.txt"},
+		Removed:   []string{},
+		Modified:  []string{"README.md"},
+	}
+
+	base := BaseSegment{
+		Trigger:   trigger,
+		Repo:      repoInfo,
+		Principal: principalInfo,
+	}
+	details := ReferenceDetailsSegment{
+		SHA:        previewSampleSHA,
+		Commit:     &commitInfo,
+		HeadCommit: &commitInfo,
+	}
+
+	switch trigger {
+	case enum.WebhookTriggerBranchCreated, enum.WebhookTriggerBranchUpdated, enum.WebhookTriggerBranchDeleted,
+		enum.WebhookTriggerTagCreated, enum.WebhookTriggerTagUpdated, enum.WebhookTriggerTagDeleted:
+		ref := gitReferenceNamePrefixBranch + "feature"
+		if trigger == enum.WebhookTriggerTagCreated || trigger == enum.WebhookTriggerTagUpdated ||
+			trigger == enum.WebhookTriggerTagDeleted {
+			ref = "refs/tags/v1.0.0"
+		}
+		return &ReferencePayload{
+			BaseSegment:             base,
+			ReferenceSegment:        ReferenceSegment{Ref: ReferenceInfo{Name: ref, Repo: repoInfo}},
+			ReferenceDetailsSegment: details,
+			ReferenceUpdateSegment:  ReferenceUpdateSegment{OldSHA: previewSampleOldSHA},
+		}
+	case enum.WebhookTriggerRepoCreated:
+		return &RepoCreatedPayload{
+			BaseSegment: base,
+		}
+	case enum.WebhookTriggerRepoDeleted:
+		return &RepoDeletedPayload{
+			BaseSegment:        base,
+			RepoDeletedSegment: RepoDeletedSegment{Deleted: now.UnixMilli()},
+		}
+	case enum.WebhookTriggerRepoRenamed:
+		return &RepoRenamedPayload{
+			BaseSegment: base,
+			RepoRenamedSegment: RepoRenamedSegment{
+				OldIdentifier: "old-" + repo.Identifier,
+				OldPath:       repo.Path + "-old",
+			},
+		}
+	case enum.WebhookTriggerRepoVisibilityChanged:
+		return &RepoVisibilityChangedPayload{
+			BaseSegment:                  base,
+			RepoVisibilitySegment:        RepoVisibilitySegment{IsPublic: true},
+			RepoVisibilityChangedSegment: RepoVisibilityChangedSegment{OldIsPublic: false},
+		}
+	case enum.WebhookTriggerCheckStatusUpdated:
+		return &CheckStatusUpdatedPayload{
+			BaseSegment: base,
+			CheckSegment: CheckSegment{
+				Check: CheckInfo{
+					Identifier: "sample-check",
+					Status:     enum.CheckStatusSuccess,
+					CommitSHA:  previewSampleSHA,
+					Summary:    "Sample check summary",
+					Started:    now.Add(-time.Minute).UnixMilli(),
+					Ended:      now.UnixMilli(),
+				},
+			},
+		}
+	case enum.WebhookTriggerPipelineExecutionStarted, enum.WebhookTriggerPipelineExecutionFinished:
+		execution := PipelineExecutionInfo{
+			Number:  1,
+			Status:  enum.CIStatusRunning,
+			Event:   enum.TriggerEventPush,
+			Trigger: principal.UID,
+			Ref:     gitReferenceNamePrefixBranch + repo.DefaultBranch,
+			SHA:     previewSampleSHA,
+			Started: now.Add(-time.Minute).UnixMilli(),
+			URL:     s.urlProvider.GenerateUIBuildURL(ctx, repo.Path, "sample-pipeline", 1),
+		}
+		if trigger == enum.WebhookTriggerPipelineExecutionFinished {
+			execution.Status = enum.CIStatusSuccess
+			execution.Finished = now.UnixMilli()
+		}
+		return &PipelineExecutionPayload{
+			BaseSegment: base,
+			PipelineExecutionSegment: PipelineExecutionSegment{
+				Pipeline:  PipelineInfo{ID: 1, Identifier: "sample-pipeline", ConfigPath: ".harness/pipeline.yaml"},
+				Execution: execution,
+			},
+		}
+	}
+
+	pr := &types.PullReq{
+		Number:       1,
+		Created:      now.UnixMilli(),
+		Updated:      now.UnixMilli(),
+		State:        enum.PullReqStateOpen,
+		Title:        "Sample pull request",
+		Description:  "Sample pull request description",
+		SourceRepoID: repo.ID,
+		SourceBranch: "feature",
+		SourceSHA:    previewSampleSHA,
+		TargetRepoID: repo.ID,
+		TargetBranch: repo.DefaultBranch,
+		MergeBaseSHA: previewSampleOldSHA,
+		Author:       *principal.ToPrincipalInfo(),
+	}
+	prSegment := PullReqSegment{PullReq: pullReqInfoFrom(ctx, pr, repo, s.urlProvider)}
+	targetRef := PullReqTargetReferenceSegment{
+		TargetRef: ReferenceInfo{Name: gitReferenceNamePrefixBranch + pr.TargetBranch, Repo: repoInfo},
+	}
+	sourceRef := ReferenceSegment{
+		Ref: ReferenceInfo{Name: gitReferenceNamePrefixBranch + pr.SourceBranch, Repo: repoInfo},
+	}
+	comment := PullReqCommentSegment{
+		CommentInfo: CommentInfo{
+			ID:      1,
+			Text:    "Sample comment",
+			Created: now.UnixMilli(),
+			Updated: now.UnixMilli(),
+			Kind:    enum.PullReqActivityKindComment,
+		},
+	}
+
+	switch trigger {
+	case enum.WebhookTriggerPullReqBranchUpdated:
+		return &PullReqBranchUpdatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			ReferenceDetailsSegment:       details,
+			ReferenceUpdateSegment:        ReferenceUpdateSegment{OldSHA: previewSampleOldSHA},
+		}
+	case enum.WebhookTriggerPullReqCommentCreated, enum.WebhookTriggerPullReqCommentUpdated:
+		return &PullReqCommentPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			ReferenceDetailsSegment:       details,
+			PullReqCommentSegment:         comment,
+		}
+	case enum.WebhookTriggerPullReqCommentStatusUpdated:
+		return &PullReqActivityStatusUpdatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			PullReqCommentSegment:         comment,
+			PullReqCommentStatusUpdatedSegment: PullReqCommentStatusUpdatedSegment{
+				Status: enum.PullReqCommentStatusResolved,
+			},
+		}
+	case enum.WebhookTriggerPullReqLabelAssigned:
+		value := "high"
+		valueID := int64(1)
+		return &PullReqLabelAssignedPayload{
+			BaseSegment:    base,
+			PullReqSegment: prSegment,
+			PullReqLabelSegment: PullReqLabelSegment{
+				LabelInfo: LabelInfo{ID: 1, Key: "priority", ValueID: &valueID, Value: &value},
+			},
+		}
+	case enum.WebhookTriggerPullReqLabelRemoved:
+		return &PullReqLabelRemovedPayload{
+			BaseSegment:    base,
+			PullReqSegment: prSegment,
+			PullReqLabelSegment: PullReqLabelSegment{
+				LabelInfo: LabelInfo{ID: 1, Key: "priority"},
+			},
+		}
+	case enum.WebhookTriggerPullReqReviewerAdded, enum.WebhookTriggerPullReqReviewerRemoved:
+		return &PullReqReviewerPayload{
+			BaseSegment:            base,
+			PullReqSegment:         prSegment,
+			PullReqReviewerSegment: PullReqReviewerSegment{ReviewerInfo: principalInfo},
+		}
+	case enum.WebhookTriggerPullReqUpdated:
+		return &PullReqUpdatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			PullReqUpdateSegment: PullReqUpdateSegment{
+				TitleChanged: true,
+				TitleOld:     "Old sample pull request",
+				TitleNew:     pr.Title,
+			},
+		}
+	case enum.WebhookTriggerPullReqReviewSubmitted:
+		return &PullReqReviewSubmittedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			PullReqReviewSegment: PullReqReviewSegment{
+				ReviewDecision: enum.PullReqReviewDecisionApproved,
+				ReviewerInfo:   principalInfo,
+			},
+			Comments: []PullReqCommentSegment{comment},
+		}
+	case enum.WebhookTriggerPullReqTargetBranchChanged:
+		return &PullReqTargetBranchChangedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			ReferenceDetailsSegment:       details,
+			PullReqTargetBrancheChangedSegment: PullReqTargetBrancheChangedSegment{
+				OldTargetBranch: "develop",
+				OldMergeBaseSHA: previewSampleOldSHA,
+			},
+		}
+	default:
+		// created, reopened, closed and merged share the same payload format.
+		return &PullReqCreatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			ReferenceDetailsSegment:       details,
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeReplay = "webhook-replay"
+
+	// replayMaxExecutions defines the max number of executions that can be replayed with a single request.
+	replayMaxExecutions = 1000
+)
+
+type replayJobInput struct {
+	WebhookID    int64   `json:"webhook_id"`
+	ExecutionIDs []int64 `json:"execution_ids"`
+}
+
+// ReplayExecutions schedules a background job that retriggers all failed executions of the webhook
+// within the provided time range (e.g. after the receiver was down for a while).
+// Only triggers that never got delivered successfully are replayed, each of them once.
+func (s *Service) ReplayExecutions(
+	ctx context.Context,
+	parentID int64,
+	parentType enum.WebhookParent,
+	webhookIdentifier string,
+	in *types.WebhookReplayInput,
+) (*types.WebhookReplayOutput, error) {
+	if in.To == 0 {
+		in.To = time.Now().UnixMilli()
+	}
+	if in.From <= 0 || in.From > in.To {
+		return nil, check.NewValidationError("A valid time range has to be provided.")
+	}
+
+	webhook, err := s.GetWebhookVerifyOwnership(ctx, parentID, parentType, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	executions, err := s.webhookExecutionStore.ListFailedForWebhook(ctx, webhook.ID, in.From, in.To,
+		replayMaxExecutions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed executions for webhook %d: %w", webhook.ID, err)
+	}
+
+	if len(executions) == 0 {
+		return &types.WebhookReplayOutput{Count: 0}, nil
+	}
+
+	input := replayJobInput{
+		WebhookID:    webhook.ID,
+		ExecutionIDs: make([]int64, len(executions)),
+	}
+	for i, execution := range executions {
+		input.ExecutionIDs[i] = execution.ID
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal replay job input: %w", err)
+	}
+
+	uid, err := job.UID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate replay job uid: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobTypeReplay + "-" + uid,
+		Type:       jobTypeReplay,
+		MaxRetries: 0,
+		Timeout:    time.Duration(len(executions))*webhookTimeLimit + time.Minute,
+		Data:       string(data),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run webhook replay job: %w", err)
+	}
+
+	return &types.WebhookReplayOutput{Count: len(executions)}, nil
+}
+
+type replayJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*replayJob)(nil)
+
+// Handle is the webhook replay background job handler.
+func (j *replayJob) Handle(ctx context.Context, data string, fn job.ProgressReporter) (string, error) {
+	input := replayJobInput{}
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal replay job input: %w", err)
+	}
+
+	var succeeded, failed int
+	for i, executionID := range input.ExecutionIDs {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		result, err := j.service.WebhookExecutor.RetriggerWebhookExecution(ctx, executionID)
+		switch {
+		case err != nil:
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to replay execution %d of webhook %d",
+				executionID, input.WebhookID)
+			failed++
+		case result.Execution.Result != enum.WebhookExecutionResultSuccess:
+			failed++
+		default:
+			succeeded++
+		}
+
+		_ = fn(100*(i+1)/len(input.ExecutionIDs), "")
+	}
+
+	return fmt.Sprintf("replayed %d executions of webhook %d: %d succeeded, %d failed",
+		len(input.ExecutionIDs), input.WebhookID, succeeded, failed), nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	jobTypeRetry = "webhook-retry"
+
+	// retryBatchSize defines the max number of due retries loaded at once.
+	retryBatchSize = 100
+)
+
+// scheduleRetry schedules an automatic retry of the execution in case it failed with a retriable error
+// and the max number of attempts isn't reached yet.
+// The delay grows exponentially with the attempts (with jitter), but honors the delay requested by the server.
+func (w *WebhookExecutor) scheduleRetry(execution *types.WebhookExecutionCore, retryAfter time.Duration) {
+	if !w.reliableDelivery ||
+		execution.Result != enum.WebhookExecutionResultRetriableError ||
+		execution.Attempt >= w.config.RetryMaxAttempts {
+		return
+	}
+
+	jitter := rand.Float64() //nolint:gosec // no need for a cryptographically secure jitter
+	delay := retryDelay(w.config.RetryBackoffBase, w.config.RetryBackoffMax, execution.Attempt, jitter)
+	if retryAfter > delay {
+		delay = min(retryAfter, w.config.RetryBackoffMax)
+	}
+
+	nextRetryAt := time.Now().Add(delay).UnixMilli()
+	execution.NextRetryAt = &nextRetryAt
+}
+
+// retryDelay returns the delay before the next attempt after the provided (1 based) attempt failed.
+// The delay doubles with every attempt (capped by maxDelay) and is randomized to the range [delay/2, delay]
+// using the provided jitter in [0, 1) to avoid retries of many executions at the same time.
+func retryDelay(base, maxDelay time.Duration, attempt int, jitter float64) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+
+	return delay/2 + time.Duration(jitter*float64(delay/2))
+}
+
+// parseRetryAfter parses the value of a Retry-After header (either delay seconds or an HTTP date).
+// It returns 0 in case the value is missing or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+// deliveryFailed returns true in case the execution failed and no further retry is going to happen.
+func deliveryFailed(execution *types.WebhookExecutionCore) bool {
+	return execution.Result != enum.WebhookExecutionResultSuccess && execution.NextRetryAt == nil
+}
+
+// RetryWebhookExecution executes the scheduled automatic retry of a webhook execution.
+// Retries of disabled webhooks are dropped.
+func (w *WebhookExecutor) RetryWebhookExecution(
+	ctx context.Context,
+	webhookExecution *types.WebhookExecutionCore,
+) (*TriggerResult, error) {
+	webhook, err := w.webhookExecutorStore.FindWebhook(ctx, webhookExecution.WebhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook with id %d: %w", webhookExecution.WebhookID, err)
+	}
+
+	if !webhook.Enabled {
+		return &TriggerResult{
+			TriggerID:   webhookExecution.TriggerID,
+			TriggerType: webhookExecution.TriggerType,
+			Webhook:     webhook,
+		}, nil
+	}
+
+	return w.retriggerWebhookExecution(ctx, webhook, webhookExecution, webhookExecution.Attempt+1)
+}
+
+// Register schedules the recurring job that executes the due automatic retries of webhook executions.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeRetry, jobTypeRetry, s.config.RetryCRON, s.config.RetryMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for webhook retries: %w", err)
+	}
+
+	return nil
+}
+
+type retryJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*retryJob)(nil)
+
+// Handle is the webhook retry background job handler.
+func (j *retryJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	s := j.service
+
+	var retried, dropped atomic.Int64
+	for {
+		executions, err := s.webhookExecutionStore.ListRetriesDue(ctx, time.Now().UnixMilli(), retryBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list due webhook retries: %w", err)
+		}
+
+		g, gCtx := errgroup.WithContext(ctx)
+		g.SetLimit(s.config.Concurrency)
+
+		for _, execution := range executions {
+			g.Go(func() error {
+				// claim the retry to ensure it's executed only once (in case of multiple instances)
+				claimed, err := s.webhookExecutionStore.ClaimRetry(gCtx, execution.ID, *execution.NextRetryAt)
+				if err != nil {
+					return fmt.Errorf("failed to claim retry of webhook execution %d: %w", execution.ID, err)
+				}
+				if !claimed {
+					return nil
+				}
+
+				result, err := s.WebhookExecutor.RetryWebhookExecution(gCtx,
+					GitnessWebhookExecutionToWebhookExecutionCore(execution))
+				if err != nil {
+					// the execution stays in the history as failed and can be replayed manually.
+					log.Ctx(gCtx).Warn().Err(err).Msgf("failed to retry webhook execution %d", execution.ID)
+					dropped.Add(1)
+					return nil
+				}
+				if result.Skipped() {
+					dropped.Add(1)
+					return nil
+				}
+
+				retried.Add(1)
+				return nil
+			})
+		}
+
+		if err = g.Wait(); err != nil {
+			return "", err
+		}
+
+		if len(executions) < retryBatchSize {
+			break
+		}
+	}
+
+	return fmt.Sprintf("retried %d webhook executions, dropped %d", retried.Load(), dropped.Load()), nil
+}
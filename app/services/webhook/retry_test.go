@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryDelay(t *testing.T) {
+	base := 30 * time.Second
+	maxDelay := 10 * time.Minute
+
+	tests := []struct {
+		attempt  int
+		jitter   float64
+		expected time.Duration
+	}{
+		{attempt: 1, jitter: 0, expected: 15 * time.Second},
+		{attempt: 1, jitter: 0.5, expected: 22500 * time.Millisecond},
+		{attempt: 2, jitter: 0, expected: 30 * time.Second},
+		{attempt: 3, jitter: 0.999999, expected: 2 * time.Minute},
+		{attempt: 10, jitter: 0, expected: 5 * time.Minute},
+		{attempt: 100, jitter: 0, expected: 5 * time.Minute},
+	}
+
+	for _, test := range tests {
+		delay := retryDelay(base, maxDelay, test.attempt, test.jitter)
+		require.InDelta(t, test.expected, delay, float64(time.Millisecond), "attempt %d", test.attempt)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("-5", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+	require.Equal(t, 120*time.Second, parseRetryAfter("120", now))
+	require.Equal(t, 90*time.Second, parseRetryAfter("Mon, 01 Jan 2024 12:01:30 GMT", now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("Mon, 01 Jan 2024 11:00:00 GMT", now))
+}
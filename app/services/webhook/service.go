@@ -21,8 +21,11 @@ import (
 	"net/http"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -30,6 +33,7 @@ import (
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/stream"
@@ -38,6 +42,10 @@ import (
 
 const (
 	eventsReaderGroupName = "gitness:webhook"
+
+	defaultRetryBackoffBase = 30 * time.Second
+	defaultRetryCRON        = "* * * * *"
+	defaultRetryMaxDuration = 5 * time.Minute
 )
 
 type Config struct {
@@ -53,6 +61,17 @@ type Config struct {
 	AllowPrivateNetwork bool
 	AllowLoopback       bool
 	InternalSecret      string
+
+	// RetryMaxAttempts is the max number of delivery attempts of an event (1 disables automatic retries).
+	RetryMaxAttempts int
+	// RetryBackoffBase is the delay before the first retry, it doubles with every further attempt.
+	RetryBackoffBase time.Duration
+	// RetryBackoffMax is the max delay between two attempts.
+	RetryBackoffMax  time.Duration
+	RetryCRON        string
+	RetryMaxDuration time.Duration
+	// AutoDisableThreshold is the number of failed deliveries in a row after which a webhook gets disabled.
+	AutoDisableThreshold int
 }
 
 func (c *Config) Prepare() error {
@@ -71,11 +90,32 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("Config.MaxRetries can't be negative")
 	}
+	if c.RetryBackoffBase < 0 || c.RetryBackoffMax < 0 {
+		return errors.New("Config.RetryBackoffBase and Config.RetryBackoffMax can't be negative")
+	}
+	if c.AutoDisableThreshold < 0 {
+		return errors.New("Config.AutoDisableThreshold can't be negative")
+	}
 
 	// Backfill data
 	if c.HeaderIdentity == "" {
 		c.HeaderIdentity = c.UserAgentIdentity
 	}
+	if c.RetryMaxAttempts < 1 {
+		c.RetryMaxAttempts = 1
+	}
+	if c.RetryBackoffBase == 0 {
+		c.RetryBackoffBase = defaultRetryBackoffBase
+	}
+	if c.RetryBackoffMax < c.RetryBackoffBase {
+		c.RetryBackoffMax = c.RetryBackoffBase
+	}
+	if c.RetryCRON == "" {
+		c.RetryCRON = defaultRetryCRON
+	}
+	if c.RetryMaxDuration == 0 {
+		c.RetryMaxDuration = defaultRetryMaxDuration
+	}
 
 	return nil
 }
@@ -120,6 +160,10 @@ type WebhookExecutor struct {
 	principalStore             store.PrincipalStore
 	webhookExecutorStore       WebhookExecutorStore
 	source                     string
+
+	// reliableDelivery enables automatic retries of failed executions and auto-disabling of failing webhooks.
+	// It requires the WebhookExecutorStore to persist the retry and failure tracking state.
+	reliableDelivery bool
 }
 
 func NewWebhookExecutor(
@@ -134,10 +178,10 @@ func NewWebhookExecutor(
 ) *WebhookExecutor {
 	return &WebhookExecutor{
 		webhookExecutorStore:       webhookExecutorStore,
-		secureHTTPClient:           newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
-		insecureHTTPClient:         newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
-		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
-		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
+		secureHTTPClient:           NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
+		insecureHTTPClient:         NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
+		secureHTTPClientInternal:   NewHTTPClient(config.AllowLoopback, true, false),
+		insecureHTTPClientInternal: NewHTTPClient(config.AllowLoopback, true, true),
 		config:                     config,
 		webhookURLProvider:         webhookURLProvider,
 		encrypter:                  encrypter,
@@ -167,6 +211,10 @@ type Service struct {
 	config                Config
 	auditService          audit.Service
 	sseStreamer           sse.Streamer
+	scheduler             *job.Scheduler
+	checkStore            store.CheckStore
+	pipelineStore         store.PipelineStore
+	executionStore        store.ExecutionStore
 }
 
 func NewService(
@@ -175,6 +223,9 @@ func NewService(
 	tx dbtx.Transactor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	spaceStore store.SpaceStore,
@@ -192,6 +243,10 @@ func NewService(
 	sseStreamer sse.Streamer,
 	secretService secret.Service,
 	spacePathStore store.SpacePathStore,
+	scheduler *job.Scheduler,
+	checkStore store.CheckStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service Config is invalid: %w", err)
@@ -199,9 +254,11 @@ func NewService(
 	webhookExecutorStore := &GitnessWebhookExecutorStore{
 		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
+		autoDisableThreshold:  config.AutoDisableThreshold,
 	}
 	executor := NewWebhookExecutor(config, webhookURLProvider, encrypter, spacePathStore,
 		secretService, principalStore, webhookExecutorStore, RepoTrigger)
+	executor.reliableDelivery = true
 
 	service := &Service{
 		WebhookExecutor:       executor,
@@ -221,6 +278,10 @@ func NewService(
 		labelValueStore:       labelValueStore,
 		auditService:          auditService,
 		sseStreamer:           sseStreamer,
+		scheduler:             scheduler,
+		checkStore:            checkStore,
+		pipelineStore:         pipelineStore,
+		executionStore:        executionStore,
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
@@ -271,6 +332,9 @@ func NewService(
 			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
 			_ = r.RegisterCommentStatusUpdated(service.handleEventPullReqCommentStatusUpdated)
 			_ = r.RegisterTargetBranchChanged(service.handleEventPullReqTargetBranchChanged)
+			_ = r.RegisterLabelUnassigned(service.handleEventPullReqLabelUnassigned)
+			_ = r.RegisterReviewerAdded(service.handleEventPullReqReviewerAdded)
+			_ = r.RegisterReviewerRemoved(service.handleEventPullReqReviewerRemoved)
 
 			return nil
 		})
@@ -278,5 +342,66 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = repoReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventRepoCreated)
+			_ = r.RegisterSoftDeleted(service.handleEventRepoSoftDeleted)
+			_ = r.RegisterRenamed(service.handleEventRepoRenamed)
+			_ = r.RegisterPublicAccessChanged(service.handleEventRepoPublicAccessChanged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for webhooks: %w", err)
+	}
+
+	_, err = checkReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterReported(service.handleEventCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for webhooks: %w", err)
+	}
+
+	_, err = pipelineReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterExecutionStarted(service.handleEventPipelineExecutionStarted)
+			_ = r.RegisterExecuted(service.handleEventPipelineExecuted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
@@ -19,11 +19,15 @@ import (
 
 	gitnessstore "github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type GitnessWebhookExecutorStore struct {
 	webhookStore          gitnessstore.WebhookStore
 	webhookExecutionStore gitnessstore.WebhookExecutionStore
+	autoDisableThreshold  int
 }
 
 func (s *GitnessWebhookExecutorStore) Find(ctx context.Context, id int64) (*types.WebhookExecutionCore, error) {
@@ -82,12 +86,28 @@ func (s *GitnessWebhookExecutorStore) UpdateOptLock(
 	webhook := CoreWebhookToGitnessWebhook(hook)
 	fn := func(hook *types.Webhook) error {
 		hook.LatestExecutionResult = &execution.Result
+
+		switch {
+		case execution.Result == enum.WebhookExecutionResultSuccess:
+			hook.ConsecutiveFailures = 0
+		case deliveryFailed(execution):
+			hook.ConsecutiveFailures++
+			if s.autoDisableThreshold > 0 && hook.Enabled && hook.ConsecutiveFailures >= s.autoDisableThreshold {
+				hook.Enabled = false
+				hook.AutoDisabled = true
+			}
+		}
+
 		return nil
 	}
 	gitnessWebhook, err := s.webhookStore.UpdateOptLock(ctx, webhook, fn)
 	if err != nil {
 		return nil, err
 	}
+	if gitnessWebhook.AutoDisabled && !webhook.AutoDisabled {
+		log.Ctx(ctx).Warn().Msgf("webhook %d got disabled after %d failed deliveries in a row",
+			gitnessWebhook.ID, gitnessWebhook.ConsecutiveFailures)
+	}
 	webhookCore := GitnessWebhookToWebhookCore(gitnessWebhook)
 	return webhookCore, err
 }
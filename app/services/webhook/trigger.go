@@ -105,8 +105,10 @@ func (w *WebhookExecutor) triggerWebhooks(
 	skipExecution := make(map[int64]bool)
 	for _, execution := range executions {
 		// skip execution in case of success or unrecoverable error
+		// (with reliable delivery retries are scheduled separately, so any previous execution is final).
 		if execution.Result == enum.WebhookExecutionResultSuccess ||
-			execution.Result == enum.WebhookExecutionResultFatalError {
+			execution.Result == enum.WebhookExecutionResultFatalError ||
+			w.reliableDelivery {
 			skipExecution[execution.WebhookID] = true
 		}
 	}
@@ -142,7 +144,7 @@ func (w *WebhookExecutor) triggerWebhooks(
 		}
 
 		// execute trigger and store output in result
-		results[i].Execution, results[i].Err = w.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+		results[i].Execution, results[i].Err = w.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil, 1)
 	}
 
 	return results, nil
@@ -158,17 +160,27 @@ func (w *WebhookExecutor) RetriggerWebhookExecution(
 		return nil, fmt.Errorf("failed to find webhook execution with id %d: %w", webhookExecutionID, err)
 	}
 
-	// ensure webhook can be retriggered
-	if !webhookExecution.Retriggerable {
-		return nil, ErrWebhookNotRetriggerable
-	}
-
 	// find webhook
 	webhook, err := w.webhookExecutorStore.FindWebhook(ctx, webhookExecution.WebhookID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find webhook with id %d: %w", webhookExecution.WebhookID, err)
 	}
 
+	// a manual retrigger starts a new series of delivery attempts
+	return w.retriggerWebhookExecution(ctx, webhook, webhookExecution, 1)
+}
+
+func (w *WebhookExecutor) retriggerWebhookExecution(
+	ctx context.Context,
+	webhook *types.WebhookCore,
+	webhookExecution *types.WebhookExecutionCore,
+	attempt int,
+) (*TriggerResult, error) {
+	// ensure webhook can be retriggered
+	if !webhookExecution.Retriggerable {
+		return nil, ErrWebhookNotRetriggerable
+	}
+
 	// reuse same trigger id as original execution
 	triggerID := webhookExecution.TriggerID
 	triggerType := webhookExecution.TriggerType
@@ -178,7 +190,7 @@ func (w *WebhookExecutor) RetriggerWebhookExecution(
 	// NOTE: bBuff.Write(v) will always return (len(v), nil) - no need to error handle
 	body.WriteString(webhookExecution.Request.Body)
 
-	newExecution, err := w.executeWebhook(ctx, webhook, triggerID, triggerType, body, &webhookExecution.ID)
+	newExecution, err := w.executeWebhook(ctx, webhook, triggerID, triggerType, body, &webhookExecution.ID, attempt)
 	return &TriggerResult{
 		TriggerID:   triggerID,
 		TriggerType: triggerType,
@@ -191,7 +203,7 @@ func (w *WebhookExecutor) RetriggerWebhookExecution(
 //nolint:gocognit // refactor into smaller chunks if necessary.
 func (w *WebhookExecutor) executeWebhook(
 	ctx context.Context, webhook *types.WebhookCore, triggerID string,
-	triggerType enum.WebhookTrigger, body any, rerunOfID *int64,
+	triggerType enum.WebhookTrigger, body any, rerunOfID *int64, attempt int,
 ) (*types.WebhookExecutionCore, error) {
 	// build execution entry on the fly (save no matter what)
 	execution := types.WebhookExecutionCore{
@@ -199,15 +211,23 @@ func (w *WebhookExecutor) executeWebhook(
 		WebhookID:   webhook.ID,
 		TriggerID:   triggerID,
 		TriggerType: triggerType,
+		Attempt:     attempt,
 		// for unexpected errors we don't retry - protect the system. User can retrigger manually (if body was set)
 		Result: enum.WebhookExecutionResultFatalError,
 		Error:  "An unknown error occurred",
 	}
+
+	// retryAfter is the delay requested by the remote server (if any).
+	var retryAfter time.Duration
+
 	defer func(oCtx context.Context, start time.Time) {
 		// set total execution time
 		execution.Duration = int64(time.Since(start))
 		execution.Created = time.Now().UnixMilli()
 
+		// schedule an automatic retry in case of a retriable error
+		w.scheduleRetry(&execution, retryAfter)
+
 		// TODO: what if saving execution failed? For now we will rerun it in case of error or not show it in history
 		err := w.webhookExecutorStore.CreateWebhookExecution(oCtx, &execution)
 		if err != nil {
@@ -217,7 +237,9 @@ func (w *WebhookExecutor) executeWebhook(
 		}
 
 		// update latest execution result of webhook IFF it's different from before (best effort)
-		if webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result {
+		// or the failure tracking of the webhook changes.
+		if webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result ||
+			w.reliableDelivery && (deliveryFailed(&execution) || webhook.ConsecutiveFailures > 0) {
 			_, err = w.webhookExecutorStore.UpdateOptLock(oCtx, webhook, &execution)
 			if err != nil {
 				log.Ctx(ctx).Warn().Err(err).Msgf(
@@ -264,10 +286,10 @@ func (w *WebhookExecutor) executeWebhook(
 	var dnsError *net.DNSError
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
-		// we assume timeout without any response is not worth retrying - protect the system
+		// the remote server might be temporarily overloaded - retries are delayed with backoff
 		tErr := fmt.Errorf("request exceeded time limit of %s", webhookTimeLimit)
 		execution.Error = tErr.Error()
-		execution.Result = enum.WebhookExecutionResultFatalError
+		execution.Result = enum.WebhookExecutionResultRetriableError
 		return &execution, tErr
 
 	case errors.As(err, &dnsError) && dnsError.IsNotFound:
@@ -277,15 +299,18 @@ func (w *WebhookExecutor) executeWebhook(
 		return &execution, fmt.Errorf("failed to resolve host name '%s': %w", dnsError.Name, err)
 
 	case err != nil:
-		// for all other errors we don't retry - protect the system. User can retrigger manually (if body was set)
+		// network errors (connection refused, reset, ...) are usually temporary - retries are delayed with backoff
 		tErr := fmt.Errorf("an error occurred while sending the request: %w", err)
 		execution.Error = tErr.Error()
-		execution.Result = enum.WebhookExecutionResultFatalError
+		execution.Result = enum.WebhookExecutionResultRetriableError
 		return &execution, tErr
 	}
 
 	// handle response
 	err = handleWebhookResponse(&execution, resp)
+	if execution.Result == enum.WebhookExecutionResultRetriableError {
+		retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
 
 	return &execution, err
 }
@@ -332,6 +357,21 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 			execution.Result = enum.WebhookExecutionResultFatalError
 			return nil, fmt.Errorf("failed to serialize body to json: %w", err)
 		}
+
+		// transform the default payload in case the webhook has a custom payload template
+		if webhook.PayloadTemplate != nil {
+			rendered, err := renderPayloadTemplate(webhook.PayloadTemplate, bBuff.Bytes())
+			if err != nil {
+				// ASSUMPTION: there was an issue with the static user input, not retriable
+				tErr := fmt.Errorf("failed to render payload template: %w", err)
+				execution.Error = tErr.Error()
+				execution.Result = enum.WebhookExecutionResultFatalError
+				return nil, tErr
+			}
+
+			bBuff.Reset()
+			bBuff.Write(rendered)
+		}
 	}
 	// set executioon body and mark it as retriggerable
 	execution.Request.Body = bBuff.String()
@@ -358,10 +398,12 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 	req.Header.Add(w.toXHeader("Webhook-Identifier"), fmt.Sprint(webhook.Identifier))
 	req.Header.Add(w.toXHeader(w.source), string(triggerType))
 
-	if webhook.ExtraHeaders != nil {
-		for _, h := range webhook.ExtraHeaders {
-			req.Header.Add(h.Key, h.Value)
-		}
+	// extra headers replace the default headers with the same name (e.g. Content-Type for non-JSON templates)
+	for _, h := range webhook.ExtraHeaders {
+		req.Header.Del(h.Key)
+	}
+	for _, h := range webhook.ExtraHeaders {
+		req.Header.Add(h.Key, h.Value)
 	}
 
 	var secretValue string
@@ -541,6 +583,8 @@ func CoreWebhookExecutionToGitnessWebhookExecution(execution *types.WebhookExecu
 		Retriggerable: execution.Retriggerable,
 		Duration:      execution.Duration,
 		Created:       execution.Created,
+		Attempt:       execution.Attempt,
+		NextRetryAt:   execution.NextRetryAt,
 	}
 }
 
@@ -558,6 +602,8 @@ func GitnessWebhookExecutionToWebhookExecutionCore(execution *types.WebhookExecu
 		Retriggerable: execution.Retriggerable,
 		Duration:      execution.Duration,
 		Created:       execution.Created,
+		Attempt:       execution.Attempt,
+		NextRetryAt:   execution.NextRetryAt,
 	}
 }
 
@@ -581,6 +627,10 @@ func GitnessWebhookToWebhookCore(webhook *types.Webhook) *types.WebhookCore {
 		Insecure:              webhook.Insecure,
 		Triggers:              webhook.Triggers,
 		LatestExecutionResult: webhook.LatestExecutionResult,
+		ConsecutiveFailures:   webhook.ConsecutiveFailures,
+		AutoDisabled:          webhook.AutoDisabled,
+		ExtraHeaders:          webhook.ExtraHeaders,
+		PayloadTemplate:       webhook.PayloadTemplate,
 	}
 }
 
@@ -604,6 +654,10 @@ func CoreWebhookToGitnessWebhook(webhook *types.WebhookCore) *types.Webhook {
 		Insecure:              webhook.Insecure,
 		Triggers:              webhook.Triggers,
 		LatestExecutionResult: webhook.LatestExecutionResult,
+		ConsecutiveFailures:   webhook.ConsecutiveFailures,
+		AutoDisabled:          webhook.AutoDisabled,
+		ExtraHeaders:          webhook.ExtraHeaders,
+		PayloadTemplate:       webhook.PayloadTemplate,
 	}
 }
 
@@ -105,11 +105,48 @@ type PullReqReviewSegment struct {
 	ReviewerInfo   PrincipalInfo              `json:"reviewer"`
 }
 
+// PullReqReviewerSegment contains details of the reviewer that got added to or removed from a pull request.
+type PullReqReviewerSegment struct {
+	ReviewerInfo PrincipalInfo `json:"reviewer"`
+}
+
 type PullReqTargetBrancheChangedSegment struct {
 	OldTargetBranch string `json:"old_target_branch"`
 	OldMergeBaseSHA string `json:"old_merge_base_sha"`
 }
 
+// RepoVisibilitySegment contains the visibility of the repo for webhooks.
+type RepoVisibilitySegment struct {
+	IsPublic bool `json:"is_public"`
+}
+
+// RepoVisibilityChangedSegment contains the previous visibility of the repo for webhooks.
+type RepoVisibilityChangedSegment struct {
+	OldIsPublic bool `json:"old_is_public"`
+}
+
+// RepoRenamedSegment contains the previous identifier and path of a renamed repo for webhooks.
+type RepoRenamedSegment struct {
+	OldIdentifier string `json:"old_identifier"`
+	OldPath       string `json:"old_path"`
+}
+
+// RepoDeletedSegment contains details of a deleted repo for webhooks.
+type RepoDeletedSegment struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// CheckSegment contains details for all status check related payloads for webhooks.
+type CheckSegment struct {
+	Check CheckInfo `json:"check"`
+}
+
+// PipelineExecutionSegment contains details for all pipeline execution related payloads for webhooks.
+type PipelineExecutionSegment struct {
+	Pipeline  PipelineInfo          `json:"pipeline"`
+	Execution PipelineExecutionInfo `json:"execution"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -338,6 +375,34 @@ type LabelInfo struct {
 	Value   *string `json:"value,omitempty"`
 }
 
+type CheckInfo struct {
+	Identifier string           `json:"identifier"`
+	Status     enum.CheckStatus `json:"status"`
+	CommitSHA  string           `json:"commit_sha"`
+	Summary    string           `json:"summary,omitempty"`
+	Link       string           `json:"link,omitempty"`
+	Started    int64            `json:"started,omitempty"`
+	Ended      int64            `json:"ended,omitempty"`
+}
+
+type PipelineInfo struct {
+	ID         int64  `json:"id"`
+	Identifier string `json:"identifier"`
+	ConfigPath string `json:"config_path"`
+}
+
+type PipelineExecutionInfo struct {
+	Number   int64             `json:"number"`
+	Status   enum.CIStatus     `json:"status"`
+	Event    enum.TriggerEvent `json:"event,omitempty"`
+	Trigger  string            `json:"trigger,omitempty"`
+	Ref      string            `json:"ref,omitempty"`
+	SHA      string            `json:"sha,omitempty"`
+	Started  int64             `json:"started,omitempty"`
+	Finished int64             `json:"finished,omitempty"`
+	URL      string            `json:"url"`
+}
+
 type CodeCommentInfo struct {
 	Outdated     bool   `json:"outdated"`
 	MergeBaseSHA string `json:"merge_base_sha"`
@@ -64,6 +64,16 @@ func (s *Service) sanitizeUpdateInput(in *types.WebhookUpdateInput) error {
 			return err
 		}
 	}
+	if in.ExtraHeaders != nil {
+		if err := CheckExtraHeaders(in.ExtraHeaders, s.reservedHeaderPrefix()); err != nil {
+			return err
+		}
+	}
+	if in.PayloadTemplate != nil && in.PayloadTemplate.Type != "" {
+		if err := CheckPayloadTemplate(in.PayloadTemplate); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -112,6 +122,9 @@ func (s *Service) Update(
 	}
 	if in.Enabled != nil {
 		hook.Enabled = *in.Enabled
+		// any explicit change of the state resets the failure tracking
+		hook.AutoDisabled = false
+		hook.ConsecutiveFailures = 0
 	}
 	if in.Insecure != nil {
 		hook.Insecure = *in.Insecure
@@ -119,6 +132,15 @@ func (s *Service) Update(
 	if in.Triggers != nil {
 		hook.Triggers = DeduplicateTriggers(in.Triggers)
 	}
+	if in.ExtraHeaders != nil {
+		hook.ExtraHeaders = in.ExtraHeaders
+	}
+	if in.PayloadTemplate != nil {
+		hook.PayloadTemplate = in.PayloadTemplate
+		if in.PayloadTemplate.Type == "" {
+			hook.PayloadTemplate = nil
+		}
+	}
 
 	if err := s.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
@@ -17,8 +17,11 @@ package webhook
 import (
 	"context"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -26,6 +29,7 @@ import (
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -44,6 +48,9 @@ func ProvideService(
 	tx dbtx.Transactor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	spaceStore store.SpaceStore,
@@ -61,13 +68,21 @@ func ProvideService(
 	sseStreamer sse.Streamer,
 	secretService secret.Service,
 	spacePathStore store.SpacePathStore,
+	scheduler *job.Scheduler,
+	checkStore store.CheckStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	executor *job.Executor,
 ) (*Service, error) {
-	return NewService(
+	service, err := NewService(
 		ctx,
 		config,
 		tx,
 		gitReaderFactory,
 		prReaderFactory,
+		repoReaderFactory,
+		checkReaderFactory,
+		pipelineReaderFactory,
 		webhookStore,
 		webhookExecutionStore,
 		spaceStore, repoStore,
@@ -84,7 +99,24 @@ func ProvideService(
 		sseStreamer,
 		secretService,
 		spacePathStore,
+		scheduler,
+		checkStore,
+		pipelineStore,
+		executionStore,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = executor.Register(jobTypeRetry, &retryJob{service: service}); err != nil {
+		return nil, err
+	}
+
+	if err = executor.Register(jobTypeReplay, &replayJob{service: service}); err != nil {
+		return nil, err
+	}
+
+	return service, nil
 }
 
 func ProvideURLProvider(ctx context.Context) URLProvider {
@@ -15,6 +15,7 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/branch"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/gitspace"
@@ -25,6 +26,8 @@ import (
 	"github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/ldapsync"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
@@ -33,6 +36,7 @@ import (
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
 	registryasyncprocessing "github.com/harness/gitness/registry/services/asyncprocessing"
+	registrycleanup "github.com/harness/gitness/registry/services/cleanup"
 	registrywebhooks "github.com/harness/gitness/registry/services/webhook"
 
 	"github.com/google/wire"
@@ -60,6 +64,10 @@ type Services struct {
 	registryWebhooksService        *registrywebhooks.Service
 	Branch                         *branch.Service
 	registryAsyncProcessingService *registryasyncprocessing.Service
+	RegistryCleanup                *registrycleanup.Service
+	MergeQueue                     *mergequeue.Service
+	AutoMerge                      *automerge.Service
+	LDAPSync                       *ldapsync.Service
 }
 
 type GitspaceServices struct {
@@ -107,6 +115,10 @@ func ProvideServices(
 	registryWebhooksService *registrywebhooks.Service,
 	branchSvc *branch.Service,
 	registryAsyncProcessingService *registryasyncprocessing.Service,
+	registryCleanupSvc *registrycleanup.Service,
+	mergeQueueSvc *mergequeue.Service,
+	autoMergeSvc *automerge.Service,
+	ldapSyncSvc *ldapsync.Service,
 ) Services {
 	return Services{
 		Webhook:                        webhooksSvc,
@@ -126,5 +138,9 @@ func ProvideServices(
 		registryWebhooksService:        registryWebhooksService,
 		Branch:                         branchSvc,
 		registryAsyncProcessingService: registryAsyncProcessingService,
+		RegistryCleanup:                registryCleanupSvc,
+		MergeQueue:                     mergeQueueSvc,
+		AutoMerge:                      autoMergeSvc,
+		LDAPSync:                       ldapSyncSvc,
 	}
 }
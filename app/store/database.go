@@ -63,6 +63,10 @@ type (
 		// FindUserByEmail finds the user by email.
 		FindUserByEmail(ctx context.Context, email string) (*types.User, error)
 
+		// FindManyUsersByID returns all users found for the provided IDs.
+		// If an ID isn't found, it's not returned in the list.
+		FindManyUsersByID(ctx context.Context, ids []int64) ([]*types.User, error)
+
 		// CreateUser saves the user details.
 		CreateUser(ctx context.Context, user *types.User) error
 
@@ -381,6 +385,9 @@ type (
 		// Create saves the token details.
 		Create(ctx context.Context, token *types.Token) error
 
+		// UpdateLastUsed updates the time at which the token was last used.
+		UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error
+
 		// Delete deletes the token with the given id.
 		Delete(ctx context.Context, id int64) error
 
@@ -445,6 +452,9 @@ type (
 			repoID int64,
 			branchNames []string,
 		) (map[string][]*types.PullReq, error)
+
+		// ListStack returns all pull requests of the pull request stack the pull request belongs to.
+		ListStack(ctx context.Context, id int64) ([]*types.PullReq, error)
 	}
 
 	PullReqActivityStore interface {
@@ -485,6 +495,12 @@ type (
 
 		// ListAuthorIDs returns a list of pull request activity author ids in a thread (order).
 		ListAuthorIDs(ctx context.Context, prID int64, order int64) ([]int64, error)
+
+		// ListPending returns the pending review comments of a principal in a pull request.
+		ListPending(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqActivity, error)
+
+		// DeletePending deletes all pending review comments of a principal in a pull request.
+		DeletePending(ctx context.Context, prID int64, principalID int64) (int64, error)
 	}
 
 	// CodeCommentView is to manipulate only code-comment subset of PullReqActivity.
@@ -556,6 +572,49 @@ type (
 		List(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqFileView, error)
 	}
 
+	// MergeQueueStore defines database interface for merge queue entries.
+	MergeQueueStore interface {
+		// Find returns the merge queue entry by its ID.
+		Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error)
+
+		// FindByPullReqID returns the merge queue entry of the pull request.
+		FindByPullReqID(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error)
+
+		// Create adds a new pull request to the merge queue of its target branch.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates the state and the speculative merge of a merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes the merge queue entry.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all entries of the merge queue of the target branch, in the queue order.
+		List(ctx context.Context, repoID int64, targetBranch string) ([]*types.MergeQueueEntry, error)
+
+		// ListBranches returns all branches with a non-empty merge queue.
+		ListBranches(ctx context.Context) ([]types.MergeQueueBranch, error)
+	}
+
+	// AutoMergeStore defines database interface for pull request auto-merges.
+	AutoMergeStore interface {
+		// Find returns the auto-merge of the pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.AutoMerge, error)
+
+		// Upsert enables the auto-merge of the pull request, or replaces its merge details if already enabled.
+		Upsert(ctx context.Context, am *types.AutoMerge) error
+
+		// UpdateSourceSHA updates the source branch commit the auto-merge is tracking.
+		UpdateSourceSHA(ctx context.Context, pullReqID int64, sourceSHA string) error
+
+		// Delete disables the auto-merge of the pull request.
+		Delete(ctx context.Context, pullReqID int64) error
+
+		// List returns pull request auto-merges of the repository.
+		// If repoID is zero, auto-merges of all repositories are returned.
+		List(ctx context.Context, repoID int64) ([]*types.AutoMerge, error)
+	}
+
 	// RuleStore defines database interface for protection rules.
 	RuleStore interface {
 		// Find finds a protection rule by ID.
@@ -667,6 +726,23 @@ type (
 
 		// ListForTrigger lists the webhook executions for a given trigger id.
 		ListForTrigger(ctx context.Context, triggerID string) ([]*types.WebhookExecution, error)
+
+		// ListRetriesDue lists the webhook executions with an automatic retry that is due at the provided time.
+		ListRetriesDue(ctx context.Context, now int64, limit int) ([]*types.WebhookExecution, error)
+
+		// ClaimRetry removes the scheduled retry of the webhook execution.
+		// It returns false in case the retry was already claimed by someone else.
+		ClaimRetry(ctx context.Context, id int64, nextRetryAt int64) (bool, error)
+
+		// ListFailedForWebhook lists the latest failed executions of all triggers of a webhook
+		// that were created within the provided time range and never got delivered successfully.
+		ListFailedForWebhook(
+			ctx context.Context,
+			webhookID int64,
+			from int64,
+			to int64,
+			limit int,
+		) ([]*types.WebhookExecution, error)
 	}
 
 	CheckStore interface {
@@ -1144,6 +1220,61 @@ type (
 			spaceID int64,
 			userGroup *types.UserGroup,
 		) error
+
+		// Update updates the name and the description of a usergroup.
+		Update(ctx context.Context, userGroup *types.UserGroup) error
+
+		// Delete deletes a usergroup.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns a list of usergroups defined in any of the provided spaces.
+		List(ctx context.Context, spaceIDs []int64, filter *types.ListQueryFilter) ([]*types.UserGroup, error)
+
+		// Count returns the number of usergroups defined in any of the provided spaces.
+		Count(ctx context.Context, spaceIDs []int64, filter *types.ListQueryFilter) (int64, error)
+	}
+
+	UserGroupMemberStore interface {
+		// Create adds a principal to a usergroup.
+		Create(ctx context.Context, member *types.UserGroupMember) error
+
+		// Delete removes a principal from a usergroup.
+		Delete(ctx context.Context, userGroupID, principalID int64) error
+
+		// List returns a list of the members of a usergroup.
+		List(ctx context.Context, userGroupID int64, filter *types.ListQueryFilter) ([]*types.UserGroupMember, error)
+
+		// Count returns the number of the members of a usergroup.
+		Count(ctx context.Context, userGroupID int64, filter *types.ListQueryFilter) (int64, error)
+
+		// MapPrincipalIDs returns IDs of the members of each of the provided usergroups.
+		MapPrincipalIDs(ctx context.Context, userGroupIDs []int64) (map[int64][]int64, error)
+
+		// MapUserGroupIDs returns the IDs of the usergroups the provided principals are members of.
+		MapUserGroupIDs(ctx context.Context, principalIDs []int64) (map[int64][]int64, error)
+	}
+
+	PrincipalIdentityStore interface {
+		// Find returns the identity of the provider with the given subject.
+		Find(ctx context.Context, provider enum.IdentityProvider, subject string) (*types.PrincipalIdentity, error)
+
+		// Create links a new external identity to a principal.
+		Create(ctx context.Context, identity *types.PrincipalIdentity) error
+
+		// Update updates the subject, the external ID and the email of an external identity.
+		Update(ctx context.Context, identity *types.PrincipalIdentity) error
+
+		// ListByPrincipal returns all external identities linked to a principal.
+		ListByPrincipal(ctx context.Context, principalID int64) ([]*types.PrincipalIdentity, error)
+
+		// ListByProvider returns all external identities of the provider.
+		ListByProvider(ctx context.Context, provider enum.IdentityProvider) ([]*types.PrincipalIdentity, error)
+
+		// Count returns the number of external identities of users matching the filter.
+		Count(ctx context.Context, filter *types.PrincipalIdentityFilter) (int64, error)
+
+		// List returns the external identities of users matching the filter, ordered by the ID.
+		List(ctx context.Context, filter *types.PrincipalIdentityFilter) ([]*types.PrincipalIdentity, error)
 	}
 
 	PublicKeyStore interface {
@@ -1195,6 +1326,87 @@ type (
 		List(ctx context.Context, publicKeyID int64) ([]string, error)
 	}
 
+	// AuditEventStore defines database interface for audit log events.
+	AuditEventStore interface {
+		// Create stores a new audit event.
+		Create(ctx context.Context, event *types.AuditEvent) error
+
+		// List returns a list of audit events matching the filter, the most recent first.
+		List(ctx context.Context, filter *types.AuditEventFilter) ([]*types.AuditEvent, error)
+
+		// Count returns the number of audit events matching the filter.
+		Count(ctx context.Context, filter *types.AuditEventFilter) (int64, error)
+	}
+
+	// NotificationStore defines database interface for in-app notifications.
+	NotificationStore interface {
+		// Find finds the notification of the principal by id.
+		Find(ctx context.Context, principalID, id int64) (*types.Notification, error)
+
+		// Create creates a new notification.
+		Create(ctx context.Context, n *types.Notification) error
+
+		// UpdateRead marks the notification of the principal as read or unread.
+		UpdateRead(ctx context.Context, principalID, id int64, read bool) error
+
+		// MarkAllRead marks all unread notifications of the principal as read.
+		MarkAllRead(ctx context.Context, principalID int64) (int64, error)
+
+		// List returns a list of notifications of the principal, the most recent first.
+		List(ctx context.Context, principalID int64, filter *types.NotificationFilter) ([]*types.Notification, error)
+
+		// Count returns the number of notifications of the principal.
+		Count(ctx context.Context, principalID int64, filter *types.NotificationFilter) (int64, error)
+	}
+
+	// NotificationSubscriptionStore defines database interface for in-app notification subscriptions.
+	NotificationSubscriptionStore interface {
+		// Upsert creates a new notification subscription or updates the level of an existing one.
+		Upsert(ctx context.Context, sub *types.NotificationSubscription) error
+
+		// Delete deletes the notification subscription of the principal for the resource.
+		Delete(ctx context.Context, principalID int64, resourceType enum.ResourceType, resourceID int64) error
+
+		// List returns all notification subscriptions of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.NotificationSubscription, error)
+
+		// ListForResources returns the notification subscriptions of the principals
+		// for the repository and for any of the spaces.
+		ListForResources(
+			ctx context.Context,
+			principalIDs []int64,
+			repoID int64,
+			spaceIDs []int64,
+		) ([]*types.NotificationSubscription, error)
+	}
+
+	// NotificationChannelStore defines database interface for space level chat notification channels.
+	NotificationChannelStore interface {
+		// Find finds the notification channel by id.
+		Find(ctx context.Context, id int64) (*types.NotificationChannel, error)
+
+		// FindByIdentifier finds the notification channel of the space by its identifier.
+		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.NotificationChannel, error)
+
+		// Create creates a new notification channel.
+		Create(ctx context.Context, channel *types.NotificationChannel) error
+
+		// Update updates an existing notification channel.
+		Update(ctx context.Context, channel *types.NotificationChannel) error
+
+		// Delete deletes the notification channel by id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the notification channels of the space.
+		List(ctx context.Context, spaceID int64, filter types.ListQueryFilter) ([]*types.NotificationChannel, error)
+
+		// Count returns the number of notification channels of the space.
+		Count(ctx context.Context, spaceID int64, filter types.ListQueryFilter) (int64, error)
+
+		// ListEnabled returns all enabled notification channels of the provided spaces.
+		ListEnabled(ctx context.Context, spaceIDs []int64) ([]*types.NotificationChannel, error)
+	}
+
 	GitSignatureResultStore interface {
 		Map(
 			ctx context.Context,
@@ -1389,6 +1601,130 @@ type (
 		) (map[int64][]*types.LabelPullReqAssignmentInfo, error)
 	}
 
+	// IssueStore defines the issue data storage.
+	IssueStore interface {
+		// Find the issue by id.
+		Find(ctx context.Context, id int64) (*types.Issue, error)
+
+		// FindByNumber finds the issue by repo ID and issue number.
+		FindByNumber(ctx context.Context, repoID, number int64) (*types.Issue, error)
+
+		// Create a new issue. The issue number is assigned by the store.
+		Create(ctx context.Context, issue *types.Issue) error
+
+		// Update the issue. It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, issue *types.Issue) error
+
+		// UpdateOptLock the issue details using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context,
+			issue *types.Issue,
+			mutateFn func(issue *types.Issue) error,
+		) (*types.Issue, error)
+
+		// UpdateActivitySeq the issue's activity sequence number.
+		UpdateActivitySeq(ctx context.Context, issue *types.Issue) (*types.Issue, error)
+
+		// Count of issues in a repository.
+		Count(ctx context.Context, repoID int64, opts *types.IssueFilter) (int64, error)
+
+		// List returns a list of issues in a repository.
+		List(ctx context.Context, repoID int64, opts *types.IssueFilter) ([]*types.Issue, error)
+
+		// CountByMilestoneIDs returns the number of issues per state for each of the provided milestones.
+		CountByMilestoneIDs(
+			ctx context.Context,
+			milestoneIDs []int64,
+		) (map[int64]map[enum.IssueState]int64, error)
+	}
+
+	// IssueActivityStore defines the issue activity data storage.
+	IssueActivityStore interface {
+		// Find the issue activity by id.
+		Find(ctx context.Context, id int64) (*types.IssueActivity, error)
+
+		// Create a new issue activity.
+		Create(ctx context.Context, act *types.IssueActivity) error
+
+		// CreateWithPayload creates a new system activity from the provided payload.
+		CreateWithPayload(
+			ctx context.Context,
+			issue *types.Issue,
+			principalID int64,
+			payload types.IssueActivityPayload,
+		) (*types.IssueActivity, error)
+
+		// Update the issue activity. It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, act *types.IssueActivity) error
+
+		// UpdateOptLock updates the issue activity using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context,
+			act *types.IssueActivity,
+			mutateFn func(act *types.IssueActivity) error,
+		) (*types.IssueActivity, error)
+
+		// List returns a list of activities of an issue.
+		List(ctx context.Context, issueID int64, filter *types.IssueActivityFilter) ([]*types.IssueActivity, error)
+	}
+
+	// IssueAssigneeStore defines the issue assignee data storage.
+	IssueAssigneeStore interface {
+		// Create assigns a principal to an issue.
+		Create(ctx context.Context, issueID, principalID, createdBy int64) error
+
+		// Delete removes a principal from the assignees of an issue.
+		Delete(ctx context.Context, issueID, principalID int64) error
+
+		// Map returns the IDs of the principals assigned to each of the provided issues.
+		Map(ctx context.Context, issueIDs []int64) (map[int64][]int64, error)
+	}
+
+	// IssueLabelAssignmentStore defines the issue label assignment data storage.
+	IssueLabelAssignmentStore interface {
+		// Assign assigns a label to an issue.
+		Assign(ctx context.Context, label *types.IssueLabel) error
+
+		// Unassign removes a label from an issue with a specified id.
+		Unassign(ctx context.Context, issueID int64, labelID int64) error
+
+		// ListAssigned list labels assigned to a specified issue.
+		ListAssigned(ctx context.Context, issueID int64) (map[int64]*types.LabelAssignment, error)
+
+		// FindByLabelID finds a label assigned to an issue with a specified id.
+		FindByLabelID(ctx context.Context, issueID int64, labelID int64) (*types.IssueLabel, error)
+
+		// FindValueByLabelID finds a value assigned to an issue label.
+		FindValueByLabelID(ctx context.Context, issueID int64, labelID int64) (*types.LabelValue, error)
+
+		// ListAssignedByIssueIDs list labels assigned to specified issues.
+		ListAssignedByIssueIDs(
+			ctx context.Context,
+			issueIDs []int64,
+		) (map[int64][]*types.LabelIssueAssignmentInfo, error)
+	}
+
+	// MilestoneStore defines the milestone data storage.
+	MilestoneStore interface {
+		// Find finds the milestone by id.
+		Find(ctx context.Context, id int64) (*types.Milestone, error)
+
+		// Create creates a new milestone.
+		Create(ctx context.Context, milestone *types.Milestone) error
+
+		// Update updates an existing milestone.
+		Update(ctx context.Context, milestone *types.Milestone) error
+
+		// Delete deletes the milestone by id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the milestones of the repository.
+		List(ctx context.Context, repoID int64, filter *types.MilestoneFilter) ([]*types.Milestone, error)
+
+		// Count returns the number of milestones of the repository.
+		Count(ctx context.Context, repoID int64, filter *types.MilestoneFilter) (int64, error)
+	}
+
 	LFSObjectStore interface {
 		// Find finds an LFS object with a specified oid and repo-id.
 		Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error)
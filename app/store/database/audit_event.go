@@ -0,0 +1,335 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.AuditEventStore = (*AuditEventStore)(nil)
+
+// NewAuditEventStore returns a new AuditEventStore.
+func NewAuditEventStore(db *sqlx.DB) *AuditEventStore {
+	return &AuditEventStore{
+		db: db,
+	}
+}
+
+// AuditEventStore implements store.AuditEventStore backed by a relational database.
+type AuditEventStore struct {
+	db *sqlx.DB
+}
+
+type auditEvent struct {
+	ID         int64  `db:"audit_event_id"`
+	Identifier string `db:"audit_event_identifier"`
+	Timestamp  int64  `db:"audit_event_timestamp"`
+
+	Action             string          `db:"audit_event_action"`
+	ResourceType       string          `db:"audit_event_resource_type"`
+	ResourceIdentifier string          `db:"audit_event_resource_identifier"`
+	ResourceData       json.RawMessage `db:"audit_event_resource_data"`
+	SpacePath          string          `db:"audit_event_space_path"`
+
+	PrincipalID    int64              `db:"audit_event_principal_id"`
+	PrincipalUID   string             `db:"audit_event_principal_uid"`
+	PrincipalType  enum.PrincipalType `db:"audit_event_principal_type"`
+	PrincipalEmail string             `db:"audit_event_principal_email"`
+	PrincipalName  string             `db:"audit_event_principal_name"`
+
+	OldObject sqlxtypes.NullJSONText `db:"audit_event_old_object"`
+	NewObject sqlxtypes.NullJSONText `db:"audit_event_new_object"`
+
+	ClientIP      string          `db:"audit_event_client_ip"`
+	RequestMethod string          `db:"audit_event_request_method"`
+	Data          json.RawMessage `db:"audit_event_data"`
+}
+
+const (
+	auditEventColumns = `
+		 audit_event_id
+		,audit_event_identifier
+		,audit_event_timestamp
+		,audit_event_action
+		,audit_event_resource_type
+		,audit_event_resource_identifier
+		,audit_event_resource_data
+		,audit_event_space_path
+		,audit_event_principal_id
+		,audit_event_principal_uid
+		,audit_event_principal_type
+		,audit_event_principal_email
+		,audit_event_principal_name
+		,audit_event_old_object
+		,audit_event_new_object
+		,audit_event_client_ip
+		,audit_event_request_method
+		,audit_event_data`
+)
+
+// Create stores a new audit event.
+func (s *AuditEventStore) Create(ctx context.Context, event *types.AuditEvent) error {
+	const sqlQuery = `
+		INSERT INTO audit_events (
+			 audit_event_identifier
+			,audit_event_timestamp
+			,audit_event_action
+			,audit_event_resource_type
+			,audit_event_resource_identifier
+			,audit_event_resource_data
+			,audit_event_space_path
+			,audit_event_principal_id
+			,audit_event_principal_uid
+			,audit_event_principal_type
+			,audit_event_principal_email
+			,audit_event_principal_name
+			,audit_event_old_object
+			,audit_event_new_object
+			,audit_event_client_ip
+			,audit_event_request_method
+			,audit_event_data
+		) VALUES (
+			 :audit_event_identifier
+			,:audit_event_timestamp
+			,:audit_event_action
+			,:audit_event_resource_type
+			,:audit_event_resource_identifier
+			,:audit_event_resource_data
+			,:audit_event_space_path
+			,:audit_event_principal_id
+			,:audit_event_principal_uid
+			,:audit_event_principal_type
+			,:audit_event_principal_email
+			,:audit_event_principal_name
+			,:audit_event_old_object
+			,:audit_event_new_object
+			,:audit_event_client_ip
+			,:audit_event_request_method
+			,:audit_event_data
+		) RETURNING audit_event_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbEvent, err := mapInternalAuditEvent(event)
+	if err != nil {
+		return fmt.Errorf("failed to map audit event: %w", err)
+	}
+
+	query, args, err := db.BindNamed(sqlQuery, dbEvent)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit event object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&event.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert audit event")
+	}
+
+	return nil
+}
+
+// List returns a list of audit events matching the filter, the most recent first.
+func (s *AuditEventStore) List(ctx context.Context, filter *types.AuditEventFilter) ([]*types.AuditEvent, error) {
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events").
+		OrderBy("audit_event_timestamp DESC", "audit_event_id DESC")
+
+	stmt = applyAuditEventFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*auditEvent
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list audit events")
+	}
+
+	result := make([]*types.AuditEvent, len(dst))
+	for i := range dst {
+		if result[i], err = mapAuditEvent(dst[i]); err != nil {
+			return nil, fmt.Errorf("failed to map audit event: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// Count returns the number of audit events matching the filter.
+func (s *AuditEventStore) Count(ctx context.Context, filter *types.AuditEventFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("audit_events")
+
+	stmt = applyAuditEventFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count audit events")
+	}
+
+	return count, nil
+}
+
+func applyAuditEventFilter(stmt squirrel.SelectBuilder, filter *types.AuditEventFilter) squirrel.SelectBuilder {
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("audit_event_timestamp > ?", filter.CreatedGt)
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("audit_event_timestamp < ?", filter.CreatedLt)
+	}
+
+	if spacePath := strings.Trim(filter.SpacePath, "/"); spacePath != "" {
+		if filter.Recursive {
+			stmt = stmt.Where(squirrel.Or{
+				squirrel.Expr("LOWER(audit_event_space_path) = LOWER(?)", spacePath),
+				squirrel.Expr(`LOWER(audit_event_space_path) LIKE LOWER(?) ESCAPE '\'`, escapeLike(spacePath)+"/%"),
+			})
+		} else {
+			stmt = stmt.Where("LOWER(audit_event_space_path) = LOWER(?)", spacePath)
+		}
+	}
+
+	if len(filter.ResourceTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_resource_type": filter.ResourceTypes})
+	}
+
+	if len(filter.Actions) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_action": filter.Actions})
+	}
+
+	if len(filter.PrincipalIDs) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_principal_id": filter.PrincipalIDs})
+	}
+
+	return stmt
+}
+
+// escapeLike escapes the metacharacters of the SQL "LIKE" expression.
+func escapeLike(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "_", `\_`)
+	value = strings.ReplaceAll(value, "%", `\%`)
+	return value
+}
+
+func mapInternalAuditEvent(event *types.AuditEvent) (*auditEvent, error) {
+	resourceData, err := marshalStringMap(event.ResourceData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource data: %w", err)
+	}
+
+	data, err := marshalStringMap(event.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	return &auditEvent{
+		ID:                 event.ID,
+		Identifier:         event.Identifier,
+		Timestamp:          event.Timestamp,
+		Action:             event.Action,
+		ResourceType:       event.ResourceType,
+		ResourceIdentifier: event.ResourceIdentifier,
+		ResourceData:       resourceData,
+		SpacePath:          event.SpacePath,
+		PrincipalID:        event.Principal.ID,
+		PrincipalUID:       event.Principal.UID,
+		PrincipalType:      event.Principal.Type,
+		PrincipalEmail:     event.Principal.Email,
+		PrincipalName:      event.Principal.DisplayName,
+		OldObject:          nullJSONText(event.OldObject),
+		NewObject:          nullJSONText(event.NewObject),
+		ClientIP:           event.ClientIP,
+		RequestMethod:      event.RequestMethod,
+		Data:               data,
+	}, nil
+}
+
+func mapAuditEvent(event *auditEvent) (*types.AuditEvent, error) {
+	var resourceData, data map[string]string
+
+	if err := json.Unmarshal(event.ResourceData, &resourceData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resource data: %w", err)
+	}
+
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+
+	return &types.AuditEvent{
+		ID:                 event.ID,
+		Identifier:         event.Identifier,
+		Timestamp:          event.Timestamp,
+		Action:             event.Action,
+		ResourceType:       event.ResourceType,
+		ResourceIdentifier: event.ResourceIdentifier,
+		ResourceData:       resourceData,
+		SpacePath:          event.SpacePath,
+		Principal: types.PrincipalInfo{
+			ID:          event.PrincipalID,
+			UID:         event.PrincipalUID,
+			DisplayName: event.PrincipalName,
+			Email:       event.PrincipalEmail,
+			Type:        event.PrincipalType,
+		},
+		OldObject:     json.RawMessage(event.OldObject.JSONText),
+		NewObject:     json.RawMessage(event.NewObject.JSONText),
+		ClientIP:      event.ClientIP,
+		RequestMethod: event.RequestMethod,
+		Data:          data,
+	}, nil
+}
+
+func marshalStringMap(m map[string]string) (json.RawMessage, error) {
+	if m == nil {
+		m = map[string]string{}
+	}
+	return json.Marshal(m)
+}
+
+func nullJSONText(data json.RawMessage) sqlxtypes.NullJSONText {
+	if len(data) == 0 {
+		return sqlxtypes.NullJSONText{}
+	}
+	return sqlxtypes.NullJSONText{JSONText: sqlxtypes.JSONText(data), Valid: true}
+}
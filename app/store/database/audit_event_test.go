@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEventStore_List(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	auditEventStore := database.NewAuditEventStore(db)
+
+	events := []*types.AuditEvent{
+		{Timestamp: 100, Action: "created", ResourceType: "repository", SpacePath: "acme", Principal: types.PrincipalInfo{ID: 1}},
+		{Timestamp: 200, Action: "updated", ResourceType: "repository", SpacePath: "acme/web", Principal: types.PrincipalInfo{ID: 2}},
+		{Timestamp: 300, Action: "deleted", ResourceType: "pipeline", SpacePath: "acme_corp", Principal: types.PrincipalInfo{ID: 1}},
+		{Timestamp: 400, Action: "created", ResourceType: "space", SpacePath: "other", Principal: types.PrincipalInfo{ID: 2}},
+	}
+	for i, event := range events {
+		event.Identifier = string(rune('a' + i))
+		event.ResourceIdentifier = "res"
+		event.Principal.UID = "user"
+		event.Principal.Type = enum.PrincipalTypeUser
+		event.Principal.Email = "user@example.com"
+		event.ResourceData = map[string]string{"repositoryName": "web"}
+		event.NewObject = json.RawMessage(`{"k":"v"}`)
+		require.NoError(t, auditEventStore.Create(ctx, event))
+		require.NotZero(t, event.ID)
+	}
+
+	tests := []struct {
+		name     string
+		filter   types.AuditEventFilter
+		expected []string
+	}{
+		{
+			name:     "all",
+			filter:   types.AuditEventFilter{},
+			expected: []string{"d", "c", "b", "a"},
+		},
+		{
+			name:     "space",
+			filter:   types.AuditEventFilter{SpacePath: "acme"},
+			expected: []string{"a"},
+		},
+		{
+			name:     "space recursive",
+			filter:   types.AuditEventFilter{SpacePath: "acme", Recursive: true},
+			expected: []string{"b", "a"},
+		},
+		{
+			name:     "resource type and action",
+			filter:   types.AuditEventFilter{ResourceTypes: []string{"repository"}, Actions: []string{"created"}},
+			expected: []string{"a"},
+		},
+		{
+			name:     "principal",
+			filter:   types.AuditEventFilter{PrincipalIDs: []int64{2}},
+			expected: []string{"d", "b"},
+		},
+		{
+			name: "time range",
+			filter: types.AuditEventFilter{
+				CreatedFilter: types.CreatedFilter{CreatedGt: 100, CreatedLt: 400},
+			},
+			expected: []string{"c", "b"},
+		},
+		{
+			name:     "pagination",
+			filter:   types.AuditEventFilter{Pagination: types.Pagination{Page: 2, Size: 3}},
+			expected: []string{"a"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, err := auditEventStore.List(ctx, &test.filter)
+			require.NoError(t, err)
+
+			identifiers := make([]string, len(list))
+			for i, event := range list {
+				identifiers[i] = event.Identifier
+			}
+			require.Equal(t, test.expected, identifiers)
+
+			if test.filter.Page == 0 {
+				count, err := auditEventStore.Count(ctx, &test.filter)
+				require.NoError(t, err)
+				require.Equal(t, int64(len(test.expected)), count)
+			}
+		})
+	}
+
+	list, err := auditEventStore.List(ctx, &types.AuditEventFilter{Actions: []string{"deleted"}})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, map[string]string{"repositoryName": "web"}, list[0].ResourceData)
+	require.Equal(t, "user@example.com", list[0].Principal.Email)
+	require.JSONEq(t, `{"k":"v"}`, string(list[0].NewObject))
+}
@@ -0,0 +1,244 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AutoMergeStore = (*AutoMergeStore)(nil)
+
+// NewAutoMergeStore returns a new AutoMergeStore.
+func NewAutoMergeStore(db *sqlx.DB) *AutoMergeStore {
+	return &AutoMergeStore{
+		db: db,
+	}
+}
+
+// AutoMergeStore implements store.AutoMergeStore backed by a relational database.
+type AutoMergeStore struct {
+	db *sqlx.DB
+}
+
+type autoMerge struct {
+	PullReqID int64 `db:"pullreq_auto_merge_pullreq_id"`
+	RepoID    int64 `db:"pullreq_auto_merge_repo_id"`
+
+	MergeMethod        enum.MergeMethod `db:"pullreq_auto_merge_merge_method"`
+	Title              string           `db:"pullreq_auto_merge_title"`
+	Message            string           `db:"pullreq_auto_merge_message"`
+	DeleteSourceBranch bool             `db:"pullreq_auto_merge_delete_source_branch"`
+	DisableOnPush      bool             `db:"pullreq_auto_merge_disable_on_push"`
+	SourceSHA          string           `db:"pullreq_auto_merge_source_sha"`
+
+	CreatedBy int64 `db:"pullreq_auto_merge_created_by"`
+	Created   int64 `db:"pullreq_auto_merge_created"`
+	Updated   int64 `db:"pullreq_auto_merge_updated"`
+}
+
+const (
+	autoMergeColumns = `
+		 pullreq_auto_merge_pullreq_id
+		,pullreq_auto_merge_repo_id
+		,pullreq_auto_merge_merge_method
+		,pullreq_auto_merge_title
+		,pullreq_auto_merge_message
+		,pullreq_auto_merge_delete_source_branch
+		,pullreq_auto_merge_disable_on_push
+		,pullreq_auto_merge_source_sha
+		,pullreq_auto_merge_created_by
+		,pullreq_auto_merge_created
+		,pullreq_auto_merge_updated`
+)
+
+// Find returns the auto-merge of the pull request.
+func (s *AutoMergeStore) Find(ctx context.Context, pullReqID int64) (*types.AutoMerge, error) {
+	stmt := database.Builder.
+		Select(autoMergeColumns).
+		From("pullreq_auto_merges").
+		Where("pullreq_auto_merge_pullreq_id = ?", pullReqID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &autoMerge{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pull request auto-merge")
+	}
+
+	return mapAutoMerge(dst), nil
+}
+
+// Upsert enables the auto-merge of the pull request, or replaces its merge details if already enabled.
+func (s *AutoMergeStore) Upsert(ctx context.Context, am *types.AutoMerge) error {
+	const sqlQuery = `
+		INSERT INTO pullreq_auto_merges (
+			 pullreq_auto_merge_pullreq_id
+			,pullreq_auto_merge_repo_id
+			,pullreq_auto_merge_merge_method
+			,pullreq_auto_merge_title
+			,pullreq_auto_merge_message
+			,pullreq_auto_merge_delete_source_branch
+			,pullreq_auto_merge_disable_on_push
+			,pullreq_auto_merge_source_sha
+			,pullreq_auto_merge_created_by
+			,pullreq_auto_merge_created
+			,pullreq_auto_merge_updated
+		) VALUES (
+			 :pullreq_auto_merge_pullreq_id
+			,:pullreq_auto_merge_repo_id
+			,:pullreq_auto_merge_merge_method
+			,:pullreq_auto_merge_title
+			,:pullreq_auto_merge_message
+			,:pullreq_auto_merge_delete_source_branch
+			,:pullreq_auto_merge_disable_on_push
+			,:pullreq_auto_merge_source_sha
+			,:pullreq_auto_merge_created_by
+			,:pullreq_auto_merge_created
+			,:pullreq_auto_merge_updated
+		) ON CONFLICT (pullreq_auto_merge_pullreq_id) DO UPDATE SET
+			 pullreq_auto_merge_merge_method = EXCLUDED.pullreq_auto_merge_merge_method
+			,pullreq_auto_merge_title = EXCLUDED.pullreq_auto_merge_title
+			,pullreq_auto_merge_message = EXCLUDED.pullreq_auto_merge_message
+			,pullreq_auto_merge_delete_source_branch = EXCLUDED.pullreq_auto_merge_delete_source_branch
+			,pullreq_auto_merge_disable_on_push = EXCLUDED.pullreq_auto_merge_disable_on_push
+			,pullreq_auto_merge_source_sha = EXCLUDED.pullreq_auto_merge_source_sha
+			,pullreq_auto_merge_created_by = EXCLUDED.pullreq_auto_merge_created_by
+			,pullreq_auto_merge_updated = EXCLUDED.pullreq_auto_merge_updated
+		RETURNING pullreq_auto_merge_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalAutoMerge(am))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request auto-merge object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&am.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert pull request auto-merge")
+	}
+
+	return nil
+}
+
+// UpdateSourceSHA updates the source branch commit the auto-merge is tracking.
+func (s *AutoMergeStore) UpdateSourceSHA(ctx context.Context, pullReqID int64, sourceSHA string) error {
+	const sqlQuery = `
+		UPDATE pullreq_auto_merges
+		SET
+			 pullreq_auto_merge_source_sha = $1
+			,pullreq_auto_merge_updated = $2
+		WHERE pullreq_auto_merge_pullreq_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, sourceSHA, time.Now().UnixMilli(), pullReqID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update pull request auto-merge")
+	}
+
+	return nil
+}
+
+// Delete disables the auto-merge of the pull request.
+func (s *AutoMergeStore) Delete(ctx context.Context, pullReqID int64) error {
+	const sqlQuery = `
+		DELETE FROM pullreq_auto_merges
+		WHERE pullreq_auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, pullReqID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pull request auto-merge")
+	}
+
+	return nil
+}
+
+// List returns pull request auto-merges of the repository. If repoID is zero, auto-merges of all repositories.
+func (s *AutoMergeStore) List(ctx context.Context, repoID int64) ([]*types.AutoMerge, error) {
+	stmt := database.Builder.
+		Select(autoMergeColumns).
+		From("pullreq_auto_merges").
+		OrderBy("pullreq_auto_merge_pullreq_id")
+
+	if repoID != 0 {
+		stmt = stmt.Where("pullreq_auto_merge_repo_id = ?", repoID)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*autoMerge
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request auto-merges")
+	}
+
+	result := make([]*types.AutoMerge, len(dst))
+	for i := range dst {
+		result[i] = mapAutoMerge(dst[i])
+	}
+
+	return result, nil
+}
+
+func mapInternalAutoMerge(am *types.AutoMerge) *autoMerge {
+	return &autoMerge{
+		PullReqID:          am.PullReqID,
+		RepoID:             am.RepoID,
+		MergeMethod:        am.MergeMethod,
+		Title:              am.Title,
+		Message:            am.Message,
+		DeleteSourceBranch: am.DeleteSourceBranch,
+		DisableOnPush:      am.DisableOnPush,
+		SourceSHA:          am.SourceSHA,
+		CreatedBy:          am.CreatedBy,
+		Created:            am.Created,
+		Updated:            am.Updated,
+	}
+}
+
+func mapAutoMerge(am *autoMerge) *types.AutoMerge {
+	return &types.AutoMerge{
+		PullReqID:          am.PullReqID,
+		RepoID:             am.RepoID,
+		MergeMethod:        am.MergeMethod,
+		Title:              am.Title,
+		Message:            am.Message,
+		DeleteSourceBranch: am.DeleteSourceBranch,
+		DisableOnPush:      am.DisableOnPush,
+		SourceSHA:          am.SourceSHA,
+		CreatedBy:          am.CreatedBy,
+		Created:            am.Created,
+		Updated:            am.Updated,
+	}
+}
@@ -0,0 +1,505 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.IssueStore = (*IssueStore)(nil)
+
+// NewIssueStore returns a new IssueStore.
+func NewIssueStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *IssueStore {
+	return &IssueStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// IssueStore implements store.IssueStore backed by a relational database.
+type IssueStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+// issue is used to fetch issue data from the database.
+type issue struct {
+	ID          int64           `db:"issue_id"`
+	Version     int64           `db:"issue_version"`
+	RepoID      int64           `db:"issue_repo_id"`
+	Number      int64           `db:"issue_number"`
+	CreatedBy   int64           `db:"issue_created_by"`
+	Created     int64           `db:"issue_created"`
+	Updated     int64           `db:"issue_updated"`
+	Edited      int64           `db:"issue_edited"`
+	Closed      null.Int        `db:"issue_closed"`
+	ClosedBy    null.Int        `db:"issue_closed_by"`
+	State       enum.IssueState `db:"issue_state"`
+	Title       string          `db:"issue_title"`
+	Description string          `db:"issue_description"`
+	MilestoneID null.Int        `db:"issue_milestone_id"`
+	ActivitySeq int64           `db:"issue_activity_seq"`
+
+	CommentCount int `db:"issue_comment_count"`
+}
+
+const (
+	issueColumns = `
+		 issue_id
+		,issue_version
+		,issue_repo_id
+		,issue_number
+		,issue_created_by
+		,issue_created
+		,issue_updated
+		,issue_edited
+		,issue_closed
+		,issue_closed_by
+		,issue_state
+		,issue_title
+		,issue_description
+		,issue_milestone_id
+		,issue_activity_seq
+		,issue_comment_count`
+)
+
+// Find finds the issue by id.
+func (s *IssueStore) Find(ctx context.Context, id int64) (*types.Issue, error) {
+	stmt := database.Builder.
+		Select(issueColumns).
+		From("issues").
+		Where("issue_id = ?", id)
+
+	return s.find(ctx, stmt)
+}
+
+// FindByNumber finds the issue by repo ID and issue number.
+func (s *IssueStore) FindByNumber(ctx context.Context, repoID, number int64) (*types.Issue, error) {
+	stmt := database.Builder.
+		Select(issueColumns).
+		From("issues").
+		Where("issue_repo_id = ?", repoID).
+		Where("issue_number = ?", number)
+
+	return s.find(ctx, stmt)
+}
+
+func (s *IssueStore) find(ctx context.Context, stmt squirrel.SelectBuilder) (*types.Issue, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &issue{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find issue")
+	}
+
+	return s.mapIssue(ctx, dst), nil
+}
+
+// Create creates a new issue. The issue number is the next free number in the repository.
+// Concurrent inserts into the same repository fail with store.ErrDuplicate and should be retried.
+func (s *IssueStore) Create(ctx context.Context, is *types.Issue) error {
+	const sqlQuery = `
+	INSERT INTO issues (
+		 issue_version
+		,issue_repo_id
+		,issue_number
+		,issue_created_by
+		,issue_created
+		,issue_updated
+		,issue_edited
+		,issue_closed
+		,issue_closed_by
+		,issue_state
+		,issue_title
+		,issue_description
+		,issue_milestone_id
+		,issue_activity_seq
+		,issue_comment_count
+	) values (
+		 :issue_version
+		,:issue_repo_id
+		,(SELECT COALESCE(MAX(issue_number), 0) + 1 FROM issues WHERE issue_repo_id = :issue_repo_id)
+		,:issue_created_by
+		,:issue_created
+		,:issue_updated
+		,:issue_edited
+		,:issue_closed
+		,:issue_closed_by
+		,:issue_state
+		,:issue_title
+		,:issue_description
+		,:issue_milestone_id
+		,:issue_activity_seq
+		,:issue_comment_count
+	) RETURNING issue_id, issue_number`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalIssue(is))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&is.ID, &is.Number); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert issue")
+	}
+
+	return nil
+}
+
+// Update updates the issue.
+func (s *IssueStore) Update(ctx context.Context, is *types.Issue) error {
+	const sqlQuery = `
+	UPDATE issues
+	SET
+	     issue_version = :issue_version
+		,issue_updated = :issue_updated
+		,issue_edited = :issue_edited
+		,issue_closed = :issue_closed
+		,issue_closed_by = :issue_closed_by
+		,issue_state = :issue_state
+		,issue_title = :issue_title
+		,issue_description = :issue_description
+		,issue_milestone_id = :issue_milestone_id
+		,issue_activity_seq = :issue_activity_seq
+		,issue_comment_count = :issue_comment_count
+	WHERE issue_id = :issue_id AND issue_version = :issue_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbIssue := mapInternalIssue(is)
+	dbIssue.Version++
+	dbIssue.Updated = time.Now().UnixMilli()
+
+	query, args, err := db.BindNamed(sqlQuery, dbIssue)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind issue object")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update issue")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	*is = *s.mapIssue(ctx, dbIssue)
+
+	return nil
+}
+
+// UpdateOptLock updates the issue using the optimistic locking mechanism.
+func (s *IssueStore) UpdateOptLock(
+	ctx context.Context,
+	is *types.Issue,
+	mutateFn func(issue *types.Issue) error,
+) (*types.Issue, error) {
+	for {
+		dup := *is
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		is, err = s.Find(ctx, is.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// UpdateActivitySeq updates the issue's activity sequence.
+func (s *IssueStore) UpdateActivitySeq(ctx context.Context, is *types.Issue) (*types.Issue, error) {
+	return s.UpdateOptLock(ctx, is, func(is *types.Issue) error {
+		is.ActivitySeq++
+		return nil
+	})
+}
+
+// Count of issues in a repository.
+func (s *IssueStore) Count(ctx context.Context, repoID int64, opts *types.IssueFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("issues").
+		Where("issue_repo_id = ?", repoID)
+
+	stmt = applyIssueFilter(stmt, opts)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count issues")
+	}
+
+	return count, nil
+}
+
+// List returns a list of issues in a repository.
+func (s *IssueStore) List(ctx context.Context, repoID int64, opts *types.IssueFilter) ([]*types.Issue, error) {
+	stmt := database.Builder.
+		Select(issueColumns).
+		From("issues").
+		Where("issue_repo_id = ?", repoID)
+
+	stmt = applyIssueFilter(stmt, opts)
+
+	stmt = stmt.Limit(database.Limit(opts.Size))
+	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+
+	// NOTE: string concatenation is safe because the
+	// order attribute is an enum and is not user-defined,
+	// and is therefore not subject to injection attacks.
+	opts.Sort, _ = opts.Sort.Sanitize()
+	stmt = stmt.OrderBy("issue_" + string(opts.Sort) + " " + opts.Order.String())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*issue, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list issues")
+	}
+
+	return s.mapSliceIssue(ctx, dst)
+}
+
+// CountByMilestoneIDs returns the number of issues per state for each of the provided milestones.
+func (s *IssueStore) CountByMilestoneIDs(
+	ctx context.Context,
+	milestoneIDs []int64,
+) (map[int64]map[enum.IssueState]int64, error) {
+	result := make(map[int64]map[enum.IssueState]int64, len(milestoneIDs))
+	if len(milestoneIDs) == 0 {
+		return result, nil
+	}
+
+	stmt := database.Builder.
+		Select("issue_milestone_id, issue_state, COUNT(*)").
+		From("issues").
+		Where(squirrel.Eq{"issue_milestone_id": milestoneIDs}).
+		GroupBy("issue_milestone_id", "issue_state")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to count issues by milestone")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var milestoneID, count int64
+		var state enum.IssueState
+		if err = rows.Scan(&milestoneID, &state, &count); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan issue count")
+		}
+
+		if result[milestoneID] == nil {
+			result[milestoneID] = make(map[enum.IssueState]int64, 2)
+		}
+		result[milestoneID][state] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to count issues by milestone")
+	}
+
+	return result, nil
+}
+
+func applyIssueFilter(stmt squirrel.SelectBuilder, opts *types.IssueFilter) squirrel.SelectBuilder {
+	if len(opts.States) == 1 {
+		stmt = stmt.Where("issue_state = ?", opts.States[0])
+	} else if len(opts.States) > 1 {
+		stmt = stmt.Where(squirrel.Eq{"issue_state": opts.States})
+	}
+
+	if len(opts.CreatedBy) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"issue_created_by": opts.CreatedBy})
+	}
+
+	if opts.MilestoneID > 0 {
+		stmt = stmt.Where("issue_milestone_id = ?", opts.MilestoneID)
+	}
+
+	if opts.AssigneeID > 0 {
+		stmt = stmt.Where(`EXISTS (SELECT 1 FROM issue_assignees
+			WHERE issue_assignee_issue_id = issue_id AND issue_assignee_principal_id = ?)`, opts.AssigneeID)
+	}
+
+	if len(opts.LabelID) > 0 {
+		subQuery, args, _ := database.Builder.
+			Select("1").
+			From("issue_labels").
+			Where("issue_label_issue_id = issue_id").
+			Where(squirrel.Eq{"issue_label_label_id": opts.LabelID}).
+			ToSql()
+		stmt = stmt.Where("EXISTS ("+subQuery+")", args...)
+	}
+
+	if opts.Query != "" {
+		stmt = stmt.Where(PartialMatch("issue_title", opts.Query))
+	}
+
+	return stmt
+}
+
+func mapIssue(is *issue) *types.Issue {
+	return &types.Issue{
+		ID:           is.ID,
+		Version:      is.Version,
+		RepoID:       is.RepoID,
+		Number:       is.Number,
+		CreatedBy:    is.CreatedBy,
+		Created:      is.Created,
+		Updated:      is.Updated,
+		Edited:       is.Edited,
+		Closed:       is.Closed.Ptr(),
+		ClosedBy:     is.ClosedBy.Ptr(),
+		State:        is.State,
+		Title:        is.Title,
+		Description:  is.Description,
+		MilestoneID:  is.MilestoneID.Ptr(),
+		ActivitySeq:  is.ActivitySeq,
+		CommentCount: is.CommentCount,
+	}
+}
+
+func mapInternalIssue(is *types.Issue) *issue {
+	return &issue{
+		ID:           is.ID,
+		Version:      is.Version,
+		RepoID:       is.RepoID,
+		Number:       is.Number,
+		CreatedBy:    is.CreatedBy,
+		Created:      is.Created,
+		Updated:      is.Updated,
+		Edited:       is.Edited,
+		Closed:       null.IntFromPtr(is.Closed),
+		ClosedBy:     null.IntFromPtr(is.ClosedBy),
+		State:        is.State,
+		Title:        is.Title,
+		Description:  is.Description,
+		MilestoneID:  null.IntFromPtr(is.MilestoneID),
+		ActivitySeq:  is.ActivitySeq,
+		CommentCount: is.CommentCount,
+	}
+}
+
+func (s *IssueStore) mapIssue(ctx context.Context, is *issue) *types.Issue {
+	m := mapIssue(is)
+
+	author, err := s.pCache.Get(ctx, is.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load issue author")
+	}
+	if author != nil {
+		m.Author = *author
+	}
+
+	if is.ClosedBy.Valid {
+		closer, err := s.pCache.Get(ctx, is.ClosedBy.Int64)
+		if err != nil {
+			log.Ctx(ctx).Err(err).Msg("failed to load issue closer")
+		}
+		m.Closer = closer
+	}
+
+	return m
+}
+
+func (s *IssueStore) mapSliceIssue(ctx context.Context, issues []*issue) ([]*types.Issue, error) {
+	// collect all principal IDs
+	ids := make([]int64, 0, 2*len(issues))
+	for _, is := range issues {
+		ids = append(ids, is.CreatedBy)
+		if is.ClosedBy.Valid {
+			ids = append(ids, is.ClosedBy.Int64)
+		}
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issue principal infos: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	m := make([]*types.Issue, len(issues))
+	for i, is := range issues {
+		m[i] = mapIssue(is)
+		if author, ok := infoMap[is.CreatedBy]; ok {
+			m[i].Author = *author
+		}
+		if is.ClosedBy.Valid {
+			if closer, ok := infoMap[is.ClosedBy.Int64]; ok {
+				m[i].Closer = closer
+			}
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.IssueAssigneeStore = (*IssueAssigneeStore)(nil)
+
+// NewIssueAssigneeStore returns a new IssueAssigneeStore.
+func NewIssueAssigneeStore(db *sqlx.DB) *IssueAssigneeStore {
+	return &IssueAssigneeStore{
+		db: db,
+	}
+}
+
+// IssueAssigneeStore implements store.IssueAssigneeStore backed by a relational database.
+type IssueAssigneeStore struct {
+	db *sqlx.DB
+}
+
+// Create assigns a principal to an issue. Assigning an already assigned principal is a no-op.
+func (s *IssueAssigneeStore) Create(ctx context.Context, issueID, principalID, createdBy int64) error {
+	const sqlQuery = `
+	INSERT INTO issue_assignees (
+		 issue_assignee_issue_id
+		,issue_assignee_principal_id
+		,issue_assignee_created_by
+		,issue_assignee_created
+	) VALUES ($1, $2, $3, $4)
+	ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery,
+		issueID, principalID, createdBy, time.Now().UnixMilli()); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert issue assignee")
+	}
+
+	return nil
+}
+
+// Delete removes a principal from the assignees of an issue.
+func (s *IssueAssigneeStore) Delete(ctx context.Context, issueID, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM issue_assignees
+	WHERE issue_assignee_issue_id = $1 AND issue_assignee_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, issueID, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete issue assignee")
+	}
+
+	return nil
+}
+
+// Map returns the IDs of the principals assigned to each of the provided issues.
+func (s *IssueAssigneeStore) Map(ctx context.Context, issueIDs []int64) (map[int64][]int64, error) {
+	result := make(map[int64][]int64, len(issueIDs))
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	stmt := database.Builder.
+		Select("issue_assignee_issue_id, issue_assignee_principal_id").
+		From("issue_assignees").
+		Where(squirrel.Eq{"issue_assignee_issue_id": issueIDs}).
+		OrderBy("issue_assignee_created")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []struct {
+		IssueID     int64 `db:"issue_assignee_issue_id"`
+		PrincipalID int64 `db:"issue_assignee_principal_id"`
+	}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list issue assignees")
+	}
+
+	for _, a := range dst {
+		result[a.IssueID] = append(result[a.IssueID], a.PrincipalID)
+	}
+
+	return result, nil
+}
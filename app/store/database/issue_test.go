@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	issueStore := database.NewIssueStore(db, pCache)
+	assigneeStore := database.NewIssueAssigneeStore(db)
+	milestoneStore := database.NewMilestoneStore(db)
+
+	m := &types.Milestone{
+		RepoID:    1,
+		Title:     "v1.0",
+		State:     enum.MilestoneStateOpen,
+		CreatedBy: userID,
+	}
+	require.NoError(t, milestoneStore.Create(ctx, m))
+
+	newIssue := func(repoID int64, title string) *types.Issue {
+		is := &types.Issue{
+			RepoID:    repoID,
+			CreatedBy: userID,
+			State:     enum.IssueStateOpen,
+			Title:     title,
+		}
+		require.NoError(t, issueStore.Create(ctx, is))
+		return is
+	}
+
+	// issue numbers are allocated per repository
+	first := newIssue(1, "first")
+	second := newIssue(1, "second")
+	other := newIssue(2, "other")
+	require.Equal(t, int64(1), first.Number)
+	require.Equal(t, int64(2), second.Number)
+	require.Equal(t, int64(1), other.Number)
+
+	found, err := issueStore.FindByNumber(ctx, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, second.ID, found.ID)
+	require.Equal(t, userID, found.Author.ID)
+
+	now := int64(1000)
+	updated, err := issueStore.UpdateOptLock(ctx, first, func(is *types.Issue) error {
+		is.State = enum.IssueStateClosed
+		is.Closed = &now
+		is.ClosedBy = &userID
+		is.MilestoneID = &m.ID
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), updated.Version)
+	require.NotNil(t, updated.Closer)
+
+	// the stale copy must be rejected
+	require.Error(t, issueStore.Update(ctx, first))
+
+	require.NoError(t, assigneeStore.Create(ctx, second.ID, userID, userID))
+	require.NoError(t, assigneeStore.Create(ctx, second.ID, userID, userID))
+
+	assignees, err := assigneeStore.Map(ctx, []int64{first.ID, second.ID})
+	require.NoError(t, err)
+	require.Equal(t, map[int64][]int64{second.ID: {userID}}, assignees)
+
+	open, err := issueStore.List(ctx, 1, &types.IssueFilter{States: []enum.IssueState{enum.IssueStateOpen}})
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	require.Equal(t, second.ID, open[0].ID)
+
+	assigned, err := issueStore.Count(ctx, 1, &types.IssueFilter{AssigneeID: userID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), assigned)
+
+	counts, err := issueStore.CountByMilestoneIDs(ctx, []int64{m.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), counts[m.ID][enum.IssueStateClosed])
+	require.Equal(t, int64(0), counts[m.ID][enum.IssueStateOpen])
+
+	// deleting the milestone detaches its issues
+	require.NoError(t, milestoneStore.Delete(ctx, m.ID))
+
+	found, err = issueStore.Find(ctx, first.ID)
+	require.NoError(t, err)
+	require.Nil(t, found.MilestoneID)
+}
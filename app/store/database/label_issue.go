@@ -0,0 +1,297 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/gotidy/ptr"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.IssueLabelAssignmentStore = (*issueLabelStore)(nil)
+
+func NewIssueLabelStore(db *sqlx.DB) store.IssueLabelAssignmentStore {
+	return &issueLabelStore{
+		db: db,
+	}
+}
+
+type issueLabelStore struct {
+	db *sqlx.DB
+}
+
+type issueLabel struct {
+	IssueID      int64    `db:"issue_label_issue_id"`
+	LabelID      int64    `db:"issue_label_label_id"`
+	LabelValueID null.Int `db:"issue_label_label_value_id"`
+	Created      int64    `db:"issue_label_created"`
+	Updated      int64    `db:"issue_label_updated"`
+	CreatedBy    int64    `db:"issue_label_created_by"`
+	UpdatedBy    int64    `db:"issue_label_updated_by"`
+}
+
+type issueAssignmentInfo struct {
+	IssueID    int64           `db:"issue_label_issue_id"`
+	LabelID    int64           `db:"label_id"`
+	LabelKey   string          `db:"label_key"`
+	LabelColor enum.LabelColor `db:"label_color"`
+	LabelScope int64           `db:"label_scope"`
+	ValueCount int64           `db:"label_value_count"`
+	ValueID    null.Int        `db:"label_value_id"`
+	Value      null.String     `db:"label_value_value"`
+	ValueColor null.String     `db:"label_value_color"` // get's converted to *enum.LabelColor
+}
+
+const (
+	issueLabelColumns = `
+		 issue_label_issue_id
+		,issue_label_label_id
+		,issue_label_label_value_id
+		,issue_label_created
+		,issue_label_updated
+		,issue_label_created_by
+		,issue_label_updated_by`
+)
+
+func (s *issueLabelStore) Assign(ctx context.Context, label *types.IssueLabel) error {
+	const sqlQuery = `
+		INSERT INTO issue_labels (` + issueLabelColumns + `)
+			values (
+				:issue_label_issue_id
+				,:issue_label_label_id
+				,:issue_label_label_value_id
+				,:issue_label_created
+				,:issue_label_updated
+				,:issue_label_created_by
+				,:issue_label_updated_by
+			)
+			ON CONFLICT (issue_label_issue_id, issue_label_label_id)
+			DO UPDATE SET
+				issue_label_label_value_id = EXCLUDED.issue_label_label_value_id,
+				issue_label_updated = EXCLUDED.issue_label_updated,
+				issue_label_updated_by = EXCLUDED.issue_label_updated_by
+			RETURNING issue_label_created, issue_label_created_by
+			`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalIssueLabel(label))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "failed to bind query")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&label.Created, &label.CreatedBy); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "failed to create issue label")
+	}
+
+	return nil
+}
+
+func (s *issueLabelStore) Unassign(ctx context.Context, issueID int64, labelID int64) error {
+	const sqlQuery = `
+		DELETE FROM issue_labels
+		WHERE issue_label_issue_id = $1 AND issue_label_label_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, issueID, labelID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "failed to delete issue label")
+	}
+
+	return nil
+}
+
+func (s *issueLabelStore) FindByLabelID(
+	ctx context.Context,
+	issueID int64,
+	labelID int64,
+) (*types.IssueLabel, error) {
+	const sqlQuery = `SELECT ` + issueLabelColumns + `
+		FROM issue_labels
+		WHERE issue_label_issue_id = $1 AND issue_label_label_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst issueLabel
+	if err := db.GetContext(ctx, &dst, sqlQuery, issueID, labelID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to find issue label by id")
+	}
+
+	return mapIssueLabel(&dst), nil
+}
+
+func (s *issueLabelStore) ListAssigned(
+	ctx context.Context,
+	issueID int64,
+) (map[int64]*types.LabelAssignment, error) {
+	const sqlQuery = `
+		SELECT
+			label_id
+			,label_repo_id
+			,label_space_id
+			,label_key
+			,label_value_id
+			,label_value_label_id
+			,label_value_value
+			,label_color
+			,label_value_color
+			,label_scope
+			,label_type
+		FROM issue_labels
+		INNER JOIN labels ON issue_label_label_id = label_id
+		LEFT JOIN label_values ON issue_label_label_value_id = label_value_id
+		WHERE issue_label_issue_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*struct {
+		labelInfo
+		labelValueInfo
+	}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, issueID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to list assigned label")
+	}
+
+	ret := make(map[int64]*types.LabelAssignment, len(dst))
+	for _, res := range dst {
+		li := mapLabelInfo(&res.labelInfo)
+		lvi := mapLabeValuelInfo(&res.labelValueInfo)
+		ret[li.ID] = &types.LabelAssignment{
+			LabelInfo:     *li,
+			AssignedValue: lvi,
+		}
+	}
+
+	return ret, nil
+}
+
+func (s *issueLabelStore) ListAssignedByIssueIDs(
+	ctx context.Context,
+	issueIDs []int64,
+) (map[int64][]*types.LabelIssueAssignmentInfo, error) {
+	stmt := database.Builder.Select(`
+			issue_label_issue_id
+			,label_id
+			,label_key
+			,label_color
+			,label_scope
+			,label_value_count
+			,label_value_id
+			,label_value_value
+			,label_value_color
+	`).
+		From("issue_labels").
+		InnerJoin("labels ON issue_label_label_id = label_id").
+		LeftJoin("label_values ON issue_label_label_value_id = label_value_id").
+		Where(squirrel.Eq{"issue_label_issue_id": issueIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*issueAssignmentInfo
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to list assigned label")
+	}
+
+	return mapIssueAssignmentInfos(dst), nil
+}
+
+func (s *issueLabelStore) FindValueByLabelID(
+	ctx context.Context,
+	issueID int64,
+	labelID int64,
+) (*types.LabelValue, error) {
+	const sqlQuery = `SELECT label_value_id, ` + labelValueColumns + `
+		FROM issue_labels
+		JOIN label_values ON issue_label_label_value_id = label_value_id
+		WHERE issue_label_issue_id = $1 AND issue_label_label_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst labelValue
+	if err := db.GetContext(ctx, &dst, sqlQuery, issueID, labelID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find label")
+	}
+
+	return mapLabelValue(&dst), nil
+}
+
+func mapInternalIssueLabel(lbl *types.IssueLabel) *issueLabel {
+	return &issueLabel{
+		IssueID:      lbl.IssueID,
+		LabelID:      lbl.LabelID,
+		LabelValueID: null.IntFromPtr(lbl.ValueID),
+		Created:      lbl.Created,
+		Updated:      lbl.Updated,
+		CreatedBy:    lbl.CreatedBy,
+		UpdatedBy:    lbl.UpdatedBy,
+	}
+}
+
+func mapIssueLabel(lbl *issueLabel) *types.IssueLabel {
+	return &types.IssueLabel{
+		IssueID:   lbl.IssueID,
+		LabelID:   lbl.LabelID,
+		ValueID:   lbl.LabelValueID.Ptr(),
+		Created:   lbl.Created,
+		Updated:   lbl.Updated,
+		CreatedBy: lbl.CreatedBy,
+		UpdatedBy: lbl.UpdatedBy,
+	}
+}
+
+func mapIssueAssignmentInfo(lbl *issueAssignmentInfo) *types.LabelIssueAssignmentInfo {
+	var valueColor *enum.LabelColor
+	if lbl.ValueColor.Valid {
+		valueColor = ptr.Of(enum.LabelColor(lbl.ValueColor.String))
+	}
+	return &types.LabelIssueAssignmentInfo{
+		IssueID:    lbl.IssueID,
+		LabelID:    lbl.LabelID,
+		LabelKey:   lbl.LabelKey,
+		LabelColor: lbl.LabelColor,
+		LabelScope: lbl.LabelScope,
+		ValueCount: lbl.ValueCount,
+		ValueID:    lbl.ValueID.Ptr(),
+		Value:      lbl.Value.Ptr(),
+		ValueColor: valueColor,
+	}
+}
+
+func mapIssueAssignmentInfos(
+	dbLabels []*issueAssignmentInfo,
+) map[int64][]*types.LabelIssueAssignmentInfo {
+	result := make(map[int64][]*types.LabelIssueAssignmentInfo)
+
+	for _, lbl := range dbLabels {
+		result[lbl.IssueID] = append(result[lbl.IssueID], mapIssueAssignmentInfo(lbl))
+	}
+
+	return result
+}
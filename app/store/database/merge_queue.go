@@ -0,0 +1,324 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeQueueStore = (*MergeQueueStore)(nil)
+
+// NewMergeQueueStore returns a new MergeQueueStore.
+func NewMergeQueueStore(db *sqlx.DB) *MergeQueueStore {
+	return &MergeQueueStore{
+		db: db,
+	}
+}
+
+// MergeQueueStore implements store.MergeQueueStore backed by a relational database.
+type MergeQueueStore struct {
+	db *sqlx.DB
+}
+
+type mergeQueueEntry struct {
+	ID            int64  `db:"merge_queue_entry_id"`
+	RepoID        int64  `db:"merge_queue_entry_repo_id"`
+	PullReqID     int64  `db:"merge_queue_entry_pullreq_id"`
+	PullReqNumber int64  `db:"merge_queue_entry_pullreq_number"`
+	TargetBranch  string `db:"merge_queue_entry_target_branch"`
+
+	State enum.MergeQueueEntryState `db:"merge_queue_entry_state"`
+
+	MergeMethod        enum.MergeMethod `db:"merge_queue_entry_merge_method"`
+	Title              string           `db:"merge_queue_entry_title"`
+	Message            string           `db:"merge_queue_entry_message"`
+	DeleteSourceBranch bool             `db:"merge_queue_entry_delete_source_branch"`
+
+	SourceSHA string `db:"merge_queue_entry_source_sha"`
+	BaseSHA   string `db:"merge_queue_entry_base_sha"`
+	MergeSHA  string `db:"merge_queue_entry_merge_sha"`
+
+	CreatedBy int64 `db:"merge_queue_entry_created_by"`
+	Created   int64 `db:"merge_queue_entry_created"`
+	Updated   int64 `db:"merge_queue_entry_updated"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 merge_queue_entry_id
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_pullreq_id
+		,merge_queue_entry_pullreq_number
+		,merge_queue_entry_target_branch
+		,merge_queue_entry_state
+		,merge_queue_entry_merge_method
+		,merge_queue_entry_title
+		,merge_queue_entry_message
+		,merge_queue_entry_delete_source_branch
+		,merge_queue_entry_source_sha
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_merge_sha
+		,merge_queue_entry_created_by
+		,merge_queue_entry_created
+		,merge_queue_entry_updated`
+)
+
+// Find returns the merge queue entry by its ID.
+func (s *MergeQueueStore) Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error) {
+	return s.find(ctx, "merge_queue_entry_id = ?", id)
+}
+
+// FindByPullReqID returns the merge queue entry of the pull request.
+func (s *MergeQueueStore) FindByPullReqID(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error) {
+	return s.find(ctx, "merge_queue_entry_pullreq_id = ?", pullReqID)
+}
+
+func (s *MergeQueueStore) find(ctx context.Context, pred string, arg any) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where(pred, arg)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry")
+	}
+
+	return mapMergeQueueEntry(dst), nil
+}
+
+// Create adds a new pull request to the merge queue of its target branch.
+func (s *MergeQueueStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		INSERT INTO merge_queue_entries (
+			 merge_queue_entry_repo_id
+			,merge_queue_entry_pullreq_id
+			,merge_queue_entry_pullreq_number
+			,merge_queue_entry_target_branch
+			,merge_queue_entry_state
+			,merge_queue_entry_merge_method
+			,merge_queue_entry_title
+			,merge_queue_entry_message
+			,merge_queue_entry_delete_source_branch
+			,merge_queue_entry_source_sha
+			,merge_queue_entry_base_sha
+			,merge_queue_entry_merge_sha
+			,merge_queue_entry_created_by
+			,merge_queue_entry_created
+			,merge_queue_entry_updated
+		) VALUES (
+			 :merge_queue_entry_repo_id
+			,:merge_queue_entry_pullreq_id
+			,:merge_queue_entry_pullreq_number
+			,:merge_queue_entry_target_branch
+			,:merge_queue_entry_state
+			,:merge_queue_entry_merge_method
+			,:merge_queue_entry_title
+			,:merge_queue_entry_message
+			,:merge_queue_entry_delete_source_branch
+			,:merge_queue_entry_source_sha
+			,:merge_queue_entry_base_sha
+			,:merge_queue_entry_merge_sha
+			,:merge_queue_entry_created_by
+			,:merge_queue_entry_created
+			,:merge_queue_entry_updated
+		) RETURNING merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert merge queue entry")
+	}
+
+	return nil
+}
+
+// Update updates the state and the speculative merge of a merge queue entry.
+func (s *MergeQueueStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		UPDATE merge_queue_entries
+		SET
+			 merge_queue_entry_state = :merge_queue_entry_state
+			,merge_queue_entry_base_sha = :merge_queue_entry_base_sha
+			,merge_queue_entry_merge_sha = :merge_queue_entry_merge_sha
+			,merge_queue_entry_updated = :merge_queue_entry_updated
+		WHERE merge_queue_entry_id = :merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	updatedAt := time.Now().UnixMilli()
+
+	dbEntry := mapInternalMergeQueueEntry(entry)
+	dbEntry.Updated = updatedAt
+
+	query, args, err := db.BindNamed(sqlQuery, dbEntry)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update merge queue entry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	entry.Updated = updatedAt
+
+	return nil
+}
+
+// Delete removes the merge queue entry.
+func (s *MergeQueueStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM merge_queue_entries
+		WHERE merge_queue_entry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete merge queue entry")
+	}
+
+	return nil
+}
+
+// List returns all entries of the merge queue of the target branch, in the queue order.
+func (s *MergeQueueStore) List(
+	ctx context.Context,
+	repoID int64,
+	targetBranch string,
+) ([]*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_repo_id = ?", repoID).
+		Where("merge_queue_entry_target_branch = ?", targetBranch).
+		OrderBy("merge_queue_entry_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*mergeQueueEntry
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue entries")
+	}
+
+	entries := make([]*types.MergeQueueEntry, len(dst))
+	for i := range dst {
+		entries[i] = mapMergeQueueEntry(dst[i])
+		entries[i].Position = i + 1
+	}
+
+	return entries, nil
+}
+
+// ListBranches returns all branches with a non-empty merge queue.
+func (s *MergeQueueStore) ListBranches(ctx context.Context) ([]types.MergeQueueBranch, error) {
+	stmt := database.Builder.
+		Select("DISTINCT merge_queue_entry_repo_id, merge_queue_entry_target_branch").
+		From("merge_queue_entries").
+		OrderBy("merge_queue_entry_repo_id", "merge_queue_entry_target_branch")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []types.MergeQueueBranch
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue branches")
+	}
+
+	return dst, nil
+}
+
+func mapInternalMergeQueueEntry(entry *types.MergeQueueEntry) *mergeQueueEntry {
+	return &mergeQueueEntry{
+		ID:                 entry.ID,
+		RepoID:             entry.RepoID,
+		PullReqID:          entry.PullReqID,
+		PullReqNumber:      entry.PullReqNumber,
+		TargetBranch:       entry.TargetBranch,
+		State:              entry.State,
+		MergeMethod:        entry.MergeMethod,
+		Title:              entry.Title,
+		Message:            entry.Message,
+		DeleteSourceBranch: entry.DeleteSourceBranch,
+		SourceSHA:          entry.SourceSHA,
+		BaseSHA:            entry.BaseSHA,
+		MergeSHA:           entry.MergeSHA,
+		CreatedBy:          entry.CreatedBy,
+		Created:            entry.Created,
+		Updated:            entry.Updated,
+	}
+}
+
+func mapMergeQueueEntry(entry *mergeQueueEntry) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		ID:                 entry.ID,
+		RepoID:             entry.RepoID,
+		PullReqID:          entry.PullReqID,
+		PullReqNumber:      entry.PullReqNumber,
+		TargetBranch:       entry.TargetBranch,
+		State:              entry.State,
+		MergeMethod:        entry.MergeMethod,
+		Title:              entry.Title,
+		Message:            entry.Message,
+		DeleteSourceBranch: entry.DeleteSourceBranch,
+		SourceSHA:          entry.SourceSHA,
+		BaseSHA:            entry.BaseSHA,
+		MergeSHA:           entry.MergeSHA,
+		CreatedBy:          entry.CreatedBy,
+		Created:            entry.Created,
+		Updated:            entry.Updated,
+	}
+}
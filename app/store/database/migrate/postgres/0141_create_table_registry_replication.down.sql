@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS registry_replication_executions;
+DROP TABLE IF EXISTS registry_replication_rules;
@@ -0,0 +1 @@
+ALTER TABLE cleanup_policies ADD COLUMN cp_keep_versions INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS pullreqs_parent_id;
+
+ALTER TABLE pullreqs DROP COLUMN pullreq_parent_id;
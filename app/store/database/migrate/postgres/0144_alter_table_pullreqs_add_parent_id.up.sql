@@ -0,0 +1,5 @@
+ALTER TABLE pullreqs
+    ADD COLUMN pullreq_parent_id INTEGER
+        CONSTRAINT fk_pullreq_parent_id REFERENCES pullreqs (pullreq_id) ON DELETE SET NULL;
+
+CREATE INDEX pullreqs_parent_id ON pullreqs(pullreq_parent_id) WHERE pullreq_parent_id IS NOT NULL;
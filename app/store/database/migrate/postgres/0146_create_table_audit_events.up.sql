@@ -0,0 +1,30 @@
+CREATE TABLE audit_events
+(
+    audit_event_id                    BIGSERIAL PRIMARY KEY,
+    audit_event_identifier            TEXT    NOT NULL,
+    audit_event_timestamp             BIGINT  NOT NULL,
+    audit_event_action                TEXT    NOT NULL,
+    audit_event_resource_type         TEXT    NOT NULL,
+    audit_event_resource_identifier   TEXT    NOT NULL,
+    audit_event_resource_data         JSONB   NOT NULL DEFAULT '{}',
+    audit_event_space_path            TEXT    NOT NULL,
+    audit_event_principal_id          INTEGER NOT NULL,
+    audit_event_principal_uid         TEXT    NOT NULL,
+    audit_event_principal_type        TEXT    NOT NULL,
+    audit_event_principal_email       TEXT    NOT NULL,
+    audit_event_principal_name        TEXT    NOT NULL,
+    audit_event_old_object            JSONB,
+    audit_event_new_object            JSONB,
+    audit_event_client_ip             TEXT    NOT NULL,
+    audit_event_request_method        TEXT    NOT NULL,
+    audit_event_data                  JSONB   NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX audit_events_timestamp
+    ON audit_events (audit_event_timestamp);
+
+CREATE INDEX audit_events_space_path_timestamp
+    ON audit_events (audit_event_space_path, audit_event_timestamp);
+
+CREATE INDEX audit_events_principal_id_timestamp
+    ON audit_events (audit_event_principal_id, audit_event_timestamp);
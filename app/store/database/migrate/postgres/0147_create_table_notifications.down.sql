@@ -0,0 +1,2 @@
+DROP TABLE notification_subscriptions;
+DROP TABLE notifications;
@@ -0,0 +1,49 @@
+CREATE TABLE notifications
+(
+    notification_id             SERIAL PRIMARY KEY,
+    notification_principal_id   INTEGER NOT NULL,
+    notification_type           TEXT    NOT NULL,
+    notification_repo_id        INTEGER NOT NULL,
+    notification_pullreq_id     INTEGER NOT NULL,
+    notification_pullreq_number INTEGER NOT NULL,
+    notification_actor_id       INTEGER,
+    notification_subject        TEXT    NOT NULL,
+    notification_text           TEXT    NOT NULL,
+    notification_url            TEXT    NOT NULL,
+    notification_read           BOOLEAN NOT NULL,
+    notification_created        BIGINT  NOT NULL,
+    notification_updated        BIGINT  NOT NULL,
+    CONSTRAINT fk_notification_principal_id FOREIGN KEY (notification_principal_id)
+        REFERENCES principals (principal_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_notification_repo_id FOREIGN KEY (notification_repo_id)
+        REFERENCES repositories (repo_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_notification_pullreq_id FOREIGN KEY (notification_pullreq_id)
+        REFERENCES pullreqs (pullreq_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_notification_actor_id FOREIGN KEY (notification_actor_id)
+        REFERENCES principals (principal_id)
+        ON DELETE SET NULL
+);
+
+CREATE INDEX notifications_principal_id_created
+    ON notifications (notification_principal_id, notification_created);
+
+CREATE INDEX notifications_principal_id_unread
+    ON notifications (notification_principal_id)
+    WHERE NOT notification_read;
+
+CREATE TABLE notification_subscriptions
+(
+    nsub_principal_id   INTEGER NOT NULL,
+    nsub_resource_type  TEXT    NOT NULL,
+    nsub_resource_id    INTEGER NOT NULL,
+    nsub_level          TEXT    NOT NULL,
+    nsub_created        BIGINT  NOT NULL,
+    nsub_updated        BIGINT  NOT NULL,
+    PRIMARY KEY (nsub_principal_id, nsub_resource_type, nsub_resource_id),
+    CONSTRAINT fk_nsub_principal_id FOREIGN KEY (nsub_principal_id)
+        REFERENCES principals (principal_id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,24 @@
+CREATE TABLE notification_channels
+(
+    nchan_id          SERIAL PRIMARY KEY,
+    nchan_space_id    INTEGER NOT NULL,
+    nchan_identifier  TEXT    NOT NULL,
+    nchan_description TEXT    NOT NULL,
+    nchan_type        TEXT    NOT NULL,
+    nchan_url         TEXT    NOT NULL,
+    nchan_events      TEXT    NOT NULL,
+    nchan_templates   TEXT    NOT NULL,
+    nchan_enabled     BOOLEAN NOT NULL,
+    nchan_created_by  INTEGER NOT NULL,
+    nchan_created     BIGINT  NOT NULL,
+    nchan_updated     BIGINT  NOT NULL,
+    CONSTRAINT fk_nchan_space_id FOREIGN KEY (nchan_space_id)
+        REFERENCES spaces (space_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_nchan_created_by FOREIGN KEY (nchan_created_by)
+        REFERENCES principals (principal_id)
+        ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX notification_channels_space_id_identifier
+    ON notification_channels (nchan_space_id, LOWER(nchan_identifier));
@@ -0,0 +1 @@
+ALTER TABLE principal_identities ADD COLUMN identity_external_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE principal_identities ADD COLUMN identity_provisioned BOOLEAN NOT NULL DEFAULT false;
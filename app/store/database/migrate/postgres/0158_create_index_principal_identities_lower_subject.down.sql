@@ -0,0 +1,2 @@
+DROP INDEX principal_identities_provider_lower_subject;
+DROP INDEX principal_identities_provider_lower_external_id;
@@ -0,0 +1,61 @@
+CREATE TABLE registry_replication_rules
+(
+    replication_rule_id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+    replication_rule_identifier         TEXT    NOT NULL,
+    replication_rule_space_id           INTEGER NOT NULL,
+    replication_rule_source_type        TEXT    NOT NULL,
+    replication_rule_source             TEXT    NOT NULL,
+    replication_rule_source_registry_id INTEGER,
+    replication_rule_destination_type   TEXT    NOT NULL,
+    replication_rule_destination        TEXT    NOT NULL,
+    replication_rule_allowed_patterns   TEXT    NOT NULL,
+    replication_rule_blocked_patterns   TEXT    NOT NULL,
+    replication_rule_created_by         INTEGER NOT NULL,
+    replication_rule_updated_by         INTEGER NOT NULL,
+    replication_rule_created            BIGINT  NOT NULL,
+    replication_rule_updated            BIGINT  NOT NULL,
+    CONSTRAINT fk_replication_rule_space_id FOREIGN KEY (replication_rule_space_id)
+        REFERENCES spaces (space_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_replication_rule_source_registry_id FOREIGN KEY (replication_rule_source_registry_id)
+        REFERENCES registries (registry_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_replication_rule_created_by FOREIGN KEY (replication_rule_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX registry_replication_rules_identifier
+    ON registry_replication_rules (replication_rule_identifier);
+
+CREATE INDEX registry_replication_rules_space_id
+    ON registry_replication_rules (replication_rule_space_id);
+
+CREATE INDEX registry_replication_rules_source_registry_id
+    ON registry_replication_rules (replication_rule_source_registry_id);
+
+CREATE TABLE registry_replication_executions
+(
+    replication_execution_id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    replication_execution_rule_id    INTEGER NOT NULL,
+    replication_execution_trigger    TEXT    NOT NULL,
+    replication_execution_image      TEXT    NOT NULL,
+    replication_execution_reference  TEXT    NOT NULL,
+    replication_execution_digest     TEXT    NOT NULL,
+    replication_execution_status     TEXT    NOT NULL,
+    replication_execution_progress   INTEGER NOT NULL,
+    replication_execution_log        TEXT    NOT NULL,
+    replication_execution_created_by INTEGER NOT NULL,
+    replication_execution_created    BIGINT  NOT NULL,
+    replication_execution_updated    BIGINT  NOT NULL,
+    CONSTRAINT fk_replication_execution_rule_id FOREIGN KEY (replication_execution_rule_id)
+        REFERENCES registry_replication_rules (replication_rule_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX registry_replication_executions_rule_id
+    ON registry_replication_executions (replication_execution_rule_id, replication_execution_id);
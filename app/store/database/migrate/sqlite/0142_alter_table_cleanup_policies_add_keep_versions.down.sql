@@ -0,0 +1 @@
+ALTER TABLE cleanup_policies DROP COLUMN cp_keep_versions;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS merge_queue_entries;
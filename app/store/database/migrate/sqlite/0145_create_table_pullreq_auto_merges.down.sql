@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pullreq_auto_merges;
@@ -0,0 +1,9 @@
+ALTER TABLE webhooks ADD COLUMN webhook_consecutive_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE webhooks ADD COLUMN webhook_auto_disabled BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE webhook_executions ADD COLUMN webhook_execution_attempt INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE webhook_executions ADD COLUMN webhook_execution_next_retry_at BIGINT;
+
+CREATE INDEX webhook_executions_next_retry_at
+    ON webhook_executions(webhook_execution_next_retry_at)
+    WHERE webhook_execution_next_retry_at IS NOT NULL;
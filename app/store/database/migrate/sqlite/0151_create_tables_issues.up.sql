@@ -0,0 +1,155 @@
+CREATE TABLE milestones
+(
+    milestone_id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    milestone_repo_id     INTEGER NOT NULL,
+    milestone_title       TEXT    NOT NULL,
+    milestone_description TEXT    NOT NULL,
+    milestone_state       TEXT    NOT NULL,
+    milestone_due_date    BIGINT,
+    milestone_created_by  INTEGER NOT NULL,
+    milestone_created     BIGINT  NOT NULL,
+    milestone_updated     BIGINT  NOT NULL,
+    CONSTRAINT fk_milestone_repo_id FOREIGN KEY (milestone_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_milestone_created_by FOREIGN KEY (milestone_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX milestones_repo_id_title
+    ON milestones (milestone_repo_id, LOWER(milestone_title));
+
+CREATE TABLE issues
+(
+    issue_id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_version       BIGINT  NOT NULL,
+    issue_repo_id       INTEGER NOT NULL,
+    issue_number        INTEGER NOT NULL,
+    issue_created_by    INTEGER NOT NULL,
+    issue_created       BIGINT  NOT NULL,
+    issue_updated       BIGINT  NOT NULL,
+    issue_edited        BIGINT  NOT NULL,
+    issue_closed        BIGINT,
+    issue_closed_by     INTEGER,
+    issue_state         TEXT    NOT NULL,
+    issue_title         TEXT    NOT NULL,
+    issue_description   TEXT    NOT NULL,
+    issue_milestone_id  INTEGER,
+    issue_activity_seq  INTEGER NOT NULL DEFAULT 0,
+    issue_comment_count INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT fk_issue_repo_id FOREIGN KEY (issue_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_issue_created_by FOREIGN KEY (issue_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION,
+    CONSTRAINT fk_issue_closed_by FOREIGN KEY (issue_closed_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION,
+    CONSTRAINT fk_issue_milestone_id FOREIGN KEY (issue_milestone_id)
+        REFERENCES milestones (milestone_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX issues_repo_id_number
+    ON issues (issue_repo_id, issue_number);
+
+CREATE INDEX issues_repo_id_state
+    ON issues (issue_repo_id, issue_state);
+
+CREATE INDEX issues_milestone_id
+    ON issues (issue_milestone_id)
+    WHERE issue_milestone_id IS NOT NULL;
+
+CREATE TABLE issue_assignees
+(
+    issue_assignee_issue_id     INTEGER NOT NULL,
+    issue_assignee_principal_id INTEGER NOT NULL,
+    issue_assignee_created_by   INTEGER NOT NULL,
+    issue_assignee_created      BIGINT  NOT NULL,
+    CONSTRAINT fk_issue_assignee_issue_id FOREIGN KEY (issue_assignee_issue_id)
+        REFERENCES issues (issue_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_issue_assignee_principal_id FOREIGN KEY (issue_assignee_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_issue_assignee_created_by FOREIGN KEY (issue_assignee_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION,
+    PRIMARY KEY (issue_assignee_issue_id, issue_assignee_principal_id)
+);
+
+CREATE TABLE issue_labels
+(
+    issue_label_issue_id       INTEGER NOT NULL,
+    issue_label_label_id       INTEGER NOT NULL,
+    issue_label_label_value_id INTEGER DEFAULT NULL,
+    issue_label_created        BIGINT  NOT NULL,
+    issue_label_updated        BIGINT  NOT NULL,
+    issue_label_created_by     INTEGER NOT NULL,
+    issue_label_updated_by     INTEGER NOT NULL,
+    CONSTRAINT fk_issue_labels_issue_id FOREIGN KEY (issue_label_issue_id)
+        REFERENCES issues (issue_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_issue_labels_label_id FOREIGN KEY (issue_label_label_id)
+        REFERENCES labels (label_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_issue_labels_label_value_id FOREIGN KEY (issue_label_label_value_id)
+        REFERENCES label_values (label_value_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE SET NULL,
+    CONSTRAINT fk_issue_labels_created_by FOREIGN KEY (issue_label_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION,
+    CONSTRAINT fk_issue_labels_updated_by FOREIGN KEY (issue_label_updated_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION,
+    PRIMARY KEY (issue_label_issue_id, issue_label_label_id)
+);
+
+CREATE TABLE issue_activities
+(
+    issue_activity_id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_activity_version    BIGINT  NOT NULL,
+    issue_activity_created_by INTEGER,
+    issue_activity_created    BIGINT  NOT NULL,
+    issue_activity_updated    BIGINT  NOT NULL,
+    issue_activity_edited     BIGINT  NOT NULL,
+    issue_activity_deleted    BIGINT,
+    issue_activity_repo_id    INTEGER NOT NULL,
+    issue_activity_issue_id   INTEGER NOT NULL,
+    issue_activity_order      INTEGER NOT NULL,
+    issue_activity_type       TEXT    NOT NULL,
+    issue_activity_kind       TEXT    NOT NULL,
+    issue_activity_text       TEXT    NOT NULL,
+    issue_activity_payload    TEXT    NOT NULL DEFAULT '{}',
+    CONSTRAINT fk_issue_activities_created_by FOREIGN KEY (issue_activity_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION,
+    CONSTRAINT fk_issue_activities_repo_id FOREIGN KEY (issue_activity_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT fk_issue_activities_issue_id FOREIGN KEY (issue_activity_issue_id)
+        REFERENCES issues (issue_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE INDEX issue_activities_issue_id_order
+    ON issue_activities (issue_activity_issue_id, issue_activity_order);
@@ -0,0 +1,20 @@
+CREATE TABLE principal_identities
+(
+    identity_id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    identity_principal_id INTEGER NOT NULL,
+    identity_provider     TEXT    NOT NULL,
+    identity_subject      TEXT    NOT NULL,
+    identity_email        TEXT    NOT NULL,
+    identity_created      BIGINT  NOT NULL,
+    identity_updated      BIGINT  NOT NULL,
+    CONSTRAINT fk_identity_principal_id FOREIGN KEY (identity_principal_id)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX principal_identities_provider_subject
+    ON principal_identities (identity_provider, identity_subject);
+
+CREATE INDEX principal_identities_principal_id
+    ON principal_identities (identity_principal_id);
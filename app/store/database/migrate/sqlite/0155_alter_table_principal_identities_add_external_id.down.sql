@@ -0,0 +1 @@
+ALTER TABLE principal_identities DROP COLUMN identity_external_id;
@@ -0,0 +1,2 @@
+ALTER TABLE tokens DROP COLUMN token_last_used;
+ALTER TABLE tokens DROP COLUMN token_scope;
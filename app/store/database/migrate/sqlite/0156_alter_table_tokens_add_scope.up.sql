@@ -0,0 +1,2 @@
+ALTER TABLE tokens ADD COLUMN token_scope TEXT;
+ALTER TABLE tokens ADD COLUMN token_last_used BIGINT;
@@ -0,0 +1 @@
+ALTER TABLE principal_identities DROP COLUMN identity_provisioned;
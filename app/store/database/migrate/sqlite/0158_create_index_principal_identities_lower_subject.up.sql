@@ -0,0 +1,5 @@
+CREATE INDEX principal_identities_provider_lower_subject
+    ON principal_identities (identity_provider, LOWER(identity_subject));
+
+CREATE INDEX principal_identities_provider_lower_external_id
+    ON principal_identities (identity_provider, LOWER(identity_external_id));
@@ -0,0 +1,285 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MilestoneStore = (*MilestoneStore)(nil)
+
+// NewMilestoneStore returns a new MilestoneStore.
+func NewMilestoneStore(db *sqlx.DB) *MilestoneStore {
+	return &MilestoneStore{
+		db: db,
+	}
+}
+
+// MilestoneStore implements store.MilestoneStore backed by a relational database.
+type MilestoneStore struct {
+	db *sqlx.DB
+}
+
+type milestone struct {
+	ID          int64               `db:"milestone_id"`
+	RepoID      int64               `db:"milestone_repo_id"`
+	Title       string              `db:"milestone_title"`
+	Description string              `db:"milestone_description"`
+	State       enum.MilestoneState `db:"milestone_state"`
+	DueDate     null.Int            `db:"milestone_due_date"`
+	CreatedBy   int64               `db:"milestone_created_by"`
+	Created     int64               `db:"milestone_created"`
+	Updated     int64               `db:"milestone_updated"`
+}
+
+const (
+	milestoneColumns = `
+		 milestone_id
+		,milestone_repo_id
+		,milestone_title
+		,milestone_description
+		,milestone_state
+		,milestone_due_date
+		,milestone_created_by
+		,milestone_created
+		,milestone_updated`
+)
+
+// Find finds the milestone by id.
+func (s *MilestoneStore) Find(ctx context.Context, id int64) (*types.Milestone, error) {
+	stmt := database.Builder.
+		Select(milestoneColumns).
+		From("milestones").
+		Where("milestone_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &milestone{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find milestone")
+	}
+
+	return mapMilestone(dst), nil
+}
+
+// Create creates a new milestone.
+func (s *MilestoneStore) Create(ctx context.Context, m *types.Milestone) error {
+	const sqlQuery = `
+		INSERT INTO milestones (
+			 milestone_repo_id
+			,milestone_title
+			,milestone_description
+			,milestone_state
+			,milestone_due_date
+			,milestone_created_by
+			,milestone_created
+			,milestone_updated
+		) VALUES (
+			 :milestone_repo_id
+			,:milestone_title
+			,:milestone_description
+			,:milestone_state
+			,:milestone_due_date
+			,:milestone_created_by
+			,:milestone_created
+			,:milestone_updated
+		) RETURNING milestone_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalMilestone(m))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind milestone object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&m.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert milestone")
+	}
+
+	return nil
+}
+
+// Update updates an existing milestone.
+func (s *MilestoneStore) Update(ctx context.Context, m *types.Milestone) error {
+	const sqlQuery = `
+		UPDATE milestones
+		SET
+			 milestone_title = :milestone_title
+			,milestone_description = :milestone_description
+			,milestone_state = :milestone_state
+			,milestone_due_date = :milestone_due_date
+			,milestone_updated = :milestone_updated
+		WHERE milestone_id = :milestone_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalMilestone(m))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind milestone object")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update milestone")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the milestone by id.
+func (s *MilestoneStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM milestones
+		WHERE milestone_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete milestone")
+	}
+
+	return nil
+}
+
+// List returns the milestones of the repository.
+func (s *MilestoneStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.MilestoneFilter,
+) ([]*types.Milestone, error) {
+	stmt := database.Builder.
+		Select(milestoneColumns).
+		From("milestones").
+		Where("milestone_repo_id = ?", repoID)
+
+	stmt = applyMilestoneFilter(stmt, filter)
+
+	stmt = stmt.OrderBy("LOWER(milestone_title)")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*milestone, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list milestones")
+	}
+
+	result := make([]*types.Milestone, len(dst))
+	for i, m := range dst {
+		result[i] = mapMilestone(m)
+	}
+
+	return result, nil
+}
+
+// Count returns the number of milestones of the repository.
+func (s *MilestoneStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.MilestoneFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("milestones").
+		Where("milestone_repo_id = ?", repoID)
+
+	stmt = applyMilestoneFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count milestones")
+	}
+
+	return count, nil
+}
+
+func applyMilestoneFilter(stmt squirrel.SelectBuilder, filter *types.MilestoneFilter) squirrel.SelectBuilder {
+	if len(filter.States) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"milestone_state": filter.States})
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("milestone_title", filter.Query))
+	}
+
+	return stmt
+}
+
+func mapMilestone(m *milestone) *types.Milestone {
+	return &types.Milestone{
+		ID:          m.ID,
+		RepoID:      m.RepoID,
+		Title:       m.Title,
+		Description: m.Description,
+		State:       m.State,
+		DueDate:     m.DueDate.Ptr(),
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+	}
+}
+
+func mapInternalMilestone(m *types.Milestone) *milestone {
+	return &milestone{
+		ID:          m.ID,
+		RepoID:      m.RepoID,
+		Title:       m.Title,
+		Description: m.Description,
+		State:       m.State,
+		DueDate:     null.IntFromPtr(m.DueDate),
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+	}
+}
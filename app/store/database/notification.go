@@ -0,0 +1,323 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationStore = (*NotificationStore)(nil)
+
+// NewNotificationStore returns a new NotificationStore.
+func NewNotificationStore(db *sqlx.DB) *NotificationStore {
+	return &NotificationStore{
+		db: db,
+	}
+}
+
+// NotificationStore implements store.NotificationStore backed by a relational database.
+type NotificationStore struct {
+	db *sqlx.DB
+}
+
+type notification struct {
+	ID            int64                 `db:"notification_id"`
+	PrincipalID   int64                 `db:"notification_principal_id"`
+	Type          enum.NotificationType `db:"notification_type"`
+	RepoID        int64                 `db:"notification_repo_id"`
+	PullReqID     int64                 `db:"notification_pullreq_id"`
+	PullReqNumber int64                 `db:"notification_pullreq_number"`
+	ActorID       null.Int              `db:"notification_actor_id"`
+	Subject       string                `db:"notification_subject"`
+	Text          string                `db:"notification_text"`
+	URL           string                `db:"notification_url"`
+	Read          bool                  `db:"notification_read"`
+	Created       int64                 `db:"notification_created"`
+	Updated       int64                 `db:"notification_updated"`
+}
+
+const (
+	notificationColumns = `
+		 notification_id
+		,notification_principal_id
+		,notification_type
+		,notification_repo_id
+		,notification_pullreq_id
+		,notification_pullreq_number
+		,notification_actor_id
+		,notification_subject
+		,notification_text
+		,notification_url
+		,notification_read
+		,notification_created
+		,notification_updated`
+)
+
+// Find finds the notification of the principal by id.
+func (s *NotificationStore) Find(ctx context.Context, principalID, id int64) (*types.Notification, error) {
+	stmt := database.Builder.
+		Select(notificationColumns).
+		From("notifications").
+		Where("notification_principal_id = ?", principalID).
+		Where("notification_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notification{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification")
+	}
+
+	return mapNotification(dst), nil
+}
+
+// Create creates a new notification.
+func (s *NotificationStore) Create(ctx context.Context, n *types.Notification) error {
+	const sqlQuery = `
+		INSERT INTO notifications (
+			 notification_principal_id
+			,notification_type
+			,notification_repo_id
+			,notification_pullreq_id
+			,notification_pullreq_number
+			,notification_actor_id
+			,notification_subject
+			,notification_text
+			,notification_url
+			,notification_read
+			,notification_created
+			,notification_updated
+		) VALUES (
+			 :notification_principal_id
+			,:notification_type
+			,:notification_repo_id
+			,:notification_pullreq_id
+			,:notification_pullreq_number
+			,:notification_actor_id
+			,:notification_subject
+			,:notification_text
+			,:notification_url
+			,:notification_read
+			,:notification_created
+			,:notification_updated
+		) RETURNING notification_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalNotification(n))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&n.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert notification")
+	}
+
+	return nil
+}
+
+// UpdateRead marks the notification of the principal as read or unread.
+func (s *NotificationStore) UpdateRead(ctx context.Context, principalID, id int64, read bool) error {
+	stmt := database.Builder.
+		Update("notifications").
+		Set("notification_read", read).
+		Set("notification_updated", time.Now().UnixMilli()).
+		Where("notification_principal_id = ?", principalID).
+		Where("notification_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update notification")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated notifications")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// MarkAllRead marks all unread notifications of the principal as read.
+// It returns the number of updated notifications.
+func (s *NotificationStore) MarkAllRead(ctx context.Context, principalID int64) (int64, error) {
+	stmt := database.Builder.
+		Update("notifications").
+		Set("notification_read", true).
+		Set("notification_updated", time.Now().UnixMilli()).
+		Where("notification_principal_id = ?", principalID).
+		Where("NOT notification_read")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to mark notifications as read")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated notifications")
+	}
+
+	return count, nil
+}
+
+// List returns a list of notifications of the principal, the most recent first.
+func (s *NotificationStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) ([]*types.Notification, error) {
+	stmt := database.Builder.
+		Select(notificationColumns).
+		From("notifications").
+		Where("notification_principal_id = ?", principalID).
+		OrderBy("notification_created DESC", "notification_id DESC")
+
+	stmt = applyNotificationFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notification
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notifications")
+	}
+
+	result := make([]*types.Notification, len(dst))
+	for i := range dst {
+		result[i] = mapNotification(dst[i])
+	}
+
+	return result, nil
+}
+
+// Count returns the number of notifications of the principal.
+func (s *NotificationStore) Count(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("notifications").
+		Where("notification_principal_id = ?", principalID)
+
+	stmt = applyNotificationFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count notifications")
+	}
+
+	return count, nil
+}
+
+func applyNotificationFilter(stmt squirrel.SelectBuilder, filter *types.NotificationFilter) squirrel.SelectBuilder {
+	if filter.UnreadOnly {
+		stmt = stmt.Where("NOT notification_read")
+	}
+
+	if len(filter.Types) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"notification_type": filter.Types})
+	}
+
+	return stmt
+}
+
+func mapNotification(n *notification) *types.Notification {
+	return &types.Notification{
+		ID:            n.ID,
+		PrincipalID:   n.PrincipalID,
+		Type:          n.Type,
+		RepoID:        n.RepoID,
+		PullReqID:     n.PullReqID,
+		PullReqNumber: n.PullReqNumber,
+		ActorID:       n.ActorID.Ptr(),
+		Subject:       n.Subject,
+		Text:          n.Text,
+		URL:           n.URL,
+		Read:          n.Read,
+		Created:       n.Created,
+		Updated:       n.Updated,
+	}
+}
+
+func mapInternalNotification(n *types.Notification) *notification {
+	return &notification{
+		ID:            n.ID,
+		PrincipalID:   n.PrincipalID,
+		Type:          n.Type,
+		RepoID:        n.RepoID,
+		PullReqID:     n.PullReqID,
+		PullReqNumber: n.PullReqNumber,
+		ActorID:       null.IntFromPtr(n.ActorID),
+		Subject:       n.Subject,
+		Text:          n.Text,
+		URL:           n.URL,
+		Read:          n.Read,
+		Created:       n.Created,
+		Updated:       n.Updated,
+	}
+}
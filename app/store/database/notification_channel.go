@@ -0,0 +1,387 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationChannelStore = (*NotificationChannelStore)(nil)
+
+// NewNotificationChannelStore returns a new NotificationChannelStore.
+func NewNotificationChannelStore(db *sqlx.DB) *NotificationChannelStore {
+	return &NotificationChannelStore{
+		db: db,
+	}
+}
+
+// NotificationChannelStore implements store.NotificationChannelStore backed by a relational database.
+type NotificationChannelStore struct {
+	db *sqlx.DB
+}
+
+type notificationChannel struct {
+	ID          int64                        `db:"nchan_id"`
+	SpaceID     int64                        `db:"nchan_space_id"`
+	Identifier  string                       `db:"nchan_identifier"`
+	Description string                       `db:"nchan_description"`
+	Type        enum.NotificationChannelType `db:"nchan_type"`
+	URL         string                       `db:"nchan_url"`
+	Events      string                       `db:"nchan_events"`
+	Templates   string                       `db:"nchan_templates"`
+	Enabled     bool                         `db:"nchan_enabled"`
+	CreatedBy   int64                        `db:"nchan_created_by"`
+	Created     int64                        `db:"nchan_created"`
+	Updated     int64                        `db:"nchan_updated"`
+}
+
+const (
+	notificationChannelColumns = `
+		 nchan_id
+		,nchan_space_id
+		,nchan_identifier
+		,nchan_description
+		,nchan_type
+		,nchan_url
+		,nchan_events
+		,nchan_templates
+		,nchan_enabled
+		,nchan_created_by
+		,nchan_created
+		,nchan_updated`
+)
+
+// Find finds the notification channel by id.
+func (s *NotificationChannelStore) Find(ctx context.Context, id int64) (*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where("nchan_id = ?", id)
+
+	return s.find(ctx, stmt)
+}
+
+// FindByIdentifier finds the notification channel of the space by its identifier.
+func (s *NotificationChannelStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where("nchan_space_id = ?", spaceID).
+		Where("LOWER(nchan_identifier) = ?", strings.ToLower(identifier))
+
+	return s.find(ctx, stmt)
+}
+
+func (s *NotificationChannelStore) find(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) (*types.NotificationChannel, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationChannel{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification channel")
+	}
+
+	return mapNotificationChannel(dst)
+}
+
+// Create creates a new notification channel.
+func (s *NotificationChannelStore) Create(ctx context.Context, channel *types.NotificationChannel) error {
+	const sqlQuery = `
+		INSERT INTO notification_channels (
+			 nchan_space_id
+			,nchan_identifier
+			,nchan_description
+			,nchan_type
+			,nchan_url
+			,nchan_events
+			,nchan_templates
+			,nchan_enabled
+			,nchan_created_by
+			,nchan_created
+			,nchan_updated
+		) VALUES (
+			 :nchan_space_id
+			,:nchan_identifier
+			,:nchan_description
+			,:nchan_type
+			,:nchan_url
+			,:nchan_events
+			,:nchan_templates
+			,:nchan_enabled
+			,:nchan_created_by
+			,:nchan_created
+			,:nchan_updated
+		) RETURNING nchan_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbChannel, err := mapInternalNotificationChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := db.BindNamed(sqlQuery, dbChannel)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification channel object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&channel.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert notification channel")
+	}
+
+	return nil
+}
+
+// Update updates an existing notification channel.
+func (s *NotificationChannelStore) Update(ctx context.Context, channel *types.NotificationChannel) error {
+	const sqlQuery = `
+		UPDATE notification_channels
+		SET
+			 nchan_identifier = :nchan_identifier
+			,nchan_description = :nchan_description
+			,nchan_type = :nchan_type
+			,nchan_url = :nchan_url
+			,nchan_events = :nchan_events
+			,nchan_templates = :nchan_templates
+			,nchan_enabled = :nchan_enabled
+			,nchan_updated = :nchan_updated
+		WHERE nchan_id = :nchan_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbChannel, err := mapInternalNotificationChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := db.BindNamed(sqlQuery, dbChannel)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification channel object")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update notification channel")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the notification channel by id.
+func (s *NotificationChannelStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM notification_channels
+		WHERE nchan_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification channel")
+	}
+
+	return nil
+}
+
+// List returns the notification channels of the space.
+func (s *NotificationChannelStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter types.ListQueryFilter,
+) ([]*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where("nchan_space_id = ?", spaceID).
+		OrderBy("LOWER(nchan_identifier)")
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("nchan_identifier", filter.Query))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	return s.list(ctx, stmt)
+}
+
+// Count returns the number of notification channels of the space.
+func (s *NotificationChannelStore) Count(
+	ctx context.Context,
+	spaceID int64,
+	filter types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("notification_channels").
+		Where("nchan_space_id = ?", spaceID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("nchan_identifier", filter.Query))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count notification channels")
+	}
+
+	return count, nil
+}
+
+// ListEnabled returns all enabled notification channels of the provided spaces.
+func (s *NotificationChannelStore) ListEnabled(
+	ctx context.Context,
+	spaceIDs []int64,
+) ([]*types.NotificationChannel, error) {
+	if len(spaceIDs) == 0 {
+		return nil, nil
+	}
+
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where(squirrel.Eq{"nchan_space_id": spaceIDs}).
+		Where("nchan_enabled = ?", true).
+		OrderBy("nchan_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *NotificationChannelStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.NotificationChannel, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*notificationChannel, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification channels")
+	}
+
+	result := make([]*types.NotificationChannel, len(dst))
+	for i, c := range dst {
+		if result[i], err = mapNotificationChannel(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func mapNotificationChannel(c *notificationChannel) (*types.NotificationChannel, error) {
+	channel := &types.NotificationChannel{
+		ID:          c.ID,
+		SpaceID:     c.SpaceID,
+		Identifier:  c.Identifier,
+		Description: c.Description,
+		Type:        c.Type,
+		URL:         c.URL,
+		Enabled:     c.Enabled,
+		CreatedBy:   c.CreatedBy,
+		Created:     c.Created,
+		Updated:     c.Updated,
+	}
+
+	if err := json.Unmarshal([]byte(c.Events), &channel.Events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification channel events: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(c.Templates), &channel.Templates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification channel templates: %w", err)
+	}
+
+	return channel, nil
+}
+
+func mapInternalNotificationChannel(channel *types.NotificationChannel) (*notificationChannel, error) {
+	events := channel.Events
+	if events == nil {
+		events = []enum.NotificationChannelEvent{}
+	}
+
+	eventsJSON, err := json.Marshal(events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification channel events: %w", err)
+	}
+
+	templates := channel.Templates
+	if templates == nil {
+		templates = map[enum.NotificationChannelEvent]string{}
+	}
+
+	templatesJSON, err := json.Marshal(templates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification channel templates: %w", err)
+	}
+
+	return &notificationChannel{
+		ID:          channel.ID,
+		SpaceID:     channel.SpaceID,
+		Identifier:  channel.Identifier,
+		Description: channel.Description,
+		Type:        channel.Type,
+		URL:         channel.URL,
+		Events:      string(eventsJSON),
+		Templates:   string(templatesJSON),
+		Enabled:     channel.Enabled,
+		CreatedBy:   channel.CreatedBy,
+		Created:     channel.Created,
+		Updated:     channel.Updated,
+	}, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationChannelStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+
+	channelStore := database.NewNotificationChannelStore(db)
+
+	slack := &types.NotificationChannel{
+		SpaceID:    1,
+		Identifier: "slack",
+		Type:       enum.NotificationChannelTypeSlack,
+		URL:        "https://hooks.slack.example/services/1",
+		Events: []enum.NotificationChannelEvent{
+			enum.NotificationChannelEventPullReqCreated,
+			enum.NotificationChannelEventPipelineFailed,
+		},
+		Templates: map[enum.NotificationChannelEvent]string{
+			enum.NotificationChannelEventPullReqCreated: "new PR {{.Base.PullReq.Title}}",
+		},
+		Enabled:   true,
+		CreatedBy: userID,
+	}
+	require.NoError(t, channelStore.Create(ctx, slack))
+
+	teams := &types.NotificationChannel{
+		SpaceID:    2,
+		Identifier: "teams",
+		Type:       enum.NotificationChannelTypeMSTeams,
+		URL:        "https://teams.example/webhook",
+		Events:     []enum.NotificationChannelEvent{enum.NotificationChannelEventRuleBypassed},
+		Enabled:    false,
+		CreatedBy:  userID,
+	}
+	require.NoError(t, channelStore.Create(ctx, teams))
+
+	duplicate := *slack
+	duplicate.Identifier = "SLACK"
+	require.ErrorIs(t, channelStore.Create(ctx, &duplicate), gitness_store.ErrDuplicate)
+
+	found, err := channelStore.FindByIdentifier(ctx, 1, "Slack")
+	require.NoError(t, err)
+	require.Equal(t, slack.ID, found.ID)
+	require.Equal(t, slack.Events, found.Events)
+	require.Equal(t, slack.Templates, found.Templates)
+
+	_, err = channelStore.FindByIdentifier(ctx, 2, "slack")
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	found, err = channelStore.Find(ctx, teams.ID)
+	require.NoError(t, err)
+	require.Empty(t, found.Templates)
+
+	enabled, err := channelStore.ListEnabled(ctx, []int64{1, 2})
+	require.NoError(t, err)
+	require.Len(t, enabled, 1)
+	require.Equal(t, slack.ID, enabled[0].ID)
+
+	teams.Enabled = true
+	require.NoError(t, channelStore.Update(ctx, teams))
+
+	enabled, err = channelStore.ListEnabled(ctx, []int64{1, 2})
+	require.NoError(t, err)
+	require.Len(t, enabled, 2)
+
+	list, err := channelStore.List(ctx, 1, types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: 10}})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	count, err := channelStore.Count(ctx, 2, types.ListQueryFilter{Query: "tea"})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+
+	require.NoError(t, channelStore.Delete(ctx, slack.ID))
+
+	_, err = channelStore.Find(ctx, slack.ID)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationSubscriptionStore = (*NotificationSubscriptionStore)(nil)
+
+// NewNotificationSubscriptionStore returns a new NotificationSubscriptionStore.
+func NewNotificationSubscriptionStore(db *sqlx.DB) *NotificationSubscriptionStore {
+	return &NotificationSubscriptionStore{
+		db: db,
+	}
+}
+
+// NotificationSubscriptionStore implements store.NotificationSubscriptionStore backed by a relational database.
+type NotificationSubscriptionStore struct {
+	db *sqlx.DB
+}
+
+type notificationSubscription struct {
+	PrincipalID  int64                  `db:"nsub_principal_id"`
+	ResourceType enum.ResourceType      `db:"nsub_resource_type"`
+	ResourceID   int64                  `db:"nsub_resource_id"`
+	Level        enum.NotificationLevel `db:"nsub_level"`
+	Created      int64                  `db:"nsub_created"`
+	Updated      int64                  `db:"nsub_updated"`
+}
+
+const (
+	notificationSubscriptionColumns = `
+		 nsub_principal_id
+		,nsub_resource_type
+		,nsub_resource_id
+		,nsub_level
+		,nsub_created
+		,nsub_updated`
+)
+
+// Upsert creates a new notification subscription or updates the level of an existing one.
+func (s *NotificationSubscriptionStore) Upsert(ctx context.Context, sub *types.NotificationSubscription) error {
+	const sqlQuery = `
+		INSERT INTO notification_subscriptions (
+			 nsub_principal_id
+			,nsub_resource_type
+			,nsub_resource_id
+			,nsub_level
+			,nsub_created
+			,nsub_updated
+		) VALUES (
+			 :nsub_principal_id
+			,:nsub_resource_type
+			,:nsub_resource_id
+			,:nsub_level
+			,:nsub_created
+			,:nsub_updated
+		) ON CONFLICT (nsub_principal_id, nsub_resource_type, nsub_resource_id) DO UPDATE SET
+			 nsub_level = EXCLUDED.nsub_level
+			,nsub_updated = EXCLUDED.nsub_updated
+		RETURNING nsub_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalNotificationSubscription(sub))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification subscription object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&sub.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification subscription")
+	}
+
+	return nil
+}
+
+// Delete deletes the notification subscription of the principal for the resource.
+func (s *NotificationSubscriptionStore) Delete(
+	ctx context.Context,
+	principalID int64,
+	resourceType enum.ResourceType,
+	resourceID int64,
+) error {
+	const sqlQuery = `
+		DELETE FROM notification_subscriptions
+		WHERE nsub_principal_id = $1 AND nsub_resource_type = $2 AND nsub_resource_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, resourceType, resourceID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification subscription")
+	}
+
+	return nil
+}
+
+// List returns all notification subscriptions of the principal.
+func (s *NotificationSubscriptionStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationSubscription, error) {
+	stmt := database.Builder.
+		Select(notificationSubscriptionColumns).
+		From("notification_subscriptions").
+		Where("nsub_principal_id = ?", principalID).
+		OrderBy("nsub_resource_type", "nsub_resource_id")
+
+	return s.list(ctx, stmt)
+}
+
+// ListForResources returns the notification subscriptions of the principals
+// for the repository and for any of the spaces.
+func (s *NotificationSubscriptionStore) ListForResources(
+	ctx context.Context,
+	principalIDs []int64,
+	repoID int64,
+	spaceIDs []int64,
+) ([]*types.NotificationSubscription, error) {
+	resources := squirrel.Or{
+		squirrel.Eq{"nsub_resource_type": enum.ResourceTypeRepo, "nsub_resource_id": repoID},
+	}
+	if len(spaceIDs) > 0 {
+		resources = append(resources,
+			squirrel.Eq{"nsub_resource_type": enum.ResourceTypeSpace, "nsub_resource_id": spaceIDs})
+	}
+
+	stmt := database.Builder.
+		Select(notificationSubscriptionColumns).
+		From("notification_subscriptions").
+		Where(squirrel.Eq{"nsub_principal_id": principalIDs}).
+		Where(resources)
+
+	return s.list(ctx, stmt)
+}
+
+func (s *NotificationSubscriptionStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.NotificationSubscription, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationSubscription
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification subscriptions")
+	}
+
+	result := make([]*types.NotificationSubscription, len(dst))
+	for i := range dst {
+		result[i] = mapNotificationSubscription(dst[i])
+	}
+
+	return result, nil
+}
+
+func mapNotificationSubscription(sub *notificationSubscription) *types.NotificationSubscription {
+	return &types.NotificationSubscription{
+		PrincipalID:  sub.PrincipalID,
+		ResourceType: sub.ResourceType,
+		ResourceID:   sub.ResourceID,
+		Level:        sub.Level,
+		Created:      sub.Created,
+		Updated:      sub.Updated,
+	}
+}
+
+func mapInternalNotificationSubscription(sub *types.NotificationSubscription) *notificationSubscription {
+	return &notificationSubscription{
+		PrincipalID:  sub.PrincipalID,
+		ResourceType: sub.ResourceType,
+		ResourceID:   sub.ResourceID,
+		Level:        sub.Level,
+		Created:      sub.Created,
+		Updated:      sub.Updated,
+	}
+}
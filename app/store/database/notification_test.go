@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+
+	pr := &types.PullReq{
+		Number:       1,
+		CreatedBy:    userID,
+		State:        enum.PullReqStateOpen,
+		Title:        "pr",
+		SourceRepoID: 1,
+		SourceBranch: "feature",
+		TargetRepoID: 1,
+		TargetBranch: "main",
+		MergeBaseSHA: "0000000000000000000000000000000000000000",
+	}
+	require.NoError(t, pullreqStore.Create(ctx, pr))
+
+	notificationStore := database.NewNotificationStore(db)
+
+	notificationTypes := []enum.NotificationType{
+		enum.NotificationTypePullReqMention,
+		enum.NotificationTypePullReqComment,
+		enum.NotificationTypePullReqReviewSubmitted,
+	}
+	ids := make([]int64, len(notificationTypes))
+	for i, notificationType := range notificationTypes {
+		n := &types.Notification{
+			PrincipalID:   userID,
+			Type:          notificationType,
+			RepoID:        1,
+			PullReqID:     pr.ID,
+			PullReqNumber: pr.Number,
+			ActorID:       &userID,
+			Subject:       "subject",
+			Created:       int64(i + 1),
+			Updated:       int64(i + 1),
+		}
+		require.NoError(t, notificationStore.Create(ctx, n))
+		ids[i] = n.ID
+	}
+
+	require.NoError(t, notificationStore.UpdateRead(ctx, userID, ids[0], true))
+
+	err := notificationStore.UpdateRead(ctx, userID+1, ids[1], true)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	n, err := notificationStore.Find(ctx, userID, ids[0])
+	require.NoError(t, err)
+	require.True(t, n.Read)
+	require.Equal(t, userID, *n.ActorID)
+
+	unread := &types.NotificationFilter{UnreadOnly: true}
+	list, err := notificationStore.List(ctx, userID, unread)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, ids[2], list[0].ID)
+	require.Equal(t, ids[1], list[1].ID)
+
+	count, err := notificationStore.Count(ctx, userID, &types.NotificationFilter{
+		Types: []enum.NotificationType{enum.NotificationTypePullReqMention},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	updated, err := notificationStore.MarkAllRead(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updated)
+
+	count, err = notificationStore.Count(ctx, userID, unread)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestNotificationSubscriptionStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	subscriptionStore := database.NewNotificationSubscriptionStore(db)
+
+	upsert := func(resourceType enum.ResourceType, resourceID int64, level enum.NotificationLevel, now int64) {
+		require.NoError(t, subscriptionStore.Upsert(ctx, &types.NotificationSubscription{
+			PrincipalID:  userID,
+			ResourceType: resourceType,
+			ResourceID:   resourceID,
+			Level:        level,
+			Created:      now,
+			Updated:      now,
+		}))
+	}
+
+	upsert(enum.ResourceTypeSpace, 1, enum.NotificationLevelNone, 1)
+	upsert(enum.ResourceTypeSpace, 2, enum.NotificationLevelDirect, 2)
+	upsert(enum.ResourceTypeRepo, 1, enum.NotificationLevelDirect, 3)
+	upsert(enum.ResourceTypeRepo, 1, enum.NotificationLevelAll, 4)
+
+	list, err := subscriptionStore.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+
+	list, err = subscriptionStore.ListForResources(ctx, []int64{userID}, 1, []int64{1})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	for _, sub := range list {
+		if sub.ResourceType == enum.ResourceTypeRepo {
+			require.Equal(t, enum.NotificationLevelAll, sub.Level)
+			require.Equal(t, int64(3), sub.Created)
+			require.Equal(t, int64(4), sub.Updated)
+		}
+	}
+
+	require.NoError(t, subscriptionStore.Delete(ctx, userID, enum.ResourceTypeRepo, 1))
+
+	list, err = subscriptionStore.ListForResources(ctx, []int64{userID}, 1, nil)
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
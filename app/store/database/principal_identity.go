@@ -0,0 +1,319 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.PrincipalIdentityStore = PrincipalIdentityStore{}
+
+// NewPrincipalIdentityStore returns a new PrincipalIdentityStore.
+func NewPrincipalIdentityStore(db *sqlx.DB) PrincipalIdentityStore {
+	return PrincipalIdentityStore{
+		db: db,
+	}
+}
+
+// PrincipalIdentityStore implements a store.PrincipalIdentityStore backed by a relational database.
+type PrincipalIdentityStore struct {
+	db *sqlx.DB
+}
+
+type principalIdentity struct {
+	ID          int64                 `db:"identity_id"`
+	PrincipalID int64                 `db:"identity_principal_id"`
+	Provider    enum.IdentityProvider `db:"identity_provider"`
+	Subject     string                `db:"identity_subject"`
+	ExternalID  string                `db:"identity_external_id"`
+	Email       string                `db:"identity_email"`
+	Provisioned bool                  `db:"identity_provisioned"`
+	Created     int64                 `db:"identity_created"`
+	Updated     int64                 `db:"identity_updated"`
+}
+
+const (
+	principalIdentityColumns = `
+		 identity_id
+		,identity_principal_id
+		,identity_provider
+		,identity_subject
+		,identity_external_id
+		,identity_email
+		,identity_provisioned
+		,identity_created
+		,identity_updated`
+
+	principalIdentitySelectBase = `
+	SELECT` + principalIdentityColumns + `
+	FROM principal_identities`
+)
+
+// Find returns the identity of the provider with the given subject.
+func (s PrincipalIdentityStore) Find(
+	ctx context.Context,
+	provider enum.IdentityProvider,
+	subject string,
+) (*types.PrincipalIdentity, error) {
+	const sqlQuery = principalIdentitySelectBase + `
+	WHERE identity_provider = $1 AND identity_subject = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &principalIdentity{}
+	if err := db.GetContext(ctx, result, sqlQuery, provider, subject); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find principal identity")
+	}
+
+	identity := mapToPrincipalIdentity(result)
+
+	return &identity, nil
+}
+
+// Create links a new external identity to a principal.
+func (s PrincipalIdentityStore) Create(ctx context.Context, identity *types.PrincipalIdentity) error {
+	const sqlQuery = `
+	INSERT INTO principal_identities (
+		 identity_principal_id
+		,identity_provider
+		,identity_subject
+		,identity_external_id
+		,identity_email
+		,identity_provisioned
+		,identity_created
+		,identity_updated
+	) values (
+		 :identity_principal_id
+		,:identity_provider
+		,:identity_subject
+		,:identity_external_id
+		,:identity_email
+		,:identity_provisioned
+		,:identity_created
+		,:identity_updated
+	) RETURNING identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPrincipalIdentity(identity))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind principal identity object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&identity.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert principal identity query failed")
+	}
+
+	return nil
+}
+
+// Update updates the subject, the external ID and the email of an external identity.
+func (s PrincipalIdentityStore) Update(ctx context.Context, identity *types.PrincipalIdentity) error {
+	const sqlQuery = `
+	UPDATE principal_identities
+	SET
+		 identity_subject = :identity_subject
+		,identity_external_id = :identity_external_id
+		,identity_email = :identity_email
+		,identity_updated = :identity_updated
+	WHERE identity_id = :identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPrincipalIdentity(identity))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind principal identity object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update principal identity query failed")
+	}
+
+	return nil
+}
+
+// ListByPrincipal returns all external identities linked to a principal.
+func (s PrincipalIdentityStore) ListByPrincipal(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.PrincipalIdentity, error) {
+	const sqlQuery = principalIdentitySelectBase + `
+	WHERE identity_principal_id = $1
+	ORDER BY identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var result []*principalIdentity
+	if err := db.SelectContext(ctx, &result, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list principal identities")
+	}
+
+	return mapToPrincipalIdentities(result), nil
+}
+
+// ListByProvider returns all external identities of the provider.
+func (s PrincipalIdentityStore) ListByProvider(
+	ctx context.Context,
+	provider enum.IdentityProvider,
+) ([]*types.PrincipalIdentity, error) {
+	const sqlQuery = principalIdentitySelectBase + `
+	WHERE identity_provider = $1
+	ORDER BY identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var result []*principalIdentity
+	if err := db.SelectContext(ctx, &result, sqlQuery, provider); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list principal identities of provider")
+	}
+
+	return mapToPrincipalIdentities(result), nil
+}
+
+// Count returns the number of external identities of users matching the filter.
+func (s PrincipalIdentityStore) Count(ctx context.Context, filter *types.PrincipalIdentityFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("principal_identities")
+
+	stmt = applyPrincipalIdentityFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count principal identities query")
+	}
+
+	return count, nil
+}
+
+// List returns the external identities of users matching the filter, ordered by the ID.
+func (s PrincipalIdentityStore) List(
+	ctx context.Context,
+	filter *types.PrincipalIdentityFilter,
+) ([]*types.PrincipalIdentity, error) {
+	stmt := database.Builder.
+		Select(principalIdentityColumns).
+		From("principal_identities").
+		OrderBy("identity_id")
+
+	stmt = applyPrincipalIdentityFilter(stmt, filter)
+
+	if filter.Limit > 0 {
+		stmt = stmt.Limit(uint64(filter.Limit)) //nolint:gosec // limit is positive
+	}
+	if filter.Offset > 0 {
+		stmt = stmt.Offset(uint64(filter.Offset)) //nolint:gosec // offset is positive
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var result []*principalIdentity
+	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list principal identities query")
+	}
+
+	return mapToPrincipalIdentities(result), nil
+}
+
+// applyPrincipalIdentityFilter joins the identities to the users they are linked to and applies the filter.
+func applyPrincipalIdentityFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.PrincipalIdentityFilter,
+) squirrel.SelectBuilder {
+	stmt = stmt.
+		InnerJoin("principals ON principal_id = identity_principal_id").
+		Where("principal_type = ?", enum.PrincipalTypeUser)
+
+	if filter.Provider != "" {
+		stmt = stmt.Where("identity_provider = ?", filter.Provider)
+	}
+	if filter.PrincipalID != 0 {
+		stmt = stmt.Where("identity_principal_id = ?", filter.PrincipalID)
+	}
+	if filter.Subject != "" {
+		stmt = stmt.Where("LOWER(identity_subject) = ?", strings.ToLower(filter.Subject))
+	}
+	if filter.ExternalID != "" {
+		stmt = stmt.Where("LOWER(identity_external_id) = ?", strings.ToLower(filter.ExternalID))
+	}
+	if filter.Email != "" {
+		stmt = stmt.Where("LOWER(principal_email) = ?", strings.ToLower(filter.Email))
+	}
+	if filter.DisplayName != "" {
+		stmt = stmt.Where("LOWER(principal_display_name) = ?", strings.ToLower(filter.DisplayName))
+	}
+
+	return stmt
+}
+
+func mapToInternalPrincipalIdentity(in *types.PrincipalIdentity) principalIdentity {
+	return principalIdentity{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Provider:    in.Provider,
+		Subject:     in.Subject,
+		ExternalID:  in.ExternalID,
+		Email:       in.Email,
+		Provisioned: in.Provisioned,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapToPrincipalIdentity(in *principalIdentity) types.PrincipalIdentity {
+	return types.PrincipalIdentity{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Provider:    in.Provider,
+		Subject:     in.Subject,
+		ExternalID:  in.ExternalID,
+		Email:       in.Email,
+		Provisioned: in.Provisioned,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapToPrincipalIdentities(in []*principalIdentity) []*types.PrincipalIdentity {
+	identities := make([]*types.PrincipalIdentity, len(in))
+	for i, r := range in {
+		identity := mapToPrincipalIdentity(r)
+		identities[i] = &identity
+	}
+	return identities
+}
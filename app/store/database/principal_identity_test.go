@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrincipalIdentityStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	identityStore := database.NewPrincipalIdentityStore(db)
+
+	_, err := identityStore.Find(ctx, enum.IdentityProviderOIDC, "subject-1")
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	identity := &types.PrincipalIdentity{
+		PrincipalID: userID,
+		Provider:    enum.IdentityProviderOIDC,
+		Subject:     "subject-1",
+		Email:       "user@example.com",
+		Provisioned: true,
+		Created:     1,
+		Updated:     1,
+	}
+	require.NoError(t, identityStore.Create(ctx, identity))
+	require.NotZero(t, identity.ID)
+
+	duplicate := *identity
+	require.ErrorIs(t, identityStore.Create(ctx, &duplicate), gitness_store.ErrDuplicate)
+
+	identity.Subject = "subject-2"
+	identity.ExternalID = "external-2"
+	identity.Email = "new@example.com"
+	identity.Updated = 2
+	require.NoError(t, identityStore.Update(ctx, identity))
+
+	_, err = identityStore.Find(ctx, enum.IdentityProviderOIDC, "subject-1")
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	found, err := identityStore.Find(ctx, enum.IdentityProviderOIDC, "subject-2")
+	require.NoError(t, err)
+	require.Equal(t, identity, found)
+
+	identities, err := identityStore.ListByPrincipal(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	require.Equal(t, identity, identities[0])
+
+	identities, err = identityStore.ListByProvider(ctx, enum.IdentityProviderOIDC)
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+
+	identities, err = identityStore.ListByProvider(ctx, enum.IdentityProviderLDAP)
+	require.NoError(t, err)
+	require.Empty(t, identities)
+}
+
+func TestPrincipalIdentityStoreList(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+	identityStore := database.NewPrincipalIdentityStore(db)
+
+	ctx := context.Background()
+
+	for i := int64(1); i <= 3; i++ {
+		require.NoError(t, principalStore.CreateUser(ctx, &types.User{
+			ID:          i,
+			UID:         "user_" + strconv.FormatInt(i, 10),
+			Email:       "User" + strconv.FormatInt(i, 10) + "@example.com",
+			DisplayName: "User " + strconv.FormatInt(i, 10),
+		}))
+
+		require.NoError(t, identityStore.Create(ctx, &types.PrincipalIdentity{
+			PrincipalID: i,
+			Provider:    enum.IdentityProviderSCIM,
+			Subject:     "User" + strconv.FormatInt(i, 10) + "@example.com",
+			ExternalID:  "ext-" + strconv.FormatInt(i, 10),
+		}))
+	}
+
+	require.NoError(t, identityStore.Create(ctx, &types.PrincipalIdentity{
+		PrincipalID: 1,
+		Provider:    enum.IdentityProviderOIDC,
+		Subject:     "oidc-1",
+	}))
+
+	principalIDs := func(identities []*types.PrincipalIdentity) []int64 {
+		ids := make([]int64, len(identities))
+		for i, identity := range identities {
+			ids[i] = identity.PrincipalID
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name     string
+		filter   types.PrincipalIdentityFilter
+		expCount int64
+		expIDs   []int64
+	}{
+		{
+			name:     "all",
+			filter:   types.PrincipalIdentityFilter{Provider: enum.IdentityProviderSCIM},
+			expCount: 3,
+			expIDs:   []int64{1, 2, 3},
+		},
+		{
+			name:     "page",
+			filter:   types.PrincipalIdentityFilter{Provider: enum.IdentityProviderSCIM, Offset: 1, Limit: 1},
+			expCount: 3,
+			expIDs:   []int64{2},
+		},
+		{
+			name:     "subject",
+			filter:   types.PrincipalIdentityFilter{Provider: enum.IdentityProviderSCIM, Subject: "user2@EXAMPLE.com"},
+			expCount: 1,
+			expIDs:   []int64{2},
+		},
+		{
+			name:     "external id",
+			filter:   types.PrincipalIdentityFilter{Provider: enum.IdentityProviderSCIM, ExternalID: "ext-3"},
+			expCount: 1,
+			expIDs:   []int64{3},
+		},
+		{
+			name:     "user attributes",
+			filter:   types.PrincipalIdentityFilter{Provider: enum.IdentityProviderSCIM, Email: "user1@example.com"},
+			expCount: 1,
+			expIDs:   []int64{1},
+		},
+		{
+			name:     "other provider",
+			filter:   types.PrincipalIdentityFilter{Provider: enum.IdentityProviderOIDC, DisplayName: "user 1"},
+			expCount: 1,
+			expIDs:   []int64{1},
+		},
+		{
+			name:     "no match",
+			filter:   types.PrincipalIdentityFilter{Provider: enum.IdentityProviderSCIM, PrincipalID: 4},
+			expCount: 0,
+			expIDs:   []int64{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			count, err := identityStore.Count(ctx, &test.filter)
+			require.NoError(t, err)
+			require.Equal(t, test.expCount, count)
+
+			identities, err := identityStore.List(ctx, &test.filter)
+			require.NoError(t, err)
+			require.Equal(t, test.expIDs, principalIDs(identities))
+		})
+	}
+
+	users, err := principalStore.FindManyUsersByID(ctx, []int64{3, 1, 4})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+}
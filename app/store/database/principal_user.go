@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
@@ -96,6 +97,34 @@ func (s *PrincipalStore) FindUserByEmail(ctx context.Context, email string) (*ty
 	return s.mapDBUser(dst), nil
 }
 
+// FindManyUsersByID returns all users found for the provided IDs.
+// If an ID isn't found, it's not returned in the list.
+func (s *PrincipalStore) FindManyUsersByID(ctx context.Context, ids []int64) ([]*types.User, error) {
+	if len(ids) == 0 {
+		return []*types.User{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(userColumns).
+		From("principals").
+		Where("principal_type = 'user'").
+		Where(squirrel.Eq{"principal_id": ids})
+
+	sqlQuery, params, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to generate find many users query")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*user{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, params...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "find many users by id query failed")
+	}
+
+	return s.mapDBUsers(dst), nil
+}
+
 // CreateUser saves the user details.
 func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error {
 	const sqlQuery = `
@@ -80,6 +80,8 @@ type pullReq struct {
 	TargetRepoID int64  `db:"pullreq_target_repo_id"`
 	TargetBranch string `db:"pullreq_target_branch"`
 
+	ParentID null.Int `db:"pullreq_parent_id"`
+
 	ActivitySeq int64 `db:"pullreq_activity_seq"`
 
 	MergedBy    null.Int    `db:"pullreq_merged_by"`
@@ -122,6 +124,7 @@ const (
 		,pullreq_source_sha
 		,pullreq_target_repo_id
 		,pullreq_target_branch
+		,pullreq_parent_id
 		,pullreq_activity_seq
 		,pullreq_merged_by
 		,pullreq_merged
@@ -222,6 +225,7 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_source_sha
 		,pullreq_target_repo_id
 		,pullreq_target_branch
+		,pullreq_parent_id
 		,pullreq_activity_seq
 		,pullreq_merged_by
 		,pullreq_merged
@@ -257,6 +261,7 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_source_sha
 		,:pullreq_target_repo_id
 		,:pullreq_target_branch
+		,:pullreq_parent_id
 		,:pullreq_activity_seq
 		,:pullreq_merged_by
 		,:pullreq_merged
@@ -307,6 +312,7 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_activity_seq = :pullreq_activity_seq
 		,pullreq_source_sha = :pullreq_source_sha
 		,pullreq_target_branch = :pullreq_target_branch
+		,pullreq_parent_id = :pullreq_parent_id
 		,pullreq_merged_by = :pullreq_merged_by
 		,pullreq_merged = :pullreq_merged
 		,pullreq_merge_method = :pullreq_merge_method
@@ -558,6 +564,45 @@ func (s *PullReqStore) Stream(ctx context.Context, opts *types.PullReqFilter) (<
 	return chPRs, chErr
 }
 
+// ListStack returns all pull requests of the pull request stack the provided pull request belongs to.
+// The stack is the tree of pull requests connected by parent links, starting from the root pull request.
+func (s *PullReqStore) ListStack(ctx context.Context, id int64) ([]*types.PullReq, error) {
+	// NOTE: UNION (instead of UNION ALL) stops the recursion in case of a parent link cycle.
+	const sqlQuery = `
+	WITH RECURSIVE
+	stack_ancestors(ancestor_id, ancestor_parent_id) AS (
+		SELECT pullreq_id, pullreq_parent_id
+		FROM pullreqs
+		WHERE pullreq_id = $1
+		UNION
+		SELECT pullreq_id, pullreq_parent_id
+		FROM pullreqs
+		JOIN stack_ancestors ON pullreq_id = ancestor_parent_id
+	),
+	stack_members(member_id) AS (
+		SELECT ancestor_id
+		FROM stack_ancestors
+		WHERE ancestor_parent_id IS NULL
+		UNION
+		SELECT pullreq_id
+		FROM pullreqs
+		JOIN stack_members ON pullreq_parent_id = member_id
+	)
+	SELECT` + pullReqColumnsNoDescription + `
+	FROM pullreqs
+	WHERE pullreq_id IN (SELECT member_id FROM stack_members)
+	ORDER BY pullreq_number`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pullReq, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request stack")
+	}
+
+	return s.mapSlicePullReq(ctx, dst)
+}
+
 func (s *PullReqStore) ListOpenByBranchName(
 	ctx context.Context,
 	repoID int64,
@@ -639,6 +684,10 @@ func (s *PullReqStore) applyFilter(stmt *squirrel.SelectBuilder, opts *types.Pul
 		*stmt = stmt.Where("pullreq_target_branch = ?", opts.TargetBranch)
 	}
 
+	if opts.ParentID != 0 {
+		*stmt = stmt.Where("pullreq_parent_id = ?", opts.ParentID)
+	}
+
 	if opts.Query != "" {
 		*stmt = stmt.Where(PartialMatch("pullreq_title", opts.Query))
 	}
@@ -705,6 +754,7 @@ func (s *PullReqStore) applyFilter(stmt *squirrel.SelectBuilder, opts *types.Pul
 	if opts.CommenterID > 0 {
 		*stmt = stmt.InnerJoin("pullreq_activities act_com ON act_com.pullreq_activity_pullreq_id = pullreq_id")
 		*stmt = stmt.Where("act_com.pullreq_activity_deleted IS NULL")
+		*stmt = stmt.Where("NOT act_com.pullreq_activity_pending")
 		*stmt = stmt.Where("(" +
 			"act_com.pullreq_activity_kind = '" + string(enum.PullReqActivityKindComment) + "' OR " +
 			"act_com.pullreq_activity_kind = '" + string(enum.PullReqActivityKindChangeComment) + "')")
@@ -723,6 +773,7 @@ func (s *PullReqStore) applyFilter(stmt *squirrel.SelectBuilder, opts *types.Pul
 	if opts.MentionedID > 0 {
 		*stmt = stmt.InnerJoin("pullreq_activities act_ment ON act_ment.pullreq_activity_pullreq_id = pullreq_id")
 		*stmt = stmt.Where("act_ment.pullreq_activity_deleted IS NULL")
+		*stmt = stmt.Where("NOT act_ment.pullreq_activity_pending")
 		*stmt = stmt.Where("(" +
 			"act_ment.pullreq_activity_kind = '" + string(enum.PullReqActivityKindComment) + "' OR " +
 			"act_ment.pullreq_activity_kind = '" + string(enum.PullReqActivityKindChangeComment) + "')")
@@ -798,6 +849,7 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 		SourceSHA:               pr.SourceSHA,
 		TargetRepoID:            pr.TargetRepoID,
 		TargetBranch:            pr.TargetBranch,
+		ParentID:                pr.ParentID.Ptr(),
 		ActivitySeq:             pr.ActivitySeq,
 		MergedBy:                pr.MergedBy.Ptr(),
 		Merged:                  pr.Merged.Ptr(),
@@ -848,6 +900,7 @@ func mapInternalPullReq(pr *types.PullReq) *pullReq {
 		SourceSHA:               pr.SourceSHA,
 		TargetRepoID:            pr.TargetRepoID,
 		TargetBranch:            pr.TargetBranch,
+		ParentID:                null.IntFromPtr(pr.ParentID),
 		ActivitySeq:             pr.ActivitySeq,
 		MergedBy:                null.IntFromPtr(pr.MergedBy),
 		Merged:                  null.IntFromPtr(pr.Merged),
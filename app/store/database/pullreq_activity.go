@@ -64,6 +64,7 @@ type pullReqActivity struct {
 	Updated   int64    `db:"pullreq_activity_updated"`
 	Edited    int64    `db:"pullreq_activity_edited"`
 	Deleted   null.Int `db:"pullreq_activity_deleted"`
+	Pending   bool     `db:"pullreq_activity_pending"`
 
 	ParentID  null.Int `db:"pullreq_activity_parent_id"`
 	RepoID    int64    `db:"pullreq_activity_repo_id"`
@@ -102,6 +103,7 @@ const (
 		,pullreq_activity_updated
 		,pullreq_activity_edited
 		,pullreq_activity_deleted
+		,pullreq_activity_pending
 		,pullreq_activity_parent_id
 		,pullreq_activity_repo_id
 		,pullreq_activity_pullreq_id
@@ -159,6 +161,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_updated
 		,pullreq_activity_edited
 		,pullreq_activity_deleted
+		,pullreq_activity_pending
 		,pullreq_activity_parent_id
 		,pullreq_activity_repo_id
 		,pullreq_activity_pullreq_id
@@ -187,6 +190,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,:pullreq_activity_updated
 		,:pullreq_activity_edited
 		,:pullreq_activity_deleted
+		,:pullreq_activity_pending
 		,:pullreq_activity_parent_id
 		,:pullreq_activity_repo_id
 		,:pullreq_activity_pullreq_id
@@ -273,6 +277,8 @@ func (s *PullReqActivityStore) Update(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_updated = :pullreq_activity_updated
 		,pullreq_activity_edited = :pullreq_activity_edited
 		,pullreq_activity_deleted = :pullreq_activity_deleted
+		,pullreq_activity_pending = :pullreq_activity_pending
+		,pullreq_activity_order = :pullreq_activity_order
 		,pullreq_activity_reply_seq = :pullreq_activity_reply_seq
 		,pullreq_activity_text = :pullreq_activity_text
 		,pullreq_activity_payload = :pullreq_activity_payload
@@ -386,6 +392,8 @@ func (s *PullReqActivityStore) Count(ctx context.Context,
 		stmt = stmt.Where("pullreq_activity_created < ?", opts.Before)
 	}
 
+	stmt = stmt.Where("(NOT pullreq_activity_pending OR pullreq_activity_created_by = ?)", opts.PendingAuthorID)
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
@@ -443,7 +451,8 @@ func (s *PullReqActivityStore) ListAuthorIDs(ctx context.Context, prID int64, or
 		Select("DISTINCT pullreq_activity_created_by").
 		From("pullreq_activities").
 		Where("pullreq_activity_pullreq_id = ?", prID).
-		Where("pullreq_activity_order = ?", order)
+		Where("pullreq_activity_order = ?", order).
+		Where("NOT pullreq_activity_pending")
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -469,6 +478,7 @@ func (s *PullReqActivityStore) CountUnresolved(ctx context.Context, prID int64)
 		Where("pullreq_activity_sub_order = 0").
 		Where("pullreq_activity_resolved IS NULL").
 		Where("pullreq_activity_deleted IS NULL").
+		Where("NOT pullreq_activity_pending").
 		Where("pullreq_activity_kind <> ?", enum.PullReqActivityKindSystem)
 
 	sql, args, err := stmt.ToSql()
@@ -487,6 +497,59 @@ func (s *PullReqActivityStore) CountUnresolved(ctx context.Context, prID int64)
 	return count, nil
 }
 
+// ListPending returns the pending review comments of a principal for a PR,
+// ordered the same way as they would appear in the activity list.
+func (s *PullReqActivityStore) ListPending(
+	ctx context.Context,
+	prID int64,
+	principalID int64,
+) ([]*types.PullReqActivity, error) {
+	stmt := database.Builder.
+		Select(pullreqActivityColumns).
+		From("pullreq_activities").
+		Where("pullreq_activity_pullreq_id = ?", prID).
+		Where("pullreq_activity_created_by = ?", principalID).
+		Where("pullreq_activity_pending").
+		Where("pullreq_activity_deleted IS NULL").
+		OrderBy("pullreq_activity_order asc", "pullreq_activity_sub_order asc")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert pending pull request activity query to sql")
+	}
+
+	dst := make([]*pullReqActivity, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pending pull request activity list query")
+	}
+
+	return s.mapSlicePullReqActivity(ctx, dst)
+}
+
+// DeletePending permanently deletes all pending review comments of a principal for a PR.
+func (s *PullReqActivityStore) DeletePending(ctx context.Context, prID int64, principalID int64) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM pullreq_activities
+	WHERE pullreq_activity_pullreq_id = $1 AND pullreq_activity_created_by = $2 AND pullreq_activity_pending`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, prID, principalID)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete pending pull request activities")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	return count, nil
+}
+
 func mapPullReqActivity(act *pullReqActivity) (*types.PullReqActivity, error) {
 	metadata := &types.PullReqActivityMetadata{}
 	err := json.Unmarshal(act.Metadata, &metadata)
@@ -502,6 +565,7 @@ func mapPullReqActivity(act *pullReqActivity) (*types.PullReqActivity, error) {
 		Updated:    act.Updated,
 		Edited:     act.Edited,
 		Deleted:    act.Deleted.Ptr(),
+		Pending:    act.Pending,
 		ParentID:   act.ParentID.Ptr(),
 		RepoID:     act.RepoID,
 		PullReqID:  act.PullReqID,
@@ -543,6 +607,7 @@ func mapInternalPullReqActivity(act *types.PullReqActivity) (*pullReqActivity, e
 		Updated:    act.Updated,
 		Edited:     act.Edited,
 		Deleted:    null.IntFromPtr(act.Deleted),
+		Pending:    act.Pending,
 		ParentID:   null.IntFromPtr(act.ParentID),
 		RepoID:     act.RepoID,
 		PullReqID:  act.PullReqID,
@@ -670,6 +735,8 @@ func applyFilter(
 		stmt = stmt.Where("pullreq_activity_created < ?", filter.Before)
 	}
 
+	stmt = stmt.Where("(NOT pullreq_activity_pending OR pullreq_activity_created_by = ?)", filter.PendingAuthorID)
+
 	if filter.Limit > 0 {
 		stmt = stmt.Limit(database.Limit(filter.Limit))
 	}
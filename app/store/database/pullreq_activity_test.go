@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullReqActivityStore_Pending(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+	activityStore := database.NewPullReqActivityStore(db, pCache)
+
+	pr := &types.PullReq{
+		Number:       1,
+		CreatedBy:    userID,
+		State:        enum.PullReqStateOpen,
+		Title:        "pr",
+		SourceRepoID: 1,
+		SourceBranch: "feature",
+		TargetRepoID: 1,
+		TargetBranch: "main",
+		MergeBaseSHA: "0000000000000000000000000000000000000000",
+	}
+	require.NoError(t, pullreqStore.Create(ctx, pr))
+
+	newComment := func(order, subOrder int64, pending bool) *types.PullReqActivity {
+		act := &types.PullReqActivity{
+			CreatedBy: userID,
+			RepoID:    1,
+			PullReqID: pr.ID,
+			Order:     order,
+			SubOrder:  subOrder,
+			Type:      enum.PullReqActivityTypeComment,
+			Kind:      enum.PullReqActivityKindComment,
+			Text:      "comment",
+			Pending:   pending,
+		}
+		require.NoError(t, act.SetPayload(types.PullRequestActivityPayloadComment{}))
+		require.NoError(t, activityStore.Create(ctx, act))
+		return act
+	}
+
+	newComment(1, 0, false)
+	pending := newComment(2, 0, true)
+
+	// pending comments are visible only to their author
+	list, err := activityStore.List(ctx, pr.ID, &types.PullReqActivityFilter{})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	list, err = activityStore.List(ctx, pr.ID, &types.PullReqActivityFilter{PendingAuthorID: userID})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.True(t, list[1].Pending)
+
+	count, err := activityStore.Count(ctx, pr.ID, &types.PullReqActivityFilter{PendingAuthorID: userID + 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	unresolved, err := activityStore.CountUnresolved(ctx, pr.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, unresolved)
+
+	list, err = activityStore.ListPending(ctx, pr.ID, userID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, pending.ID, list[0].ID)
+
+	// publishing moves the comment to the new position in the timeline
+	pending.Pending = false
+	pending.Order = 3
+	require.NoError(t, activityStore.Update(ctx, pending))
+
+	found, err := activityStore.Find(ctx, pending.ID)
+	require.NoError(t, err)
+	require.False(t, found.Pending)
+	require.Equal(t, int64(3), found.Order)
+
+	newComment(4, 0, true)
+	newComment(4, 1, true)
+
+	deleted, err := activityStore.DeletePending(ctx, pr.ID, userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+
+	list, err = activityStore.List(ctx, pr.ID, &types.PullReqActivityFilter{PendingAuthorID: userID})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+}
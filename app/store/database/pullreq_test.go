@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestPullReqStore_ListStack(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullreqStore := database.NewPullReqStore(db, pCache)
+
+	// #1 <- #2 <- #3, #1 <- #4 and the unrelated #5
+	parents := map[int64]int64{2: 1, 3: 2, 4: 1}
+	ids := map[int64]int64{}
+
+	for number := int64(1); number <= 5; number++ {
+		pr := &types.PullReq{
+			Number:       number,
+			CreatedBy:    userID,
+			State:        enum.PullReqStateOpen,
+			Title:        "pr",
+			SourceRepoID: 1,
+			SourceBranch: "branch" + strconv.FormatInt(number, 10),
+			TargetRepoID: 1,
+			TargetBranch: "main",
+			MergeBaseSHA: "0000000000000000000000000000000000000000",
+		}
+		if parent, ok := parents[number]; ok {
+			parentID := ids[parent]
+			pr.ParentID = &parentID
+		}
+
+		if err := pullreqStore.Create(ctx, pr); err != nil {
+			t.Fatalf("failed to create pull request: %v", err)
+		}
+
+		ids[number] = pr.ID
+	}
+
+	tests := []struct {
+		name   string
+		number int64
+		exp    []int64
+	}{
+		{name: "root", number: 1, exp: []int64{1, 2, 3, 4}},
+		{name: "leaf", number: 3, exp: []int64{1, 2, 3, 4}},
+		{name: "not-stacked", number: 5, exp: []int64{5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prs, err := pullreqStore.ListStack(ctx, ids[test.number])
+			if err != nil {
+				t.Fatalf("failed to list stack: %v", err)
+			}
+
+			numbers := make([]int64, len(prs))
+			for i, pr := range prs {
+				numbers[i] = pr.Number
+			}
+
+			if !slices.Equal(numbers, test.exp) {
+				t.Errorf("expected=%v, got=%v", test.exp, numbers)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
 )
 
 var _ store.TokenStore = (*TokenStore)(nil)
@@ -42,23 +44,29 @@ type TokenStore struct {
 	db *sqlx.DB
 }
 
+// token is used to store the token scope as a JSON column.
+type token struct {
+	types.Token
+	Scope sqlxtypes.NullJSONText `db:"token_scope"`
+}
+
 // Find finds the token by id.
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token")
 	}
 
-	return dst, nil
+	return mapToken(dst)
 }
 
 // FindByIdentifier finds the token by principalId and token identifier.
 func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(
 		ctx,
 		dst,
@@ -69,25 +77,41 @@ func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, id
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token by identifier")
 	}
 
-	return dst, nil
+	return mapToken(dst)
 }
 
 // Create saves the token details.
-func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
+func (s *TokenStore) Create(ctx context.Context, tkn *types.Token) error {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(tokenInsert, token)
+	dbToken, err := mapInternalToken(tkn)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(tokenInsert, dbToken)
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind token object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&token.ID); err != nil {
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&tkn.ID); err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
 	}
 
 	return nil
 }
 
+// UpdateLastUsed updates the time at which the token was last used.
+func (s *TokenStore) UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, tokenUpdateLastUsed, lastUsed, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update token last used time")
+	}
+
+	return nil
+}
+
 // Delete deletes the token with the given id.
 func (s *TokenStore) Delete(ctx context.Context, id int64) error {
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -153,7 +177,7 @@ func (s *TokenStore) List(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) ([]*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Token{}
+	dst := []*token{}
 
 	// TODO: custom filters / sorting for tokens.
 
@@ -161,7 +185,50 @@ func (s *TokenStore) List(ctx context.Context,
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing token list query")
 	}
-	return dst, nil
+
+	tokens := make([]*types.Token, len(dst))
+	for i := range dst {
+		if tokens[i], err = mapToken(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return tokens, nil
+}
+
+func mapInternalToken(tkn *types.Token) (*token, error) {
+	dbToken := &token{Token: *tkn}
+	if tkn.Scope.IsEmpty() {
+		return dbToken, nil
+	}
+
+	// space and repo paths can change, only their IDs are persisted.
+	scope := *tkn.Scope
+	scope.Spaces = nil
+	scope.Repos = nil
+
+	data, err := json.Marshal(scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token scope: %w", err)
+	}
+
+	dbToken.Scope = sqlxtypes.NullJSONText{JSONText: data, Valid: true}
+
+	return dbToken, nil
+}
+
+func mapToken(dbToken *token) (*types.Token, error) {
+	tkn := dbToken.Token
+	if !dbToken.Scope.Valid {
+		return &tkn, nil
+	}
+
+	tkn.Scope = &types.TokenScope{}
+	if err := json.Unmarshal(dbToken.Scope.JSONText, tkn.Scope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token scope: %w", err)
+	}
+
+	return &tkn, nil
 }
 
 const tokenSelectBase = `
@@ -173,6 +240,8 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_scope
+,token_last_used
 FROM tokens
 ` //#nosec G101
 
@@ -195,6 +264,12 @@ const TokenSelectByPrincipalIDAndIdentifier = tokenSelectBase + `
 WHERE token_principal_id = $1 AND LOWER(token_uid) = $2
 `
 
+const tokenUpdateLastUsed = `
+UPDATE tokens
+SET token_last_used = $1
+WHERE token_id = $2
+`
+
 const tokenDelete = `
 DELETE FROM tokens
 WHERE token_id = $1
@@ -208,6 +283,7 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_scope
 ) values (
 	:token_type
 	,:token_uid
@@ -215,5 +291,6 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_scope
 ) RETURNING token_id
 `
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	tokenStore := database.NewTokenStore(db)
+
+	unscoped := &types.Token{
+		Type:        enum.TokenTypePAT,
+		Identifier:  "unscoped",
+		PrincipalID: userID,
+		IssuedAt:    1,
+		CreatedBy:   userID,
+	}
+	require.NoError(t, tokenStore.Create(ctx, unscoped))
+
+	scoped := &types.Token{
+		Type:        enum.TokenTypePAT,
+		Identifier:  "scoped",
+		PrincipalID: userID,
+		IssuedAt:    2,
+		CreatedBy:   userID,
+		Scope: &types.TokenScope{
+			Permissions: []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush},
+			SpaceIDs:    []int64{1},
+			Spaces:      []string{"space"},
+			RepoIDs:     []int64{2},
+			Repos:       []string{"space/repo"},
+			IPRanges:    []string{"10.0.0.0/8"},
+		},
+	}
+	require.NoError(t, tokenStore.Create(ctx, scoped))
+
+	found, err := tokenStore.Find(ctx, unscoped.ID)
+	require.NoError(t, err)
+	require.Nil(t, found.Scope)
+	require.Nil(t, found.LastUsed)
+
+	found, err = tokenStore.FindByIdentifier(ctx, userID, "SCOPED")
+	require.NoError(t, err)
+	require.Equal(t, &types.TokenScope{
+		Permissions: []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush},
+		SpaceIDs:    []int64{1},
+		RepoIDs:     []int64{2},
+		IPRanges:    []string{"10.0.0.0/8"},
+	}, found.Scope)
+
+	require.NoError(t, tokenStore.UpdateLastUsed(ctx, scoped.ID, 42))
+
+	tokens, err := tokenStore.List(ctx, userID, enum.TokenTypePAT)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	require.Equal(t, scoped.ID, tokens[0].ID)
+	require.Equal(t, ptr.Int64(42), tokens[0].LastUsed)
+	require.NotNil(t, tokens[0].Scope)
+	require.Nil(t, tokens[1].Scope)
+}
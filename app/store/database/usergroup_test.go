@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserGroupStore_Members(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	userGroupStore := database.NewUserGroupStore(db)
+	memberStore := database.NewUserGroupMemberStore(db, pCache)
+
+	rootGroup := &types.UserGroup{Identifier: "backend", Name: "Backend"}
+	require.NoError(t, userGroupStore.Create(ctx, 1, rootGroup))
+
+	childGroup := &types.UserGroup{Identifier: "frontend", Name: "Frontend"}
+	require.NoError(t, userGroupStore.Create(ctx, 2, childGroup))
+
+	// groups of the space and of its ancestors are listed together
+	filter := &types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: 10}}
+	groups, err := userGroupStore.List(ctx, []int64{2, 1}, filter)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	groups, err = userGroupStore.List(ctx, []int64{1}, filter)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, rootGroup.ID, groups[0].ID)
+
+	count, err := userGroupStore.Count(ctx, []int64{2, 1},
+		&types.ListQueryFilter{Query: "front"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	member := &types.UserGroupMember{
+		UserGroupID: rootGroup.ID,
+		PrincipalID: userID,
+		CreatedBy:   userID,
+		Created:     1,
+	}
+	require.NoError(t, memberStore.Create(ctx, member))
+	require.ErrorIs(t, memberStore.Create(ctx, member), gitness_store.ErrDuplicate)
+
+	members, err := memberStore.List(ctx, rootGroup.ID, filter)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	require.Equal(t, "user_1", members[0].Principal.UID)
+
+	memberMap, err := memberStore.MapPrincipalIDs(ctx, []int64{rootGroup.ID, childGroup.ID})
+	require.NoError(t, err)
+	require.Equal(t, map[int64][]int64{rootGroup.ID: {userID}}, memberMap)
+
+	groupMap, err := memberStore.MapUserGroupIDs(ctx, []int64{userID})
+	require.NoError(t, err)
+	require.Equal(t, map[int64][]int64{userID: {rootGroup.ID}}, groupMap)
+
+	require.NoError(t, memberStore.Delete(ctx, rootGroup.ID, userID))
+	require.ErrorIs(t, memberStore.Delete(ctx, rootGroup.ID, userID), gitness_store.ErrResourceNotFound)
+
+	// deleting a group deletes its members too
+	require.NoError(t, memberStore.Create(ctx, member))
+	require.NoError(t, userGroupStore.Delete(ctx, rootGroup.ID))
+
+	memberCount, err := memberStore.Count(ctx, rootGroup.ID, &types.ListQueryFilter{})
+	require.NoError(t, err)
+	require.Zero(t, memberCount)
+}
@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -69,6 +70,10 @@ type webhook struct {
 	Insecure              bool        `db:"webhook_insecure"`
 	Triggers              string      `db:"webhook_triggers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
+	ConsecutiveFailures   int         `db:"webhook_consecutive_failures"`
+	AutoDisabled          bool        `db:"webhook_auto_disabled"`
+	ExtraHeaders          null.String `db:"webhook_extra_headers"`
+	PayloadTemplate       null.String `db:"webhook_payload_template"`
 }
 
 const (
@@ -89,8 +94,12 @@ const (
 		,webhook_insecure
 		,webhook_triggers
 		,webhook_latest_execution_result
+		,webhook_consecutive_failures
+		,webhook_auto_disabled
 		,webhook_type
-		,webhook_scope`
+		,webhook_scope
+		,webhook_extra_headers
+		,webhook_payload_template`
 
 	webhookSelectBase = `
 	SELECT` + webhookColumns + `
@@ -177,8 +186,12 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_insecure
 			,webhook_triggers
 			,webhook_latest_execution_result
+			,webhook_consecutive_failures
+			,webhook_auto_disabled
 			,webhook_type
 			,webhook_scope
+			,webhook_extra_headers
+			,webhook_payload_template
 		) values (
 			:webhook_repo_id
 			,:webhook_space_id
@@ -194,8 +207,12 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_insecure
 			,:webhook_triggers
 			,:webhook_latest_execution_result
+			,:webhook_consecutive_failures
+			,:webhook_auto_disabled
 			,:webhook_type
 			,:webhook_scope
+			,:webhook_extra_headers
+			,:webhook_payload_template
 		) RETURNING webhook_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -233,6 +250,10 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_insecure = :webhook_insecure
 			,webhook_triggers = :webhook_triggers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
+			,webhook_consecutive_failures = :webhook_consecutive_failures
+			,webhook_auto_disabled = :webhook_auto_disabled
+			,webhook_extra_headers = :webhook_extra_headers
+			,webhook_payload_template = :webhook_payload_template
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -460,9 +481,24 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Insecure:              hook.Insecure,
 		Triggers:              triggersFromString(hook.Triggers),
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
+		AutoDisabled:          hook.AutoDisabled,
 		Type:                  hook.Type,
 	}
 
+	if hook.ExtraHeaders.Valid {
+		if err := json.Unmarshal([]byte(hook.ExtraHeaders.String), &res.ExtraHeaders); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal extra headers of hook %d: %w", hook.ID, err)
+		}
+	}
+
+	if hook.PayloadTemplate.Valid {
+		res.PayloadTemplate = &types.WebhookPayloadTemplate{}
+		if err := json.Unmarshal([]byte(hook.PayloadTemplate.String), res.PayloadTemplate); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payload template of hook %d: %w", hook.ID, err)
+		}
+	}
+
 	switch {
 	case hook.RepoID.Valid && hook.SpaceID.Valid:
 		return nil, fmt.Errorf("both repoID and spaceID are set for hook %d", hook.ID)
@@ -497,9 +533,27 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Insecure:              hook.Insecure,
 		Triggers:              triggersToString(hook.Triggers),
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
+		AutoDisabled:          hook.AutoDisabled,
 		Type:                  hook.Type,
 	}
 
+	if len(hook.ExtraHeaders) > 0 {
+		extraHeaders, err := json.Marshal(hook.ExtraHeaders)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal extra headers: %w", err)
+		}
+		res.ExtraHeaders = null.StringFrom(string(extraHeaders))
+	}
+
+	if hook.PayloadTemplate != nil {
+		payloadTemplate, err := json.Marshal(hook.PayloadTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload template: %w", err)
+		}
+		res.PayloadTemplate = null.StringFrom(string(payloadTemplate))
+	}
+
 	switch hook.ParentType {
 	case enum.WebhookParentRepo:
 		res.RepoID = null.IntFrom(hook.ParentID)
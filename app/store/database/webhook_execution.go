@@ -63,6 +63,8 @@ type webhookExecution struct {
 	ResponseStatus     string                      `db:"webhook_execution_response_status"`
 	ResponseHeaders    string                      `db:"webhook_execution_response_headers"`
 	ResponseBody       string                      `db:"webhook_execution_response_body"`
+	Attempt            int                         `db:"webhook_execution_attempt"`
+	NextRetryAt        null.Int                    `db:"webhook_execution_next_retry_at"`
 }
 
 const (
@@ -83,7 +85,9 @@ const (
 		,webhook_execution_response_status_code
 		,webhook_execution_response_status
 		,webhook_execution_response_headers
-		,webhook_execution_response_body`
+		,webhook_execution_response_body
+		,webhook_execution_attempt
+		,webhook_execution_next_retry_at`
 
 	webhookExecutionSelectBase = `
 	SELECT` + webhookExecutionColumns + `
@@ -125,6 +129,8 @@ func (s *WebhookExecutionStore) Create(ctx context.Context, execution *types.Web
 		,webhook_execution_response_status
 		,webhook_execution_response_headers
 		,webhook_execution_response_body
+		,webhook_execution_attempt
+		,webhook_execution_next_retry_at
 	) values (
 		 :webhook_execution_retrigger_of
 		,:webhook_execution_retriggerable
@@ -142,6 +148,8 @@ func (s *WebhookExecutionStore) Create(ctx context.Context, execution *types.Web
 		,:webhook_execution_response_status
 		,:webhook_execution_response_headers
 		,:webhook_execution_response_body
+		,:webhook_execution_attempt
+		,:webhook_execution_next_retry_at
 	) RETURNING webhook_execution_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -254,6 +262,100 @@ func (s *WebhookExecutionStore) ListForTrigger(ctx context.Context,
 	return mapToWebhookExecutions(dst), nil
 }
 
+// ListRetriesDue lists the webhook executions with an automatic retry that is due at the provided time.
+func (s *WebhookExecutionStore) ListRetriesDue(
+	ctx context.Context,
+	now int64,
+	limit int,
+) ([]*types.WebhookExecution, error) {
+	stmt := database.Builder.
+		Select(webhookExecutionColumns).
+		From("webhook_executions").
+		Where("webhook_execution_next_retry_at <= ?", now).
+		OrderBy("webhook_execution_next_retry_at").
+		Limit(database.Limit(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*webhookExecution{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToWebhookExecutions(dst), nil
+}
+
+// ClaimRetry removes the scheduled retry of the webhook execution.
+// It returns false in case the retry was already claimed (or rescheduled) by someone else.
+func (s *WebhookExecutionStore) ClaimRetry(ctx context.Context, id int64, nextRetryAt int64) (bool, error) {
+	const sqlQuery = `
+	UPDATE webhook_executions
+	SET webhook_execution_next_retry_at = NULL
+	WHERE webhook_execution_id = $1 AND webhook_execution_next_retry_at = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id, nextRetryAt)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Update query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	return count > 0, nil
+}
+
+// ListFailedForWebhook lists the failed webhook executions of a webhook created within the provided time range.
+// Only the latest execution of every trigger is returned, and only if the trigger never got delivered successfully
+// and has no automatic retry pending.
+func (s *WebhookExecutionStore) ListFailedForWebhook(
+	ctx context.Context,
+	webhookID int64,
+	from int64,
+	to int64,
+	limit int,
+) ([]*types.WebhookExecution, error) {
+	stmt := database.Builder.
+		Select(webhookExecutionColumns).
+		From("webhook_executions e").
+		Where("e.webhook_execution_webhook_id = ?", webhookID).
+		Where("e.webhook_execution_created >= ?", from).
+		Where("e.webhook_execution_created <= ?", to).
+		Where("e.webhook_execution_result <> ?", enum.WebhookExecutionResultSuccess).
+		Where("e.webhook_execution_retriggerable").
+		Where("e.webhook_execution_next_retry_at IS NULL").
+		Where(`NOT EXISTS (
+			SELECT 1 FROM webhook_executions o
+			WHERE o.webhook_execution_webhook_id = e.webhook_execution_webhook_id
+			AND o.webhook_execution_trigger_id = e.webhook_execution_trigger_id
+			AND (o.webhook_execution_id > e.webhook_execution_id OR o.webhook_execution_result = ?))`,
+			enum.WebhookExecutionResultSuccess).
+		OrderBy("e.webhook_execution_id").
+		Limit(database.Limit(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*webhookExecution{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToWebhookExecutions(dst), nil
+}
+
 func mapToWebhookExecution(execution *webhookExecution) *types.WebhookExecution {
 	return &types.WebhookExecution{
 		ID:            execution.ID,
@@ -277,6 +379,8 @@ func mapToWebhookExecution(execution *webhookExecution) *types.WebhookExecution
 			Headers:    execution.ResponseHeaders,
 			Body:       execution.ResponseBody,
 		},
+		Attempt:     execution.Attempt,
+		NextRetryAt: execution.NextRetryAt.Ptr(),
 	}
 }
 
@@ -299,6 +403,8 @@ func mapToInternalWebhookExecution(execution *types.WebhookExecution) *webhookEx
 		ResponseStatus:     execution.Response.Status,
 		ResponseHeaders:    execution.Response.Headers,
 		ResponseBody:       execution.Response.Body,
+		Attempt:            execution.Attempt,
+		NextRetryAt:        null.IntFromPtr(execution.NextRetryAt),
 	}
 }
 
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookExecutionStoreRetries(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	webhookStore := database.NewWebhookStore(db)
+	executionStore := database.NewWebhookExecutionStore(db)
+
+	hook := &types.Webhook{
+		ParentID:   1,
+		ParentType: enum.WebhookParentSpace,
+		CreatedBy:  userID,
+		Identifier: "hook",
+		URL:        "https://example.com/hook",
+		Enabled:    true,
+		Type:       enum.WebhookTypeExternal,
+	}
+	require.NoError(t, webhookStore.Create(ctx, hook))
+
+	createExecution := func(triggerID string, created int64, result enum.WebhookExecutionResult,
+		nextRetryAt *int64) *types.WebhookExecution {
+		execution := &types.WebhookExecution{
+			WebhookID:     hook.ID,
+			TriggerID:     triggerID,
+			TriggerType:   enum.WebhookTriggerBranchCreated,
+			Result:        result,
+			Retriggerable: true,
+			Created:       created,
+			Attempt:       1,
+			NextRetryAt:   nextRetryAt,
+		}
+		require.NoError(t, executionStore.Create(ctx, execution))
+		return execution
+	}
+
+	due := int64(1000)
+	notDue := int64(5000)
+
+	// t1: failed, retry due
+	e1 := createExecution("t1", 100, enum.WebhookExecutionResultRetriableError, &due)
+	// t2: failed, retry not yet due
+	createExecution("t2", 200, enum.WebhookExecutionResultRetriableError, &notDue)
+	// t3: failed and later delivered successfully
+	createExecution("t3", 300, enum.WebhookExecutionResultFatalError, nil)
+	createExecution("t3", 400, enum.WebhookExecutionResultSuccess, nil)
+	// t4: failed twice, all retries exhausted
+	createExecution("t4", 500, enum.WebhookExecutionResultRetriableError, nil)
+	e4 := createExecution("t4", 600, enum.WebhookExecutionResultRetriableError, nil)
+
+	retries, err := executionStore.ListRetriesDue(ctx, 2000, 10)
+	require.NoError(t, err)
+	require.Len(t, retries, 1)
+	require.Equal(t, e1.ID, retries[0].ID)
+	require.Equal(t, due, *retries[0].NextRetryAt)
+
+	claimed, err := executionStore.ClaimRetry(ctx, e1.ID, due)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = executionStore.ClaimRetry(ctx, e1.ID, due)
+	require.NoError(t, err)
+	require.False(t, claimed, "retry can be claimed only once")
+
+	retries, err = executionStore.ListRetriesDue(ctx, 2000, 10)
+	require.NoError(t, err)
+	require.Empty(t, retries)
+
+	failed, err := executionStore.ListFailedForWebhook(ctx, hook.ID, 0, 1000, 10)
+	require.NoError(t, err)
+	require.Len(t, failed, 2)
+	require.Equal(t, e1.ID, failed[0].ID)
+	require.Equal(t, e4.ID, failed[1].ID)
+
+	failed, err = executionStore.ListFailedForWebhook(ctx, hook.ID, 150, 1000, 10)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	require.Equal(t, e4.ID, failed[0].ID)
+
+	hook.ConsecutiveFailures = 3
+	hook.AutoDisabled = true
+	hook.Enabled = false
+	require.NoError(t, webhookStore.Update(ctx, hook))
+
+	found, err := webhookStore.Find(ctx, hook.ID)
+	require.NoError(t, err)
+	require.Equal(t, 3, found.ConsecutiveFailures)
+	require.True(t, found.AutoDisabled)
+	require.False(t, found.Enabled)
+}
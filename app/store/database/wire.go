@@ -32,6 +32,8 @@ var WireSet = wire.NewSet(
 	ProvidePrincipalStore,
 	ProvideUserGroupStore,
 	ProvideUserGroupReviewerStore,
+	ProvideUserGroupMemberStore,
+	ProvidePrincipalIdentityStore,
 	ProvidePrincipalInfoView,
 	ProvideInfraProviderResourceView,
 	ProvideSpacePathStore,
@@ -53,6 +55,12 @@ var WireSet = wire.NewSet(
 	ProvidePullReqReviewStore,
 	ProvidePullReqReviewerStore,
 	ProvidePullReqFileViewStore,
+	ProvideMergeQueueStore,
+	ProvideAutoMergeStore,
+	ProvideAuditEventStore,
+	ProvideNotificationStore,
+	ProvideNotificationSubscriptionStore,
+	ProvideNotificationChannelStore,
 	ProvideWebhookStore,
 	ProvideWebhookExecutionStore,
 	ProvideSettingsStore,
@@ -73,6 +81,11 @@ var WireSet = wire.NewSet(
 	ProvideLabelStore,
 	ProvideLabelValueStore,
 	ProvidePullReqLabelStore,
+	ProvideIssueStore,
+	ProvideIssueActivityStore,
+	ProvideIssueAssigneeStore,
+	ProvideIssueLabelStore,
+	ProvideMilestoneStore,
 	ProvideLFSObjectStore,
 	ProvideInfraProviderTemplateStore,
 	ProvideInfraProvisionedStore,
@@ -117,6 +130,19 @@ func ProvideUserGroupReviewerStore(
 	return NewUsergroupReviewerStore(db, pInfoCache, userGroupStore)
 }
 
+// ProvideUserGroupMemberStore provides a usergroup member store.
+func ProvideUserGroupMemberStore(
+	db *sqlx.DB,
+	pInfoCache store.PrincipalInfoCache,
+) store.UserGroupMemberStore {
+	return NewUserGroupMemberStore(db, pInfoCache)
+}
+
+// ProvidePrincipalIdentityStore provides a principal identity store.
+func ProvidePrincipalIdentityStore(db *sqlx.DB) store.PrincipalIdentityStore {
+	return NewPrincipalIdentityStore(db)
+}
+
 // ProvidePrincipalInfoView provides a principal info store.
 func ProvidePrincipalInfoView(db *sqlx.DB) store.PrincipalInfoView {
 	return NewPrincipalInfoView(db)
@@ -301,6 +327,36 @@ func ProvidePullReqReviewerStore(
 	return NewPullReqReviewerStore(db, principalInfoCache)
 }
 
+// ProvideMergeQueueStore provides a merge queue store.
+func ProvideMergeQueueStore(db *sqlx.DB) store.MergeQueueStore {
+	return NewMergeQueueStore(db)
+}
+
+// ProvideAutoMergeStore provides a pull request auto-merge store.
+func ProvideAutoMergeStore(db *sqlx.DB) store.AutoMergeStore {
+	return NewAutoMergeStore(db)
+}
+
+// ProvideAuditEventStore provides an audit event store.
+func ProvideAuditEventStore(db *sqlx.DB) store.AuditEventStore {
+	return NewAuditEventStore(db)
+}
+
+// ProvideNotificationStore provides a notification store.
+func ProvideNotificationStore(db *sqlx.DB) store.NotificationStore {
+	return NewNotificationStore(db)
+}
+
+// ProvideNotificationSubscriptionStore provides a notification subscription store.
+func ProvideNotificationSubscriptionStore(db *sqlx.DB) store.NotificationSubscriptionStore {
+	return NewNotificationSubscriptionStore(db)
+}
+
+// ProvideNotificationChannelStore provides a notification channel store.
+func ProvideNotificationChannelStore(db *sqlx.DB) store.NotificationChannelStore {
+	return NewNotificationChannelStore(db)
+}
+
 // ProvidePullReqFileViewStore provides a pull request file view store.
 func ProvidePullReqFileViewStore(db *sqlx.DB) store.PullReqFileViewStore {
 	return NewPullReqFileViewStore(db)
@@ -373,6 +429,37 @@ func ProvidePullReqLabelStore(db *sqlx.DB) store.PullReqLabelAssignmentStore {
 	return NewPullReqLabelStore(db)
 }
 
+// ProvideIssueStore provides an issue store.
+func ProvideIssueStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.IssueStore {
+	return NewIssueStore(db, principalInfoCache)
+}
+
+// ProvideIssueActivityStore provides an issue activity store.
+func ProvideIssueActivityStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.IssueActivityStore {
+	return NewIssueActivityStore(db, principalInfoCache)
+}
+
+// ProvideIssueAssigneeStore provides an issue assignee store.
+func ProvideIssueAssigneeStore(db *sqlx.DB) store.IssueAssigneeStore {
+	return NewIssueAssigneeStore(db)
+}
+
+// ProvideIssueLabelStore provides an issue label assignment store.
+func ProvideIssueLabelStore(db *sqlx.DB) store.IssueLabelAssignmentStore {
+	return NewIssueLabelStore(db)
+}
+
+// ProvideMilestoneStore provides a milestone store.
+func ProvideMilestoneStore(db *sqlx.DB) store.MilestoneStore {
+	return NewMilestoneStore(db)
+}
+
 // ProvideLFSObjectStore provides an lfs object store.
 func ProvideLFSObjectStore(db *sqlx.DB) store.LFSObjectStore {
 	return NewLFSObjectStore(db)
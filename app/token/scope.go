@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// ParseIPRange parses an IP range of a token scope.
+// Both CIDR notation and single IP addresses are accepted.
+func ParseIPRange(ipRange string) (netip.Prefix, error) {
+	ipRange = strings.TrimSpace(ipRange)
+
+	if strings.Contains(ipRange, "/") {
+		prefix, err := netip.ParsePrefix(ipRange)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid CIDR %q: %w", ipRange, err)
+		}
+
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(ipRange)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address %q: %w", ipRange, err)
+	}
+
+	return netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()), nil
+}
+
+// IPRangesContain returns true if the IP address is within any of the provided IP ranges.
+// Ranges that fail to parse are ignored.
+func IPRangesContain(ipRanges []string, ip string) bool {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	for _, ipRange := range ipRanges {
+		prefix, err := ParseIPRange(ipRange)
+		if err != nil {
+			continue
+		}
+
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"testing"
+)
+
+func TestParseIPRange(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "10.1.2.3/8", want: "10.0.0.0/8"},
+		{input: "192.168.0.1", want: "192.168.0.1/32"},
+		{input: "::ffff:192.168.0.1", want: "192.168.0.1/32"},
+		{input: "2001:db8::/32", want: "2001:db8::/32"},
+		{input: "2001:db8::1", want: "2001:db8::1/128"},
+		{input: "10.0.0.0/33", wantErr: true},
+		{input: "not-an-ip", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := ParseIPRange(test.input)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %s", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.String() != test.want {
+				t.Errorf("want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestIPRangesContain(t *testing.T) {
+	ranges := []string{"10.0.0.0/8", "2001:db8::/32", "192.168.1.10"}
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "10.20.30.40", want: true},
+		{ip: "::ffff:10.20.30.40", want: true},
+		{ip: "11.0.0.1", want: false},
+		{ip: "2001:db8::5", want: true},
+		{ip: "2001:db9::5", want: false},
+		{ip: "192.168.1.10", want: true},
+		{ip: "192.168.1.11", want: false},
+		{ip: "garbage", want: false},
+	}
+
+	for _, test := range tests {
+		if got := IPRangesContain(ranges, test.ip); got != test.want {
+			t.Errorf("IPRangesContain(%s): want %t, got %t", test.ip, test.want, got)
+		}
+	}
+}
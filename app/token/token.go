@@ -63,6 +63,7 @@ func CreateUserSession(
 		principal,
 		identifier,
 		ptr.Duration(userSessionTokenLifeTime),
+		nil,
 	)
 }
 
@@ -73,6 +74,7 @@ func CreatePAT(
 	createdFor *types.User,
 	identifier string,
 	lifetime *time.Duration,
+	scope *types.TokenScope,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
@@ -82,6 +84,7 @@ func CreatePAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		scope,
 	)
 }
 
@@ -101,6 +104,7 @@ func CreateSAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		nil,
 	)
 }
 
@@ -118,6 +122,7 @@ func CreateRemoteAuthToken(
 		principal,
 		identifier,
 		ptr.Duration(RemoteAuthTokenLifeTime),
+		nil,
 	)
 }
 
@@ -135,6 +140,7 @@ func create(
 	createdFor *types.Principal,
 	identifier string,
 	lifetime *time.Duration,
+	scope *types.TokenScope,
 ) (*types.Token, string, error) {
 	issuedAt := time.Now()
 
@@ -151,6 +157,7 @@ func create(
 		IssuedAt:    issuedAt.UnixMilli(),
 		ExpiresAt:   expiresAt,
 		CreatedBy:   createdBy.ID,
+		Scope:       scope,
 	}
 
 	err := tokenStore.Create(ctx, &token)
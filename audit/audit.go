@@ -47,6 +47,7 @@ const (
 	BypassActionCreated             = "created"
 	BypassActionCommitted           = "committed"
 	BypassActionMerged              = "merged"
+	BypassActionPushed              = "pushed"
 	BypassSHALabelFormat            = "%s @%s"
 	BypassPullReqLabelFormat        = "%s #%s"
 )
@@ -79,6 +80,7 @@ const (
 	ResourceTypeTag                   ResourceType = "tag"
 	ResourceTypeTagRule               ResourceType = "tag_rule"
 	ResourceTypePushRule              ResourceType = "push_rule"
+	ResourceTypeFilePathRule          ResourceType = "file_path_rule"
 	ResourceTypePullRequest           ResourceType = "pull_request"
 	ResourceTypeRepositorySettings    ResourceType = "repository_settings"
 	ResourceTypeCodeWebhook           ResourceType = "code_webhook"
@@ -96,6 +98,7 @@ func (a ResourceType) Validate() error {
 		ResourceTypeTag,
 		ResourceTypeTagRule,
 		ResourceTypePushRule,
+		ResourceTypeFilePathRule,
 		ResourceTypePullRequest,
 		ResourceTypeRepositorySettings,
 		ResourceTypeCodeWebhook,
@@ -21,6 +21,7 @@ type Provider string
 const (
 	ProviderGCS        Provider = "gcs"
 	ProviderFileSystem Provider = "filesystem"
+	ProviderS3         Provider = "s3"
 )
 
 type Config struct {
@@ -29,4 +30,27 @@ type Config struct {
 	KeyPath               string
 	TargetPrincipal       string
 	ImpersonationLifetime time.Duration
+	S3                    S3Config
+}
+
+// S3Config holds the configuration of an S3 compatible blob store, like AWS S3 or MinIO.
+type S3Config struct {
+	// Endpoint overrides the default AWS S3 endpoint, e.g. "http://minio:9000".
+	Endpoint string
+	Region   string
+
+	// AccessKey and SecretKey are the static credentials. If empty, the default AWS credential chain is used.
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+
+	// PathStyle forces path-style addressing (endpoint/bucket/key), as required by most S3 compatible servers.
+	PathStyle  bool
+	DisableSSL bool
+	SkipVerify bool
+
+	// Encrypt enables server-side encryption of the stored objects.
+	// SSE-S3 (AES256) is used, unless KMSKeyID is provided, in which case SSE-KMS is used.
+	Encrypt  bool
+	KMSKeyID string
 }
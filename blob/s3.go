@@ -0,0 +1,210 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+
+	sseAlgorithmAES256 = "AES256"
+	sseAlgorithmKMS    = "aws:kms"
+)
+
+// S3Store is a blob store backed by AWS S3 or any S3 compatible object storage, like MinIO.
+type S3Store struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+	config   Config
+}
+
+func NewS3Store(cfg Config) (Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("bucket is required for the s3 blob store")
+	}
+
+	region := cfg.S3.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	awsConfig := aws.NewConfig().
+		WithRegion(region).
+		WithDisableSSL(cfg.S3.DisableSSL).
+		WithS3ForcePathStyle(cfg.S3.PathStyle)
+
+	if cfg.S3.Endpoint != "" {
+		awsConfig.WithEndpoint(cfg.S3.Endpoint)
+	}
+
+	if cfg.S3.AccessKey != "" && cfg.S3.SecretKey != "" {
+		awsConfig.WithCredentials(credentials.NewStaticCredentials(
+			cfg.S3.AccessKey,
+			cfg.S3.SecretKey,
+			cfg.S3.SessionToken,
+		))
+	}
+
+	if cfg.S3.SkipVerify {
+		httpTransport, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("failed to get default transport")
+		}
+		httpTransport = httpTransport.Clone()
+		httpTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12} //nolint:gosec
+		awsConfig.WithHTTPClient(&http.Client{Transport: httpTransport})
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 session: %w", err)
+	}
+
+	client := s3.New(sess)
+
+	return &S3Store{
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+		config:   cfg,
+	}, nil
+}
+
+func (c *S3Store) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	_, err := c.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:               aws.String(c.config.Bucket),
+		Key:                  aws.String(filePath),
+		Body:                 file,
+		ServerSideEncryption: c.encryptionMode(),
+		SSEKMSKeyId:          c.kmsKeyID(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write file %q to bucket %q: %w", filePath, c.config.Bucket, err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) GetSignedURL(
+	_ context.Context,
+	filePath string,
+	expire time.Time,
+	opts ...SignURLOption,
+) (string, error) {
+	config := SignURLConfig{
+		Method: http.MethodGet,
+	}
+
+	for _, opt := range opts {
+		opt.Apply(&config)
+	}
+
+	var req *request.Request
+
+	switch config.Method {
+	case http.MethodGet:
+		req, _ = c.client.GetObjectRequest(&s3.GetObjectInput{
+			Bucket: aws.String(c.config.Bucket),
+			Key:    aws.String(filePath),
+		})
+	case http.MethodPut:
+		input := &s3.PutObjectInput{
+			Bucket:               aws.String(c.config.Bucket),
+			Key:                  aws.String(filePath),
+			ServerSideEncryption: c.encryptionMode(),
+			SSEKMSKeyId:          c.kmsKeyID(),
+		}
+		if config.ContentType != "" {
+			input.ContentType = aws.String(config.ContentType)
+		}
+		req, _ = c.client.PutObjectRequest(input)
+	default:
+		return "", fmt.Errorf("unsupported method %q for signed URL of s3 blob store", config.Method)
+	}
+
+	// Additional query parameters and headers must be part of the request before it gets signed.
+	req.Handlers.Build.PushBack(func(r *request.Request) {
+		if len(config.QueryParameters) > 0 {
+			query := r.HTTPRequest.URL.Query()
+			for key, values := range config.QueryParameters {
+				for _, value := range values {
+					query.Add(key, value)
+				}
+			}
+			r.HTTPRequest.URL.RawQuery = query.Encode()
+		}
+
+		for _, header := range config.Headers {
+			key, value, _ := strings.Cut(header, ":")
+			r.HTTPRequest.Header.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+	})
+
+	signedURL, err := req.Presign(time.Until(expire))
+	if err != nil {
+		return "", fmt.Errorf("failed to create signed URL for file %q: %w", filePath, err)
+	}
+
+	return signedURL, nil
+}
+
+func (c *S3Store) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	out, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if err != nil {
+		var s3Err awserr.Error
+		if errors.As(err, &s3Err) && s3Err.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read file %q from bucket %q: %w", filePath, c.config.Bucket, err)
+	}
+
+	return out.Body, nil
+}
+
+func (c *S3Store) encryptionMode() *string {
+	if !c.config.S3.Encrypt {
+		return nil
+	}
+	if c.config.S3.KMSKeyID == "" {
+		return aws.String(sseAlgorithmAES256)
+	}
+	return aws.String(sseAlgorithmKMS)
+}
+
+func (c *S3Store) kmsKeyID() *string {
+	if !c.config.S3.Encrypt || c.config.S3.KMSKeyID == "" {
+		return nil
+	}
+	return aws.String(c.config.S3.KMSKeyID)
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3 compatible server (like MinIO) using path-style addressing.
+type fakeS3 struct {
+	mx      sync.Mutex
+	objects map[string][]byte
+	sse     map[string]string
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		objects: make(map[string][]byte),
+		sse:     make(map[string]string),
+	}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f.objects[key] = data
+		f.sse[key] = r.Header.Get("X-Amz-Server-Side-Encryption")
+		w.Header().Set("ETag", `"etag"`)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+				`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestS3Store(t *testing.T, server *httptest.Server, encrypt bool) Store {
+	t.Helper()
+
+	store, err := NewS3Store(Config{
+		Provider: ProviderS3,
+		Bucket:   "gitness",
+		S3: S3Config{
+			Endpoint:  server.URL,
+			AccessKey: "access",
+			SecretKey: "secret",
+			PathStyle: true,
+			Encrypt:   encrypt,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create s3 store: %s", err.Error())
+	}
+
+	return store
+}
+
+func TestS3Store_UploadDownload(t *testing.T) {
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store := newTestS3Store(t, server, true)
+	ctx := context.Background()
+
+	content := []byte("hello blob")
+	if err := store.Upload(ctx, bytes.NewReader(content), "lfs/abc"); err != nil {
+		t.Fatalf("failed to upload: %s", err.Error())
+	}
+
+	if got := fake.sse["gitness/lfs/abc"]; got != sseAlgorithmAES256 {
+		t.Errorf("expected server-side encryption %q, got %q", sseAlgorithmAES256, got)
+	}
+
+	rc, err := store.Download(ctx, "lfs/abc")
+	if err != nil {
+		t.Fatalf("failed to download: %s", err.Error())
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("failed to read: %s", err.Error())
+	}
+
+	if !bytes.Equal(content, data) {
+		t.Errorf("content mismatch: want=%q got=%q", content, data)
+	}
+}
+
+func TestS3Store_DownloadNotFound(t *testing.T) {
+	server := httptest.NewServer(newFakeS3())
+	defer server.Close()
+
+	store := newTestS3Store(t, server, false)
+
+	_, err := store.Download(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
+
+func TestS3Store_GetSignedURL(t *testing.T) {
+	server := httptest.NewServer(newFakeS3())
+	defer server.Close()
+
+	store := newTestS3Store(t, server, false)
+
+	signedURL, err := store.GetSignedURL(context.Background(), "avatars/1.png", time.Now().Add(time.Hour),
+		SignWithQueryParameters(url.Values{"response-content-type": {"image/png"}}))
+	if err != nil {
+		t.Fatalf("failed to get signed URL: %s", err.Error())
+	}
+
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("failed to parse signed URL: %s", err.Error())
+	}
+
+	if want := "/gitness/avatars/1.png"; u.Path != want {
+		t.Errorf("path mismatch: want=%q got=%q", want, u.Path)
+	}
+
+	query := u.Query()
+	if query.Get("X-Amz-Signature") == "" {
+		t.Error("expected the URL to be signed")
+	}
+	if want, got := "image/png", query.Get("response-content-type"); want != got {
+		t.Errorf("query parameter mismatch: want=%q got=%q", want, got)
+	}
+
+	_, err = store.GetSignedURL(context.Background(), "avatars/1.png", time.Now().Add(time.Hour),
+		SignWithMethod(http.MethodDelete))
+	if err == nil {
+		t.Error("expected an error for an unsupported method")
+	}
+}
@@ -199,6 +199,13 @@ func backfillURLs(config *types.Config) error {
 	if config.URL.Registry == "" {
 		config.URL.Registry = combineToRawURL(scheme, "host.docker.internal", port, "")
 	}
+	if config.OIDC.RedirectURL == "" {
+		redirectURL, err := url.JoinPath(config.URL.API, "v1/login/oidc/callback")
+		if err != nil {
+			return fmt.Errorf("failed to derive oidc redirect url from api url '%s': %w", config.URL.API, err)
+		}
+		config.OIDC.RedirectURL = redirectURL
+	}
 
 	return nil
 }
@@ -296,6 +303,18 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 		KeyPath:               config.BlobStore.KeyPath,
 		TargetPrincipal:       config.BlobStore.TargetPrincipal,
 		ImpersonationLifetime: config.BlobStore.ImpersonationLifetime,
+		S3: blob.S3Config{
+			Endpoint:     config.BlobStore.S3.Endpoint,
+			Region:       config.BlobStore.S3.Region,
+			AccessKey:    config.BlobStore.S3.AccessKey,
+			SecretKey:    config.BlobStore.S3.SecretKey,
+			SessionToken: config.BlobStore.S3.SessionToken,
+			PathStyle:    config.BlobStore.S3.PathStyle,
+			DisableSSL:   config.BlobStore.S3.DisableSSL,
+			SkipVerify:   config.BlobStore.S3.SkipVerify,
+			Encrypt:      config.BlobStore.S3.Encrypt,
+			KMSKeyID:     config.BlobStore.S3.KMSKeyID,
+		},
 	}, nil
 }
 
@@ -334,6 +353,13 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 		AllowLoopback:       config.Webhook.AllowLoopback,
 		InternalSecret:      config.Webhook.InternalSecret,
+
+		RetryMaxAttempts:     config.Webhook.RetryMaxAttempts,
+		RetryBackoffBase:     config.Webhook.RetryBackoffBase,
+		RetryBackoffMax:      config.Webhook.RetryBackoffMax,
+		RetryCRON:            config.Webhook.RetryCRON,
+		RetryMaxDuration:     config.Webhook.RetryMaxDuration,
+		AutoDisableThreshold: config.Webhook.AutoDisableThreshold,
 	}
 }
 
@@ -342,6 +368,9 @@ func ProvideNotificationConfig(config *types.Config) notification.Config {
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.Notification.Concurrency,
 		MaxRetries:      config.Notification.MaxRetries,
+
+		AllowLoopback:       config.Webhook.AllowLoopback,
+		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 	}
 }
 
@@ -405,10 +434,17 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
 func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
+	indexDir := config.KeywordSearch.IndexDir
+	if indexDir == "" {
+		indexDir = filepath.Join(config.Git.Root, "keyword-search")
+	}
+
 	return keywordsearch.Config{
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.KeywordSearch.Concurrency,
 		MaxRetries:      config.KeywordSearch.MaxRetries,
+		IndexDir:        indexDir,
+		MaxFileSize:     config.KeywordSearch.MaxFileSize,
 	}
 }
 
@@ -105,6 +105,31 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.RegistryCleanup.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register registry cleanup service")
+			return err
+		}
+
+		if err := system.services.MergeQueue.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register merge queue service")
+			return err
+		}
+
+		if err := system.services.AutoMerge.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pull request auto-merge service")
+			return err
+		}
+
+		if err := system.services.LDAPSync.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register ldap sync service")
+			return err
+		}
+
+		if err := system.services.Webhook.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register webhook retry service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
@@ -20,23 +20,28 @@ package main
 import (
 	"context"
 
+	"github.com/harness/gitness/app/api/controller/auditlog"
 	checkcontroller "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	githookCtrl "github.com/harness/gitness/app/api/controller/githook"
 	gitspaceCtrl "github.com/harness/gitness/app/api/controller/gitspace"
 	infraproviderCtrl "github.com/harness/gitness/app/api/controller/infraprovider"
+	"github.com/harness/gitness/app/api/controller/issue"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	notificationcontroller "github.com/harness/gitness/app/api/controller/notification"
+	"github.com/harness/gitness/app/api/controller/notificationchannel"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/scim"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -51,6 +56,8 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
 	connectorservice "github.com/harness/gitness/app/connector"
 	checkevents "github.com/harness/gitness/app/events/check"
@@ -85,6 +92,8 @@ import (
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	auditlogservice "github.com/harness/gitness/app/services/auditlog"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/branch"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
@@ -96,10 +105,13 @@ import (
 	"github.com/harness/gitness/app/services/gitspacesettings"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
+	issueservice "github.com/harness/gitness/app/services/issue"
 	"github.com/harness/gitness/app/services/keyfetcher"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	svclabel "github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/ldapsync"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	migrateservice "github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/notification"
@@ -108,6 +120,7 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/remoteauth"
 	reposervice "github.com/harness/gitness/app/services/repo"
@@ -125,7 +138,6 @@ import (
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
 	"github.com/harness/gitness/app/url"
-	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/blob"
 	cliserver "github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/encrypt"
@@ -144,6 +156,8 @@ import (
 	cargoutils "github.com/harness/gitness/registry/app/utils/cargo"
 	gopackageutils "github.com/harness/gitness/registry/app/utils/gopackage"
 	registryindex "github.com/harness/gitness/registry/services/asyncprocessing"
+	registrycleanup "github.com/harness/gitness/registry/services/cleanup"
+	registryreplication "github.com/harness/gitness/registry/services/replication"
 	registrywebhooks "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/ssh"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -180,6 +194,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		repo.WireSet,
 		reposettings.WireSet,
 		pullreq.WireSet,
+		issue.WireSet,
+		issueservice.WireSet,
 		controllerwebhook.WireSet,
 		controllerwebhook.ProvidePreprocessor,
 		svclabel.WireSet,
@@ -192,6 +208,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		system.WireSet,
 		authn.WireSet,
 		authz.WireSet,
+		oidc.WireSet,
+		ldap.WireSet,
 		infrastructure.WireSet,
 		infraproviderpkg.WireSet,
 		gitspaceevents.WireSet,
@@ -260,6 +278,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		reposervice.WireSet,
 		cliserver.ProvideCodeOwnerConfig,
 		codeowners.WireSet,
+		pullreqtemplate.WireSet,
 		gitspaceevent.WireSet,
 		cliserver.ProvideKeywordSearchConfig,
 		keywordsearch.WireSet,
@@ -268,9 +287,13 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		controllerkeywordsearch.WireSet,
 		settings.WireSet,
 		usergroup.WireSet,
+		scim.WireSet,
 		openapi.WireSet,
 		repo.ProvideRepoCheck,
-		audit.WireSet,
+		auditlogservice.WireSet,
+		auditlog.WireSet,
+		notificationcontroller.WireSet,
+		notificationchannel.WireSet,
 		ssh.WireSet,
 		publickey.WireSet,
 		keyfetcher.ProvideService,
@@ -308,6 +331,11 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		gitspacedeleteevents.WireSet,
 		gitspacedeleteeventservice.WireSet,
 		registryindex.WireSet,
+		registryreplication.WireSet,
+		registrycleanup.WireSet,
+		mergequeue.WireSet,
+		automerge.WireSet,
+		ldapsync.WireSet,
 		cliserver.ProvideBranchConfig,
 		branch.WireSet,
 		cargoutils.WireSet,
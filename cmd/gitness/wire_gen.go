@@ -9,23 +9,28 @@ package main
 import (
 	"context"
 
+	auditlog2 "github.com/harness/gitness/app/api/controller/auditlog"
 	check2 "github.com/harness/gitness/app/api/controller/check"
 	connector2 "github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	gitspace2 "github.com/harness/gitness/app/api/controller/gitspace"
 	infraprovider3 "github.com/harness/gitness/app/api/controller/infraprovider"
+	issue2 "github.com/harness/gitness/app/api/controller/issue"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
 	migrate2 "github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/notification"
+	"github.com/harness/gitness/app/api/controller/notificationchannel"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	pullreq2 "github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/scim"
 	secret2 "github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -40,6 +45,8 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/connector"
 	events12 "github.com/harness/gitness/app/events/check"
@@ -74,8 +81,10 @@ import (
 	router2 "github.com/harness/gitness/app/router"
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/auditlog"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/branch"
-	"github.com/harness/gitness/app/services/cleanup"
+	cleanup2 "github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/exporter"
@@ -88,18 +97,22 @@ import (
 	"github.com/harness/gitness/app/services/importer"
 	infraprovider2 "github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/issue"
 	"github.com/harness/gitness/app/services/keyfetcher"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/ldapsync"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/migrate"
-	"github.com/harness/gitness/app/services/notification"
+	notification2 "github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/remoteauth"
 	repo2 "github.com/harness/gitness/app/services/repo"
@@ -117,7 +130,6 @@ import (
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
 	"github.com/harness/gitness/app/url"
-	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/encrypt"
@@ -162,6 +174,8 @@ import (
 	gopackage3 "github.com/harness/gitness/registry/app/utils/gopackage"
 	"github.com/harness/gitness/registry/gc"
 	asyncprocessing2 "github.com/harness/gitness/registry/services/asyncprocessing"
+	"github.com/harness/gitness/registry/services/cleanup"
+	"github.com/harness/gitness/registry/services/replication"
 	webhook3 "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/ssh"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -201,14 +215,14 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
 	permissionCache := authz.ProvidePermissionCache(spaceFinder, membershipStore)
-	publicAccessStore := database.ProvidePublicAccessStore(db)
 	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore)
 	cacheEvictor := cache.ProvideEvictorRepositoryCore(pubSub)
 	repoIDCache := cache.ProvideRepoIDCache(ctx, repoStore, evictor, cacheEvictor)
 	repoRefCache := cache.ProvideRepoRefCache(ctx, repoStore, evictor, cacheEvictor)
 	repoFinder := refcache.ProvideRepoFinder(repoStore, spacePathCache, repoIDCache, repoRefCache, cacheEvictor)
+	publicAccessStore := database.ProvidePublicAccessStore(db)
 	publicaccessService := publicaccess.ProvidePublicAccess(config, publicAccessStore, spaceFinder, repoFinder)
-	authorizer := authz.ProvideAuthorizer(permissionCache, spaceFinder, publicaccessService)
+	authorizer := authz.ProvideAuthorizer(permissionCache, spaceFinder, repoFinder, publicaccessService)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
@@ -225,11 +239,20 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	favoriteStore := database.ProvideFavoriteStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, publicKeySubKeyStore, gitSignatureResultStore, reporter, repoFinder, favoriteStore)
+	principalIdentityStore := database.ProvidePrincipalIdentityStore(db)
+	provider, err := oidc.ProvideProvider(config)
+	if err != nil {
+		return nil, err
+	}
+	client, err := ldap.ProvideClient(config)
+	if err != nil {
+		return nil, err
+	}
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, publicKeySubKeyStore, gitSignatureResultStore, reporter, repoFinder, favoriteStore, principalIdentityStore, spaceFinder, provider, client)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
 	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
-	provider, err := url.ProvideURLProvider(config)
+	urlProvider, err := url.ProvideURLProvider(config)
 	if err != nil {
 		return nil, err
 	}
@@ -274,23 +297,30 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	streamer := sse.ProvideEventsStreaming(pubSub)
-	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
+	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
+	localIndexSearcher, err := keywordsearch.ProvideLocalIndexSearcher(keywordsearchConfig, gitInterface, repoStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
 	eventsReporter, err := events3.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	auditService := audit.ProvideAuditService()
-	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, repoFinder, encrypter, jobScheduler, executor, streamer, indexer, publicaccessService, eventsReporter, auditService, settingsService)
+	auditEventStore := database.ProvideAuditEventStore(db)
+	auditService := auditlog.ProvideService(auditEventStore)
+	repository, err := importer.ProvideRepoImporter(config, urlProvider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, repoFinder, encrypter, jobScheduler, executor, streamer, indexer, publicaccessService, eventsReporter, auditService, settingsService)
 	if err != nil {
 		return nil, err
 	}
-	referenceSync, err := importer.ProvideReferenceSync(config, provider, gitInterface, repoStore, repoFinder, jobScheduler, executor, indexer, eventsReporter)
+	referenceSync, err := importer.ProvideReferenceSync(config, urlProvider, gitInterface, repoStore, repoFinder, jobScheduler, executor, indexer, eventsReporter)
 	if err != nil {
 		return nil, err
 	}
 	codeownersConfig := server.ProvideCodeOwnerConfig(config)
-	usergroupResolver := usergroup.ProvideUserGroupResolver()
+	userGroupStore := database.ProvideUserGroupStore(db)
+	userGroupMemberStore := database.ProvideUserGroupMemberStore(db, principalInfoCache)
+	usergroupResolver := usergroup.ProvideUserGroupResolver(userGroupStore, userGroupMemberStore, spaceFinder, principalInfoCache)
 	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, usergroupResolver)
 	resourceLimiter, err := limiter.ProvideLimiter()
 	if err != nil {
@@ -302,10 +332,10 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	labelStore := database.ProvideLabelStore(db)
 	labelValueStore := database.ProvideLabelValueStore(db)
 	pullReqLabelAssignmentStore := database.ProvidePullReqLabelStore(db)
-	labelService := label.ProvideLabel(transactor, spaceStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, spaceFinder)
+	issueLabelAssignmentStore := database.ProvideIssueLabelStore(db)
+	labelService := label.ProvideLabel(transactor, spaceStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, issueLabelAssignmentStore, spaceFinder)
 	instrumentService := instrument.ProvideService()
-	userGroupStore := database.ProvideUserGroupStore(db)
-	usergroupService := usergroup.ProvideService()
+	usergroupService := usergroup.ProvideService(userGroupStore, userGroupMemberStore, spaceFinder, principalInfoCache)
 	reporter2, err := events4.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
@@ -322,10 +352,10 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	remoteauthService := remoteauth.ProvideRemoteAuth(tokenStore, principalStore)
-	lfsController := lfs.ProvideController(authorizer, repoFinder, repoStore, principalStore, lfsObjectStore, blobStore, remoteauthService, provider, settingsService)
+	lfsController := lfs.ProvideController(authorizer, repoFinder, repoStore, principalStore, lfsObjectStore, blobStore, remoteauthService, urlProvider, settingsService)
 	keyfetcherService := keyfetcher.ProvideService(publicKeyStore)
-	signatureVerifyService := publickey.ProvideSignatureVerifyService(principalStore, keyfetcherService, gitSignatureResultStore)
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, spaceFinder, repoFinder, repository, referenceSync, codeownersService, eventsReporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, usergroupService, rulesService, streamer, lfsController, favoriteStore, signatureVerifyService)
+	signatureVerifyService := publickey.ProvideSignatureVerifyService(principalStore, keyfetcherService, gitSignatureResultStore, gitInterface)
+	repoController := repo.ProvideController(config, transactor, urlProvider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, spaceFinder, repoFinder, repository, referenceSync, codeownersService, eventsReporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, usergroupService, rulesService, streamer, lfsController, favoriteStore, signatureVerifyService)
 	reposettingsController := reposettings.ProvideController(authorizer, repoFinder, settingsService, auditService)
 	stageStore := database.ProvideStageStore(db)
 	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, mutexManager)
@@ -339,7 +369,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	converterService := converter.ProvideService(fileService, publicaccessService)
 	templateStore := database.ProvideTemplateStore(db)
 	pluginStore := database.ProvidePluginStore(db)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService)
+	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, urlProvider, templateStore, pluginStore, publicaccessService)
 	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, stageStore, pipelineStore, repoFinder)
 	logStore := logs.ProvideLogStore(db, config)
 	logStream := livelog.ProvideLogStream()
@@ -348,7 +378,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	secretStore := database.ProvideSecretStore(db)
 	connectorStore := database.ProvideConnectorStore(db, secretStore)
 	listService := pullreq.ProvideListService(transactor, gitInterface, authorizer, spaceStore, pullReqStore, checkStore, repoFinder, labelService, protectionManager)
-	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
+	exporterRepository, err := exporter.ProvideSpaceExporter(urlProvider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
 	if err != nil {
 		return nil, err
 	}
@@ -377,7 +407,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	factory := infraprovider.ProvideFactory(dockerProvider)
 	cdeGatewayStore := database.ProvideCDEGatewayStore(db)
 	infraproviderService := infraprovider2.ProvideInfraProvider(transactor, gitspaceConfigStore, infraProviderResourceStore, infraProviderConfigStore, infraProviderTemplateStore, factory, spaceFinder, cdeGatewayStore)
-	gitnessSCM := scm.ProvideGitnessSCM(repoStore, repoFinder, gitInterface, tokenStore, principalStore, provider)
+	gitnessSCM := scm.ProvideGitnessSCM(repoStore, repoFinder, gitInterface, tokenStore, principalStore, urlProvider)
 	genericSCM := scm.ProvideGenericSCM()
 	scmFactory := scm.ProvideFactory(gitnessSCM, genericSCM)
 	scmSCM := scm.ProvideSCM(scmFactory)
@@ -427,7 +457,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	tokenGenerator := tokengenerator.ProvideTokenGenerator()
 	gitspaceService := gitspace.ProvideGitspace(transactor, gitspaceConfigStore, gitspaceInstanceStore, reporter3, gitspaceEventStore, spaceFinder, infraproviderService, orchestratorOrchestrator, scmSCM, config, reporter6, ideFactory, spaceStore, tokenGenerator)
 	usageMetricStore := database.ProvideUsageMetricStore(db)
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, spaceFinder, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, executionStore, rulesService, usageMetricStore, repoIdentifier, infraproviderService, favoriteStore)
+	spaceController := space.ProvideController(config, transactor, urlProvider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, spaceFinder, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, executionStore, rulesService, usageMetricStore, repoIdentifier, infraproviderService, favoriteStore)
 	reporter7, err := events9.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
@@ -459,19 +489,52 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter8, gitInterface, repoFinder, repoStore, pullReqStore, pullReqActivityStore, principalInfoCache, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter8, gitInterface, repoFinder, repoStore, pullReqStore, pullReqActivityStore, principalInfoCache, codeCommentView, migrator, pullReqFileViewStore, pubSub, urlProvider, streamer, principalStore, authorizer, protectionManager, usergroupService)
 	if err != nil {
 		return nil, err
 	}
-	pullReq := migrate.ProvidePullReqImporter(provider, gitInterface, principalStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, pullReqReviewerStore, pullReqReviewStore, repoFinder, transactor, mutexManager)
+	pullReq := migrate.ProvidePullReqImporter(urlProvider, gitInterface, principalStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, pullReqReviewerStore, pullReqReviewStore, repoFinder, transactor, mutexManager)
 	branchStore := database.ProvideBranchStore(db)
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewerStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, repoFinder, reporter8, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, usergroupService, branchStore, usergroupResolver)
+	mergeQueueStore := database.ProvideMergeQueueStore(db)
+	mergeService := pullreq.ProvideMergeService(gitInterface, pullReqStore, pullReqActivityStore, urlProvider, reporter8, eventsReporter, streamer, auditService, instrumentService)
+	readerFactory2, err := events12.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	mergequeueService, err := mergequeue.ProvideService(ctx, config, mergeQueueStore, pullReqStore, pullReqActivityStore, checkStore, principalStore, pullReqReviewerStore, repoFinder, gitInterface, protectionManager, usergroupService, authorizer, codeownersService, signatureVerifyService, mergeService, lockerLocker, reporter8, streamer, urlProvider, jobScheduler, executor, readerFactory, eventsReaderFactory, readerFactory2)
+	if err != nil {
+		return nil, err
+	}
+	autoMergeStore := database.ProvideAutoMergeStore(db)
+	automergeService, err := automerge.ProvideService(ctx, config, autoMergeStore, pullReqStore, pullReqActivityStore, pullReqReviewerStore, checkStore, principalStore, repoFinder, gitInterface, authorizer, protectionManager, codeownersService, usergroupService, signatureVerifyService, lockerLocker, streamer, mergeService, jobScheduler, executor, eventsReaderFactory, readerFactory2)
+	if err != nil {
+		return nil, err
+	}
+	pullreqtemplateService := pullreqtemplate.ProvideService(gitInterface)
+	pullreqController := pullreq2.ProvideController(transactor, urlProvider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewerStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, repoFinder, reporter8, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, usergroupService, branchStore, usergroupResolver, mergequeueService, automergeService, signatureVerifyService, pullreqtemplateService, settingsService, eventsReporter, mergeService)
+	issueStore := database.ProvideIssueStore(db, principalInfoCache)
+	issueActivityStore := database.ProvideIssueActivityStore(db, principalInfoCache)
+	issueAssigneeStore := database.ProvideIssueAssigneeStore(db)
+	milestoneStore := database.ProvideMilestoneStore(db)
+	issueService, err := issue.ProvideService(ctx, config, gitInterface, repoFinder, pullReqStore, issueStore, issueActivityStore, eventsReaderFactory)
+	if err != nil {
+		return nil, err
+	}
+	issueController := issue2.ProvideController(transactor, authorizer, repoFinder, principalStore, principalInfoCache, issueStore, issueActivityStore, issueAssigneeStore, milestoneStore, labelService, issueService)
 	webhookConfig := server.ProvideWebhookConfig(config)
+	readerFactory3, err := events3.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	readerFactory4, err := events9.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
 	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	urlProvider := webhook.ProvideURLProvider(ctx)
+	webhookURLProvider := webhook.ProvideURLProvider(ctx)
 	secretService := secret3.ProvideSecretService(secretStore, encrypter, spaceFinder)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, transactor, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter, labelStore, urlProvider, labelValueStore, auditService, streamer, secretService, spacePathStore)
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, transactor, readerFactory, eventsReaderFactory, readerFactory3, readerFactory2, readerFactory4, webhookStore, webhookExecutionStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, urlProvider, principalStore, gitInterface, encrypter, labelStore, webhookURLProvider, labelValueStore, auditService, streamer, secretService, spacePathStore, jobScheduler, checkStore, pipelineStore, executionStore, executor)
 	if err != nil {
 		return nil, err
 	}
@@ -493,10 +556,10 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoFinder, reporter9, eventsReporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, streamer, lfsObjectStore, auditService, usergroupService)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoFinder, reporter9, eventsReporter, gitInterface, pullReqStore, urlProvider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, streamer, lfsObjectStore, auditService, usergroupService, signatureVerifyService)
 	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
 	principalController := principal.ProvideController(principalStore, authorizer)
-	usergroupController := usergroup2.ProvideController(userGroupStore, spaceStore, spaceFinder, authorizer, usergroupService)
+	usergroupController := usergroup2.ProvideController(userGroupStore, userGroupMemberStore, principalStore, spaceStore, spaceFinder, authorizer, usergroupService)
 	v2 := check2.ProvideCheckSanitizers()
 	reporter10, err := events12.ProvideReporter(eventsSystem)
 	if err != nil {
@@ -513,7 +576,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	rule := migrate.ProvideRuleImporter(ruleStore, transactor, principalStore)
 	migrateWebhook := migrate.ProvideWebhookImporter(webhookConfig, transactor, webhookStore)
 	migrateLabel := migrate.ProvideLabelImporter(transactor, labelStore, labelValueStore, spaceStore)
-	migrateController := migrate2.ProvideController(authorizer, publicaccessService, gitInterface, provider, pullReq, rule, migrateWebhook, migrateLabel, resourceLimiter, auditService, repoIdentifier, transactor, spaceStore, repoStore, spaceFinder, repoFinder, eventsReporter)
+	migrateController := migrate2.ProvideController(authorizer, publicaccessService, gitInterface, urlProvider, pullReq, rule, migrateWebhook, migrateLabel, resourceLimiter, auditService, repoIdentifier, transactor, spaceStore, repoStore, spaceFinder, repoFinder, eventsReporter)
 	openapiService := openapi.ProvideOpenAPIService()
 	storageDriver, err := api2.BlobStorageProvider(config)
 	if err != nil {
@@ -538,7 +601,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	manifestService := docker.ManifestServiceProvider(registryRepository, manifestRepository, blobRepository, mediaTypesRepository, manifestReferenceRepository, tagRepository, imageRepository, artifactRepository, layerRepository, gcService, transactor, eventReporter, spaceFinder, ociImageIndexMappingRepository, artifactReporter, provider)
+	manifestService := docker.ManifestServiceProvider(registryRepository, manifestRepository, blobRepository, mediaTypesRepository, manifestReferenceRepository, tagRepository, imageRepository, artifactRepository, layerRepository, gcService, transactor, eventReporter, spaceFinder, ociImageIndexMappingRepository, artifactReporter, urlProvider)
 	registryBlobRepository := database2.ProvideRegistryBlobDao(db)
 	bandwidthStatRepository := database2.ProvideBandwidthStatDao(db)
 	downloadStatRepository := database2.ProvideDownloadStatDao(db)
@@ -554,7 +617,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	registryIDCache := cache2.ProvideRegistryIDCache(ctx, registryRepository, evictor2)
 	registryRootRefCache := cache2.ProvideRegRootRefCache(ctx, registryRepository, evictor2)
 	registryFinder := refcache2.ProvideRegistryFinder(registryRepository, registryIDCache, registryRootRefCache, evictor2, spaceFinder)
-	handler := api2.NewHandlerProvider(dockerController, spaceFinder, spaceStore, tokenStore, controller, authenticator, provider, authorizer, config, registryFinder)
+	handler := api2.NewHandlerProvider(dockerController, spaceFinder, spaceStore, tokenStore, controller, authenticator, urlProvider, authorizer, config, registryFinder)
 	registryOCIHandler := router.OCIHandlerProvider(handler)
 	genericBlobRepository := database2.ProvideGenericBlobDao(db)
 	nodesRepository := database2.ProvideNodeDao(db)
@@ -562,11 +625,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	cleanupPolicyRepository := database2.ProvideCleanupPolicyDao(db, transactor)
 	webhooksRepository := database2.ProvideWebhookDao(db)
 	webhooksExecutionRepository := database2.ProvideWebhookExecutionDao(db)
-	readerFactory2, err := artifact.ProvideReaderFactory(eventsSystem)
+	readerFactory5, err := artifact.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	service2, err := webhook3.ProvideService(ctx, webhookConfig, transactor, readerFactory2, webhooksRepository, webhooksExecutionRepository, spaceStore, provider, principalStore, urlProvider, spacePathStore, secretService, registryRepository, encrypter, spaceFinder)
+	service2, err := webhook3.ProvideService(ctx, webhookConfig, transactor, readerFactory5, webhooksRepository, webhooksExecutionRepository, spaceStore, urlProvider, principalStore, webhookURLProvider, spacePathStore, secretService, registryRepository, encrypter, spaceFinder)
 	if err != nil {
 		return nil, err
 	}
@@ -578,7 +641,19 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	registryHelper := cargo.LocalRegistryHelperProvider(fileManager, artifactRepository, spaceFinder)
-	apiHandler := router.APIHandlerProvider(registryRepository, upstreamProxyConfigRepository, fileManager, tagRepository, manifestRepository, cleanupPolicyRepository, imageRepository, storageDriver, spaceFinder, transactor, authenticator, provider, authorizer, auditService, artifactRepository, webhooksRepository, webhooksExecutionRepository, service2, spacePathStore, artifactReporter, downloadStatRepository, config, registryBlobRepository, registryFinder, asyncprocessingReporter, registryHelper, spaceController, quarantineArtifactRepository, spaceStore)
+	replicationRuleRepository := database2.ProvideReplicationRuleDao(db)
+	replicationExecutionRepository := database2.ProvideReplicationExecutionDao(db)
+	replicationConfig := replication.ProvideReplicationConfig(config)
+	replicationService, err := replication.ProvideService(ctx, replicationConfig, readerFactory5, replicationRuleRepository, replicationExecutionRepository, registryRepository, tagRepository, spaceFinder, principalStore, authorizer, secretService, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	cleanupConfig := cleanup.ProvideCleanupConfig(config)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, transactor, cleanupPolicyRepository, registryRepository, imageRepository, artifactRepository, tagRepository, manifestRepository, fileManager, asyncprocessingReporter, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	apiHandler := router.APIHandlerProvider(registryRepository, upstreamProxyConfigRepository, fileManager, tagRepository, manifestRepository, cleanupPolicyRepository, imageRepository, storageDriver, spaceFinder, transactor, authenticator, urlProvider, authorizer, auditService, artifactRepository, webhooksRepository, webhooksExecutionRepository, service2, spacePathStore, artifactReporter, downloadStatRepository, config, registryBlobRepository, registryFinder, asyncprocessingReporter, registryHelper, spaceController, quarantineArtifactRepository, spaceStore, replicationRuleRepository, replicationExecutionRepository, replicationService, cleanupService)
 	packageTagRepository := database2.ProvidePackageTagDao(db)
 	localBase := base.LocalBaseProvider(registryRepository, fileManager, transactor, imageRepository, artifactRepository, nodesRepository, packageTagRepository)
 	mavenDBStore := maven.DBStoreProvider(registryRepository, imageRepository, artifactRepository, spaceStore, bandwidthStatRepository, downloadStatRepository, nodesRepository, upstreamProxyConfigRepository)
@@ -590,64 +665,72 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	handler2 := router.MavenHandlerProvider(mavenHandler)
 	genericDBStore := generic.DBStoreProvider(imageRepository, artifactRepository, bandwidthStatRepository, downloadStatRepository, registryRepository)
 	genericController := generic.ControllerProvider(spaceStore, authorizer, fileManager, genericDBStore, transactor, spaceFinder)
-	packagesHandler := api2.NewPackageHandlerProvider(registryRepository, downloadStatRepository, spaceStore, tokenStore, controller, authenticator, provider, authorizer, spaceFinder, registryFinder, fileManager, quarantineArtifactRepository)
-	genericHandler := api2.NewGenericHandlerProvider(spaceStore, genericController, tokenStore, controller, authenticator, provider, authorizer, packagesHandler, spaceFinder)
+	packagesHandler := api2.NewPackageHandlerProvider(registryRepository, downloadStatRepository, spaceStore, tokenStore, controller, authenticator, urlProvider, authorizer, spaceFinder, registryFinder, fileManager, quarantineArtifactRepository)
+	genericHandler := api2.NewGenericHandlerProvider(spaceStore, genericController, tokenStore, controller, authenticator, urlProvider, authorizer, packagesHandler, spaceFinder)
 	handler3 := router.GenericHandlerProvider(genericHandler)
-	pythonLocalRegistry := python.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider)
+	pythonLocalRegistry := python.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider)
 	localRegistryHelper := python.LocalRegistryHelperProvider(pythonLocalRegistry, localBase)
-	proxy := python.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, localRegistryHelper)
-	pythonController := python2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, pythonLocalRegistry, proxy)
+	proxy := python.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, localRegistryHelper)
+	pythonController := python2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, pythonLocalRegistry, proxy)
 	pythonHandler := api2.NewPythonHandlerProvider(pythonController, packagesHandler)
-	nugetLocalRegistry := nuget.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider)
+	nugetLocalRegistry := nuget.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider)
 	nugetLocalRegistryHelper := nuget.LocalRegistryHelperProvider(nugetLocalRegistry, localBase)
-	nugetProxy := nuget.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, nugetLocalRegistryHelper)
-	nugetController := nuget2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, nugetLocalRegistry, nugetProxy)
+	nugetProxy := nuget.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, nugetLocalRegistryHelper)
+	nugetController := nuget2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, nugetLocalRegistry, nugetProxy)
 	nugetHandler := api2.NewNugetHandlerProvider(nugetController, packagesHandler)
-	npmLocalRegistry := npm.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, packageTagRepository, registryRepository, imageRepository, artifactRepository, nodesRepository, provider)
+	npmLocalRegistry := npm.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, packageTagRepository, registryRepository, imageRepository, artifactRepository, nodesRepository, urlProvider)
 	npmLocalRegistryHelper := npm.LocalRegistryHelperProvider(npmLocalRegistry, localBase)
-	npmProxy := npm.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, npmLocalRegistryHelper)
-	npmController := npm2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, downloadStatRepository, provider, npmLocalRegistry, npmProxy)
+	npmProxy := npm.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, npmLocalRegistryHelper)
+	npmController := npm2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, downloadStatRepository, urlProvider, npmLocalRegistry, npmProxy)
 	npmHandler := api2.NewNPMHandlerProvider(npmController, packagesHandler)
 	rpmRegistryHelper := rpm.RegistryHelperProvider(localBase, fileManager, asyncprocessingReporter)
-	rpmLocalRegistry := rpm.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, rpmRegistryHelper)
-	rpmProxy := rpm.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, localBase, rpmRegistryHelper, spaceFinder, secretService)
-	rpmController := rpm2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, rpmLocalRegistry, rpmProxy, asyncprocessingReporter)
+	rpmLocalRegistry := rpm.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider, rpmRegistryHelper)
+	rpmProxy := rpm.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, localBase, rpmRegistryHelper, spaceFinder, secretService)
+	rpmController := rpm2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, rpmLocalRegistry, rpmProxy, asyncprocessingReporter)
 	rpmHandler := api2.NewRpmHandlerProvider(rpmController, packagesHandler)
-	cargoLocalRegistry := cargo2.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, artifactReporter, asyncprocessingReporter)
+	cargoLocalRegistry := cargo2.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider, artifactReporter, asyncprocessingReporter)
 	cargoLocalRegistryHelper := cargo2.LocalRegistryHelperProvider(cargoLocalRegistry, localBase, asyncprocessingReporter)
-	cargoProxy := cargo2.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, cargoLocalRegistryHelper, artifactReporter)
-	cargoController := cargo3.ControllerProvider(upstreamProxyConfigRepository, registryRepository, registryFinder, imageRepository, artifactRepository, fileManager, transactor, provider, cargoLocalRegistry, cargoProxy)
+	cargoProxy := cargo2.ProxyProvider(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, cargoLocalRegistryHelper, artifactReporter)
+	cargoController := cargo3.ControllerProvider(upstreamProxyConfigRepository, registryRepository, registryFinder, imageRepository, artifactRepository, fileManager, transactor, urlProvider, cargoLocalRegistry, cargoProxy)
 	cargoHandler := api2.NewCargoHandlerProvider(cargoController, packagesHandler)
-	gopackageLocalRegistry := gopackage.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider, artifactReporter, asyncprocessingReporter)
+	gopackageLocalRegistry := gopackage.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider, artifactReporter, asyncprocessingReporter)
 	gopackageLocalRegistryHelper := gopackage.LocalRegistryHelperProvider(gopackageLocalRegistry, localBase, asyncprocessingReporter)
-	gopackageProxy := gopackage.ProxyProvider(localBase, upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, spaceFinder, secretService, artifactReporter, gopackageLocalRegistryHelper)
-	gopackageController := gopackage2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, registryFinder, imageRepository, artifactRepository, fileManager, transactor, provider, gopackageLocalRegistry, gopackageProxy)
+	gopackageProxy := gopackage.ProxyProvider(localBase, upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, spaceFinder, secretService, artifactReporter, gopackageLocalRegistryHelper)
+	gopackageController := gopackage2.ControllerProvider(upstreamProxyConfigRepository, registryRepository, registryFinder, imageRepository, artifactRepository, fileManager, transactor, urlProvider, gopackageLocalRegistry, gopackageProxy)
 	gopackageHandler := api2.NewGoPackageHandlerProvider(gopackageController, packagesHandler)
-	huggingfaceLocalRegistry := huggingface.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, provider)
-	huggingfaceController := huggingface2.ProvideController(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, provider, huggingfaceLocalRegistry)
+	huggingfaceLocalRegistry := huggingface.LocalRegistryProvider(localBase, fileManager, upstreamProxyConfigRepository, transactor, registryRepository, imageRepository, artifactRepository, urlProvider)
+	huggingfaceController := huggingface2.ProvideController(upstreamProxyConfigRepository, registryRepository, imageRepository, artifactRepository, fileManager, transactor, urlProvider, huggingfaceLocalRegistry)
 	huggingfaceHandler := huggingface3.ProvideHandler(huggingfaceController, packagesHandler)
 	handler4 := router.PackageHandlerProvider(packagesHandler, mavenHandler, genericHandler, pythonHandler, nugetHandler, npmHandler, rpmHandler, cargoHandler, gopackageHandler, huggingfaceHandler)
 	appRouter := router.AppRouterProvider(registryOCIHandler, apiHandler, handler2, handler3, handler4)
-	readerFactory3, err := events3.ProvideReaderFactory(eventsSystem)
+	sender, err := usage.ProvideMediator(ctx, config, spaceFinder, repoFinder, usageMetricStore, readerFactory3)
 	if err != nil {
 		return nil, err
 	}
-	sender, err := usage.ProvideMediator(ctx, config, spaceFinder, repoFinder, usageMetricStore, readerFactory3)
+	auditlogController := auditlog2.ProvideController(auditEventStore)
+	notificationStore := database.ProvideNotificationStore(db)
+	notificationSubscriptionStore := database.ProvideNotificationSubscriptionStore(db)
+	notificationController := notification.ProvideController(authorizer, notificationStore, notificationSubscriptionStore, principalInfoCache, repoFinder, spaceFinder, streamer)
+	notificationConfig := server.ProvideNotificationConfig(config)
+	notificationChannelStore := database.ProvideNotificationChannelStore(db)
+	chatClient := notification2.ProvideChatClient(notificationConfig, notificationChannelStore, spaceStore)
+	notificationchannelController := notificationchannel.ProvideController(notificationConfig, authorizer, spaceFinder, notificationChannelStore, chatClient)
+	scimController, err := scim.ProvideController(config, transactor, principalStore, principalIdentityStore, userGroupStore, userGroupMemberStore, membershipStore, spaceFinder, controller)
 	if err != nil {
 		return nil, err
 	}
-	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, provider, openapiService, appRouter, sender, lfsController)
+	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, issueController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, urlProvider, openapiService, appRouter, sender, lfsController, auditlogController, notificationController, notificationchannelController, scimController)
 	serverServer := server2.ProvideServer(config, routerRouter)
 	sshAuthService := publickey.ProvideSSHAuthService(publicKeyStore, principalInfoCache)
 	sshServer := ssh.ProvideServer(config, sshAuthService, repoController, lfsController)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter7)
-	client := manager.ProvideExecutionClient(executionManager, provider, config)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, urlProvider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter7)
+	clientClient := manager.ProvideExecutionClient(executionManager, urlProvider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
-	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
+	runtimeRunner, err := runner.ProvideExecutionRunner(config, clientClient, resolverManager)
 	if err != nil {
 		return nil, err
 	}
-	poller := runner.ProvideExecutionPoller(runtimeRunner, client)
+	poller := runner.ProvideExecutionPoller(runtimeRunner, clientClient)
 	triggerConfig := server.ProvideTriggerConfig(config)
 	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoFinder, pipelineStore, triggererTriggerer, readerFactory, eventsReaderFactory)
 	if err != nil {
@@ -657,15 +740,15 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	readerFactory4, err := events2.ProvideReaderFactory(eventsSystem)
+	readerFactory6, err := events2.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory5, err := events4.ProvideReaderFactory(eventsSystem)
+	readerFactory7, err := events4.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	submitter, err := metric.ProvideSubmitter(ctx, config, values, principalStore, principalInfoCache, pullReqStore, ruleStore, readerFactory4, readerFactory3, eventsReaderFactory, readerFactory5, publicaccessService, spaceFinder, repoFinder)
+	submitter, err := metric.ProvideSubmitter(ctx, config, values, principalStore, principalInfoCache, pullReqStore, ruleStore, readerFactory6, readerFactory3, eventsReaderFactory, readerFactory7, publicaccessService, spaceFinder, repoFinder)
 	if err != nil {
 		return nil, err
 	}
@@ -677,58 +760,58 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	repoService, err := repo2.ProvideService(ctx, config, eventsReporter, readerFactory3, repoStore, provider, gitInterface, lockerLocker)
+	repoService, err := repo2.ProvideService(ctx, config, eventsReporter, readerFactory3, repoStore, urlProvider, gitInterface, lockerLocker)
 	if err != nil {
 		return nil, err
 	}
-	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	config2 := server.ProvideCleanupConfig(config)
+	service3, err := cleanup2.ProvideService(config2, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
 	if err != nil {
 		return nil, err
 	}
 	mailerMailer := mailer.ProvideMailClient(config)
-	notificationClient := notification.ProvideMailClient(mailerMailer)
-	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	mailClient := notification2.ProvideMailClient(mailerMailer)
+	inboxClient := notification2.ProvideInboxClient(notificationStore, notificationSubscriptionStore, spaceStore, streamer)
+	notificationClient := notification2.ProvideNotificationClient(mailClient, inboxClient, chatClient)
+	notificationService, err := notification2.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, readerFactory4, readerFactory3, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, pipelineStore, executionStore, urlProvider)
 	if err != nil {
 		return nil, err
 	}
-	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
 	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, readerFactory3, repoStore, indexer)
 	if err != nil {
 		return nil, err
 	}
 	gitspaceeventConfig := server.ProvideGitspaceEventConfig(config)
-	readerFactory6, err := events5.ProvideReaderFactory(eventsSystem)
+	readerFactory8, err := events5.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceeventService, err := gitspaceevent.ProvideService(ctx, gitspaceeventConfig, readerFactory6, gitspaceEventStore)
+	gitspaceeventService, err := gitspaceevent.ProvideService(ctx, gitspaceeventConfig, readerFactory8, gitspaceEventStore)
 	if err != nil {
 		return nil, err
 	}
 	gitspacedeleteeventConfig := server.ProvideGitspaceDeleteEventConfig(config)
-	readerFactory7, err := events8.ProvideReaderFactory(eventsSystem)
+	readerFactory9, err := events8.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspacedeleteeventService, err := gitspacedeleteevent.ProvideService(ctx, gitspacedeleteeventConfig, readerFactory7, gitspaceService)
+	gitspacedeleteeventService, err := gitspacedeleteevent.ProvideService(ctx, gitspacedeleteeventConfig, readerFactory9, gitspaceService)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory8, err := events6.ProvideReaderFactory(eventsSystem)
+	readerFactory10, err := events6.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceinfraeventService, err := gitspaceinfraevent.ProvideService(ctx, gitspaceeventConfig, readerFactory8, orchestratorOrchestrator, gitspaceService, reporter3)
+	gitspaceinfraeventService, err := gitspaceinfraevent.ProvideService(ctx, gitspaceeventConfig, readerFactory10, orchestratorOrchestrator, gitspaceService, reporter3)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory9, err := events7.ProvideReaderFactory(eventsSystem)
+	readerFactory11, err := events7.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceoperationseventService, err := gitspaceoperationsevent.ProvideService(ctx, gitspaceeventConfig, readerFactory9, orchestratorOrchestrator, gitspaceService, reporter3)
+	gitspaceoperationseventService, err := gitspaceoperationsevent.ProvideService(ctx, gitspaceeventConfig, readerFactory11, orchestratorOrchestrator, gitspaceService, reporter3)
 	if err != nil {
 		return nil, err
 	}
@@ -748,16 +831,20 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	rpmHelper := asyncprocessing2.ProvideRpmHelper(fileManager, artifactRepository, upstreamProxyConfigRepository, spaceFinder, secretService, registryRepository)
 	gopackageRegistryHelper := gopackage3.LocalRegistryHelperProvider(fileManager, artifactRepository, spaceFinder, registryFinder)
-	readerFactory10, err := asyncprocessing.ProvideReaderFactory(eventsSystem)
+	readerFactory12, err := asyncprocessing.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	asyncprocessingConfig := asyncprocessing2.ProvideRegistryPostProcessingConfig(config)
-	asyncprocessingService, err := asyncprocessing2.ProvideService(ctx, transactor, rpmHelper, registryHelper, gopackageRegistryHelper, lockerLocker, readerFactory10, asyncprocessingConfig, registryRepository, taskRepository, taskSourceRepository, taskEventRepository, eventsSystem, asyncprocessingReporter)
+	asyncprocessingService, err := asyncprocessing2.ProvideService(ctx, transactor, rpmHelper, registryHelper, gopackageRegistryHelper, lockerLocker, readerFactory12, asyncprocessingConfig, registryRepository, taskRepository, taskSourceRepository, taskEventRepository, eventsSystem, asyncprocessingReporter)
+	if err != nil {
+		return nil, err
+	}
+	ldapsyncService, err := ldapsync.ProvideService(config, client, principalStore, principalIdentityStore, userGroupStore, userGroupMemberStore, membershipStore, spaceFinder, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collectorJob, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, service2, branchService, asyncprocessingService)
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collectorJob, sizeCalculator, repoService, service3, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, service2, branchService, asyncprocessingService, cleanupService, mergequeueService, automergeService, ldapsyncService)
 	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, resolverManager, servicesServices)
 	return serverSystem, nil
 }
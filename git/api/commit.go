@@ -193,6 +193,7 @@ func (g *Git) ListCommitSHAs(
 func (g *Git) ListCommits(
 	ctx context.Context,
 	repoPath string,
+	alternateObjectDirs []string,
 	ref string,
 	page int,
 	limit int,
@@ -203,12 +204,12 @@ func (g *Git) ListCommits(
 		return nil, nil, ErrRepositoryPathEmpty
 	}
 
-	commitSHAs, err := g.listCommitSHAs(ctx, repoPath, nil, ref, page, limit, filter)
+	commitSHAs, err := g.listCommitSHAs(ctx, repoPath, alternateObjectDirs, ref, page, limit, filter)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to list commit SHAs: %w", err)
 	}
 
-	commits, err := CatFileCommits(ctx, repoPath, nil, commitSHAs)
+	commits, err := CatFileCommits(ctx, repoPath, alternateObjectDirs, commitSHAs)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to list commits by SHAs: %w", err)
 	}
@@ -640,8 +640,14 @@ func (g *Git) DiffFileName(ctx context.Context,
 	headRef string,
 	mergeBase bool,
 	ignoreWhitespace bool,
+	alternates []string,
 ) ([]string, error) {
-	cmd := command.New("diff", command.WithFlag("--name-only"))
+	// renames are listed as deletion and addition, so both the old and the new path are returned.
+	cmd := command.New("diff",
+		command.WithFlag("--name-only"),
+		command.WithFlag("--no-renames"),
+		command.WithAlternateObjectDirs(alternates...),
+	)
 	if mergeBase {
 		cmd.Add(command.WithFlag("--merge-base"))
 	}
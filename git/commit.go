@@ -170,6 +170,7 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	gitCommits, renameDetails, err := s.git.ListCommits(
 		ctx,
 		repoPath,
+		params.AlternateObjectDirs,
 		params.GitREF,
 		int(params.Page),
 		int(params.Limit),
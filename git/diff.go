@@ -445,6 +445,7 @@ func (s *Service) DiffFileNames(ctx context.Context, params *DiffParams) (DiffFi
 		params.HeadRef,
 		params.MergeBase,
 		params.IgnoreWhitespace,
+		params.AlternateObjectDirs,
 	)
 	if err != nil {
 		return DiffFileNamesOutput{}, fmt.Errorf("failed to get diff file data between '%s' and '%s': %w",
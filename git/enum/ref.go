@@ -22,6 +22,7 @@ const (
 	RefTypeTag
 	RefTypePullReqHead
 	RefTypePullReqMerge
+	RefTypePullReqQueue
 )
 
 func (t RefType) String() string {
@@ -36,6 +37,8 @@ func (t RefType) String() string {
 		return "head"
 	case RefTypePullReqMerge:
 		return "merge"
+	case RefTypePullReqQueue:
+		return "queue"
 	default:
 		return ""
 	}
@@ -67,6 +67,11 @@ type MergeParams struct {
 	DeleteHeadBranch bool
 
 	Method enum.MergeMethod
+
+	// UpstreamSHA, if provided, is used instead of the merge base of the base and the head commits
+	// to select the commits of the head branch that are applied on top of the base commit,
+	// like "git rebase --onto <base> <upstream>" does. Supported only by the rebase merge method.
+	UpstreamSHA sha.SHA
 }
 
 type RefUpdate struct {
@@ -101,6 +106,10 @@ func (p *MergeParams) Validate() error {
 		}
 	}
 
+	if !p.UpstreamSHA.IsEmpty() && p.Method != enum.MergeMethodRebase {
+		return errors.InvalidArgument("upstream commit SHA is supported only by the rebase merge method")
+	}
+
 	return nil
 }
 
@@ -264,6 +273,11 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 	var mergeCommitSHA sha.SHA
 	var conflicts []string
 
+	mergeFuncBaseSHA := mergeBaseCommitSHA
+	if !params.UpstreamSHA.IsEmpty() {
+		mergeFuncBaseSHA = params.UpstreamSHA
+	}
+
 	err = sharedrepo.Run(ctx, refUpdater, s.sharedRepoRoot, repoPath, func(s *sharedrepo.SharedRepo) error {
 		mergeCommitSHA, conflicts, err = mergeFunc(
 			ctx,
@@ -272,7 +286,7 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 				Author:       &author,
 				Committer:    &committer,
 				Message:      message,
-				MergeBaseSHA: mergeBaseCommitSHA,
+				MergeBaseSHA: mergeFuncBaseSHA,
 				TargetSHA:    baseCommitSHA,
 				SourceSHA:    headCommitSHA,
 			})
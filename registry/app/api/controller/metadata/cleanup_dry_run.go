@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+// GetRegistryCleanupDryRun returns the versions the cleanup policies of the registry would delete
+// if they were enforced right now.
+func (c *APIController) GetRegistryCleanupDryRun(
+	ctx context.Context,
+	r artifact.GetRegistryCleanupDryRunRequestObject,
+) (artifact.GetRegistryCleanupDryRunResponseObject, error) {
+	regInfo, err := c.RegistryMetadataHelper.GetRegistryRequestBaseInfo(ctx, "", string(r.RegistryRef))
+	if err != nil {
+		return artifact.GetRegistryCleanupDryRun400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, err.Error()),
+			),
+		}, nil
+	}
+	space, err := c.SpaceFinder.FindByRef(ctx, regInfo.ParentRef)
+	if err != nil {
+		return artifact.GetRegistryCleanupDryRun400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, err.Error()),
+			),
+		}, nil
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	permissionChecks := c.RegistryMetadataHelper.GetPermissionChecks(space, regInfo.RegistryIdentifier,
+		enum.PermissionRegistryView)
+	if err = apiauth.CheckRegistry(
+		ctx,
+		c.Authorizer,
+		session,
+		permissionChecks...,
+	); err != nil {
+		return artifact.GetRegistryCleanupDryRun403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(
+				*GetErrorResponse(http.StatusForbidden, err.Error()),
+			),
+		}, nil
+	}
+
+	registry, err := c.RegistryRepository.GetByParentIDAndName(ctx, regInfo.ParentID, regInfo.RegistryIdentifier)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return artifact.GetRegistryCleanupDryRun404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(
+				*GetErrorResponse(http.StatusNotFound, "registry doesn't exist with this key"),
+			),
+		}, nil
+	}
+	if err != nil {
+		return throwGetRegistryCleanupDryRun500Error(err), nil
+	}
+
+	candidates, err := c.CleanupService.DryRun(ctx, registry)
+	if err != nil {
+		return throwGetRegistryCleanupDryRun500Error(err), nil
+	}
+
+	return artifact.GetRegistryCleanupDryRun200JSONResponse{
+		CleanupDryRunResponseJSONResponse: artifact.CleanupDryRunResponseJSONResponse{
+			Data:   *CreateCleanupDryRunReport(candidates),
+			Status: artifact.StatusSUCCESS,
+		},
+	}, nil
+}
+
+func CreateCleanupDryRunReport(candidates []types.CleanupCandidate) *artifact.CleanupDryRunReport {
+	items := make([]artifact.CleanupCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		lastModified := GetTimeInMs(c.LastModified)
+		items = append(items, artifact.CleanupCandidate{
+			Package:      c.Package,
+			Version:      c.Version,
+			Policy:       c.Policy,
+			Reason:       c.Reason,
+			LastModified: &lastModified,
+		})
+	}
+
+	return &artifact.CleanupDryRunReport{
+		ItemCount: int64(len(items)),
+		Items:     items,
+	}
+}
+
+func throwGetRegistryCleanupDryRun500Error(err error) artifact.GetRegistryCleanupDryRun500JSONResponse {
+	return artifact.GetRegistryCleanupDryRun500JSONResponse{
+		InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(
+			*GetErrorResponse(http.StatusInternalServerError, err.Error()),
+		),
+	}
+}
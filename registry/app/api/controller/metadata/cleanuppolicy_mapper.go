@@ -55,14 +55,26 @@ func getCleanupPolicyEntity(
 	cleanupPolicy artifact.CleanupPolicy,
 	repoID int64,
 ) *types.CleanupPolicy {
-	expireTime := time.Duration(*cleanupPolicy.ExpireDays) * 24 * time.Hour
-	return &types.CleanupPolicy{
-		Name:          *cleanupPolicy.Name,
-		VersionPrefix: *cleanupPolicy.VersionPrefix,
-		PackagePrefix: *cleanupPolicy.PackagePrefix,
-		ExpiryTime:    expireTime.Milliseconds(),
-		RegistryID:    repoID,
+	entity := &types.CleanupPolicy{
+		RegistryID: repoID,
 	}
+	if cleanupPolicy.Name != nil {
+		entity.Name = *cleanupPolicy.Name
+	}
+	if cleanupPolicy.VersionPrefix != nil {
+		entity.VersionPrefix = *cleanupPolicy.VersionPrefix
+	}
+	if cleanupPolicy.PackagePrefix != nil {
+		entity.PackagePrefix = *cleanupPolicy.PackagePrefix
+	}
+	if cleanupPolicy.ExpireDays != nil {
+		expireTime := time.Duration(*cleanupPolicy.ExpireDays) * 24 * time.Hour
+		entity.ExpiryTime = expireTime.Milliseconds()
+	}
+	if cleanupPolicy.KeepVersions != nil {
+		entity.KeepVersions = *cleanupPolicy.KeepVersions
+	}
+	return entity
 }
 
 func getCleanupPolicyDto(
@@ -70,12 +82,15 @@ func getCleanupPolicyDto(
 ) *artifact.CleanupPolicy {
 	packagePrefix := cleanupPolicy.PackagePrefix
 	versionPrefix := cleanupPolicy.VersionPrefix
-	expiryDays := int(time.Duration(cleanupPolicy.ExpiryTime).Hours() / 24)
+	// expiry time is stored in milliseconds.
+	expiryDays := int((time.Duration(cleanupPolicy.ExpiryTime) * time.Millisecond).Hours() / 24)
+	keepVersions := cleanupPolicy.KeepVersions
 
 	return &artifact.CleanupPolicy{
 		Name:          &cleanupPolicy.Name,
 		VersionPrefix: &versionPrefix,
 		PackagePrefix: &packagePrefix,
 		ExpireDays:    &expiryDays,
+		KeepVersions:  &keepVersions,
 	}
 }
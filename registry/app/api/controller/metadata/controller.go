@@ -28,6 +28,8 @@ import (
 	"github.com/harness/gitness/registry/app/services/refcache"
 	"github.com/harness/gitness/registry/app/store"
 	"github.com/harness/gitness/registry/app/utils/cargo"
+	"github.com/harness/gitness/registry/services/cleanup"
+	"github.com/harness/gitness/registry/services/replication"
 	"github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/store/database/dbtx"
 )
@@ -64,6 +66,10 @@ type APIController struct {
 	SpaceController              *spacecontroller.Controller
 	QuarantineArtifactRepository store.QuarantineArtifactRepository
 	SpaceStore                   gstore.SpaceStore
+	ReplicationRuleStore         store.ReplicationRuleRepository
+	ReplicationExecutionStore    store.ReplicationExecutionRepository
+	ReplicationService           replication.ServiceInterface
+	CleanupService               cleanup.ServiceInterface
 }
 
 func NewAPIController(
@@ -97,6 +103,10 @@ func NewAPIController(
 	spaceController *spacecontroller.Controller,
 	quarantineArtifactRepository store.QuarantineArtifactRepository,
 	spaceStore gstore.SpaceStore,
+	replicationRuleStore store.ReplicationRuleRepository,
+	replicationExecutionStore store.ReplicationExecutionRepository,
+	replicationService replication.ServiceInterface,
+	cleanupService cleanup.ServiceInterface,
 ) *APIController {
 	return &APIController{
 		fileManager:                  fileManager,
@@ -129,5 +139,9 @@ func NewAPIController(
 		SpaceController:              spaceController,
 		QuarantineArtifactRepository: quarantineArtifactRepository,
 		SpaceStore:                   spaceStore,
+		ReplicationRuleStore:         replicationRuleStore,
+		ReplicationExecutionStore:    replicationExecutionStore,
+		ReplicationService:           replicationService,
+		CleanupService:               cleanupService,
 	}
 }
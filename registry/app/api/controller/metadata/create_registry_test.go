@@ -272,6 +272,10 @@ func TestCreateRegistry(t *testing.T) {
 					nil,
 					nil,
 					nil,
+					nil, // replicationRuleStore.
+					nil, // replicationExecutionStore.
+					nil, // replicationService.
+					nil, // cleanupService.
 				)
 			},
 		},
@@ -348,6 +352,10 @@ func TestCreateRegistry(t *testing.T) {
 					nil,
 					nil,
 					nil,
+					nil, // replicationRuleStore.
+					nil, // replicationExecutionStore.
+					nil, // replicationService.
+					nil, // cleanupService.
 				)
 			},
 		},
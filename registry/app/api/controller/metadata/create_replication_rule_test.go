@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/registry/app/api/controller/metadata"
+	"github.com/harness/gitness/registry/app/api/controller/mocks"
+	api "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/store"
+	registrytypes "github.com/harness/gitness/registry/types"
+	coretypes "github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testReplicationRuleStore struct {
+	store.ReplicationRuleRepository
+	created []*registrytypes.ReplicationRule
+}
+
+func (s *testReplicationRuleStore) Create(_ context.Context, rule *registrytypes.ReplicationRule) error {
+	s.created = append(s.created, rule)
+	return nil
+}
+
+func TestCreateReplicationRuleSecretAccess(t *testing.T) {
+	space := &coretypes.SpaceCore{ID: 2, Path: "root/parent"}
+	secretSpace := &coretypes.SpaceCore{ID: 5, Path: "other"}
+	registry := &registrytypes.Registry{
+		ID:          1,
+		Name:        "local",
+		ParentID:    space.ID,
+		Type:        api.RegistryTypeVIRTUAL,
+		PackageType: api.PackageTypeDOCKER,
+	}
+	session := &auth.Session{Principal: coretypes.Principal{ID: 123}}
+
+	tests := []struct {
+		name          string
+		secretAccess  bool
+		expectCreated bool
+	}{
+		{
+			name:          "secret_access_granted",
+			secretAccess:  true,
+			expectCreated: true,
+		},
+		{
+			name:         "secret_access_denied",
+			secretAccess: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSpaceFinder := new(mocks.SpaceFinder)
+			mockSpaceFinder.On("FindByRef", mock.Anything, space.Path).Return(space, nil)
+			mockSpaceFinder.On("FindByRef", mock.Anything, secretSpace.Path).Return(secretSpace, nil)
+			mockSpaceFinder.On("FindByID", mock.Anything, secretSpace.ID).Return(secretSpace, nil)
+
+			mockRegistryRepository := new(mocks.RegistryRepository)
+			mockRegistryRepository.On("GetByParentIDAndName", mock.Anything, space.ID, registry.Name).
+				Return(registry, nil)
+			mockRegistryRepository.On("Get", mock.Anything, registry.ID).Return(registry, nil)
+
+			permissionCheck := coretypes.PermissionCheck{
+				Scope:      coretypes.Scope{SpacePath: space.Path},
+				Resource:   coretypes.Resource{Type: enum.ResourceTypeRegistry, Identifier: registry.Name},
+				Permission: enum.PermissionArtifactsDownload,
+			}
+			mockRegistryMetadataHelper := new(mocks.RegistryMetadataHelper)
+			mockRegistryMetadataHelper.On("GetPermissionChecks", space, registry.Name, enum.PermissionArtifactsDownload).
+				Return([]coretypes.PermissionCheck{permissionCheck})
+
+			mockAuthorizer := new(mocks.Authorizer)
+			mockAuthorizer.On("Check", mock.Anything, session, &coretypes.Scope{SpacePath: space.Path},
+				&coretypes.Resource{Type: enum.ResourceTypeRegistry}, enum.PermissionRegistryEdit).Return(true, nil)
+			mockAuthorizer.On("CheckAll", mock.Anything, session, permissionCheck).Return(true, nil)
+			mockAuthorizer.On("Check", mock.Anything, session, &coretypes.Scope{SpacePath: secretSpace.Path},
+				&coretypes.Resource{Type: enum.ResourceTypeSecret, Identifier: "jfrog-token"},
+				enum.PermissionSecretAccess).Return(tt.secretAccess, nil)
+
+			ruleStore := &testReplicationRuleStore{}
+			controller := &metadata.APIController{
+				SpaceFinder:            mockSpaceFinder,
+				RegistryRepository:     mockRegistryRepository,
+				RegistryMetadataHelper: mockRegistryMetadataHelper,
+				Authorizer:             mockAuthorizer,
+				ReplicationRuleStore:   ruleStore,
+			}
+
+			var source, destination api.ReplicationRegistry
+			require.NoError(t, source.FromLocalReplicationRegistry(api.LocalReplicationRegistry{
+				RegistryIdentifier: registry.Name,
+			}))
+			require.NoError(t, destination.FromJfrogReplicationRegistry(api.JfrogReplicationRegistry{
+				Url:                   "https://example.jfrog.io",
+				Namespace:             "docker-remote",
+				PasswordSecretId:      &[]string{"jfrog-token"}[0],
+				PasswordSecretSpaceId: &secretSpace.Path,
+			}))
+
+			spaceRef := api.SpaceRefQueryParam(space.Path)
+			ctx := request.WithAuthSession(context.Background(), session)
+			resp, err := controller.CreateReplicationRule(ctx, api.CreateReplicationRuleRequestObject{
+				Params: api.CreateReplicationRuleParams{SpaceRef: &spaceRef},
+				Body: &api.CreateReplicationRuleJSONRequestBody{
+					SourceType:      "Local",
+					Source:          source,
+					DestinationType: "Jfrog",
+					Destination:     destination,
+				},
+			})
+			require.NoError(t, err)
+
+			if !tt.expectCreated {
+				_, ok := resp.(api.CreateReplicationRule403JSONResponse)
+				assert.True(t, ok, "Expected 403 response, got %T", resp)
+				assert.Empty(t, ruleStore.created, "Rule shouldn't be created")
+				return
+			}
+
+			_, ok := resp.(api.CreateReplicationRule200JSONResponse)
+			assert.True(t, ok, "Expected 200 response, got %T", resp)
+			require.Len(t, ruleStore.created, 1)
+			assert.Equal(t, secretSpace.ID, ruleStore.created[0].Destination.SecretSpaceID)
+			assert.Equal(t, "jfrog-token", ruleStore.created[0].Destination.SecretID)
+		})
+	}
+}
@@ -16,78 +16,542 @@ package metadata
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/services/replication"
+	registrytypes "github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 )
 
 func (c *APIController) ListReplicationRules(
-	_ context.Context,
-	_ artifact.ListReplicationRulesRequestObject,
+	ctx context.Context,
+	r artifact.ListReplicationRulesRequestObject,
 ) (artifact.ListReplicationRulesResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	if r.Params.SpaceRef == nil || *r.Params.SpaceRef == "" {
+		return artifact.ListReplicationRules400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(
+				*GetErrorResponse(http.StatusBadRequest, "space reference is required"),
+			),
+		}, nil
+	}
+
+	space, err := c.SpaceFinder.FindByRef(ctx, string(*r.Params.SpaceRef))
+	if err != nil {
+		return artifact.ListReplicationRules404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(
+				*GetErrorResponse(http.StatusNotFound, err.Error()),
+			),
+		}, nil
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	if err = apiauth.CheckSpaceScope(
+		ctx,
+		c.Authorizer,
+		session,
+		space,
+		enum.ResourceTypeRegistry,
+		enum.PermissionRegistryView,
+	); err != nil {
+		return artifact.ListReplicationRules403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(
+				*GetErrorResponse(http.StatusForbidden, err.Error()),
+			),
+		}, nil
+	}
+
+	rules, err := c.ReplicationRuleStore.ListBySpace(ctx, space.ID)
+	if err != nil {
+		return listReplicationRulesInternalErrorResponse(err)
+	}
+
+	result := make([]artifact.ReplicationRule, 0, len(rules))
+	for _, rule := range rules {
+		mapped, err := c.mapToReplicationRuleResponse(ctx, rule, space)
+		if err != nil {
+			return listReplicationRulesInternalErrorResponse(err)
+		}
+		result = append(result, *mapped)
+	}
+
+	return artifact.ListReplicationRules200JSONResponse{
+		ListReplicationRuleResponseJSONResponse: artifact.ListReplicationRuleResponseJSONResponse{
+			Data: artifact.ListReplicationRule{
+				ItemCount: int64(len(result)),
+				PageCount: 1,
+				PageIndex: 0,
+				PageSize:  len(result),
+				Rules:     result,
+			},
+			Status: artifact.StatusSUCCESS,
+		},
+	}, nil
 }
 
 func (c *APIController) CreateReplicationRule(
-	_ context.Context,
-	_ artifact.CreateReplicationRuleRequestObject,
+	ctx context.Context,
+	r artifact.CreateReplicationRuleRequestObject,
 ) (artifact.CreateReplicationRuleResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	if r.Params.SpaceRef == nil || *r.Params.SpaceRef == "" || r.Body == nil {
+		return createReplicationRuleErrorResponse(
+			replicationBadRequest("space reference and request body are required"))
+	}
+
+	space, err := c.SpaceFinder.FindByRef(ctx, string(*r.Params.SpaceRef))
+	if err != nil {
+		return createReplicationRuleErrorResponse(err)
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	if err = apiauth.CheckSpaceScope(
+		ctx,
+		c.Authorizer,
+		session,
+		space,
+		enum.ResourceTypeRegistry,
+		enum.PermissionRegistryEdit,
+	); err != nil {
+		return createReplicationRuleErrorResponse(err)
+	}
+
+	rule := &registrytypes.ReplicationRule{
+		Identifier: uuid.NewString(),
+		SpaceID:    space.ID,
+		CreatedBy:  session.Principal.ID,
+		UpdatedBy:  session.Principal.ID,
+	}
+	err = c.mapFromReplicationRuleRequest(ctx, session, space, artifact.ReplicationRuleRequest(*r.Body), rule)
+	if err != nil {
+		return createReplicationRuleErrorResponse(err)
+	}
+
+	if err = c.ReplicationRuleStore.Create(ctx, rule); err != nil {
+		return createReplicationRuleErrorResponse(err)
+	}
+
+	mapped, err := c.mapToReplicationRuleResponse(ctx, rule, space)
+	if err != nil {
+		return createReplicationRuleErrorResponse(err)
+	}
+
+	return artifact.CreateReplicationRule200JSONResponse{
+		ReplicationRuleResponseJSONResponse: artifact.ReplicationRuleResponseJSONResponse{
+			Data:   *mapped,
+			Status: artifact.StatusSUCCESS,
+		},
+	}, nil
 }
 
 func (c *APIController) DeleteReplicationRule(
-	_ context.Context,
-	_ artifact.DeleteReplicationRuleRequestObject,
+	ctx context.Context,
+	r artifact.DeleteReplicationRuleRequestObject,
 ) (artifact.DeleteReplicationRuleResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	session, _ := request.AuthSessionFrom(ctx)
+	rule, _, err := c.findReplicationRule(ctx, session, r.Id, enum.PermissionRegistryDelete)
+	if err != nil {
+		return deleteReplicationRuleErrorResponse(err)
+	}
+
+	if err = c.ReplicationService.StopSync(ctx, rule); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to stop replication of rule %s", rule.Identifier)
+	}
+
+	if err = c.ReplicationRuleStore.Delete(ctx, rule.ID); err != nil {
+		return deleteReplicationRuleErrorResponse(err)
+	}
+
+	return artifact.DeleteReplicationRule200JSONResponse{
+		SuccessJSONResponse: artifact.SuccessJSONResponse(*GetSuccessResponse()),
+	}, nil
 }
 
 func (c *APIController) GetReplicationRule(
-	_ context.Context,
-	_ artifact.GetReplicationRuleRequestObject,
+	ctx context.Context,
+	r artifact.GetReplicationRuleRequestObject,
 ) (artifact.GetReplicationRuleResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	session, _ := request.AuthSessionFrom(ctx)
+	rule, space, err := c.findReplicationRule(ctx, session, r.Id, enum.PermissionRegistryView)
+	if err != nil {
+		return getReplicationRuleErrorResponse(err)
+	}
+
+	mapped, err := c.mapToReplicationRuleResponse(ctx, rule, space)
+	if err != nil {
+		return getReplicationRuleErrorResponse(err)
+	}
+
+	return artifact.GetReplicationRule200JSONResponse{
+		ReplicationRuleResponseJSONResponse: artifact.ReplicationRuleResponseJSONResponse{
+			Data:   *mapped,
+			Status: artifact.StatusSUCCESS,
+		},
+	}, nil
 }
 
 func (c *APIController) UpdateReplicationRule(
-	_ context.Context,
-	_ artifact.UpdateReplicationRuleRequestObject,
+	ctx context.Context,
+	r artifact.UpdateReplicationRuleRequestObject,
 ) (artifact.UpdateReplicationRuleResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	if r.Body == nil {
+		return updateReplicationRuleErrorResponse(replicationBadRequest("request body is required"))
+	}
+
+	session, _ := request.AuthSessionFrom(ctx)
+	rule, space, err := c.findReplicationRule(ctx, session, r.Id, enum.PermissionRegistryEdit)
+	if err != nil {
+		return updateReplicationRuleErrorResponse(err)
+	}
+
+	err = c.mapFromReplicationRuleRequest(ctx, session, space, artifact.ReplicationRuleRequest(*r.Body), rule)
+	if err != nil {
+		return updateReplicationRuleErrorResponse(err)
+	}
+	rule.UpdatedBy = session.Principal.ID
+
+	if err = c.ReplicationRuleStore.Update(ctx, rule); err != nil {
+		return updateReplicationRuleErrorResponse(err)
+	}
+
+	mapped, err := c.mapToReplicationRuleResponse(ctx, rule, space)
+	if err != nil {
+		return updateReplicationRuleErrorResponse(err)
+	}
+
+	return artifact.UpdateReplicationRule200JSONResponse{
+		ReplicationRuleResponseJSONResponse: artifact.ReplicationRuleResponseJSONResponse{
+			Data:   *mapped,
+			Status: artifact.StatusSUCCESS,
+		},
+	}, nil
 }
 
 func (c *APIController) ListMigrationImages(
-	_ context.Context,
-	_ artifact.ListMigrationImagesRequestObject,
+	ctx context.Context,
+	r artifact.ListMigrationImagesRequestObject,
 ) (artifact.ListMigrationImagesResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	session, _ := request.AuthSessionFrom(ctx)
+	rule, _, err := c.findReplicationRule(ctx, session, r.Id, enum.PermissionRegistryView)
+	if err != nil {
+		return listMigrationImagesErrorResponse(err)
+	}
+
+	limit := GetPageLimit(r.Params.Size)
+	offset := GetOffset(r.Params.Size, r.Params.Page)
+	pageNumber := GetPageNumber(r.Params.Page)
+
+	executions, err := c.ReplicationExecutionStore.ListForRule(ctx, rule.ID, limit, offset)
+	if err != nil {
+		return listMigrationImagesErrorResponse(err)
+	}
+
+	count, err := c.ReplicationExecutionStore.CountForRule(ctx, rule.ID)
+	if err != nil {
+		return listMigrationImagesErrorResponse(err)
+	}
+
+	images := make([]artifact.MigrationImage, 0, len(executions))
+	for _, execution := range executions {
+		imageID := strconv.FormatInt(execution.ID, 10)
+		imageTag := execution.Image + ":" + execution.Reference
+		status := string(execution.Status)
+		progress := execution.Progress
+		images = append(images, artifact.MigrationImage{
+			ImageId:  &imageID,
+			ImageTag: &imageTag,
+			Status:   &status,
+			Progress: &progress,
+		})
+	}
+
+	return artifact.ListMigrationImages200JSONResponse{
+		ListMigrationImageResponseJSONResponse: artifact.ListMigrationImageResponseJSONResponse{
+			Data: artifact.ListMigrationImage{
+				Images:    images,
+				ItemCount: count,
+				PageCount: GetPageCount(count, limit),
+				PageIndex: pageNumber,
+				PageSize:  limit,
+			},
+			Status: artifact.StatusSUCCESS,
+		},
+	}, nil
 }
 
 func (c *APIController) GetMigrationLogsForImage(
-	_ context.Context,
-	_ artifact.GetMigrationLogsForImageRequestObject,
+	ctx context.Context,
+	r artifact.GetMigrationLogsForImageRequestObject,
 ) (artifact.GetMigrationLogsForImageResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	session, _ := request.AuthSessionFrom(ctx)
+	rule, _, err := c.findReplicationRule(ctx, session, r.Id, enum.PermissionRegistryView)
+	if err != nil {
+		return getMigrationLogsForImageErrorResponse(err)
+	}
+
+	executionID, err := strconv.ParseInt(r.ImageId, 10, 64)
+	if err != nil {
+		return getMigrationLogsForImageErrorResponse(replicationBadRequest("invalid image id %q", r.ImageId))
+	}
+
+	execution, err := c.ReplicationExecutionStore.Find(ctx, executionID)
+	if err != nil {
+		return getMigrationLogsForImageErrorResponse(err)
+	}
+	if execution.RuleID != rule.ID {
+		return artifact.GetMigrationLogsForImage404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(
+				*GetErrorResponse(http.StatusNotFound, fmt.Sprintf("image %s not found", r.ImageId)),
+			),
+		}, nil
+	}
+
+	return artifact.GetMigrationLogsForImage200TextplainCharsetUtf8Response{
+		PlainTextResponseTextplainCharsetUtf8Response: artifact.PlainTextResponseTextplainCharsetUtf8Response{
+			Body:          strings.NewReader(execution.Log),
+			ContentLength: int64(len(execution.Log)),
+		},
+	}, nil
 }
 
 func (c *APIController) StartMigration(
-	_ context.Context,
-	_ artifact.StartMigrationRequestObject,
+	ctx context.Context,
+	r artifact.StartMigrationRequestObject,
 ) (artifact.StartMigrationResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	session, _ := request.AuthSessionFrom(ctx)
+	rule, _, err := c.findReplicationRule(ctx, session, r.Id, enum.PermissionRegistryEdit)
+	if err != nil {
+		return startMigrationErrorResponse(err)
+	}
+
+	err = c.ReplicationService.StartSync(ctx, rule, session.Principal.ID)
+	if errors.Is(err, replication.ErrSyncInProgress) {
+		return startMigrationErrorResponse(replicationBadRequest("%s", err.Error()))
+	}
+	if err != nil {
+		return startMigrationErrorResponse(err)
+	}
+
+	return artifact.StartMigration200JSONResponse{
+		SuccessJSONResponse: artifact.SuccessJSONResponse(*GetSuccessResponse()),
+	}, nil
 }
 
 func (c *APIController) StopMigration(
-	_ context.Context,
-	_ artifact.StopMigrationRequestObject,
+	ctx context.Context,
+	r artifact.StopMigrationRequestObject,
 ) (artifact.StopMigrationResponseObject, error) {
-	// TODO implement me
-	panic("implement me")
+	session, _ := request.AuthSessionFrom(ctx)
+	rule, _, err := c.findReplicationRule(ctx, session, r.Id, enum.PermissionRegistryEdit)
+	if err != nil {
+		return stopMigrationErrorResponse(err)
+	}
+
+	if err = c.ReplicationService.StopSync(ctx, rule); err != nil {
+		return stopMigrationErrorResponse(err)
+	}
+
+	return artifact.StopMigration200JSONResponse{
+		SuccessJSONResponse: artifact.SuccessJSONResponse(*GetSuccessResponse()),
+	}, nil
+}
+
+func listReplicationRulesInternalErrorResponse(err error) (artifact.ListReplicationRulesResponseObject, error) {
+	return artifact.ListReplicationRules500JSONResponse{
+		InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(
+			*GetErrorResponse(http.StatusInternalServerError, err.Error()),
+		),
+	}, nil
+}
+
+func createReplicationRuleErrorResponse(err error) (artifact.CreateReplicationRuleResponseObject, error) {
+	code := replicationErrorCode(err)
+	errResponse := *GetErrorResponse(code, err.Error())
+	switch code {
+	case http.StatusBadRequest:
+		return artifact.CreateReplicationRule400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(errResponse),
+		}, nil
+	case http.StatusForbidden:
+		return artifact.CreateReplicationRule403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(errResponse),
+		}, nil
+	case http.StatusNotFound:
+		return artifact.CreateReplicationRule404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(errResponse),
+		}, nil
+	default:
+		return artifact.CreateReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(errResponse),
+		}, nil
+	}
+}
+
+func deleteReplicationRuleErrorResponse(err error) (artifact.DeleteReplicationRuleResponseObject, error) {
+	code := replicationErrorCode(err)
+	errResponse := *GetErrorResponse(code, err.Error())
+	switch code {
+	case http.StatusBadRequest:
+		return artifact.DeleteReplicationRule400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(errResponse),
+		}, nil
+	case http.StatusForbidden:
+		return artifact.DeleteReplicationRule403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(errResponse),
+		}, nil
+	case http.StatusNotFound:
+		return artifact.DeleteReplicationRule404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(errResponse),
+		}, nil
+	default:
+		return artifact.DeleteReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(errResponse),
+		}, nil
+	}
+}
+
+func getReplicationRuleErrorResponse(err error) (artifact.GetReplicationRuleResponseObject, error) {
+	code := replicationErrorCode(err)
+	errResponse := *GetErrorResponse(code, err.Error())
+	switch code {
+	case http.StatusBadRequest:
+		return artifact.GetReplicationRule400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(errResponse),
+		}, nil
+	case http.StatusForbidden:
+		return artifact.GetReplicationRule403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(errResponse),
+		}, nil
+	case http.StatusNotFound:
+		return artifact.GetReplicationRule404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(errResponse),
+		}, nil
+	default:
+		return artifact.GetReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(errResponse),
+		}, nil
+	}
+}
+
+func updateReplicationRuleErrorResponse(err error) (artifact.UpdateReplicationRuleResponseObject, error) {
+	code := replicationErrorCode(err)
+	errResponse := *GetErrorResponse(code, err.Error())
+	switch code {
+	case http.StatusBadRequest:
+		return artifact.UpdateReplicationRule400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(errResponse),
+		}, nil
+	case http.StatusForbidden:
+		return artifact.UpdateReplicationRule403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(errResponse),
+		}, nil
+	case http.StatusNotFound:
+		return artifact.UpdateReplicationRule404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(errResponse),
+		}, nil
+	default:
+		return artifact.UpdateReplicationRule500JSONResponse{
+			InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(errResponse),
+		}, nil
+	}
+}
+
+func listMigrationImagesErrorResponse(err error) (artifact.ListMigrationImagesResponseObject, error) {
+	code := replicationErrorCode(err)
+	errResponse := *GetErrorResponse(code, err.Error())
+	switch code {
+	case http.StatusBadRequest:
+		return artifact.ListMigrationImages400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(errResponse),
+		}, nil
+	case http.StatusForbidden:
+		return artifact.ListMigrationImages403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(errResponse),
+		}, nil
+	case http.StatusNotFound:
+		return artifact.ListMigrationImages404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(errResponse),
+		}, nil
+	default:
+		return artifact.ListMigrationImages500JSONResponse{
+			InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(errResponse),
+		}, nil
+	}
+}
+
+func getMigrationLogsForImageErrorResponse(err error) (artifact.GetMigrationLogsForImageResponseObject, error) {
+	code := replicationErrorCode(err)
+	errResponse := *GetErrorResponse(code, err.Error())
+	switch code {
+	case http.StatusBadRequest:
+		return artifact.GetMigrationLogsForImage400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(errResponse),
+		}, nil
+	case http.StatusForbidden:
+		return artifact.GetMigrationLogsForImage403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(errResponse),
+		}, nil
+	case http.StatusNotFound:
+		return artifact.GetMigrationLogsForImage404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(errResponse),
+		}, nil
+	default:
+		return artifact.GetMigrationLogsForImage500JSONResponse{
+			InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(errResponse),
+		}, nil
+	}
+}
+
+func startMigrationErrorResponse(err error) (artifact.StartMigrationResponseObject, error) {
+	code := replicationErrorCode(err)
+	errResponse := *GetErrorResponse(code, err.Error())
+	switch code {
+	case http.StatusBadRequest:
+		return artifact.StartMigration400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(errResponse),
+		}, nil
+	case http.StatusForbidden:
+		return artifact.StartMigration403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(errResponse),
+		}, nil
+	case http.StatusNotFound:
+		return artifact.StartMigration404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(errResponse),
+		}, nil
+	default:
+		return artifact.StartMigration500JSONResponse{
+			InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(errResponse),
+		}, nil
+	}
+}
+
+func stopMigrationErrorResponse(err error) (artifact.StopMigrationResponseObject, error) {
+	code := replicationErrorCode(err)
+	errResponse := *GetErrorResponse(code, err.Error())
+	switch code {
+	case http.StatusBadRequest:
+		return artifact.StopMigration400JSONResponse{
+			BadRequestJSONResponse: artifact.BadRequestJSONResponse(errResponse),
+		}, nil
+	case http.StatusForbidden:
+		return artifact.StopMigration403JSONResponse{
+			UnauthorizedJSONResponse: artifact.UnauthorizedJSONResponse(errResponse),
+		}, nil
+	case http.StatusNotFound:
+		return artifact.StopMigration404JSONResponse{
+			NotFoundJSONResponse: artifact.NotFoundJSONResponse(errResponse),
+		}, nil
+	default:
+		return artifact.StopMigration500JSONResponse{
+			InternalServerErrorJSONResponse: artifact.InternalServerErrorJSONResponse(errResponse),
+		}, nil
+	}
 }
@@ -0,0 +1,361 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	api "github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	registrytypes "github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// replicationError carries the http status code that should be returned for a replication request.
+type replicationError struct {
+	code int
+	err  error
+}
+
+func (e *replicationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *replicationError) Unwrap() error {
+	return e.err
+}
+
+func replicationBadRequest(format string, args ...any) error {
+	return &replicationError{code: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+// replicationErrorCode maps an error returned by the replication helpers to a http status code.
+func replicationErrorCode(err error) int {
+	var rErr *replicationError
+	switch {
+	case errors.As(err, &rErr):
+		return rErr.code
+	case errors.Is(err, apiauth.ErrForbidden), errors.Is(err, apiauth.ErrUnauthorized):
+		return http.StatusForbidden
+	case errors.Is(err, gitnessstore.ErrResourceNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// findReplicationRule finds the replication rule together with its space and
+// verifies that the current principal has the requested permission on it.
+func (c *APIController) findReplicationRule(
+	ctx context.Context,
+	session *auth.Session,
+	identifier string,
+	permission enum.Permission,
+) (*registrytypes.ReplicationRule, *types.SpaceCore, error) {
+	rule, err := c.ReplicationRuleStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find replication rule %s: %w", identifier, err)
+	}
+
+	space, err := c.SpaceFinder.FindByID(ctx, rule.SpaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find space of replication rule %s: %w", identifier, err)
+	}
+
+	if err = apiauth.CheckSpaceScope(
+		ctx,
+		c.Authorizer,
+		session,
+		space,
+		enum.ResourceTypeRegistry,
+		permission,
+	); err != nil {
+		return nil, nil, err
+	}
+
+	return rule, space, nil
+}
+
+// mapFromReplicationRuleRequest validates the request and populates the endpoints and patterns of the rule.
+func (c *APIController) mapFromReplicationRuleRequest(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.SpaceCore,
+	req api.ReplicationRuleRequest,
+	rule *registrytypes.ReplicationRule,
+) error {
+	sourceType := registrytypes.ReplicationEndpointType(req.SourceType)
+	destinationType := registrytypes.ReplicationEndpointType(req.DestinationType)
+	if sourceType != registrytypes.ReplicationEndpointTypeLocal &&
+		destinationType != registrytypes.ReplicationEndpointTypeLocal {
+		return replicationBadRequest("either the source or the destination of a replication rule has to be Local")
+	}
+
+	for _, pattern := range append(append([]string{}, req.AllowedPatterns...), req.BlockedPatterns...) {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return replicationBadRequest("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	source, err := c.mapToReplicationEndpoint(ctx, session, space, sourceType, req.Source,
+		enum.PermissionArtifactsDownload)
+	if err != nil {
+		return err
+	}
+
+	destination, err := c.mapToReplicationEndpoint(ctx, session, space, destinationType, req.Destination,
+		enum.PermissionArtifactsUpload)
+	if err != nil {
+		return err
+	}
+
+	if sourceType == registrytypes.ReplicationEndpointTypeLocal &&
+		destinationType == registrytypes.ReplicationEndpointTypeLocal &&
+		source.RegistryID == destination.RegistryID {
+		return replicationBadRequest("source and destination of a replication rule can't be the same registry")
+	}
+
+	rule.SourceType = sourceType
+	rule.Source = *source
+	rule.DestinationType = destinationType
+	rule.Destination = *destination
+	rule.AllowedPatterns = req.AllowedPatterns
+	rule.BlockedPatterns = req.BlockedPatterns
+
+	return nil
+}
+
+func (c *APIController) mapToReplicationEndpoint(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.SpaceCore,
+	endpointType registrytypes.ReplicationEndpointType,
+	registry api.ReplicationRegistry,
+	permission enum.Permission,
+) (*registrytypes.ReplicationEndpoint, error) {
+	switch endpointType {
+	case registrytypes.ReplicationEndpointTypeLocal:
+		local, err := registry.AsLocalReplicationRegistry()
+		if err != nil {
+			return nil, replicationBadRequest("invalid local replication registry: %w", err)
+		}
+		return c.mapToLocalReplicationEndpoint(ctx, session, space, local.RegistryIdentifier, permission)
+	case registrytypes.ReplicationEndpointTypeJfrog:
+		remote, err := registry.AsJfrogReplicationRegistry()
+		if err != nil {
+			return nil, replicationBadRequest("invalid remote replication registry: %w", err)
+		}
+		return c.mapToRemoteReplicationEndpoint(ctx, session, space, remote)
+	case registrytypes.ReplicationEndpointTypeGCP:
+		return nil, replicationBadRequest("replication registry type %s is not supported", endpointType)
+	default:
+		return nil, replicationBadRequest("unknown replication registry type %s", endpointType)
+	}
+}
+
+// mapToLocalReplicationEndpoint resolves a local registry either by its identifier within
+// the space of the rule or by its full reference.
+func (c *APIController) mapToLocalReplicationEndpoint(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.SpaceCore,
+	registryRef string,
+	permission enum.Permission,
+) (*registrytypes.ReplicationEndpoint, error) {
+	parentSpace := space
+	registryIdentifier := registryRef
+	if strings.Contains(registryRef, types.PathSeparatorAsString) {
+		parentRef, identifier, err := paths.DisectLeaf(registryRef)
+		if err != nil {
+			return nil, replicationBadRequest("invalid registry reference %s: %w", registryRef, err)
+		}
+		parentSpace, err = c.SpaceFinder.FindByRef(ctx, parentRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %s: %w", parentRef, err)
+		}
+		registryIdentifier = identifier
+	}
+
+	registry, err := c.RegistryRepository.GetByParentIDAndName(ctx, parentSpace.ID, registryIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find registry %s: %w", registryRef, err)
+	}
+	if registry.Type != api.RegistryTypeVIRTUAL {
+		return nil, replicationBadRequest("registry %s is not a local registry", registryRef)
+	}
+	if registry.PackageType != api.PackageTypeDOCKER && registry.PackageType != api.PackageTypeHELM {
+		return nil, replicationBadRequest("replication is not supported for %s registries", registry.PackageType)
+	}
+
+	if err = apiauth.CheckRegistry(
+		ctx,
+		c.Authorizer,
+		session,
+		c.RegistryMetadataHelper.GetPermissionChecks(parentSpace, registry.Name, permission)...,
+	); err != nil {
+		return nil, err
+	}
+
+	return &registrytypes.ReplicationEndpoint{RegistryID: registry.ID}, nil
+}
+
+// mapToRemoteReplicationEndpoint validates a remote registry. The replication jobs send the password
+// secret to the registry url, so the principal has to be allowed to access the secret.
+func (c *APIController) mapToRemoteReplicationEndpoint(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.SpaceCore,
+	remote api.JfrogReplicationRegistry,
+) (*registrytypes.ReplicationEndpoint, error) {
+	u, err := url.Parse(remote.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, replicationBadRequest("invalid replication registry url %q", remote.Url)
+	}
+
+	endpoint := &registrytypes.ReplicationEndpoint{
+		URL:       strings.TrimRight(remote.Url, "/"),
+		Namespace: strings.Trim(remote.Namespace, "/"),
+	}
+	if remote.Username != nil {
+		endpoint.Username = *remote.Username
+	}
+
+	if remote.PasswordSecretId != nil && *remote.PasswordSecretId != "" {
+		endpoint.SecretID = *remote.PasswordSecretId
+		secretSpace := space
+		if remote.PasswordSecretSpaceId != nil && *remote.PasswordSecretSpaceId != "" {
+			secretSpace, err = c.SpaceFinder.FindByRef(ctx, *remote.PasswordSecretSpaceId)
+			if err != nil {
+				return nil, replicationBadRequest("invalid secret space: %w", err)
+			}
+		}
+
+		if err = apiauth.CheckSecret(
+			ctx,
+			c.Authorizer,
+			session,
+			secretSpace.Path,
+			endpoint.SecretID,
+			enum.PermissionSecretAccess,
+		); err != nil {
+			return nil, err
+		}
+
+		endpoint.SecretSpaceID = secretSpace.ID
+	}
+
+	return endpoint, nil
+}
+
+func (c *APIController) mapToReplicationRuleResponse(
+	ctx context.Context,
+	rule *registrytypes.ReplicationRule,
+	space *types.SpaceCore,
+) (*api.ReplicationRule, error) {
+	source, err := c.mapToReplicationRegistry(ctx, space, rule.SourceType, rule.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, err := c.mapToReplicationRegistry(ctx, space, rule.DestinationType, rule.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	allowedPatterns := rule.AllowedPatterns
+	if allowedPatterns == nil {
+		allowedPatterns = []string{}
+	}
+	blockedPatterns := rule.BlockedPatterns
+	if blockedPatterns == nil {
+		blockedPatterns = []string{}
+	}
+
+	return &api.ReplicationRule{
+		Identifier:      rule.Identifier,
+		ParentRef:       space.Path,
+		SourceType:      api.ReplicationRuleSourceType(rule.SourceType),
+		Source:          *source,
+		DestinationType: api.ReplicationRuleDestinationType(rule.DestinationType),
+		Destination:     *destination,
+		AllowedPatterns: allowedPatterns,
+		BlockedPatterns: blockedPatterns,
+		CreatedAt:       GetTimeInMs(rule.CreatedAt),
+		ModifiedAt:      GetTimeInMs(rule.UpdatedAt),
+	}, nil
+}
+
+func (c *APIController) mapToReplicationRegistry(
+	ctx context.Context,
+	space *types.SpaceCore,
+	endpointType registrytypes.ReplicationEndpointType,
+	endpoint registrytypes.ReplicationEndpoint,
+) (*api.ReplicationRegistry, error) {
+	result := &api.ReplicationRegistry{}
+
+	if endpointType == registrytypes.ReplicationEndpointTypeLocal {
+		registry, err := c.RegistryRepository.Get(ctx, endpoint.RegistryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find registry %d: %w", endpoint.RegistryID, err)
+		}
+
+		registryRef := registry.Name
+		if registry.ParentID != space.ID {
+			parentSpace, err := c.SpaceFinder.FindByID(ctx, registry.ParentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find space of registry %s: %w", registry.Name, err)
+			}
+			registryRef = paths.Concatenate(parentSpace.Path, registry.Name)
+		}
+
+		if err = result.FromLocalReplicationRegistry(api.LocalReplicationRegistry{
+			RegistryIdentifier: registryRef,
+		}); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	remote := api.JfrogReplicationRegistry{
+		Url:       endpoint.URL,
+		Namespace: endpoint.Namespace,
+	}
+	if endpoint.Username != "" {
+		remote.Username = &endpoint.Username
+	}
+	if endpoint.SecretID != "" {
+		secretSpace, err := c.SpaceFinder.FindByID(ctx, endpoint.SecretSpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find secret space %d: %w", endpoint.SecretSpaceID, err)
+		}
+		remote.PasswordSecretId = &endpoint.SecretID
+		remote.PasswordSecretSpaceId = &secretSpace.Path
+	}
+
+	if err := result.FromJfrogReplicationRegistry(remote); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -127,6 +127,64 @@ func (_c *CleanupPolicyRepository_Delete_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// GetAllRegistryIDs provides a mock function with given fields: ctx
+func (_m *CleanupPolicyRepository) GetAllRegistryIDs(ctx context.Context) ([]int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllRegistryIDs")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CleanupPolicyRepository_GetAllRegistryIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllRegistryIDs'
+type CleanupPolicyRepository_GetAllRegistryIDs_Call struct {
+	*mock.Call
+}
+
+// GetAllRegistryIDs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *CleanupPolicyRepository_Expecter) GetAllRegistryIDs(ctx interface{}) *CleanupPolicyRepository_GetAllRegistryIDs_Call {
+	return &CleanupPolicyRepository_GetAllRegistryIDs_Call{Call: _e.mock.On("GetAllRegistryIDs", ctx)}
+}
+
+func (_c *CleanupPolicyRepository_GetAllRegistryIDs_Call) Run(run func(ctx context.Context)) *CleanupPolicyRepository_GetAllRegistryIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *CleanupPolicyRepository_GetAllRegistryIDs_Call) Return(ids []int64, err error) *CleanupPolicyRepository_GetAllRegistryIDs_Call {
+	_c.Call.Return(ids, err)
+	return _c
+}
+
+func (_c *CleanupPolicyRepository_GetAllRegistryIDs_Call) RunAndReturn(run func(context.Context) ([]int64, error)) *CleanupPolicyRepository_GetAllRegistryIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByRegistryID provides a mock function with given fields: ctx, id
 func (_m *CleanupPolicyRepository) GetByRegistryID(ctx context.Context, id int64) (*[]types.CleanupPolicy, error) {
 	ret := _m.Called(ctx, id)
@@ -93,6 +93,9 @@ type ServerInterface interface {
 	// List Artifacts for Registry
 	// (GET /registry/{registry_ref}/artifacts)
 	GetAllArtifactsByRegistry(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetAllArtifactsByRegistryParams)
+	// Dry Run Cleanup Policies
+	// (GET /registry/{registry_ref}/cleanup/dry-run)
+	GetRegistryCleanupDryRun(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam)
 	// Returns CLI Client Setup Details
 	// (GET /registry/{registry_ref}/client-setup-details)
 	GetClientSetupDetails(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetClientSetupDetailsParams)
@@ -306,6 +309,12 @@ func (_ Unimplemented) GetAllArtifactsByRegistry(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Dry Run Cleanup Policies
+// (GET /registry/{registry_ref}/cleanup/dry-run)
+func (_ Unimplemented) GetRegistryCleanupDryRun(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Returns CLI Client Setup Details
 // (GET /registry/{registry_ref}/client-setup-details)
 func (_ Unimplemented) GetClientSetupDetails(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetClientSetupDetailsParams) {
@@ -1655,6 +1664,31 @@ func (siw *ServerInterfaceWrapper) GetAllArtifactsByRegistry(w http.ResponseWrit
 	handler.ServeHTTP(w, r)
 }
 
+// GetRegistryCleanupDryRun operation middleware
+func (siw *ServerInterfaceWrapper) GetRegistryCleanupDryRun(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "registry_ref" -------------
+	var registryRef RegistryRefPathParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "registry_ref", chi.URLParam(r, "registry_ref"), &registryRef, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "registry_ref", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRegistryCleanupDryRun(w, r, registryRef)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetClientSetupDetails operation middleware
 func (siw *ServerInterfaceWrapper) GetClientSetupDetails(w http.ResponseWriter, r *http.Request) {
 
@@ -2801,6 +2835,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/artifacts", wrapper.GetAllArtifactsByRegistry)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/registry/{registry_ref}/cleanup/dry-run", wrapper.GetRegistryCleanupDryRun)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/registry/{registry_ref}/client-setup-details", wrapper.GetClientSetupDetails)
 	})
@@ -2924,6 +2961,14 @@ type ArtifactVersionSummaryResponseJSONResponse struct {
 
 type BadRequestJSONResponse Error
 
+type CleanupDryRunResponseJSONResponse struct {
+	// Data The artifact versions the cleanup policies of a registry would delete
+	Data CleanupDryRunReport `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 type ClientSetupDetailsResponseJSONResponse struct {
 	// Data Client Setup Details
 	Data ClientSetupDetails `json:"data"`
@@ -4672,6 +4717,72 @@ func (response GetAllArtifactsByRegistry500JSONResponse) VisitGetAllArtifactsByR
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetRegistryCleanupDryRunRequestObject struct {
+	RegistryRef RegistryRefPathParam `json:"registry_ref"`
+}
+
+type GetRegistryCleanupDryRunResponseObject interface {
+	VisitGetRegistryCleanupDryRunResponse(w http.ResponseWriter) error
+}
+
+type GetRegistryCleanupDryRun200JSONResponse struct {
+	CleanupDryRunResponseJSONResponse
+}
+
+func (response GetRegistryCleanupDryRun200JSONResponse) VisitGetRegistryCleanupDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRegistryCleanupDryRun400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetRegistryCleanupDryRun400JSONResponse) VisitGetRegistryCleanupDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRegistryCleanupDryRun401JSONResponse struct{ UnauthenticatedJSONResponse }
+
+func (response GetRegistryCleanupDryRun401JSONResponse) VisitGetRegistryCleanupDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRegistryCleanupDryRun403JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetRegistryCleanupDryRun403JSONResponse) VisitGetRegistryCleanupDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRegistryCleanupDryRun404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetRegistryCleanupDryRun404JSONResponse) VisitGetRegistryCleanupDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRegistryCleanupDryRun500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response GetRegistryCleanupDryRun500JSONResponse) VisitGetRegistryCleanupDryRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetClientSetupDetailsRequestObject struct {
 	RegistryRef RegistryRefPathParam `json:"registry_ref"`
 	Params      GetClientSetupDetailsParams
@@ -6193,6 +6304,9 @@ type StrictServerInterface interface {
 	// List Artifacts for Registry
 	// (GET /registry/{registry_ref}/artifacts)
 	GetAllArtifactsByRegistry(ctx context.Context, request GetAllArtifactsByRegistryRequestObject) (GetAllArtifactsByRegistryResponseObject, error)
+	// Dry Run Cleanup Policies
+	// (GET /registry/{registry_ref}/cleanup/dry-run)
+	GetRegistryCleanupDryRun(ctx context.Context, request GetRegistryCleanupDryRunRequestObject) (GetRegistryCleanupDryRunResponseObject, error)
 	// Returns CLI Client Setup Details
 	// (GET /registry/{registry_ref}/client-setup-details)
 	GetClientSetupDetails(ctx context.Context, request GetClientSetupDetailsRequestObject) (GetClientSetupDetailsResponseObject, error)
@@ -6956,6 +7070,32 @@ func (sh *strictHandler) GetAllArtifactsByRegistry(w http.ResponseWriter, r *htt
 	}
 }
 
+// GetRegistryCleanupDryRun operation middleware
+func (sh *strictHandler) GetRegistryCleanupDryRun(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam) {
+	var request GetRegistryCleanupDryRunRequestObject
+
+	request.RegistryRef = registryRef
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRegistryCleanupDryRun(ctx, request.(GetRegistryCleanupDryRunRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRegistryCleanupDryRun")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRegistryCleanupDryRunResponseObject); ok {
+		if err := validResponse.VisitGetRegistryCleanupDryRunResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetClientSetupDetails operation middleware
 func (sh *strictHandler) GetClientSetupDetails(w http.ResponseWriter, r *http.Request, registryRef RegistryRefPathParam, params GetClientSetupDetailsParams) {
 	var request GetClientSetupDetailsRequestObject
@@ -7609,127 +7749,132 @@ func (sh *strictHandler) GetAllRegistries(w http.ResponseWriter, r *http.Request
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x93XLcOJLuq+DwnImY1tAqd4/PxoY25kKWJbdmZLmmJHmiY9ohQSSqimMWyQZAyRqH",
-	"IvZqH2D3DedJNvBHgiRAgvWnksWbbrmIn0Tiy0QigUx884J0kaUJSijxDr55GcRwgSjC/F9n8BbFZMx+",
-	"Y/8MEQlwlNEoTbwD8XHf872I/eu3HOEHz/cSuEDegRezj57vkWCOFpBVjiha8EbpQ8ZKEIqjZOY9+uoH",
-	"iDF88B4ffW+CZhGh+OE0RAmNphHCFhJUQVCWtNCD0ew60gutRNjlQ4a6SGJlLMRQ8akkASX5wjv4u/fp",
-	"dHJ5dXjm+d7V+OJycnz4wfvs1+l69D2IaTSFAbXQcMg/U0vvqnKFgrY+6NzSzzlcIJBOgSpagCGDdG7s",
-	"EKPf8gij0DugOEduBLQwWxUBrPZ+x3ivrWxfpCEHawgpJIiaeR7Mozj8hDCJ0sRCzhErAu5EGRAlASSc",
-	"P+/S4AvCBZuIjVK9i47ZCaMZIvRjZoPAO/7d1pGo7dTFau33me9pFCOGKAfAHap5P4liZEEda+6a/92f",
-	"jBYS1GfLyHmvkpDWXnC6eAepDdjs0z44SfECUvAKfPgwevdu9Msvv/xi6xani44eY0gRoQpdBm3OPgP5",
-	"nTGWImzX7qzw9Z0dqrdpGiOY8J4zGHyBM+SiNMeiaJvylK01pbmHHs/gDJ3ni1uEDUKcY4wSClgZkIhC",
-	"NkpmVQpCNIV5TL2DH31vyufOO/CihP7bG68gIkoomiFckHER/RMZgM77ZVDnowIZwkB2Z6KEsEaMlPz0",
-	"2o0UjIIck+jONkN/myM6RxjQFMQRoQCLGYsQAUXV+GH/1+TXZG/vHcowCiBF4f7eHrgiCNA5Agm6Bzck",
-	"SDN0AwozQ9QAN0Ujf2ISegPAv/7rv2XpP8EkQISmmNzUik5hTNCNXjRJE3Tza2I1AmRNM694c74JwXK0",
-	"DxM0bVENV0n0W44Ak35Q2hpgmmI+/mmUwFgx7gFECf/1FsMkmO+DyzkCdzDOEQhgAm4RyHB6F4UoBCji",
-	"nIcEQDDN4/gBXE3OXqEkSNlX3tvv0f5s3wc3KZ7BJPonZAT97qeTDKf/QAH93U8nqtebH0Aqm8piGCWi",
-	"OkrCKJmB+4jOAQQUwyhm/87inAASzRLw+5s/3PzAqhHEZo6m2NjlSHY4Ut2N/nDzw345HVUFrQpdYzTt",
-	"qaP5ZFtmYW/vgn1lsqOBVOJ2b49BaG+P4WRvD/zrP/8HBFLeSQYDBNIkfgC/l5D4AQDAShcANFbZ22OM",
-	"2tsDMI4ZsIsvRFZn9KEkhAl1aIDbAEX9X5PTKUgXEaUo9MENhzeICICE5AsU7luhzjlkNHaKwTCDp6SM",
-	"VU0TZLZ9CII4mF8ibOC3+AbYR9tyIYpcU1a/Y2JTTE8iFIeGfopPlk5STK+nskBXHx9xaNL95aeWPlJZ",
-	"oLUPNn9r0BYCINtTFVvSD21qgQ95GZ0gWf5XNmUDzzt4Xge2xvQ2JtN0jUYrTTt6u2vdbZUbJVPjd07b",
-	"qKIH9y2H7Nay6yi77YPde3Q7T9Mvx19RkLN+T8NuxSHrAKQqaYi2ECerXBdVrqNwOUp1z4wroc7kVfw0",
-	"7sQ9isKI0LdpGCG+FVCzxn1VE/GV/R6kCUUJ/xNmWRwFQlb+QcTWqOzk/zGZOPD+76h0k43EVzIyNs7p",
-	"qPJBUsVskDwLIUWFJwBwNxnxNNfSuomst9tCH1ODAUacwCRUtCozTRBZkDHJY7R+Wo3NL0Fy0Q7AeYwY",
-	"6X8T4Fo3ybVme5MqMc8o/C2HGCY0StbO12bL7SgtywOSoSCaRgGYRrFYOOWGiGRpQqpC9g5RGMUT+akX",
-	"9RlOM4SplNoQUmfhE50y/hEKaU666l2IUkpbCNXyd1VZ+AA1Izi9ZYunmV9inIxhM0RLmQ45RVyoFZEn",
-	"EUPz6nxJ75M4heEVjpvaVn0EOY51p6znNx0ia2KVRk5fjs0RDEuWMXDp/JIadatAusgXCyjU3K4gia8O",
-	"QH3WGcT6JttmEOtzl9jDmiJm9oi5HBBESpIUldJ+fRoWVTvfAU6F1aOZ4vBGY9xbGK57QT7GOMUm8t7C",
-	"EGC1RvveURyhhF4gmmdinduWzDc7fsq54qYTpwgQRpK+xIqztScxQUxd7yCkw4KwKsEfYBJNEaFPwi3V",
-	"+Q7ya6GRJog+gw8Ik63ySXS5kzYJI6zkjZrI7bKn6HU3WcPs/bWpImYcE8MlD3EQl07BzxAniJDqqTgb",
-	"UnEi2saXktbmUalo4ijNBdVVAi7njAUUxvKUtDit9HwPfYWLLEZuJ6HiILRHL6x4tZfXr537OU1C9NXc",
-	"T6Ad/erNuzduPs1lbSf2E12dWc1m14huX2JpJZSLJh5972cUL55k3W12vANaYI7ihWnN1Ynd8opr6nrn",
-	"OKWvtqcJRTiB8QXCdwgLI3njJrfqFBDeK0CioO+dRYQ+hUOi0e9Tm958nTG4rHVCn4A3O8WWOj/kRvcJ",
-	"2KKOxXaBO3I3TSr+SMmpD9EMcw6cLuAMbZFR1Y6fgE+TBp8WiiQQLbhtI3mkDm+eQMrqXe+ktJWHW1vn",
-	"y07wQz+bE8TVDtC2yJZKzzshVfVjwEKs5KEdKc7bt8ioRt9PsYXl7JFHj6S8QVB1puvUPgGDdkLA7jVi",
-	"zlN6kuZJuHmTlO0X5cErCtmcpDkOELiHBCQpBVNOxaPvjWMYJZfoq21doOgrHfE7Rf8BgjnEBNE/5XT6",
-	"6t+rNBb7T7ZZiVMf3Kc4Dv9P8+CwSemhvLLEeqqAZ8uaeVe0sjjg98WO2fEOxZYYtFP6ua6aJaMYWRd5",
-	"ECBCVuDHOgbmMiJJKZhouL9KYE7nKKERv/++eV1R77CgIcXRP7dHgOytvGiz7bW13u0TILx5I0/XiMVN",
-	"oW2yY0f1ofHW0xjS+Za4U+30CZik3bDit31LoDyqK47iahXXMH9BDxcowIj+BT00BwxVGWPsE6y2oAXK",
-	"OpS+yGCATrkScXCAmypz/pp6ImpAHRQV5frRUq1moaI+jQaSPj/63mGSJg+LlMNDu0ghXc+W6NuAAlnA",
-	"98KIfV9ECaTCo7mAWcYoOPjmHR1O3n+0HsNDPEur/R2lyTSaeb737uPRX44nfc6mi6rvj8+PJ6dHtrrv",
-	"UYJwFNgqW6l9byP15+OzD+7O/LLa1fv3p+fvTw6Pjq2189ksSmYnMECWRj4cfjo+t1X/AO9QYql4PrbS",
-	"fJ7ZSD6/en98aa2WzxC1VBz/cvnzRyud4wc6T22ETuyETiyEPvpKhzycV6Iqedzlo++lCfo49Q7+3v8C",
-	"RNFD3zMcx4pt4Oyqa5/urpotE9BV9TxbbqCTJevZUdZV065tOidluWpd0vv42a+vdVpOANc7ZwrTwhYJ",
-	"D6lxnZFf35pXUXXVtTikdliCIvLXYpUPTaHRvrdIQ77Ht9Ak4iIMH3Rp7eDCuCrY+lVwKC2p5porT7Eb",
-	"H+7K6PH2pZS7sc9F8L0eEiO2wTJ+PsdscdTH0jSdfGsIRxUY8lSqXxi4TrFsoI2CD4hCZV1alvyiyDqB",
-	"q8BH1oy+/jxjdQj9IFG7LcxmeRwfpYsFTMxdOmEaN3LKtBaz2q3OIlCknmj0W8/FUOu1DYLiDnYDf40b",
-	"QKKcb4kf6IMjVUfdbHGowq/rXNAUaxdiHKrlWa9+HtvYJO/NOTBKltzeQrOcNBf61NTkMoLcsfQsK60t",
-	"64Crold91t0KU4R5HH8l6Y/n98ji07gE76DRVUKUzWh2nienAIJd+nqBZRrFaO1LxQtX+xaTaBs6vxYz",
-	"0Qy7FveXG4jdhGpr10MOQNqw5bqigdqponI6N6unw9IJz3hfU01XBOExJOQ+xaHnm7x6upfJpLjse7Nm",
-	"AiP+O3e/8lrNyMQ6IhaaGoRhGLGGYDzWyojY67pb08ChIzbReTZO4ygwYFV+BuI7p7GxJE+KrC0NQtHX",
-	"LMLoHXwgZnXZpYXGGE2jr/0WSpUioHdVM3sacTUGHvFIF14IvLNNGYySnxEM7V7T9q/i5oE+GsdwoAtR",
-	"t3M/pRGok6N1/rmdP6qjdv6oUu1O1tPzs9PzY5fRUZQVjrXLw7cXtjqX8LZeoelUo728aWYyunwoJkIa",
-	"bpP5skihDnpaToHR/qM2TVobbNcssyL1QQXCNlgOxZxbwrYwyPx8NY7UOio408UFzdrpYAZQRX2TH8S8",
-	"NMI4t5gw3XRZVprOOSIUZUtPUG+VWjDbQmmlUH2NZlvmKPB8j3uYIUWX6ReUGBdjY+Bfp11WHAfVYLzt",
-	"3eLqfskdsen7GuvC47grfs2Ww5M2k84c3dk0ENo5/dhJUBEw0onromTTRimbaGdrUdLOKB4QeZxQJ6+O",
-	"iJ60rRgrbPBUCx10kk4HlChm3aPJBLpmZ8+DzM7tpFMb3DMsdyk5xIHDAbmkyj54BQWrbes8U+1K0c6d",
-	"TfnKrSxa7UjFzOBikot+u1newuyySJ3N7QvFQm+6B9jqKLBvqpZTuCZmFMFidYkPLfGYc0ozEesFeCEt",
-	"DNN78/qNKWtOaEP1YbFnVuoYwNs0pzyDoIgnM5C8QITAmYU8zKHEGygSSMEoRqHnd6ooPhrVupFZXymG",
-	"pdVfy/Qrb7HxQqDYtlX5+sVy26jFyNRp/MI9HqKwiUAtJrlBH/tmtaXmKPhC8kVPX7ibCdZmt1gdD738",
-	"hjKNczmKZuc6sSbOtV2PaDMrZqJet11RacHJrnjf32/1frtOK0NAcVOZonjRacsX8UktNwzWaeg/mZ3+",
-	"fZjg1ktIbdg0BXqvw/w2Rmt3wHDTpnfXBZ1WPom6UxigrQrzn6c4nenhDir2omEcMK3LE99ahEf469W9",
-	"WYdC2hVVG+4Loc5xZLIxcoKwZTGpTZyAeDkG0/xVwqVbMoioMsSq0YhLddeUI40LNUPikZ1LPFJDW4mD",
-	"LpydKQ+kc7oa8cpUc3OyFQQsc3diQI0jalou1ZlSFjiomCKlgFVTfVIFerbWS3PV748MCuz5K7BaXogW",
-	"+DTSNRj9KptBYDN7xQC8nQZeySh9arS+9TH6Cjo2kOrWrPMC23KjYgDLU+d3Kx8pWn5OnTSHgo590aoh",
-	"V6OsC447uMmokzas1d/RWl1PZ9CCm2YWmEEHPuXsvzYqwbwjx6k24WCSx320XiPxRavS67lcC8JtMC1y",
-	"2Tho9lKhl1lnBqTu2mp97zCj5pl0QquWtqEVpUW7XcjT0kwth0EtO5ThOq5L452N9uFMJb/HsIzv9DKu",
-	"TbIRpmkAYydHvVMQh9l4reRyMBBhDwlvO9tYsFrdpxqqgOVIYIbTPDt1PR9quicMPgdLT/zbJZyZzzBw",
-	"OsMy41Jb+mkHGm2x7m28TLLFVs+H7PHxrVTmxjeeNkjnuHp4a4uGk2ec2kVWmRhEpb4ockuUeT9kRg6V",
-	"rkLkuhCJJHyZkITn+aim4DDdhG3Ji9DGzYxX2yo77Y4T29baEDkVx+k9CseQUoSTfmcGt3EafFmyblAP",
-	"qnG8Ta3XMjVbTJTLPrqMcui4yNB6QcT3ovZIvB2KZbVdVjBf3qm8WinvlrtdQ7B6KoY8A/aA02o45ZZC",
-	"UtcTTbrJoNGatDYQdJHfSj1cvuyYYvApwjSHMUgxuMoIxQgudDXYFlt1Nb64nBwfWnMQqfaKsKpPp5PL",
-	"q8MzW3lJypqCquqttZeu0doMpHKJ/lF86xcQ1fDOuq9T3dqgl0R3KfDl1MT6tX6niljh+pvtWpuSvwvb",
-	"9bb+AHFcZeSKoo+ptubkxmc4jS8LD2aNwax5AsNlFYSyzf+Ele+8MGkwTFxNkq7dB6sonOxSFUX7CNyV",
-	"i0kuFappDbHodbWNUcuEX64wph2IxYHgtjpYXRBdC4X1kuHj52aK5y6JI6uI3HqvuiNC2QRJEXD1q2vZ",
-	"rbUWFHbUhHJme/KCJttgHo2NM9ohZ53Gvl0wfE9kUl9ybKLycsNqk0lJVJX9le6afPUbGGoCQ2dGhW9d",
-	"QQT2Z953BcY7AdRdAdOm8GOExhIevsn4w1ZdPXpCgpa1q/KirMxKoYLC+y5WMsGEzBlhAstF4UetPz8W",
-	"8sztBETTSrjXPSSAiAzz05wvpklK9Xj1q6Oj44sLz/dODk/Prias9+PJ5OPE2L2eJsLg84e3MoqfmKL4",
-	"59tPJdKYVEOei45hgEDtOGt7OHjrTm6Fb26E4mg2MwXWaVaTLFJO5uHk8vTk8Ojy+mhyfHh5yr21xW/v",
-	"js+O+W+mia1tWS1ymMv7isaEO6qJMU6/mu6IwVzshtxsqkquoy47qkx61FmymTOJ21pQS8nUWl+Vqyju",
-	"wmfOA1fn+a3ne0c5oSmzWA/vyXHAZokfEh2hhGKunMcP48jjhx1/ZsziBwofMQPaEWaSzPR2ypj54FVC",
-	"aIzz57T5KwbZUMm+9/VVRVm9kiGYpdXMQKLPSfO9DJfM8GSJhPDEIQ98ThA+dwt4USXZtFf9Oj1Qrzxd",
-	"Lpcj85pgrJj8SR3uWz07EzSTB+WqaL+4+EoO4zV4elACb2ObVxeVQcTu6lSPPDYd4LdjMEoICnKMzARF",
-	"8iFPmxOaIkL1V0nymPa4dCArrJC0+UnFTC45PRY+uUYZZskhhNMle6t1P1RGtnklBrXZ/2yXLTFTxXal",
-	"S8x+vrwcK1kDql5d5m7T0BzsPi/B767W2ykvXz/pSbqsuBbarUf+6tORzKrgkpK3KUIthlHjBR2jvTs5",
-	"vpycHr49O74W9i6zgC8Pz67t1m/j8pC7CgbHGi1GZeyqbOVq5FgcqYQWBv+IYxO4FARnJSdq8MolFt1V",
-	"ZPHaEV5ev2IkldXHqfNAZQ2mKszqXxZwsRQ1zSfx6KiJW+BvdaF8X0vwS1376quZYlJl+bIscabVrPY+",
-	"VUNb1Z6P6rh45pgy2sq/yJYDwiHrsmP/0nRwF7Sq8VA5Pvd8ffwFne18tvs421J6tLINryFNMSyDXqzD",
-	"eORiOU3Vm2mSWCGLLafGr0CI7lDMBkskJA+8OaUZORiN7u/v9+ei6n6UckmIaNze4OH4VMvJceD9uP96",
-	"/zU/qs9QArPIO/D+yH8SB6ycvSOs3/pMTWbbEV9mASw62vd4k/I2ZFgU0e9tQQwXiHKht/gryiIjnkBh",
-	"gqZ/zRF+GLPf+dG/XD/fShvK1EhZJELlmaJhGeWD/en1j/aGZLlR4+XUR9978/p1d8W3MNQ6fuPSl+Hx",
-	"yDev/+har3zz8f+70Hcq92cXCN8hrN6T9D2icuWpmdbnmcIZm0JP26V/ZpUK3Iy+qb+uMZo+CvjEiBqM",
-	"6Hf8dw1IIBIZtmAQpHlCuZ+A/XsW3aEEiCRVVaCJJpYGGi7mdsrUhg61CkwcuKmeSX0G6Hjz+k13peJ1",
-	"5fXBqTHfNjz53gwZFM8E0RwnpISLTGjXHzbvEd0FzDxH1fJU4LFNvh1DWW7A0BV/8JSspHT4JaeHTQBo",
-	"7evbAMK1grCJniWWxJEyIkflFSWjvjuLCK1n5WnaWo1cP2RNiPQ762Vwhs55aJJraX5Pz6EsQRAH80uE",
-	"l1WtDa4M8O6GtwlwGsDLhAGO+CbqdTQjvN8jWnsgbd+0UFeeWjtJ8Zr1bjcWpzhdvIMUOVegqVZ8KfRW",
-	"xjwgtxu5TSytgttv6i+X7Ytqfd+yOdHyZGwHr4r4pSpdPmRo2AZtYxuk4WINQNVsiRa7t9uaEOWeyJ5Y",
-	"K3J72tLGZ20fV1Heg9nRy6pep+GhycX6bZDdFofBWnm51sqIlM+cOMBdFG4HfPkeyvdvu9QGPSC5L5IL",
-	"sKwDy/LcavRN/tHHFldvrnbZ5J+0l0Z3Ft7qwcvBnN/pU42kgb5NCcJIe/imW82XfnKrltceNX1WWn4z",
-	"shPMozj8pCquvpwI7g6riYsoMRTfIhN4NyRJPM7ASaDMz0ca5cr0lCD5/hYZ8TraKiJiYtQgKD0Exfqm",
-	"qRKXWoG1Sk358qGz0BTPC3bITPkM4SAyJpER/BlEZQVRKSC2DVHR339yFhbtNakOcdHfnRoEpm2NUZwa",
-	"RGcF0dHgtk3hIUtJD3EXn2e34KwgEMWYB0lYgyRsfB2ZRjEafWP/vU7gAj1aReEfOaHgDsYRP1NBXyNC",
-	"URKgyhs3rJk2f8CJ+D44Awjn+zlcoFWFTmftIHE9HcsSr5txAbDGHV1pomiH4AxutI1fDkwx/YhDt4ZZ",
-	"4ZMIxeFWrh2WD2UPQr6Mv09J2GZEfY7ihZOvz/S4tFHwmy8WvwwbsjnuAe898G55vFyhvvJ5jdB38kJY",
-	"X7RuBf9z9UCsjP7BobAy/g3uhA1IQK+7MfL4z+mOjCz7XK/KbNJv9zGj69g0VTk8SFrP7VMNzOs1r9rD",
-	"lwiAcczD6erUWC5axvFh/d3o70+gXuTWyPDA+CDJfWOyNKFYVob7CiwROWPLqKs2oSVvH7YenyWui78Y",
-	"4dve5T/Tg8KDyPYU2Yb49A4XFnmRX/G8yK+6vBcqTP7o7BSI1L4yA6/KlXALCQpBmqiHylSG5YZUa4mB",
-	"n86z0ddCXR7szeEOUHfPymCD2zJ4L5NAtd34Fr+D8rEr7jtU6bdMl77LoicqTdQzAbTbMdULymCzJvSG",
-	"dlwo1GpgtOYU+c0FgxtD3zLBjs08a0tFOlbT4r3ABFm/OUOnTeHpb1i3Lupn8gVnlQrVdjBReer8O0gG",
-	"stubWsXpF4j/GtAU8oufuMo0JhFUkO6CskhBp6VffyKNWcsbu1QmwaKNF5pIsJxFA1BcFOTom/zrukze",
-	"6pZhsOzaZBmuF17daqdIY6wGMSQf3FKYXisEO9IOdqmq94g+eyC9XBVVmT3zQpavAA6R42Ln8DGsgluE",
-	"WB0D61wFR8UTDd3biMZLCoVzkNlzbbuJ47KTXYDw5jYlK28G9IdtXvCuoAKYDeG9/F78dh2Fj8uLQcvK",
-	"Xnl85Bng/75G9mm4JgvhJePbDIftontUvLHShnNRwvh0ThXhEyTf2BhwPuC8PNyxg8KK9uJx3RHO2wIr",
-	"uC8TxjHQqgBRxWSA1J5AJut7U2K5U/Hag8zDSaHTobhprsvzweJbi9+weHyk3pT1DZLq0+9P9xSJ8Qnv",
-	"JTO2D+hb7iUTI2zMADRqs9E3aVh2Ohw74aleLumAZ8RalQeK8gUh+bySepRIPMMtnsoyPWA0OBQ3/JqJ",
-	"M6R8+8VzB8DwN0t2Ey2DQlrqgnYv6LRldXZAjyi5LQANi+MzzL+8lsVxtIhmAnajaAFnXRuAojQQpeWr",
-	"OzABHHbNfcAHVeFUtL4BBD/Hqw5L72Sq/BykxXEjU8ftOiRl9I3/n3t34nSmS07DEiim7SydkZMU89nb",
-	"kDCYGpGEbt60GMcwSi7R1+HiuaNRUSKTYYhfPocSpauBlFCIqf1V0Av2Weu9TZHzsgWEh03P80FYbZZX",
-	"RVSatQEqzZzxlGYDnJ4lnPQ5bkUTd8SR0Tf+/97vjKmigMjXFjqfGeNPmC/tLhye7Pge9+t1ECm0cqyQ",
-	"bqC6hl6W5TuiLbeDz0nxlHt5NjcEW8rSMaSIFA9JOA2Sx92tIzhzCMrsuW3TBctVeHEZt+YmvWUFW4oD",
-	"LRRuKwLchJy70L+oxAa+w/WGIMckunPnCQnS9YVhD5LufNKsiVhT1FkF3oAQuvrFmSJYO8exd+CNYBaN",
-	"7n7k8yfbqtc5HJ8SQFMQ8INGH+Tcp+qDuEGM3IFoOoCByNzaDFHZhK65ZAulFdDaAJAh4yCdApFQ2dRY",
-	"I2mtc5tzFC9MLdZSONnbM7Lsvowqku0VN00ePz/+bwAAAP//zYBsuSYtAQA=",
+	"H4sIAAAAAAAC/+xd/XLkuHF/FYSJq3zyrGbvfEmllPIfWn2dbK1WHknruvJtSRCJmaGXQ/IAUNq5LVXl",
+	"rzxA8oZ+khS+SJAESHC+teI/d9ohPhqNXzcaDXTjq+cnszSJUUyJd/DVSyGGM0QR5v+6gA8oIlfsN/bP",
+	"ABEfhykNk9g7EB/3vYEXsn/9miE89wZeDGfIO/Ai9tEbeMSfohlklUOKZrxROk9ZCUJxGE+854H6AWIM",
+	"597z88AboUlIKJ6fByim4ThE2EKCKgiKkhZ6MJrchXqhpQi7maeojSRWxkIMFZ8KElCczbyDv3sfz0c3",
+	"t4cX3sC7vbq+GZ0cvvc+Dap0PQ88iGk4hj610HDIP1NL76pyiYKmPujU0s8lnCGQjIEqmoMhhXRq7BCj",
+	"X7MQo8A7oDhDbgQ0MFsVAaz2fst476xsnyUBB2sAKSSImnnuT8Mo+IgwCZPYQs4RKwIeRRkQxj4knD/H",
+	"if8Z4ZxNxEap3kXL7AThBBH6IbVB4Jh/t3Ukajt1sVz7XeZ7HEaIIcoBcIdq3k/DCFlQx5q74393J6OB",
+	"BPXZMnLeqySksReczI4htQGbfdoHpwmeQQregPfvh8fHw59//vlnW7c4mbX0GEGKCFXoMmhz9hnI74yx",
+	"FGG7dmeF7x7tUH1IkgjBmPecQv8znCAXpXklijYpT9laXZo76PEUTtBlNntA2CDEGcYopoCVAbEoZKNk",
+	"UqYgQGOYRdQ7+H7gjfnceQdeGNP/+NHLiQhjiiYI52Rch78hA9B5vwzqfFQgRRjI7kyUkPA3CyU/vHUj",
+	"BSM/wyR8tM3Q36aIThEGNAFRSCjAYsZCREBeNZrv/xL/Eu/tHaMUIx9SFOzv7YFbggCdIhCjJ3BP/CRF",
+	"9yA3M0QNcJ838icmofcA/PN//leW/hOMfURogsl9pegYRgTd60XjJEb3v8RWI0DWNPOKNzcwIViOdj5C",
+	"4wbVcBuHv2YIMOkHha0Bxgnm4x+HMYwU4+YgjPmvDxjG/nQf3EwReIRRhoAPY/CAQIqTxzBAAUAh5zwk",
+	"AIJxFkVzcDu6eINiP2FfeW+/R/uT/QG4T/AExuFvkBH0ux9OU5z8A/n0dz+cql7vvwOJbCqNYBiL6igO",
+	"wngCnkI6BRBQDMOI/TuNMgJIOInB7+//cP8dq0YQmzmaYGOXQ9nhUHU3/MP9d/vFdJQVtCp0h9G4o47m",
+	"k22Zhb29a/aVyY4GUonbvT0Gob09hpO9PfDP//4/4Et5Jyn0EUjiaA5+LyHxHQCAlc4BaKyyt8cYtbcH",
+	"YBQxYOdfiKzO6ENxAGPq0AC3AfL6v8TnY5DMQkpRMAD3HN4gJAASks1QsG+FOueQ0djJB+MNPI0yVjWJ",
+	"kdn2IQhif3qDsIHf4htgH23LhShyR1n9lolNMD0NURQY+sk/WTpJML0bywJtfXzAgUn3F58a+khkgcY+",
+	"2PytQFsIgGxOVWxIPzSpBT7kRXSCZPlf2ZT1PG/heRXYGtObmEyTFRqtNGnp7bFxt1VslEyNPzpto/Ie",
+	"3LccslvLrqPotgt2n9DDNEk+n3xBfsb6PQ/aFYesA5CqpCHaQpyscpdXuQuDxSjVPTOuhDqTV/LTuBP3",
+	"LAojQt8lQYj4VkDNGvdVjcRX9rufxBTF/E+YplHoC1n5BxFbo6KTf2MyceD967Bwkw3FVzI0Ns7pKPNB",
+	"UsVskCwNIEW5JwBwNxnxNNfSqomstttAH1ODPkacwDhQtCozTRCZkzHKIrR6Wo3NL0By3g7AWYQY6X8T",
+	"4Fo1yZVmO5MqMc8o/DWDGMY0jFfO13rLzSgtygOSIj8chz5gjg2+MskNEUmTmJSF7BhRGEYj+akT9SlO",
+	"UoSplNoAUmfhE50y/hEKaUba6l2LUs/Pumr5u6osfICaEZw8sMXTzC8xTsawCaKFTAecIi7UikjmploF",
+	"X5KnOEpgcIujurZVH0GGI90p6w3qDpEVsUojpyvHpggGBcsYuHR+SY26USBdZ7MZFGpuV5DEVwegPusM",
+	"Yn2TTTOI9blL7GFNETN7xFz2CCIFSYpKab9uh0XlzneAU0H5aCY/vNEY9w4Gq16QTzBOsIm8dzAAWK3R",
+	"A+8oQjDO0mM8H2Xxhqaq0meaYLqFefIFFSBNotAPEQEBngOcxSVxP4pCFNNrRLNUmAFkYzyqdrxNKHPL",
+	"klMECCNJt0DE0eNWLDRT1zso8UFOWJng9zAOx4jQrXBLdb6D/JpppAmiL+AcYbJRPokud9JkY4QVvFET",
+	"uVn25L3uJmvYdmhlqojtHYjhDow4p0zG4CeIY0RI+dIAG1J+YNzEl4LW+kmyaOIoyWJaJ4B5jGlCYSQP",
+	"kfPDXG/goS9wlkbI7aBYnBN36IUVL/fy9q1zP+dxgL6Y+/G1k3G9effGzYfdrO3YfuCtM6ve7ArRPZBY",
+	"WgrloonngfcTimZbWXfrHe+AFpiiaGZac3ViN7zimrreOU7pq+15TBGOYXSN8CPCYg+x9h2J6hQQ3itA",
+	"ouDAuwgJ3Ya/ptbvtk1vvs4YPPo6oVvgzU6xpcoP6QfYAltkzzvBHelsICV3reTU+3CCOQfOZ3CCNsio",
+	"csdb4NOoxqeZIgmEM27bSB6ps60tSFm1652UtuLsb+N82Ql+6EeXgrjK+eIG2VLqeSekqnpKmouVPNMk",
+	"+XWEDTKq1vc2trCcPfJklhQXLMpnDTq1W2DQTgjYk0bMZUJPkywO1m+S3kzzc2nE3OUkybCPwBMkIE7Y",
+	"QTuj4nngXUUwjG/QF9u6QNEXOuRXrv4L+FOICaJ/yuj4zX+Wacz3n2yzEiUD8JTgKPiX+rlqndJDeaOL",
+	"9VQCz4Y1865oZXH/YSB2zI5XTDbEoJ3Sz1XVLBnFyLrOfB8RsgQ/VjEwlxFJSsFIw/1tDDM6RTENeXjA",
+	"+nVFtcOchgSHv22OANlbcQ9p02trtdstILx+YVHXiPlFqk2yY0f1ofFSGLtouSHulDvdApMKAsRl6AIo",
+	"z+oGqLh5xjXMX9D8GvkY0b+geX3AUJUxhobBcgtaHLFD6esU+uicKxEHB7ipMuevqSeiBtRCUV6uGy3l",
+	"ahYqqtNoIOkTu1gSJ/F8lnB4aPdMpOvZEpzsUyALDLwgZN9nYQyp8GjOYJoyCg6+ekeHo7MP1mN4iCdJ",
+	"ub+jJB6HE2/gHX84+svJqMvZdF717OTyZHR+ZKt7hmKEQ99W2UrtmY3Un04u3rs784tqt2dn55dnp4dH",
+	"J9ba2WQSxpNT6CNLI+8PP55c2qq/h48otlS8vLLSfJnaSL68PTu5sVbLJohaKl79fPPTByudV3M6TWyE",
+	"juyEjiyEPg+UDplfloJOeVjq88BLYvRh7B38vfsFiLyHrmc4jhWbwNlW1z7dbTUbJqCt6mW62EBHC9az",
+	"o6ytpl3btE7KYtXapPf506C61mkpE1yv5ClMC1skOKTGdUZ+fWdeRdVN4PyQ2mEJCslf81U+MEWOD7xZ",
+	"EvA9voUmETZi+KBLawsXrsqCrd+Uh9KSqjVP5Cl27cNjEVzfvJRyN/alyE2gRwyJbbBML5DhyCuPpW46",
+	"DawRLmVgyFOpblHyOsWygSYK3iMKlXVpWfLzIqsErgIfWTH6uvOM1SH0vUTtpjCbZlF0lMxmMDZ36YRp",
+	"XEu501jMarc6i0CemaPWbzVVRaXXJgiKK+o1/NVuAIlyA0t4RRccqTrqZotDFX5d55omWLsQ41AtSzv1",
+	"89zEJnlvzoFRsuTmFprFpDnXp6YmFxHklqVnUWltWAdcFb3qs+pWGCPM0xyUciJ5gw5JjmoxAg4aXZZc",
+	"k2bnaYRyINilrxNYxmGEVr5UvHK1bzGJNqHzKyEl9ah0cX+5hth1qLZmPeQApDVbrksaqK0qKqNTs3o6",
+	"LJzwjPcV1XRLWBw5IU8JDryByaune5lMisu+N6vnd+K/c/crr1UP3KwiYqapQRgEIWsIRldaGRGaXnVr",
+	"Gjgkg2uOYByE/ESnzqm4How0g9SfogA8zAEEpciYOnxbVZGcRPM30ab5hq5MhECnqEIDoFNIcyLZ9wIy",
+	"BlWyPDrVEErYVOyQHXyyc78U2mQca3UGSH3UIRLXtoqETk9JFgUgQBGiqDYtHS+Ka/1CKlt+QLLxoPsN",
+	"8tzwcbrzXkNp2+6wGJ3qqoH9VxaQyc9AfOcCWrNHR3lGpxqH0Zc0xOgYzonZVviMUCp1P2m7gz5L+P0Y",
+	"H8UaBMSVdJGfjk8LxAh8RikrOYE4iBilQkBCDAQ+62S0WQJXGI3DL92MVZXFpHNVs4qqxbYZpoqVAbwQ",
+	"OLapTRjGPyEY2E8umr9SNVOOmM3JvhZ1W1GrEaiTo3X+qZk/qqNm/qhSzQcd55cX55cnLqOjKM2d2zeH",
+	"765tdW7gQ7VC3bFNO3m0zWS0+TFNhNRcl9NFkUIdbCU5BcY9GLVZM5XBts0yK1IdlC/s88VQzLnF65tk",
+	"frocRyod5Zxp44K242hhBlBFByZfpNkAgFFm2Ua002Wx9lrniFCULjxBnVVqzmwLpaVCVTuZua1Cnx0y",
+	"ohhhSNFN8hnFRoPYGHzbujfKj2QrMN60x2b5s4Ed2Vd33TALr/+unC00HGA2bavMEdZ1A6GZ08+tBOVB",
+	"W624zkvWbZSiiWa25iXtjOJByScxdfKs8sLEtmIs4WRRLbTQSVqdwKKY1U8ic3ybHa5zhN11ao17huUu",
+	"IYfYd7ikIqmyD15BwWrbOs9Us1K0c2dd51VWFi13rGlmcD7Jeb/tLG9gdlGktnFuXChmetMdwFZFgX1T",
+	"tZjCNTEjD9isSnxgiYmeUpqKeEvAC2m7fu/Htz+avCuBDdWHud9KqWMAH5KMcscG78MzkDxDhEhfUZ08",
+	"4WjhDeQ57mAYoaBoyaqi+GhU60ZmfaEYFlZ/JRm5vEnKC4F821bm62c072pk6jR+5l5HUdhEoJYXoEYf",
+	"+2a1pabI/0yyWcfzKDcTrMlusToeOvnueeGBNop65zqxJs41XVFqMismol67XVFqwcmuOOvuOz7brOPY",
+	"ENRfV6YscrzNlldEN93yWaWhvzU7/dswwa0XAZuwaUq2sArz25gxoQWG6za92y7JNfJJ1B1DH21UmP88",
+	"xslEDzmSyKgbB0zr8tzcFuERZ2bq7rpDIe2auA33uVBnODTZGBlB2LKYVCZOQLwYg2n+SikLGrL4qDLE",
+	"qtGIS3XXtD+1S2198p+dS/5TQVuBgzacXSgPpHPKKF6DLHuqtyACFrm/1KPGETUNF1tNaUMcVEx+YmjV",
+	"VPZjyObWOmmu6h2uXoG9fAVWyc3SAJ9ayhSjX2U9CKxnkOmBt9PAKxg1KF3lyPvWxzhQ0LGBVLdmnRfY",
+	"hosdPVi2nWOxeEdt8Tl10hwKOvZFq4JcjbI2OO7gJqNKWr9Wf0NrdTWlSANu6pmYeh24zdl/a2pXTEzD",
+	"LGoTDkZZ1EXr1ZLPNCq9jsu1INwG0zyflINmLxR6kfmpR+qurdZPDjNqnkkntGqpUxpRmrfbhjwt1dti",
+	"GNQytBluBbs03tpoF86Ucuz0y/hOL+PaJBthmvgwcnLUOwVSmY1XvY6JCHtahqazjRmr1X6qoQpYjgQm",
+	"OMnSc9fzobp7wuBzsPTEv93AifFjipMJllnPmlLAO9BoyzfRxMs4nW30fMieo6KRysz4DN0a6bwqH97a",
+	"IlKLcB11kVUm51HpZ/L8LkXuHZkVR6WMEflmRDKXgUwKxHPtlNPgmG7CNuQmaeJmyqttlJ12x4lta22I",
+	"Xoyi5Amx52MpwnG3M4OHiN2NWqyuX43t6RJoJGuZms0nymUfXUQ5tFxkaLwgMvDC5mjYHYont11WMF/e",
+	"KT2sy2u4XkOweir6XB/2SMtySPOGwsJXE9G9zsDtirTWEHSdPYhP2uOzCQYfQ0wzGLG30m9TQjGCM10N",
+	"NsVW3V5d34xODq15wFR7eVjVx/PRze3hha28JGVFQVXV1ppLV2itB1K5RP8ovnULiKp5Z93XqXZt0Emi",
+	"2xT4Ympi9VrfNRh7ketvtmttSv6ubdfbugPEcZWRK4o+psqak+GoEVnW/FW9WbMtw2UZhGIU0xEaG/qp",
+	"IMdkmLiaJG27D1ZRONmlKgr3EXgsFpNMKlTTGmLR62obo5aJQbHCmHYgFgeC2+pgdUG0LRTWS4bPn+pp",
+	"1tskjiwjcqu96o4IZRMkRcDVr15wTW9BYUdNKGe2Jy9osg3m0ZVxRlvkrNXYtwvGwBOvGSw4NlF5sWE1",
+	"yaQkqsz+Und1vg5qGKoDQ2dGiW9tQQS1ZwKcFo5NwngngLorYFoXfozQWMDDN7p6v1FXj56QoGHtKr3q",
+	"LLNSqKDwrouVTDAhc0aYwHKd+1GrTwAGDB2IgHBcCvdiL64Q8crDOOOLaZxQPV799ujo5PraG3inh+cX",
+	"tyPW+8lo9GFk7F5PE2Hw+cMHGcVPTFH8082nEqlNqiHPRcswgK92nOXRUPjgTm6Jb26E4nAyQbgJeVQW",
+	"KSbzcHRzfnp4dHN3NDo5vDnn3tr8t+OTixP+m2liK1tWixxm8r6iMe+PauIKJ19Md8TYGxvuNlUp31ib",
+	"HVUkHmstWc9bxm0tqKVFa6yvypUUd+4z54Gr0+yBOcAzQpMZm4AncuJjTx4SHaGYYq6cr+ZXoccPO/5M",
+	"PHmg8AEzoB1hSDkDzxLGzLlXCqExzp/T5i8fZE0lD7wvb0rK6o0MwSysZgYSfU5qyzhxeZ2BLPAoA3F4",
+	"iyEjCF+6Bbyokmzay36dDqiXFZ0uR2YVwVgy+ZM63Ld6dkZoIg/KVdFucfGlPOIr8PSgGD5ENq8uKoKI",
+	"3dWpHnlsOsBvxmAYE+RnGJkJCuVjujYnNEWE6i8DZRHtcOlAVlgicfpWxUwuOR0WPlHBNEsOIZwuGZSt",
+	"+6Eiss0rMKjN/ie7bImZyrcrbWL2083NlZI1oOpVZe4hCczB7tMC/O5qvZny4gWijqTLiiuh3Xrkrz4d",
+	"yawKLmmx6yLUYBjVXrEy2rujk5vR+eG7i5M7Ye8yC/jm8OLObv3WLg+5q2BwotFiVMauylauRo7FkUpo",
+	"YfCPODaBC0FwVnKiBq9cYNG5dvHiGF5cv2IkldWHsfNAZQ2mKszqXxZwsRQ1zSfx6KiJG+BvdaF8W0vw",
+	"a137qquZYlJp+bIscabVrPJGXE1bVZ5wa7l45pi23cq/0JYDwiHzuWP/0nRwF7Sy8VA6PvcG+vgbcxbr",
+	"qZtsPs6mlB6NbFtFMmZYBL1Yh/HMxXKcqHcLJbFCFhtOjd+AAD2iiA2WSEgeeFNKU3IwHD49Pe1PRdX9",
+	"MOGSENKoucHDq3MtJ8eB9/3+2/23rGqSohimoXfg/ZH/JA5YOXuHWL/1mZjMtiO+zAKYd7Tv8Sblbcgg",
+	"L6Lf24IYzhDlQm/xVxRFhjyBwgiN/5ohdrUCwxk/+pfr5ztpQ5kaKYqEqDhTNCyjfLA/vP3e3pAsN6y9",
+	"Xvw88H58+7a94jsYaB3/6NKX4QHXH9/+0bVe8e7qv7vQdy73Z9cIPyKs3nQdeETlylMzrc8zhRM2hZ62",
+	"S//EKuW4GX5Vf91hNH4W8ImQKfv7Mf9dAxIIRYYt6PvsKgP3E7B/T0J2vVckqSoDTTSxMNBwPrdjpjZ0",
+	"qJVg4sBN9VTxC0AHS6fWWil/4Xx1cKrNtw1PA2+CDIpnhGiGY1LARSa06w6bM0R3ATMvUbVsCzy2ybdj",
+	"KM0MGLrljw6TpZQOv+Q0XweAVr6+9SBcKQjr6FlgSRwqI3JYXFEy6jsWN1bNylO3tWq5fsiKEDlorZey",
+	"zG88NMm1NL+n51CWIIj96Q3Ci6rWGld6eLfD2wQ4DeCHRRi4G76JeqHQCO8zRCuPFO6bFurSc4enCV6x",
+	"3m3H4hgns2NIkXMFmmjFF0Jvacw9ctuRW8fSMrj9qv5y2b6o1vctmxMtT8Zm8KqIX6gSc8j226BNbIM0",
+	"XKwAqJot0WD3tlsTotyW7ImVIrejLW18Wvp5GeXdmx2drOpVGh6aXKzeBtltceitlddrrQxJ8cyJA9xF",
+	"4WbAF++hfPu2S2XQPZK7IjkHyyqwLM+thl/lH11scfXucZtN/lF77Xdn4a0evOzN+Z0+1Yhr6FuXIAy1",
+	"h2/a1XzhJ7dq+aLIy9Ly65EdfxpGwUdVcfnlRHC3X01cRImh+AGZwLsmSeJxBk4CZX4+0ihXpqcEybe3",
+	"yIjX0ZYREROjekHpICjWN02VuFQKrFRqipcPnYUmf16wRWbycr3IGEVG8KcXlSVEJYfYJkRFf//JWVi0",
+	"16RaxEUr2QtM4xqjONWLzhKio8Ftk8JDFpIe4i4+L27BWUIg8jH3krACSVj7OjIOIzT8yv57F8MZeraK",
+	"wj8yQsEjjEJ+poK+hISi2EelN25YM03+gFPxvXcGEM53FuS8rNDprO0lrqNjWeJ1PS4A1rijK00UbRGc",
+	"3o229suBCaYfcICwa+HTEEXBRq4dFg9l90K+iL9PSdh6RJ09Fezk6zM9Lm0U/PqLxa/DhqyPu8d7B7xb",
+	"Hi9XqC99XiH0nbwQ1hetG8H/Uj0QS6O/dygsjX+DO2ENEtDpbow8/nO6IyPLvtSrMuv0231I6So2TWUO",
+	"95LWcftUAfNqzavm8CUCYBTxcLoqNZaLllF0WH03+tsTqFe5NTI8MN5LcteYLE0oFpXhrgJLRM7YIuqq",
+	"SWjJu/nG47PEdfFXI3ybu/xnelC4F9mOIlsTn87hwvJZhmGA529wFlvFV0XIM+GF1efs+a+yJZAmUeiH",
+	"iLBDAcpTHIv+wFOSRQGQl2wbkibINx+O8XyUxdvMoFAipMemwxYMz8Eoi4FkHLiSUFgMmCGK6RuesPtN",
+	"m1tNofPo4hyInNMyNbRK4vEACQpAEqsX9FTq7xoItYzV23O5dd06LQPx6nB7nLunC7HBbRG8F9nJmkIR",
+	"xO+geIWNO7VVXjhTNEJR9FTlL3shgHY7P31FqZVWhN7AjguFWg2M1mQ3v7pgcG3oWyQKt54AcKEQ3HK+",
+	"xleYue1XZ+g0KTz9cfXGRf1CPi2ucvTaTsxKb/B/A1lqdtvbojj9CvFfAZpCfv4TV5kJaYB0G5RFbkTt",
+	"XYAtacxKQuOFUlzmbbzSDJfFLBqA4qIgh1/lX3dFVmG31JdF1ybLcLXwalc7eX5tNYg+K+aG4kcbIdiS",
+	"D7NNVZ0h+uKB9HpVVGn2zAtZtgQ4RPKVncNHvwpuEGJVDKxyFRzmb4e0byNqT3zkzkFmzzXtJk6KTnYB",
+	"wuvblCy9GdBfXHrFu4ISYNaE9+J7/ttdGDwvLgYNK3vpVZwXgP+nCtnnwYoshNeMbzMcNovuYf74TxPO",
+	"RQnjm05lhI+QfPylx3mP8+Jwxw4KK9rzV5+HOGuK+OG+THYJRqsCRBWTAVJ5m5us7rGTxa5rVF4K708K",
+	"nW5rmOa6OB/MvzX4DfNXcapNWR/HKc3UFt/IMb4tv+BTAj36FntixwgbMwCN2mz4NQzcHI6t8FRP6rTA",
+	"M2StygNF+bSVfPdLvZYl3ocXb7iZXtbqHYprfmbHGVIDe0SEA2D4vbDdREuvkBaKHOgEnaZ04w7oESU3",
+	"BaB+cXyBicFXsjgOZ+FEwG4YzuCkbQOQlwaitHwOCsYgDGoYZjXeqwrnovU1IPglXnVYeCdT5mcvLY4b",
+	"mSpuVyEpw6/8/9y7EyUTXXJqlkA+bRfJhL3Ww2dvTcJgakQSun7T4iqCYXyDvvQREY5GRYFMhiEeFQEl",
+	"SpcDKaEQU/tztdfss9Z7kyLnZXMI95uel4Owyiwvi6gkbQJUkjrjKUl7OL1IOCWpI5q4I44Mv/L/d34A",
+	"TxUFoqjD+3f8bf2F3YX9WzLf4n69CiKFVo4V0g5U15jgonxLGPBm8KnCEfWzuT4KWJaOIEUkf+HEaZA8",
+	"7m4VUcN9tHDHbZsuWK7Ci4u4NTfpLSrYcm9ooXAbEeA65NyF/lVl3GgvjZGfYRI+uvOE+Mnq8gP0ku58",
+	"0qyJWF3UWQXegBC66sWZPItAhiPvwBvCNBw+fs/nT7ZVrXN4dU4ATYDPDxoHIOM+1QGIasTIHYimA54H",
+	"ttYmiMomdM0lWyisgMYGgAwZZ4FjItO3qbFaNmXnNlnKO1OLldxiz4NOLHsqoopke/lNk+dPz/8/AJTY",
+	"if1iNAEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -334,9 +334,30 @@ type CargoArtifactDetailConfig struct {
 	Metadata *map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// CleanupCandidate An artifact version matched by a cleanup policy
+type CleanupCandidate struct {
+	LastModified *string `json:"lastModified,omitempty"`
+	Package      string  `json:"package"`
+
+	// Policy The name of the cleanup policy that matched the version
+	Policy  string `json:"policy"`
+	Reason  string `json:"reason"`
+	Version string `json:"version"`
+}
+
+// CleanupDryRunReport The artifact versions the cleanup policies of a registry would delete
+type CleanupDryRunReport struct {
+	// ItemCount The total number of versions that would be deleted
+	ItemCount int64              `json:"itemCount"`
+	Items     []CleanupCandidate `json:"items"`
+}
+
 // CleanupPolicy Cleanup Policy for Harness Artifact Registries
 type CleanupPolicy struct {
-	ExpireDays    *int      `json:"expireDays,omitempty"`
+	ExpireDays *int `json:"expireDays,omitempty"`
+
+	// KeepVersions The number of most recent versions per package that are kept regardless of their age
+	KeepVersions  *int      `json:"keepVersions,omitempty"`
 	Name          *string   `json:"name,omitempty"`
 	PackagePrefix *[]string `json:"packagePrefix,omitempty"`
 	VersionPrefix *[]string `json:"versionPrefix,omitempty"`
@@ -1135,6 +1156,15 @@ type ArtifactVersionSummaryResponse struct {
 // BadRequest defines model for BadRequest.
 type BadRequest Error
 
+// CleanupDryRunResponse defines model for CleanupDryRunResponse.
+type CleanupDryRunResponse struct {
+	// Data The artifact versions the cleanup policies of a registry would delete
+	Data CleanupDryRunReport `json:"data"`
+
+	// Status Indicates if the request was successful or not
+	Status Status `json:"status"`
+}
+
 // ClientSetupDetailsResponse defines model for ClientSetupDetailsResponse.
 type ClientSetupDetailsResponse struct {
 	// Data Client Setup Details
@@ -36,6 +36,8 @@ import (
 	"github.com/harness/gitness/registry/app/services/refcache"
 	"github.com/harness/gitness/registry/app/store"
 	"github.com/harness/gitness/registry/app/utils/cargo"
+	registrycleanup "github.com/harness/gitness/registry/services/cleanup"
+	registryreplication "github.com/harness/gitness/registry/services/replication"
 	registrywebhook "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -90,6 +92,10 @@ func NewAPIHandler(
 	spaceController *spacecontroller.Controller,
 	quarantineArtifactRepository store.QuarantineArtifactRepository,
 	spaceStore corestore.SpaceStore,
+	replicationRuleDao store.ReplicationRuleRepository,
+	replicationExecutionDao store.ReplicationExecutionRepository,
+	replicationService *registryreplication.Service,
+	cleanupService *registrycleanup.Service,
 ) APIHandler {
 	r := chi.NewRouter()
 	r.Use(audit.Middleware())
@@ -127,6 +133,10 @@ func NewAPIHandler(
 		spaceController,
 		quarantineArtifactRepository,
 		spaceStore,
+		replicationRuleDao,
+		replicationExecutionDao,
+		replicationService,
+		cleanupService,
 	)
 
 	handler := artifact.NewStrictHandler(apiController, []artifact.StrictMiddlewareFunc{})
@@ -46,6 +46,8 @@ import (
 	refcache2 "github.com/harness/gitness/registry/app/services/refcache"
 	"github.com/harness/gitness/registry/app/store"
 	cargoutils "github.com/harness/gitness/registry/app/utils/cargo"
+	registrycleanup "github.com/harness/gitness/registry/services/cleanup"
+	registryreplication "github.com/harness/gitness/registry/services/replication"
 	registrywebhook "github.com/harness/gitness/registry/services/webhook"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -93,6 +95,10 @@ func APIHandlerProvider(
 	spaceController *spacecontroller.Controller,
 	quarantineArtifactRepository store.QuarantineArtifactRepository,
 	spaceStore corestore.SpaceStore,
+	replicationRuleDao store.ReplicationRuleRepository,
+	replicationExecutionDao store.ReplicationExecutionRepository,
+	replicationService *registryreplication.Service,
+	cleanupService *registrycleanup.Service,
 ) harness.APIHandler {
 	return harness.NewAPIHandler(
 		repoDao,
@@ -125,6 +131,10 @@ func APIHandlerProvider(
 		spaceController,
 		quarantineArtifactRepository,
 		spaceStore,
+		replicationRuleDao,
+		replicationExecutionDao,
+		replicationService,
+		cleanupService,
 	)
 }
 
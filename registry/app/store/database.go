@@ -55,6 +55,8 @@ type BlobRepository interface {
 type CleanupPolicyRepository interface {
 	// GetIDsByRegistryID the CleanupPolicy Ids specified by Registry Key
 	GetIDsByRegistryID(ctx context.Context, id int64) (ids []int64, err error)
+	// GetAllRegistryIDs returns the IDs of all registries with at least one CleanupPolicy
+	GetAllRegistryIDs(ctx context.Context) (ids []int64, err error)
 	// GetByRegistryID the CleanupPolicy specified by Registry Key
 	GetByRegistryID(
 		ctx context.Context,
@@ -699,6 +701,54 @@ type WebhooksExecutionRepository interface {
 	ListForTrigger(ctx context.Context, triggerID string) ([]*gitnesstypes.WebhookExecutionCore, error)
 }
 
+type ReplicationRuleRepository interface {
+	// Find finds the replication rule by id.
+	Find(ctx context.Context, id int64) (*types.ReplicationRule, error)
+
+	// FindByIdentifier finds the replication rule by its identifier.
+	FindByIdentifier(ctx context.Context, identifier string) (*types.ReplicationRule, error)
+
+	// Create creates a new replication rule.
+	Create(ctx context.Context, rule *types.ReplicationRule) error
+
+	// Update updates an existing replication rule.
+	Update(ctx context.Context, rule *types.ReplicationRule) error
+
+	// Delete deletes the replication rule by id.
+	Delete(ctx context.Context, id int64) error
+
+	// ListBySpace lists all replication rules of a space.
+	ListBySpace(ctx context.Context, spaceID int64) ([]*types.ReplicationRule, error)
+
+	// ListBySourceRegistry lists all replication rules that replicate from the provided local registry.
+	ListBySourceRegistry(ctx context.Context, registryID int64) ([]*types.ReplicationRule, error)
+}
+
+type ReplicationExecutionRepository interface {
+	// Find finds the replication execution by id.
+	Find(ctx context.Context, id int64) (*types.ReplicationExecution, error)
+
+	// Create creates a new replication execution entry.
+	Create(ctx context.Context, execution *types.ReplicationExecution) error
+
+	// Update updates the status, progress, digest and log of a replication execution.
+	Update(ctx context.Context, execution *types.ReplicationExecution) error
+
+	// ListForRule lists the replication executions of a rule, newest first.
+	ListForRule(
+		ctx context.Context,
+		ruleID int64,
+		limit int,
+		offset int,
+	) ([]*types.ReplicationExecution, error)
+
+	// CountForRule counts the replication executions of a rule.
+	CountForRule(ctx context.Context, ruleID int64) (int64, error)
+
+	// StopPending marks all pending replication executions of a rule as stopped.
+	StopPending(ctx context.Context, ruleID int64) (int64, error)
+}
+
 type PackageTagRepository interface {
 	FindByImageNameAndRegID(ctx context.Context, image string, regID int64) ([]*types.PackageTagMetadata, error)
 
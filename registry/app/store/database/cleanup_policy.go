@@ -42,6 +42,7 @@ type CleanupPolicyDB struct {
 	RegistryID     int64  `db:"cp_registry_id"`
 	Name           string `db:"cp_name"`
 	ExpiryTimeInMs int64  `db:"cp_expiry_time_ms"`
+	KeepVersions   int    `db:"cp_keep_versions"`
 	CreatedAt      int64  `db:"cp_created_at"`
 	UpdatedAt      int64  `db:"cp_updated_at"`
 	CreatedBy      int64  `db:"cp_created_by"`
@@ -88,6 +89,22 @@ func (c CleanupPolicyDao) GetIDsByRegistryID(ctx context.Context, id int64) (ids
 	return res, nil
 }
 
+func (c CleanupPolicyDao) GetAllRegistryIDs(ctx context.Context) (ids []int64, err error) {
+	stmt := databaseg.Builder.Select("DISTINCT cp_registry_id").From("cleanup_policies").
+		OrderBy("cp_registry_id")
+	db := dbtx.GetAccessor(ctx, c.db)
+	var res []int64
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err = db.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "failed to get registry ids with cleanup policies")
+	}
+
+	return res, nil
+}
+
 func (c CleanupPolicyDao) GetByRegistryID(
 	ctx context.Context,
 	id int64,
@@ -97,18 +114,20 @@ func (c CleanupPolicyDao) GetByRegistryID(
 		"cp_registry_id",
 		"cp_name",
 		"cp_expiry_time_ms",
+		"cp_keep_versions",
 		"cp_created_at",
 		"cp_updated_at",
 		"cp_created_by",
 		"cp_updated_by",
-		"cpp_id",
-		"cpp_cleanup_policy_id",
-		"cpp_prefix",
-		"cpp_prefix_type",
+		"COALESCE(cpp_id, 0) AS cpp_id",
+		"COALESCE(cpp_cleanup_policy_id, 0) AS cpp_cleanup_policy_id",
+		"COALESCE(cpp_prefix, '') AS cpp_prefix",
+		"COALESCE(cpp_prefix_type, '') AS cpp_prefix_type",
 	).
 		From("cleanup_policies").
-		Join("cleanup_policy_prefix_mappings ON cp_id = cpp_cleanup_policy_id").
-		Where("cp_registry_id = ?", id)
+		LeftJoin("cleanup_policy_prefix_mappings ON cp_id = cpp_cleanup_policy_id").
+		Where("cp_registry_id = ?", id).
+		OrderBy("cp_id")
 
 	db := dbtx.GetAccessor(ctx, c.db)
 	query, args, err := stmt.ToSql()
@@ -142,6 +161,7 @@ func (c CleanupPolicyDao) Create(ctx context.Context, cleanupPolicy *types.Clean
 			cp_registry_id
 			,cp_name
 			,cp_expiry_time_ms
+			,cp_keep_versions
 			,cp_created_at
 			,cp_updated_at
 			,cp_created_by
@@ -150,6 +170,7 @@ func (c CleanupPolicyDao) Create(ctx context.Context, cleanupPolicy *types.Clean
 			:cp_registry_id
 			,:cp_name
 			,:cp_expiry_time_ms
+			,:cp_keep_versions
 			,:cp_created_at
 			,:cp_updated_at
 			,:cp_created_by
@@ -344,6 +365,7 @@ func (c CleanupPolicyDao) mapToInternalCleanupPolicy(
 		RegistryID:     cp.RegistryID,
 		Name:           cp.Name,
 		ExpiryTimeInMs: cp.ExpiryTime,
+		KeepVersions:   cp.KeepVersions,
 		CreatedAt:      cp.CreatedAt.UnixMilli(),
 		UpdatedAt:      cp.UpdatedAt.UnixMilli(),
 		CreatedBy:      cp.CreatedBy,
@@ -356,6 +378,7 @@ func (c CleanupPolicyDao) mapToCleanupPolicies(
 	rows *sqlx.Rows,
 ) (*[]types.CleanupPolicy, error) {
 	cleanupPolicies := make(map[int64]*types.CleanupPolicy)
+	var ids []int64
 
 	for rows.Next() {
 		var cp CleanupPolicyJoinMapping
@@ -365,11 +388,13 @@ func (c CleanupPolicyDao) mapToCleanupPolicies(
 		}
 
 		if _, exists := cleanupPolicies[cp.ID]; !exists {
+			ids = append(ids, cp.ID)
 			cleanupPolicies[cp.ID] = &types.CleanupPolicy{
 				ID:            cp.ID,
 				RegistryID:    cp.RegistryID,
 				Name:          cp.Name,
 				ExpiryTime:    cp.ExpiryTimeInMs,
+				KeepVersions:  cp.KeepVersions,
 				CreatedAt:     time.UnixMilli(cp.CreatedAt),
 				UpdatedAt:     time.UnixMilli(cp.UpdatedAt),
 				PackagePrefix: make([]string, 0),
@@ -386,8 +411,8 @@ func (c CleanupPolicyDao) mapToCleanupPolicies(
 		}
 	}
 	var result []types.CleanupPolicy
-	for _, cp := range cleanupPolicies {
-		result = append(result, *cp)
+	for _, id := range ids {
+		result = append(result, *cleanupPolicies[id])
 	}
 	return &result, nil
 }
@@ -0,0 +1,277 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	databaseg "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	replicationExecutionColumns = `
+		 replication_execution_id
+		,replication_execution_rule_id
+		,replication_execution_trigger
+		,replication_execution_image
+		,replication_execution_reference
+		,replication_execution_digest
+		,replication_execution_status
+		,replication_execution_progress
+		,replication_execution_log
+		,replication_execution_created_by
+		,replication_execution_created
+		,replication_execution_updated`
+
+	replicationExecutionSelectBase = `
+	SELECT` + replicationExecutionColumns + `
+	FROM registry_replication_executions`
+)
+
+type ReplicationExecutionDao struct {
+	db *sqlx.DB
+}
+
+func NewReplicationExecutionDao(db *sqlx.DB) store.ReplicationExecutionRepository {
+	return &ReplicationExecutionDao{
+		db: db,
+	}
+}
+
+type replicationExecutionDB struct {
+	ID        int64                            `db:"replication_execution_id"`
+	RuleID    int64                            `db:"replication_execution_rule_id"`
+	Trigger   types.ReplicationTrigger         `db:"replication_execution_trigger"`
+	Image     string                           `db:"replication_execution_image"`
+	Reference string                           `db:"replication_execution_reference"`
+	Digest    string                           `db:"replication_execution_digest"`
+	Status    types.ReplicationExecutionStatus `db:"replication_execution_status"`
+	Progress  int                              `db:"replication_execution_progress"`
+	Log       string                           `db:"replication_execution_log"`
+	CreatedBy int64                            `db:"replication_execution_created_by"`
+	Created   int64                            `db:"replication_execution_created"`
+	Updated   int64                            `db:"replication_execution_updated"`
+}
+
+func (r ReplicationExecutionDao) Find(ctx context.Context, id int64) (*types.ReplicationExecution, error) {
+	const sqlQuery = replicationExecutionSelectBase + `
+	WHERE replication_execution_id = $1`
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := &replicationExecutionDB{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to find replication execution")
+	}
+
+	return mapToReplicationExecution(dst), nil
+}
+
+func (r ReplicationExecutionDao) Create(ctx context.Context, execution *types.ReplicationExecution) error {
+	const sqlQuery = `
+		INSERT INTO registry_replication_executions (
+			 replication_execution_rule_id
+			,replication_execution_trigger
+			,replication_execution_image
+			,replication_execution_reference
+			,replication_execution_digest
+			,replication_execution_status
+			,replication_execution_progress
+			,replication_execution_log
+			,replication_execution_created_by
+			,replication_execution_created
+			,replication_execution_updated
+		) VALUES (
+			 :replication_execution_rule_id
+			,:replication_execution_trigger
+			,:replication_execution_image
+			,:replication_execution_reference
+			,:replication_execution_digest
+			,:replication_execution_status
+			,:replication_execution_progress
+			,:replication_execution_log
+			,:replication_execution_created_by
+			,:replication_execution_created
+			,:replication_execution_updated
+		) RETURNING replication_execution_id`
+
+	now := time.Now()
+	execution.CreatedAt = now
+	execution.UpdatedAt = now
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToReplicationExecutionDB(execution))
+	if err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Failed to bind replication execution object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&execution.ID); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+func (r ReplicationExecutionDao) Update(ctx context.Context, execution *types.ReplicationExecution) error {
+	const sqlQuery = `
+		UPDATE registry_replication_executions
+		SET
+			 replication_execution_digest = :replication_execution_digest
+			,replication_execution_status = :replication_execution_status
+			,replication_execution_progress = :replication_execution_progress
+			,replication_execution_log = :replication_execution_log
+			,replication_execution_updated = :replication_execution_updated
+		WHERE replication_execution_id = :replication_execution_id`
+
+	execution.UpdatedAt = time.Now()
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToReplicationExecutionDB(execution))
+	if err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Failed to bind replication execution object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Update query failed")
+	}
+
+	return nil
+}
+
+func (r ReplicationExecutionDao) ListForRule(
+	ctx context.Context,
+	ruleID int64,
+	limit int,
+	offset int,
+) ([]*types.ReplicationExecution, error) {
+	stmt := databaseg.Builder.
+		Select(replicationExecutionColumns).
+		From("registry_replication_executions").
+		Where("replication_execution_rule_id = ?", ruleID).
+		OrderBy("replication_execution_id DESC").
+		Limit(uint64(limit)).  //nolint:gosec
+		Offset(uint64(offset)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := []*replicationExecutionDB{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	executions := make([]*types.ReplicationExecution, len(dst))
+	for i, d := range dst {
+		executions[i] = mapToReplicationExecution(d)
+	}
+	return executions, nil
+}
+
+func (r ReplicationExecutionDao) CountForRule(ctx context.Context, ruleID int64) (int64, error) {
+	stmt := databaseg.Builder.
+		Select("COUNT(*)").
+		From("registry_replication_executions").
+		Where("replication_execution_rule_id = ?", ruleID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	var count int64
+	if err = db.GetContext(ctx, &count, sql, args...); err != nil {
+		return 0, databaseg.ProcessSQLErrorf(ctx, err, "Count query failed")
+	}
+
+	return count, nil
+}
+
+func (r ReplicationExecutionDao) StopPending(ctx context.Context, ruleID int64) (int64, error) {
+	stmt := databaseg.Builder.
+		Update("registry_replication_executions").
+		Set("replication_execution_status", types.ReplicationExecutionStatusStopped).
+		Set("replication_execution_updated", time.Now().UnixMilli()).
+		Where("replication_execution_rule_id = ?", ruleID).
+		Where("replication_execution_status IN (?, ?)",
+			types.ReplicationExecutionStatusPending, types.ReplicationExecutionStatusRunning)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, databaseg.ProcessSQLErrorf(ctx, err, "Failed to stop pending replication executions")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, databaseg.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	return count, nil
+}
+
+func mapToReplicationExecutionDB(execution *types.ReplicationExecution) *replicationExecutionDB {
+	return &replicationExecutionDB{
+		ID:        execution.ID,
+		RuleID:    execution.RuleID,
+		Trigger:   execution.Trigger,
+		Image:     execution.Image,
+		Reference: execution.Reference,
+		Digest:    execution.Digest,
+		Status:    execution.Status,
+		Progress:  execution.Progress,
+		Log:       execution.Log,
+		CreatedBy: execution.CreatedBy,
+		Created:   execution.CreatedAt.UnixMilli(),
+		Updated:   execution.UpdatedAt.UnixMilli(),
+	}
+}
+
+func mapToReplicationExecution(dst *replicationExecutionDB) *types.ReplicationExecution {
+	return &types.ReplicationExecution{
+		ID:        dst.ID,
+		RuleID:    dst.RuleID,
+		Trigger:   dst.Trigger,
+		Image:     dst.Image,
+		Reference: dst.Reference,
+		Digest:    dst.Digest,
+		Status:    dst.Status,
+		Progress:  dst.Progress,
+		Log:       dst.Log,
+		CreatedBy: dst.CreatedBy,
+		CreatedAt: time.UnixMilli(dst.Created),
+		UpdatedAt: time.UnixMilli(dst.Updated),
+	}
+}
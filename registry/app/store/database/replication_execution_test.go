@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/registry/app/store/database"
+	"github.com/harness/gitness/registry/types"
+)
+
+func TestReplicationExecutionDao(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	createUserAndSpace(ctx, t, db)
+	registryID := createRegistry(ctx, t, db, "docker-local")
+
+	rule := &types.ReplicationRule{
+		Identifier:      "to-gcp",
+		SpaceID:         testSpaceID,
+		SourceType:      types.ReplicationEndpointTypeLocal,
+		Source:          types.ReplicationEndpoint{RegistryID: registryID},
+		DestinationType: types.ReplicationEndpointTypeGCP,
+		Destination:     types.ReplicationEndpoint{URL: "https://gcr.io", Namespace: "project"},
+		CreatedBy:       testUserID,
+		UpdatedBy:       testUserID,
+	}
+	if err := database.NewReplicationRuleDao(db).Create(ctx, rule); err != nil {
+		t.Fatalf("failed to create replication rule: %v", err)
+	}
+
+	executionStore := database.NewReplicationExecutionDao(db)
+
+	statuses := []types.ReplicationExecutionStatus{
+		types.ReplicationExecutionStatusSuccess,
+		types.ReplicationExecutionStatusRunning,
+		types.ReplicationExecutionStatusPending,
+	}
+
+	executions := make([]*types.ReplicationExecution, len(statuses))
+	for i, status := range statuses {
+		executions[i] = &types.ReplicationExecution{
+			RuleID:    rule.ID,
+			Trigger:   types.ReplicationTriggerEvent,
+			Image:     "app",
+			Reference: "v1",
+			Status:    status,
+			CreatedBy: testUserID,
+		}
+		if err := executionStore.Create(ctx, executions[i]); err != nil {
+			t.Fatalf("failed to create replication execution: %v", err)
+		}
+	}
+
+	first := executions[0]
+	first.Digest = "sha256:abc"
+	first.Progress = 100
+	first.Log = "copied 3 blobs"
+	if err := executionStore.Update(ctx, first); err != nil {
+		t.Fatalf("failed to update replication execution: %v", err)
+	}
+
+	found, err := executionStore.Find(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("failed to find replication execution: %v", err)
+	}
+	if found.Digest != first.Digest || found.Progress != 100 || found.Log != first.Log ||
+		found.Status != types.ReplicationExecutionStatusSuccess || found.Trigger != types.ReplicationTriggerEvent {
+		t.Errorf("found execution doesn't match the updated one: want=%+v got=%+v", first, found)
+	}
+
+	count, err := executionStore.CountForRule(ctx, rule.ID)
+	if err != nil {
+		t.Fatalf("failed to count replication executions: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 executions, got %d", count)
+	}
+
+	// executions are listed newest first.
+	list, err := executionStore.ListForRule(ctx, rule.ID, 2, 1)
+	if err != nil {
+		t.Fatalf("failed to list replication executions: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != executions[1].ID || list[1].ID != executions[0].ID {
+		t.Errorf("unexpected page of executions: %+v", list)
+	}
+
+	stopped, err := executionStore.StopPending(ctx, rule.ID)
+	if err != nil {
+		t.Fatalf("failed to stop pending replication executions: %v", err)
+	}
+	if stopped != 2 {
+		t.Errorf("expected 2 stopped executions, got %d", stopped)
+	}
+
+	for i, execution := range executions {
+		found, err = executionStore.Find(ctx, execution.ID)
+		if err != nil {
+			t.Fatalf("failed to find replication execution: %v", err)
+		}
+
+		want := types.ReplicationExecutionStatusStopped
+		if i == 0 {
+			want = types.ReplicationExecutionStatusSuccess
+		}
+		if found.Status != want {
+			t.Errorf("execution %d: expected status %s, got %s", i, want, found.Status)
+		}
+	}
+}
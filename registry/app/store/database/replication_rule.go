@@ -0,0 +1,340 @@
+//  Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	databaseg "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	replicationRuleColumns = `
+		 replication_rule_id
+		,replication_rule_identifier
+		,replication_rule_space_id
+		,replication_rule_source_type
+		,replication_rule_source
+		,replication_rule_source_registry_id
+		,replication_rule_destination_type
+		,replication_rule_destination
+		,replication_rule_allowed_patterns
+		,replication_rule_blocked_patterns
+		,replication_rule_created_by
+		,replication_rule_updated_by
+		,replication_rule_created
+		,replication_rule_updated`
+
+	replicationRuleSelectBase = `
+	SELECT` + replicationRuleColumns + `
+	FROM registry_replication_rules`
+)
+
+type ReplicationRuleDao struct {
+	db *sqlx.DB
+}
+
+func NewReplicationRuleDao(db *sqlx.DB) store.ReplicationRuleRepository {
+	return &ReplicationRuleDao{
+		db: db,
+	}
+}
+
+type replicationRuleDB struct {
+	ID               int64                         `db:"replication_rule_id"`
+	Identifier       string                        `db:"replication_rule_identifier"`
+	SpaceID          int64                         `db:"replication_rule_space_id"`
+	SourceType       types.ReplicationEndpointType `db:"replication_rule_source_type"`
+	Source           string                        `db:"replication_rule_source"`
+	SourceRegistryID null.Int                      `db:"replication_rule_source_registry_id"`
+	DestinationType  types.ReplicationEndpointType `db:"replication_rule_destination_type"`
+	Destination      string                        `db:"replication_rule_destination"`
+	AllowedPatterns  string                        `db:"replication_rule_allowed_patterns"`
+	BlockedPatterns  string                        `db:"replication_rule_blocked_patterns"`
+	CreatedBy        int64                         `db:"replication_rule_created_by"`
+	UpdatedBy        int64                         `db:"replication_rule_updated_by"`
+	Created          int64                         `db:"replication_rule_created"`
+	Updated          int64                         `db:"replication_rule_updated"`
+}
+
+func (r ReplicationRuleDao) Find(ctx context.Context, id int64) (*types.ReplicationRule, error) {
+	const sqlQuery = replicationRuleSelectBase + `
+	WHERE replication_rule_id = $1`
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := &replicationRuleDB{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to find replication rule")
+	}
+
+	return mapToReplicationRule(dst)
+}
+
+func (r ReplicationRuleDao) FindByIdentifier(ctx context.Context, identifier string) (*types.ReplicationRule, error) {
+	const sqlQuery = replicationRuleSelectBase + `
+	WHERE replication_rule_identifier = $1`
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := &replicationRuleDB{}
+	if err := db.GetContext(ctx, dst, sqlQuery, identifier); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Failed to find replication rule")
+	}
+
+	return mapToReplicationRule(dst)
+}
+
+func (r ReplicationRuleDao) Create(ctx context.Context, rule *types.ReplicationRule) error {
+	const sqlQuery = `
+		INSERT INTO registry_replication_rules (
+			 replication_rule_identifier
+			,replication_rule_space_id
+			,replication_rule_source_type
+			,replication_rule_source
+			,replication_rule_source_registry_id
+			,replication_rule_destination_type
+			,replication_rule_destination
+			,replication_rule_allowed_patterns
+			,replication_rule_blocked_patterns
+			,replication_rule_created_by
+			,replication_rule_updated_by
+			,replication_rule_created
+			,replication_rule_updated
+		) VALUES (
+			 :replication_rule_identifier
+			,:replication_rule_space_id
+			,:replication_rule_source_type
+			,:replication_rule_source
+			,:replication_rule_source_registry_id
+			,:replication_rule_destination_type
+			,:replication_rule_destination
+			,:replication_rule_allowed_patterns
+			,:replication_rule_blocked_patterns
+			,:replication_rule_created_by
+			,:replication_rule_updated_by
+			,:replication_rule_created
+			,:replication_rule_updated
+		) RETURNING replication_rule_id`
+
+	now := time.Now()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	dbRule, err := mapToReplicationRuleDB(rule)
+	if err != nil {
+		return fmt.Errorf("failed to map replication rule to internal db type: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	query, args, err := db.BindNamed(sqlQuery, dbRule)
+	if err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Failed to bind replication rule object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&rule.ID); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+func (r ReplicationRuleDao) Update(ctx context.Context, rule *types.ReplicationRule) error {
+	const sqlQuery = `
+		UPDATE registry_replication_rules
+		SET
+			 replication_rule_source_type = :replication_rule_source_type
+			,replication_rule_source = :replication_rule_source
+			,replication_rule_source_registry_id = :replication_rule_source_registry_id
+			,replication_rule_destination_type = :replication_rule_destination_type
+			,replication_rule_destination = :replication_rule_destination
+			,replication_rule_allowed_patterns = :replication_rule_allowed_patterns
+			,replication_rule_blocked_patterns = :replication_rule_blocked_patterns
+			,replication_rule_updated_by = :replication_rule_updated_by
+			,replication_rule_updated = :replication_rule_updated
+		WHERE replication_rule_id = :replication_rule_id`
+
+	rule.UpdatedAt = time.Now()
+
+	dbRule, err := mapToReplicationRuleDB(rule)
+	if err != nil {
+		return fmt.Errorf("failed to map replication rule to internal db type: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	query, args, err := db.BindNamed(sqlQuery, dbRule)
+	if err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Failed to bind replication rule object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "Update query failed")
+	}
+
+	return nil
+}
+
+func (r ReplicationRuleDao) Delete(ctx context.Context, id int64) error {
+	stmt := databaseg.Builder.Delete("registry_replication_rules").
+		Where("replication_rule_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert delete replication rule query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return databaseg.ProcessSQLErrorf(ctx, err, "the delete replication rule query failed")
+	}
+
+	return nil
+}
+
+func (r ReplicationRuleDao) ListBySpace(ctx context.Context, spaceID int64) ([]*types.ReplicationRule, error) {
+	const sqlQuery = replicationRuleSelectBase + `
+	WHERE replication_rule_space_id = $1
+	ORDER BY replication_rule_created ASC`
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := []*replicationRuleDB{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToReplicationRules(dst)
+}
+
+func (r ReplicationRuleDao) ListBySourceRegistry(
+	ctx context.Context,
+	registryID int64,
+) ([]*types.ReplicationRule, error) {
+	const sqlQuery = replicationRuleSelectBase + `
+	WHERE replication_rule_source_registry_id = $1
+	ORDER BY replication_rule_id ASC`
+
+	db := dbtx.GetAccessor(ctx, r.db)
+
+	dst := []*replicationRuleDB{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, registryID); err != nil {
+		return nil, databaseg.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToReplicationRules(dst)
+}
+
+func mapToReplicationRuleDB(rule *types.ReplicationRule) (*replicationRuleDB, error) {
+	source, err := json.Marshal(rule.Source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal replication source: %w", err)
+	}
+	destination, err := json.Marshal(rule.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal replication destination: %w", err)
+	}
+	allowed, err := marshalPatterns(rule.AllowedPatterns)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := marshalPatterns(rule.BlockedPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var sourceRegistryID null.Int
+	if rule.SourceType == types.ReplicationEndpointTypeLocal {
+		sourceRegistryID = null.IntFrom(rule.Source.RegistryID)
+	}
+
+	return &replicationRuleDB{
+		ID:               rule.ID,
+		Identifier:       rule.Identifier,
+		SpaceID:          rule.SpaceID,
+		SourceType:       rule.SourceType,
+		Source:           string(source),
+		SourceRegistryID: sourceRegistryID,
+		DestinationType:  rule.DestinationType,
+		Destination:      string(destination),
+		AllowedPatterns:  allowed,
+		BlockedPatterns:  blocked,
+		CreatedBy:        rule.CreatedBy,
+		UpdatedBy:        rule.UpdatedBy,
+		Created:          rule.CreatedAt.UnixMilli(),
+		Updated:          rule.UpdatedAt.UnixMilli(),
+	}, nil
+}
+
+func mapToReplicationRule(dst *replicationRuleDB) (*types.ReplicationRule, error) {
+	rule := &types.ReplicationRule{
+		ID:              dst.ID,
+		Identifier:      dst.Identifier,
+		SpaceID:         dst.SpaceID,
+		SourceType:      dst.SourceType,
+		DestinationType: dst.DestinationType,
+		CreatedBy:       dst.CreatedBy,
+		UpdatedBy:       dst.UpdatedBy,
+		CreatedAt:       time.UnixMilli(dst.Created),
+		UpdatedAt:       time.UnixMilli(dst.Updated),
+	}
+	if err := json.Unmarshal([]byte(dst.Source), &rule.Source); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal replication source: %w", err)
+	}
+	if err := json.Unmarshal([]byte(dst.Destination), &rule.Destination); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal replication destination: %w", err)
+	}
+	if err := json.Unmarshal([]byte(dst.AllowedPatterns), &rule.AllowedPatterns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal replication allowed patterns: %w", err)
+	}
+	if err := json.Unmarshal([]byte(dst.BlockedPatterns), &rule.BlockedPatterns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal replication blocked patterns: %w", err)
+	}
+	return rule, nil
+}
+
+func mapToReplicationRules(dst []*replicationRuleDB) ([]*types.ReplicationRule, error) {
+	rules := make([]*types.ReplicationRule, len(dst))
+	for i, d := range dst {
+		rule, err := mapToReplicationRule(d)
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+func marshalPatterns(patterns []string) (string, error) {
+	if patterns == nil {
+		patterns = []string{}
+	}
+	data, err := json.Marshal(patterns)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal replication patterns: %w", err)
+	}
+	return string(data), nil
+}
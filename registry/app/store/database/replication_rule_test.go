@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/registry/app/store/database"
+	"github.com/harness/gitness/registry/types"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+func TestReplicationRuleDao(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	createUserAndSpace(ctx, t, db)
+	registryID := createRegistry(ctx, t, db, "docker-local")
+
+	ruleStore := database.NewReplicationRuleDao(db)
+
+	local := &types.ReplicationRule{
+		Identifier:      "to-jfrog",
+		SpaceID:         testSpaceID,
+		SourceType:      types.ReplicationEndpointTypeLocal,
+		Source:          types.ReplicationEndpoint{RegistryID: registryID},
+		DestinationType: types.ReplicationEndpointTypeJfrog,
+		Destination: types.ReplicationEndpoint{
+			URL:       "https://example.jfrog.io",
+			Namespace: "docker-remote",
+			Username:  "robot",
+			SecretID:  "jfrog-token",
+		},
+		AllowedPatterns: []string{"app/*"},
+		CreatedBy:       testUserID,
+		UpdatedBy:       testUserID,
+	}
+	if err := ruleStore.Create(ctx, local); err != nil {
+		t.Fatalf("failed to create replication rule: %v", err)
+	}
+
+	remote := &types.ReplicationRule{
+		Identifier:      "from-gcp",
+		SpaceID:         testSpaceID,
+		SourceType:      types.ReplicationEndpointTypeGCP,
+		Source:          types.ReplicationEndpoint{URL: "https://gcr.io", Namespace: "project"},
+		DestinationType: types.ReplicationEndpointTypeLocal,
+		Destination:     types.ReplicationEndpoint{RegistryID: registryID},
+		CreatedBy:       testUserID,
+		UpdatedBy:       testUserID,
+	}
+	if err := ruleStore.Create(ctx, remote); err != nil {
+		t.Fatalf("failed to create replication rule: %v", err)
+	}
+
+	found, err := ruleStore.Find(ctx, local.ID)
+	if err != nil {
+		t.Fatalf("failed to find replication rule: %v", err)
+	}
+	if found.Identifier != local.Identifier || found.Source != local.Source || found.Destination != local.Destination {
+		t.Errorf("found rule doesn't match the created one: want=%+v got=%+v", local, found)
+	}
+	if !slices.Equal(found.AllowedPatterns, []string{"app/*"}) || len(found.BlockedPatterns) != 0 {
+		t.Errorf("unexpected patterns: allowed=%v blocked=%v", found.AllowedPatterns, found.BlockedPatterns)
+	}
+
+	found, err = ruleStore.FindByIdentifier(ctx, remote.Identifier)
+	if err != nil {
+		t.Fatalf("failed to find replication rule by identifier: %v", err)
+	}
+	if found.ID != remote.ID {
+		t.Errorf("expected rule %d, got %d", remote.ID, found.ID)
+	}
+
+	local.BlockedPatterns = []string{"app/internal"}
+	local.UpdatedBy = testUserID
+	if err = ruleStore.Update(ctx, local); err != nil {
+		t.Fatalf("failed to update replication rule: %v", err)
+	}
+
+	found, err = ruleStore.Find(ctx, local.ID)
+	if err != nil {
+		t.Fatalf("failed to find replication rule: %v", err)
+	}
+	if !slices.Equal(found.BlockedPatterns, []string{"app/internal"}) {
+		t.Errorf("expected updated blocked patterns, got %v", found.BlockedPatterns)
+	}
+
+	rules, err := ruleStore.ListBySpace(ctx, testSpaceID)
+	if err != nil {
+		t.Fatalf("failed to list replication rules: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Errorf("expected 2 rules in the space, got %d", len(rules))
+	}
+
+	// only the rules replicating from the local registry are listed, not the ones replicating into it.
+	rules, err = ruleStore.ListBySourceRegistry(ctx, registryID)
+	if err != nil {
+		t.Fatalf("failed to list replication rules by source registry: %v", err)
+	}
+	if len(rules) != 1 || rules[0].ID != local.ID {
+		t.Errorf("expected only rule %d, got %+v", local.ID, rules)
+	}
+
+	if err = ruleStore.Delete(ctx, local.ID); err != nil {
+		t.Fatalf("failed to delete replication rule: %v", err)
+	}
+
+	_, err = ruleStore.Find(ctx, local.ID)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected not found error after delete, got %v", err)
+	}
+}
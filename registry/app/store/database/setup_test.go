@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	appdatabase "github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/xid"
+)
+
+const (
+	testUserID  int64 = 1
+	testSpaceID int64 = 1
+)
+
+func New(dsn string) (*sqlx.DB, error) {
+	if dsn == ":memory:" {
+		dsn = fmt.Sprintf("file:%s.db?mode=memory&cache=shared", xid.New().String())
+	}
+	db, err := sqlx.Connect("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(`PRAGMA foreign_keys = ON;`); err != nil {
+		return nil, fmt.Errorf("foreign keys pragma: %w", err)
+	}
+	return db, nil
+}
+
+func setupDB(t *testing.T) (*sqlx.DB, func()) {
+	t.Helper()
+	// must use file as db because in memory have only basic features
+	// file is anyway removed on every test. SQLite is fast
+	// so it will not affect too much performance.
+	_ = os.Remove("test.db")
+	db, err := New("test.db")
+	if err != nil {
+		t.Fatalf("Error opening db, err: %v", err)
+	}
+	_, _ = db.Exec("PRAGMA busy_timeout = 5000;")
+	if err = migrate.Migrate(context.Background(), db); err != nil {
+		t.Fatalf("Error migrating db, err: %v", err)
+	}
+
+	return db, func() {
+		db.Close()
+	}
+}
+
+// createUserAndSpace creates the principal and the space referenced by the registry tables.
+func createUserAndSpace(ctx context.Context, t *testing.T, db *sqlx.DB) {
+	t.Helper()
+
+	principalStore := appdatabase.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation)
+	if err := principalStore.CreateUser(ctx, &types.User{ID: testUserID, UID: "user_1"}); err != nil {
+		t.Fatalf("failed to create user %v", err)
+	}
+
+	spacePathStore := appdatabase.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	evictor := cache.NewEvictor[*types.SpaceCore]("namespace", "space-topic", nil)
+	spacePathCache := cache.New(ctx, spacePathStore, store.ToLowerSpacePathTransformation, evictor, time.Minute)
+	spaceStore := appdatabase.NewSpaceStore(db, spacePathCache, spacePathStore)
+
+	space := types.Space{ID: testSpaceID, Identifier: "space_1", CreatedBy: testUserID}
+	if err := spaceStore.Create(ctx, &space); err != nil {
+		t.Fatalf("failed to create space %v", err)
+	}
+}
+
+// createRegistry creates a registry in the test space and returns its ID.
+func createRegistry(ctx context.Context, t *testing.T, db *sqlx.DB, name string) int64 {
+	t.Helper()
+
+	const sqlQuery = `
+		INSERT INTO registries (
+			 registry_name
+			,registry_root_parent_id
+			,registry_parent_id
+			,registry_type
+			,registry_package_type
+			,registry_created_at
+			,registry_updated_at
+			,registry_created_by
+			,registry_updated_by
+			,registry_uuid
+		) VALUES ($1, $2, $2, 'VIRTUAL', 'DOCKER', $3, $3, $4, $4, $5)
+		RETURNING registry_id`
+
+	var id int64
+	now := time.Now().UnixMilli()
+	err := db.QueryRowContext(ctx, sqlQuery, name, testSpaceID, now, testUserID, xid.New().String()).Scan(&id)
+	if err != nil {
+		t.Fatalf("failed to create registry %v", err)
+	}
+
+	return id
+}
@@ -75,6 +75,14 @@ func ProvideWebhookExecutionDao(sqlDB *sqlx.DB) store.WebhooksExecutionRepositor
 	return NewWebhookExecutionDao(sqlDB)
 }
 
+func ProvideReplicationRuleDao(sqlDB *sqlx.DB) store.ReplicationRuleRepository {
+	return NewReplicationRuleDao(sqlDB)
+}
+
+func ProvideReplicationExecutionDao(sqlDB *sqlx.DB) store.ReplicationExecutionRepository {
+	return NewReplicationExecutionDao(sqlDB)
+}
+
 func ProvideManifestRefDao(db *sqlx.DB) store.ManifestReferenceRepository {
 	return NewManifestReferenceDao(db)
 }
@@ -143,6 +151,8 @@ var WireSet = wire.NewSet(
 	ProvideGenericBlobDao,
 	ProvideWebhookDao,
 	ProvideWebhookExecutionDao,
+	ProvideReplicationRuleDao,
+	ProvideReplicationExecutionDao,
 	ProvidePackageTagDao,
 	ProvideTaskRepository,
 	ProvideTaskSourceRepository,
@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/job"
+	gitnessstore "github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobType = "registry-cleanup-policies"
+
+var _ job.Handler = (*Service)(nil)
+
+// Register schedules the recurring job enforcing the cleanup policies.
+func (s *Service) Register(ctx context.Context) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.config.Cron, s.config.MaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for registry cleanup policies: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the registry cleanup policies background job handler.
+func (s *Service) Handle(ctx context.Context, _ string, progressReporter job.ProgressReporter) (string, error) {
+	registryIDs, err := s.cleanupPolicyDao.GetAllRegistryIDs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list registries with cleanup policies: %w", err)
+	}
+
+	deleted, failed := 0, 0
+	for i, registryID := range registryIDs {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		registry, err := s.registryDao.Get(ctx, registryID)
+		if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to find registry %d: %w", registryID, err)
+		}
+
+		n, err := s.Prune(ctx, registry)
+		deleted += n
+		if err != nil {
+			// keep going, a single broken registry shouldn't block the cleanup of all others.
+			failed++
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to enforce cleanup policies of registry %d", registryID)
+		}
+
+		if err = progressReporter((i+1)*job.ProgressMax/len(registryIDs), ""); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to report registry cleanup job progress")
+		}
+	}
+
+	return fmt.Sprintf("deleted %d versions, %d of %d registries failed", deleted, failed, len(registryIDs)), nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+// version is a single version of a package as seen by the cleanup policies.
+type version struct {
+	name       string
+	digest     string
+	modifiedAt time.Time
+}
+
+// evaluate returns the versions of the package that are matched by any of the cleanup policies.
+// The versions have to be sorted from newest to oldest.
+//
+// The package and version prefixes restrict which versions a policy applies to,
+// a version within that scope is matched in case it's older than the expiry time of the policy
+// and isn't one of the KeepVersions most recent versions in scope.
+// Policies without an expiry time and without a number of versions to keep never match anything.
+func evaluate(
+	policies []types.CleanupPolicy,
+	pkg string,
+	versions []version,
+	now time.Time,
+) []types.CleanupCandidate {
+	var candidates []types.CleanupCandidate
+	matched := map[string]struct{}{}
+
+	for _, policy := range policies {
+		if policy.ExpiryTime <= 0 && policy.KeepVersions <= 0 {
+			continue
+		}
+
+		if !hasAnyPrefix(pkg, policy.PackagePrefix) {
+			continue
+		}
+
+		expiredBefore := now.Add(-time.Duration(policy.ExpiryTime) * time.Millisecond)
+
+		inScope := 0
+		for _, v := range versions {
+			if !hasAnyPrefix(v.name, policy.VersionPrefix) {
+				continue
+			}
+			inScope++
+
+			if policy.KeepVersions > 0 && inScope <= policy.KeepVersions {
+				continue
+			}
+
+			if policy.ExpiryTime > 0 && v.modifiedAt.After(expiredBefore) {
+				continue
+			}
+
+			if _, ok := matched[v.name]; ok {
+				continue
+			}
+			matched[v.name] = struct{}{}
+
+			candidates = append(candidates, types.CleanupCandidate{
+				Package:      pkg,
+				Version:      v.name,
+				Digest:       v.digest,
+				Policy:       policy.Name,
+				Reason:       reason(policy),
+				LastModified: v.modifiedAt,
+			})
+		}
+	}
+
+	return candidates
+}
+
+func reason(policy types.CleanupPolicy) string {
+	var reasons []string
+	if policy.ExpiryTime > 0 {
+		reasons = append(reasons, fmt.Sprintf("not modified within %s",
+			formatDuration(time.Duration(policy.ExpiryTime)*time.Millisecond)))
+	}
+	if policy.KeepVersions > 0 {
+		reasons = append(reasons, fmt.Sprintf("not within the %d most recent versions", policy.KeepVersions))
+	}
+	return strings.Join(reasons, " and ")
+}
+
+func formatDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d%day == 0 {
+		days := int64(d / day)
+		if days == 1 {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", days)
+	}
+	return d.String()
+}
+
+// hasAnyPrefix returns true if s starts with any of the prefixes, or if no prefixes are provided.
+func hasAnyPrefix(s string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	daysMs := func(n int) int64 { return (time.Duration(n) * day).Milliseconds() }
+
+	versions := []version{
+		{name: "2.0.0", modifiedAt: now.Add(-1 * day)},
+		{name: "1.2.0", modifiedAt: now.Add(-10 * day)},
+		{name: "1.1.0", modifiedAt: now.Add(-20 * day)},
+		{name: "dev-1", modifiedAt: now.Add(-30 * day)},
+		{name: "1.0.0", modifiedAt: now.Add(-40 * day)},
+	}
+
+	tests := []struct {
+		name     string
+		pkg      string
+		policies []types.CleanupPolicy
+		expected []string
+	}{
+		{
+			name:     "expiry time",
+			pkg:      "app",
+			policies: []types.CleanupPolicy{{Name: "p", ExpiryTime: daysMs(15)}},
+			expected: []string{"1.1.0", "dev-1", "1.0.0"},
+		},
+		{
+			name:     "keep versions",
+			pkg:      "app",
+			policies: []types.CleanupPolicy{{Name: "p", KeepVersions: 3}},
+			expected: []string{"dev-1", "1.0.0"},
+		},
+		{
+			name:     "expiry time and keep versions",
+			pkg:      "app",
+			policies: []types.CleanupPolicy{{Name: "p", ExpiryTime: daysMs(5), KeepVersions: 3}},
+			expected: []string{"dev-1", "1.0.0"},
+		},
+		{
+			name: "version prefix",
+			pkg:  "app",
+			policies: []types.CleanupPolicy{
+				{Name: "p", VersionPrefix: []string{"dev-"}, KeepVersions: 0, ExpiryTime: daysMs(1)},
+			},
+			expected: []string{"dev-1"},
+		},
+		{
+			name: "version prefix scopes keep versions",
+			pkg:  "app",
+			policies: []types.CleanupPolicy{
+				{Name: "p", VersionPrefix: []string{"1."}, KeepVersions: 2},
+			},
+			expected: []string{"1.0.0"},
+		},
+		{
+			name:     "package prefix mismatch",
+			pkg:      "lib",
+			policies: []types.CleanupPolicy{{Name: "p", PackagePrefix: []string{"app"}, KeepVersions: 1}},
+			expected: nil,
+		},
+		{
+			name:     "policy without criteria",
+			pkg:      "app",
+			policies: []types.CleanupPolicy{{Name: "p", VersionPrefix: []string{"dev-"}}},
+			expected: nil,
+		},
+		{
+			name: "multiple policies are deduplicated",
+			pkg:  "app",
+			policies: []types.CleanupPolicy{
+				{Name: "a", ExpiryTime: daysMs(25)},
+				{Name: "b", KeepVersions: 3},
+			},
+			expected: []string{"dev-1", "1.0.0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, c := range evaluate(test.policies, test.pkg, versions, now) {
+				if c.Package != test.pkg {
+					t.Errorf("unexpected package %q", c.Package)
+				}
+				got = append(got, c.Version)
+			}
+
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/registry/app/api/openapi/contracts/artifact"
+	"github.com/harness/gitness/registry/app/api/utils"
+	registrypostprocessingevents "github.com/harness/gitness/registry/app/events/asyncprocessing"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/rs/zerolog/log"
+)
+
+const listPageSize = 100
+
+// Verify Service implements ServiceInterface.
+var _ ServiceInterface = (*Service)(nil)
+
+// Service enforces the cleanup policies of registries.
+// Versions matched by a policy are deleted the same way as when deleted manually,
+// manifests that end up untagged are deleted as well which queues their blobs for garbage collection.
+type Service struct {
+	config                 Config
+	tx                     dbtx.Transactor
+	cleanupPolicyDao       store.CleanupPolicyRepository
+	registryDao            store.RegistryRepository
+	imageDao               store.ImageRepository
+	artifactDao            store.ArtifactRepository
+	tagDao                 store.TagRepository
+	manifestDao            store.ManifestRepository
+	fileManager            filemanager.FileManager
+	postProcessingReporter *registrypostprocessingevents.Reporter
+	scheduler              *job.Scheduler
+}
+
+func NewService(
+	config Config,
+	tx dbtx.Transactor,
+	cleanupPolicyDao store.CleanupPolicyRepository,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	tagDao store.TagRepository,
+	manifestDao store.ManifestRepository,
+	fileManager filemanager.FileManager,
+	postProcessingReporter *registrypostprocessingevents.Reporter,
+	scheduler *job.Scheduler,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided registry cleanup service config is invalid: %w", err)
+	}
+
+	return &Service{
+		config:                 config,
+		tx:                     tx,
+		cleanupPolicyDao:       cleanupPolicyDao,
+		registryDao:            registryDao,
+		imageDao:               imageDao,
+		artifactDao:            artifactDao,
+		tagDao:                 tagDao,
+		manifestDao:            manifestDao,
+		fileManager:            fileManager,
+		postProcessingReporter: postProcessingReporter,
+		scheduler:              scheduler,
+	}, nil
+}
+
+type Config struct {
+	Enabled bool
+	// Cron is the schedule of the job enforcing the cleanup policies of all registries.
+	Cron        string
+	MaxDuration time.Duration
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if c.Cron == "" {
+		return errors.New("Config.Cron is required")
+	}
+	if c.MaxDuration <= 0 {
+		return errors.New("Config.MaxDuration has to be a positive duration")
+	}
+	return nil
+}
+
+// DryRun returns the versions of the registry that would be deleted by its cleanup policies.
+func (s *Service) DryRun(ctx context.Context, registry *types.Registry) ([]types.CleanupCandidate, error) {
+	candidates := []types.CleanupCandidate{}
+	err := s.forEachPackage(ctx, registry, func(_ string, _ []version, matched []types.CleanupCandidate) error {
+		candidates = append(candidates, matched...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// Prune deletes all versions of the registry that are matched by its cleanup policies.
+// It returns the number of deleted versions.
+func (s *Service) Prune(ctx context.Context, registry *types.Registry) (int, error) {
+	deleted := 0
+	err := s.forEachPackage(ctx, registry, func(pkg string, versions []version, matched []types.CleanupCandidate) error {
+		if len(matched) == 0 {
+			return nil
+		}
+
+		var n int
+		var err error
+		if isOCI(registry.PackageType) {
+			n, err = s.pruneOCIPackage(ctx, registry, pkg, versions, matched)
+		} else {
+			n, err = s.prunePackage(ctx, registry, pkg, matched)
+		}
+		deleted += n
+
+		return err
+	})
+	if err != nil {
+		return deleted, err
+	}
+
+	if deleted > 0 && registry.PackageType == artifact.PackageTypeRPM {
+		s.postProcessingReporter.BuildRegistryIndex(ctx, registry.ID, make([]types.SourceRef, 0))
+	}
+
+	return deleted, nil
+}
+
+// forEachPackage evaluates the cleanup policies of the registry against every package in it.
+func (s *Service) forEachPackage(
+	ctx context.Context,
+	registry *types.Registry,
+	fn func(pkg string, versions []version, matched []types.CleanupCandidate) error,
+) error {
+	// cleanup policies can only be configured for virtual registries.
+	if registry.Type != artifact.RegistryTypeVIRTUAL || !isSupported(registry.PackageType) {
+		return nil
+	}
+
+	policies, err := s.cleanupPolicyDao.GetByRegistryID(ctx, registry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get cleanup policies: %w", err)
+	}
+	if policies == nil || len(*policies) == 0 {
+		return nil
+	}
+
+	packages, err := s.listPackages(ctx, registry)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, pkg := range packages {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		versions, err := s.listVersions(ctx, registry, pkg)
+		if err != nil {
+			return err
+		}
+
+		if err = fn(pkg, versions, evaluate(*policies, pkg, versions, now)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) listPackages(ctx context.Context, registry *types.Registry) ([]string, error) {
+	var packages []string
+	for offset := 0; ; offset += listPageSize {
+		var page *[]types.ArtifactMetadata
+		var err error
+		if isOCI(registry.PackageType) {
+			page, err = s.tagDao.GetAllArtifactsByRepo(
+				ctx, registry.ParentID, registry.Name, "image_name", "ASC", listPageSize, offset, "", nil,
+			)
+		} else {
+			page, err = s.artifactDao.GetArtifactsByRepo(
+				ctx, registry.ParentID, registry.Name, "image_name", "ASC", listPageSize, offset, "", nil, nil,
+			)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list packages: %w", err)
+		}
+
+		for _, a := range *page {
+			packages = append(packages, a.Name)
+		}
+
+		if len(*page) < listPageSize {
+			return packages, nil
+		}
+	}
+}
+
+// listVersions returns all versions of the package, sorted from newest to oldest.
+func (s *Service) listVersions(ctx context.Context, registry *types.Registry, pkg string) ([]version, error) {
+	var versions []version
+	for offset := 0; ; offset += listPageSize {
+		var pageSize int
+		if isOCI(registry.PackageType) {
+			page, err := s.tagDao.GetAllTagsByRepoAndImage(
+				ctx, registry.ParentID, registry.Name, pkg, "updated_at", "DESC", listPageSize, offset, "",
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list tags of %s: %w", pkg, err)
+			}
+
+			for _, tag := range *page {
+				versions = append(versions, version{name: tag.Name, digest: tag.Digest, modifiedAt: tag.ModifiedAt})
+			}
+			pageSize = len(*page)
+		} else {
+			page, err := s.artifactDao.GetAllVersionsByRepoAndImage(
+				ctx, registry.ID, pkg, "updated_at", "DESC", listPageSize, offset, "", nil,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list versions of %s: %w", pkg, err)
+			}
+
+			for _, v := range *page {
+				versions = append(versions, version{name: v.Name, modifiedAt: v.ModifiedAt})
+			}
+			pageSize = len(*page)
+		}
+
+		if pageSize < listPageSize {
+			return versions, nil
+		}
+	}
+}
+
+// pruneOCIPackage deletes the matched tags of an image, as well as the manifests that aren't tagged anymore.
+func (s *Service) pruneOCIPackage(
+	ctx context.Context,
+	registry *types.Registry,
+	pkg string,
+	versions []version,
+	matched []types.CleanupCandidate,
+) (int, error) {
+	pruned := make(map[string]struct{}, len(matched))
+	for _, c := range matched {
+		pruned[c.Version] = struct{}{}
+	}
+
+	// manifests that are still referenced by any remaining tag have to be kept.
+	tagged := map[string]struct{}{}
+	for _, v := range versions {
+		if _, ok := pruned[v.name]; !ok {
+			tagged[v.digest] = struct{}{}
+		}
+	}
+
+	deleted := 0
+	untagged := map[string]struct{}{}
+	for _, c := range matched {
+		if err := s.tagDao.DeleteTag(ctx, registry.ID, pkg, c.Version); err != nil {
+			return deleted, fmt.Errorf("failed to delete tag %s:%s: %w", pkg, c.Version, err)
+		}
+		deleted++
+
+		if _, ok := tagged[c.Digest]; !ok && c.Digest != "" {
+			untagged[c.Digest] = struct{}{}
+		}
+	}
+
+	for dgst := range untagged {
+		err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+			if _, err := s.manifestDao.DeleteManifest(ctx, registry.ID, pkg, digest.Digest(dgst)); err != nil {
+				return fmt.Errorf("failed to delete manifest: %w", err)
+			}
+
+			if err := s.artifactDao.DeleteByVersionAndImageName(ctx, pkg, dgst, registry.ID); err != nil {
+				return fmt.Errorf("failed to delete artifact: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			// the manifest could still be referenced (e.g. by an image index), it's left to the garbage collection.
+			log.Ctx(ctx).Warn().Err(err).
+				Msgf("failed to delete untagged manifest %s@%s of registry %d", pkg, dgst, registry.ID)
+		}
+	}
+
+	return deleted, nil
+}
+
+// prunePackage deletes the matched versions of a non-OCI package.
+func (s *Service) prunePackage(
+	ctx context.Context,
+	registry *types.Registry,
+	pkg string,
+	matched []types.CleanupCandidate,
+) (int, error) {
+	deleted := 0
+	for _, c := range matched {
+		filePath, err := utils.GetFilePath(registry.PackageType, pkg, c.Version)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to get file path: %w", err)
+		}
+
+		err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+			if err := s.fileManager.DeleteNode(ctx, registry.ID, filePath); err != nil {
+				return err
+			}
+
+			if err := s.artifactDao.DeleteByVersionAndImageName(ctx, pkg, c.Version, registry.ID); err != nil {
+				return fmt.Errorf("failed to delete version: %w", err)
+			}
+
+			if err := s.imageDao.DeleteByImageNameIfNoLinkedArtifacts(ctx, registry.ID, pkg); err != nil {
+				return fmt.Errorf("failed to delete image: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete version %s of %s: %w", c.Version, pkg, err)
+		}
+		deleted++
+	}
+
+	//nolint:exhaustive
+	switch registry.PackageType {
+	case artifact.PackageTypeCARGO, artifact.PackageTypeGO:
+		if deleted > 0 {
+			s.postProcessingReporter.BuildPackageIndex(ctx, registry.ID, pkg)
+		}
+	}
+
+	return deleted, nil
+}
+
+// isSupported returns true if versions of the package type can be cleaned up.
+func isSupported(packageType artifact.PackageType) bool {
+	if isOCI(packageType) {
+		return true
+	}
+	_, err := utils.GetFilePath(packageType, "", "")
+	return err == nil
+}
+
+func isOCI(packageType artifact.PackageType) bool {
+	return packageType == artifact.PackageTypeDOCKER || packageType == artifact.PackageTypeHELM
+}
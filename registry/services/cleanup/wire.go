@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"github.com/harness/gitness/job"
+	registrypostprocessingevents "github.com/harness/gitness/registry/app/events/asyncprocessing"
+	"github.com/harness/gitness/registry/app/pkg/filemanager"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideCleanupConfig,
+	ProvideService,
+)
+
+func ProvideService(
+	config Config,
+	tx dbtx.Transactor,
+	cleanupPolicyDao store.CleanupPolicyRepository,
+	registryDao store.RegistryRepository,
+	imageDao store.ImageRepository,
+	artifactDao store.ArtifactRepository,
+	tagDao store.TagRepository,
+	manifestDao store.ManifestRepository,
+	fileManager filemanager.FileManager,
+	postProcessingReporter *registrypostprocessingevents.Reporter,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	service, err := NewService(
+		config,
+		tx,
+		cleanupPolicyDao,
+		registryDao,
+		imageDao,
+		artifactDao,
+		tagDao,
+		manifestDao,
+		fileManager,
+		postProcessingReporter,
+		scheduler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = executor.Register(jobType, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func ProvideCleanupConfig(config *types.Config) Config {
+	return Config{
+		Enabled:     config.Registry.CleanupPolicy.Enabled,
+		Cron:        config.Registry.CleanupPolicy.Cron,
+		MaxDuration: config.Registry.CleanupPolicy.MaxDuration,
+	}
+}
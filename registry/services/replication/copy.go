@@ -0,0 +1,347 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/registry/app/api/handler/utils"
+	artifactevents "github.com/harness/gitness/registry/app/events/artifact"
+	"github.com/harness/gitness/registry/app/manifest/manifestlist"
+	"github.com/harness/gitness/registry/app/manifest/schema2"
+	"github.com/harness/gitness/registry/app/remote/clients/registry"
+	"github.com/harness/gitness/registry/app/remote/clients/registry/auth/basic"
+	"github.com/harness/gitness/registry/types"
+	gitnesstypes "github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ociReference returns the image name and the reference (tag or digest) of a pushed OCI artifact.
+func ociReference(a artifactevents.Artifact) (string, string, bool) {
+	var name, tag, digest string
+	switch v := a.(type) {
+	case *artifactevents.DockerArtifact:
+		name, tag, digest = v.Name, v.Tag, v.Digest
+	case *artifactevents.HelmArtifact:
+		name, tag, digest = v.Name, v.Tag, v.Digest
+	default:
+		return "", "", false
+	}
+
+	if tag != "" {
+		return name, tag, true
+	}
+	if digest != "" {
+		return name, digest, true
+	}
+	return "", "", false
+}
+
+// IsAllowed returns true if the image reference passes the allowed and blocked patterns of the rule.
+func IsAllowed(rule *types.ReplicationRule, image string, reference string) bool {
+	allowed, err := utils.IsPatternAllowed(rule.AllowedPatterns, rule.BlockedPatterns, image+":"+reference)
+	return err == nil && allowed
+}
+
+// endpointClient returns a registry client for one side of the rule
+// together with the repository the image lives in on that registry.
+// The permissions are the ones required on a local registry.
+func (s *Service) endpointClient(
+	ctx context.Context,
+	rule *types.ReplicationRule,
+	endpointType types.ReplicationEndpointType,
+	endpoint types.ReplicationEndpoint,
+	image string,
+	permissions ...enum.Permission,
+) (registry.Client, string, error) {
+	switch endpointType {
+	case types.ReplicationEndpointTypeLocal:
+		return s.localClient(ctx, rule, endpoint, image, permissions)
+	case types.ReplicationEndpointTypeJfrog:
+		return s.remoteClient(ctx, rule, endpoint, image)
+	case types.ReplicationEndpointTypeGCP:
+		return nil, "", fmt.Errorf("replication endpoint type %s is not supported", endpointType)
+	default:
+		return nil, "", fmt.Errorf("unknown replication endpoint type %s", endpointType)
+	}
+}
+
+// localClient accesses a registry of this instance through its OCI API on behalf of the
+// principal that last configured the rule, using a short-lived token scoped to the registry.
+func (s *Service) localClient(
+	ctx context.Context,
+	rule *types.ReplicationRule,
+	endpoint types.ReplicationEndpoint,
+	image string,
+	permissions []enum.Permission,
+) (registry.Client, string, error) {
+	reg, err := s.registryDao.Get(ctx, endpoint.RegistryID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find registry %d: %w", endpoint.RegistryID, err)
+	}
+
+	rootSpace, err := s.spaceFinder.FindByID(ctx, reg.RootParentID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find root space of registry %s: %w", reg.Name, err)
+	}
+
+	parentSpace, err := s.spaceFinder.FindByID(ctx, reg.ParentID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find parent space of registry %s: %w", reg.Name, err)
+	}
+
+	user, err := s.principalStore.FindUser(ctx, rule.UpdatedBy)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find principal %d: %w", rule.UpdatedBy, err)
+	}
+
+	// the principal could have been blocked or lost access since the rule was configured.
+	if err = s.checkAccess(ctx, user.ToPrincipal(), parentSpace, permissions); err != nil {
+		return nil, "", err
+	}
+
+	accessPermissions := []jwt.AccessPermissions{{SpaceID: rootSpace.ID, Permissions: permissions}}
+	if reg.ParentID != reg.RootParentID {
+		accessPermissions = append(accessPermissions, jwt.AccessPermissions{SpaceID: reg.ParentID, Permissions: permissions})
+	}
+
+	jwtToken, err := token.CreateUserWithAccessPermissions(user, &jwt.SubClaimsAccessPermissions{
+		Source:      jwt.OciSource,
+		Permissions: accessPermissions,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create registry access token: %w", err)
+	}
+
+	client := registry.NewClientWithAuthorizer(s.config.InternalURL, basic.NewAuthorizer(user.UID, jwtToken), false)
+
+	return client, path.Join(strings.ToLower(rootSpace.Identifier), reg.Name, image), nil
+}
+
+// checkAccess verifies that the principal isn't blocked and has all the permissions
+// on the registries of the space.
+func (s *Service) checkAccess(
+	ctx context.Context,
+	principal *gitnesstypes.Principal,
+	space *gitnesstypes.SpaceCore,
+	permissions []enum.Permission,
+) error {
+	session, err := sessionFor(principal)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		err := apiauth.CheckSpaceScope(ctx, s.authorizer, session, space, enum.ResourceTypeRegistry, permission)
+		if err != nil {
+			return fmt.Errorf("principal %s is missing permission %s in space %s: %w",
+				principal.UID, permission, space.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// checkSecretAccess verifies that the principal isn't blocked and is allowed to access the secret of the space.
+func (s *Service) checkSecretAccess(
+	ctx context.Context,
+	principal *gitnesstypes.Principal,
+	space *gitnesstypes.SpaceCore,
+	secretID string,
+) error {
+	session, err := sessionFor(principal)
+	if err != nil {
+		return err
+	}
+
+	err = apiauth.CheckSecret(ctx, s.authorizer, session, space.Path, secretID, enum.PermissionSecretAccess)
+	if err != nil {
+		return fmt.Errorf("principal %s is not allowed to access secret %s in space %s: %w",
+			principal.UID, secretID, space.Path, err)
+	}
+
+	return nil
+}
+
+func sessionFor(principal *gitnesstypes.Principal) (*auth.Session, error) {
+	if principal.Blocked {
+		return nil, fmt.Errorf("principal %s is blocked", principal.UID)
+	}
+	return &auth.Session{Principal: *principal}, nil
+}
+
+// remoteClient accesses a remote registry with the credentials of the endpoint.
+// The password secret is only decrypted if the principal that last configured the rule can still access it.
+func (s *Service) remoteClient(
+	ctx context.Context,
+	rule *types.ReplicationRule,
+	endpoint types.ReplicationEndpoint,
+	image string,
+) (registry.Client, string, error) {
+	var password string
+	if endpoint.SecretID != "" {
+		secretSpace, err := s.spaceFinder.FindByID(ctx, endpoint.SecretSpaceID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find secret space %d: %w", endpoint.SecretSpaceID, err)
+		}
+
+		user, err := s.principalStore.FindUser(ctx, rule.UpdatedBy)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find principal %d: %w", rule.UpdatedBy, err)
+		}
+
+		if err = s.checkSecretAccess(ctx, user.ToPrincipal(), secretSpace, endpoint.SecretID); err != nil {
+			return nil, "", err
+		}
+
+		password, err = s.secretService.DecryptSecret(ctx, secretSpace.Path, endpoint.SecretID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to decrypt secret %s: %w", endpoint.SecretID, err)
+		}
+	}
+
+	client := registry.NewClient(endpoint.URL, endpoint.Username, password, false, true)
+
+	return client, remoteRepository(endpoint, image), nil
+}
+
+func remoteRepository(endpoint types.ReplicationEndpoint, image string) string {
+	if endpoint.Namespace == "" {
+		return image
+	}
+	return path.Join(endpoint.Namespace, image)
+}
+
+// copyArtifact copies the manifest tree and all blobs referenced by it from the source
+// to the destination repository. It returns the digest of the copied top-level manifest.
+func copyArtifact(
+	ctx context.Context,
+	src registry.Client,
+	srcRepo string,
+	dst registry.Client,
+	dstRepo string,
+	reference string,
+	logs *executionLog,
+) (string, error) {
+	man, digest, err := src.PullManifest(ctx, srcRepo, reference)
+	if err != nil {
+		return "", fmt.Errorf("failed to pull manifest %s: %w", reference, err)
+	}
+
+	exists, desc, err := dst.ManifestExist(ctx, dstRepo, reference)
+	if err != nil {
+		return digest, fmt.Errorf("failed to check manifest %s on destination: %w", reference, err)
+	}
+	if exists && desc != nil && digest != "" && string(desc.Digest) == digest {
+		logs.Printf("manifest %s already exists on destination", reference)
+		return digest, nil
+	}
+
+	for _, descriptor := range man.References() {
+		if err = ctx.Err(); err != nil {
+			return digest, err
+		}
+
+		dgst := descriptor.Digest.String()
+		switch descriptor.MediaType {
+		case schema2.MediaTypeForeignLayer:
+			continue
+		case v1.MediaTypeImageIndex, manifestlist.MediaTypeManifestList,
+			v1.MediaTypeImageManifest, schema2.MediaTypeManifest,
+			registry.MediaTypeSignedManifest, registry.MediaTypeManifest:
+			if _, err = copyArtifact(ctx, src, srcRepo, dst, dstRepo, dgst, logs); err != nil {
+				return digest, err
+			}
+		default:
+			if err = copyBlob(ctx, src, srcRepo, dst, dstRepo, dgst, logs); err != nil {
+				return digest, err
+			}
+		}
+	}
+
+	mediaType, payload, err := man.Payload()
+	if err != nil {
+		return digest, fmt.Errorf("failed to get payload of manifest %s: %w", reference, err)
+	}
+
+	pushed, err := dst.PushManifest(ctx, dstRepo, reference, mediaType, payload)
+	if err != nil {
+		return digest, fmt.Errorf("failed to push manifest %s: %w", reference, err)
+	}
+	logs.Printf("pushed manifest %s (%s)", reference, mediaType)
+
+	if digest == "" {
+		digest = pushed
+	}
+	return digest, nil
+}
+
+func copyBlob(
+	ctx context.Context,
+	src registry.Client,
+	srcRepo string,
+	dst registry.Client,
+	dstRepo string,
+	digest string,
+	logs *executionLog,
+) error {
+	exists, err := dst.BlobExist(ctx, dstRepo, digest)
+	if err != nil {
+		return fmt.Errorf("failed to check blob %s on destination: %w", digest, err)
+	}
+	if exists {
+		logs.Printf("blob %s already exists on destination", digest)
+		return nil
+	}
+
+	size, blob, err := src.PullBlob(ctx, srcRepo, digest)
+	if err != nil {
+		return fmt.Errorf("failed to pull blob %s: %w", digest, err)
+	}
+	defer blob.Close()
+
+	if err = dst.PushBlob(ctx, dstRepo, digest, size, blob); err != nil {
+		return fmt.Errorf("failed to push blob %s: %w", digest, err)
+	}
+	logs.Printf("copied blob %s (%d bytes)", digest, size)
+
+	return nil
+}
+
+// executionLog collects the log lines of a single replication execution.
+type executionLog struct {
+	sb strings.Builder
+}
+
+func (l *executionLog) Printf(format string, args ...any) {
+	l.sb.WriteString(time.Now().UTC().Format(time.RFC3339))
+	l.sb.WriteByte(' ')
+	fmt.Fprintf(&l.sb, format, args...)
+	l.sb.WriteByte('\n')
+}
+
+func (l *executionLog) String() string {
+	return l.sb.String()
+}
@@ -0,0 +1,331 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/registry/app/manifest/manifestlist"
+	"github.com/harness/gitness/registry/app/manifest/schema2"
+	"github.com/harness/gitness/registry/app/remote/clients/registry"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// testRegistry is an in-memory stand-in for the OCI distribution API used by the registry client.
+type testRegistry struct {
+	mx        sync.Mutex
+	manifests map[string]testManifest
+	blobs     map[string][]byte
+	pushes    []string
+}
+
+type testManifest struct {
+	mediaType string
+	payload   []byte
+}
+
+func newTestRegistry(t *testing.T) (*testRegistry, registry.Client) {
+	r := &testRegistry{
+		manifests: map[string]testManifest{},
+		blobs:     map[string][]byte{},
+	}
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	return r, registry.NewClientWithAuthorizer(srv.URL, nil, false)
+}
+
+func (r *testRegistry) putBlob(repo string, data []byte) string {
+	dgst := digest.FromBytes(data).String()
+	r.blobs[repo+"@"+dgst] = data
+	return dgst
+}
+
+func (r *testRegistry) putManifest(repo, reference, mediaType string, payload []byte) string {
+	dgst := digest.FromBytes(payload).String()
+	r.manifests[repo+":"+reference] = testManifest{mediaType: mediaType, payload: payload}
+	r.manifests[repo+":"+dgst] = testManifest{mediaType: mediaType, payload: payload}
+	return dgst
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	p := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case strings.Contains(p, "/blobs/uploads/"):
+		repo := p[:strings.Index(p, "/blobs/uploads/")]
+		if req.Method == http.MethodPost {
+			w.Header().Set("Location", "/v2/"+repo+"/blobs/uploads/upload-id")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		data, _ := io.ReadAll(req.Body)
+		dgst := req.URL.Query().Get("digest")
+		if digest.FromBytes(data).String() != dgst {
+			http.Error(w, "digest mismatch", http.StatusBadRequest)
+			return
+		}
+		r.blobs[repo+"@"+dgst] = data
+		r.pushes = append(r.pushes, "blob "+dgst)
+		w.WriteHeader(http.StatusCreated)
+
+	case strings.Contains(p, "/blobs/"):
+		i := strings.LastIndex(p, "/blobs/")
+		data, ok := r.blobs[p[:i]+"@"+p[i+len("/blobs/"):]]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+
+	case strings.Contains(p, "/manifests/"):
+		i := strings.LastIndex(p, "/manifests/")
+		repo, reference := p[:i], p[i+len("/manifests/"):]
+		if req.Method == http.MethodPut {
+			payload, _ := io.ReadAll(req.Body)
+			dgst := r.putManifest(repo, reference, req.Header.Get("Content-Type"), payload)
+			r.pushes = append(r.pushes, "manifest "+reference)
+			w.Header().Set("Docker-Content-Digest", dgst)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
+		m, ok := r.manifests[repo+":"+reference]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", m.mediaType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(m.payload)))
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(m.payload).String())
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(m.payload)
+		}
+
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func TestCopyArtifact(t *testing.T) {
+	ctx := context.Background()
+
+	src, srcClient := newTestRegistry(t)
+
+	config := src.putBlob("acme/app", []byte(`{"architecture":"amd64","os":"linux"}`))
+	layer := src.putBlob("acme/app", []byte("layer"))
+	image := fmt.Appendf(nil, `{
+		"schemaVersion": 2,
+		"mediaType": %q,
+		"config": {"mediaType": %q, "size": 37, "digest": %q},
+		"layers": [
+			{"mediaType": %q, "size": 5, "digest": %q},
+			{"mediaType": %q, "size": 10, "digest": "sha256:%s"}
+		]
+	}`, schema2.MediaTypeManifest, schema2.MediaTypeImageConfig, config, schema2.MediaTypeLayer, layer,
+		schema2.MediaTypeForeignLayer, strings.Repeat("0", 64))
+	imageDigest := src.putManifest("acme/app", "amd64", schema2.MediaTypeManifest, image)
+	index := fmt.Appendf(nil, `{
+		"schemaVersion": 2,
+		"mediaType": %q,
+		"manifests": [
+			{"mediaType": %q, "size": %d, "digest": %q, "platform": {"architecture": "amd64", "os": "linux"}}
+		]
+	}`, manifestlist.MediaTypeManifestList, schema2.MediaTypeManifest, len(image), imageDigest)
+	indexDigest := src.putManifest("acme/app", "v1", manifestlist.MediaTypeManifestList, index)
+
+	t.Run("copies the manifest tree and blobs", func(t *testing.T) {
+		dst, dstClient := newTestRegistry(t)
+		// blobs that exist on the destination already aren't uploaded again.
+		dst.putBlob("mirror/app", []byte("layer"))
+
+		dgst, err := copyArtifact(ctx, srcClient, "acme/app", dstClient, "mirror/app", "v1", &executionLog{})
+		if err != nil {
+			t.Fatalf("failed to copy artifact: %v", err)
+		}
+		if dgst != indexDigest {
+			t.Errorf("expected digest %s, got %s", indexDigest, dgst)
+		}
+
+		// children are pushed before the manifests referencing them, the foreign layer is skipped.
+		expected := []string{"blob " + config, "manifest " + imageDigest, "manifest v1"}
+		if !equalStrings(dst.pushes, expected) {
+			t.Errorf("expected pushes %v, got %v", expected, dst.pushes)
+		}
+
+		if m := dst.manifests["mirror/app:v1"]; string(m.payload) != string(index) ||
+			m.mediaType != manifestlist.MediaTypeManifestList {
+			t.Errorf("unexpected manifest on destination: %s (%s)", m.payload, m.mediaType)
+		}
+	})
+
+	t.Run("skips manifests existing on the destination", func(t *testing.T) {
+		dst, dstClient := newTestRegistry(t)
+		dst.putManifest("mirror/app", "v1", manifestlist.MediaTypeManifestList, index)
+
+		logs := &executionLog{}
+		dgst, err := copyArtifact(ctx, srcClient, "acme/app", dstClient, "mirror/app", "v1", logs)
+		if err != nil {
+			t.Fatalf("failed to copy artifact: %v", err)
+		}
+		if dgst != indexDigest {
+			t.Errorf("expected digest %s, got %s", indexDigest, dgst)
+		}
+		if len(dst.pushes) != 0 {
+			t.Errorf("expected nothing to be pushed, got %v", dst.pushes)
+		}
+		if !strings.Contains(logs.String(), "already exists on destination") {
+			t.Errorf("expected the skipped manifest to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("fails for unknown references", func(t *testing.T) {
+		_, dstClient := newTestRegistry(t)
+
+		_, err := copyArtifact(ctx, srcClient, "acme/app", dstClient, "mirror/app", "v2", &executionLog{})
+		if err == nil {
+			t.Fatal("expected an error for a missing source manifest")
+		}
+	})
+}
+
+func equalStrings(a, b []string) bool {
+	return strings.Join(a, "\n") == strings.Join(b, "\n")
+}
+
+type testAuthorizer struct {
+	authz.Authorizer
+	permissions []enum.Permission
+}
+
+func (a testAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	for _, p := range a.permissions {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestCheckAccess(t *testing.T) {
+	ctx := context.Background()
+	space := &types.SpaceCore{ID: 1, Path: "acme"}
+	permissions := []enum.Permission{enum.PermissionArtifactsDownload, enum.PermissionArtifactsUpload}
+
+	tests := []struct {
+		name      string
+		blocked   bool
+		granted   []enum.Permission
+		expectErr bool
+	}{
+		{
+			name:    "granted",
+			granted: permissions,
+		},
+		{
+			name:      "blocked",
+			blocked:   true,
+			granted:   permissions,
+			expectErr: true,
+		},
+		{
+			name:      "lost upload permission",
+			granted:   []enum.Permission{enum.PermissionArtifactsDownload},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{authorizer: testAuthorizer{permissions: test.granted}}
+			principal := &types.Principal{ID: 1, UID: "user", Type: enum.PrincipalTypeUser, Blocked: test.blocked}
+
+			err := s.checkAccess(ctx, principal, space, permissions)
+			if test.expectErr != (err != nil) {
+				t.Errorf("expected error=%t, got %v", test.expectErr, err)
+			}
+			if test.expectErr && !test.blocked && !apiauth.IsNoAccess(err) {
+				t.Errorf("expected no access error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckSecretAccess(t *testing.T) {
+	ctx := context.Background()
+	space := &types.SpaceCore{ID: 1, Path: "acme"}
+
+	tests := []struct {
+		name      string
+		blocked   bool
+		granted   []enum.Permission
+		expectErr bool
+	}{
+		{
+			name:    "granted",
+			granted: []enum.Permission{enum.PermissionSecretAccess},
+		},
+		{
+			name:      "blocked",
+			blocked:   true,
+			granted:   []enum.Permission{enum.PermissionSecretAccess},
+			expectErr: true,
+		},
+		{
+			name:      "lost secret access",
+			granted:   []enum.Permission{enum.PermissionSecretView, enum.PermissionArtifactsUpload},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{authorizer: testAuthorizer{permissions: test.granted}}
+			principal := &types.Principal{ID: 1, UID: "user", Type: enum.PrincipalTypeUser, Blocked: test.blocked}
+
+			err := s.checkSecretAccess(ctx, principal, space, "jfrog-token")
+			if test.expectErr != (err != nil) {
+				t.Errorf("expected error=%t, got %v", test.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+
+	"github.com/harness/gitness/registry/types"
+)
+
+// ServiceInterface interface for replication operations.
+type ServiceInterface interface {
+	StartSync(ctx context.Context, rule *types.ReplicationRule, principalID int64) error
+	StopSync(ctx context.Context, rule *types.ReplicationRule) error
+}
@@ -0,0 +1,259 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/registry/types"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "registry_replication"
+	jobMaxRetries  = 0
+	jobMaxDuration = 12 * time.Hour
+	listPageSize   = 100
+)
+
+var ErrSyncInProgress = errors.New("replication of the rule is already in progress")
+
+var _ job.Handler = (*Service)(nil)
+
+type syncInput struct {
+	RuleID      int64 `json:"rule_id"`
+	PrincipalID int64 `json:"principal_id"`
+}
+
+type imageReference struct {
+	image     string
+	reference string
+}
+
+// JobIDFromRuleID returns the ID of the full synchronisation job of a replication rule.
+func JobIDFromRuleID(ruleID int64) string {
+	return "registry-replication-" + strconv.FormatInt(ruleID, 10)
+}
+
+// StartSync starts a background job that replicates all matching artifacts of the rule's source.
+func (s *Service) StartSync(ctx context.Context, rule *types.ReplicationRule, principalID int64) error {
+	jobUID := JobIDFromRuleID(rule.ID)
+
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	switch {
+	case errors.Is(err, gitnessstore.ErrResourceNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to get replication job progress: %w", err)
+	case progress.State == job.JobStateScheduled || progress.State == job.JobStateRunning:
+		return ErrSyncInProgress
+	default:
+		// the job UID is deterministic, so the previous run has to be removed first.
+		if err = s.scheduler.PurgeJobByUID(ctx, jobUID); err != nil {
+			return fmt.Errorf("failed to purge previous replication job: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(syncInput{RuleID: rule.ID, PrincipalID: principalID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal replication job input json: %w", err)
+	}
+
+	return s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       jobType,
+		MaxRetries: jobMaxRetries,
+		Timeout:    jobMaxDuration,
+		Data:       string(data),
+	})
+}
+
+// StopSync cancels the synchronisation job of the rule and marks all of its unfinished executions as stopped.
+func (s *Service) StopSync(ctx context.Context, rule *types.ReplicationRule) error {
+	if err := s.scheduler.CancelJob(ctx, JobIDFromRuleID(rule.ID)); err != nil {
+		return fmt.Errorf("failed to cancel replication job: %w", err)
+	}
+
+	if _, err := s.executionStore.StopPending(ctx, rule.ID); err != nil {
+		return fmt.Errorf("failed to stop pending replication executions: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the full synchronisation background job handler.
+func (s *Service) Handle(ctx context.Context, data string, progressReporter job.ProgressReporter) (string, error) {
+	var input syncInput
+	if err := json.NewDecoder(strings.NewReader(data)).Decode(&input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal replication job input json: %w", err)
+	}
+
+	rule, err := s.ruleStore.Find(ctx, input.RuleID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return "replication rule no longer exists", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find replication rule: %w", err)
+	}
+
+	refs, err := s.listSourceReferences(ctx, rule)
+	if err != nil {
+		return "", fmt.Errorf("failed to list artifacts of the replication source: %w", err)
+	}
+
+	// all executions are created upfront so the whole synchronisation is visible right away.
+	executions := make([]*types.ReplicationExecution, 0, len(refs))
+	for _, ref := range refs {
+		if !IsAllowed(rule, ref.image, ref.reference) {
+			continue
+		}
+
+		execution := &types.ReplicationExecution{
+			RuleID:    rule.ID,
+			Trigger:   types.ReplicationTriggerManual,
+			Image:     ref.image,
+			Reference: ref.reference,
+			Status:    types.ReplicationExecutionStatusPending,
+			CreatedBy: input.PrincipalID,
+		}
+		if err = s.executionStore.Create(ctx, execution); err != nil {
+			return "", fmt.Errorf("failed to create replication execution: %w", err)
+		}
+		executions = append(executions, execution)
+	}
+
+	failed := 0
+	for i, execution := range executions {
+		if ctx.Err() != nil {
+			if _, err = s.executionStore.StopPending(context.WithoutCancel(ctx), rule.ID); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to stop pending replication executions")
+			}
+			return "", ctx.Err()
+		}
+
+		if err = s.execute(ctx, rule, execution); err != nil {
+			failed++
+			log.Ctx(ctx).Warn().Err(err).
+				Msgf("failed to replicate %s:%s for replication rule %s",
+					execution.Image, execution.Reference, rule.Identifier)
+		}
+
+		if err = progressReporter((i+1)*job.ProgressMax/len(executions), ""); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to report replication job progress")
+		}
+	}
+
+	return fmt.Sprintf("replicated %d of %d artifacts", len(executions)-failed, len(executions)), nil
+}
+
+func (s *Service) listSourceReferences(ctx context.Context, rule *types.ReplicationRule) ([]imageReference, error) {
+	if rule.SourceType == types.ReplicationEndpointTypeLocal {
+		return s.listLocalReferences(ctx, rule.Source.RegistryID)
+	}
+
+	client, _, err := s.endpointClient(ctx, rule, rule.SourceType, rule.Source, "", enum.PermissionArtifactsDownload)
+	if err != nil {
+		return nil, err
+	}
+
+	repositories, err := client.Catalog(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	prefix := ""
+	if rule.Source.Namespace != "" {
+		prefix = strings.Trim(rule.Source.Namespace, "/") + "/"
+	}
+
+	var refs []imageReference
+	for _, repository := range repositories {
+		if !strings.HasPrefix(repository, prefix) {
+			continue
+		}
+
+		tags, err := client.ListTags(ctx, repository)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of %s: %w", repository, err)
+		}
+
+		image := strings.TrimPrefix(repository, prefix)
+		for _, tag := range tags {
+			refs = append(refs, imageReference{image: image, reference: tag})
+		}
+	}
+
+	return refs, nil
+}
+
+func (s *Service) listLocalReferences(ctx context.Context, registryID int64) ([]imageReference, error) {
+	reg, err := s.registryDao.Get(ctx, registryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find registry %d: %w", registryID, err)
+	}
+
+	var refs []imageReference
+	for offset := 0; ; offset += listPageSize {
+		artifacts, err := s.tagDao.GetAllArtifactsByRepo(
+			ctx, reg.ParentID, reg.Name, "image_name", "ASC", listPageSize, offset, "", nil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list images: %w", err)
+		}
+
+		for _, a := range *artifacts {
+			tags, err := s.listLocalTags(ctx, reg.ParentID, reg.Name, a.Name)
+			if err != nil {
+				return nil, err
+			}
+			for _, tag := range tags {
+				refs = append(refs, imageReference{image: a.Name, reference: tag})
+			}
+		}
+
+		if len(*artifacts) < listPageSize {
+			return refs, nil
+		}
+	}
+}
+
+func (s *Service) listLocalTags(ctx context.Context, parentID int64, registryName, image string) ([]string, error) {
+	var tags []string
+	for offset := 0; ; offset += listPageSize {
+		page, err := s.tagDao.GetAllTagsByRepoAndImage(
+			ctx, parentID, registryName, image, "name", "ASC", listPageSize, offset, "",
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of %s: %w", image, err)
+		}
+
+		for _, tag := range *page {
+			tags = append(tags, tag.Name)
+		}
+
+		if len(*page) < listPageSize {
+			return tags, nil
+		}
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	corestore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
+	artifactevents "github.com/harness/gitness/registry/app/events/artifact"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/registry/types"
+	"github.com/harness/gitness/secret"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:registry:replication"
+)
+
+// Verify Service implements ServiceInterface.
+var _ ServiceInterface = (*Service)(nil)
+
+// Service replicates OCI artifacts between registries according to the configured replication rules.
+// Pushes to a local source registry are replicated right away, full synchronisations run as background jobs.
+type Service struct {
+	config         Config
+	ruleStore      store.ReplicationRuleRepository
+	executionStore store.ReplicationExecutionRepository
+	registryDao    store.RegistryRepository
+	tagDao         store.TagRepository
+	spaceFinder    refcache.SpaceFinder
+	principalStore corestore.PrincipalStore
+	authorizer     authz.Authorizer
+	secretService  secret.Service
+	scheduler      *job.Scheduler
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	artifactsReaderFactory *events.ReaderFactory[*artifactevents.Reader],
+	ruleStore store.ReplicationRuleRepository,
+	executionStore store.ReplicationExecutionRepository,
+	registryDao store.RegistryRepository,
+	tagDao store.TagRepository,
+	spaceFinder refcache.SpaceFinder,
+	principalStore corestore.PrincipalStore,
+	authorizer authz.Authorizer,
+	secretService secret.Service,
+	scheduler *job.Scheduler,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided replication service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:         config,
+		ruleStore:      ruleStore,
+		executionStore: executionStore,
+		registryDao:    registryDao,
+		tagDao:         tagDao,
+		spaceFinder:    spaceFinder,
+		principalStore: principalStore,
+		authorizer:     authorizer,
+		secretService:  secretService,
+		scheduler:      scheduler,
+	}
+
+	_, err := artifactsReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *artifactevents.Reader) error {
+			const idleTimeout = 1 * time.Hour
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterArtifactCreated(service.handleEventArtifactCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch registry event reader for replication: %w", err)
+	}
+
+	return service, nil
+}
+
+type Config struct {
+	EventReaderName string
+	// InternalURL is the base URL through which the local OCI registry is reachable.
+	InternalURL string
+	Concurrency int
+	MaxRetries  int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("Config.EventReaderName is required")
+	}
+	if c.InternalURL == "" {
+		return errors.New("Config.InternalURL is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("Config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("Config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// handleEventArtifactCreated replicates a freshly pushed image to the destination of every
+// replication rule that uses the registry as its source.
+func (s *Service) handleEventArtifactCreated(
+	ctx context.Context,
+	event *events.Event[*artifactevents.ArtifactCreatedPayload],
+) error {
+	image, reference, ok := ociReference(event.Payload.Artifact)
+	if !ok {
+		return nil
+	}
+
+	rules, err := s.ruleStore.ListBySourceRegistry(ctx, event.Payload.RegistryID)
+	if err != nil {
+		return fmt.Errorf("failed to list replication rules for registry %d: %w", event.Payload.RegistryID, err)
+	}
+
+	for _, rule := range rules {
+		if !IsAllowed(rule, image, reference) {
+			continue
+		}
+
+		err = s.Replicate(ctx, rule, types.ReplicationTriggerEvent, event.Payload.PrincipalID, image, reference)
+		if err != nil {
+			// the failure is recorded on the execution, retrying the event would only duplicate it.
+			log.Ctx(ctx).Warn().Err(err).
+				Msgf("failed to replicate %s:%s for replication rule %s", image, reference, rule.Identifier)
+		}
+	}
+
+	return nil
+}
+
+// Replicate copies a single image reference according to the provided rule
+// and records the outcome as a new replication execution.
+func (s *Service) Replicate(
+	ctx context.Context,
+	rule *types.ReplicationRule,
+	trigger types.ReplicationTrigger,
+	principalID int64,
+	image string,
+	reference string,
+) error {
+	execution := &types.ReplicationExecution{
+		RuleID:    rule.ID,
+		Trigger:   trigger,
+		Image:     image,
+		Reference: reference,
+		Status:    types.ReplicationExecutionStatusPending,
+		CreatedBy: principalID,
+	}
+	if err := s.executionStore.Create(ctx, execution); err != nil {
+		return fmt.Errorf("failed to create replication execution: %w", err)
+	}
+
+	return s.execute(ctx, rule, execution)
+}
+
+func (s *Service) execute(
+	ctx context.Context,
+	rule *types.ReplicationRule,
+	execution *types.ReplicationExecution,
+) error {
+	logs := &executionLog{}
+
+	execution.Status = types.ReplicationExecutionStatusRunning
+	if err := s.executionStore.Update(ctx, execution); err != nil {
+		return fmt.Errorf("failed to update replication execution: %w", err)
+	}
+
+	digest, err := s.copy(ctx, rule, execution.Image, execution.Reference, logs)
+	switch {
+	case ctx.Err() != nil:
+		logs.Printf("replication stopped")
+		execution.Status = types.ReplicationExecutionStatusStopped
+	case err != nil:
+		logs.Printf("replication failed: %s", err)
+		execution.Status = types.ReplicationExecutionStatusFailure
+	default:
+		logs.Printf("replication finished")
+		execution.Status = types.ReplicationExecutionStatusSuccess
+		execution.Progress = 100
+	}
+	execution.Digest = digest
+	execution.Log = logs.String()
+
+	// the execution outcome has to be stored even if the replication got canceled.
+	if uErr := s.executionStore.Update(context.WithoutCancel(ctx), execution); uErr != nil {
+		return fmt.Errorf("failed to update replication execution: %w", uErr)
+	}
+
+	return err
+}
+
+func (s *Service) copy(
+	ctx context.Context,
+	rule *types.ReplicationRule,
+	image string,
+	reference string,
+	logs *executionLog,
+) (string, error) {
+	src, srcRepo, err := s.endpointClient(ctx, rule, rule.SourceType, rule.Source, image,
+		enum.PermissionArtifactsDownload)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare replication source: %w", err)
+	}
+
+	dst, dstRepo, err := s.endpointClient(ctx, rule, rule.DestinationType, rule.Destination, image,
+		enum.PermissionArtifactsDownload, enum.PermissionArtifactsUpload)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare replication destination: %w", err)
+	}
+
+	logs.Printf("replicating %s:%s to %s:%s", srcRepo, reference, dstRepo, reference)
+
+	return copyArtifact(ctx, src, srcRepo, dst, dstRepo, reference, logs)
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	corestore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
+	artifactevents "github.com/harness/gitness/registry/app/events/artifact"
+	"github.com/harness/gitness/registry/app/store"
+	"github.com/harness/gitness/secret"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideReplicationConfig,
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config Config,
+	artifactsReaderFactory *events.ReaderFactory[*artifactevents.Reader],
+	ruleStore store.ReplicationRuleRepository,
+	executionStore store.ReplicationExecutionRepository,
+	registryDao store.RegistryRepository,
+	tagDao store.TagRepository,
+	spaceFinder refcache.SpaceFinder,
+	principalStore corestore.PrincipalStore,
+	authorizer authz.Authorizer,
+	secretService secret.Service,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	service, err := NewService(
+		ctx,
+		config,
+		artifactsReaderFactory,
+		ruleStore,
+		executionStore,
+		registryDao,
+		tagDao,
+		spaceFinder,
+		principalStore,
+		authorizer,
+		secretService,
+		scheduler,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = executor.Register(jobType, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func ProvideReplicationConfig(config *types.Config) Config {
+	return Config{
+		EventReaderName: config.InstanceID,
+		InternalURL:     config.URL.Internal,
+		Concurrency:     config.Registry.Replication.Concurrency,
+		MaxRetries:      config.Registry.Replication.MaxRetries,
+	}
+}
@@ -28,10 +28,12 @@ type CleanupPolicy struct {
 	VersionPrefix []string
 	PackagePrefix []string
 	ExpiryTime    int64
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	CreatedBy     int64
-	UpdatedBy     int64
+	// KeepVersions is the number of most recent versions per package that are never cleaned up.
+	KeepVersions int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	CreatedBy    int64
+	UpdatedBy    int64
 }
 
 // CleanupPolicyPrefix DTO object.
@@ -41,3 +43,13 @@ type CleanupPolicyPrefix struct {
 	Prefix          string
 	PrefixType      enum.PrefixType
 }
+
+// CleanupCandidate is an artifact version matched by a CleanupPolicy.
+type CleanupCandidate struct {
+	Package      string
+	Version      string
+	Digest       string
+	Policy       string
+	Reason       string
+	LastModified time.Time
+}
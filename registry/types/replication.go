@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"time"
+)
+
+type ReplicationEndpointType string
+
+const (
+	ReplicationEndpointTypeLocal ReplicationEndpointType = "Local"
+	ReplicationEndpointTypeJfrog ReplicationEndpointType = "Jfrog"
+	ReplicationEndpointTypeGCP   ReplicationEndpointType = "GCP"
+)
+
+type ReplicationExecutionStatus string
+
+const (
+	ReplicationExecutionStatusPending ReplicationExecutionStatus = "pending"
+	ReplicationExecutionStatusRunning ReplicationExecutionStatus = "running"
+	ReplicationExecutionStatusSuccess ReplicationExecutionStatus = "success"
+	ReplicationExecutionStatusFailure ReplicationExecutionStatus = "failure"
+	ReplicationExecutionStatusStopped ReplicationExecutionStatus = "stopped"
+)
+
+type ReplicationTrigger string
+
+const (
+	// ReplicationTriggerEvent marks executions started by an artifact push to the source registry.
+	ReplicationTriggerEvent ReplicationTrigger = "event"
+	// ReplicationTriggerManual marks executions started by a full synchronisation of the rule.
+	ReplicationTriggerManual ReplicationTrigger = "manual"
+)
+
+// ReplicationEndpoint describes one side of a replication rule.
+// Local endpoints only use RegistryID, remote endpoints use the remaining fields.
+type ReplicationEndpoint struct {
+	RegistryID    int64  `json:"registry_id,omitempty"`
+	URL           string `json:"url,omitempty"`
+	Namespace     string `json:"namespace,omitempty"`
+	Username      string `json:"username,omitempty"`
+	SecretID      string `json:"secret_identifier,omitempty"`
+	SecretSpaceID int64  `json:"secret_space_id,omitempty"`
+}
+
+// ReplicationRule DTO object.
+type ReplicationRule struct {
+	ID              int64
+	Identifier      string
+	SpaceID         int64
+	SourceType      ReplicationEndpointType
+	Source          ReplicationEndpoint
+	DestinationType ReplicationEndpointType
+	Destination     ReplicationEndpoint
+	AllowedPatterns []string
+	BlockedPatterns []string
+	CreatedBy       int64
+	UpdatedBy       int64
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ReplicationExecution is a single replication of one image reference performed for a rule.
+type ReplicationExecution struct {
+	ID        int64
+	RuleID    int64
+	Trigger   ReplicationTrigger
+	Image     string
+	Reference string
+	Digest    string
+	Status    ReplicationExecutionStatus
+	Progress  int
+	Log       string
+	CreatedBy int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+)
+
+// AuditEvent is a persisted audit log entry.
+// The Action and ResourceType hold the values of audit.Action and audit.ResourceType.
+type AuditEvent struct {
+	ID         int64  `json:"id"`
+	Identifier string `json:"identifier"`
+	Timestamp  int64  `json:"timestamp"`
+
+	Action             string            `json:"action"`
+	ResourceType       string            `json:"resource_type"`
+	ResourceIdentifier string            `json:"resource_identifier"`
+	ResourceData       map[string]string `json:"resource_data,omitempty"`
+	SpacePath          string            `json:"space_path"`
+
+	// Principal holds the principal's info as it was at the time of the event.
+	Principal PrincipalInfo `json:"principal"`
+
+	OldObject json.RawMessage `json:"old_object,omitempty"`
+	NewObject json.RawMessage `json:"new_object,omitempty"`
+
+	ClientIP      string            `json:"client_ip,omitempty"`
+	RequestMethod string            `json:"request_method,omitempty"`
+	Data          map[string]string `json:"data,omitempty"`
+}
+
+// AuditEventFilter stores audit event query parameters.
+type AuditEventFilter struct {
+	Pagination
+	CreatedFilter
+
+	// SpacePath limits the events to the space. If Recursive is set, the events of all its subspaces are included.
+	SpacePath string `json:"space_path"`
+	Recursive bool   `json:"recursive"`
+
+	ResourceTypes []string `json:"resource_types"`
+	Actions       []string `json:"actions"`
+	PrincipalIDs  []int64  `json:"principal_ids"`
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// AutoMerge holds the merge details of a pull request that is merged automatically
+// once all its merge requirements are satisfied.
+type AutoMerge struct {
+	PullReqID int64 `json:"-"`
+	RepoID    int64 `json:"-"`
+
+	MergeMethod        enum.MergeMethod `json:"merge_method"`
+	Title              string           `json:"title"`
+	Message            string           `json:"message"`
+	DeleteSourceBranch bool             `json:"delete_source_branch"`
+
+	// DisableOnPush disables the auto-merge when new commits are pushed to the source branch.
+	DisableOnPush bool `json:"disable_on_push"`
+	// SourceSHA is the commit of the source branch at the time auto-merge was enabled.
+	SourceSHA string `json:"source_sha"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
@@ -105,9 +105,12 @@ type PullReqChecks struct {
 }
 
 type PullReqCheck struct {
-	Required   bool  `json:"required"`
-	Bypassable bool  `json:"bypassable"`
-	Check      Check `json:"check"`
+	Required   bool `json:"required"`
+	Bypassable bool `json:"bypassable"`
+	// Missing is true for the required status checks that have not been reported for the commit.
+	// The identifier of a missing status check can be a glob pattern.
+	Missing bool  `json:"missing"`
+	Check   Check `json:"check"`
 }
 
 type CheckCountSummary struct {
@@ -186,10 +186,10 @@ type Config struct {
 	BlobStore struct {
 		// MaxFileSize defines the maximum size of files that can be uploaded (in bytes)
 		MaxFileSize int64 `envconfig:"GITNESS_BLOBSTORE_MAX_FILE_SIZE" default:"10485760"` // 10MB default
-		// Provider is a name of blob storage service like filesystem or gcs or cloudflare
+		// Provider is a name of blob storage service like filesystem, gcs or s3
 		Provider blob.Provider `envconfig:"GITNESS_BLOBSTORE_PROVIDER" default:"filesystem"`
 		// Bucket is a path to the directory where the files will be stored when using filesystem blob storage,
-		// in case of gcs provider this will be the actual bucket where the images are stored.
+		// in case of gcs or s3 provider this will be the actual bucket where the files are stored.
 		Bucket string `envconfig:"GITNESS_BLOBSTORE_BUCKET"`
 
 		// In case of GCS provider, this is expected to be the path to the service account key file.
@@ -199,12 +199,143 @@ type Config struct {
 		TargetPrincipal string `envconfig:"GITNESS_BLOBSTORE_TARGET_PRINCIPAL" default:""`
 
 		ImpersonationLifetime time.Duration `envconfig:"GITNESS_BLOBSTORE_IMPERSONATION_LIFETIME" default:"12h"`
+
+		// S3 defines the configuration of the s3 provider, which works with AWS S3 and S3 compatible storages.
+		S3 struct {
+			// Endpoint overrides the default AWS S3 endpoint, e.g. when using MinIO.
+			Endpoint     string `envconfig:"GITNESS_BLOBSTORE_S3_ENDPOINT"`
+			Region       string `envconfig:"GITNESS_BLOBSTORE_S3_REGION" default:"us-east-1"`
+			AccessKey    string `envconfig:"GITNESS_BLOBSTORE_S3_ACCESS_KEY"`
+			SecretKey    string `envconfig:"GITNESS_BLOBSTORE_S3_SECRET_KEY"`
+			SessionToken string `envconfig:"GITNESS_BLOBSTORE_S3_SESSION_TOKEN"`
+			PathStyle    bool   `envconfig:"GITNESS_BLOBSTORE_S3_PATH_STYLE" default:"false"`
+			DisableSSL   bool   `envconfig:"GITNESS_BLOBSTORE_S3_DISABLE_SSL" default:"false"`
+			SkipVerify   bool   `envconfig:"GITNESS_BLOBSTORE_S3_SKIP_VERIFY" default:"false"`
+
+			// Encrypt enables server-side encryption. SSE-KMS is used if KMSKeyID is set, SSE-S3 otherwise.
+			Encrypt  bool   `envconfig:"GITNESS_BLOBSTORE_S3_ENCRYPT" default:"false"`
+			KMSKeyID string `envconfig:"GITNESS_BLOBSTORE_S3_KMS_KEY_ID"`
+		}
 	}
 
 	// Token defines token configuration parameters.
 	Token struct {
 		CookieName string        `envconfig:"GITNESS_TOKEN_COOKIE_NAME" default:"token"`
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
+		// ClientIPHeader is the request header used to determine the client IP address when checking
+		// the IP ranges of scoped tokens. It must only be set when running behind a trusted proxy,
+		// otherwise the address of the connection is used. Single value headers that the proxy overwrites
+		// (e.g. "X-Real-IP") are the safe choice. For list headers that proxies append to
+		// (e.g. "X-Forwarded-For") entries are taken from the right, as the leftmost ones are client provided.
+		ClientIPHeader string `envconfig:"GITNESS_TOKEN_CLIENT_IP_HEADER"`
+		// ClientIPTrustedHops is the number of trusted proxies appending to the client IP header.
+		// The client IP is the entry at this position counted from the right.
+		ClientIPTrustedHops int `envconfig:"GITNESS_TOKEN_CLIENT_IP_TRUSTED_HOPS" default:"1"`
+	}
+
+	// OIDC defines the configuration of the OpenID Connect single sign-on.
+	OIDC struct {
+		Enabled bool `envconfig:"GITNESS_OIDC_ENABLED" default:"false"`
+
+		// ProviderName is the name of the identity provider shown to the users on the login page.
+		ProviderName string `envconfig:"GITNESS_OIDC_PROVIDER_NAME" default:"SSO"`
+
+		// Issuer is the URL of the OpenID provider, it's used to discover the provider configuration.
+		Issuer       string   `envconfig:"GITNESS_OIDC_ISSUER"`
+		ClientID     string   `envconfig:"GITNESS_OIDC_CLIENT_ID"`
+		ClientSecret string   `envconfig:"GITNESS_OIDC_CLIENT_SECRET"`
+		Scopes       []string `envconfig:"GITNESS_OIDC_SCOPES" default:"openid,email,profile"`
+
+		// RedirectURL is the callback URL registered with the provider.
+		// Value is derived from URL.API unless explicitly specified
+		// (e.g. http://localhost:3000/api/v1/login/oidc/callback).
+		RedirectURL string `envconfig:"GITNESS_OIDC_REDIRECT_URL"`
+
+		// AutoProvision specifies whether unknown users are created on their first login.
+		AutoProvision bool `envconfig:"GITNESS_OIDC_AUTO_PROVISION" default:"true"`
+
+		// LinkByEmail specifies whether an existing user is linked to the identity with the same verified email.
+		LinkByEmail bool `envconfig:"GITNESS_OIDC_LINK_BY_EMAIL" default:"true"`
+
+		UsernameClaim string `envconfig:"GITNESS_OIDC_USERNAME_CLAIM" default:"preferred_username"`
+		GroupsClaim   string `envconfig:"GITNESS_OIDC_GROUPS_CLAIM" default:"groups"`
+
+		// AdminGroups are the groups whose members are system administrators.
+		// The admin status of a user is synced on every login if the value is provided.
+		AdminGroups []string `envconfig:"GITNESS_OIDC_ADMIN_GROUPS"`
+
+		// RoleMappings grant space memberships to the members of a group on login.
+		// Each mapping is in the format "group=space/path:role" (e.g. "developers=acme:contributor").
+		RoleMappings []string `envconfig:"GITNESS_OIDC_ROLE_MAPPINGS"`
+	}
+
+	// LDAP defines the configuration of the LDAP authentication and the directory sync.
+	LDAP struct {
+		Enabled bool `envconfig:"GITNESS_LDAP_ENABLED" default:"false"`
+
+		// URL of the LDAP server (e.g. ldap://localhost:389 or ldaps://localhost:636).
+		URL                string `envconfig:"GITNESS_LDAP_URL"`
+		StartTLS           bool   `envconfig:"GITNESS_LDAP_START_TLS" default:"false"`
+		InsecureSkipVerify bool   `envconfig:"GITNESS_LDAP_INSECURE_SKIP_VERIFY" default:"false"`
+
+		// BindDN and BindPassword are the credentials of the service account used to search the directory.
+		BindDN       string `envconfig:"GITNESS_LDAP_BIND_DN"`
+		BindPassword string `envconfig:"GITNESS_LDAP_BIND_PASSWORD"`
+
+		UserBaseDN string `envconfig:"GITNESS_LDAP_USER_BASE_DN"`
+		// UserFilter finds the user that logs in, %s is replaced with the escaped login identifier.
+		UserFilter string `envconfig:"GITNESS_LDAP_USER_FILTER" default:"(&(objectClass=inetOrgPerson)(uid=%s))"`
+		// UserListFilter finds all users of the directory, it's used by the sync to detect removed users.
+		UserListFilter string `envconfig:"GITNESS_LDAP_USER_LIST_FILTER" default:"(objectClass=inetOrgPerson)"`
+		// UniqueIDAttribute is an immutable attribute that identifies the user (e.g. entryUUID or objectGUID).
+		// The DN of the user is used if the value isn't provided.
+		UniqueIDAttribute    string `envconfig:"GITNESS_LDAP_UNIQUE_ID_ATTRIBUTE"`
+		UsernameAttribute    string `envconfig:"GITNESS_LDAP_USERNAME_ATTRIBUTE" default:"uid"`
+		EmailAttribute       string `envconfig:"GITNESS_LDAP_EMAIL_ATTRIBUTE" default:"mail"`
+		DisplayNameAttribute string `envconfig:"GITNESS_LDAP_DISPLAY_NAME_ATTRIBUTE" default:"cn"`
+
+		// GroupBaseDN is the base of the group search, UserBaseDN is used if the value isn't provided.
+		GroupBaseDN          string `envconfig:"GITNESS_LDAP_GROUP_BASE_DN"`
+		GroupFilter          string `envconfig:"GITNESS_LDAP_GROUP_FILTER" default:"(objectClass=groupOfNames)"`
+		GroupNameAttribute   string `envconfig:"GITNESS_LDAP_GROUP_NAME_ATTRIBUTE" default:"cn"`
+		GroupMemberAttribute string `envconfig:"GITNESS_LDAP_GROUP_MEMBER_ATTRIBUTE" default:"member"`
+
+		// AutoProvision specifies whether unknown users are created on their first login.
+		AutoProvision bool `envconfig:"GITNESS_LDAP_AUTO_PROVISION" default:"true"`
+
+		// LinkByEmail specifies whether an existing user is linked to the directory account with the same email.
+		LinkByEmail bool `envconfig:"GITNESS_LDAP_LINK_BY_EMAIL" default:"true"`
+
+		// AdminGroups are the groups whose members are system administrators.
+		AdminGroups []string `envconfig:"GITNESS_LDAP_ADMIN_GROUPS"`
+
+		// RoleMappings mirror the mapped groups into user groups of the space and grant the members
+		// a space membership. Each mapping is in the format "group=space/path:role" (e.g. "developers=acme:contributor").
+		RoleMappings []string `envconfig:"GITNESS_LDAP_ROLE_MAPPINGS"`
+
+		// SyncCRON schedules the job that mirrors the directory groups and deprovisions removed users.
+		SyncCRON        string        `envconfig:"GITNESS_LDAP_SYNC_CRON" default:"*/30 * * * *"`
+		SyncMaxDuration time.Duration `envconfig:"GITNESS_LDAP_SYNC_MAX_DURATION" default:"10m"`
+
+		// DeprovisionAction is applied to users that were removed from the directory, either "block" or "delete".
+		DeprovisionAction string `envconfig:"GITNESS_LDAP_DEPROVISION_ACTION" default:"block"`
+	}
+
+	// SCIM defines the configuration of the SCIM 2.0 provisioning endpoint (/api/v1/scim/v2).
+	SCIM struct {
+		Enabled bool `envconfig:"GITNESS_SCIM_ENABLED" default:"false"`
+
+		// ServiceAccountUID is the UID of the service account whose token the identity provider uses.
+		// The provisioned groups are created as user groups of the parent space of the service account.
+		ServiceAccountUID string `envconfig:"GITNESS_SCIM_SERVICE_ACCOUNT_UID"`
+
+		// RoleMappings grant the members of the provisioned groups a space membership.
+		// Each mapping is in the format "group=space/path:role" (e.g. "developers=acme:contributor").
+		RoleMappings []string `envconfig:"GITNESS_SCIM_ROLE_MAPPINGS"`
+
+		// LinkByEmail specifies whether a provisioned user is linked to an existing user with the same email.
+		// Linked users are never modified through SCIM, and admin users are never linked.
+		LinkByEmail bool `envconfig:"GITNESS_SCIM_LINK_BY_EMAIL" default:"false"`
 	}
 
 	Logs struct {
@@ -343,6 +474,19 @@ type Config struct {
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime  time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
 		InternalSecret string        `envconfig:"GITNESS_WEBHOOK_INTERNAL_SECRET"`
+
+		// RetryMaxAttempts is the max number of delivery attempts of an event (1 disables automatic retries).
+		RetryMaxAttempts int `envconfig:"GITNESS_WEBHOOK_RETRY_MAX_ATTEMPTS" default:"5"`
+		// RetryBackoffBase is the delay before the first retry, it doubles with every further attempt.
+		RetryBackoffBase time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_BACKOFF_BASE" default:"30s"`
+		// RetryBackoffMax is the max delay between two attempts (also caps the Retry-After response header).
+		RetryBackoffMax time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_BACKOFF_MAX" default:"1h"`
+		// RetryCRON schedules the job that executes all due retries.
+		RetryCRON        string        `envconfig:"GITNESS_WEBHOOK_RETRY_CRON" default:"* * * * *"`
+		RetryMaxDuration time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_MAX_DURATION" default:"5m"`
+		// AutoDisableThreshold is the number of failed deliveries in a row after which a webhook gets disabled.
+		// A value of 0 disables the auto-disabling of webhooks.
+		AutoDisableThreshold int `envconfig:"GITNESS_WEBHOOK_AUTO_DISABLE_THRESHOLD" default:"20"`
 	}
 
 	Trigger struct {
@@ -350,6 +494,26 @@ type Config struct {
 		MaxRetries  int `envconfig:"GITNESS_TRIGGER_MAX_RETRIES" default:"3"`
 	}
 
+	MergeQueue struct {
+		Concurrency int `envconfig:"GITNESS_MERGE_QUEUE_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_MERGE_QUEUE_MAX_RETRIES" default:"3"`
+		// CRON schedules the job that periodically advances all merge queues.
+		// Required because the status checks reported by pipelines don't publish check events.
+		CRON        string        `envconfig:"GITNESS_MERGE_QUEUE_CRON" default:"* * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_MERGE_QUEUE_MAX_DURATION" default:"5m"`
+	}
+
+	AutoMerge struct {
+		Concurrency int `envconfig:"GITNESS_AUTO_MERGE_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_AUTO_MERGE_MAX_RETRIES" default:"3"`
+		// CRON schedules the job that periodically re-evaluates all pull requests with auto-merge enabled.
+		// Required because the status checks reported by pipelines don't publish check events.
+		CRON        string        `envconfig:"GITNESS_AUTO_MERGE_CRON" default:"* * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_AUTO_MERGE_MAX_DURATION" default:"5m"`
+		// DisableOnPush is the default for disabling auto-merge when new commits are pushed to the source branch.
+		DisableOnPush bool `envconfig:"GITNESS_AUTO_MERGE_DISABLE_ON_PUSH" default:"false"`
+	}
+
 	Branch struct {
 		Concurrency int `envconfig:"GITNESS_BRANCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_BRANCH_MAX_RETRIES" default:"3"`
@@ -397,6 +561,10 @@ type Config struct {
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+		// IndexDir is the directory of the local code search index (defaults to a folder in the git root).
+		IndexDir string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_DIR"`
+		// MaxFileSize is the size (in bytes) above which files are excluded from the local code search index.
+		MaxFileSize int64 `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_FILE_SIZE" default:"1048576"`
 	}
 
 	Repos struct {
@@ -567,6 +735,17 @@ type Config struct {
 			MaxRetries    int  `envconfig:"GITNESS_REGISTRY_POST_PROCESSING_MAX_RETRIES" default:"3"`
 			AllowLoopback bool `envconfig:"GITNESS_REGISTRY_POST_PROCESSING_ALLOW_LOOPBACK" default:"false"`
 		}
+
+		Replication struct {
+			Concurrency int `envconfig:"GITNESS_REGISTRY_REPLICATION_CONCURRENCY" default:"4"`
+			MaxRetries  int `envconfig:"GITNESS_REGISTRY_REPLICATION_MAX_RETRIES" default:"3"`
+		}
+
+		CleanupPolicy struct {
+			Enabled     bool          `envconfig:"GITNESS_REGISTRY_CLEANUP_POLICY_ENABLED" default:"true"`
+			Cron        string        `envconfig:"GITNESS_REGISTRY_CLEANUP_POLICY_CRON" default:"0 3 * * *"`
+			MaxDuration time.Duration `envconfig:"GITNESS_REGISTRY_CLEANUP_POLICY_MAX_DURATION" default:"2h"`
+		}
 	}
 
 	Instrumentation struct {
@@ -0,0 +1,31 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AutoMergeAction defines the action recorded in a pull request activity of auto-merge.
+type AutoMergeAction string
+
+func (AutoMergeAction) Enum() []interface{} { return toInterfaceSlice(autoMergeActions) }
+
+// AutoMergeAction enumeration.
+const (
+	AutoMergeActionEnabled  AutoMergeAction = "enabled"
+	AutoMergeActionDisabled AutoMergeAction = "disabled"
+)
+
+var autoMergeActions = sortEnum([]AutoMergeAction{
+	AutoMergeActionEnabled,
+	AutoMergeActionDisabled,
+})
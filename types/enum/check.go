@@ -73,6 +73,30 @@ var checkPayloadTypes = sortEnum([]CheckPayloadKind{
 	CheckPayloadKindPipeline,
 })
 
+// CheckMissingPolicy defines how the required status checks that were never reported are treated.
+type CheckMissingPolicy string
+
+func (CheckMissingPolicy) Enum() []interface{} { return toInterfaceSlice(checkMissingPolicies) }
+func (p CheckMissingPolicy) Sanitize() (CheckMissingPolicy, bool) {
+	return Sanitize(p, GetAllCheckMissingPolicies)
+}
+func GetAllCheckMissingPolicies() ([]CheckMissingPolicy, CheckMissingPolicy) {
+	return checkMissingPolicies, CheckMissingPolicyBlock
+}
+
+// CheckMissingPolicy enumeration.
+const (
+	// CheckMissingPolicyBlock blocks the merge until all required status checks are reported.
+	CheckMissingPolicyBlock CheckMissingPolicy = "block"
+	// CheckMissingPolicySkip ignores the required status checks that were never reported.
+	CheckMissingPolicySkip CheckMissingPolicy = "skip"
+)
+
+var checkMissingPolicies = sortEnum([]CheckMissingPolicy{
+	CheckMissingPolicyBlock,
+	CheckMissingPolicySkip,
+})
+
 func (s CheckStatus) IsCompleted() bool {
 	return slices.Contains(terminalCheckStatuses, s)
 }
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// IdentityProvider defines the supported types of external identity providers.
+type IdentityProvider string
+
+func (IdentityProvider) Enum() []interface{} { return toInterfaceSlice(identityProviders) }
+func (p IdentityProvider) Sanitize() (IdentityProvider, bool) {
+	return Sanitize(p, GetAllIdentityProviders)
+}
+func GetAllIdentityProviders() ([]IdentityProvider, IdentityProvider) { return identityProviders, "" }
+
+const (
+	// IdentityProviderOIDC represents an OpenID Connect identity provider.
+	IdentityProviderOIDC IdentityProvider = "oidc"
+	// IdentityProviderLDAP represents an LDAP directory.
+	IdentityProviderLDAP IdentityProvider = "ldap"
+	// IdentityProviderSCIM represents an identity provider that provisions users with SCIM.
+	IdentityProviderSCIM IdentityProvider = "scim"
+)
+
+var identityProviders = sortEnum([]IdentityProvider{
+	IdentityProviderOIDC,
+	IdentityProviderLDAP,
+	IdentityProviderSCIM,
+})
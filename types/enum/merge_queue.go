@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MergeQueueEntryState defines the state of a merge queue entry.
+type MergeQueueEntryState string
+
+func (MergeQueueEntryState) Enum() []interface{} { return toInterfaceSlice(mergeQueueEntryStates) }
+
+func (s MergeQueueEntryState) Sanitize() (MergeQueueEntryState, bool) {
+	return Sanitize(s, GetAllMergeQueueEntryStates)
+}
+
+func GetAllMergeQueueEntryStates() ([]MergeQueueEntryState, MergeQueueEntryState) {
+	return mergeQueueEntryStates, ""
+}
+
+// MergeQueueEntryState enumeration.
+const (
+	// MergeQueueEntryStateQueued means that the speculative merge of the entry is not yet created.
+	MergeQueueEntryStateQueued MergeQueueEntryState = "queued"
+	// MergeQueueEntryStateChecking means that the speculative merge is created
+	// and the entry is waiting for the required status checks.
+	MergeQueueEntryStateChecking MergeQueueEntryState = "checking"
+)
+
+var mergeQueueEntryStates = sortEnum([]MergeQueueEntryState{
+	MergeQueueEntryStateQueued,
+	MergeQueueEntryStateChecking,
+})
+
+// MergeQueueAction defines the action recorded in a pull request activity of a merge queue.
+type MergeQueueAction string
+
+func (MergeQueueAction) Enum() []interface{} { return toInterfaceSlice(mergeQueueActions) }
+
+// MergeQueueAction enumeration.
+const (
+	MergeQueueActionEnqueued MergeQueueAction = "enqueued"
+	MergeQueueActionDequeued MergeQueueAction = "dequeued"
+	MergeQueueActionEjected  MergeQueueAction = "ejected"
+)
+
+var mergeQueueActions = sortEnum([]MergeQueueAction{
+	MergeQueueActionEnqueued,
+	MergeQueueActionDequeued,
+	MergeQueueActionEjected,
+})
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationType defines the kind of the in-app notification.
+type NotificationType string
+
+func (NotificationType) Enum() []interface{} { return toInterfaceSlice(notificationTypes) }
+func (t NotificationType) Sanitize() (NotificationType, bool) {
+	return Sanitize(t, GetAllNotificationTypes)
+}
+func GetAllNotificationTypes() ([]NotificationType, NotificationType) {
+	return notificationTypes, ""
+}
+
+// NotificationType enumeration.
+const (
+	NotificationTypePullReqMention         NotificationType = "pullreq_mention"
+	NotificationTypePullReqReviewRequested NotificationType = "pullreq_review_requested"
+	NotificationTypePullReqComment         NotificationType = "pullreq_comment"
+	NotificationTypePullReqReviewSubmitted NotificationType = "pullreq_review_submitted"
+)
+
+var notificationTypes = sortEnum([]NotificationType{
+	NotificationTypePullReqMention,
+	NotificationTypePullReqReviewRequested,
+	NotificationTypePullReqComment,
+	NotificationTypePullReqReviewSubmitted,
+})
+
+// IsDirect returns true if the notification is addressed directly to the recipient.
+func (t NotificationType) IsDirect() bool {
+	return t == NotificationTypePullReqMention || t == NotificationTypePullReqReviewRequested
+}
+
+// NotificationLevel defines which in-app notifications a principal receives for a repository or a space.
+type NotificationLevel string
+
+func (NotificationLevel) Enum() []interface{} { return toInterfaceSlice(notificationLevels) }
+func (l NotificationLevel) Sanitize() (NotificationLevel, bool) {
+	return Sanitize(l, GetAllNotificationLevels)
+}
+func GetAllNotificationLevels() ([]NotificationLevel, NotificationLevel) {
+	return notificationLevels, NotificationLevelAll
+}
+
+// NotificationLevel enumeration.
+const (
+	// NotificationLevelAll enables all notifications.
+	NotificationLevelAll NotificationLevel = "all"
+	// NotificationLevelDirect enables only notifications addressed directly to the principal,
+	// like mentions and review requests.
+	NotificationLevelDirect NotificationLevel = "direct"
+	// NotificationLevelNone disables all notifications.
+	NotificationLevelNone NotificationLevel = "none"
+)
+
+var notificationLevels = sortEnum([]NotificationLevel{
+	NotificationLevelAll,
+	NotificationLevelDirect,
+	NotificationLevelNone,
+})
+
+// Allows returns true if the notification of the provided type is enabled by the level.
+func (l NotificationLevel) Allows(t NotificationType) bool {
+	switch l {
+	case NotificationLevelAll:
+		return true
+	case NotificationLevelDirect:
+		return t.IsDirect()
+	case NotificationLevelNone:
+		return false
+	}
+	return true
+}
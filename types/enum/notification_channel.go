@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationChannelType defines the chat platform of a notification channel.
+type NotificationChannelType string
+
+func (NotificationChannelType) Enum() []interface{} {
+	return toInterfaceSlice(notificationChannelTypes)
+}
+func (t NotificationChannelType) Sanitize() (NotificationChannelType, bool) {
+	return Sanitize(t, GetAllNotificationChannelTypes)
+}
+func GetAllNotificationChannelTypes() ([]NotificationChannelType, NotificationChannelType) {
+	return notificationChannelTypes, ""
+}
+
+// NotificationChannelType enumeration.
+const (
+	NotificationChannelTypeSlack      NotificationChannelType = "slack"
+	NotificationChannelTypeMSTeams    NotificationChannelType = "msteams"
+	NotificationChannelTypeMattermost NotificationChannelType = "mattermost"
+)
+
+var notificationChannelTypes = sortEnum([]NotificationChannelType{
+	NotificationChannelTypeSlack,
+	NotificationChannelTypeMSTeams,
+	NotificationChannelTypeMattermost,
+})
+
+// NotificationChannelEvent defines the event that can be posted to a notification channel.
+type NotificationChannelEvent string
+
+func (NotificationChannelEvent) Enum() []interface{} {
+	return toInterfaceSlice(notificationChannelEvents)
+}
+func (e NotificationChannelEvent) Sanitize() (NotificationChannelEvent, bool) {
+	return Sanitize(e, GetAllNotificationChannelEvents)
+}
+func GetAllNotificationChannelEvents() ([]NotificationChannelEvent, NotificationChannelEvent) {
+	return notificationChannelEvents, ""
+}
+
+// NotificationChannelEvent enumeration.
+const (
+	NotificationChannelEventPullReqCreated         NotificationChannelEvent = "pullreq_created"
+	NotificationChannelEventPullReqMerged          NotificationChannelEvent = "pullreq_merged"
+	NotificationChannelEventPullReqReviewRequested NotificationChannelEvent = "pullreq_review_requested"
+	NotificationChannelEventPipelineFailed         NotificationChannelEvent = "pipeline_failed"
+	NotificationChannelEventRuleBypassed           NotificationChannelEvent = "rule_bypassed"
+)
+
+var notificationChannelEvents = sortEnum([]NotificationChannelEvent{
+	NotificationChannelEventPullReqCreated,
+	NotificationChannelEventPullReqMerged,
+	NotificationChannelEventPullReqReviewRequested,
+	NotificationChannelEventPipelineFailed,
+	NotificationChannelEventRuleBypassed,
+})
@@ -17,6 +17,65 @@ package enum
 // Permission represents the different types of permissions a principal can have.
 type Permission string
 
+func (Permission) Enum() []interface{}              { return toInterfaceSlice(permissions) }
+func (p Permission) Sanitize() (Permission, bool)   { return Sanitize(p, GetAllPermissions) }
+func GetAllPermissions() ([]Permission, Permission) { return permissions, "" }
+
+var permissions = sortEnum([]Permission{
+	PermissionSpaceView,
+	PermissionSpaceEdit,
+	PermissionSpaceDelete,
+	PermissionRepoView,
+	PermissionRepoCreate,
+	PermissionRepoEdit,
+	PermissionRepoDelete,
+	PermissionRepoPush,
+	PermissionRepoReview,
+	PermissionRepoReportCommitCheck,
+	PermissionUserView,
+	PermissionUserEdit,
+	PermissionUserDelete,
+	PermissionUserEditAdmin,
+	PermissionServiceAccountView,
+	PermissionServiceAccountEdit,
+	PermissionServiceAccountDelete,
+	PermissionServiceView,
+	PermissionServiceEdit,
+	PermissionServiceDelete,
+	PermissionServiceEditAdmin,
+	PermissionPipelineView,
+	PermissionPipelineEdit,
+	PermissionPipelineDelete,
+	PermissionPipelineExecute,
+	PermissionSecretView,
+	PermissionSecretEdit,
+	PermissionSecretDelete,
+	PermissionSecretAccess,
+	PermissionConnectorView,
+	PermissionConnectorEdit,
+	PermissionConnectorDelete,
+	PermissionConnectorAccess,
+	PermissionTemplateView,
+	PermissionTemplateEdit,
+	PermissionTemplateDelete,
+	PermissionTemplateAccess,
+	PermissionGitspaceView,
+	PermissionGitspaceCreate,
+	PermissionGitspaceEdit,
+	PermissionGitspaceDelete,
+	PermissionGitspaceUse,
+	PermissionInfraProviderView,
+	PermissionInfraProviderEdit,
+	PermissionInfraProviderDelete,
+	PermissionArtifactsDownload,
+	PermissionArtifactsUpload,
+	PermissionArtifactsDelete,
+	PermissionArtifactsQuarantine,
+	PermissionRegistryView,
+	PermissionRegistryEdit,
+	PermissionRegistryDelete,
+})
+
 const (
 	/*
 	   ----- SPACE -----
@@ -93,6 +93,8 @@ const (
 	PullReqActivityTypeTargetBranchChange      PullReqActivityType = "target-branch-change"
 	PullReqActivityTypeMerge                   PullReqActivityType = "merge"
 	PullReqActivityTypeLabelModify             PullReqActivityType = "label-modify"
+	PullReqActivityTypeMergeQueue              PullReqActivityType = "merge-queue"
+	PullReqActivityTypeAutoMerge               PullReqActivityType = "auto-merge"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -111,6 +113,8 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeTargetBranchChange,
 	PullReqActivityTypeMerge,
 	PullReqActivityTypeLabelModify,
+	PullReqActivityTypeMergeQueue,
+	PullReqActivityTypeAutoMerge,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -23,15 +23,17 @@ type RuleType string
 
 // RuleType enumeration.
 const (
-	RuleTypeBranch RuleType = "branch"
-	RuleTypeTag    RuleType = "tag"
-	RuleTypePush   RuleType = "push"
+	RuleTypeBranch   RuleType = "branch"
+	RuleTypeTag      RuleType = "tag"
+	RuleTypePush     RuleType = "push"
+	RuleTypeFilePath RuleType = "file_path"
 )
 
 var ruleTypes = sortEnum([]RuleType{
 	RuleTypeBranch,
 	RuleTypeTag,
 	RuleTypePush,
+	RuleTypeFilePath,
 })
 
 func (RuleType) Enum() []interface{} { return toInterfaceSlice(ruleTypes) }
@@ -51,6 +51,10 @@ const (
 	SSETypePullReqMarkedAsDraft  SSEType = "pullreq_marked_as_draft"
 	SSETypePullReqReadyForReview SSEType = "pullreq_ready_for_review"
 
+	// Merge queue.
+
+	SSETypeMergeQueueUpdated SSEType = "merge_queue_updated"
+
 	// Branches.
 
 	SSETypeBranchMergableUpdated SSEType = "branch_mergable_updated"
@@ -84,4 +88,10 @@ const (
 	SSETypeWebhookCreated SSEType = "webhook_created"
 	SSETypeWebhookUpdated SSEType = "webhook_updated"
 	SSETypeWebhookDeleted SSEType = "webhook_deleted"
+
+	// Notifications.
+
+	SSETypeNotificationCreated SSEType = "notification_created"
+	SSETypeNotificationUpdated SSEType = "notification_updated"
+	SSETypeNotificationAllRead SSEType = "notification_all_read"
 )
@@ -40,6 +40,9 @@ const (
 	TriggerActionPullReqClosed TriggerAction = "pullreq_closed"
 	// TriggerActionPullReqMerged gets triggered when a pull request is merged.
 	TriggerActionPullReqMerged TriggerAction = "pullreq_merged"
+	// TriggerActionPullReqMergeQueued gets triggered when a speculative merge
+	// of a pull request is created by the merge queue.
+	TriggerActionPullReqMergeQueued TriggerAction = "pullreq_merge_queued"
 )
 
 func (TriggerAction) Enum() []interface{}               { return toInterfaceSlice(triggerActions) }
@@ -49,7 +52,8 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 		t == TriggerActionPullReqBranchUpdated ||
 		t == TriggerActionPullReqReopened ||
 		t == TriggerActionPullReqClosed ||
-		t == TriggerActionPullReqMerged {
+		t == TriggerActionPullReqMerged ||
+		t == TriggerActionPullReqMergeQueued {
 		return TriggerEventPullRequest
 	}
 	if t == TriggerActionTagCreated || t == TriggerActionTagUpdated {
@@ -75,6 +79,7 @@ var triggerActions = sortEnum([]TriggerAction{
 	TriggerActionPullReqBranchUpdated,
 	TriggerActionPullReqClosed,
 	TriggerActionPullReqMerged,
+	TriggerActionPullReqMergeQueued,
 })
 
 // Trigger types.
@@ -196,6 +196,29 @@ const (
 	WebhookTriggerPullReqReviewSubmitted = "pullreq_review_submitted"
 	// WebhookTriggerPullReqTargetBranchChanged gets triggered when a pull request target branch is changed.
 	WebhookTriggerPullReqTargetBranchChanged = "pullreq_target_branch_changed"
+	// WebhookTriggerPullReqReviewerAdded gets triggered when a reviewer is added to a pull request.
+	WebhookTriggerPullReqReviewerAdded WebhookTrigger = "pullreq_reviewer_added"
+	// WebhookTriggerPullReqReviewerRemoved gets triggered when a reviewer is removed from a pull request.
+	WebhookTriggerPullReqReviewerRemoved WebhookTrigger = "pullreq_reviewer_removed"
+	// WebhookTriggerPullReqLabelRemoved gets triggered when a label is removed from a pull request.
+	WebhookTriggerPullReqLabelRemoved WebhookTrigger = "pullreq_label_removed"
+
+	// WebhookTriggerRepoCreated gets triggered when a repository gets created.
+	WebhookTriggerRepoCreated WebhookTrigger = "repo_created"
+	// WebhookTriggerRepoDeleted gets triggered when a repository gets deleted.
+	WebhookTriggerRepoDeleted WebhookTrigger = "repo_deleted"
+	// WebhookTriggerRepoRenamed gets triggered when a repository gets renamed or moved.
+	WebhookTriggerRepoRenamed WebhookTrigger = "repo_renamed"
+	// WebhookTriggerRepoVisibilityChanged gets triggered when the public access of a repository changes.
+	WebhookTriggerRepoVisibilityChanged WebhookTrigger = "repo_visibility_changed"
+
+	// WebhookTriggerCheckStatusUpdated gets triggered when a status check gets reported for a commit.
+	WebhookTriggerCheckStatusUpdated WebhookTrigger = "check_status_updated"
+
+	// WebhookTriggerPipelineExecutionStarted gets triggered when a pipeline execution starts running.
+	WebhookTriggerPipelineExecutionStarted WebhookTrigger = "pipeline_execution_started"
+	// WebhookTriggerPipelineExecutionFinished gets triggered when a pipeline execution finishes.
+	WebhookTriggerPipelineExecutionFinished WebhookTrigger = "pipeline_execution_finished"
 
 	// WebhookTriggerArtifactCreated gets triggered when an artifact gets created.
 	WebhookTriggerArtifactCreated WebhookTrigger = "artifact_created"
@@ -222,6 +245,42 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqLabelAssigned,
 	WebhookTriggerPullReqReviewSubmitted,
 	WebhookTriggerPullReqTargetBranchChanged,
+	WebhookTriggerPullReqReviewerAdded,
+	WebhookTriggerPullReqReviewerRemoved,
+	WebhookTriggerPullReqLabelRemoved,
+	WebhookTriggerRepoCreated,
+	WebhookTriggerRepoDeleted,
+	WebhookTriggerRepoRenamed,
+	WebhookTriggerRepoVisibilityChanged,
+	WebhookTriggerCheckStatusUpdated,
+	WebhookTriggerPipelineExecutionStarted,
+	WebhookTriggerPipelineExecutionFinished,
 	WebhookTriggerArtifactCreated,
 	WebhookTriggerArtifactDeleted,
 })
+
+// WebhookPayloadTemplateType defines how the payload template of a webhook transforms the default payload.
+type WebhookPayloadTemplateType string
+
+func (WebhookPayloadTemplateType) Enum() []interface{} {
+	return toInterfaceSlice(webhookPayloadTemplateTypes)
+}
+func (s WebhookPayloadTemplateType) Sanitize() (WebhookPayloadTemplateType, bool) {
+	return Sanitize(s, GetAllWebhookPayloadTemplateTypes)
+}
+func GetAllWebhookPayloadTemplateTypes() ([]WebhookPayloadTemplateType, WebhookPayloadTemplateType) {
+	return webhookPayloadTemplateTypes, "" // No default value
+}
+
+const (
+	// WebhookPayloadTemplateTypeGoTemplate renders the request body using a Go text template.
+	WebhookPayloadTemplateTypeGoTemplate WebhookPayloadTemplateType = "go_template"
+	// WebhookPayloadTemplateTypeJSONPath builds the request body from a JSON document
+	// in which JSONPath expressions are replaced with the values they select from the default payload.
+	WebhookPayloadTemplateTypeJSONPath WebhookPayloadTemplateType = "jsonpath"
+)
+
+var webhookPayloadTemplateTypes = sortEnum([]WebhookPayloadTemplateType{
+	WebhookPayloadTemplateTypeGoTemplate,
+	WebhookPayloadTemplateTypeJSONPath,
+})
@@ -0,0 +1,214 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Issue represents an issue of a repository.
+type Issue struct {
+	ID      int64 `json:"-"` // not returned, it's an internal field
+	Version int64 `json:"-"` // not returned, it's an internal field
+	RepoID  int64 `json:"repo_id"`
+	Number  int64 `json:"number"`
+
+	CreatedBy int64  `json:"-"` // not returned, because the author info is in the Author field
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	Edited    int64  `json:"edited"`
+	Closed    *int64 `json:"closed,omitempty"`
+	ClosedBy  *int64 `json:"-"` // not returned, because the closer info is in the Closer field
+
+	State       enum.IssueState `json:"state"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+
+	MilestoneID *int64 `json:"milestone_id,omitempty"`
+
+	ActivitySeq  int64 `json:"-"` // not returned, because it's a server's internal field
+	CommentCount int   `json:"comment_count"`
+
+	Author    PrincipalInfo               `json:"author"`
+	Closer    *PrincipalInfo              `json:"closer,omitempty"`
+	Assignees []*PrincipalInfo            `json:"assignees,omitempty"`
+	Labels    []*LabelIssueAssignmentInfo `json:"labels,omitempty"`
+}
+
+// IssueFilter stores issue query parameters.
+type IssueFilter struct {
+	Page        int               `json:"page"`
+	Size        int               `json:"size"`
+	Query       string            `json:"query"`
+	States      []enum.IssueState `json:"state"`
+	CreatedBy   []int64           `json:"created_by"`
+	AssigneeID  int64             `json:"assignee_id"`
+	MilestoneID int64             `json:"milestone_id"`
+	LabelID     []int64           `json:"label_id"`
+	Sort        enum.IssueSort    `json:"sort"`
+	Order       enum.Order        `json:"order"`
+}
+
+// Milestone groups issues of a repository towards a common goal.
+type Milestone struct {
+	ID          int64               `json:"id"`
+	RepoID      int64               `json:"repo_id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	State       enum.MilestoneState `json:"state"`
+	DueDate     *int64              `json:"due_date,omitempty"`
+	CreatedBy   int64               `json:"created_by"`
+	Created     int64               `json:"created"`
+	Updated     int64               `json:"updated"`
+
+	OpenIssues   int64 `json:"open_issues"`
+	ClosedIssues int64 `json:"closed_issues"`
+}
+
+// MilestoneFilter stores milestone query parameters.
+type MilestoneFilter struct {
+	ListQueryFilter
+	States []enum.MilestoneState `json:"state"`
+}
+
+// IssueActivity represents an entry in the timeline of an issue: a comment or a system event.
+type IssueActivity struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"-"` // not returned, it's an internal field
+
+	CreatedBy int64  `json:"-"` // not returned, because the author info is in the Author field
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	Edited    int64  `json:"edited"`
+	Deleted   *int64 `json:"deleted,omitempty"`
+
+	RepoID  int64 `json:"repo_id"`
+	IssueID int64 `json:"-"`
+	Order   int64 `json:"order"`
+
+	Type enum.IssueActivityType   `json:"type"`
+	Kind enum.PullReqActivityKind `json:"kind"`
+
+	Text       string          `json:"text"`
+	PayloadRaw json.RawMessage `json:"payload"`
+
+	Author PrincipalInfo `json:"author"`
+}
+
+// SetPayload sets the payload and verifies it's of correct type for the activity.
+func (a *IssueActivity) SetPayload(payload IssueActivityPayload) error {
+	if payload == nil {
+		a.PayloadRaw = nil
+		return nil
+	}
+
+	if payload.ActivityType() != a.Type {
+		return fmt.Errorf("wrong payload type %T for activity %s, payload is for %s",
+			payload, a.Type, payload.ActivityType())
+	}
+
+	var err error
+	if a.PayloadRaw, err = json.Marshal(payload); err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return nil
+}
+
+// IssueActivityFilter stores issue activity query parameters.
+type IssueActivityFilter struct {
+	After  int64 `json:"after"`
+	Before int64 `json:"before"`
+	Limit  int   `json:"limit"`
+
+	Types []enum.IssueActivityType `json:"type"`
+}
+
+// IssueActivityPayload is an interface used to identify issue activity payload types.
+type IssueActivityPayload interface {
+	ActivityType() enum.IssueActivityType
+}
+
+type IssueActivityPayloadStateChange struct {
+	Old enum.IssueState `json:"old"`
+	New enum.IssueState `json:"new"`
+
+	// PullReqNumber and CommitSHA are set if the state was changed by a merged pull request.
+	PullReqNumber *int64 `json:"pullreq_number,omitempty"`
+	CommitSHA     string `json:"commit_sha,omitempty"`
+}
+
+func (a *IssueActivityPayloadStateChange) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeStateChange
+}
+
+type IssueActivityPayloadTitleChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+func (a *IssueActivityPayloadTitleChange) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeTitleChange
+}
+
+type IssueActivityPayloadAssigneeAdd struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+func (a *IssueActivityPayloadAssigneeAdd) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeAssigneeAdd
+}
+
+type IssueActivityPayloadAssigneeDelete struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+func (a *IssueActivityPayloadAssigneeDelete) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeAssigneeDelete
+}
+
+type IssueActivityPayloadLabel struct {
+	PullRequestActivityLabelBase
+	Type enum.PullReqLabelActivityType `json:"type"`
+}
+
+func (a *IssueActivityPayloadLabel) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeLabelModify
+}
+
+type IssueActivityPayloadMilestoneChange struct {
+	Old *string `json:"old,omitempty"`
+	New *string `json:"new,omitempty"`
+}
+
+func (a *IssueActivityPayloadMilestoneChange) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeMilestoneChange
+}
+
+// IssueActivityPayloadReference is written when an issue is mentioned
+// by a merged pull request or by one of its commits.
+type IssueActivityPayloadReference struct {
+	PullReqNumber int64  `json:"pullreq_number"`
+	PullReqTitle  string `json:"pullreq_title"`
+	CommitSHA     string `json:"commit_sha,omitempty"`
+	Closing       bool   `json:"closing"`
+}
+
+func (a *IssueActivityPayloadReference) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeReference
+}
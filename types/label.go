@@ -71,6 +71,17 @@ type PullReqLabel struct {
 	UpdatedBy int64  `json:"updated_by"`
 }
 
+// Used to assign label to issue.
+type IssueLabel struct {
+	IssueID   int64  `json:"issue_id"`
+	LabelID   int64  `json:"label_id"`
+	ValueID   *int64 `json:"value_id,omitempty"`
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	CreatedBy int64  `json:"created_by"`
+	UpdatedBy int64  `json:"updated_by"`
+}
+
 type LabelInfo struct {
 	SpaceID  *int64          `json:"-"`
 	RepoID   *int64          `json:"-"`
@@ -107,6 +118,18 @@ type LabelPullReqAssignmentInfo struct {
 	ValueColor *enum.LabelColor `json:"value_color,omitempty"`
 }
 
+type LabelIssueAssignmentInfo struct {
+	IssueID    int64            `json:"-"`
+	LabelID    int64            `json:"id"`
+	LabelKey   string           `json:"key"`
+	LabelColor enum.LabelColor  `json:"color,omitempty"`
+	LabelScope int64            `json:"scope"`
+	ValueCount int64            `json:"value_count"`
+	ValueID    *int64           `json:"value_id,omitempty"`
+	Value      *string          `json:"value,omitempty"`
+	ValueColor *enum.LabelColor `json:"value_color,omitempty"`
+}
+
 type ScopeData struct {
 	// Scope = 0 is repo, scope >= 1 is a depth level of a space
 	Scope int64           `json:"scope"`
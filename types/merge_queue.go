@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its target branch.
+type MergeQueueEntry struct {
+	ID            int64  `json:"id"`
+	RepoID        int64  `json:"repo_id"`
+	PullReqID     int64  `json:"pullreq_id"`
+	PullReqNumber int64  `json:"pullreq_number"`
+	TargetBranch  string `json:"target_branch"`
+
+	State enum.MergeQueueEntryState `json:"state"`
+
+	MergeMethod        enum.MergeMethod `json:"merge_method"`
+	Title              string           `json:"title"`
+	Message            string           `json:"message"`
+	DeleteSourceBranch bool             `json:"delete_source_branch"`
+
+	// SourceSHA is the commit of the source branch that was queued.
+	SourceSHA string `json:"source_sha"`
+	// BaseSHA is the commit the speculative merge is built on top of:
+	// either the target branch or the speculative merge of the previous entry.
+	BaseSHA string `json:"base_sha,omitempty"`
+	// MergeSHA is the commit of the speculative merge. The required status checks are evaluated against it.
+	MergeSHA string `json:"merge_sha,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	// Position is the position of the entry in the queue, starting with 1. Not stored in the DB.
+	Position int `json:"position"`
+}
+
+// MergeQueue holds all entries of the merge queue of a branch, ordered by position.
+type MergeQueue struct {
+	RepoID       int64              `json:"repo_id"`
+	TargetBranch string             `json:"target_branch"`
+	Entries      []*MergeQueueEntry `json:"entries"`
+}
+
+// MergeQueueBranch identifies a branch with a non-empty merge queue.
+type MergeQueueBranch struct {
+	RepoID       int64  `db:"merge_queue_entry_repo_id"`
+	TargetBranch string `db:"merge_queue_entry_target_branch"`
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Notification is an in-app notification in the inbox of a principal.
+type Notification struct {
+	ID            int64                 `json:"id"`
+	PrincipalID   int64                 `json:"-"`
+	Type          enum.NotificationType `json:"type"`
+	RepoID        int64                 `json:"repo_id"`
+	PullReqID     int64                 `json:"pullreq_id"`
+	PullReqNumber int64                 `json:"pullreq_number"`
+	ActorID       *int64                `json:"-"`
+	Subject       string                `json:"subject"`
+	Text          string                `json:"text"`
+	URL           string                `json:"url"`
+	Read          bool                  `json:"read"`
+	Created       int64                 `json:"created"`
+	Updated       int64                 `json:"updated"`
+
+	// Actor is the principal that caused the notification. It's not stored in the DB.
+	Actor *PrincipalInfo `json:"actor,omitempty"`
+}
+
+// NotificationFilter stores notification query parameters.
+type NotificationFilter struct {
+	Pagination
+	UnreadOnly bool                    `json:"unread_only"`
+	Types      []enum.NotificationType `json:"types"`
+}
+
+// NotificationSubscription holds the in-app notification level of a principal for a repository or a space.
+// The subscription of a repository takes precedence over the subscriptions of its parent spaces
+// and the subscription of a space takes precedence over the subscriptions of its ancestors.
+type NotificationSubscription struct {
+	PrincipalID  int64                  `json:"-"`
+	ResourceID   int64                  `json:"resource_id"`
+	ResourceType enum.ResourceType      `json:"resource_type"`
+	Level        enum.NotificationLevel `json:"level"`
+	Created      int64                  `json:"created"`
+	Updated      int64                  `json:"updated"`
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"slices"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationChannel is a space level chat channel to which the notifications are posted
+// using an incoming webhook URL of the chat platform.
+// The channel gets the events of all repositories in the space and in its subspaces.
+type NotificationChannel struct {
+	ID          int64                           `json:"id"`
+	SpaceID     int64                           `json:"space_id"`
+	Identifier  string                          `json:"identifier"`
+	Description string                          `json:"description"`
+	Type        enum.NotificationChannelType    `json:"type"`
+	URL         string                          `json:"url"`
+	Events      []enum.NotificationChannelEvent `json:"events"`
+	Enabled     bool                            `json:"enabled"`
+	CreatedBy   int64                           `json:"created_by"`
+	Created     int64                           `json:"created"`
+	Updated     int64                           `json:"updated"`
+
+	// Templates holds custom message templates of the channel, the default templates are used for other events.
+	Templates map[enum.NotificationChannelEvent]string `json:"templates,omitempty"`
+}
+
+// HasEvent returns true if the channel is subscribed to the event.
+func (c *NotificationChannel) HasEvent(event enum.NotificationChannelEvent) bool {
+	return slices.Contains(c.Events, event)
+}
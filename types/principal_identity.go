@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PrincipalIdentity links a principal to an account of an external identity provider.
+type PrincipalIdentity struct {
+	ID          int64                 `json:"id"`
+	PrincipalID int64                 `json:"principal_id"`
+	Provider    enum.IdentityProvider `json:"provider"`
+	Subject     string                `json:"subject"`
+	ExternalID  string                `json:"external_id,omitempty"`
+	Email       string                `json:"email"`
+	// Provisioned is true if the user was created for the identity, rather than linked to it.
+	Provisioned bool  `json:"provisioned"`
+	Created     int64 `json:"created"`
+	Updated     int64 `json:"updated"`
+}
+
+// PrincipalIdentityFilter stores the parameters for listing the external identities of users.
+// The string attributes are compared case-insensitively, empty attributes aren't filtered on.
+type PrincipalIdentityFilter struct {
+	Provider    enum.IdentityProvider
+	PrincipalID int64
+	Subject     string
+	ExternalID  string
+	// Email and DisplayName are the attributes of the user, not of the identity.
+	Email       string
+	DisplayName string
+	// Offset is the number of identities to skip, Limit is the maximum number of identities to return.
+	// A zero Limit returns all identities.
+	Offset int
+	Limit  int
+}
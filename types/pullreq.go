@@ -47,6 +47,9 @@ type PullReq struct {
 	TargetRepoID int64  `json:"target_repo_id"`
 	TargetBranch string `json:"target_branch"`
 
+	// ParentID is the ID of the pull request this pull request is stacked on.
+	ParentID *int64 `json:"-"` // not returned, the parent is returned as part of the Stack field
+
 	ActivitySeq int64 `json:"-"` // not returned, because it's a server's internal field
 
 	MergedBy                *int64            `json:"-"` // not returned, because the merger info is in the Merger field
@@ -70,6 +73,7 @@ type PullReq struct {
 	Labels       []*LabelPullReqAssignmentInfo `json:"labels,omitempty"`
 	CheckSummary *CheckCountSummary            `json:"check_summary,omitempty"`
 	Rules        []RuleInfo                    `json:"rules,omitempty"`
+	Stack        []PullReqStackItem            `json:"stack,omitempty"`
 }
 
 func (pr *PullReq) UpdateMergeOutcome(method enum.MergeMethod, conflictFiles []string) {
@@ -171,6 +175,7 @@ type PullReqFilter struct {
 	PullReqMetadataOptions
 
 	// internal use only
+	ParentID        int64
 	SpaceIDs        []int64
 	RepoIDBlacklist []int64
 }
@@ -179,6 +184,7 @@ type PullReqMetadataOptions struct {
 	IncludeGitStats bool `json:"include_git_stats"`
 	IncludeChecks   bool `json:"include_checks"`
 	IncludeRules    bool `json:"include_rules"`
+	IncludeStack    bool `json:"include_stack"`
 }
 
 // PullReqReview holds pull request review.
@@ -284,6 +290,38 @@ type MergeResponse struct {
 	RequiresCodeOwnersApprovalLatest bool `json:"requires_code_owners_approval_latest,omitempty"`
 	RequiresCommentResolution        bool `json:"requires_comment_resolution,omitempty"`
 	RequiresNoChangeRequests         bool `json:"requires_no_change_requests,omitempty"`
+
+	// BlockingParent is the open parent pull request that must be merged first.
+	BlockingParent *PullReqStackItem  `json:"blocking_parent,omitempty"`
+	Stack          []PullReqStackItem `json:"stack,omitempty"`
+}
+
+// PullReqStackItem is a summary of a pull request that is part of a pull request stack.
+type PullReqStackItem struct {
+	ID           int64             `json:"-"`
+	Number       int64             `json:"number"`
+	ParentNumber *int64            `json:"parent_number,omitempty"`
+	Title        string            `json:"title"`
+	State        enum.PullReqState `json:"state"`
+	IsDraft      bool              `json:"is_draft"`
+	SourceBranch string            `json:"source_branch"`
+	TargetBranch string            `json:"target_branch"`
+	Merged       *int64            `json:"merged,omitempty"`
+}
+
+// StackItem returns the pull request stack item summary of the pull request.
+// The parent number isn't populated because the pull request holds only the parent's ID.
+func (pr *PullReq) StackItem() PullReqStackItem {
+	return PullReqStackItem{
+		ID:           pr.ID,
+		Number:       pr.Number,
+		Title:        pr.Title,
+		State:        pr.State,
+		IsDraft:      pr.IsDraft,
+		SourceBranch: pr.SourceBranch,
+		TargetBranch: pr.TargetBranch,
+		Merged:       pr.Merged,
+	}
 }
 
 type MergeViolations struct {
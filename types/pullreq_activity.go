@@ -33,6 +33,10 @@ type PullReqActivity struct {
 	Edited    int64  `json:"edited"`
 	Deleted   *int64 `json:"deleted,omitempty"`
 
+	// Pending is set for comments that are part of a review that hasn't been submitted yet.
+	// Such comments are visible only to their author.
+	Pending bool `json:"pending,omitempty"`
+
 	ParentID  *int64 `json:"parent_id"`
 	RepoID    int64  `json:"repo_id"`
 	PullReqID int64  `json:"pullreq_id"`
@@ -99,7 +103,8 @@ func (a *PullReqActivity) IsReply() bool {
 
 // IsBlocking returns true if the pull request activity (comment/code-comment) is blocking the pull request merge.
 func (a *PullReqActivity) IsBlocking() bool {
-	return a.SubOrder == 0 && a.Resolved == nil && a.Deleted == nil && a.Kind != enum.PullReqActivityKindSystem
+	return a.SubOrder == 0 && a.Resolved == nil && a.Deleted == nil && !a.Pending &&
+		a.Kind != enum.PullReqActivityKindSystem
 }
 
 // SetPayload sets the payload and verifies it's of correct type for the activity.
@@ -167,4 +172,8 @@ type PullReqActivityFilter struct {
 
 	Types []enum.PullReqActivityType `json:"type"`
 	Kinds []enum.PullReqActivityKind `json:"kind"`
+
+	// PendingAuthorID is the principal whose pending review comments are included in the result.
+	// Pending comments of all other principals are always excluded.
+	PendingAuthorID int64 `json:"-"`
 }
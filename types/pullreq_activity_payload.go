@@ -61,6 +61,8 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchRestore{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueue{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMerge{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -102,6 +104,26 @@ func (a *PullRequestActivityPayloadMerge) ActivityType() enum.PullReqActivityTyp
 	return enum.PullReqActivityTypeMerge
 }
 
+type PullRequestActivityPayloadMergeQueue struct {
+	Action       enum.MergeQueueAction `json:"action"`
+	TargetBranch string                `json:"target_branch"`
+	Reason       string                `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueue) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueue
+}
+
+type PullRequestActivityPayloadAutoMerge struct {
+	Action      enum.AutoMergeAction `json:"action"`
+	MergeMethod enum.MergeMethod     `json:"merge_method,omitempty"`
+	Reason      string               `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadAutoMerge) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMerge
+}
+
 type PullRequestActivityPayloadStateChange struct {
 	Old      enum.PullReqState `json:"old"`
 	New      enum.PullReqState `json:"new"`
@@ -0,0 +1,30 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PullReqTemplate is a pull request description template stored in a repository.
+type PullReqTemplate struct {
+	// Name identifies the template, the default template is named "default".
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Default bool   `json:"default"`
+	SHA     string `json:"sha"`
+
+	// Content is only returned when a single template is requested.
+	Content string `json:"content,omitempty"`
+
+	// RequiredItems are the checklist items of the template that have to be checked.
+	RequiredItems []string `json:"required_items,omitempty"`
+}
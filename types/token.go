@@ -32,6 +32,38 @@ type Token struct {
 	// IssuedAt is the unix time at which the token was issued.
 	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
 	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+	// LastUsed is the unix time at which the token was last used to authenticate a request.
+	LastUsed *int64 `db:"token_last_used"          json:"last_used,omitempty"`
+	// Scope optionally restricts what the token can be used for.
+	Scope *TokenScope `db:"-"                        json:"scope,omitempty"`
+}
+
+// TokenScope restricts a token to a subset of the permissions of its principal.
+// Empty lists impose no restriction.
+type TokenScope struct {
+	// Permissions lists the permissions the token can be used for.
+	Permissions []enum.Permission `json:"permissions,omitempty"`
+	// SpaceIDs lists the spaces (including their sub spaces and repos) the token can access.
+	SpaceIDs []int64 `json:"space_ids,omitempty"`
+	// Spaces contains the paths of the spaces, it is only populated for display purposes.
+	Spaces []string `json:"spaces,omitempty"`
+	// RepoIDs lists the repositories the token can access.
+	RepoIDs []int64 `json:"repo_ids,omitempty"`
+	// Repos contains the paths of the repositories, it is only populated for display purposes.
+	Repos []string `json:"repos,omitempty"`
+	// IPRanges lists the CIDR ranges the token can be used from.
+	IPRanges []string `json:"ip_ranges,omitempty"`
+}
+
+// IsEmpty returns true if the scope doesn't impose any restriction.
+func (s *TokenScope) IsEmpty() bool {
+	return s == nil ||
+		len(s.Permissions) == 0 && !s.RestrictsResources() && len(s.IPRanges) == 0
+}
+
+// RestrictsResources returns true if the scope limits the spaces or repositories the token can access.
+func (s *TokenScope) RestrictsResources() bool {
+	return s != nil && (len(s.SpaceIDs) > 0 || len(s.RepoIDs) > 0)
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
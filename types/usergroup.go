@@ -44,3 +44,14 @@ func (u *UserGroup) ToUserGroupInfo() *UserGroupInfo {
 		Scope:       u.Scope,
 	}
 }
+
+// UserGroupMember represents the membership of a principal in a user group.
+type UserGroupMember struct {
+	UserGroupID int64 `json:"-"`
+	PrincipalID int64 `json:"-"`
+	CreatedBy   int64 `json:"-"`
+	Created     int64 `json:"created"`
+
+	Principal PrincipalInfo `json:"principal"`
+	AddedBy   PrincipalInfo `json:"added_by"`
+}
@@ -45,6 +45,20 @@ type Webhook struct {
 	Insecure              bool                         `json:"insecure" yaml:"insecure"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers" yaml:"triggers"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty" yaml:"-"`
+
+	// ConsecutiveFailures is the number of deliveries that failed in a row (after all retries).
+	ConsecutiveFailures int `json:"consecutive_failures" yaml:"-"`
+	// AutoDisabled is true in case the webhook got disabled by the system because it kept failing.
+	AutoDisabled bool `json:"auto_disabled" yaml:"-"`
+
+	ExtraHeaders    []ExtraHeader           `json:"extra_headers,omitempty" yaml:"extra_headers,omitempty"`
+	PayloadTemplate *WebhookPayloadTemplate `json:"payload_template,omitempty" yaml:"payload_template,omitempty"`
+}
+
+// WebhookPayloadTemplate defines a transformation of the default webhook payload into a custom request body.
+type WebhookPayloadTemplate struct {
+	Type     enum.WebhookPayloadTemplateType `json:"type" yaml:"type"`
+	Template string                          `json:"template" yaml:"template"`
 }
 
 // MarshalJSON overrides the default json marshaling for `Webhook` allowing us to inject the `HasSecret` field.
@@ -86,6 +100,19 @@ func (w Webhook) Clone() Webhook {
 		webhook.Triggers = triggers
 	}
 
+	// Deep copy the ExtraHeaders slice
+	if len(w.ExtraHeaders) > 0 {
+		headers := make([]ExtraHeader, len(w.ExtraHeaders))
+		copy(headers, w.ExtraHeaders)
+		webhook.ExtraHeaders = headers
+	}
+
+	// Deep copy the PayloadTemplate pointer if it exists
+	if w.PayloadTemplate != nil {
+		payloadTemplate := *w.PayloadTemplate
+		webhook.PayloadTemplate = &payloadTemplate
+	}
+
 	return webhook
 }
 
@@ -101,6 +128,9 @@ type WebhookCreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	ExtraHeaders    []ExtraHeader           `json:"extra_headers"`
+	PayloadTemplate *WebhookPayloadTemplate `json:"payload_template"`
 }
 
 type WebhookSignatureMetadata struct {
@@ -120,6 +150,23 @@ type WebhookUpdateInput struct {
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	// ExtraHeaders, if provided, replace all extra headers of the webhook.
+	ExtraHeaders []ExtraHeader `json:"extra_headers"`
+	// PayloadTemplate, if provided, replaces the payload template of the webhook.
+	// A template with an empty type removes the payload template.
+	PayloadTemplate *WebhookPayloadTemplate `json:"payload_template"`
+}
+
+// WebhookPreviewInput is the input for rendering the request body of a webhook for a sample event.
+type WebhookPreviewInput struct {
+	Trigger         enum.WebhookTrigger     `json:"trigger"`
+	PayloadTemplate *WebhookPayloadTemplate `json:"payload_template"`
+}
+
+// WebhookPreviewOutput is the request body a webhook would send for a sample event.
+type WebhookPreviewOutput struct {
+	Body string `json:"body"`
 }
 
 // WebhookExecution represents a single execution of a webhook.
@@ -136,6 +183,23 @@ type WebhookExecution struct {
 	Error         string                      `json:"error,omitempty"`
 	Request       WebhookExecutionRequest     `json:"request"`
 	Response      WebhookExecutionResponse    `json:"response"`
+	// Attempt is the delivery attempt of the execution, automatic retries increase it, manual retriggers reset it.
+	Attempt int `json:"attempt"`
+	// NextRetryAt is the time an automatic retry of the execution is scheduled for (if any).
+	NextRetryAt *int64 `json:"next_retry_at,omitempty"`
+}
+
+// WebhookReplayInput is the input for replaying all failed executions of a webhook within a time range.
+type WebhookReplayInput struct {
+	// From and To define the time range (unix milliseconds) of the executions that should be replayed.
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// WebhookReplayOutput is the result of a replay request.
+type WebhookReplayOutput struct {
+	// Count is the number of executions that got scheduled for replay.
+	Count int `json:"count"`
 }
 
 // WebhookExecutionRequest represents the request of a webhook execution.
@@ -194,9 +258,12 @@ type WebhookCore struct {
 	Insecure              bool
 	Triggers              []enum.WebhookTrigger
 	LatestExecutionResult *enum.WebhookExecutionResult
+	ConsecutiveFailures   int
+	AutoDisabled          bool
 	SecretIdentifier      string
 	SecretSpaceID         int64
 	ExtraHeaders          []ExtraHeader
+	PayloadTemplate       *WebhookPayloadTemplate
 }
 
 // WebhookExecutionCore represents a webhook execution DTO object.
@@ -213,6 +280,8 @@ type WebhookExecutionCore struct {
 	Error         string
 	Request       WebhookExecutionRequest
 	Response      WebhookExecutionResponse
+	Attempt       int
+	NextRetryAt   *int64
 }
 
 type ExtraHeader struct {